@@ -0,0 +1,350 @@
+// Package asctest runs a fake App Store Connect API for tests, so code that
+// talks to the API can be exercised without credentials or network access.
+//
+// A Server answers GET requests for apps, builds, beta groups and App Store
+// versions from canned JSON:API fixtures modeled on real responses: lists
+// (with filter[app], limit and cursor pagination), single resources, and an
+// app's versions and beta groups. Other endpoints, and other methods, can be
+// answered with Handle. Point a client at Server.URL and sign its tokens
+// with IssuerID, KeyID and a key from NewPrivateKeyPEM; the server checks
+// that requests carry a bearer token but not the token itself.
+package asctest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"embed"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Credentials to create a client for a Server with. Any key works, as the
+// server doesn't verify token signatures.
+const (
+	IssuerID = "00000000-0000-0000-0000-000000000000"
+	KeyID    = "ASCTEST000"
+)
+
+// IDs of the resources in the fixtures.
+const (
+	// AppID is the app "Example" (com.example.app), which has every fixture
+	// build, beta group and version but one.
+	AppID = "1000000001"
+
+	// SecondAppID is the app "Example Pro" (com.example.pro), with one build
+	// and one version.
+	SecondAppID = "1000000002"
+
+	// BuildID is Example's build 102, processed (VALID).
+	BuildID = "b0000000-0000-0000-0000-000000000002"
+
+	// ProcessingBuildID is Example's newest build, 103, still PROCESSING.
+	ProcessingBuildID = "b0000000-0000-0000-0000-000000000003"
+
+	// BetaGroupID is Example's internal beta group.
+	BetaGroupID = "g0000000-0000-0000-0000-000000000001"
+
+	// PublicBetaGroupID is Example's external beta group with a public link.
+	PublicBetaGroupID = "g0000000-0000-0000-0000-000000000002"
+
+	// VersionID is Example's version 2.1, in PREPARE_FOR_SUBMISSION.
+	VersionID = "v0000000-0000-0000-0000-000000000002"
+
+	// LiveVersionID is Example's version 2.0, READY_FOR_SALE.
+	LiveVersionID = "v0000000-0000-0000-0000-000000000001"
+)
+
+// defaultPageSize is the page size of lists requested without a limit, as
+// in the real API.
+const defaultPageSize = 50
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Request is a request a Server received.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a fake App Store Connect API. Its methods are safe to call
+// while it serves requests.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	resources map[string][]resource
+	handlers  map[string]http.Handler
+	requests  []Request
+}
+
+// resource is a fixture resource and the app it belongs to, if any.
+type resource struct {
+	id    string
+	appID string
+	raw   json.RawMessage
+}
+
+// NewServer starts a Server with the canned fixtures. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		resources: make(map[string][]resource),
+		handlers:  make(map[string]http.Handler),
+	}
+
+	entries, err := fixtures.ReadDir("fixtures")
+	if err != nil {
+		panic(fmt.Sprintf("asctest: reading fixtures: %v", err))
+	}
+	for _, entry := range entries {
+		data, err := fixtures.ReadFile("fixtures/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("asctest: reading fixture %s: %v", entry.Name(), err))
+		}
+		var doc struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			panic(fmt.Sprintf("asctest: parsing fixture %s: %v", entry.Name(), err))
+		}
+		for _, raw := range doc.Data {
+			if err := s.Add(raw); err != nil {
+				panic(fmt.Sprintf("asctest: fixture %s: %v", entry.Name(), err))
+			}
+		}
+	}
+
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Add adds a JSON:API resource object, such as
+// {"type": "builds", "id": "b1", "attributes": {...}, "relationships": {"app": {...}}},
+// replacing the resource of the same type and ID if there is one. A
+// resource whose app relationship names an app is listed among that app's.
+func (s *Server) Add(raw json.RawMessage) error {
+	var object struct {
+		Type          string `json:"type"`
+		ID            string `json:"id"`
+		Relationships struct {
+			App struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"app"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return fmt.Errorf("invalid resource: %w", err)
+	}
+	if object.Type == "" || object.ID == "" {
+		return fmt.Errorf("resource has no type or id")
+	}
+
+	r := resource{id: object.ID, appID: object.Relationships.App.Data.ID, raw: append(json.RawMessage(nil), raw...)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, existing := range s.resources[object.Type] {
+		if existing.id == object.ID {
+			s.resources[object.Type][i] = r
+			return nil
+		}
+	}
+	s.resources[object.Type] = append(s.resources[object.Type], r)
+	return nil
+}
+
+// Handle answers requests with the method and path, such as
+// "PATCH" and "/v1/builds/b1", with handler instead of the fixtures.
+// Handlers are matched before the bearer token is checked.
+func (s *Server) Handle(method, path string, handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ServeHTTP records the request and answers it from a handler or the fixtures.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+	handler := s.handlers[r.Method+" "+r.URL.Path]
+	s.mu.Unlock()
+
+	if handler != nil {
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "NOT_AUTHORIZED", "Authentication credentials are missing or invalid.",
+			"Provide a properly configured and signed bearer token, and make sure that it has not expired.")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "The request method is not valid for the resource path.",
+			fmt.Sprintf("The request method '%s' is not allowed; asctest only serves GET requests from fixtures.", r.Method))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	switch {
+	case len(parts) == 1 && s.known(parts[0]):
+		s.writeList(w, r, parts[0], r.URL.Query().Get("filter[app]"))
+	case len(parts) == 2 && s.known(parts[0]):
+		s.writeResource(w, r, parts[0], parts[1])
+	case len(parts) == 3 && parts[0] == "apps" && s.known(parts[2]):
+		if _, ok := s.find("apps", parts[1]); !ok {
+			writeNotFound(w, "apps", parts[1])
+			return
+		}
+		s.writeList(w, r, parts[2], parts[1])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The specified resource does not exist",
+			fmt.Sprintf("The path provided does not match a defined resource type, or asctest has no fixtures for %s.", r.URL.Path))
+	}
+}
+
+// known reports whether there are fixtures of a resource type.
+func (s *Server) known(resourceType string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.resources[resourceType]
+	return ok
+}
+
+// find returns the resource of a type and ID.
+func (s *Server) find(resourceType, id string) (resource, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.resources[resourceType] {
+		if r.id == id {
+			return r, true
+		}
+	}
+	return resource{}, false
+}
+
+// writeResource writes a single resource document.
+func (s *Server) writeResource(w http.ResponseWriter, r *http.Request, resourceType, id string) {
+	found, ok := s.find(resourceType, id)
+	if !ok {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":  found.raw,
+		"links": map[string]string{"self": s.URL + r.URL.Path},
+	})
+}
+
+// writeList writes a page of the resources of a type, only those of the
+// comma-separated apps in appIDs if it isn't empty. The cursor is the offset
+// of the page.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, resourceType, appIDs string) {
+	query := r.URL.Query()
+	limit := defaultPageSize
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value",
+				fmt.Sprintf("'%s' is not a valid value for 'limit'; it must be between 1 and 200.", v))
+			return
+		}
+		limit = n
+	}
+	offset, _ := strconv.Atoi(query.Get("cursor"))
+
+	var apps map[string]bool
+	if appIDs != "" {
+		apps = make(map[string]bool)
+		for _, id := range strings.Split(appIDs, ",") {
+			apps[id] = true
+		}
+	}
+	s.mu.Lock()
+	var matched []json.RawMessage
+	for _, res := range s.resources[resourceType] {
+		if apps == nil || apps[res.appID] {
+			matched = append(matched, res.raw)
+		}
+	}
+	s.mu.Unlock()
+
+	page := []json.RawMessage{}
+	if offset < len(matched) {
+		page = matched[offset:min(offset+limit, len(matched))]
+	}
+	links := map[string]string{"self": s.URL + r.URL.RequestURI()}
+	if offset+limit < len(matched) {
+		next := r.URL.Query()
+		next.Set("cursor", strconv.Itoa(offset+limit))
+		next.Set("limit", strconv.Itoa(limit))
+		links["next"] = s.URL + r.URL.Path + "?" + next.Encode()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":  page,
+		"links": links,
+		"meta":  map[string]any{"paging": map[string]int{"total": len(matched), "limit": limit}},
+	})
+}
+
+// writeNotFound writes the error for a missing resource.
+func writeNotFound(w http.ResponseWriter, resourceType, id string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "The specified resource does not exist",
+		fmt.Sprintf("There is no resource of type '%s' with id '%s'", resourceType, id))
+}
+
+// writeError writes a JSON:API error document.
+func writeError(w http.ResponseWriter, status int, code, title, detail string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]string{{
+			"id":     fmt.Sprintf("asctest-%d", status),
+			"status": strconv.Itoa(status),
+			"code":   code,
+			"title":  title,
+			"detail": detail,
+		}},
+	})
+}
+
+// writeJSON writes v as the JSON body of a response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// NewPrivateKeyPEM returns a new P-256 private key in PEM-encoded PKCS #8,
+// the format of App Store Connect API keys (.p8 files).
+func NewPrivateKeyPEM() []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("asctest: generating key: %v", err))
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(fmt.Sprintf("asctest: encoding key: %v", err))
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
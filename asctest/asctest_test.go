@@ -0,0 +1,150 @@
+package asctest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// newClient returns an API client for server. It decodes strictly, so the
+// fixtures are checked against the client's types.
+func newClient(t *testing.T, server *Server) *api.Client {
+	t.Helper()
+
+	tokens, err := api.NewTokenProviderFromKey(IssuerID, KeyID, NewPrivateKeyPEM())
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+	return api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL), api.WithStrictDecoding())
+}
+
+func TestServer_Fixtures(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	apps, err := client.ListApps(ctx, api.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListApps failed: %v", err)
+	}
+	if len(apps.Data) != 2 || apps.Data[0].ID != AppID || apps.Data[0].Attributes.BundleID != "com.example.app" {
+		t.Errorf("apps = %+v", apps.Data)
+	}
+
+	builds, err := client.ListBuilds(ctx, AppID, api.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListBuilds failed: %v", err)
+	}
+	if len(builds.Data) != 2 || builds.Data[0].ID != ProcessingBuildID || builds.Data[1].ID != BuildID {
+		t.Errorf("builds = %+v", builds.Data)
+	}
+
+	build, err := client.GetBuild(ctx, BuildID)
+	if err != nil {
+		t.Fatalf("GetBuild failed: %v", err)
+	}
+	if build.Data.Attributes.Version != "102" || build.Data.Attributes.ProcessingState != "VALID" {
+		t.Errorf("build = %+v", build.Data.Attributes)
+	}
+
+	groups, err := client.ListBetaGroups(ctx, AppID, api.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListBetaGroups failed: %v", err)
+	}
+	if len(groups.Data) != 2 || groups.Data[1].ID != PublicBetaGroupID {
+		t.Errorf("beta groups = %+v", groups.Data)
+	}
+
+	versions, err := client.GetAppVersions(ctx, SecondAppID, api.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAppVersions failed: %v", err)
+	}
+	if len(versions.Data) != 1 || versions.Data[0].Attributes.VersionString != "1.4" {
+		t.Errorf("versions = %+v", versions.Data)
+	}
+
+	_, err = client.GetApp(ctx, "missing")
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetApp(missing) error = %v, want a 404", err)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newClient(t, server)
+
+	var ids []string
+	for page, err := range api.Pages(context.Background(), 0,
+		func(ctx context.Context) (*api.BuildsResponse, error) {
+			return client.ListBuilds(ctx, "", api.ListOptions{Limit: 2})
+		},
+		func(resp *api.BuildsResponse) api.PagedDocumentLinks { return resp.Links },
+	) {
+		if err != nil {
+			t.Fatalf("Pages failed: %v", err)
+		}
+		for _, build := range page.Data {
+			ids = append(ids, build.ID)
+		}
+	}
+	if len(ids) != 3 {
+		t.Errorf("builds = %v, want all 3", ids)
+	}
+	if n := len(server.Requests()); n != 2 {
+		t.Errorf("requests = %d, want 2 pages", n)
+	}
+}
+
+func TestServer_HandleAndAdd(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	server.Handle(http.MethodDelete, "/v1/betaGroups/"+BetaGroupID, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	if err := client.DeleteBetaGroup(ctx, BetaGroupID); err != nil {
+		t.Fatalf("DeleteBetaGroup failed: %v", err)
+	}
+	requests := server.Requests()
+	if last := requests[len(requests)-1]; last.Method != http.MethodDelete || last.Path != "/v1/betaGroups/"+BetaGroupID {
+		t.Errorf("last request = %+v", last)
+	}
+
+	if err := client.DeleteBetaGroup(ctx, PublicBetaGroupID); err == nil {
+		t.Error("expected an unhandled DELETE to fail")
+	}
+
+	if err := server.Add(json.RawMessage(`{"type": "apps", "id": "` + AppID + `", "attributes": {"name": "Renamed"}}`)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	app, err := client.GetApp(ctx, AppID)
+	if err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if app.Data.Attributes.Name != "Renamed" {
+		t.Errorf("name = %q, want Renamed", app.Data.Attributes.Name)
+	}
+}
+
+func TestServer_RequiresBearerToken(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/apps")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
@@ -26,32 +26,41 @@ const (
 )
 
 // TokenProvider manages JWT tokens for App Store Connect API authentication.
+// It is safe for concurrent use, including while its key is rotated.
 type TokenProvider struct {
 	issuerID   string
 	keyID      string
 	privateKey *ecdsa.PrivateKey
 
-	mu        sync.RWMutex
-	token     string
-	expiresAt time.Time
+	mu            sync.RWMutex
+	token         string
+	expiresAt     time.Time
+	refreshBuffer time.Duration
 }
 
-// NewTokenProvider creates a new token provider.
+// NewTokenProvider creates a new token provider from a .p8 private key file.
 func NewTokenProvider(issuerID, keyID, privateKeyPath string) (*TokenProvider, error) {
 	keyData, err := os.ReadFile(privateKeyPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read private key: %w", err)
 	}
 
+	return NewTokenProviderFromKey(issuerID, keyID, keyData)
+}
+
+// NewTokenProviderFromKey creates a new token provider from the PEM contents
+// of a .p8 private key, for deployments that inject the key without a file.
+func NewTokenProviderFromKey(issuerID, keyID string, keyData []byte) (*TokenProvider, error) {
 	privateKey, err := parsePrivateKey(keyData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
 	return &TokenProvider{
-		issuerID:   issuerID,
-		keyID:      keyID,
-		privateKey: privateKey,
+		issuerID:      issuerID,
+		keyID:         keyID,
+		privateKey:    privateKey,
+		refreshBuffer: TokenRefreshBuffer,
 	}, nil
 }
 
@@ -75,10 +84,21 @@ func parsePrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
 	return ecKey, nil
 }
 
+// SetRefreshBuffer sets how long before its expiry the signed token is
+// replaced with a new one, TokenRefreshBuffer unless set otherwise. It must
+// be shorter than TokenDuration.
+func (tp *TokenProvider) SetRefreshBuffer(buffer time.Duration) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.refreshBuffer = buffer
+}
+
 // GetToken returns a valid JWT token, generating a new one if necessary.
+// The signed token is reused until it is within the refresh buffer of its
+// expiry.
 func (tp *TokenProvider) GetToken() (string, error) {
 	tp.mu.RLock()
-	if tp.token != "" && time.Now().Add(TokenRefreshBuffer).Before(tp.expiresAt) {
+	if tp.valid(time.Now()) {
 		token := tp.token
 		tp.mu.RUnlock()
 		return token, nil
@@ -89,7 +109,7 @@ func (tp *TokenProvider) GetToken() (string, error) {
 	defer tp.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if tp.token != "" && time.Now().Add(TokenRefreshBuffer).Before(tp.expiresAt) {
+	if tp.valid(time.Now()) {
 		return tp.token, nil
 	}
 
@@ -104,6 +124,56 @@ func (tp *TokenProvider) GetToken() (string, error) {
 	return token, nil
 }
 
+// valid reports whether the cached token can still be used at now. The
+// caller holds tp.mu.
+func (tp *TokenProvider) valid(now time.Time) bool {
+	return tp.token != "" && now.Add(tp.refreshBuffer).Before(tp.expiresAt)
+}
+
+// invalidate drops the cached token if it is still token, so the next
+// request signs a new one. A token already replaced by another request is
+// kept.
+func (tp *TokenProvider) invalidate(token string) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if tp.token == token {
+		tp.token = ""
+		tp.expiresAt = time.Time{}
+	}
+}
+
+// identity returns the issuer and key IDs tokens are signed for.
+func (tp *TokenProvider) identity() (issuerID, keyID string) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	return tp.issuerID, tp.keyID
+}
+
+// replace switches tp to other's issuer and key, dropping the token signed
+// with the old key. Requests already holding a token keep using it.
+func (tp *TokenProvider) replace(other *TokenProvider) {
+	issuerID, keyID := other.identity()
+	other.mu.RLock()
+	privateKey := other.privateKey
+	other.mu.RUnlock()
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.issuerID = issuerID
+	tp.keyID = keyID
+	tp.privateKey = privateKey
+	tp.token = ""
+	tp.expiresAt = time.Time{}
+}
+
+// ExpiresAt returns when the current token expires, or the zero time if no
+// token has been generated yet.
+func (tp *TokenProvider) ExpiresAt() time.Time {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	return tp.expiresAt
+}
+
 // generateToken creates a new JWT token using ES256.
 func (tp *TokenProvider) generateToken() (string, time.Time, error) {
 	now := time.Now()
@@ -113,6 +113,23 @@ func TestNewTokenProvider(t *testing.T) {
 	}
 }
 
+func TestNewTokenProviderFromKey(t *testing.T) {
+	keyPEM, _ := generateTestKey(t)
+
+	tp, err := NewTokenProviderFromKey("test-issuer-id", "TESTKEY123", keyPEM)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tp.GetToken(); err != nil {
+		t.Errorf("GetToken failed: %v", err)
+	}
+
+	_, err = NewTokenProviderFromKey("test-issuer-id", "TESTKEY123", []byte("not a key"))
+	if err == nil || !strings.Contains(err.Error(), "failed to parse private key") {
+		t.Errorf("error = %v, want parse failure", err)
+	}
+}
+
 func TestParsePrivateKey(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -212,6 +229,38 @@ func TestTokenProvider_GetToken(t *testing.T) {
 	}
 }
 
+func TestTokenProvider_RefreshBuffer(t *testing.T) {
+	keyPEM, _ := generateTestKey(t)
+	tp, err := NewTokenProviderFromKey("test-issuer", "TESTKEY123", keyPEM)
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+
+	// A buffer as long as the token's lifetime replaces it on every call.
+	tp.SetRefreshBuffer(TokenDuration)
+	token1, _ := tp.GetToken()
+	token2, _ := tp.GetToken()
+	if token1 == token2 {
+		t.Error("expected a new token within the refresh buffer")
+	}
+
+	tp.SetRefreshBuffer(TokenRefreshBuffer)
+	token3, _ := tp.GetToken()
+	if cached, _ := tp.GetToken(); cached != token3 {
+		t.Error("expected same token from cache")
+	}
+
+	// Invalidating a token other than the cached one keeps it.
+	tp.invalidate(token1)
+	if cached, _ := tp.GetToken(); cached != token3 {
+		t.Error("invalidating a stale token dropped the cached one")
+	}
+	tp.invalidate(token3)
+	if fresh, _ := tp.GetToken(); fresh == token3 {
+		t.Error("expected a new token after invalidating the cached one")
+	}
+}
+
 func TestTokenProvider_GenerateToken(t *testing.T) {
 	keyPEM, _ := generateTestKey(t)
 	keyPath := createTestKeyFile(t, keyPEM)
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTLs are how long GET responses for slowly changing resources are
+// reused, keyed by path pattern. "*" matches one path segment.
+var cacheTTLs = map[string]time.Duration{
+	"/v1/apps":                        5 * time.Minute,
+	"/v1/territories":                 24 * time.Hour,
+	"/v1/appCategories":               24 * time.Hour,
+	"/v1/betaGroups":                  5 * time.Minute,
+	"/v1/apps/*/appPricePoints":       time.Hour,
+	"/v1/subscriptions/*/pricePoints": time.Hour,
+}
+
+// cacheTTL returns how long a GET response for path is cached, or 0 if it
+// isn't cacheable.
+func cacheTTL(path string) time.Duration {
+	segments := strings.Split(path, "/")
+	for pattern, ttl := range cacheTTLs {
+		if matchPath(strings.Split(pattern, "/"), segments) {
+			return ttl
+		}
+	}
+	return 0
+}
+
+// matchPath reports whether path segments match pattern segments.
+func matchPath(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// responseCache holds response bodies of cacheable GET requests.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+
+	// dir, if set, keeps a copy of each entry on disk, so entries outlive
+	// the process.
+	dir string
+}
+
+// cacheEntry is a cached response body, the validators to revalidate it
+// with, and when it stops being used without revalidation.
+type cacheEntry struct {
+	Key          string    `json:"key"`
+	Path         string    `json:"path"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Expires      time.Time `json:"expires"`
+}
+
+// revalidatable reports whether the entry can be revalidated with a
+// conditional request once it expires.
+func (e cacheEntry) revalidatable() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// conditionalHeader returns the headers that ask the API to answer 304 Not
+// Modified if the entry is still current.
+func (e cacheEntry) conditionalHeader() http.Header {
+	header := http.Header{}
+	if e.ETag != "" {
+		header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("If-Modified-Since", e.LastModified)
+	}
+	return header
+}
+
+// cacheDependents lists cached collections whose responses include data
+// changed through another resource type, keyed by that type. An app's name,
+// for example, is its primary app info localization's name.
+var cacheDependents = map[string][]string{
+	"appInfoLocalizations": {"apps"},
+	"appInfos":             {"apps"},
+}
+
+// WithResponseCache makes the client reuse GET responses for apps, beta
+// groups, territories, app categories, and price points until their TTL
+// passes.
+// After that, a response with an ETag or Last-Modified header is revalidated
+// with a conditional request, and reused again if the API answers 304 Not
+// Modified. A successful mutating request drops the responses it may have
+// made stale.
+func WithResponseCache() ClientOption {
+	return func(c *Client) {
+		c.cache = &responseCache{
+			entries: make(map[string]cacheEntry),
+			now:     time.Now,
+		}
+	}
+}
+
+// WithResponseCacheDir is WithResponseCache with the cached responses also
+// kept as files in dir, so a restarted server can revalidate them instead of
+// fetching them again. Entries already in dir are loaded. If dir can't be
+// created, responses are only cached in memory.
+func WithResponseCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		WithResponseCache()(c)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return
+		}
+		c.cache.dir = dir
+		c.cache.load()
+	}
+}
+
+// cacheKey identifies a request by the key that signs it and its full URL.
+func cacheKey(team Team, path string, query url.Values) string {
+	key := team.credentialKey() + " " + team.BaseURL + path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
+
+// lookup returns the entry cached under key and whether it is still fresh.
+// Expired entries are only returned if they can be revalidated.
+func (rc *responseCache) lookup(key string) (entry cacheEntry, fresh, ok bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok = rc.entries[key]
+	if !ok {
+		return cacheEntry{}, false, false
+	}
+	if rc.now().Before(entry.Expires) {
+		return entry, true, true
+	}
+	if !entry.revalidatable() {
+		rc.remove(key)
+		return cacheEntry{}, false, false
+	}
+	return entry, false, true
+}
+
+// put caches the body of a GET request for path for ttl, with the
+// validators in header.
+func (rc *responseCache) put(key, path string, body []byte, header http.Header, ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry := cacheEntry{
+		Key:          key,
+		Path:         path,
+		Body:         body,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Expires:      rc.now().Add(ttl),
+	}
+	rc.entries[key] = entry
+	rc.write(entry)
+}
+
+// revalidated keeps using an entry the API answered 304 Not Modified for,
+// for another ttl. Validators sent with the 304 replace the entry's.
+func (rc *responseCache) revalidated(entry cacheEntry, header http.Header, ttl time.Duration) {
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		entry.LastModified = lastModified
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry.Expires = rc.now().Add(ttl)
+	rc.entries[entry.Key] = entry
+	rc.write(entry)
+}
+
+// invalidate drops the cached bodies a successful mutation of path may have
+// made stale: those of the resource and anything under it, the collections
+// of the resource types the path names, and their cacheDependents.
+func (rc *responseCache) invalidate(path string) {
+	stale := staleCachePaths(path)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, entry := range rc.entries {
+		if entry.Path == path || strings.HasPrefix(entry.Path, path+"/") || stale[entry.Path] {
+			rc.remove(key)
+		}
+	}
+}
+
+// remove drops the entry cached under key. The caller holds rc.mu.
+func (rc *responseCache) remove(key string) {
+	delete(rc.entries, key)
+	if rc.dir != "" {
+		os.Remove(rc.file(key))
+	}
+}
+
+// file returns the file an entry is kept in on disk.
+func (rc *responseCache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// write keeps a copy of entry on disk, if the cache has a directory. The
+// cache works from memory, so a failed write only costs a request after a
+// restart. The caller holds rc.mu.
+func (rc *responseCache) write(entry cacheEntry) {
+	if rc.dir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(rc.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), rc.file(entry.Key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// load reads the entries kept in the cache's directory, removing those that
+// are unreadable or expired without validators.
+func (rc *responseCache) load() {
+	files, err := filepath.Glob(filepath.Join(rc.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.now()
+	for _, file := range files {
+		var entry cacheEntry
+		data, err := os.ReadFile(file)
+		if err == nil {
+			err = json.Unmarshal(data, &entry)
+		}
+		if err != nil || entry.Key == "" || file != rc.file(entry.Key) || (!now.Before(entry.Expires) && !entry.revalidatable()) {
+			os.Remove(file)
+			continue
+		}
+		rc.entries[entry.Key] = entry
+	}
+}
+
+// staleCachePaths returns the collection paths a mutation of path may make
+// stale. For "/v1/betaGroups/1/relationships/betaTesters" they are
+// "/v1/betaGroups", "/v1/betaTesters" and the related resources at
+// "/v1/betaGroups/1/betaTesters".
+func staleCachePaths(path string) map[string]bool {
+	segments := strings.Split(path, "/")
+	if len(segments) < 3 {
+		return nil
+	}
+	version := segments[1]
+
+	var types []string
+	for i := 2; i < len(segments); i += 2 {
+		if segments[i] == "relationships" {
+			i++
+			if i >= len(segments) {
+				break
+			}
+		}
+		types = append(types, segments[i])
+	}
+
+	stale := make(map[string]bool)
+	if resource, relationship, ok := strings.Cut(path, "/relationships/"); ok {
+		stale[resource+"/"+relationship] = true
+	}
+	for _, resourceType := range types {
+		stale["/"+version+"/"+resourceType] = true
+		for _, dependent := range cacheDependents[resourceType] {
+			stale["/"+version+"/"+dependent] = true
+		}
+	}
+	return stale
+}
+
+// refreshKey is the context key for bypassing the response cache.
+type refreshKey struct{}
+
+// WithRefresh returns a context whose GET requests skip cached responses. The
+// fresh responses are cached for later requests.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
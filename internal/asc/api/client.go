@@ -5,11 +5,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,86 +22,748 @@ const (
 	// BaseURL is the App Store Connect API base URL.
 	BaseURL = "https://api.appstoreconnect.apple.com"
 
-	// DefaultTimeout is the default HTTP request timeout.
+	// DefaultTimeout is the default time limit for one attempt of a request
+	// that isn't a list, report or download. See DefaultRequestTimeouts.
 	DefaultTimeout = 30 * time.Second
+
+	// DefaultUserAgent is the User-Agent of requests from a client without
+	// WithUserAgent.
+	DefaultUserAgent = "asc-mcp"
 )
 
+// DefaultTeam is the name of a client's initial credentials until teams are added.
+const DefaultTeam = "default"
+
 // Client is an HTTP client for the App Store Connect API.
+// Besides its initial credentials it can hold several named teams, each with
+// its own API key; requests use the team on their context or the selected team.
 type Client struct {
 	httpClient    *http.Client
 	tokenProvider *TokenProvider
 	baseURL       string
+	cache         *responseCache
+
+	// userAgent is the User-Agent header of every request.
+	userAgent string
+
+	// rateLimitWait is how long a request refused with a 429 may wait in
+	// total for retries.
+	rateLimitWait time.Duration
+
+	// retryPolicy decides which failed requests are sent again.
+	retryPolicy RetryPolicy
+
+	// timeouts limit each attempt of a request, by category.
+	timeouts map[RequestCategory]time.Duration
+
+	// pageConcurrency is how many pages ListAll methods fetch at once.
+	pageConcurrency int
+
+	// strictDecoding rejects responses with members the types don't
+	// describe.
+	strictDecoding bool
+
+	// middlewares wrap httpClient's transport, outermost first.
+	middlewares []Middleware
+
+	// flights coalesces identical concurrent GET requests.
+	flights flightGroup
+
+	rateLimitsMu sync.Mutex
+	rateLimits   map[string]RateLimitStatus
+
+	teamsMu    sync.RWMutex
+	teams      map[string]Team
+	activeTeam string
+}
+
+// Team holds the credentials and endpoint for one App Store Connect team.
+type Team struct {
+	TokenProvider *TokenProvider
+
+	// BaseURL overrides the client's base URL for this team when set.
+	BaseURL string
+}
+
+// credentialKey identifies the API key a team signs requests with.
+func (t Team) credentialKey() string {
+	issuerID, keyID := t.TokenProvider.identity()
+	return issuerID + "/" + keyID
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithBaseURL sends requests to baseURL instead of the production API, for
+// example to target a local mock in tests or a gateway in front of the API.
+// A path in baseURL prefixes every request's path. An empty URL keeps the
+// default, BaseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the client's requests,
+// including downloads, so that proxies and Apple can tell its traffic apart.
+// An empty value keeps the default, DefaultUserAgent.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// UserAgent returns the User-Agent of asc-mcp at version, as in
+// "asc-mcp/1.2.0", followed by the caller's tag, if any, as in
+// "asc-mcp/1.2.0 release-bot/3".
+func UserAgent(version, tag string) string {
+	userAgent := DefaultUserAgent + "/" + version
+	if tag != "" {
+		userAgent += " " + tag
+	}
+	return userAgent
 }
 
 // NewClient creates a new App Store Connect API client.
-func NewClient(issuerID, keyID, privateKeyPath string) (*Client, error) {
+func NewClient(issuerID, keyID, privateKeyPath string, opts ...ClientOption) (*Client, error) {
 	tokenProvider, err := NewTokenProvider(issuerID, keyID, privateKeyPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token provider: %w", err)
 	}
 
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+	return NewClientWithTokenProvider(tokenProvider, opts...), nil
+}
+
+// NewClientWithKey creates a new App Store Connect API client from the PEM
+// contents of a .p8 private key.
+func NewClientWithKey(issuerID, keyID string, keyData []byte, opts ...ClientOption) (*Client, error) {
+	tokenProvider, err := NewTokenProviderFromKey(issuerID, keyID, keyData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token provider: %w", err)
+	}
+
+	return NewClientWithTokenProvider(tokenProvider, opts...), nil
+}
+
+// NewClientWithTokenProvider creates a client that authenticates with an
+// existing token provider, using the default base URL and request timeouts.
+func NewClientWithTokenProvider(tokenProvider *TokenProvider, opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient:    &http.Client{Transport: newTransport()},
+		timeouts:      maps.Clone(DefaultRequestTimeouts),
 		tokenProvider: tokenProvider,
 		baseURL:       BaseURL,
+		userAgent:     DefaultUserAgent,
+		rateLimitWait: DefaultRateLimitWait,
+		retryPolicy:   DefaultRetryPolicy,
+
+		pageConcurrency: DefaultPageConcurrency,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applyMiddlewares()
+	return c
+}
+
+// AddTeam registers a team under name. Once any team is added, requests must
+// resolve to one of the added teams.
+func (c *Client) AddTeam(name string, team Team) {
+	c.teamsMu.Lock()
+	defer c.teamsMu.Unlock()
+
+	if c.teams == nil {
+		c.teams = make(map[string]Team)
+	}
+	team.BaseURL = strings.TrimSuffix(team.BaseURL, "/")
+	c.teams[name] = team
+	if c.activeTeam == "" {
+		c.activeTeam = name
+	}
+}
+
+// Teams returns the names of the teams the client can act as, sorted.
+func (c *Client) Teams() []string {
+	c.teamsMu.RLock()
+	defer c.teamsMu.RUnlock()
+
+	if len(c.teams) == 0 {
+		return []string{DefaultTeam}
+	}
+	names := make([]string, 0, len(c.teams))
+	for name := range c.teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SelectTeam makes name the team used by requests that don't name one with WithTeam.
+func (c *Client) SelectTeam(name string) error {
+	c.teamsMu.Lock()
+	defer c.teamsMu.Unlock()
+
+	if _, ok := c.teams[name]; !ok {
+		return fmt.Errorf("unknown team: %s", name)
+	}
+	c.activeTeam = name
+	return nil
+}
+
+// ActiveTeam returns the team used by requests that don't name one with WithTeam.
+func (c *Client) ActiveTeam() string {
+	c.teamsMu.RLock()
+	defer c.teamsMu.RUnlock()
+
+	if c.activeTeam == "" {
+		return DefaultTeam
+	}
+	return c.activeTeam
+}
+
+// HasTeam reports whether the client can act as the named team.
+func (c *Client) HasTeam(name string) bool {
+	for _, team := range c.Teams() {
+		if team == name {
+			return true
+		}
+	}
+	return false
+}
+
+// teamKey is the context key for a team name.
+type teamKey struct{}
+
+// WithTeam returns a context whose requests use the named team's credentials
+// instead of the selected team's. An empty name returns ctx unchanged.
+func WithTeam(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, teamKey{}, name)
+}
+
+// Credentials describes the API key a request context resolves to.
+type Credentials struct {
+	Team           string
+	IssuerID       string
+	KeyID          string
+	BaseURL        string
+	TokenExpiresAt time.Time
+}
+
+// Credentials signs a token for the context's team, or reuses a current one,
+// and describes the key behind it. It fails if the team is unknown or the key
+// cannot sign, without making a request.
+func (c *Client) Credentials(ctx context.Context) (*Credentials, error) {
+	team, err := c.teamFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, _ := ctx.Value(teamKey{}).(string)
+	if name == "" {
+		name = c.ActiveTeam()
+	}
+
+	if _, err := team.TokenProvider.GetToken(); err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	issuerID, keyID := team.TokenProvider.identity()
+	return &Credentials{
+		Team:           name,
+		IssuerID:       issuerID,
+		KeyID:          keyID,
+		BaseURL:        team.BaseURL,
+		TokenExpiresAt: team.TokenProvider.ExpiresAt(),
 	}, nil
 }
 
+// RotateKey replaces the API key of the context's team with the key keyID,
+// given as PEM data, once a request signed with it is accepted. An empty
+// issuerID keeps the team's issuer. Requests in flight finish with the old
+// key and later ones use the new key. If the new key can't sign a token or
+// is refused, the old key stays in use.
+func (c *Client) RotateKey(ctx context.Context, issuerID, keyID string, keyData []byte) error {
+	team, err := c.teamFor(ctx)
+	if err != nil {
+		return err
+	}
+	if issuerID == "" {
+		issuerID, _ = team.TokenProvider.identity()
+	}
+
+	next, err := NewTokenProviderFromKey(issuerID, keyID, keyData)
+	if err != nil {
+		return err
+	}
+	if _, err := next.GetToken(); err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	// Any role may list apps, so a key that can't is not usable.
+	probe := Team{TokenProvider: next, BaseURL: team.BaseURL}
+	path := "/v1/apps"
+	query := url.Values{"limit": {"1"}}
+	resp, body, err := c.send(ctx, probe, http.MethodGet, path, probe.BaseURL+path+"?"+query.Encode(), nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the new key was refused: %w", responseError(resp, body))
+	}
+
+	team.TokenProvider.replace(next)
+	return nil
+}
+
+// teamFor returns the credentials and base URL for the team a request should use.
+func (c *Client) teamFor(ctx context.Context) (Team, error) {
+	c.teamsMu.RLock()
+	defer c.teamsMu.RUnlock()
+
+	if len(c.teams) == 0 {
+		return Team{TokenProvider: c.tokenProvider, BaseURL: c.baseURL}, nil
+	}
+
+	name, _ := ctx.Value(teamKey{}).(string)
+	if name == "" {
+		name = c.activeTeam
+	}
+	team, ok := c.teams[name]
+	if !ok {
+		return Team{}, fmt.Errorf("unknown team: %s", name)
+	}
+	if team.BaseURL == "" {
+		team.BaseURL = c.baseURL
+	}
+	return team, nil
+}
+
+// cursorKey is the context key for a pagination cursor.
+type cursorKey struct{}
+
+// WithCursor returns a context that resumes GET requests made with it from the
+// given pagination cursor. An empty cursor returns ctx unchanged.
+func WithCursor(ctx context.Context, cursor string) context.Context {
+	if cursor == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, cursorKey{}, cursor)
+}
+
+// NextCursor returns the cursor for the next page, or "" if this is the last page.
+func (l PagedDocumentLinks) NextCursor() string {
+	if l.Next == "" {
+		return ""
+	}
+	next, err := url.Parse(l.Next)
+	if err != nil {
+		return ""
+	}
+	return next.Query().Get("cursor")
+}
+
+// ErrDryRun is returned for mutating requests that were recorded instead of sent.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// PlannedRequest is a mutating request recorded in dry-run mode.
+type PlannedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   any
+}
+
+// DryRun records the mutating requests made with a dry-run context.
+type DryRun struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// Requests returns the recorded requests in the order they were made.
+func (d *DryRun) Requests() []PlannedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]PlannedRequest(nil), d.requests...)
+}
+
+func (d *DryRun) record(req PlannedRequest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, req)
+}
+
+// dryRunKey is the context key for a dry-run recorder.
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which mutating requests are recorded in the
+// returned DryRun and fail with ErrDryRun instead of being sent. GET requests
+// are still sent.
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	dryRun := &DryRun{}
+	return context.WithValue(ctx, dryRunKey{}, dryRun), dryRun
+}
+
+// Response describes a completed API request.
+type Response struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+
+	// RequestID is the ID the API gave the request, from the X-Request-ID
+	// header, to quote when reporting a problem to Apple. It is empty
+	// without one.
+	RequestID string
+
+	// RateLimit is nil when the response carried no X-Rate-Limit header.
+	RateLimit *RateLimit
+
+	// Date is the server's time from the Date header, or zero without one.
+	Date time.Time
+}
+
+// RateLimit is the hourly request quota reported in the X-Rate-Limit header.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+}
+
+// RateLimitStatus is the latest hourly quota reported for an API key.
+type RateLimitStatus struct {
+	Limit      int       `json:"limit"`
+	Remaining  int       `json:"remaining"`
+	Used       int       `json:"used"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// RateLimit returns the quota most recently reported for the context's team,
+// or nil if no response has carried an X-Rate-Limit header yet.
+func (c *Client) RateLimit(ctx context.Context) *RateLimitStatus {
+	team, err := c.teamFor(ctx)
+	if err != nil {
+		return nil
+	}
+
+	c.rateLimitsMu.Lock()
+	defer c.rateLimitsMu.Unlock()
+
+	status, ok := c.rateLimits[team.credentialKey()]
+	if !ok {
+		return nil
+	}
+	return &status
+}
+
+// recordRateLimit stores the quota a response reported for team's key.
+func (c *Client) recordRateLimit(team Team, rl *RateLimit) {
+	c.rateLimitsMu.Lock()
+	defer c.rateLimitsMu.Unlock()
+
+	if c.rateLimits == nil {
+		c.rateLimits = make(map[string]RateLimitStatus)
+	}
+	c.rateLimits[team.credentialKey()] = RateLimitStatus{
+		Limit:      rl.Limit,
+		Remaining:  rl.Remaining,
+		Used:       rl.Limit - rl.Remaining,
+		ObservedAt: time.Now(),
+	}
+}
+
+// ResponseObserver is called after each API response received with a context
+// from WithResponseObserver.
+type ResponseObserver func(Response)
+
+// observerKey is the context key for a response observer.
+type observerKey struct{}
+
+// WithResponseObserver returns a context whose API responses are reported to
+// observer, after any observer ctx already has.
+func WithResponseObserver(ctx context.Context, observer ResponseObserver) context.Context {
+	if parent, ok := ctx.Value(observerKey{}).(ResponseObserver); ok {
+		next := observer
+		observer = func(resp Response) {
+			parent(resp)
+			next(resp)
+		}
+	}
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// ParseRateLimit parses an X-Rate-Limit header such as
+// "user-hour-lim:3600;user-hour-rem:3599;". It returns nil if either value is missing.
+func ParseRateLimit(header string) *RateLimit {
+	limit, remaining := -1, -1
+	for _, field := range strings.Split(header, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "user-hour-lim":
+			limit = n
+		case "user-hour-rem":
+			remaining = n
+		}
+	}
+	if limit < 0 || remaining < 0 {
+		return nil
+	}
+	return &RateLimit{Limit: limit, Remaining: remaining}
+}
+
 // doRequest performs an HTTP request with authentication.
 func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error) {
-	token, err := c.tokenProvider.GetToken()
+	data, _, err := c.doRequestWithHeader(ctx, method, path, query, body, nil)
+	return data, err
+}
+
+// doRequestWithHeader performs an HTTP request with authentication and any
+// extra header, and returns the response body and header. A response served
+// from the cache has no header.
+func (c *Client) doRequestWithHeader(ctx context.Context, method, path string, query url.Values, body any, header http.Header) ([]byte, http.Header, error) {
+	if err := validateRequest(body); err != nil {
+		return nil, nil, err
+	}
+	if dryRun, ok := ctx.Value(dryRunKey{}).(*DryRun); ok && method != http.MethodGet {
+		dryRun.record(PlannedRequest{Method: method, Path: path, Query: query, Body: body})
+		return nil, nil, ErrDryRun
+	}
+
+	team, err := c.teamFor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token: %w", err)
+		return nil, nil, err
+	}
+
+	if cursor, ok := ctx.Value(cursorKey{}).(string); ok && method == http.MethodGet {
+		paged := url.Values{}
+		for key, values := range query {
+			paged[key] = values
+		}
+		paged.Set("cursor", cursor)
+		query = paged
+	}
+
+	if method != http.MethodGet {
+		return c.request(ctx, team, method, path, query, body, header)
+	}
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return c.flights.do(ctx, flightKey(team, path, query, header, refresh), func() ([]byte, http.Header, error) {
+		return c.request(ctx, team, method, path, query, nil, header)
+	})
+}
+
+// request performs a request for team, serving GETs from the response cache
+// when it can, and retrying as the retry policy and rate limits allow.
+func (c *Client) request(ctx context.Context, team Team, method, path string, query url.Values, body any, header http.Header) ([]byte, http.Header, error) {
+	var key string
+	var ttl time.Duration
+	var cached cacheEntry
+	var conditional http.Header
+	if c.cache != nil && method == http.MethodGet {
+		ttl = cacheTTL(path)
+	}
+	if ttl > 0 {
+		key = cacheKey(team, path, query)
+		entry, fresh, ok := c.cache.lookup(key)
+		refresh, _ := ctx.Value(refreshKey{}).(bool)
+		switch {
+		case ok && fresh && !refresh:
+			return entry.Body, nil, nil
+		case ok && entry.revalidatable():
+			cached, conditional = entry, entry.conditionalHeader()
+		}
 	}
 
-	reqURL := c.baseURL + path
+	reqHeader := header.Clone()
+	for name, values := range conditional {
+		if reqHeader == nil {
+			reqHeader = http.Header{}
+		}
+		reqHeader[name] = values
+	}
+
+	reqURL := team.BaseURL + path
 	if query != nil && len(query) > 0 {
 		reqURL = reqURL + "?" + query.Encode()
 	}
 
-	var bodyReader io.Reader
+	var bodyData []byte
 	if body != nil {
-		bodyData, err := json.Marshal(body)
+		var err error
+		bodyData, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	var waited time.Duration
+	rateLimited, failed := 0, 0
+	reauthorized := false
+	for {
+		resp, respBody, err := c.send(ctx, team, method, path, reqURL, bodyData, reqHeader)
+		var transportErr *transportError
+		if errors.As(err, &transportErr) && ctx.Err() == nil {
+			if delay, ok := c.retryPolicy.delay(method, failed, nil); ok {
+				if err := sleep(ctx, delay); err != nil {
+					return nil, nil, fmt.Errorf("request failed: %w", err)
+				}
+				failed++
+				continue
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if delay, ok := c.rateLimitDelay(rateLimited, resp.Header, waited); ok {
+				if err := sleep(ctx, delay); err != nil {
+					return nil, nil, fmt.Errorf("request failed: %w", err)
+				}
+				waited += delay
+				rateLimited++
+				continue
+			}
+		}
+
+		if c.retryPolicy.retryableStatus(resp.StatusCode) {
+			if delay, ok := c.retryPolicy.delay(method, failed, resp.Header); ok {
+				if err := sleep(ctx, delay); err != nil {
+					return nil, nil, fmt.Errorf("request failed: %w", err)
+				}
+				failed++
+				continue
+			}
+		}
+
+		// The API can refuse a token before it expires, so a 401 replaces
+		// the token and sends the request once more.
+		if resp.StatusCode == http.StatusUnauthorized && !reauthorized && resp.Request != nil {
+			team.TokenProvider.invalidate(strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer "))
+			reauthorized = true
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotModified && conditional != nil {
+			c.cache.revalidated(cached, resp.Header, ttl)
+			return cached.Body, resp.Header, nil
+		}
+
+		if resp.StatusCode >= 400 {
+			return nil, nil, responseError(resp, respBody)
+		}
+
+		switch {
+		case ttl > 0:
+			c.cache.put(key, path, respBody, resp.Header, ttl)
+		case c.cache != nil && method != http.MethodGet:
+			c.cache.invalidate(path)
+		}
+
+		return respBody, resp.Header, nil
+	}
+}
+
+// send sends one attempt of a request with any extra header and reads its
+// response, recording the rate limit it reports and notifying the context's
+// observer.
+func (c *Client) send(ctx context.Context, team Team, method, path, reqURL string, bodyData []byte, header http.Header) (*http.Response, []byte, error) {
+	token, err := team.TokenProvider.GetToken()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	var bodyReader io.Reader
+	if bodyData != nil {
 		bodyReader = bytes.NewReader(bodyData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	category := requestCategory(method, path)
+	attemptCtx, timeout, cancel := c.withAttemptDeadline(ctx, category)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, method, reqURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for name, values := range header {
+		req.Header[name] = values
+	}
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		if attemptCtx.Err() != nil && ctx.Err() == nil {
+			err = timeoutError(category, timeout)
+		}
+		return nil, nil, &transportError{err: err}
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	rateLimit := ParseRateLimit(resp.Header.Get("X-Rate-Limit"))
+	if rateLimit != nil {
+		c.recordRateLimit(team, rateLimit)
 	}
 
-	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && len(errResp.Errors) > 0 {
-			errMsgs := make([]string, 0, len(errResp.Errors))
-			for _, e := range errResp.Errors {
-				errMsgs = append(errMsgs, fmt.Sprintf("%s: %s", e.Title, e.Detail))
-			}
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, strings.Join(errMsgs, "; "))
+	if observer, ok := ctx.Value(observerKey{}).(ResponseObserver); ok {
+		date, _ := http.ParseTime(resp.Header.Get("Date"))
+		observer(Response{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Duration:   time.Since(start),
+			RequestID:  ResponseRequestID(resp.Header),
+			RateLimit:  rateLimit,
+			Date:       date,
+		})
+	}
+
+	withBodyProgress(ctx, resp)
+	respBody, err := readBody(resp)
+	if err != nil {
+		if attemptCtx.Err() != nil && ctx.Err() == nil {
+			return nil, nil, &transportError{err: timeoutError(category, timeout)}
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, respBody, nil
+}
+
+// maxPreallocBytes caps the buffer readBody sizes from Content-Length, so a
+// bogus header cannot force a huge allocation up front.
+const maxPreallocBytes = 32 << 20
+
+// readBody reads a response body. When the length is known the buffer is
+// allocated once, instead of doubling through a multi-megabyte list response.
+// Compressed responses have no known length and are read with io.ReadAll.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.ContentLength <= 0 || resp.ContentLength > maxPreallocBytes {
+		return io.ReadAll(resp.Body)
 	}
 
-	return respBody, nil
+	buf := bytes.NewBuffer(make([]byte, 0, resp.ContentLength+bytes.MinRead))
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // Get performs a GET request.
@@ -120,37 +787,47 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 	return err
 }
 
-// Apps API methods
+// DeleteWithBody performs a DELETE request with a JSON body, as removing
+// resources from a relationship requires.
+func (c *Client) DeleteWithBody(ctx context.Context, path string, body any) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil, body)
+	return err
+}
 
-// ListApps returns a list of apps.
-func (c *Client) ListApps(ctx context.Context, limit int) (*AppsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+// Do performs an authenticated request with an arbitrary method, query, and body.
+// It is intended for endpoints that have no typed wrapper yet.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error) {
+	return c.doRequest(ctx, method, path, query, body)
+}
+
+// Apps API methods
 
-	data, err := c.Get(ctx, "/v1/apps", query)
+// ListApps returns a list of apps, with the related resources named by
+// opts.Include, such as "appInfos", in the response's Included.
+func (c *Client) ListApps(ctx context.Context, opts ListOptions) (*AppsResponse, error) {
+	data, err := c.Get(ctx, "/v1/apps", opts.query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
-// GetApp returns a single app by ID.
-func (c *Client) GetApp(ctx context.Context, appID string) (*AppResponse, error) {
-	data, err := c.Get(ctx, "/v1/apps/"+appID, nil)
+// GetApp returns a single app by ID, with the related resources named by
+// include in the response's Included.
+func (c *Client) GetApp(ctx context.Context, appID string, include ...string) (*AppResponse, error) {
+	data, err := c.Get(ctx, "/v1/apps/"+appID, includeQuery(include))
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -158,11 +835,8 @@ func (c *Client) GetApp(ctx context.Context, appID string) (*AppResponse, error)
 }
 
 // GetAppVersions returns versions for an app.
-func (c *Client) GetAppVersions(ctx context.Context, appID string, limit int) (*AppStoreVersionsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) GetAppVersions(ctx context.Context, appID string, opts ListOptions) (*AppStoreVersionsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/appStoreVersions", query)
 	if err != nil {
@@ -170,7 +844,7 @@ func (c *Client) GetAppVersions(ctx context.Context, appID string, limit int) (*
 	}
 
 	var resp AppStoreVersionsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -180,37 +854,61 @@ func (c *Client) GetAppVersions(ctx context.Context, appID string, limit int) (*
 // Builds API methods
 
 // ListBuilds returns a list of builds.
-func (c *Client) ListBuilds(ctx context.Context, appID string, limit int) (*BuildsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListBuilds(ctx context.Context, appID string, opts ListOptions) (*BuildsResponse, error) {
+	data, err := c.Get(ctx, "/v1/builds", opts.WithFilter("app", appID).query())
+	if err != nil {
+		return nil, err
 	}
-	if appID != "" {
-		query.Set("filter[app]", appID)
+
+	var resp BuildsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	data, err := c.Get(ctx, "/v1/builds", query)
+	return &resp, nil
+}
+
+// GetBuild returns a single build by ID, with the related resources named
+// by include, such as "preReleaseVersion", in the response's Included.
+func (c *Client) GetBuild(ctx context.Context, buildID string, include ...string) (*BuildResponse, error) {
+	data, err := c.Get(ctx, "/v1/builds/"+buildID, includeQuery(include))
 	if err != nil {
 		return nil, err
 	}
 
-	var resp BuildsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	var resp BuildResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
-// GetBuild returns a single build by ID.
-func (c *Client) GetBuild(ctx context.Context, buildID string) (*BuildResponse, error) {
-	data, err := c.Get(ctx, "/v1/builds/"+buildID, nil)
+// GetBuildBetaUsages returns a build's TestFlight install, session, crash,
+// feedback, and invitation counts.
+func (c *Client) GetBuildBetaUsages(ctx context.Context, buildID string) (*BetaBuildUsagesResponse, error) {
+	data, err := c.Get(ctx, "/v1/builds/"+buildID+"/metrics/betaBuildUsages", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BetaBuildUsagesResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateBuild updates a build's attributes.
+func (c *Client) UpdateBuild(ctx context.Context, buildID string, req *BuildUpdateRequest) (*BuildResponse, error) {
+	data, err := c.Patch(ctx, "/v1/builds/"+buildID, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BuildResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -220,11 +918,8 @@ func (c *Client) GetBuild(ctx context.Context, buildID string) (*BuildResponse,
 // Beta Groups API methods
 
 // ListBetaGroups returns a list of beta groups.
-func (c *Client) ListBetaGroups(ctx context.Context, appID string, limit int) (*BetaGroupsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListBetaGroups(ctx context.Context, appID string, opts ListOptions) (*BetaGroupsResponse, error) {
+	query := opts.query()
 	if appID != "" {
 		query.Set("filter[app]", appID)
 	}
@@ -235,7 +930,7 @@ func (c *Client) ListBetaGroups(ctx context.Context, appID string, limit int) (*
 	}
 
 	var resp BetaGroupsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -250,7 +945,58 @@ func (c *Client) CreateBetaGroup(ctx context.Context, req *BetaGroupCreateReques
 	}
 
 	var resp BetaGroupResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetBetaGroup returns a single beta group by ID.
+func (c *Client) GetBetaGroup(ctx context.Context, betaGroupID string) (*BetaGroupResponse, error) {
+	data, err := c.Get(ctx, "/v1/betaGroups/"+betaGroupID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BetaGroupResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetBetaGroupPublicLinkUsages returns public link usage metrics for a beta group.
+func (c *Client) GetBetaGroupPublicLinkUsages(ctx context.Context, betaGroupID string) (*BetaPublicLinkUsagesResponse, error) {
+	data, err := c.Get(ctx, "/v1/betaGroups/"+betaGroupID+"/metrics/publicLinkUsages", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BetaPublicLinkUsagesResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetBetaGroupTesterUsages returns tester usage metrics for a beta group.
+// Period is an ISO 8601 duration accepted by the API, such as P7D or P30D.
+func (c *Client) GetBetaGroupTesterUsages(ctx context.Context, betaGroupID, period string) (*BetaTesterUsagesResponse, error) {
+	query := url.Values{}
+	if period != "" {
+		query.Set("period", period)
+	}
+
+	data, err := c.Get(ctx, "/v1/betaGroups/"+betaGroupID+"/metrics/betaTesterUsages", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BetaTesterUsagesResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -262,25 +1008,45 @@ func (c *Client) DeleteBetaGroup(ctx context.Context, betaGroupID string) error
 	return c.Delete(ctx, "/v1/betaGroups/"+betaGroupID)
 }
 
-// Beta Testers API methods
+// AddBuildsToBetaGroup gives a beta group's testers access to builds.
+func (c *Client) AddBuildsToBetaGroup(ctx context.Context, betaGroupID string, buildIDs ...string) error {
+	return c.AddRelationships(ctx, "betaGroups", betaGroupID, "builds", identifiers("builds", buildIDs...)...)
+}
 
-// ListBetaTesters returns a list of beta testers.
-func (c *Client) ListBetaTesters(ctx context.Context, betaGroupID string, limit int) (*BetaTestersResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
+// RemoveBuildsFromBetaGroup takes builds away from a beta group's testers.
+func (c *Client) RemoveBuildsFromBetaGroup(ctx context.Context, betaGroupID string, buildIDs ...string) error {
+	return c.RemoveRelationships(ctx, "betaGroups", betaGroupID, "builds", identifiers("builds", buildIDs...)...)
+}
+
+// ListBetaGroupBuilds returns the builds a beta group has access to.
+func (c *Client) ListBetaGroupBuilds(ctx context.Context, betaGroupID string, opts ListOptions) (*BuildsResponse, error) {
+	query := opts.query()
+
+	data, err := c.Get(ctx, "/v1/betaGroups/"+betaGroupID+"/builds", query)
+	if err != nil {
+		return nil, err
 	}
-	if betaGroupID != "" {
-		query.Set("filter[betaGroups]", betaGroupID)
+
+	var resp BuildsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	data, err := c.Get(ctx, "/v1/betaTesters", query)
+	return &resp, nil
+}
+
+// Beta Testers API methods
+
+// ListBetaTesters returns a list of beta testers, with the related resources
+// named by opts.Include, such as "betaGroups", in the response's Included.
+func (c *Client) ListBetaTesters(ctx context.Context, betaGroupID string, opts ListOptions) (*BetaTestersResponse, error) {
+	data, err := c.Get(ctx, "/v1/betaTesters", opts.WithFilter("betaGroups", betaGroupID).query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BetaTestersResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -295,7 +1061,7 @@ func (c *Client) CreateBetaTester(ctx context.Context, req *BetaTesterCreateRequ
 	}
 
 	var resp BetaTesterResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -309,34 +1075,20 @@ func (c *Client) DeleteBetaTester(ctx context.Context, betaTesterID string) erro
 
 // AddBetaTesterToGroup adds a beta tester to a group.
 func (c *Client) AddBetaTesterToGroup(ctx context.Context, betaGroupID, betaTesterID string) error {
-	body := map[string]any{
-		"data": []map[string]string{
-			{
-				"type": "betaTesters",
-				"id":   betaTesterID,
-			},
-		},
-	}
-
-	_, err := c.Post(ctx, "/v1/betaGroups/"+betaGroupID+"/relationships/betaTesters", body)
-	return err
+	return c.AddRelationships(ctx, "betaGroups", betaGroupID, "betaTesters", identifiers("betaTesters", betaTesterID)...)
 }
 
-// RemoveBetaTesterFromGroup removes a beta tester from a group.
+// RemoveBetaTesterFromGroup removes a beta tester from a group. The tester
+// keeps access through any other group.
 func (c *Client) RemoveBetaTesterFromGroup(ctx context.Context, betaGroupID, betaTesterID string) error {
-	// This requires a DELETE with a body, which is non-standard
-	// For now, we use the delete beta tester endpoint
-	return c.Delete(ctx, "/v1/betaGroups/"+betaGroupID+"/relationships/betaTesters")
+	return c.RemoveRelationships(ctx, "betaGroups", betaGroupID, "betaTesters", identifiers("betaTesters", betaTesterID)...)
 }
 
 // Bundle IDs API methods
 
 // ListBundleIDs returns a list of bundle IDs.
-func (c *Client) ListBundleIDs(ctx context.Context, limit int) (*BundleIDsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListBundleIDs(ctx context.Context, opts ListOptions) (*BundleIDsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/bundleIds", query)
 	if err != nil {
@@ -344,7 +1096,7 @@ func (c *Client) ListBundleIDs(ctx context.Context, limit int) (*BundleIDsRespon
 	}
 
 	var resp BundleIDsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -359,7 +1111,7 @@ func (c *Client) GetBundleID(ctx context.Context, bundleIDID string) (*BundleIDR
 	}
 
 	var resp BundleIDResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -369,11 +1121,8 @@ func (c *Client) GetBundleID(ctx context.Context, bundleIDID string) (*BundleIDR
 // Devices API methods
 
 // ListDevices returns a list of devices.
-func (c *Client) ListDevices(ctx context.Context, limit int) (*DevicesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListDevices(ctx context.Context, opts ListOptions) (*DevicesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/devices", query)
 	if err != nil {
@@ -381,7 +1130,7 @@ func (c *Client) ListDevices(ctx context.Context, limit int) (*DevicesResponse,
 	}
 
 	var resp DevicesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -396,7 +1145,7 @@ func (c *Client) RegisterDevice(ctx context.Context, req *DeviceCreateRequest) (
 	}
 
 	var resp DeviceResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -406,11 +1155,8 @@ func (c *Client) RegisterDevice(ctx context.Context, req *DeviceCreateRequest) (
 // Certificates API methods
 
 // ListCertificates returns a list of certificates.
-func (c *Client) ListCertificates(ctx context.Context, limit int) (*CertificatesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListCertificates(ctx context.Context, opts ListOptions) (*CertificatesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/certificates", query)
 	if err != nil {
@@ -418,7 +1164,7 @@ func (c *Client) ListCertificates(ctx context.Context, limit int) (*Certificates
 	}
 
 	var resp CertificatesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -428,11 +1174,8 @@ func (c *Client) ListCertificates(ctx context.Context, limit int) (*Certificates
 // Profiles API methods
 
 // ListProfiles returns a list of provisioning profiles.
-func (c *Client) ListProfiles(ctx context.Context, limit int) (*ProfilesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListProfiles(ctx context.Context, opts ListOptions) (*ProfilesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/profiles", query)
 	if err != nil {
@@ -440,7 +1183,7 @@ func (c *Client) ListProfiles(ctx context.Context, limit int) (*ProfilesResponse
 	}
 
 	var resp ProfilesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -455,7 +1198,7 @@ func (c *Client) GetProfile(ctx context.Context, profileID string) (*ProfileResp
 	}
 
 	var resp ProfileResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -472,7 +1215,7 @@ func (c *Client) GetAppInfos(ctx context.Context, appID string) (*AppInfosRespon
 	}
 
 	var resp AppInfosResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -482,14 +1225,14 @@ func (c *Client) GetAppInfos(ctx context.Context, appID string) (*AppInfosRespon
 // App Info Localization API methods
 
 // ListAppInfoLocalizations returns localizations for an app info.
-func (c *Client) ListAppInfoLocalizations(ctx context.Context, appInfoID string) (*AppInfoLocalizationsResponse, error) {
-	data, err := c.Get(ctx, "/v1/appInfos/"+appInfoID+"/appInfoLocalizations", nil)
+func (c *Client) ListAppInfoLocalizations(ctx context.Context, appInfoID string, opts ListOptions) (*AppInfoLocalizationsResponse, error) {
+	data, err := c.Get(ctx, "/v1/appInfos/"+appInfoID+"/appInfoLocalizations", opts.query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppInfoLocalizationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -504,7 +1247,7 @@ func (c *Client) GetAppInfoLocalization(ctx context.Context, localizationID stri
 	}
 
 	var resp AppInfoLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -519,7 +1262,7 @@ func (c *Client) CreateAppInfoLocalization(ctx context.Context, req *AppInfoLoca
 	}
 
 	var resp AppInfoLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -534,7 +1277,7 @@ func (c *Client) UpdateAppInfoLocalization(ctx context.Context, localizationID s
 	}
 
 	var resp AppInfoLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -549,14 +1292,14 @@ func (c *Client) DeleteAppInfoLocalization(ctx context.Context, localizationID s
 // App Store Version Localization API methods
 
 // ListAppStoreVersionLocalizations returns localizations for a version.
-func (c *Client) ListAppStoreVersionLocalizations(ctx context.Context, versionID string) (*AppStoreVersionLocalizationsResponse, error) {
-	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID+"/appStoreVersionLocalizations", nil)
+func (c *Client) ListAppStoreVersionLocalizations(ctx context.Context, versionID string, opts ListOptions) (*AppStoreVersionLocalizationsResponse, error) {
+	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID+"/appStoreVersionLocalizations", opts.query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppStoreVersionLocalizationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -571,7 +1314,7 @@ func (c *Client) GetAppStoreVersionLocalization(ctx context.Context, localizatio
 	}
 
 	var resp AppStoreVersionLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -586,7 +1329,7 @@ func (c *Client) CreateAppStoreVersionLocalization(ctx context.Context, req *App
 	}
 
 	var resp AppStoreVersionLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -601,7 +1344,7 @@ func (c *Client) UpdateAppStoreVersionLocalization(ctx context.Context, localiza
 	}
 
 	var resp AppStoreVersionLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -616,40 +1359,88 @@ func (c *Client) DeleteAppStoreVersionLocalization(ctx context.Context, localiza
 // Customer Reviews API methods
 
 // ListCustomerReviews returns customer reviews for an app.
-func (c *Client) ListCustomerReviews(ctx context.Context, appID string, limit int) (*CustomerReviewsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
-
-	data, err := c.Get(ctx, "/v1/apps/"+appID+"/customerReviews", query)
+func (c *Client) ListCustomerReviews(ctx context.Context, appID string, opts ListOptions) (*CustomerReviewsResponse, error) {
+	data, err := c.Get(ctx, "/v1/apps/"+appID+"/customerReviews", opts.query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp CustomerReviewsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
-// GetCustomerReview returns a single customer review by ID.
-func (c *Client) GetCustomerReview(ctx context.Context, reviewID string) (*CustomerReviewResponse, error) {
-	data, err := c.Get(ctx, "/v1/customerReviews/"+reviewID, nil)
+// GetCustomerReview returns a single customer review by ID, with any
+// related resources named in include, such as "response".
+func (c *Client) GetCustomerReview(ctx context.Context, reviewID string, include ...string) (*CustomerReviewResponse, error) {
+	data, err := c.Get(ctx, "/v1/customerReviews/"+reviewID, includeQuery(include))
 	if err != nil {
 		return nil, err
 	}
 
 	var resp CustomerReviewResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
+// customerReviewConcurrency caps the reviews GetCustomerReviews requests at
+// once.
+const customerReviewConcurrency = 4
+
+// CustomerReviewResult is a review fetched by GetCustomerReviews, with the
+// developer response to it, if any, or the error fetching it.
+type CustomerReviewResult struct {
+	ID       string
+	Review   *CustomerReview
+	Response *CustomerReviewResponseV1
+	Err      error
+}
+
+// GetCustomerReviews fetches the reviews with the given IDs concurrently,
+// each with its developer response included. Results are in the order of
+// reviewIDs; a review that can't be fetched has Err set.
+func (c *Client) GetCustomerReviews(ctx context.Context, reviewIDs []string) []CustomerReviewResult {
+	results := make([]CustomerReviewResult, len(reviewIDs))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, customerReviewConcurrency)
+	for i, id := range reviewIDs {
+		results[i].ID = id
+		wg.Add(1)
+		go func(result *CustomerReviewResult) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			resp, err := c.GetCustomerReview(ctx, result.ID, "response")
+			if err != nil {
+				result.Err = err
+				return
+			}
+			result.Review = &resp.Data
+			included, err := DecodeIncluded(resp.Included)
+			if err != nil {
+				result.Err = err
+				return
+			}
+			for _, resource := range included {
+				if response, ok := resource.(CustomerReviewResponseV1); ok {
+					result.Response = &response
+				}
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
+
 // CreateCustomerReviewResponse creates a response to a customer review.
 func (c *Client) CreateCustomerReviewResponse(ctx context.Context, req *CustomerReviewResponseCreateRequest) (*CustomerReviewResponseV1Response, error) {
 	data, err := c.Post(ctx, "/v1/customerReviewResponses", req)
@@ -658,7 +1449,7 @@ func (c *Client) CreateCustomerReviewResponse(ctx context.Context, req *Customer
 	}
 
 	var resp CustomerReviewResponseV1Response
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -672,20 +1463,17 @@ func (c *Client) DeleteCustomerReviewResponse(ctx context.Context, responseID st
 
 // In-App Purchases API methods
 
-// ListInAppPurchases returns in-app purchases for an app.
-func (c *Client) ListInAppPurchases(ctx context.Context, appID string, limit int) (*InAppPurchasesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
-
+// ListInAppPurchases returns in-app purchases for an app.
+func (c *Client) ListInAppPurchases(ctx context.Context, appID string, opts ListOptions) (*InAppPurchasesResponse, error) {
+	query := opts.query()
+
 	data, err := c.Get(ctx, "/v2/apps/"+appID+"/inAppPurchasesV2", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp InAppPurchasesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -700,7 +1488,7 @@ func (c *Client) GetInAppPurchase(ctx context.Context, iapID string) (*InAppPurc
 	}
 
 	var resp InAppPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -715,7 +1503,7 @@ func (c *Client) CreateInAppPurchase(ctx context.Context, req *InAppPurchaseCrea
 	}
 
 	var resp InAppPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -730,7 +1518,7 @@ func (c *Client) UpdateInAppPurchase(ctx context.Context, iapID string, req *InA
 	}
 
 	var resp InAppPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -745,11 +1533,8 @@ func (c *Client) DeleteInAppPurchase(ctx context.Context, iapID string) error {
 // Subscriptions API methods
 
 // ListSubscriptionGroups returns subscription groups for an app.
-func (c *Client) ListSubscriptionGroups(ctx context.Context, appID string, limit int) (*SubscriptionGroupsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListSubscriptionGroups(ctx context.Context, appID string, opts ListOptions) (*SubscriptionGroupsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/subscriptionGroups", query)
 	if err != nil {
@@ -757,7 +1542,7 @@ func (c *Client) ListSubscriptionGroups(ctx context.Context, appID string, limit
 	}
 
 	var resp SubscriptionGroupsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -772,7 +1557,7 @@ func (c *Client) GetSubscriptionGroup(ctx context.Context, groupID string) (*Sub
 	}
 
 	var resp SubscriptionGroupResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -780,11 +1565,8 @@ func (c *Client) GetSubscriptionGroup(ctx context.Context, groupID string) (*Sub
 }
 
 // ListSubscriptions returns subscriptions for a subscription group.
-func (c *Client) ListSubscriptions(ctx context.Context, groupID string, limit int) (*SubscriptionsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListSubscriptions(ctx context.Context, groupID string, opts ListOptions) (*SubscriptionsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/subscriptionGroups/"+groupID+"/subscriptions", query)
 	if err != nil {
@@ -792,7 +1574,7 @@ func (c *Client) ListSubscriptions(ctx context.Context, groupID string, limit in
 	}
 
 	var resp SubscriptionsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -807,7 +1589,7 @@ func (c *Client) GetSubscription(ctx context.Context, subscriptionID string) (*S
 	}
 
 	var resp SubscriptionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -816,15 +1598,17 @@ func (c *Client) GetSubscription(ctx context.Context, subscriptionID string) (*S
 
 // App Store Version API methods
 
-// GetAppStoreVersion returns a single app store version by ID.
-func (c *Client) GetAppStoreVersion(ctx context.Context, versionID string) (*AppStoreVersionResponse, error) {
-	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID, nil)
+// GetAppStoreVersion returns a single app store version by ID, with the
+// related resources named by include, such as "build", in the response's
+// Included.
+func (c *Client) GetAppStoreVersion(ctx context.Context, versionID string, include ...string) (*AppStoreVersionResponse, error) {
+	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID, includeQuery(include))
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppStoreVersionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -839,7 +1623,7 @@ func (c *Client) CreateAppStoreVersion(ctx context.Context, req *AppStoreVersion
 	}
 
 	var resp AppStoreVersionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -854,7 +1638,7 @@ func (c *Client) UpdateAppStoreVersion(ctx context.Context, versionID string, re
 	}
 
 	var resp AppStoreVersionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -876,7 +1660,7 @@ func (c *Client) CreateAppStoreVersionSubmission(ctx context.Context, req *AppSt
 	}
 
 	var resp AppStoreVersionSubmissionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -893,13 +1677,40 @@ func (c *Client) GetAppStoreReviewDetail(ctx context.Context, versionID string)
 	}
 
 	var resp AppStoreReviewDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetAppStoreReviewDetailWithAttachments returns review details for a
+// version, including up to limit of its review attachments.
+func (c *Client) GetAppStoreReviewDetailWithAttachments(ctx context.Context, versionID string, limit int) (*AppStoreReviewDetailResponse, error) {
+	query := url.Values{}
+	query.Set("include", "appStoreReviewAttachments")
+	if limit > 0 {
+		query.Set("limit[appStoreReviewAttachments]", fmt.Sprintf("%d", limit))
+	}
+
+	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID+"/appStoreReviewDetail", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AppStoreReviewDetailResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
+// Attachments returns the review attachments included in the response.
+func (r *AppStoreReviewDetailResponse) Attachments() ([]AppStoreReviewAttachment, error) {
+	return IncludedOf[AppStoreReviewAttachment](r.Included)
+}
+
 // CreateAppStoreReviewDetail creates review details for a version.
 func (c *Client) CreateAppStoreReviewDetail(ctx context.Context, req *AppStoreReviewDetailCreateRequest) (*AppStoreReviewDetailResponse, error) {
 	data, err := c.Post(ctx, "/v1/appStoreReviewDetails", req)
@@ -908,7 +1719,7 @@ func (c *Client) CreateAppStoreReviewDetail(ctx context.Context, req *AppStoreRe
 	}
 
 	var resp AppStoreReviewDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -923,7 +1734,7 @@ func (c *Client) UpdateAppStoreReviewDetail(ctx context.Context, detailID string
 	}
 
 	var resp AppStoreReviewDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -940,7 +1751,7 @@ func (c *Client) GetAppStoreVersionPhasedRelease(ctx context.Context, versionID
 	}
 
 	var resp AppStoreVersionPhasedReleaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -955,7 +1766,7 @@ func (c *Client) CreateAppStoreVersionPhasedRelease(ctx context.Context, req *Ap
 	}
 
 	var resp AppStoreVersionPhasedReleaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -970,7 +1781,7 @@ func (c *Client) UpdateAppStoreVersionPhasedRelease(ctx context.Context, phasedR
 	}
 
 	var resp AppStoreVersionPhasedReleaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -985,11 +1796,8 @@ func (c *Client) DeleteAppStoreVersionPhasedRelease(ctx context.Context, phasedR
 // App Screenshot API methods
 
 // ListAppScreenshotSets returns screenshot sets for a version localization.
-func (c *Client) ListAppScreenshotSets(ctx context.Context, localizationID string, limit int) (*AppScreenshotSetsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppScreenshotSets(ctx context.Context, localizationID string, opts ListOptions) (*AppScreenshotSetsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appStoreVersionLocalizations/"+localizationID+"/appScreenshotSets", query)
 	if err != nil {
@@ -997,7 +1805,7 @@ func (c *Client) ListAppScreenshotSets(ctx context.Context, localizationID strin
 	}
 
 	var resp AppScreenshotSetsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1005,11 +1813,8 @@ func (c *Client) ListAppScreenshotSets(ctx context.Context, localizationID strin
 }
 
 // ListAppScreenshots returns screenshots for a screenshot set.
-func (c *Client) ListAppScreenshots(ctx context.Context, screenshotSetID string, limit int) (*AppScreenshotsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppScreenshots(ctx context.Context, screenshotSetID string, opts ListOptions) (*AppScreenshotsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appScreenshotSets/"+screenshotSetID+"/appScreenshots", query)
 	if err != nil {
@@ -1017,7 +1822,7 @@ func (c *Client) ListAppScreenshots(ctx context.Context, screenshotSetID string,
 	}
 
 	var resp AppScreenshotsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1032,7 +1837,7 @@ func (c *Client) GetAppScreenshot(ctx context.Context, screenshotID string) (*Ap
 	}
 
 	var resp AppScreenshotResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1047,7 +1852,7 @@ func (c *Client) CreateAppScreenshot(ctx context.Context, req *AppScreenshotCrea
 	}
 
 	var resp AppScreenshotResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1062,7 +1867,7 @@ func (c *Client) UpdateAppScreenshot(ctx context.Context, screenshotID string, r
 	}
 
 	var resp AppScreenshotResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1077,11 +1882,8 @@ func (c *Client) DeleteAppScreenshot(ctx context.Context, screenshotID string) e
 // App Preview API methods
 
 // ListAppPreviewSets returns preview sets for a version localization.
-func (c *Client) ListAppPreviewSets(ctx context.Context, localizationID string, limit int) (*AppPreviewSetsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppPreviewSets(ctx context.Context, localizationID string, opts ListOptions) (*AppPreviewSetsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appStoreVersionLocalizations/"+localizationID+"/appPreviewSets", query)
 	if err != nil {
@@ -1089,7 +1891,7 @@ func (c *Client) ListAppPreviewSets(ctx context.Context, localizationID string,
 	}
 
 	var resp AppPreviewSetsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1097,11 +1899,8 @@ func (c *Client) ListAppPreviewSets(ctx context.Context, localizationID string,
 }
 
 // ListAppPreviews returns previews for a preview set.
-func (c *Client) ListAppPreviews(ctx context.Context, previewSetID string, limit int) (*AppPreviewsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppPreviews(ctx context.Context, previewSetID string, opts ListOptions) (*AppPreviewsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appPreviewSets/"+previewSetID+"/appPreviews", query)
 	if err != nil {
@@ -1109,7 +1908,7 @@ func (c *Client) ListAppPreviews(ctx context.Context, previewSetID string, limit
 	}
 
 	var resp AppPreviewsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1124,7 +1923,7 @@ func (c *Client) GetAppPreview(ctx context.Context, previewID string) (*AppPrevi
 	}
 
 	var resp AppPreviewResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1139,7 +1938,22 @@ func (c *Client) CreateAppPreview(ctx context.Context, req *AppPreviewCreateRequ
 	}
 
 	var resp AppPreviewResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateAppPreview updates a preview.
+func (c *Client) UpdateAppPreview(ctx context.Context, previewID string, req *AppPreviewUpdateRequest) (*AppPreviewResponse, error) {
+	data, err := c.Patch(ctx, "/v1/appPreviews/"+previewID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AppPreviewResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1161,7 +1975,7 @@ func (c *Client) GetAppPreOrder(ctx context.Context, appID string) (*AppPreOrder
 	}
 
 	var resp AppPreOrderResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1176,7 +1990,7 @@ func (c *Client) CreateAppPreOrder(ctx context.Context, req *AppPreOrderCreateRe
 	}
 
 	var resp AppPreOrderResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1191,7 +2005,7 @@ func (c *Client) UpdateAppPreOrder(ctx context.Context, preOrderID string, req *
 	}
 
 	var resp AppPreOrderResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1206,11 +2020,8 @@ func (c *Client) DeleteAppPreOrder(ctx context.Context, preOrderID string) error
 // App Event API methods
 
 // ListAppEvents returns app events for an app.
-func (c *Client) ListAppEvents(ctx context.Context, appID string, limit int) (*AppEventsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppEvents(ctx context.Context, appID string, opts ListOptions) (*AppEventsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/appEvents", query)
 	if err != nil {
@@ -1218,7 +2029,7 @@ func (c *Client) ListAppEvents(ctx context.Context, appID string, limit int) (*A
 	}
 
 	var resp AppEventsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1233,7 +2044,7 @@ func (c *Client) GetAppEvent(ctx context.Context, eventID string) (*AppEventResp
 	}
 
 	var resp AppEventResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1248,7 +2059,7 @@ func (c *Client) CreateAppEvent(ctx context.Context, req *AppEventCreateRequest)
 	}
 
 	var resp AppEventResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1263,7 +2074,7 @@ func (c *Client) UpdateAppEvent(ctx context.Context, eventID string, req *AppEve
 	}
 
 	var resp AppEventResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1275,14 +2086,75 @@ func (c *Client) DeleteAppEvent(ctx context.Context, eventID string) error {
 	return c.Delete(ctx, "/v1/appEvents/"+eventID)
 }
 
+// Review Submission API methods
+
+// ListReviewSubmissions returns review submissions, filtered by app,
+// platform and state through opts.
+func (c *Client) ListReviewSubmissions(ctx context.Context, opts ListOptions) (*ReviewSubmissionsResponse, error) {
+	data, err := c.Get(ctx, "/v1/reviewSubmissions", opts.query())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ReviewSubmissionsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// CreateReviewSubmission creates a review submission for an app and platform.
+func (c *Client) CreateReviewSubmission(ctx context.Context, req *ReviewSubmissionCreateRequest) (*ReviewSubmissionResponse, error) {
+	data, err := c.Post(ctx, "/v1/reviewSubmissions", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ReviewSubmissionResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateReviewSubmission submits a review submission to App Review or
+// cancels it.
+func (c *Client) UpdateReviewSubmission(ctx context.Context, submissionID string, req *ReviewSubmissionUpdateRequest) (*ReviewSubmissionResponse, error) {
+	data, err := c.Patch(ctx, "/v1/reviewSubmissions/"+submissionID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ReviewSubmissionResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// CreateReviewSubmissionItem adds an item to a review submission.
+func (c *Client) CreateReviewSubmissionItem(ctx context.Context, req *ReviewSubmissionItemCreateRequest) (*ReviewSubmissionItemResponse, error) {
+	data, err := c.Post(ctx, "/v1/reviewSubmissionItems", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ReviewSubmissionItemResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
 // Analytics API methods
 
 // ListAnalyticsReportRequests returns analytics report requests for an app.
-func (c *Client) ListAnalyticsReportRequests(ctx context.Context, appID string, limit int) (*AnalyticsReportRequestsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAnalyticsReportRequests(ctx context.Context, appID string, opts ListOptions) (*AnalyticsReportRequestsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/analyticsReportRequests", query)
 	if err != nil {
@@ -1290,7 +2162,7 @@ func (c *Client) ListAnalyticsReportRequests(ctx context.Context, appID string,
 	}
 
 	var resp AnalyticsReportRequestsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1305,7 +2177,7 @@ func (c *Client) GetAnalyticsReportRequest(ctx context.Context, requestID string
 	}
 
 	var resp AnalyticsReportRequestResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1320,7 +2192,7 @@ func (c *Client) CreateAnalyticsReportRequest(ctx context.Context, req *Analytic
 	}
 
 	var resp AnalyticsReportRequestResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1333,11 +2205,8 @@ func (c *Client) DeleteAnalyticsReportRequest(ctx context.Context, requestID str
 }
 
 // ListAnalyticsReports returns analytics reports for a request.
-func (c *Client) ListAnalyticsReports(ctx context.Context, requestID string, limit int) (*AnalyticsReportsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAnalyticsReports(ctx context.Context, requestID string, opts ListOptions) (*AnalyticsReportsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/analyticsReportRequests/"+requestID+"/reports", query)
 	if err != nil {
@@ -1345,7 +2214,7 @@ func (c *Client) ListAnalyticsReports(ctx context.Context, requestID string, lim
 	}
 
 	var resp AnalyticsReportsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1353,11 +2222,8 @@ func (c *Client) ListAnalyticsReports(ctx context.Context, requestID string, lim
 }
 
 // ListAnalyticsReportInstances returns instances for a report.
-func (c *Client) ListAnalyticsReportInstances(ctx context.Context, reportID string, limit int) (*AnalyticsReportInstancesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAnalyticsReportInstances(ctx context.Context, reportID string, opts ListOptions) (*AnalyticsReportInstancesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/analyticsReports/"+reportID+"/instances", query)
 	if err != nil {
@@ -1365,7 +2231,7 @@ func (c *Client) ListAnalyticsReportInstances(ctx context.Context, reportID stri
 	}
 
 	var resp AnalyticsReportInstancesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1373,11 +2239,8 @@ func (c *Client) ListAnalyticsReportInstances(ctx context.Context, reportID stri
 }
 
 // ListAnalyticsReportSegments returns segments for a report instance.
-func (c *Client) ListAnalyticsReportSegments(ctx context.Context, instanceID string, limit int) (*AnalyticsReportSegmentsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAnalyticsReportSegments(ctx context.Context, instanceID string, opts ListOptions) (*AnalyticsReportSegmentsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/analyticsReportInstances/"+instanceID+"/segments", query)
 	if err != nil {
@@ -1385,7 +2248,7 @@ func (c *Client) ListAnalyticsReportSegments(ctx context.Context, instanceID str
 	}
 
 	var resp AnalyticsReportSegmentsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1395,11 +2258,8 @@ func (c *Client) ListAnalyticsReportSegments(ctx context.Context, instanceID str
 // App Clip API methods
 
 // ListAppClips returns app clips for an app.
-func (c *Client) ListAppClips(ctx context.Context, appID string, limit int) (*AppClipsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppClips(ctx context.Context, appID string, opts ListOptions) (*AppClipsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/appClips", query)
 	if err != nil {
@@ -1407,7 +2267,7 @@ func (c *Client) ListAppClips(ctx context.Context, appID string, limit int) (*Ap
 	}
 
 	var resp AppClipsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1422,7 +2282,7 @@ func (c *Client) GetAppClip(ctx context.Context, appClipID string) (*AppClipResp
 	}
 
 	var resp AppClipResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1430,11 +2290,8 @@ func (c *Client) GetAppClip(ctx context.Context, appClipID string) (*AppClipResp
 }
 
 // ListAppClipDefaultExperiences returns default experiences for an app clip.
-func (c *Client) ListAppClipDefaultExperiences(ctx context.Context, appClipID string, limit int) (*AppClipDefaultExperiencesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppClipDefaultExperiences(ctx context.Context, appClipID string, opts ListOptions) (*AppClipDefaultExperiencesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appClips/"+appClipID+"/appClipDefaultExperiences", query)
 	if err != nil {
@@ -1442,7 +2299,7 @@ func (c *Client) ListAppClipDefaultExperiences(ctx context.Context, appClipID st
 	}
 
 	var resp AppClipDefaultExperiencesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1457,7 +2314,7 @@ func (c *Client) GetAppClipDefaultExperience(ctx context.Context, experienceID s
 	}
 
 	var resp AppClipDefaultExperienceResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1465,11 +2322,8 @@ func (c *Client) GetAppClipDefaultExperience(ctx context.Context, experienceID s
 }
 
 // ListAppClipAdvancedExperiences returns advanced experiences for an app clip.
-func (c *Client) ListAppClipAdvancedExperiences(ctx context.Context, appClipID string, limit int) (*AppClipAdvancedExperiencesResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppClipAdvancedExperiences(ctx context.Context, appClipID string, opts ListOptions) (*AppClipAdvancedExperiencesResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/appClips/"+appClipID+"/appClipAdvancedExperiences", query)
 	if err != nil {
@@ -1477,7 +2331,7 @@ func (c *Client) ListAppClipAdvancedExperiences(ctx context.Context, appClipID s
 	}
 
 	var resp AppClipAdvancedExperiencesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1492,7 +2346,7 @@ func (c *Client) GetAppClipAdvancedExperience(ctx context.Context, experienceID
 	}
 
 	var resp AppClipAdvancedExperienceResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1509,7 +2363,7 @@ func (c *Client) GetGameCenterDetail(ctx context.Context, appID string) (*GameCe
 	}
 
 	var resp GameCenterDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1517,11 +2371,8 @@ func (c *Client) GetGameCenterDetail(ctx context.Context, appID string) (*GameCe
 }
 
 // ListGameCenterAchievements returns achievements for a game center detail.
-func (c *Client) ListGameCenterAchievements(ctx context.Context, gameCenterDetailID string, limit int) (*GameCenterAchievementsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListGameCenterAchievements(ctx context.Context, gameCenterDetailID string, opts ListOptions) (*GameCenterAchievementsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/gameCenterDetails/"+gameCenterDetailID+"/gameCenterAchievements", query)
 	if err != nil {
@@ -1529,7 +2380,7 @@ func (c *Client) ListGameCenterAchievements(ctx context.Context, gameCenterDetai
 	}
 
 	var resp GameCenterAchievementsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1544,7 +2395,7 @@ func (c *Client) GetGameCenterAchievement(ctx context.Context, achievementID str
 	}
 
 	var resp GameCenterAchievementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1559,7 +2410,7 @@ func (c *Client) CreateGameCenterAchievement(ctx context.Context, req *GameCente
 	}
 
 	var resp GameCenterAchievementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1574,7 +2425,7 @@ func (c *Client) UpdateGameCenterAchievement(ctx context.Context, achievementID
 	}
 
 	var resp GameCenterAchievementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1587,11 +2438,8 @@ func (c *Client) DeleteGameCenterAchievement(ctx context.Context, achievementID
 }
 
 // ListGameCenterLeaderboards returns leaderboards for a game center detail.
-func (c *Client) ListGameCenterLeaderboards(ctx context.Context, gameCenterDetailID string, limit int) (*GameCenterLeaderboardsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListGameCenterLeaderboards(ctx context.Context, gameCenterDetailID string, opts ListOptions) (*GameCenterLeaderboardsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/gameCenterDetails/"+gameCenterDetailID+"/gameCenterLeaderboards", query)
 	if err != nil {
@@ -1599,7 +2447,7 @@ func (c *Client) ListGameCenterLeaderboards(ctx context.Context, gameCenterDetai
 	}
 
 	var resp GameCenterLeaderboardsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1614,7 +2462,7 @@ func (c *Client) GetGameCenterLeaderboard(ctx context.Context, leaderboardID str
 	}
 
 	var resp GameCenterLeaderboardResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1629,7 +2477,7 @@ func (c *Client) CreateGameCenterLeaderboard(ctx context.Context, req *GameCente
 	}
 
 	var resp GameCenterLeaderboardResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1644,7 +2492,7 @@ func (c *Client) UpdateGameCenterLeaderboard(ctx context.Context, leaderboardID
 	}
 
 	var resp GameCenterLeaderboardResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1659,11 +2507,8 @@ func (c *Client) DeleteGameCenterLeaderboard(ctx context.Context, leaderboardID
 // Xcode Cloud API methods
 
 // ListCiProducts returns Xcode Cloud products for an app.
-func (c *Client) ListCiProducts(ctx context.Context, appID string, limit int) (*CiProductsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListCiProducts(ctx context.Context, appID string, opts ListOptions) (*CiProductsResponse, error) {
+	query := opts.query()
 	if appID != "" {
 		query.Set("filter[app]", appID)
 	}
@@ -1674,7 +2519,7 @@ func (c *Client) ListCiProducts(ctx context.Context, appID string, limit int) (*
 	}
 
 	var resp CiProductsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1689,7 +2534,7 @@ func (c *Client) GetCiProduct(ctx context.Context, productID string) (*CiProduct
 	}
 
 	var resp CiProductResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1697,11 +2542,8 @@ func (c *Client) GetCiProduct(ctx context.Context, productID string) (*CiProduct
 }
 
 // ListCiWorkflows returns workflows for a product.
-func (c *Client) ListCiWorkflows(ctx context.Context, productID string, limit int) (*CiWorkflowsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListCiWorkflows(ctx context.Context, productID string, opts ListOptions) (*CiWorkflowsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/ciProducts/"+productID+"/workflows", query)
 	if err != nil {
@@ -1709,7 +2551,7 @@ func (c *Client) ListCiWorkflows(ctx context.Context, productID string, limit in
 	}
 
 	var resp CiWorkflowsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1724,7 +2566,7 @@ func (c *Client) GetCiWorkflow(ctx context.Context, workflowID string) (*CiWorkf
 	}
 
 	var resp CiWorkflowResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1732,11 +2574,8 @@ func (c *Client) GetCiWorkflow(ctx context.Context, workflowID string) (*CiWorkf
 }
 
 // ListCiBuildRuns returns build runs for a workflow.
-func (c *Client) ListCiBuildRuns(ctx context.Context, workflowID string, limit int) (*CiBuildRunsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListCiBuildRuns(ctx context.Context, workflowID string, opts ListOptions) (*CiBuildRunsResponse, error) {
+	query := opts.query()
 
 	data, err := c.Get(ctx, "/v1/ciWorkflows/"+workflowID+"/buildRuns", query)
 	if err != nil {
@@ -1744,22 +2583,41 @@ func (c *Client) ListCiBuildRuns(ctx context.Context, workflowID string, limit i
 	}
 
 	var resp CiBuildRunsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
-// GetCiBuildRun returns a single build run.
+// GetCiBuildRun returns a single build run, including its workflow and source linkage.
 func (c *Client) GetCiBuildRun(ctx context.Context, buildRunID string) (*CiBuildRunResponse, error) {
-	data, err := c.Get(ctx, "/v1/ciBuildRuns/"+buildRunID, nil)
+	query := url.Values{}
+	query.Set("include", "workflow,sourceBranchOrTag,pullRequest")
+
+	data, err := c.Get(ctx, "/v1/ciBuildRuns/"+buildRunID, query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp CiBuildRunResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// ListCiProductBuildRuns returns build runs across all of a product's
+// workflows, with each run's workflow linkage.
+func (c *Client) ListCiProductBuildRuns(ctx context.Context, productID string, opts ListOptions) (*CiBuildRunsResponse, error) {
+	data, err := c.Get(ctx, "/v1/ciProducts/"+productID+"/buildRuns", opts.WithInclude("workflow").query())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CiBuildRunsResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1788,7 +2646,22 @@ func (c *Client) StartCiBuildRun(ctx context.Context, workflowID string) (*CiBui
 	}
 
 	var resp CiBuildRunResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// CreateCiBuildRun starts a build run from a fully specified create request.
+func (c *Client) CreateCiBuildRun(ctx context.Context, req *CiBuildRunCreateRequest) (*CiBuildRunResponse, error) {
+	data, err := c.Post(ctx, "/v1/ciBuildRuns", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CiBuildRunResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1800,49 +2673,79 @@ func (c *Client) CancelCiBuildRun(ctx context.Context, buildRunID string) error
 	return c.Delete(ctx, "/v1/ciBuildRuns/"+buildRunID)
 }
 
-// Sales and Finance API methods
+// ListCiBuildActions returns the actions of a build run.
+func (c *Client) ListCiBuildActions(ctx context.Context, buildRunID string, opts ListOptions) (*CiBuildActionsResponse, error) {
+	query := opts.query()
 
-// GetSalesReport returns sales reports.
-func (c *Client) GetSalesReport(ctx context.Context, vendorNumber, reportType, reportSubType, frequency, reportDate string) ([]byte, error) {
-	query := url.Values{}
-	query.Set("filter[vendorNumber]", vendorNumber)
-	query.Set("filter[reportType]", reportType)
-	query.Set("filter[reportSubType]", reportSubType)
-	query.Set("filter[frequency]", frequency)
-	query.Set("filter[reportDate]", reportDate)
+	data, err := c.Get(ctx, "/v1/ciBuildRuns/"+buildRunID+"/actions", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CiBuildActionsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
 
-	data, err := c.Get(ctx, "/v1/salesReports", query)
+// ListCiIssues returns the issues reported by a build action.
+func (c *Client) ListCiIssues(ctx context.Context, actionID string, opts ListOptions) (*CiIssuesResponse, error) {
+	query := opts.query()
+
+	data, err := c.Get(ctx, "/v1/ciBuildActions/"+actionID+"/issues", query)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	var resp CiIssuesResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
 }
 
-// GetFinanceReport returns finance reports.
-func (c *Client) GetFinanceReport(ctx context.Context, vendorNumber, regionCode, reportType, reportDate string) ([]byte, error) {
-	query := url.Values{}
-	query.Set("filter[vendorNumber]", vendorNumber)
-	query.Set("filter[regionCode]", regionCode)
-	query.Set("filter[reportType]", reportType)
-	query.Set("filter[reportDate]", reportDate)
+// ListCiTestResults returns the test results of a build action.
+func (c *Client) ListCiTestResults(ctx context.Context, actionID string, opts ListOptions) (*CiTestResultsResponse, error) {
+	query := opts.query()
+
+	data, err := c.Get(ctx, "/v1/ciBuildActions/"+actionID+"/testResults", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CiTestResultsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
 
-	data, err := c.Get(ctx, "/v1/financeReports", query)
+// ListCiArtifacts returns the artifacts produced by a build action.
+func (c *Client) ListCiArtifacts(ctx context.Context, actionID string, opts ListOptions) (*CiArtifactsResponse, error) {
+	query := opts.query()
+
+	data, err := c.Get(ctx, "/v1/ciBuildActions/"+actionID+"/artifacts", query)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	var resp CiArtifactsResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
 }
 
 // App Encryption API methods
 
 // ListAppEncryptionDeclarations returns encryption declarations for an app.
-func (c *Client) ListAppEncryptionDeclarations(ctx context.Context, appID string, limit int) (*AppEncryptionDeclarationsResponse, error) {
-	query := url.Values{}
-	if limit > 0 {
-		query.Set("limit", fmt.Sprintf("%d", limit))
-	}
+func (c *Client) ListAppEncryptionDeclarations(ctx context.Context, appID string, opts ListOptions) (*AppEncryptionDeclarationsResponse, error) {
+	query := opts.query()
 	if appID != "" {
 		query.Set("filter[app]", appID)
 	}
@@ -1853,7 +2756,7 @@ func (c *Client) ListAppEncryptionDeclarations(ctx context.Context, appID string
 	}
 
 	var resp AppEncryptionDeclarationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1868,7 +2771,7 @@ func (c *Client) GetAppEncryptionDeclaration(ctx context.Context, declarationID
 	}
 
 	var resp AppEncryptionDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1883,7 +2786,7 @@ func (c *Client) CreateAppEncryptionDeclaration(ctx context.Context, req *AppEnc
 	}
 
 	var resp AppEncryptionDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1892,32 +2795,21 @@ func (c *Client) CreateAppEncryptionDeclaration(ctx context.Context, req *AppEnc
 
 // AssignBuildToEncryptionDeclaration assigns a build to an encryption declaration.
 func (c *Client) AssignBuildToEncryptionDeclaration(ctx context.Context, declarationID, buildID string) error {
-	body := map[string]any{
-		"data": []map[string]string{
-			{
-				"type": "builds",
-				"id":   buildID,
-			},
-		},
-	}
-
-	_, err := c.Post(ctx, "/v1/appEncryptionDeclarations/"+declarationID+"/relationships/builds", body)
-	return err
+	return c.AddRelationships(ctx, "appEncryptionDeclarations", declarationID, "builds", identifiers("builds", buildID)...)
 }
 
 // User management methods
 
 // ListUsers returns a list of users.
-func (c *Client) ListUsers(ctx context.Context, limit int) (*UsersResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListUsers(ctx context.Context, opts ListOptions) (*UsersResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/users", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp UsersResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1932,7 +2824,7 @@ func (c *Client) GetUser(ctx context.Context, userID string) (*UserResponse, err
 	}
 
 	var resp UserResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1947,7 +2839,7 @@ func (c *Client) UpdateUser(ctx context.Context, userID string, req *UserUpdateR
 	}
 
 	var resp UserResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1959,17 +2851,28 @@ func (c *Client) DeleteUser(ctx context.Context, userID string) error {
 	return c.Delete(ctx, "/v1/users/"+userID)
 }
 
+// AddUserVisibleApps gives a user without access to all apps access to more
+// apps.
+func (c *Client) AddUserVisibleApps(ctx context.Context, userID string, appIDs ...string) error {
+	return c.AddRelationships(ctx, "users", userID, "visibleApps", identifiers("apps", appIDs...)...)
+}
+
+// RemoveUserVisibleApps takes apps away from a user without access to all
+// apps.
+func (c *Client) RemoveUserVisibleApps(ctx context.Context, userID string, appIDs ...string) error {
+	return c.RemoveRelationships(ctx, "users", userID, "visibleApps", identifiers("apps", appIDs...)...)
+}
+
 // ListUserInvitations returns a list of user invitations.
-func (c *Client) ListUserInvitations(ctx context.Context, limit int) (*UserInvitationsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListUserInvitations(ctx context.Context, opts ListOptions) (*UserInvitationsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/userInvitations", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp UserInvitationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1984,7 +2887,7 @@ func (c *Client) GetUserInvitation(ctx context.Context, invitationID string) (*U
 	}
 
 	var resp UserInvitationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -1999,7 +2902,7 @@ func (c *Client) CreateUserInvitation(ctx context.Context, req *UserInvitationCr
 	}
 
 	var resp UserInvitationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2021,7 +2924,7 @@ func (c *Client) GetAppPriceSchedule(ctx context.Context, appID string) (*AppPri
 	}
 
 	var resp AppPriceScheduleResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2029,16 +2932,15 @@ func (c *Client) GetAppPriceSchedule(ctx context.Context, appID string) (*AppPri
 }
 
 // ListAppPricePoints returns price points for an app.
-func (c *Client) ListAppPricePoints(ctx context.Context, appID string, limit int) (*AppPricePointsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAppPricePoints(ctx context.Context, appID string, opts ListOptions) (*AppPricePointsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/appPricePoints", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppPricePointsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2046,16 +2948,15 @@ func (c *Client) ListAppPricePoints(ctx context.Context, appID string, limit int
 }
 
 // ListTerritories returns all territories.
-func (c *Client) ListTerritories(ctx context.Context, limit int) (*TerritoriesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListTerritories(ctx context.Context, opts ListOptions) (*TerritoriesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/territories", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp TerritoriesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2072,7 +2973,7 @@ func (c *Client) GetAppAvailability(ctx context.Context, appID string) (*AppAvai
 	}
 
 	var resp AppAvailabilityResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2087,7 +2988,7 @@ func (c *Client) CreateAppAvailability(ctx context.Context, req *AppAvailability
 	}
 
 	var resp AppAvailabilityResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2095,16 +2996,15 @@ func (c *Client) CreateAppAvailability(ctx context.Context, req *AppAvailability
 }
 
 // ListTerritoryAvailabilities returns territory availabilities.
-func (c *Client) ListTerritoryAvailabilities(ctx context.Context, appAvailabilityID string, limit int) (*TerritoryAvailabilitiesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListTerritoryAvailabilities(ctx context.Context, appAvailabilityID string, opts ListOptions) (*TerritoryAvailabilitiesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/appAvailabilities/"+appAvailabilityID+"/territoryAvailabilities", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp TerritoryAvailabilitiesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2121,7 +3021,7 @@ func (c *Client) GetAgeRatingDeclaration(ctx context.Context, appInfoID string)
 	}
 
 	var resp AgeRatingDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2136,7 +3036,7 @@ func (c *Client) UpdateAgeRatingDeclaration(ctx context.Context, declarationID s
 	}
 
 	var resp AgeRatingDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2153,7 +3053,7 @@ func (c *Client) GetIdfaDeclaration(ctx context.Context, versionID string) (*Idf
 	}
 
 	var resp IdfaDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2168,7 +3068,7 @@ func (c *Client) CreateIdfaDeclaration(ctx context.Context, req *IdfaDeclaration
 	}
 
 	var resp IdfaDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2183,7 +3083,7 @@ func (c *Client) UpdateIdfaDeclaration(ctx context.Context, declarationID string
 	}
 
 	var resp IdfaDeclarationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2205,7 +3105,7 @@ func (c *Client) GetEndUserLicenseAgreement(ctx context.Context, appID string) (
 	}
 
 	var resp EndUserLicenseAgreementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2220,7 +3120,7 @@ func (c *Client) CreateEndUserLicenseAgreement(ctx context.Context, req *EndUser
 	}
 
 	var resp EndUserLicenseAgreementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2235,7 +3135,7 @@ func (c *Client) UpdateEndUserLicenseAgreement(ctx context.Context, agreementID
 	}
 
 	var resp EndUserLicenseAgreementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2250,22 +3150,33 @@ func (c *Client) DeleteEndUserLicenseAgreement(ctx context.Context, agreementID
 // Beta App Review Submission methods
 
 // ListBetaAppReviewSubmissions returns a list of beta app review submissions.
-func (c *Client) ListBetaAppReviewSubmissions(ctx context.Context, limit int) (*BetaAppReviewSubmissionsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
-	data, err := c.Get(ctx, "/v1/betaAppReviewSubmissions", query)
+func (c *Client) ListBetaAppReviewSubmissions(ctx context.Context, opts ListOptions) (*BetaAppReviewSubmissionsResponse, error) {
+	data, err := c.Get(ctx, "/v1/betaAppReviewSubmissions", opts.query())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BetaAppReviewSubmissionsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
 
+// GetBetaAppReviewSubmissionForBuild returns the beta app review submission for a build.
+// It returns nil without an error if the build has never been submitted.
+func (c *Client) GetBetaAppReviewSubmissionForBuild(ctx context.Context, buildID string) (*BetaAppReviewSubmission, error) {
+	resp, err := c.ListBetaAppReviewSubmissions(ctx, ListOptions{Limit: 1}.WithFilter("build", buildID))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return &resp.Data[0], nil
+}
+
 // GetBetaAppReviewSubmission returns a single beta app review submission.
 func (c *Client) GetBetaAppReviewSubmission(ctx context.Context, submissionID string) (*BetaAppReviewSubmissionResponse, error) {
 	data, err := c.Get(ctx, "/v1/betaAppReviewSubmissions/"+submissionID, nil)
@@ -2274,7 +3185,7 @@ func (c *Client) GetBetaAppReviewSubmission(ctx context.Context, submissionID st
 	}
 
 	var resp BetaAppReviewSubmissionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2289,7 +3200,7 @@ func (c *Client) CreateBetaAppReviewSubmission(ctx context.Context, req *BetaApp
 	}
 
 	var resp BetaAppReviewSubmissionResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2299,16 +3210,15 @@ func (c *Client) CreateBetaAppReviewSubmission(ctx context.Context, req *BetaApp
 // Beta License Agreement methods
 
 // ListBetaLicenseAgreements returns a list of beta license agreements.
-func (c *Client) ListBetaLicenseAgreements(ctx context.Context, limit int) (*BetaLicenseAgreementsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListBetaLicenseAgreements(ctx context.Context, opts ListOptions) (*BetaLicenseAgreementsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/betaLicenseAgreements", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BetaLicenseAgreementsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2323,7 +3233,7 @@ func (c *Client) GetBetaLicenseAgreement(ctx context.Context, agreementID string
 	}
 
 	var resp BetaLicenseAgreementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2338,7 +3248,7 @@ func (c *Client) UpdateBetaLicenseAgreement(ctx context.Context, agreementID str
 	}
 
 	var resp BetaLicenseAgreementResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2348,16 +3258,15 @@ func (c *Client) UpdateBetaLicenseAgreement(ctx context.Context, agreementID str
 // Sandbox Tester methods
 
 // ListSandboxTesters returns a list of sandbox testers.
-func (c *Client) ListSandboxTesters(ctx context.Context, limit int) (*SandboxTestersResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListSandboxTesters(ctx context.Context, opts ListOptions) (*SandboxTestersResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v2/sandboxTesters", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SandboxTestersResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2372,7 +3281,7 @@ func (c *Client) CreateSandboxTester(ctx context.Context, req *SandboxTesterCrea
 	}
 
 	var resp SandboxTesterResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2387,7 +3296,7 @@ func (c *Client) UpdateSandboxTester(ctx context.Context, testerID string, req *
 	}
 
 	var resp SandboxTesterResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2402,16 +3311,15 @@ func (c *Client) DeleteSandboxTester(ctx context.Context, testerID string) error
 // Promoted Purchase methods
 
 // ListPromotedPurchases returns promoted purchases for an app.
-func (c *Client) ListPromotedPurchases(ctx context.Context, appID string, limit int) (*PromotedPurchasesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListPromotedPurchases(ctx context.Context, appID string, opts ListOptions) (*PromotedPurchasesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/promotedPurchases", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp PromotedPurchasesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2426,7 +3334,7 @@ func (c *Client) GetPromotedPurchase(ctx context.Context, promotedPurchaseID str
 	}
 
 	var resp PromotedPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2441,7 +3349,7 @@ func (c *Client) CreatePromotedPurchase(ctx context.Context, req *PromotedPurcha
 	}
 
 	var resp PromotedPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2456,7 +3364,7 @@ func (c *Client) UpdatePromotedPurchase(ctx context.Context, promotedPurchaseID
 	}
 
 	var resp PromotedPurchaseResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2471,16 +3379,15 @@ func (c *Client) DeletePromotedPurchase(ctx context.Context, promotedPurchaseID
 // Subscription Offer Code methods
 
 // ListSubscriptionOfferCodes returns offer codes for a subscription.
-func (c *Client) ListSubscriptionOfferCodes(ctx context.Context, subscriptionID string, limit int) (*SubscriptionOfferCodesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListSubscriptionOfferCodes(ctx context.Context, subscriptionID string, opts ListOptions) (*SubscriptionOfferCodesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/subscriptions/"+subscriptionID+"/offerCodes", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SubscriptionOfferCodesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2495,7 +3402,7 @@ func (c *Client) GetSubscriptionOfferCode(ctx context.Context, offerCodeID strin
 	}
 
 	var resp SubscriptionOfferCodeResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2510,7 +3417,7 @@ func (c *Client) CreateSubscriptionOfferCode(ctx context.Context, req *Subscript
 	}
 
 	var resp SubscriptionOfferCodeResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2525,7 +3432,53 @@ func (c *Client) UpdateSubscriptionOfferCode(ctx context.Context, offerCodeID st
 	}
 
 	var resp SubscriptionOfferCodeResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// ListSubscriptionOfferCodeCustomCodes returns the custom codes of an offer code.
+func (c *Client) ListSubscriptionOfferCodeCustomCodes(ctx context.Context, offerCodeID string, opts ListOptions) (*SubscriptionOfferCodeCustomCodesResponse, error) {
+	query := opts.query()
+	data, err := c.Get(ctx, "/v1/subscriptionOfferCodes/"+offerCodeID+"/customCodes", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SubscriptionOfferCodeCustomCodesResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// CreateSubscriptionOfferCodeCustomCode creates a custom code for an offer code.
+func (c *Client) CreateSubscriptionOfferCodeCustomCode(ctx context.Context, req *SubscriptionOfferCodeCustomCodeCreateRequest) (*SubscriptionOfferCodeCustomCodeResponse, error) {
+	data, err := c.Post(ctx, "/v1/subscriptionOfferCodeCustomCodes", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SubscriptionOfferCodeCustomCodeResponse
+	if err := c.decode(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateSubscriptionOfferCodeCustomCode updates a custom offer code.
+func (c *Client) UpdateSubscriptionOfferCodeCustomCode(ctx context.Context, customCodeID string, req *SubscriptionOfferCodeCustomCodeUpdateRequest) (*SubscriptionOfferCodeCustomCodeResponse, error) {
+	data, err := c.Patch(ctx, "/v1/subscriptionOfferCodeCustomCodes/"+customCodeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SubscriptionOfferCodeCustomCodeResponse
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2535,16 +3488,15 @@ func (c *Client) UpdateSubscriptionOfferCode(ctx context.Context, offerCodeID st
 // Subscription Price Point methods
 
 // ListSubscriptionPricePoints returns price points for a subscription.
-func (c *Client) ListSubscriptionPricePoints(ctx context.Context, subscriptionID string, limit int) (*SubscriptionPricePointsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListSubscriptionPricePoints(ctx context.Context, subscriptionID string, opts ListOptions) (*SubscriptionPricePointsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/subscriptions/"+subscriptionID+"/pricePoints", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SubscriptionPricePointsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2554,16 +3506,15 @@ func (c *Client) ListSubscriptionPricePoints(ctx context.Context, subscriptionID
 // Win-back Offer methods
 
 // ListWinBackOffers returns win-back offers for a subscription.
-func (c *Client) ListWinBackOffers(ctx context.Context, subscriptionID string, limit int) (*WinBackOffersResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListWinBackOffers(ctx context.Context, subscriptionID string, opts ListOptions) (*WinBackOffersResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/subscriptions/"+subscriptionID+"/winBackOffers", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp WinBackOffersResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2578,7 +3529,7 @@ func (c *Client) GetWinBackOffer(ctx context.Context, offerID string) (*WinBackO
 	}
 
 	var resp WinBackOfferResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2593,7 +3544,7 @@ func (c *Client) CreateWinBackOffer(ctx context.Context, req *WinBackOfferCreate
 	}
 
 	var resp WinBackOfferResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2608,7 +3559,7 @@ func (c *Client) UpdateWinBackOffer(ctx context.Context, offerID string, req *Wi
 	}
 
 	var resp WinBackOfferResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2623,16 +3574,15 @@ func (c *Client) DeleteWinBackOffer(ctx context.Context, offerID string) error {
 // App Store Version Experiment methods
 
 // ListAppStoreVersionExperiments returns experiments for a version.
-func (c *Client) ListAppStoreVersionExperiments(ctx context.Context, versionID string, limit int) (*AppStoreVersionExperimentsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAppStoreVersionExperiments(ctx context.Context, versionID string, opts ListOptions) (*AppStoreVersionExperimentsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/appStoreVersions/"+versionID+"/appStoreVersionExperiments", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppStoreVersionExperimentsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2647,7 +3597,7 @@ func (c *Client) GetAppStoreVersionExperiment(ctx context.Context, experimentID
 	}
 
 	var resp AppStoreVersionExperimentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2662,7 +3612,7 @@ func (c *Client) CreateAppStoreVersionExperiment(ctx context.Context, req *AppSt
 	}
 
 	var resp AppStoreVersionExperimentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2677,7 +3627,7 @@ func (c *Client) UpdateAppStoreVersionExperiment(ctx context.Context, experiment
 	}
 
 	var resp AppStoreVersionExperimentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2692,16 +3642,15 @@ func (c *Client) DeleteAppStoreVersionExperiment(ctx context.Context, experiment
 // Custom Product Page methods
 
 // ListAppCustomProductPages returns custom product pages for an app.
-func (c *Client) ListAppCustomProductPages(ctx context.Context, appID string, limit int) (*AppCustomProductPagesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAppCustomProductPages(ctx context.Context, appID string, opts ListOptions) (*AppCustomProductPagesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/appCustomProductPages", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppCustomProductPagesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2716,7 +3665,7 @@ func (c *Client) GetAppCustomProductPage(ctx context.Context, pageID string) (*A
 	}
 
 	var resp AppCustomProductPageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2731,7 +3680,7 @@ func (c *Client) CreateAppCustomProductPage(ctx context.Context, req *AppCustomP
 	}
 
 	var resp AppCustomProductPageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2746,7 +3695,7 @@ func (c *Client) UpdateAppCustomProductPage(ctx context.Context, pageID string,
 	}
 
 	var resp AppCustomProductPageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2768,7 +3717,7 @@ func (c *Client) GetRoutingAppCoverage(ctx context.Context, versionID string) (*
 	}
 
 	var resp RoutingAppCoverageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2783,7 +3732,7 @@ func (c *Client) CreateRoutingAppCoverage(ctx context.Context, req *RoutingAppCo
 	}
 
 	var resp RoutingAppCoverageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2798,7 +3747,7 @@ func (c *Client) UpdateRoutingAppCoverage(ctx context.Context, coverageID string
 	}
 
 	var resp RoutingAppCoverageResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2813,16 +3762,15 @@ func (c *Client) DeleteRoutingAppCoverage(ctx context.Context, coverageID string
 // Performance Metrics methods
 
 // ListPerfPowerMetrics returns performance and power metrics.
-func (c *Client) ListPerfPowerMetrics(ctx context.Context, appID string, limit int) (*PerfPowerMetricsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListPerfPowerMetrics(ctx context.Context, appID string, opts ListOptions) (*PerfPowerMetricsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/perfPowerMetrics", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp PerfPowerMetricsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2830,16 +3778,15 @@ func (c *Client) ListPerfPowerMetrics(ctx context.Context, appID string, limit i
 }
 
 // ListBuildPerfPowerMetrics returns performance metrics for a build.
-func (c *Client) ListBuildPerfPowerMetrics(ctx context.Context, buildID string, limit int) (*PerfPowerMetricsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListBuildPerfPowerMetrics(ctx context.Context, buildID string, opts ListOptions) (*PerfPowerMetricsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/builds/"+buildID+"/perfPowerMetrics", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp PerfPowerMetricsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2849,16 +3796,15 @@ func (c *Client) ListBuildPerfPowerMetrics(ctx context.Context, buildID string,
 // Diagnostic methods
 
 // ListDiagnosticSignatures returns diagnostic signatures.
-func (c *Client) ListDiagnosticSignatures(ctx context.Context, buildID string, limit int) (*DiagnosticSignaturesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListDiagnosticSignatures(ctx context.Context, buildID string, opts ListOptions) (*DiagnosticSignaturesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/builds/"+buildID+"/diagnosticSignatures", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp DiagnosticSignaturesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2866,16 +3812,15 @@ func (c *Client) ListDiagnosticSignatures(ctx context.Context, buildID string, l
 }
 
 // ListDiagnosticLogs returns diagnostic logs.
-func (c *Client) ListDiagnosticLogs(ctx context.Context, signatureID string, limit int) (*DiagnosticLogsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListDiagnosticLogs(ctx context.Context, signatureID string, opts ListOptions) (*DiagnosticLogsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/diagnosticSignatures/"+signatureID+"/logs", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp DiagnosticLogsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2885,16 +3830,15 @@ func (c *Client) ListDiagnosticLogs(ctx context.Context, signatureID string, lim
 // Review Attachment methods
 
 // ListAppStoreReviewAttachments returns review attachments.
-func (c *Client) ListAppStoreReviewAttachments(ctx context.Context, reviewDetailID string, limit int) (*AppStoreReviewAttachmentsResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAppStoreReviewAttachments(ctx context.Context, reviewDetailID string, opts ListOptions) (*AppStoreReviewAttachmentsResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/appStoreReviewDetails/"+reviewDetailID+"/appStoreReviewAttachments", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppStoreReviewAttachmentsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2909,7 +3853,7 @@ func (c *Client) GetAppStoreReviewAttachment(ctx context.Context, attachmentID s
 	}
 
 	var resp AppStoreReviewAttachmentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2924,7 +3868,7 @@ func (c *Client) CreateAppStoreReviewAttachment(ctx context.Context, req *AppSto
 	}
 
 	var resp AppStoreReviewAttachmentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2939,7 +3883,7 @@ func (c *Client) UpdateAppStoreReviewAttachment(ctx context.Context, attachmentI
 	}
 
 	var resp AppStoreReviewAttachmentResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2954,16 +3898,15 @@ func (c *Client) DeleteAppStoreReviewAttachment(ctx context.Context, attachmentI
 // App Category methods
 
 // ListAppCategories returns all app categories.
-func (c *Client) ListAppCategories(ctx context.Context, limit int) (*AppCategoriesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAppCategories(ctx context.Context, opts ListOptions) (*AppCategoriesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/appCategories", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AppCategoriesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2978,7 +3921,7 @@ func (c *Client) GetAppCategory(ctx context.Context, categoryID string) (*AppCat
 	}
 
 	var resp AppCategoryResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -2988,17 +3931,15 @@ func (c *Client) GetAppCategory(ctx context.Context, categoryID string) (*AppCat
 // Beta App Localization methods
 
 // ListBetaAppLocalizations returns beta app localizations.
-func (c *Client) ListBetaAppLocalizations(ctx context.Context, appID string, limit int) (*BetaAppLocalizationsResponse, error) {
-	query := url.Values{}
-	query.Set("filter[app]", appID)
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListBetaAppLocalizations(ctx context.Context, appID string, opts ListOptions) (*BetaAppLocalizationsResponse, error) {
+	query := opts.WithFilter("app", appID).query()
 	data, err := c.Get(ctx, "/v1/betaAppLocalizations", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BetaAppLocalizationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3013,7 +3954,7 @@ func (c *Client) GetBetaAppLocalization(ctx context.Context, localizationID stri
 	}
 
 	var resp BetaAppLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3028,7 +3969,7 @@ func (c *Client) CreateBetaAppLocalization(ctx context.Context, req *BetaAppLoca
 	}
 
 	var resp BetaAppLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3043,7 +3984,7 @@ func (c *Client) UpdateBetaAppLocalization(ctx context.Context, localizationID s
 	}
 
 	var resp BetaAppLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3058,17 +3999,15 @@ func (c *Client) DeleteBetaAppLocalization(ctx context.Context, localizationID s
 // Beta Build Localization methods
 
 // ListBetaBuildLocalizations returns beta build localizations.
-func (c *Client) ListBetaBuildLocalizations(ctx context.Context, buildID string, limit int) (*BetaBuildLocalizationsResponse, error) {
-	query := url.Values{}
-	query.Set("filter[build]", buildID)
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListBetaBuildLocalizations(ctx context.Context, buildID string, opts ListOptions) (*BetaBuildLocalizationsResponse, error) {
+	query := opts.WithFilter("build", buildID).query()
 	data, err := c.Get(ctx, "/v1/betaBuildLocalizations", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp BetaBuildLocalizationsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3083,7 +4022,7 @@ func (c *Client) GetBetaBuildLocalization(ctx context.Context, localizationID st
 	}
 
 	var resp BetaBuildLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3098,7 +4037,7 @@ func (c *Client) CreateBetaBuildLocalization(ctx context.Context, req *BetaBuild
 	}
 
 	var resp BetaBuildLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3113,7 +4052,7 @@ func (c *Client) UpdateBetaBuildLocalization(ctx context.Context, localizationID
 	}
 
 	var resp BetaBuildLocalizationResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3135,7 +4074,7 @@ func (c *Client) GetBuildBetaDetail(ctx context.Context, buildID string) (*Build
 	}
 
 	var resp BuildBetaDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3150,7 +4089,7 @@ func (c *Client) UpdateBuildBetaDetail(ctx context.Context, detailID string, req
 	}
 
 	var resp BuildBetaDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3160,16 +4099,15 @@ func (c *Client) UpdateBuildBetaDetail(ctx context.Context, detailID string, req
 // Alternative Distribution methods
 
 // ListAlternativeDistributionKeys returns alternative distribution keys.
-func (c *Client) ListAlternativeDistributionKeys(ctx context.Context, limit int) (*AlternativeDistributionKeysResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAlternativeDistributionKeys(ctx context.Context, opts ListOptions) (*AlternativeDistributionKeysResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/alternativeDistributionKeys", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AlternativeDistributionKeysResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3184,7 +4122,7 @@ func (c *Client) GetAlternativeDistributionKey(ctx context.Context, keyID string
 	}
 
 	var resp AlternativeDistributionKeyResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3199,7 +4137,7 @@ func (c *Client) CreateAlternativeDistributionKey(ctx context.Context, req *Alte
 	}
 
 	var resp AlternativeDistributionKeyResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3212,16 +4150,15 @@ func (c *Client) DeleteAlternativeDistributionKey(ctx context.Context, keyID str
 }
 
 // ListAlternativeDistributionPackages returns alternative distribution packages.
-func (c *Client) ListAlternativeDistributionPackages(ctx context.Context, appID string, limit int) (*AlternativeDistributionPackagesResponse, error) {
-	query := url.Values{}
-	query.Set("limit", fmt.Sprintf("%d", limit))
+func (c *Client) ListAlternativeDistributionPackages(ctx context.Context, appID string, opts ListOptions) (*AlternativeDistributionPackagesResponse, error) {
+	query := opts.query()
 	data, err := c.Get(ctx, "/v1/apps/"+appID+"/alternativeDistributionPackages", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp AlternativeDistributionPackagesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3238,7 +4175,7 @@ func (c *Client) GetMarketplaceSearchDetail(ctx context.Context, appID string) (
 	}
 
 	var resp MarketplaceSearchDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3253,7 +4190,7 @@ func (c *Client) CreateMarketplaceSearchDetail(ctx context.Context, req *Marketp
 	}
 
 	var resp MarketplaceSearchDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -3268,7 +4205,7 @@ func (c *Client) UpdateMarketplaceSearchDetail(ctx context.Context, detailID str
 	}
 
 	var resp MarketplaceSearchDetailResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decode(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
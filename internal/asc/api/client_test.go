@@ -1,16 +1,37 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 // mockTokenProvider creates a mock token provider for testing.
@@ -44,6 +65,57 @@ func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Serve
 	return client, server
 }
 
+// largeBuildsBody returns a builds list response with n builds and three
+// included resources per build, the shape of a fully populated page.
+func largeBuildsBody(n int) []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"data": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"type": "builds", "id": "build-%d", "attributes": {"version": "%d", "uploadedDate": "2024-01-01T00:00:00Z", "expirationDate": "2024-04-01T00:00:00Z", "minOsVersion": "17.0", "iconAssetToken": {"templateUrl": "https://example.com/{w}x{h}bb.{f}", "width": 1024, "height": 1024}, "processingState": "VALID"}, "relationships": {"app": {"data": {"type": "apps", "id": "1"}}}}`, i, i)
+	}
+	sb.WriteString(`], "included": [`)
+	for i := 0; i < n*3; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"type": "preReleaseVersions", "id": "prv-%d", "attributes": {"version": "1.%d", "platform": "IOS", "notes": "%s"}, "relationships": {"builds": {"links": {"self": "https://api.appstoreconnect.apple.com/v1/preReleaseVersions/prv-%d/relationships/builds"}}}}`, i, i, strings.Repeat("lorem ipsum ", 20), i)
+	}
+	sb.WriteString(`], "links": {"self": "https://api.appstoreconnect.apple.com/v1/builds", "next": "https://api.appstoreconnect.apple.com/v1/builds?cursor=abc"}}`)
+	return []byte(sb.String())
+}
+
+func TestClient_LargeResponse(t *testing.T) {
+	body := largeBuildsBody(200)
+
+	for _, chunked := range []bool{false, true} {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !chunked {
+				w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			}
+			w.Write(body)
+		})
+		client, server := newTestClient(t, handler)
+
+		resp, err := client.ListBuilds(context.Background(), "", ListOptions{Limit: 200})
+		server.Close()
+		if err != nil {
+			t.Fatalf("chunked=%v: ListBuilds failed: %v", chunked, err)
+		}
+		if len(resp.Data) != 200 || resp.Data[199].ID != "build-199" {
+			t.Errorf("chunked=%v: got %d builds", chunked, len(resp.Data))
+		}
+		if !json.Valid(resp.Included) || !bytes.HasPrefix(resp.Included, []byte(`[{"type": "preReleaseVersions"`)) {
+			t.Errorf("chunked=%v: included not kept as raw JSON", chunked)
+		}
+		if resp.Links.NextCursor() != "abc" {
+			t.Errorf("chunked=%v: NextCursor() = %q", chunked, resp.Links.NextCursor())
+		}
+	}
+}
+
 func TestClient_Get(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify method
@@ -174,6 +246,35 @@ func TestClient_Delete(t *testing.T) {
 	}
 }
 
+func TestClient_RequestID(t *testing.T) {
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "5F2A-11C0")
+		w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3500;")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"title": "Not Found", "detail": "No app"}]}`))
+	}))
+	defer server.Close()
+
+	var observed Response
+	ctx := WithResponseObserver(context.Background(), func(resp Response) {
+		observed = resp
+	})
+	_, err := client.GetApp(ctx, "missing")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if id := RequestID(err); id != "5F2A-11C0" {
+		t.Errorf("RequestID(err) = %q", id)
+	}
+	if !strings.HasSuffix(err.Error(), "(request ID 5F2A-11C0)") {
+		t.Errorf("error %q doesn't end with the request ID", err)
+	}
+	if observed.RequestID != "5F2A-11C0" || observed.StatusCode != http.StatusNotFound || observed.RateLimit == nil || observed.RateLimit.Remaining != 3500 {
+		t.Errorf("observed response = %+v", observed)
+	}
+}
+
 func TestClient_ErrorResponse(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -203,6 +304,24 @@ func TestClient_ErrorResponse(t *testing.T) {
 			body:        `{}`,
 			errContains: "API error (404)",
 		},
+		{
+			name:        "error with only a code",
+			statusCode:  http.StatusConflict,
+			body:        `{"errors": [{"code": "ENTITY_ERROR.ATTRIBUTE.INVALID", "title": null}]}`,
+			errContains: "API error (409): ENTITY_ERROR.ATTRIBUTE.INVALID",
+		},
+		{
+			name:        "empty body",
+			statusCode:  http.StatusBadGateway,
+			body:        ``,
+			errContains: "API error (502): Bad Gateway",
+		},
+		{
+			name:        "oversized HTML body",
+			statusCode:  http.StatusServiceUnavailable,
+			body:        "<html>" + strings.Repeat("x", 2*maxErrorBodyBytes) + "</html>",
+			errContains: "... (truncated)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -229,6 +348,75 @@ func TestClient_ErrorResponse(t *testing.T) {
 	}
 }
 
+func TestError_Typed(t *testing.T) {
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"errors": [
+			{"status": "409", "code": "ENTITY_ERROR.ATTRIBUTE.INVALID", "title": "An attribute value is invalid.", "detail": "The version string is taken."},
+			{"status": "409", "code": "STATE_ERROR", "title": "The version isn't editable."}
+		]}`))
+	}))
+	defer server.Close()
+
+	_, err := client.Patch(context.Background(), "/v1/appStoreVersions/1", map[string]any{})
+	wrapped := fmt.Errorf("failed to update version: %w", err)
+
+	var apiErr *Error
+	if !errors.As(wrapped, &apiErr) {
+		t.Fatalf("errors.As(%v) found no *Error", wrapped)
+	}
+	if apiErr.StatusCode != http.StatusConflict || len(apiErr.Errors) != 2 {
+		t.Errorf("Error = %+v", apiErr)
+	}
+	if got := apiErr.Codes(); !reflect.DeepEqual(got, []string{"ENTITY_ERROR.ATTRIBUTE.INVALID", "STATE_ERROR"}) {
+		t.Errorf("Codes() = %v", got)
+	}
+	if !apiErr.HasCode(CodeEntityError) || !apiErr.HasCode(CodeAttributeInvalid) || !apiErr.HasCode(CodeStateError) {
+		t.Errorf("HasCode missed a code of %v", apiErr.Codes())
+	}
+	if apiErr.HasCode(CodeNotFound) || apiErr.HasCode("ENTITY") {
+		t.Errorf("HasCode matched a code not in %v", apiErr.Codes())
+	}
+	if !IsConflict(wrapped) || IsNotFound(wrapped) || IsForbidden(wrapped) || StatusCode(wrapped) != http.StatusConflict {
+		t.Errorf("status helpers disagree with a 409: %v", wrapped)
+	}
+	if IsNotFound(errors.New("API error (404): not a typed error")) || StatusCode(nil) != 0 {
+		t.Error("status helpers matched an error that isn't an *Error")
+	}
+}
+
+func FuzzAPIError(f *testing.F) {
+	f.Add(400, []byte(`{"errors":[{"title":"Invalid","detail":"Bad value"}]}`))
+	f.Add(403, []byte(`{"errors":[null,{"code":"FORBIDDEN"}]}`))
+	f.Add(500, []byte(`{"errors":`))
+	f.Add(502, []byte("<html>\xff\xfe</html>"))
+	f.Add(404, []byte(``))
+
+	f.Fuzz(func(t *testing.T, statusCode int, body []byte) {
+		err := apiError(statusCode, body)
+		if err == nil {
+			t.Fatal("apiError returned nil")
+		}
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "API error (") {
+			t.Errorf("error %q does not start with the status", msg)
+		}
+		if !utf8.ValidString(msg) {
+			t.Errorf("error %q is not valid UTF-8", msg)
+		}
+	})
+}
+
+func FuzzPagedDocumentLinks_NextCursor(f *testing.F) {
+	f.Add("https://api.appstoreconnect.apple.com/v1/apps?cursor=abc&limit=50")
+	f.Add("%zz")
+	f.Add("?cursor=")
+
+	f.Fuzz(func(t *testing.T, next string) {
+		_ = PagedDocumentLinks{Next: next}.NextCursor()
+	})
+}
+
 func TestClient_ContextCancellation(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
@@ -275,7 +463,7 @@ func TestClient_ListApps(t *testing.T) {
 	defer server.Close()
 
 	ctx := context.Background()
-	resp, err := client.ListApps(ctx, 50)
+	resp, err := client.ListApps(ctx, ListOptions{Limit: 50})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -354,7 +542,7 @@ func TestClient_ListBuilds(t *testing.T) {
 	defer server.Close()
 
 	ctx := context.Background()
-	resp, err := client.ListBuilds(ctx, "app123", 50)
+	resp, err := client.ListBuilds(ctx, "app123", ListOptions{Limit: 50})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -364,142 +552,1991 @@ func TestClient_ListBuilds(t *testing.T) {
 	}
 }
 
-func TestClient_ListBetaGroups(t *testing.T) {
+func TestClient_ListBuildsFilters(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := BetaGroupsResponse{
-			Data: []BetaGroup{
-				{
-					Type: "betaGroups",
-					ID:   "group1",
-					Attributes: BetaGroupAttributes{
-						Name:              "External Testers",
-						PublicLinkEnabled: true,
-					},
-				},
-			},
+		want := map[string]string{
+			"filter[app]":                                     "app123",
+			"filter[preReleaseVersion.version]":               "2.4.0",
+			"filter[betaAppReviewSubmission.betaReviewState]": "WAITING_FOR_REVIEW",
+			"filter[expired]":                                 "false",
+		}
+		for key, value := range want {
+			if got := r.URL.Query().Get(key); got != value {
+				t.Errorf("%s = %q, want %q", key, got, value)
+			}
+		}
+		if r.URL.Query().Has("filter[processingState]") {
+			t.Error("unexpected filter[processingState]")
 		}
 
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(BuildsResponse{})
 	})
 
 	client, server := newTestClient(t, handler)
 	defer server.Close()
 
-	ctx := context.Background()
-	resp, err := client.ListBetaGroups(ctx, "", 50)
+	opts := ListOptions{}.
+		WithFilter("preReleaseVersion.version", "2.4.0").
+		WithFilter("betaAppReviewSubmission.betaReviewState", "WAITING_FOR_REVIEW").
+		WithFilter("processingState", "").
+		WithFilter("expired", "false")
+	_, err := client.ListBuilds(context.Background(), "app123", opts)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+}
 
-	if len(resp.Data) != 1 {
-		t.Fatalf("expected 1 beta group, got %d", len(resp.Data))
-	}
+func TestClient_UpdateBuild(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/builds/build123" {
+			t.Errorf("expected path /v1/builds/build123, got %s", r.URL.Path)
+		}
 
-	if resp.Data[0].Attributes.Name != "External Testers" {
-		t.Errorf("name = %q, want External Testers", resp.Data[0].Attributes.Name)
+		var req BuildUpdateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Data.Attributes.Expired == nil || !*req.Data.Attributes.Expired {
+			t.Error("expected expired=true")
+		}
+		if req.Data.Attributes.UsesNonExemptEncryption != nil {
+			t.Error("expected usesNonExemptEncryption to be omitted")
+		}
+
+		json.NewEncoder(w).Encode(BuildResponse{
+			Data: Build{Type: "builds", ID: "build123", Attributes: BuildAttributes{Expired: true}},
+		})
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	expired := true
+	resp, err := client.UpdateBuild(context.Background(), "build123", &BuildUpdateRequest{
+		Data: BuildUpdateData{Type: "builds", ID: "build123", Attributes: BuildUpdateAttributes{Expired: &expired}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Data.Attributes.Expired {
+		t.Error("expected build to be expired")
 	}
 }
 
-func TestClient_ListDevices(t *testing.T) {
+func TestClient_GetBetaAppReviewSubmissionForBuild(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := DevicesResponse{
-			Data: []Device{
+		if got := r.URL.Query().Get("filter[build]"); got != "build123" {
+			t.Errorf("filter[build] = %q, want %q", got, "build123")
+		}
+
+		resp := BetaAppReviewSubmissionsResponse{}
+		if r.URL.Query().Get("filter[build]") == "build123" {
+			resp.Data = []BetaAppReviewSubmission{
 				{
-					Type: "devices",
-					ID:   "device1",
-					Attributes: DeviceAttributes{
-						Name:     "iPhone 15",
-						UDID:     "00000000-0000-0000-0000-000000000001",
-						Platform: "IOS",
-						Status:   "ENABLED",
-					},
+					Type:       "betaAppReviewSubmissions",
+					ID:         "sub1",
+					Attributes: BetaAppReviewSubmissionAttributes{BetaReviewState: "APPROVED"},
 				},
-			},
+			}
 		}
-
 		json.NewEncoder(w).Encode(resp)
 	})
 
 	client, server := newTestClient(t, handler)
 	defer server.Close()
 
-	ctx := context.Background()
-	resp, err := client.ListDevices(ctx, 50)
+	sub, err := client.GetBetaAppReviewSubmissionForBuild(context.Background(), "build123")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if sub == nil {
+		t.Fatal("expected a submission, got nil")
+	}
+	if sub.Attributes.BetaReviewState != "APPROVED" {
+		t.Errorf("expected state APPROVED, got %s", sub.Attributes.BetaReviewState)
+	}
+}
 
-	if len(resp.Data) != 1 {
-		t.Fatalf("expected 1 device, got %d", len(resp.Data))
+func TestClient_WithCursor(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("cursor"); got != "Mg" {
+			t.Errorf("cursor = %q, want %q", got, "Mg")
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want %q", got, "2")
+		}
+
+		json.NewEncoder(w).Encode(AppsResponse{
+			Links: PagedDocumentLinks{Next: "https://api.appstoreconnect.apple.com/v1/apps?cursor=NA&limit=2"},
+		})
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp, err := client.ListApps(WithCursor(context.Background(), "Mg"), ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Links.NextCursor(); got != "NA" {
+		t.Errorf("NextCursor() = %q, want %q", got, "NA")
 	}
 }
 
-// Helper function
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
+func TestClient_WithDryRun(t *testing.T) {
+	var methods []string
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	ctx, dryRun := WithDryRun(context.Background())
+
+	if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+		t.Fatalf("GET under dry run failed: %v", err)
+	}
+
+	_, err := client.Post(ctx, "/v1/betaGroups", map[string]string{"name": "QA"})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("POST error = %v, want ErrDryRun", err)
+	}
+	if err := client.Delete(ctx, "/v1/betaGroups/123"); !errors.Is(err, ErrDryRun) {
+		t.Fatalf("DELETE error = %v, want ErrDryRun", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("server saw %v, want only GET", methods)
+	}
+
+	planned := dryRun.Requests()
+	if len(planned) != 2 {
+		t.Fatalf("len(planned) = %d, want 2", len(planned))
+	}
+	if planned[0].Method != http.MethodPost || planned[0].Path != "/v1/betaGroups" || planned[0].Body == nil {
+		t.Errorf("planned[0] = %+v", planned[0])
+	}
+	if planned[1].Method != http.MethodDelete || planned[1].Path != "/v1/betaGroups/123" {
+		t.Errorf("planned[1] = %+v", planned[1])
+	}
 }
 
-func containsHelper(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+func TestClient_ResponseCache(t *testing.T) {
+	requests := make(map[string]int)
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.RequestURI()]++
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+	WithResponseCache()(client)
+
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListTerritories(ctx, ListOptions{Limit: 200}); err != nil {
+			t.Fatalf("ListTerritories failed: %v", err)
+		}
+		if _, err := client.ListAppPricePoints(ctx, "123", ListOptions{Limit: 100}); err != nil {
+			t.Fatalf("ListAppPricePoints failed: %v", err)
+		}
+		if _, err := client.Get(ctx, "/v1/apps/123", nil); err != nil {
+			t.Fatalf("Get failed: %v", err)
 		}
 	}
-	return false
-}
+	if requests["GET /v1/territories?limit=200"] != 1 || requests["GET /v1/apps/123/appPricePoints?limit=100"] != 1 {
+		t.Errorf("cacheable lists requested %v, want once each", requests)
+	}
+	if requests["GET /v1/apps/123"] != 2 {
+		t.Errorf("uncached GET requested %d times, want 2", requests["GET /v1/apps/123"])
+	}
 
-// Benchmarks
+	// A different query is a different response.
+	if _, err := client.ListTerritories(ctx, ListOptions{Limit: 50}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests["GET /v1/territories?limit=50"] != 1 {
+		t.Errorf("territories with limit 50 requested %d times, want 1", requests["GET /v1/territories?limit=50"])
+	}
 
-func BenchmarkClient_Get(b *testing.B) {
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(`{"data": []}`))
-	})
+	// Refresh skips the cache and stores the new response.
+	if _, err := client.ListTerritories(WithRefresh(ctx), ListOptions{Limit: 200}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if _, err := client.ListTerritories(ctx, ListOptions{Limit: 200}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests["GET /v1/territories?limit=200"] != 2 {
+		t.Errorf("territories requested %d times after refresh, want 2", requests["GET /v1/territories?limit=200"])
+	}
 
-	server := httptest.NewServer(handler)
-	defer server.Close()
+	// Expired responses are fetched again.
+	now = now.Add(time.Hour)
+	if _, err := client.ListAppPricePoints(ctx, "123", ListOptions{Limit: 100}); err != nil {
+		t.Fatalf("ListAppPricePoints failed: %v", err)
+	}
+	if requests["GET /v1/apps/123/appPricePoints?limit=100"] != 2 {
+		t.Errorf("expired price points requested %d times, want 2", requests["GET /v1/apps/123/appPricePoints?limit=100"])
+	}
 
-	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	client := &Client{
-		httpClient:    &http.Client{Timeout: 10 * time.Second},
-		tokenProvider: &TokenProvider{issuerID: "test", keyID: "TEST", privateKey: privateKey},
-		baseURL:       server.URL,
+	// A mutating request leaves unrelated responses cached.
+	if err := client.Delete(ctx, "/v1/betaGroups/1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := client.ListTerritories(ctx, ListOptions{Limit: 200}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests["GET /v1/territories?limit=200"] != 2 {
+		t.Errorf("territories requested %d times after an unrelated mutation, want 2", requests["GET /v1/territories?limit=200"])
 	}
 
-	ctx := context.Background()
+	// It drops the responses of the resource, what's under it, and the
+	// collections it belongs to.
+	if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := client.ListAppPricePoints(ctx, "456", ListOptions{Limit: 100}); err != nil {
+		t.Fatalf("ListAppPricePoints failed: %v", err)
+	}
+	if _, err := client.Patch(ctx, "/v1/apps/123", map[string]any{}); err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if _, err := client.ListAppPricePoints(ctx, "123", ListOptions{Limit: 100}); err != nil {
+			t.Fatalf("ListAppPricePoints failed: %v", err)
+		}
+		if _, err := client.ListAppPricePoints(ctx, "456", ListOptions{Limit: 100}); err != nil {
+			t.Fatalf("ListAppPricePoints failed: %v", err)
+		}
+	}
+	if requests["GET /v1/apps"] != 2 {
+		t.Errorf("apps requested %d times around a mutation, want 2", requests["GET /v1/apps"])
+	}
+	if requests["GET /v1/apps/123/appPricePoints?limit=100"] != 3 {
+		t.Errorf("mutated app's price points requested %d times, want 3", requests["GET /v1/apps/123/appPricePoints?limit=100"])
+	}
+	if requests["GET /v1/apps/456/appPricePoints?limit=100"] != 1 {
+		t.Errorf("other app's price points requested %d times, want 1", requests["GET /v1/apps/456/appPricePoints?limit=100"])
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := client.Get(ctx, "/test", nil)
-		if err != nil {
-			b.Fatalf("request failed: %v", err)
+	// Changing a localization drops the apps list, which includes names.
+	if _, err := client.Post(ctx, "/v1/appInfoLocalizations", map[string]any{}); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if requests["GET /v1/apps"] != 3 {
+		t.Errorf("apps requested %d times after a localization change, want 3", requests["GET /v1/apps"])
+	}
+}
+
+func TestClient_ResponseCacheRevalidation(t *testing.T) {
+	var requests, notModified int
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
 		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"data": [{"type": "territories", "id": "USA"}]}`))
+	}))
+	defer server.Close()
+	WithResponseCache()(client)
+
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := client.ListTerritories(ctx, ListOptions{}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+
+	// An expired response with an ETag is revalidated, and a 304 keeps it.
+	now = now.Add(48 * time.Hour)
+	resp, err := client.ListTerritories(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "USA" {
+		t.Errorf("revalidated territories = %+v", resp.Data)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests = %d, 304s = %d, want 2 and 1", requests, notModified)
+	}
+
+	// The 304 renewed the entry.
+	if _, err := client.ListTerritories(ctx, ListOptions{}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests after renewal = %d, want 2", requests)
+	}
+
+	// Refresh revalidates too.
+	if _, err := client.ListTerritories(WithRefresh(ctx), ListOptions{}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if notModified != 2 {
+		t.Errorf("304s after refresh = %d, want 2", notModified)
 	}
 }
 
-func BenchmarkClient_Post(b *testing.B) {
+func TestClient_ResponseCacheDir(t *testing.T) {
+	var requests int
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusCreated)
-		w.Write([]byte(`{"data": {"id": "123"}}`))
+		requests++
+		if r.Header.Get("If-Modified-Since") == "Mon, 05 Oct 2026 10:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", "Mon, 05 Oct 2026 10:00:00 GMT")
+		w.Write([]byte(`{"data": [{"type": "territories", "id": "USA"}]}`))
 	})
+	dir := filepath.Join(t.TempDir(), "cache")
+	ctx := context.Background()
 
-	server := httptest.NewServer(handler)
+	client, server := newTestClient(t, handler)
 	defer server.Close()
+	WithResponseCacheDir(dir)(client)
+	if _, err := client.ListTerritories(ctx, ListOptions{}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("cache files = %v, want 1", files)
+	}
+	if info, err := os.Stat(dir); err != nil || info.Mode().Perm() != 0o700 {
+		t.Errorf("cache dir mode = %v, %v", info.Mode().Perm(), err)
+	}
 
-	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	client := &Client{
-		httpClient:    &http.Client{Timeout: 10 * time.Second},
-		tokenProvider: &TokenProvider{issuerID: "test", keyID: "TEST", privateKey: privateKey},
-		baseURL:       server.URL,
+	// A new client loads the entry and serves it without a request.
+	WithResponseCacheDir(dir)(client)
+	resp, err := client.ListTerritories(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests != 1 || len(resp.Data) != 1 {
+		t.Errorf("requests = %d, data = %+v, want the cached response", requests, resp.Data)
 	}
 
-	ctx := context.Background()
-	body := map[string]string{"name": "test"}
+	// Once expired, it is revalidated with its Last-Modified date.
+	client.cache.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	if resp, err = client.ListTerritories(ctx, ListOptions{}); err != nil {
+		t.Fatalf("ListTerritories failed: %v", err)
+	}
+	if requests != 2 || len(resp.Data) != 1 {
+		t.Errorf("requests = %d, data = %+v, want a revalidated response", requests, resp.Data)
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := client.Post(ctx, "/test", body)
-		if err != nil {
-			b.Fatalf("request failed: %v", err)
+	// Invalidation removes the file.
+	if err := client.Delete(ctx, "/v1/territories/USA"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 0 {
+		t.Errorf("cache files after invalidation = %v, want none", files)
+	}
+}
+
+func TestStaleCachePaths(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/v1/betaGroups/1", []string{"/v1/betaGroups"}},
+		{"/v1/betaGroups/1/relationships/betaTesters", []string{"/v1/betaGroups", "/v1/betaGroups/1/betaTesters", "/v1/betaTesters"}},
+		{"/v2/inAppPurchases", []string{"/v2/inAppPurchases"}},
+		{"/v1/appInfoLocalizations/9", []string{"/v1/appInfoLocalizations", "/v1/apps"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for path := range staleCachePaths(tt.path) {
+			got = append(got, path)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("staleCachePaths(%q) = %v, want %v", tt.path, got, tt.want)
 		}
 	}
 }
+
+func TestClient_Teams(t *testing.T) {
+	var keyIDs []string
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		header, _, _ := strings.Cut(token, ".")
+		data, _ := base64.RawURLEncoding.DecodeString(header)
+		var jwtHeader struct {
+			Kid string `json:"kid"`
+		}
+		json.Unmarshal(data, &jwtHeader)
+		keyIDs = append(keyIDs, jwtHeader.Kid)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	if got := client.Teams(); len(got) != 1 || got[0] != DefaultTeam {
+		t.Errorf("Teams() = %v, want [%s]", got, DefaultTeam)
+	}
+
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	client.AddTeam("default", Team{TokenProvider: client.tokenProvider})
+	client.AddTeam("acme", Team{TokenProvider: &TokenProvider{issuerID: "acme-issuer", keyID: "ACMEKEY", privateKey: privateKey}})
+
+	if got := client.Teams(); len(got) != 2 || got[0] != "acme" || got[1] != "default" {
+		t.Errorf("Teams() = %v, want [acme default]", got)
+	}
+	if client.ActiveTeam() != "default" {
+		t.Errorf("ActiveTeam() = %q, want default", client.ActiveTeam())
+	}
+
+	ctx := context.Background()
+	client.Get(ctx, "/v1/apps", nil)
+	client.Get(WithTeam(ctx, "acme"), "/v1/apps", nil)
+	if err := client.SelectTeam("acme"); err != nil {
+		t.Fatalf("SelectTeam failed: %v", err)
+	}
+	client.Get(ctx, "/v1/apps", nil)
+
+	want := []string{client.tokenProvider.keyID, "ACMEKEY", "ACMEKEY"}
+	if strings.Join(keyIDs, ",") != strings.Join(want, ",") {
+		t.Errorf("key IDs = %v, want %v", keyIDs, want)
+	}
+
+	if err := client.SelectTeam("contoso"); err == nil {
+		t.Error("expected error selecting unknown team")
+	}
+	if _, err := client.Get(WithTeam(ctx, "contoso"), "/v1/apps", nil); err == nil || !strings.Contains(err.Error(), "unknown team") {
+		t.Errorf("err = %v, want unknown team", err)
+	}
+}
+
+func TestClient_TeamBaseURL(t *testing.T) {
+	var defaultHits, stagingHits int
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultHits++
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	staging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stagingHits++
+		if r.URL.Path != "/v1/apps" {
+			t.Errorf("path = %s, want /v1/apps", r.URL.Path)
+		}
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer staging.Close()
+
+	client.AddTeam("default", Team{TokenProvider: client.tokenProvider})
+	client.AddTeam("staging", Team{TokenProvider: client.tokenProvider, BaseURL: staging.URL + "/"})
+
+	ctx := context.Background()
+	if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+		t.Fatalf("default team request failed: %v", err)
+	}
+	if _, err := client.Get(WithTeam(ctx, "staging"), "/v1/apps", nil); err != nil {
+		t.Fatalf("staging team request failed: %v", err)
+	}
+
+	if defaultHits != 1 || stagingHits != 1 {
+		t.Errorf("hits = %d default, %d staging; want 1 each", defaultHits, stagingHits)
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	client := NewClientWithTokenProvider(nil, WithBaseURL("https://asc.example.com/"))
+	if client.baseURL != "https://asc.example.com" {
+		t.Errorf("baseURL = %q, want https://asc.example.com", client.baseURL)
+	}
+
+	client = NewClientWithTokenProvider(nil, WithBaseURL(""))
+	if client.baseURL != BaseURL {
+		t.Errorf("baseURL = %q, want %q", client.baseURL, BaseURL)
+	}
+}
+
+func TestClient_WithResponseObserver(t *testing.T) {
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:42;")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": []}`))
+	}))
+	defer server.Close()
+
+	var responses []Response
+	ctx := WithResponseObserver(context.Background(), func(resp Response) {
+		responses = append(responses, resp)
+	})
+
+	client.Get(ctx, "/v1/apps/123", nil)
+
+	if len(responses) != 1 {
+		t.Fatalf("len(responses) = %d, want 1", len(responses))
+	}
+	resp := responses[0]
+	if resp.Method != http.MethodGet || resp.Path != "/v1/apps/123" || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("response = %+v", resp)
+	}
+	if resp.RateLimit == nil || resp.RateLimit.Limit != 3600 || resp.RateLimit.Remaining != 42 {
+		t.Errorf("RateLimit = %+v, want 42 of 3600", resp.RateLimit)
+	}
+
+	// A nested observer runs after the outer one instead of replacing it.
+	var order []string
+	ctx = WithResponseObserver(context.Background(), func(Response) { order = append(order, "outer") })
+	ctx = WithResponseObserver(ctx, func(Response) { order = append(order, "inner") })
+
+	client.Get(ctx, "/v1/apps/123", nil)
+
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("observers ran as %v, want [outer inner]", order)
+	}
+}
+
+func TestClient_Credentials(t *testing.T) {
+	client := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL("https://example.com/"))
+
+	creds, err := client.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials failed: %v", err)
+	}
+	if creds.Team != DefaultTeam || creds.IssuerID != "test-issuer" || creds.KeyID != "TESTKEY123" || creds.BaseURL != "https://example.com" {
+		t.Errorf("Credentials = %+v", creds)
+	}
+	if until := time.Until(creds.TokenExpiresAt); until <= 0 || until > TokenDuration {
+		t.Errorf("TokenExpiresAt = %v, want within %v", creds.TokenExpiresAt, TokenDuration)
+	}
+
+	client.AddTeam("acme", Team{TokenProvider: mockTokenProvider(t)})
+	if _, err := client.Credentials(WithTeam(context.Background(), "other")); err == nil {
+		t.Error("expected an error for an unknown team")
+	}
+	creds, err = client.Credentials(WithTeam(context.Background(), "acme"))
+	if err != nil || creds.Team != "acme" {
+		t.Errorf("Credentials(acme) = %+v, %v", creds, err)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/apps" {
+			w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3500;")
+		}
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	if status := client.RateLimit(context.Background()); status != nil {
+		t.Errorf("RateLimit() before any request = %+v, want nil", status)
+	}
+
+	client.Get(context.Background(), "/v1/apps", nil)
+	// A response without the header keeps the last known quota.
+	client.Get(context.Background(), "/v1/territories", nil)
+
+	status := client.RateLimit(context.Background())
+	if status == nil || status.Limit != 3600 || status.Remaining != 3500 || status.Used != 100 {
+		t.Fatalf("RateLimit() = %+v, want 100 of 3600 used", status)
+	}
+	if status.ObservedAt.IsZero() {
+		t.Error("ObservedAt is zero")
+	}
+
+	// Other teams have their own quota.
+	other := mockTokenProvider(t)
+	other.keyID = "OTHERKEY"
+	client.AddTeam("other", Team{TokenProvider: other, BaseURL: server.URL})
+	if status := client.RateLimit(WithTeam(context.Background(), "other")); status != nil {
+		t.Errorf("RateLimit() for another team = %+v, want nil", status)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	if rl := ParseRateLimit("user-hour-lim:3500;user-hour-rem:3499;"); rl == nil || rl.Limit != 3500 || rl.Remaining != 3499 {
+		t.Errorf("ParseRateLimit() = %+v, want 3499 of 3500", rl)
+	}
+	if rl := ParseRateLimit(""); rl != nil {
+		t.Errorf("ParseRateLimit(\"\") = %+v, want nil", rl)
+	}
+	if rl := ParseRateLimit("user-hour-lim:3500;"); rl != nil {
+		t.Errorf("ParseRateLimit() without remaining = %+v, want nil", rl)
+	}
+}
+
+func TestPagedDocumentLinks_NextCursor_LastPage(t *testing.T) {
+	links := PagedDocumentLinks{Self: "https://api.appstoreconnect.apple.com/v1/apps"}
+	if got := links.NextCursor(); got != "" {
+		t.Errorf("NextCursor() = %q, want empty", got)
+	}
+}
+
+func TestClient_ListBetaGroupBuilds(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/betaGroups/group1/builds" {
+			t.Errorf("expected path /v1/betaGroups/group1/builds, got %s", r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode(BuildsResponse{
+			Data: []Build{
+				{Type: "builds", ID: "build1", Attributes: BuildAttributes{Version: "42", ProcessingState: "VALID"}},
+			},
+		})
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp, err := client.ListBetaGroupBuilds(context.Background(), "group1", ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Attributes.Version != "42" {
+		t.Errorf("unexpected builds: %+v", resp.Data)
+	}
+}
+
+func TestClient_GetBetaGroupTesterUsages(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/betaGroups/group1/metrics/betaTesterUsages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("period"); got != "P7D" {
+			t.Errorf("period = %q, want P7D", got)
+		}
+
+		w.Write([]byte(`{"data":[{"dataPoints":{"start":"2024-01-01T00:00:00Z","end":"2024-01-08T00:00:00Z","values":{"sessionCount":12,"crashCount":1,"feedbackCount":3}}}],"links":{"self":""}}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp, err := client.GetBetaGroupTesterUsages(context.Background(), "group1", "P7D")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].DataPoints.Values.SessionCount != 12 {
+		t.Errorf("unexpected usages: %+v", resp.Data)
+	}
+}
+
+func TestClient_ListBetaGroups(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := BetaGroupsResponse{
+			Data: []BetaGroup{
+				{
+					Type: "betaGroups",
+					ID:   "group1",
+					Attributes: BetaGroupAttributes{
+						Name:              "External Testers",
+						PublicLinkEnabled: true,
+					},
+				},
+			},
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	resp, err := client.ListBetaGroups(ctx, "", ListOptions{Limit: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 beta group, got %d", len(resp.Data))
+	}
+
+	if resp.Data[0].Attributes.Name != "External Testers" {
+		t.Errorf("name = %q, want External Testers", resp.Data[0].Attributes.Name)
+	}
+}
+
+func TestClient_ListDevices(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := DevicesResponse{
+			Data: []Device{
+				{
+					Type: "devices",
+					ID:   "device1",
+					Attributes: DeviceAttributes{
+						Name:     "iPhone 15",
+						UDID:     "00000000-0000-0000-0000-000000000001",
+						Platform: "IOS",
+						Status:   "ENABLED",
+					},
+				},
+			},
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	resp, err := client.ListDevices(ctx, ListOptions{Limit: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(resp.Data))
+	}
+}
+
+func TestClient_CreateCiBuildRun(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/ciBuildRuns" {
+			t.Errorf("got %s %s, want POST /v1/ciBuildRuns", r.Method, r.URL.Path)
+		}
+
+		var req CiBuildRunCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+
+		rels := req.Data.Relationships
+		if rels.Workflow == nil || rels.Workflow.Data.ID != "wf1" {
+			t.Errorf("workflow relationship = %+v, want wf1", rels.Workflow)
+		}
+		if rels.BuildRun == nil || rels.BuildRun.Data.ID != "run1" {
+			t.Errorf("buildRun relationship = %+v, want run1", rels.BuildRun)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(CiBuildRunResponse{
+			Data: CiBuildRun{Type: "ciBuildRuns", ID: "run2", Attributes: CiBuildRunAttributes{Number: 43}},
+		})
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	req := &CiBuildRunCreateRequest{
+		Data: CiBuildRunCreateData{
+			Type: "ciBuildRuns",
+			Relationships: CiBuildRunCreateRelationships{
+				Workflow: &RelationshipData{Data: ResourceIdentifier{Type: "ciWorkflows", ID: "wf1"}},
+				BuildRun: &RelationshipData{Data: ResourceIdentifier{Type: "ciBuildRuns", ID: "run1"}},
+			},
+		},
+	}
+
+	resp, err := client.CreateCiBuildRun(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Data.ID != "run2" || resp.Data.Attributes.Number != 43 {
+		t.Errorf("got run %s #%d, want run2 #43", resp.Data.ID, resp.Data.Attributes.Number)
+	}
+}
+
+func TestClient_GetAppStoreReviewDetailWithAttachments(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/appStoreVersions/v1/appStoreReviewDetail" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include"); got != "appStoreReviewAttachments" {
+			t.Errorf("include = %q, want appStoreReviewAttachments", got)
+		}
+		if got := r.URL.Query().Get("limit[appStoreReviewAttachments]"); got != "10" {
+			t.Errorf("limit[appStoreReviewAttachments] = %q, want 10", got)
+		}
+
+		w.Write([]byte(`{
+			"data": {"type": "appStoreReviewDetails", "id": "detail1", "attributes": {"notes": "Tap twice"}},
+			"included": [
+				{"type": "appStoreReviewAttachments", "id": "att1", "attributes": {"fileName": "demo.mov", "fileSize": 2048, "sourceFileChecksum": "abc"}},
+				{"type": "appStoreVersions", "id": "v1", "attributes": {}}
+			]
+		}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp, err := client.GetAppStoreReviewDetailWithAttachments(context.Background(), "v1", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attachments, err := resp.Attachments()
+	if err != nil {
+		t.Fatalf("Attachments failed: %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].ID != "att1" || attachments[0].Attributes.FileSize != 2048 {
+		t.Errorf("attachments = %+v", attachments)
+	}
+}
+
+func TestClient_GetBuildIncluded(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include"); got != "preReleaseVersion,app,buildBundles" {
+			t.Errorf("include = %q", got)
+		}
+
+		w.Write([]byte(`{
+			"data": {"type": "builds", "id": "b1", "attributes": {"version": "42"}},
+			"included": [
+				{"type": "preReleaseVersions", "id": "p1", "attributes": {"version": "1.2", "platform": "IOS"}},
+				{"type": "apps", "id": "a1", "attributes": {"name": "Demo", "bundleId": "com.example.demo"}},
+				{"type": "buildBundles", "id": "bb1", "attributes": {"bundleId": "com.example.demo"}}
+			]
+		}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	resp, err := client.GetBuild(context.Background(), "b1", "preReleaseVersion", "app", "buildBundles")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	included, err := DecodeIncluded(resp.Included)
+	if err != nil {
+		t.Fatalf("DecodeIncluded failed: %v", err)
+	}
+	if len(included) != 3 {
+		t.Fatalf("included = %+v", included)
+	}
+	for _, resource := range included {
+		switch resource := resource.(type) {
+		case PreReleaseVersion:
+			if resource.Attributes.Version != "1.2" || resource.Attributes.Platform != "IOS" {
+				t.Errorf("preReleaseVersion = %+v", resource)
+			}
+		case App:
+			if resource.Attributes.BundleID != "com.example.demo" {
+				t.Errorf("app = %+v", resource)
+			}
+		case IncludedResource:
+			if resource.Type != "buildBundles" || !bytes.Contains(resource.Attributes, []byte("bundleId")) {
+				t.Errorf("unregistered resource = %+v", resource)
+			}
+		default:
+			t.Errorf("unexpected included %T", resource)
+		}
+	}
+
+	apps, err := IncludedOf[App](resp.Included)
+	if err != nil || len(apps) != 1 || apps[0].ID != "a1" {
+		t.Errorf("IncludedOf[App] = %+v, %v", apps, err)
+	}
+	if builds, err := IncludedOf[Build](nil); err != nil || builds != nil {
+		t.Errorf("IncludedOf[Build](nil) = %+v, %v", builds, err)
+	}
+}
+
+func TestClient_SparseFieldsets(t *testing.T) {
+	var queries []url.Values
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Write([]byte(`{"data": []}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	if _, err := client.ListBuilds(context.Background(), "a1", ListOptions{}.WithFields("builds", "version", "processingState")); err != nil {
+		t.Fatalf("ListBuilds failed: %v", err)
+	}
+	if _, err := client.ListCustomerReviews(context.Background(), "a1", ListOptions{Limit: 5}.WithFields("customerReviews", "rating")); err != nil {
+		t.Fatalf("ListCustomerReviews failed: %v", err)
+	}
+	if _, err := client.ListCustomerReviews(context.Background(), "a1", ListOptions{Limit: 5}); err != nil {
+		t.Fatalf("ListCustomerReviews failed: %v", err)
+	}
+
+	if got := queries[0].Get("fields[builds]"); got != "version,processingState" {
+		t.Errorf("fields[builds] = %q", got)
+	}
+	if got := queries[1].Get("fields[customerReviews]"); got != "rating" {
+		t.Errorf("fields[customerReviews] = %q", got)
+	}
+	if queries[2].Has("fields[customerReviews]") {
+		t.Errorf("unexpected fields in %v", queries[2])
+	}
+}
+
+func TestClient_ListOptions(t *testing.T) {
+	var query url.Values
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"data": []}`))
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	opts := ListOptions{Limit: 25}.
+		WithFilter("processingState", "VALID,PROCESSING").
+		WithFilter("expired", "").
+		WithSort("-uploadedDate").
+		WithInclude("preReleaseVersion").
+		WithFields("builds", "version", "uploadedDate")
+	if _, err := client.ListBuilds(WithCursor(context.Background(), "abc"), "a1", opts); err != nil {
+		t.Fatalf("ListBuilds failed: %v", err)
+	}
+
+	want := url.Values{
+		"filter[app]":             {"a1"},
+		"filter[processingState]": {"VALID,PROCESSING"},
+		"limit":                   {"25"},
+		"sort":                    {"-uploadedDate"},
+		"include":                 {"preReleaseVersion"},
+		"fields[builds]":          {"version,uploadedDate"},
+		"cursor":                  {"abc"},
+	}
+	if query.Encode() != want.Encode() {
+		t.Errorf("query = %s, want %s", query.Encode(), want.Encode())
+	}
+	if len(opts.Filters) != 1 {
+		t.Errorf("ListBuilds changed opts.Filters: %v", opts.Filters)
+	}
+}
+
+func TestClient_WithMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.URL.Path)
+				req.Header.Set("X-Trace", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	// fake answers requests itself, so nothing reaches the network.
+	fake := func(http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("X-Trace"); got != "inner" {
+				t.Errorf("X-Trace = %q, want inner", got)
+			}
+			if !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ") {
+				t.Error("request reached middleware without Authorization")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"type": "apps", "id": "a1", "attributes": {"name": "Weather"}}}`)),
+				Request:    req,
+			}, nil
+		})
+	}
+
+	client := NewClientWithTokenProvider(mockTokenProvider(t),
+		WithBaseURL("http://asc.invalid"),
+		WithMiddleware(record("outer")),
+		WithMiddleware(record("inner"), fake),
+	)
+
+	resp, err := client.GetApp(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if resp.Data.Attributes.Name != "Weather" {
+		t.Errorf("name = %q", resp.Data.Attributes.Name)
+	}
+	if want := []string{"outer /v1/apps/a1", "inner /v1/apps/a1"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_WithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"data": {"type": "apps", "id": "a1", "attributes": {}}}`))
+	}))
+	defer proxy.Close()
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	opts, err := TransportOptions(proxy.URL, "")
+	if err != nil {
+		t.Fatalf("TransportOptions failed: %v", err)
+	}
+	client := NewClientWithTokenProvider(mockTokenProvider(t), append([]ClientOption{
+		WithHTTPClient(httpClient),
+		WithTimeout(5 * time.Second),
+		WithBaseURL("http://asc.invalid"),
+	}, opts...)...)
+
+	if _, err := client.GetApp(context.Background(), "a1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if proxied != "http://asc.invalid/v1/apps/a1" {
+		t.Errorf("proxy got %q", proxied)
+	}
+	if client.timeouts[CategoryDefault] != 5*time.Second || client.timeouts[CategoryReport] != 5*time.Second {
+		t.Errorf("timeouts = %v, want 5s for every category", client.timeouts)
+	}
+	if httpClient.Timeout != time.Minute || httpClient.Transport != nil {
+		t.Error("options modified the client given to WithHTTPClient")
+	}
+}
+
+func TestClient_RequestTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/apps/slow" {
+			<-release
+		}
+		w.Write([]byte(`{"data": {"type": "apps", "id": "a1", "attributes": {}}}`))
+	}))
+	defer server.Close()
+	defer close(release)
+	client.retryPolicy = RetryPolicy{MaxAttempts: 1}
+	WithRequestTimeouts(map[RequestCategory]time.Duration{CategoryDefault: 20 * time.Millisecond})(client)
+
+	_, err := client.Get(context.Background(), "/v1/apps/slow", nil)
+	if err == nil || !strings.Contains(err.Error(), "default request limit of 20ms") {
+		t.Fatalf("expected a request limit error, got %v", err)
+	}
+
+	// A per-call timeout replaces the category's.
+	ctx := WithRequestTimeout(context.Background(), time.Minute)
+	if _, err := client.Get(ctx, "/v1/apps/fast", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	tests := []struct {
+		method, path string
+		want         RequestCategory
+	}{
+		{http.MethodGet, "/v1/apps", CategoryList},
+		{http.MethodGet, "/v1/apps/1/builds", CategoryList},
+		{http.MethodGet, "/v1/apps/1", CategoryDefault},
+		{http.MethodPost, "/v1/apps", CategoryDefault},
+		{http.MethodGet, "/v1/salesReports", CategoryReport},
+	}
+	for _, tt := range tests {
+		if got := requestCategory(tt.method, tt.path); got != tt.want {
+			t.Errorf("requestCategory(%s %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RetriesUnauthorizedWithNewToken(t *testing.T) {
+	var tokens []string
+	refuse := 1
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if len(tokens) <= refuse {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors": [{"status": "401", "code": "NOT_AUTHORIZED", "title": "Authentication credentials are missing or invalid."}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"type": "apps", "id": "a1", "attributes": {}}}`))
+	}))
+	defer server.Close()
+
+	if _, err := client.GetApp(context.Background(), "a1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] == tokens[1] {
+		t.Fatalf("expected one retry with a new token, got %d requests", len(tokens))
+	}
+
+	// A second 401 is returned rather than retried again.
+	tokens, refuse = nil, 2
+	_, err := client.GetApp(context.Background(), "a1")
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected a 401 error, got %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("requests = %d, want 2", len(tokens))
+	}
+}
+
+func TestClient_CoalescesConcurrentGets(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Write([]byte(`{"data": {"type": "apps", "id": "a1", "attributes": {"name": "Weather"}}}`))
+	}))
+	defer server.Close()
+
+	const callers = 5
+	var wg sync.WaitGroup
+	names := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.GetApp(context.Background(), "a1")
+			if err == nil {
+				names[i] = resp.Data.Attributes.Name
+			}
+			errs[i] = err
+		}(i)
+	}
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+	for i := range names {
+		if errs[i] != nil || names[i] != "Weather" {
+			t.Errorf("caller %d got %q, %v", i, names[i], errs[i])
+		}
+	}
+}
+
+func TestFlightGroup_LeaderCanceled(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+
+	go g.do(leaderCtx, "k", func() ([]byte, http.Header, error) {
+		close(started)
+		<-leaderCtx.Done()
+		return nil, nil, fmt.Errorf("request failed: %w", leaderCtx.Err())
+	})
+	<-started
+
+	done := make(chan struct{})
+	var body []byte
+	var err error
+	go func() {
+		defer close(done)
+		body, _, err = g.do(context.Background(), "k", func() ([]byte, http.Header, error) {
+			return []byte("own"), nil, nil
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	// A follower isn't failed by the leader giving up; it makes its own call.
+	if err != nil || string(body) != "own" {
+		t.Errorf("follower got %q, %v", body, err)
+	}
+}
+
+func TestClient_GetSalesReport(t *testing.T) {
+	const tsv = "Provider\tSKU\tUnits\nAPPLE\tcom.example\t3\n"
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(tsv))
+	gz.Close()
+
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/a-gzip" {
+			t.Errorf("Accept = %q, want application/a-gzip", got)
+		}
+		if r.URL.Path == "/v1/financeReports" {
+			w.Write([]byte(tsv))
+			return
+		}
+		w.Header().Set("Content-Type", "application/a-gzip")
+		w.Header().Set("Content-Disposition", `attachment; filename="S_D_1_20240101.txt.gz"`)
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	report, err := client.GetSalesReport(context.Background(), "1", "SALES", "SUMMARY", "DAILY", "2024-01-01")
+	if err != nil {
+		t.Fatalf("GetSalesReport failed: %v", err)
+	}
+	data, err := io.ReadAll(report.Body)
+	if err != nil {
+		t.Fatalf("reading report failed: %v", err)
+	}
+	if string(data) != tsv {
+		t.Errorf("report = %q, want %q", data, tsv)
+	}
+	if report.Filename != "S_D_1_20240101.txt.gz" || !report.Compressed || report.DownloadedBytes != compressed.Len() {
+		t.Errorf("report metadata = %q, %v, %d", report.Filename, report.Compressed, report.DownloadedBytes)
+	}
+
+	// A report sent uncompressed is returned as is.
+	report, err = client.GetFinanceReport(context.Background(), "1", "US", "FINANCIAL", "2024-01")
+	if err != nil {
+		t.Fatalf("GetFinanceReport failed: %v", err)
+	}
+	data, _ = io.ReadAll(report.Body)
+	if string(data) != tsv || report.Compressed {
+		t.Errorf("report = %q, compressed %v", data, report.Compressed)
+	}
+}
+
+func TestClient_Download(t *testing.T) {
+	body := strings.Repeat("segment,", 10000)
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("pre-signed download sent an Authorization header")
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	stream, err := client.Download(context.Background(), server.URL+"/segment")
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	defer stream.Close()
+	if stream.Size != int64(len(body)) || stream.ContentType != "application/gzip" {
+		t.Errorf("size %d, content type %q", stream.Size, stream.ContentType)
+	}
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("reading download failed: %v", err)
+	}
+	if string(data) != body || stream.BytesRead() != int64(len(body)) {
+		t.Errorf("read %d bytes, want %d", len(data), len(body))
+	}
+
+	md5Sum := md5.Sum([]byte(body))
+	sha256Sum := sha256.Sum256([]byte(body))
+	if stream.MD5() != hex.EncodeToString(md5Sum[:]) || stream.SHA256() != hex.EncodeToString(sha256Sum[:]) {
+		t.Errorf("checksums = %s, %s", stream.MD5(), stream.SHA256())
+	}
+	if err := stream.VerifyMD5(strings.ToUpper(hex.EncodeToString(md5Sum[:]))); err != nil {
+		t.Errorf("VerifyMD5 failed: %v", err)
+	}
+	if err := stream.VerifyMD5("0123"); err == nil {
+		t.Error("expected a checksum mismatch")
+	}
+
+	if _, err := client.Download(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+	if _, err := client.DownloadAll(context.Background(), server.URL+"/segment", 100); err == nil || !strings.Contains(err.Error(), "exceeds 100 bytes") {
+		t.Errorf("expected a size error, got %v", err)
+	}
+}
+
+func TestClient_BodyProgress(t *testing.T) {
+	body := strings.Repeat("x", 100000)
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var reports int
+	var last, total int64
+	ctx := WithBodyProgress(context.Background(), func(read, length int64) {
+		reports++
+		last, total = read, length
+	})
+	data, err := client.DownloadAll(ctx, server.URL+"/report", 1<<20)
+	if err != nil {
+		t.Fatalf("DownloadAll failed: %v", err)
+	}
+	if len(data) != len(body) || reports == 0 || last != int64(len(body)) || total != int64(len(body)) {
+		t.Errorf("read %d bytes, %d reports, last %d of %d", len(data), reports, last, total)
+	}
+}
+
+func TestTransportOptions_CAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TransportOptions("", caFile); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("err = %v, want no PEM certificates", err)
+	}
+	if _, err := TransportOptions("", filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+	if opts, err := TransportOptions("", ""); err != nil || len(opts) != 0 {
+		t.Errorf("TransportOptions(\"\", \"\") = %d options, %v", len(opts), err)
+	}
+}
+
+func TestClient_ConnectionPool(t *testing.T) {
+	client := NewClientWithTokenProvider(mockTokenProvider(t))
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.IdleConnTimeout != DefaultIdleConnTimeout || !transport.ForceAttemptHTTP2 {
+		t.Errorf("default transport keeps %d idle connections per host for %s, HTTP/2 %v", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout, transport.ForceAttemptHTTP2)
+	}
+
+	client = NewClientWithTokenProvider(mockTokenProvider(t), WithMaxIdleConnsPerHost(64), WithIdleConnTimeout(0), WithMaxIdleConnsPerHost(0))
+	transport = client.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != 0 {
+		t.Errorf("transport keeps %d idle connections per host for %s, want 64 for 0s", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data": {"type": "apps", "id": "1", "attributes": {"name": %q}}}`, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{NextProtos: []string{"h2", "http/1.1"}}
+	server.StartTLS()
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	for _, tt := range []struct {
+		http2 bool
+		proto string
+	}{{true, "HTTP/2.0"}, {false, "HTTP/1.1"}} {
+		client := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL), WithTLSConfig(&tls.Config{RootCAs: roots}), WithHTTP2(tt.http2))
+		resp, err := client.GetApp(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("GetApp failed: %v", err)
+		}
+		if resp.Data.Attributes.Name != tt.proto {
+			t.Errorf("WithHTTP2(%v) sent the request over %s, want %s", tt.http2, resp.Data.Attributes.Name, tt.proto)
+		}
+	}
+}
+
+func TestClient_WithBaseURLPath(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL+"/gateway/asc/"))
+	if _, err := client.ListApps(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("ListApps failed: %v", err)
+	}
+	if path != "/gateway/asc/v1/apps" {
+		t.Errorf("path = %q, want /gateway/asc/v1/apps", path)
+	}
+}
+
+func TestClient_ListAllApps(t *testing.T) {
+	var pages []string
+	var server *httptest.Server
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		pages = append(pages, cursor)
+		if got := r.URL.Query().Get("limit"); got != "200" {
+			t.Errorf("limit = %q, want 200", got)
+		}
+
+		next := map[string]string{"": "page2", "page2": "page3"}[cursor]
+		links := ""
+		if next != "" {
+			links = `, "links": {"next": "` + server.URL + `/v1/apps?cursor=` + next + `&limit=200"}`
+		}
+		fmt.Fprintf(w, `{"data": [{"type": "apps", "id": "app-%s"}]%s}`, cursor, links)
+	})
+
+	client, server := newTestClient(t, handler)
+	defer server.Close()
+
+	apps, err := client.ListAllApps(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(apps) != 3 || apps[0].ID != "app-" || apps[2].ID != "app-page3" {
+		t.Errorf("apps = %+v", apps)
+	}
+	if !reflect.DeepEqual(pages, []string{"", "page2", "page3"}) {
+		t.Errorf("requested cursors %q", pages)
+	}
+
+	// A capped listing returns what it read with ErrTooManyPages.
+	pages = nil
+	apps, err = ListAll(context.Background(), 2,
+		func(ctx context.Context) (*AppsResponse, error) { return client.ListApps(ctx, ListOptions{Limit: 200}) },
+		func(resp *AppsResponse) ([]App, PagedDocumentLinks) { return resp.Data, resp.Links },
+	)
+	if !errors.Is(err, ErrTooManyPages) {
+		t.Errorf("err = %v, want ErrTooManyPages", err)
+	}
+	if len(apps) != 2 || len(pages) != 2 {
+		t.Errorf("got %d apps from %d pages, want 2 from 2", len(apps), len(pages))
+	}
+}
+
+func TestClient_ListAllBetaTesters(t *testing.T) {
+	const total = 450
+	var mu sync.Mutex
+	var offsets []int
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		offset := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			var ok bool
+			if offset, ok = cursorOffset(cursor); !ok {
+				t.Errorf("invalid cursor %q", cursor)
+			}
+		}
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+		if r.URL.Query().Get("filter[betaGroups]") == "broken" && offset == 400 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not found"}]}`))
+			return
+		}
+
+		var data []string
+		for i := offset; i < min(offset+200, total); i++ {
+			data = append(data, fmt.Sprintf(`{"type": "betaTesters", "id": "tester-%d"}`, i))
+		}
+		links := ""
+		if offset+200 < total {
+			next := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"offset":"%d"}`, offset+200)))
+			links = `, "links": {"next": "https://api.appstoreconnect.apple.com/v1/betaTesters?cursor=` + next + `&limit=200"}`
+		}
+		fmt.Fprintf(w, `{"data": [%s]%s, "meta": {"paging": {"total": %d, "limit": 200}}}`, strings.Join(data, ","), links, total)
+	}))
+	defer server.Close()
+
+	client := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL), WithPageConcurrency(3))
+	testers, err := client.ListAllBetaTesters(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListAllBetaTesters failed: %v", err)
+	}
+	if len(testers) != total {
+		t.Fatalf("got %d testers, want %d", len(testers), total)
+	}
+	for i, tester := range testers {
+		if tester.ID != fmt.Sprintf("tester-%d", i) {
+			t.Fatalf("testers[%d] = %s, out of order", i, tester.ID)
+		}
+	}
+	sort.Ints(offsets)
+	if !reflect.DeepEqual(offsets, []int{0, 200, 400}) {
+		t.Errorf("requested offsets %v", offsets)
+	}
+	if maxInFlight.Load() != 2 {
+		t.Errorf("at most %d requests in flight, want 2", maxInFlight.Load())
+	}
+
+	// A failed page returns the pages before it with the error.
+	testers, err = client.ListAllBetaTesters(context.Background(), "broken")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("err = %v, want a 404", err)
+	}
+	if len(testers) != 400 {
+		t.Errorf("got %d testers with the error, want 400", len(testers))
+	}
+}
+
+func TestCursorAtOffset(t *testing.T) {
+	cursor, ok := cursorAtOffset("eyJvZmZzZXQiOiIyMDAifQ", 600)
+	if !ok || cursor != "eyJvZmZzZXQiOiI2MDAifQ" {
+		t.Errorf("cursorAtOffset() = %q, %v", cursor, ok)
+	}
+	if offset, ok := cursorOffset(cursor); !ok || offset != 600 {
+		t.Errorf("cursorOffset(%q) = %d, %v", cursor, offset, ok)
+	}
+	for _, cursor := range []string{"page2", "eyJwYWdlIjoyfQ", ""} {
+		if _, ok := cursorOffset(cursor); ok {
+			t.Errorf("cursorOffset(%q) reported an offset", cursor)
+		}
+	}
+}
+
+func TestClient_StrictDecoding(t *testing.T) {
+	body := `{"data": {"type": "apps", "id": "1", "attributes": {"name": "Example"}, "links": {"self": "https://example.com/v1/apps/1"}, "relationships": {"ciProduct": {"links": {"related": "https://example.com"}}}}, "links": {"self": "https://example.com/v1/apps/1"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	lenient := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL))
+	strict := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL), WithStrictDecoding())
+	ctx := context.Background()
+
+	// Links, meta and relationships without linkage are accepted.
+	if _, err := strict.GetApp(ctx, "1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		reason string
+	}{
+		{"unknown attribute", `{"data": {"type": "apps", "id": "1", "attributes": {"name": "Example", "appName": "Example"}}}`, `json: unknown field "appName"`},
+		{"unknown linkage", `{"data": {"type": "apps", "id": "1", "relationships": {"ciProduct": {"data": {"type": "ciProducts", "id": "2"}}}}}`, `json: unknown field "ciProduct"`},
+		{"wrong type", `{"data": {"type": "builds", "id": "1"}}`, `data.type is "builds", want "apps"`},
+	}
+	for _, tt := range tests {
+		body = tt.body
+		app, err := lenient.GetApp(ctx, "1")
+		if err != nil || app.Data.ID != "1" {
+			t.Errorf("%s: lenient GetApp = %+v, %v", tt.name, app, err)
+		}
+		_, err = strict.GetApp(ctx, "1")
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.Type != "AppResponse" || decodeErr.Reason != tt.reason {
+			t.Errorf("%s: strict GetApp error = %v, want %s", tt.name, err, tt.reason)
+		}
+	}
+
+	body = `{"data": [{"type": "apps", "id": "1"}, {"type": "bundleIds", "id": "2"}], "links": {"self": "https://example.com/v1/apps"}, "meta": {"paging": {"total": 2, "limit": 50}}}`
+	if _, err := strict.ListApps(ctx, ListOptions{}); err == nil || !strings.Contains(err.Error(), `data[1].type is "bundleIds", want "apps"`) {
+		t.Errorf("strict ListApps error = %v", err)
+	}
+}
+
+func TestClient_ValidatesRequests(t *testing.T) {
+	var requests int
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"type": "appStoreVersions", "id": "1"}}`))
+	}))
+	defer server.Close()
+
+	req := &AppStoreVersionCreateRequest{Data: AppStoreVersionCreateData{
+		Type:       "appStoreVersions",
+		Attributes: AppStoreVersionCreateAttributes{Platform: "iOS", ReleaseType: "SCHEDULED"},
+	}}
+	_, err := client.CreateAppStoreVersion(context.Background(), req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("CreateAppStoreVersion error = %v, want a ValidationError", err)
+	}
+	want := []string{
+		`data.attributes.platform is "iOS", must be one of IOS, MAC_OS, TV_OS or VISION_OS`,
+		"data.attributes.versionString is required",
+		"data.relationships.app is required",
+	}
+	if !slices.Equal(validationErr.Problems, want) {
+		t.Errorf("Problems = %q, want %q", validationErr.Problems, want)
+	}
+	if requests != 0 {
+		t.Errorf("server saw %d requests, want none", requests)
+	}
+
+	req.Data.Attributes.Platform = "IOS"
+	req.Data.Attributes.VersionString = "2.0"
+	req.Data.Relationships.App = RelationshipData{Data: ResourceIdentifier{Type: "apps", ID: "123"}}
+	if _, err := client.CreateAppStoreVersion(context.Background(), req); err != nil {
+		t.Fatalf("CreateAppStoreVersion failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests, want 1", requests)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    any
+		problem string
+	}{
+		{"raw body", map[string]string{"locale": "english"}, ""},
+		{"valid locale", &BetaBuildLocalizationCreateRequest{Data: BetaBuildLocalizationCreateData{
+			Attributes:    BetaBuildLocalizationCreateAttributes{Locale: "zh-Hans", WhatsNew: "Fixes"},
+			Relationships: BetaBuildLocalizationCreateRelationships{Build: RelationshipData{Data: ResourceIdentifier{Type: "builds", ID: "1"}}},
+		}}, ""},
+		{"invalid locale", &BetaBuildLocalizationCreateRequest{Data: BetaBuildLocalizationCreateData{
+			Attributes:    BetaBuildLocalizationCreateAttributes{Locale: "en_US"},
+			Relationships: BetaBuildLocalizationCreateRelationships{Build: RelationshipData{Data: ResourceIdentifier{Type: "builds", ID: "1"}}},
+		}}, `data.attributes.locale is "en_US", must be a locale code such as en-US or zh-Hans`},
+		{"too long", &AppInfoLocalizationUpdateRequest{Data: AppInfoLocalizationUpdateData{
+			Attributes: AppInfoLocalizationUpdateAttributes{Subtitle: strings.Repeat("é", 31)},
+		}}, "data.attributes.subtitle is 31 characters long, the limit is 30"},
+		{"role", &UserUpdateRequest{Data: UserUpdateData{
+			Attributes: UserUpdateAttributes{Roles: []string{"DEVELOPER", "OWNER"}},
+		}}, `data.attributes.roles is "OWNER", must be one of`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRequest(tt.body)
+			if tt.problem == "" {
+				if err != nil {
+					t.Errorf("validateRequest failed: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || len(validationErr.Problems) != 1 || !strings.HasPrefix(validationErr.Problems[0], tt.problem) {
+				t.Errorf("validateRequest error = %v, want %q", err, tt.problem)
+			}
+		})
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	if p, err := ParseEnum(" mac_os ", Platforms); err != nil || p != PlatformMacOS {
+		t.Errorf(`ParseEnum(" mac_os ") = %q, %v`, p, err)
+	}
+	if _, err := ParseEnum("ANDROID", Platforms); err == nil || err.Error() != `"ANDROID" is not one of IOS, MAC_OS, TV_OS or VISION_OS` {
+		t.Errorf("ParseEnum(ANDROID) error = %v", err)
+	}
+	if !ReleaseTypeScheduled.Valid() || ReleaseType("LATER").Valid() || BundleIDPlatform("TV_OS").Valid() {
+		t.Error("Valid accepted a value outside its enum, or rejected one in it")
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	tests := map[reflect.Type]string{
+		reflect.TypeFor[[]BetaTester]():               "betaTesters",
+		reflect.TypeFor[Territory]():                  "territories",
+		reflect.TypeFor[AlternativeDistributionKey](): "alternativeDistributionKeys",
+		reflect.TypeFor[*BundleID]():                  "bundleIds",
+		reflect.TypeFor[[]ResourceIdentifier]():       "",
+		reflect.TypeFor[json.RawMessage]():            "",
+	}
+	for typ, want := range tests {
+		if got := resourceType(typ); got != want {
+			t.Errorf("resourceType(%s) = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+// Helper function
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
+}
+
+func containsHelper(s, substr string) bool {
+	for i := 0; i <= len(s)-len(substr); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+// Benchmarks
+
+func BenchmarkClient_Get(b *testing.B) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": []}`))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	client := &Client{
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		tokenProvider: &TokenProvider{issuerID: "test", keyID: "TEST", privateKey: privateKey},
+		baseURL:       server.URL,
+	}
+
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := client.Get(ctx, "/test", nil)
+		if err != nil {
+			b.Fatalf("request failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkClient_Post(b *testing.B) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "123"}}`))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	client := &Client{
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		tokenProvider: &TokenProvider{issuerID: "test", keyID: "TEST", privateKey: privateKey},
+		baseURL:       server.URL,
+	}
+
+	ctx := context.Background()
+	body := map[string]string{"name": "test"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := client.Post(ctx, "/test", body)
+		if err != nil {
+			b.Fatalf("request failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkDecode_BuildsResponse(b *testing.B) {
+	body := largeBuildsBody(200)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		var resp BuildsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			b.Fatalf("unmarshal failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkClient_ListBuilds_Large(b *testing.B) {
+	body := largeBuildsBody(200)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	client := &Client{
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		tokenProvider: &TokenProvider{issuerID: "test", keyID: "TEST", privateKey: privateKey},
+		baseURL:       server.URL,
+	}
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.ListBuilds(ctx, "", ListOptions{Limit: 200}); err != nil {
+			b.Fatalf("request failed: %v", err)
+		}
+	}
+}
+
+func TestClient_RetriesRateLimitedRequests(t *testing.T) {
+	var requests atomic.Int32
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"x"}` {
+			t.Errorf("attempt %d body = %q", requests.Load()+1, body)
+		}
+		if requests.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+	client.rateLimitWait = time.Minute
+
+	if _, err := client.Post(context.Background(), "/v1/things", map[string]string{"name": "x"}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestClient_RateLimitWaitExceeded(t *testing.T) {
+	var requests atomic.Int32
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"errors": [{"status": "429", "title": "Rate limit exceeded", "detail": "Try again later."}]}`))
+	}))
+	defer server.Close()
+	client.rateLimitWait = time.Minute
+
+	_, err := client.Get(context.Background(), "/v1/apps", nil)
+	if err == nil || !strings.Contains(err.Error(), "API error (429)") {
+		t.Fatalf("Get() error = %v, want a 429", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, true},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"later", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for retry := 0; retry < 8; retry++ {
+		want := min(rateLimitBaseDelay<<retry, maxRateLimitDelay)
+		if got := backoff(rateLimitBaseDelay, maxRateLimitDelay, retry); got < want/2 || got > want {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", retry, got, want/2, want)
+		}
+	}
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+		StatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		nonIdem      bool
+		failures     int
+		status       int
+		wantRequests int32
+		wantErr      string
+	}{
+		{name: "GET after 503s", method: http.MethodGet, failures: 2, status: http.StatusServiceUnavailable, wantRequests: 3},
+		{name: "GET out of attempts", method: http.MethodGet, failures: 3, status: http.StatusBadGateway, wantRequests: 3, wantErr: "API error (502)"},
+		{name: "GET after a dropped connection", method: http.MethodGet, failures: 1, wantRequests: 2},
+		{name: "status not retried", method: http.MethodDelete, failures: 1, status: http.StatusInternalServerError, wantRequests: 1, wantErr: "API error (500)"},
+		{name: "POST not retried", method: http.MethodPost, failures: 1, status: http.StatusServiceUnavailable, wantRequests: 1, wantErr: "API error (503)"},
+		{name: "POST retried when allowed", method: http.MethodPost, nonIdem: true, failures: 1, status: http.StatusServiceUnavailable, wantRequests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(requests.Add(1)) > tt.failures {
+					w.Write([]byte(`{"data": {}}`))
+					return
+				}
+				if tt.status == 0 {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			policy.RetryNonIdempotent = tt.nonIdem
+			WithRetryPolicy(policy)(client)
+
+			_, err := client.Do(context.Background(), tt.method, "/v1/things", nil, nil)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Do() error = %v, want %q", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/v1/apps":                                    "/v1/apps",
+		"/v1/apps/123456789":                          "/v1/apps/{id}",
+		"/v1/apps/123456789/appStoreVersions":         "/v1/apps/{id}/appStoreVersions",
+		"/v1/betaGroups/abc-123/relationships/builds": "/v1/betaGroups/{id}/relationships/builds",
+		"/v1/builds/1f2e/metrics/betaBuildUsages":     "/v1/builds/{id}/metrics/betaBuildUsages",
+		"/v1/territories/USA":                         "/v1/territories/{id}",
+		"/v2/inAppPurchases/6450000000":               "/v2/inAppPurchases/{id}",
+	}
+	for path, want := range tests {
+		if got := EndpointTemplate(path); got != want {
+			t.Errorf("EndpointTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestClient_Relationships(t *testing.T) {
+	var got []string
+	client, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	if err := client.RemoveBetaTesterFromGroup(ctx, "group-1", "tester-1"); err != nil {
+		t.Fatalf("RemoveBetaTesterFromGroup failed: %v", err)
+	}
+	if err := client.AddBuildsToBetaGroup(ctx, "group-1", "build-1", "build-2"); err != nil {
+		t.Fatalf("AddBuildsToBetaGroup failed: %v", err)
+	}
+	if err := client.RemoveUserVisibleApps(ctx, "user-1", "app-1"); err != nil {
+		t.Fatalf("RemoveUserVisibleApps failed: %v", err)
+	}
+	if err := client.RemoveBuildsFromBetaGroup(ctx, "group-1"); err != nil {
+		t.Fatalf("RemoveBuildsFromBetaGroup without builds failed: %v", err)
+	}
+
+	want := []string{
+		`DELETE /v1/betaGroups/group-1/relationships/betaTesters {"data":[{"type":"betaTesters","id":"tester-1"}]}`,
+		`POST /v1/betaGroups/group-1/relationships/builds {"data":[{"type":"builds","id":"build-1"},{"type":"builds","id":"build-2"}]}`,
+		`DELETE /v1/users/user-1/relationships/visibleApps {"data":[{"type":"apps","id":"app-1"}]}`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.UserAgent())
+		io.WriteString(w, `{"data": {"type": "apps", "id": "1"}}`)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	client := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL))
+	if _, err := client.GetApp(ctx, "1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+
+	tagged := NewClientWithTokenProvider(mockTokenProvider(t), WithBaseURL(server.URL), WithUserAgent(UserAgent("1.2.0", "release-bot/3")))
+	if _, err := tagged.GetApp(ctx, "1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	download, err := tagged.Download(ctx, server.URL+"/report.gz")
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	download.Close()
+
+	want := []string{"asc-mcp", "asc-mcp/1.2.0 release-bot/3", "asc-mcp/1.2.0 release-bot/3"}
+	if !slices.Equal(got, want) {
+		t.Errorf("User-Agents = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,723 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// specPath is Apple's App Store Connect OpenAPI document, relative to this package.
+const specPath = "../../../doc/references/apple-asc/openapi.oas.json"
+
+var (
+	specOnce sync.Once
+	spec     map[string]any
+	specErr  error
+)
+
+// loadSpec parses the OpenAPI document once per test binary.
+func loadSpec(t *testing.T) map[string]any {
+	t.Helper()
+
+	specOnce.Do(func() {
+		var data []byte
+		data, specErr = os.ReadFile(specPath)
+		if specErr == nil {
+			specErr = json.Unmarshal(data, &spec)
+		}
+	})
+	if specErr != nil {
+		t.Fatalf("failed to load OpenAPI spec: %v", specErr)
+	}
+
+	return spec
+}
+
+// contractCase binds a request type to the endpoint it is sent to.
+type contractCase struct {
+	method   string
+	path     string
+	request  any
+	response any
+	// skip explains why an endpoint cannot be checked against the spec.
+	skip string
+}
+
+// contractCases lists every create and update request type in types.go.
+// Paths use the spec's {id} placeholder for resource identifiers.
+var contractCases = []contractCase{
+	{method: http.MethodPatch, path: "/v1/builds/{id}", request: &BuildUpdateRequest{}, response: &BuildResponse{}},
+	{method: http.MethodPost, path: "/v1/betaGroups", request: &BetaGroupCreateRequest{}, response: &BetaGroupResponse{}},
+	{method: http.MethodPost, path: "/v1/betaTesters", request: &BetaTesterCreateRequest{}, response: &BetaTesterResponse{}},
+	{method: http.MethodPost, path: "/v1/devices", request: &DeviceCreateRequest{}, response: &DeviceResponse{}},
+	{method: http.MethodPost, path: "/v1/appInfoLocalizations", request: &AppInfoLocalizationCreateRequest{}, response: &AppInfoLocalizationResponse{}},
+	{method: http.MethodPatch, path: "/v1/appInfoLocalizations/{id}", request: &AppInfoLocalizationUpdateRequest{}, response: &AppInfoLocalizationResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreVersionLocalizations", request: &AppStoreVersionLocalizationCreateRequest{}, response: &AppStoreVersionLocalizationResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreVersionLocalizations/{id}", request: &AppStoreVersionLocalizationUpdateRequest{}, response: &AppStoreVersionLocalizationResponse{}},
+	{method: http.MethodPost, path: "/v1/customerReviewResponses", request: &CustomerReviewResponseCreateRequest{}, response: &CustomerReviewResponseV1Response{}},
+	{method: http.MethodPost, path: "/v2/inAppPurchases", request: &InAppPurchaseCreateRequest{}, response: &InAppPurchaseResponse{}},
+	{method: http.MethodPatch, path: "/v2/inAppPurchases/{id}", request: &InAppPurchaseUpdateRequest{}, response: &InAppPurchaseResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreVersionSubmissions", request: &AppStoreVersionSubmissionCreateRequest{}, skip: "endpoint removed from the spec in favour of reviewSubmissions"},
+	{method: http.MethodPost, path: "/v1/appStoreVersions", request: &AppStoreVersionCreateRequest{}, response: &AppStoreVersionResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreVersions/{id}", request: &AppStoreVersionUpdateRequest{}, response: &AppStoreVersionResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreReviewDetails", request: &AppStoreReviewDetailCreateRequest{}, response: &AppStoreReviewDetailResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreReviewDetails/{id}", request: &AppStoreReviewDetailUpdateRequest{}, response: &AppStoreReviewDetailResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreVersionPhasedReleases", request: &AppStoreVersionPhasedReleaseCreateRequest{}, response: &AppStoreVersionPhasedReleaseResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreVersionPhasedReleases/{id}", request: &AppStoreVersionPhasedReleaseUpdateRequest{}, response: &AppStoreVersionPhasedReleaseResponse{}},
+	{method: http.MethodPost, path: "/v1/appScreenshots", request: &AppScreenshotCreateRequest{}, response: &AppScreenshotResponse{}},
+	{method: http.MethodPatch, path: "/v1/appScreenshots/{id}", request: &AppScreenshotUpdateRequest{}, response: &AppScreenshotResponse{}},
+	{method: http.MethodPost, path: "/v1/appPreviews", request: &AppPreviewCreateRequest{}, response: &AppPreviewResponse{}},
+	{method: http.MethodPatch, path: "/v1/appPreviews/{id}", request: &AppPreviewUpdateRequest{}, response: &AppPreviewResponse{}},
+	{method: http.MethodPost, path: "/v1/appPreOrders", request: &AppPreOrderCreateRequest{}, skip: "endpoint removed from the spec in favour of appAvailabilities v2"},
+	{method: http.MethodPatch, path: "/v1/appPreOrders/{id}", request: &AppPreOrderUpdateRequest{}, skip: "endpoint removed from the spec in favour of appAvailabilities v2"},
+	{method: http.MethodPost, path: "/v1/appEvents", request: &AppEventCreateRequest{}, response: &AppEventResponse{}},
+	{method: http.MethodPatch, path: "/v1/appEvents/{id}", request: &AppEventUpdateRequest{}, response: &AppEventResponse{}},
+	{method: http.MethodPost, path: "/v1/reviewSubmissions", request: &ReviewSubmissionCreateRequest{}, response: &ReviewSubmissionResponse{}},
+	{method: http.MethodPatch, path: "/v1/reviewSubmissions/{id}", request: &ReviewSubmissionUpdateRequest{}, response: &ReviewSubmissionResponse{}},
+	{method: http.MethodPost, path: "/v1/reviewSubmissionItems", request: &ReviewSubmissionItemCreateRequest{}, response: &ReviewSubmissionItemResponse{}},
+	{method: http.MethodPost, path: "/v1/analyticsReportRequests", request: &AnalyticsReportRequestCreateRequest{}, response: &AnalyticsReportRequestResponse{}},
+	{method: http.MethodPost, path: "/v1/gameCenterAchievements", request: &GameCenterAchievementCreateRequest{}, response: &GameCenterAchievementResponse{}},
+	{method: http.MethodPatch, path: "/v1/gameCenterAchievements/{id}", request: &GameCenterAchievementUpdateRequest{}, response: &GameCenterAchievementResponse{}},
+	{method: http.MethodPost, path: "/v1/gameCenterLeaderboards", request: &GameCenterLeaderboardCreateRequest{}, response: &GameCenterLeaderboardResponse{}},
+	{method: http.MethodPatch, path: "/v1/gameCenterLeaderboards/{id}", request: &GameCenterLeaderboardUpdateRequest{}, response: &GameCenterLeaderboardResponse{}},
+	{method: http.MethodPost, path: "/v1/ciBuildRuns", request: &CiBuildRunCreateRequest{}, response: &CiBuildRunResponse{}},
+	{method: http.MethodPost, path: "/v1/appEncryptionDeclarations", request: &AppEncryptionDeclarationCreateRequest{}, response: &AppEncryptionDeclarationResponse{}},
+	{method: http.MethodPatch, path: "/v1/users/{id}", request: &UserUpdateRequest{}, response: &UserResponse{}},
+	{method: http.MethodPost, path: "/v1/userInvitations", request: &UserInvitationCreateRequest{}, response: &UserInvitationResponse{}},
+	{method: http.MethodPost, path: "/v1/appAvailabilities", request: &AppAvailabilityCreateRequest{}, skip: "endpoint removed from the spec in favour of appAvailabilities v2"},
+	{method: http.MethodPatch, path: "/v1/ageRatingDeclarations/{id}", request: &AgeRatingDeclarationUpdateRequest{}, response: &AgeRatingDeclarationResponse{}},
+	{method: http.MethodPost, path: "/v1/idfaDeclarations", request: &IdfaDeclarationCreateRequest{}, skip: "endpoint removed from the spec"},
+	{method: http.MethodPatch, path: "/v1/idfaDeclarations/{id}", request: &IdfaDeclarationUpdateRequest{}, skip: "endpoint removed from the spec"},
+	{method: http.MethodPost, path: "/v1/endUserLicenseAgreements", request: &EndUserLicenseAgreementCreateRequest{}, response: &EndUserLicenseAgreementResponse{}},
+	{method: http.MethodPatch, path: "/v1/endUserLicenseAgreements/{id}", request: &EndUserLicenseAgreementUpdateRequest{}, response: &EndUserLicenseAgreementResponse{}},
+	{method: http.MethodPost, path: "/v1/betaAppReviewSubmissions", request: &BetaAppReviewSubmissionCreateRequest{}, response: &BetaAppReviewSubmissionResponse{}},
+	{method: http.MethodPatch, path: "/v1/betaLicenseAgreements/{id}", request: &BetaLicenseAgreementUpdateRequest{}, response: &BetaLicenseAgreementResponse{}},
+	{method: http.MethodPost, path: "/v2/sandboxTesters", request: &SandboxTesterCreateRequest{}, skip: "sandbox testers can no longer be created through the API"},
+	{method: http.MethodPatch, path: "/v2/sandboxTesters/{id}", request: &SandboxTesterUpdateRequest{}, response: &SandboxTesterResponse{}},
+	{method: http.MethodPost, path: "/v1/promotedPurchases", request: &PromotedPurchaseCreateRequest{}, response: &PromotedPurchaseResponse{}},
+	{method: http.MethodPatch, path: "/v1/promotedPurchases/{id}", request: &PromotedPurchaseUpdateRequest{}, response: &PromotedPurchaseResponse{}},
+	{method: http.MethodPost, path: "/v1/subscriptionOfferCodes", request: &SubscriptionOfferCodeCreateRequest{}, response: &SubscriptionOfferCodeResponse{}},
+	{method: http.MethodPatch, path: "/v1/subscriptionOfferCodes/{id}", request: &SubscriptionOfferCodeUpdateRequest{}, response: &SubscriptionOfferCodeResponse{}},
+	{method: http.MethodPost, path: "/v1/subscriptionOfferCodeCustomCodes", request: &SubscriptionOfferCodeCustomCodeCreateRequest{}, response: &SubscriptionOfferCodeCustomCodeResponse{}},
+	{method: http.MethodPatch, path: "/v1/subscriptionOfferCodeCustomCodes/{id}", request: &SubscriptionOfferCodeCustomCodeUpdateRequest{}, response: &SubscriptionOfferCodeCustomCodeResponse{}},
+	{method: http.MethodPost, path: "/v1/winBackOffers", request: &WinBackOfferCreateRequest{}, response: &WinBackOfferResponse{}},
+	{method: http.MethodPatch, path: "/v1/winBackOffers/{id}", request: &WinBackOfferUpdateRequest{}, response: &WinBackOfferResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreVersionExperiments", request: &AppStoreVersionExperimentCreateRequest{}, response: &AppStoreVersionExperimentResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreVersionExperiments/{id}", request: &AppStoreVersionExperimentUpdateRequest{}, response: &AppStoreVersionExperimentResponse{}},
+	{method: http.MethodPost, path: "/v1/appCustomProductPages", request: &AppCustomProductPageCreateRequest{}, response: &AppCustomProductPageResponse{}},
+	{method: http.MethodPatch, path: "/v1/appCustomProductPages/{id}", request: &AppCustomProductPageUpdateRequest{}, response: &AppCustomProductPageResponse{}},
+	{method: http.MethodPost, path: "/v1/routingAppCoverages", request: &RoutingAppCoverageCreateRequest{}, response: &RoutingAppCoverageResponse{}},
+	{method: http.MethodPatch, path: "/v1/routingAppCoverages/{id}", request: &RoutingAppCoverageUpdateRequest{}, response: &RoutingAppCoverageResponse{}},
+	{method: http.MethodPost, path: "/v1/appStoreReviewAttachments", request: &AppStoreReviewAttachmentCreateRequest{}, response: &AppStoreReviewAttachmentResponse{}},
+	{method: http.MethodPatch, path: "/v1/appStoreReviewAttachments/{id}", request: &AppStoreReviewAttachmentUpdateRequest{}, response: &AppStoreReviewAttachmentResponse{}},
+	{method: http.MethodPost, path: "/v1/betaAppLocalizations", request: &BetaAppLocalizationCreateRequest{}, response: &BetaAppLocalizationResponse{}},
+	{method: http.MethodPatch, path: "/v1/betaAppLocalizations/{id}", request: &BetaAppLocalizationUpdateRequest{}, response: &BetaAppLocalizationResponse{}},
+	{method: http.MethodPost, path: "/v1/betaBuildLocalizations", request: &BetaBuildLocalizationCreateRequest{}, response: &BetaBuildLocalizationResponse{}},
+	{method: http.MethodPatch, path: "/v1/betaBuildLocalizations/{id}", request: &BetaBuildLocalizationUpdateRequest{}, response: &BetaBuildLocalizationResponse{}},
+	{method: http.MethodPatch, path: "/v1/buildBetaDetails/{id}", request: &BuildBetaDetailUpdateRequest{}, response: &BuildBetaDetailResponse{}},
+	{method: http.MethodPost, path: "/v1/alternativeDistributionKeys", request: &AlternativeDistributionKeyCreateRequest{}, response: &AlternativeDistributionKeyResponse{}},
+	{method: http.MethodPost, path: "/v1/marketplaceSearchDetails", request: &MarketplaceSearchDetailCreateRequest{}, response: &MarketplaceSearchDetailResponse{}},
+	{method: http.MethodPatch, path: "/v1/marketplaceSearchDetails/{id}", request: &MarketplaceSearchDetailUpdateRequest{}, response: &MarketplaceSearchDetailResponse{}},
+}
+
+// TestContract_RequestTypes checks that every create and update request
+// serializes to a body Apple's schema accepts: known property names, matching
+// JSON types, enum values and required fields that survive omitempty.
+func TestContract_RequestTypes(t *testing.T) {
+	doc := loadSpec(t)
+
+	for _, tc := range contractCases {
+		name := reflect.TypeOf(tc.request).Elem().Name()
+		t.Run(name, func(t *testing.T) {
+			if tc.skip != "" {
+				t.Skipf("%s %s: %s", tc.method, tc.path, tc.skip)
+			}
+
+			op := specOperation(t, doc, tc.method, tc.path)
+			schema := resolveRef(doc, dig(op, "requestBody", "content", "application/json", "schema"))
+			if schema == nil {
+				t.Fatalf("%s %s has no JSON request body in the spec", tc.method, tc.path)
+			}
+
+			v := &schemaValidator{doc: doc, strict: true}
+			v.fill(reflect.ValueOf(tc.request).Elem(), schema, "$")
+			v.validate(marshalGeneric(t, tc.request), schema, "$")
+
+			// A zero value must still carry every required property, so a
+			// required field tagged omitempty is reported here.
+			zero := reflect.New(reflect.TypeOf(tc.request).Elem()).Interface()
+			v.validateRequired(marshalGeneric(t, zero), schema, "$")
+
+			v.report(t)
+		})
+	}
+}
+
+// TestContract_ResponseTypes checks that the fields decoded from each
+// endpoint's success response exist in Apple's schema with the same JSON type,
+// so a misspelled tag does not silently decode to a zero value.
+func TestContract_ResponseTypes(t *testing.T) {
+	doc := loadSpec(t)
+
+	for _, tc := range contractCases {
+		if tc.skip != "" || tc.response == nil {
+			continue
+		}
+		name := reflect.TypeOf(tc.response).Elem().Name()
+		t.Run(tc.method+" "+name, func(t *testing.T) {
+			op := specOperation(t, doc, tc.method, tc.path)
+			var schema map[string]any
+			for _, status := range []string{"200", "201"} {
+				if s := resolveRef(doc, dig(op, "responses", status, "content", "application/json", "schema")); s != nil {
+					schema = s
+					break
+				}
+			}
+			if schema == nil {
+				t.Fatalf("%s %s has no JSON success response in the spec", tc.method, tc.path)
+			}
+
+			v := &schemaValidator{doc: doc}
+			resp := reflect.New(reflect.TypeOf(tc.response).Elem())
+			v.fill(resp.Elem(), schema, "$")
+			v.validate(marshalGeneric(t, resp.Interface()), schema, "$")
+			v.report(t)
+		})
+	}
+}
+
+// TestContract_ResourceTypes checks that strict decoding expects the
+// resource type each endpoint's success response holds in the spec.
+func TestContract_ResourceTypes(t *testing.T) {
+	doc := loadSpec(t)
+
+	for _, tc := range contractCases {
+		if tc.skip != "" || tc.response == nil {
+			continue
+		}
+		respType := reflect.TypeOf(tc.response).Elem()
+		field, ok := respType.FieldByName("Data")
+		if !ok {
+			continue
+		}
+		op := specOperation(t, doc, tc.method, tc.path)
+		var schema map[string]any
+		for _, status := range []string{"200", "201"} {
+			if s := resolveRef(doc, dig(op, "responses", status, "content", "application/json", "schema")); s != nil {
+				schema = s
+				break
+			}
+		}
+		data := resolveRef(doc, dig(schema, "properties", "data"))
+		enum, _ := dig(data, "properties", "type", "enum").([]any)
+		if len(enum) != 1 {
+			continue
+		}
+		if got := resourceType(field.Type); got != enum[0] {
+			t.Errorf("%s %s: resourceType(%s) = %q, spec says %q", tc.method, tc.path, field.Type, got, enum[0])
+		}
+	}
+}
+
+// TestContract_RequestValidation checks that the validate tags of request
+// types require what the spec requires and allow the values its enums list.
+func TestContract_RequestValidation(t *testing.T) {
+	doc := loadSpec(t)
+
+	for _, tc := range contractCases {
+		if tc.skip != "" {
+			continue
+		}
+		op := specOperation(t, doc, tc.method, tc.path)
+		schema := resolveRef(doc, dig(op, "requestBody", "content", "application/json", "schema"))
+		data := resolveRef(doc, dig(schema, "properties", "data"))
+		dataType, _ := reflect.TypeOf(tc.request).Elem().FieldByName("Data")
+
+		for _, member := range []string{"attributes", "relationships"} {
+			specSchema := resolveRef(doc, dig(data, "properties", member))
+			name := jsonFieldName(dataType.Type, member)
+			if specSchema == nil || name == "" {
+				continue
+			}
+			field, _ := dataType.Type.FieldByName(name)
+			goType := field.Type
+			for goType.Kind() == reflect.Pointer {
+				goType = goType.Elem()
+			}
+
+			required, _ := specSchema["required"].([]any)
+			properties, _ := specSchema["properties"].(map[string]any)
+			for prop, propSchema := range properties {
+				name := jsonFieldName(goType, prop)
+				if name == "" {
+					continue
+				}
+				goField, _ := goType.FieldByName(name)
+				rules := strings.Split(goField.Tag.Get("validate"), ",")
+				where := fmt.Sprintf("%s %s: %s.%s", tc.method, tc.path, goType.Name(), name)
+
+				if slices.Contains(required, any(prop)) && goField.Type.Kind() != reflect.Bool && !slices.Contains(rules, "required") {
+					t.Errorf("%s is required by the spec but not tagged validate:\"required\"", where)
+				}
+
+				propSchema := resolveRef(doc, propSchema)
+				enum, _ := propSchema["enum"].([]any)
+				if items := resolveRef(doc, propSchema["items"]); enum == nil && items != nil {
+					enum, _ = items["enum"].([]any)
+				}
+				if enum == nil || slices.Contains(rules, "territory") {
+					continue
+				}
+				var allowed []string
+				for _, rule := range rules {
+					if set, ok := strings.CutPrefix(rule, "enum="); ok {
+						allowed = requestEnums[set]
+					}
+				}
+				var want []string
+				for _, v := range enum {
+					want = append(want, fmt.Sprint(v))
+				}
+				slices.Sort(want)
+				if got := slices.Sorted(slices.Values(allowed)); !slices.Equal(got, want) {
+					t.Errorf("%s allows %v, spec allows %v", where, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestContract_Enums checks that the typed enums in enums.go list the values
+// of the spec's enums, in the spec's order.
+func TestContract_Enums(t *testing.T) {
+	doc := loadSpec(t)
+
+	tests := []struct {
+		schema string // path under components/schemas
+		values []string
+	}{
+		{"Platform", EnumStrings(Platforms)},
+		{"BundleIdPlatform", EnumStrings(BundleIDPlatforms)},
+		{"Build/properties/attributes/properties/processingState", EnumStrings(ProcessingStates)},
+		{"AppStoreVersionState", EnumStrings(AppStoreStates)},
+		{"AppStoreVersion/properties/attributes/properties/releaseType", EnumStrings(ReleaseTypes)},
+		{"Profile/properties/attributes/properties/profileType", EnumStrings(ProfileTypes)},
+		{"Profile/properties/attributes/properties/profileState", EnumStrings(ProfileStates)},
+		{"CertificateType", EnumStrings(CertificateTypes)},
+		{"Device/properties/attributes/properties/status", EnumStrings(DeviceStatuses)},
+		{"Device/properties/attributes/properties/deviceClass", EnumStrings(DeviceClasses)},
+		{"InAppPurchaseType", EnumStrings(InAppPurchaseTypes)},
+		{"PhasedReleaseState", EnumStrings(PhasedReleaseStates)},
+		{"BetaReviewState", EnumStrings(BetaReviewStates)},
+	}
+	for _, tt := range tests {
+		keys := append([]string{"components", "schemas"}, strings.Split(tt.schema, "/")...)
+		enum, _ := resolveRef(doc, dig(doc, keys...))["enum"].([]any)
+		var want []string
+		for _, v := range enum {
+			want = append(want, fmt.Sprint(v))
+		}
+		if !slices.Equal(tt.values, want) {
+			t.Errorf("%s: got %v, spec lists %v", tt.schema, tt.values, want)
+		}
+	}
+}
+
+// TestContract_CoversAllRequestTypes fails when a request type is added to
+// types.go without a contract case.
+func TestContract_CoversAllRequestTypes(t *testing.T) {
+	src, err := os.ReadFile("types.go")
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+
+	covered := make(map[string]bool)
+	for _, tc := range contractCases {
+		covered[reflect.TypeOf(tc.request).Elem().Name()] = true
+	}
+
+	for _, line := range strings.Split(string(src), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] != "type" || fields[2] != "struct" {
+			continue
+		}
+		name := fields[1]
+		if !strings.HasSuffix(name, "CreateRequest") && !strings.HasSuffix(name, "UpdateRequest") {
+			continue
+		}
+		if !covered[name] {
+			t.Errorf("%s has no entry in contractCases", name)
+		}
+	}
+}
+
+// specOperation returns the operation object for method and path.
+func specOperation(t *testing.T, doc map[string]any, method, path string) map[string]any {
+	t.Helper()
+
+	op, _ := dig(doc, "paths", path, strings.ToLower(method)).(map[string]any)
+	if op == nil {
+		t.Fatalf("%s %s is not in the spec", method, path)
+	}
+	return op
+}
+
+// dig walks nested JSON objects by key.
+func dig(node any, keys ...string) any {
+	for _, key := range keys {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = m[key]
+	}
+	return node
+}
+
+// resolveRef follows local $ref pointers until it reaches a concrete schema.
+func resolveRef(doc map[string]any, node any) map[string]any {
+	schema, _ := node.(map[string]any)
+	for schema != nil {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		keys := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+		schema, _ = dig(doc, keys...).(map[string]any)
+	}
+	return nil
+}
+
+// marshalGeneric round-trips v through JSON into generic values.
+func marshalGeneric(t *testing.T, v any) any {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %T: %v", v, err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to unmarshal %T: %v", v, err)
+	}
+	return out
+}
+
+// schemaValidator fills Go values from a schema and checks JSON against it.
+// Strict mode additionally enforces required properties and enum values,
+// which only apply to request bodies we build ourselves.
+type schemaValidator struct {
+	doc    map[string]any
+	strict bool
+	errs   []string
+}
+
+func (v *schemaValidator) errorf(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) report(t *testing.T) {
+	t.Helper()
+
+	sort.Strings(v.errs)
+	for _, err := range v.errs {
+		t.Error(err)
+	}
+}
+
+// variants returns the alternatives of a oneOf/anyOf schema, or the schema itself.
+func (v *schemaValidator) variants(schema map[string]any) []map[string]any {
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if list, ok := schema[key].([]any); ok {
+			out := make([]map[string]any, 0, len(list))
+			for _, item := range list {
+				if s := resolveRef(v.doc, item); s != nil {
+					out = append(out, s)
+				}
+			}
+			return out
+		}
+	}
+	return []map[string]any{schema}
+}
+
+// properties merges the properties of a schema and its allOf members.
+func (v *schemaValidator) properties(schema map[string]any) map[string]any {
+	props := make(map[string]any)
+	if p, ok := schema["properties"].(map[string]any); ok {
+		for name, prop := range p {
+			props[name] = prop
+		}
+	}
+	if list, ok := schema["allOf"].([]any); ok {
+		for _, item := range list {
+			if s := resolveRef(v.doc, item); s != nil {
+				for name, prop := range v.properties(s) {
+					props[name] = prop
+				}
+			}
+		}
+	}
+	return props
+}
+
+// fill populates every exported field of rv with a value the schema allows,
+// so omitempty does not hide fields from validation. Fields whose JSON name
+// is not in the schema are reported.
+func (v *schemaValidator) fill(rv reflect.Value, schema map[string]any, path string) {
+	if schema == nil {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.Type().Elem() == reflect.TypeOf(json.RawMessage{}) {
+			return
+		}
+		rv.Set(reflect.New(rv.Type().Elem()))
+		v.fill(rv.Elem(), schema, path)
+	case reflect.Struct:
+		if rv.Type() == reflect.TypeOf(time.Time{}) {
+			rv.Set(reflect.ValueOf(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+			return
+		}
+		v.fillStruct(rv, schema, path)
+	case reflect.Slice:
+		if rv.Type() == reflect.TypeOf(json.RawMessage{}) {
+			return
+		}
+		items := resolveRef(v.doc, v.variants(schema)[0]["items"])
+		elem := reflect.New(rv.Type().Elem()).Elem()
+		v.fill(elem, items, path+"[0]")
+		rv.Set(reflect.Append(reflect.MakeSlice(rv.Type(), 0, 1), elem))
+	case reflect.String:
+		s := v.variants(schema)[0]
+		if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+			if str, ok := enum[0].(string); ok {
+				rv.SetString(str)
+				return
+			}
+		}
+		switch s["format"] {
+		case "date":
+			rv.SetString("2024-01-02")
+		case "date-time":
+			rv.SetString("2024-01-02T03:04:05Z")
+		case "uri", "uri-reference":
+			rv.SetString("https://example.com/sample")
+		default:
+			rv.SetString("sample")
+		}
+	case reflect.Bool:
+		rv.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		rv.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(1.5)
+	}
+}
+
+func (v *schemaValidator) fillStruct(rv reflect.Value, schema map[string]any, path string) {
+	props := v.properties(v.variants(schema)[0])
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		// Untyped fields such as Included []any make no claim about the schema.
+		if isUntyped(field.Type) {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		prop, ok := props[name]
+		if !ok {
+			v.errorf("%s.%s: field %s.%s is not in the schema", path, name, rt.Name(), field.Name)
+			continue
+		}
+		v.fill(rv.Field(i), resolveRef(v.doc, prop), path+"."+name)
+	}
+}
+
+// isUntyped reports whether t is raw JSON, an interface or a slice of interfaces.
+func isUntyped(t reflect.Type) bool {
+	if t == reflect.TypeOf(json.RawMessage(nil)) {
+		return true
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Interface
+}
+
+// validate checks value against schema: JSON type, date formats, enum values
+// and, in strict mode, required properties.
+func (v *schemaValidator) validate(value any, schema map[string]any, path string) {
+	if schema == nil || value == nil {
+		return
+	}
+
+	variants := v.variants(schema)
+	if len(variants) > 1 {
+		// Accept the first alternative that validates cleanly.
+		var first []string
+		for i, variant := range variants {
+			trial := &schemaValidator{doc: v.doc, strict: v.strict}
+			trial.validate(value, variant, path)
+			if len(trial.errs) == 0 {
+				return
+			}
+			if i == 0 {
+				first = trial.errs
+			}
+		}
+		v.errs = append(v.errs, first...)
+		return
+	}
+
+	if !jsonTypeMatches(value, schema["type"]) {
+		v.errorf("%s: got %s, schema expects %v", path, jsonTypeName(value), schema["type"])
+		return
+	}
+
+	if format, ok := schema["format"].(string); ok {
+		if str, isString := value.(string); isString && !formatMatches(str, format) {
+			v.errorf("%s: %q is not a valid %s", path, str, format)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && v.strict {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		props := v.properties(schema)
+		if len(props) == 0 {
+			return
+		}
+		// Unknown property names were already reported by fill.
+		for name, item := range value {
+			if prop, ok := props[name]; ok {
+				v.validate(item, resolveRef(v.doc, prop), path+"."+name)
+			}
+		}
+		if v.strict {
+			v.checkRequired(value, schema, path)
+		}
+	case []any:
+		items := resolveRef(v.doc, schema["items"])
+		for i, item := range value {
+			v.validate(item, items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// validateRequired checks only required properties, descending into objects
+// that are present. It is used on zero values, where enums cannot match.
+func (v *schemaValidator) validateRequired(value any, schema map[string]any, path string) {
+	object, ok := value.(map[string]any)
+	if !ok || schema == nil {
+		return
+	}
+
+	schema = v.variants(schema)[0]
+	v.checkRequired(object, schema, path)
+
+	props := v.properties(schema)
+	for name, item := range object {
+		v.validateRequired(item, resolveRef(v.doc, props[name]), path+"."+name)
+	}
+}
+
+func (v *schemaValidator) checkRequired(object map[string]any, schema map[string]any, path string) {
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, ok := object[name.(string)]; !ok {
+			v.errorf("%s: missing required property %q", path, name)
+		}
+	}
+}
+
+// formatMatches reports whether s is valid for the date and date-time
+// formats. Other formats are not checked.
+func formatMatches(s, format string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	}
+	return true
+}
+
+// jsonTypeMatches reports whether value has the JSON type named by want.
+// Schemas without a type accept anything.
+func jsonTypeMatches(value any, want any) bool {
+	switch want {
+	case nil:
+		return true
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == float64(int64(f))
+	}
+	return false
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DownloadStream is the body of a pre-signed download, read as it arrives.
+// Its checksums cover the bytes read so far, so they are complete once it
+// has been read to the end. Close it to release the connection.
+type DownloadStream struct {
+	// Size is the download's length from Content-Length, or -1 if the
+	// server didn't send one.
+	Size int64
+
+	// ContentType is the download's media type, if the server sent one.
+	ContentType string
+
+	body    io.ReadCloser
+	read    int64
+	md5     hash.Hash
+	sha256  hash.Hash
+	timeout func(error) error
+	cancel  context.CancelFunc
+}
+
+// Read reads the next bytes of the download, adding them to its checksums.
+func (d *DownloadStream) Read(p []byte) (int, error) {
+	n, err := d.body.Read(p)
+	if n > 0 {
+		d.read += int64(n)
+		d.md5.Write(p[:n])
+		d.sha256.Write(p[:n])
+	}
+	if err != nil && err != io.EOF {
+		err = d.timeout(err)
+	}
+	return n, err
+}
+
+// Close closes the download's connection.
+func (d *DownloadStream) Close() error {
+	err := d.body.Close()
+	d.cancel()
+	return err
+}
+
+// BytesRead returns how many bytes of the download have been read.
+func (d *DownloadStream) BytesRead() int64 {
+	return d.read
+}
+
+// MD5 returns the hex MD5 digest of the bytes read, the form of the
+// checksums App Store Connect lists for analytics segments and uploaded
+// assets.
+func (d *DownloadStream) MD5() string {
+	return hex.EncodeToString(d.md5.Sum(nil))
+}
+
+// SHA256 returns the hex SHA-256 digest of the bytes read.
+func (d *DownloadStream) SHA256() string {
+	return hex.EncodeToString(d.sha256.Sum(nil))
+}
+
+// VerifyMD5 returns an error if the bytes read don't match checksum, a hex
+// MD5 digest. An empty checksum isn't checked.
+func (d *DownloadStream) VerifyMD5(checksum string) error {
+	if checksum != "" && !strings.EqualFold(d.MD5(), checksum) {
+		return fmt.Errorf("download checksum %s doesn't match %s", d.MD5(), checksum)
+	}
+	return nil
+}
+
+// Download opens a pre-signed download URL, such as an analytics report
+// segment, a CI artifact or a screenshot, and streams its body. The URL is
+// used as-is without authentication. The download category's time limit
+// covers reading the whole body.
+func (c *Client) Download(ctx context.Context, rawURL string) (*DownloadStream, error) {
+	attemptCtx, timeout, cancel := c.withAttemptDeadline(ctx, CategoryDownload)
+	timeoutErr := func(err error) error {
+		if attemptCtx.Err() != nil && ctx.Err() == nil {
+			return timeoutError(CategoryDownload, timeout)
+		}
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("download failed: %w", timeoutErr(err))
+	}
+
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("download failed (%d)", resp.StatusCode)
+	}
+
+	withBodyProgress(ctx, resp)
+	return &DownloadStream{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		body:        resp.Body,
+		md5:         md5.New(),
+		sha256:      sha256.New(),
+		timeout:     timeoutErr,
+		cancel:      cancel,
+	}, nil
+}
+
+// DownloadAll fetches a pre-signed download URL into memory, for content that
+// must be read as a whole, such as a zip archive. Downloads larger than
+// maxBytes are rejected.
+func (c *Client) DownloadAll(ctx context.Context, rawURL string, maxBytes int64) ([]byte, error) {
+	stream, err := c.Download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	if stream.Size > maxBytes {
+		return nil, fmt.Errorf("download exceeds %d bytes", maxBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(stream, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("download exceeds %d bytes", maxBytes)
+	}
+	return data, nil
+}
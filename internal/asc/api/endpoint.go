@@ -0,0 +1,25 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nameSegment matches path segments that are resource or relationship names,
+// such as "appStoreVersions" or "relationships". Anything else is an ID.
+var nameSegment = regexp.MustCompile(`^[a-z][A-Za-z]*$`)
+
+// EndpointTemplate returns an API path with its resource IDs replaced by
+// {id}, as in "/v1/apps/{id}/builds", to group requests to the same endpoint
+// in metrics and traces without the IDs they name.
+func EndpointTemplate(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		// The segment after the version and resource type is always an ID,
+		// even one made of letters such as the territory "USA".
+		if i == 2 || (i > 0 && !nameSegment.MatchString(segment)) {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Platform is the platform of an App Store version, pre-release version or
+// review submission.
+type Platform string
+
+// Platforms.
+const (
+	PlatformIOS      Platform = "IOS"
+	PlatformMacOS    Platform = "MAC_OS"
+	PlatformTVOS     Platform = "TV_OS"
+	PlatformVisionOS Platform = "VISION_OS"
+)
+
+// Platforms are the values of Platform.
+var Platforms = []Platform{PlatformIOS, PlatformMacOS, PlatformTVOS, PlatformVisionOS}
+
+// Valid reports whether p is one of Platforms.
+func (p Platform) Valid() bool { return slices.Contains(Platforms, p) }
+
+// BundleIDPlatform is the platform of a bundle ID, device, certificate or
+// provisioning profile.
+type BundleIDPlatform string
+
+// Bundle ID platforms.
+const (
+	BundleIDPlatformIOS       BundleIDPlatform = "IOS"
+	BundleIDPlatformMacOS     BundleIDPlatform = "MAC_OS"
+	BundleIDPlatformUniversal BundleIDPlatform = "UNIVERSAL"
+)
+
+// BundleIDPlatforms are the values of BundleIDPlatform.
+var BundleIDPlatforms = []BundleIDPlatform{BundleIDPlatformIOS, BundleIDPlatformMacOS, BundleIDPlatformUniversal}
+
+// Valid reports whether p is one of BundleIDPlatforms.
+func (p BundleIDPlatform) Valid() bool { return slices.Contains(BundleIDPlatforms, p) }
+
+// ProcessingState is how far App Store Connect has got processing an
+// uploaded build.
+type ProcessingState string
+
+// Processing states.
+const (
+	ProcessingStateProcessing ProcessingState = "PROCESSING"
+	ProcessingStateFailed     ProcessingState = "FAILED"
+	ProcessingStateInvalid    ProcessingState = "INVALID"
+	ProcessingStateValid      ProcessingState = "VALID"
+)
+
+// ProcessingStates are the values of ProcessingState.
+var ProcessingStates = []ProcessingState{ProcessingStateProcessing, ProcessingStateFailed, ProcessingStateInvalid, ProcessingStateValid}
+
+// Valid reports whether s is one of ProcessingStates.
+func (s ProcessingState) Valid() bool { return slices.Contains(ProcessingStates, s) }
+
+// AppStoreState is the state of an App Store version, or of an app's info
+// while it is edited for a version.
+type AppStoreState string
+
+// App Store states.
+const (
+	AppStoreStateAccepted                   AppStoreState = "ACCEPTED"
+	AppStoreStateDeveloperRemovedFromSale   AppStoreState = "DEVELOPER_REMOVED_FROM_SALE"
+	AppStoreStateDeveloperRejected          AppStoreState = "DEVELOPER_REJECTED"
+	AppStoreStateInReview                   AppStoreState = "IN_REVIEW"
+	AppStoreStateInvalidBinary              AppStoreState = "INVALID_BINARY"
+	AppStoreStateMetadataRejected           AppStoreState = "METADATA_REJECTED"
+	AppStoreStatePendingAppleRelease        AppStoreState = "PENDING_APPLE_RELEASE"
+	AppStoreStatePendingContract            AppStoreState = "PENDING_CONTRACT"
+	AppStoreStatePendingDeveloperRelease    AppStoreState = "PENDING_DEVELOPER_RELEASE"
+	AppStoreStatePrepareForSubmission       AppStoreState = "PREPARE_FOR_SUBMISSION"
+	AppStoreStatePreorderReadyForSale       AppStoreState = "PREORDER_READY_FOR_SALE"
+	AppStoreStateProcessingForAppStore      AppStoreState = "PROCESSING_FOR_APP_STORE"
+	AppStoreStateReadyForReview             AppStoreState = "READY_FOR_REVIEW"
+	AppStoreStateReadyForSale               AppStoreState = "READY_FOR_SALE"
+	AppStoreStateRejected                   AppStoreState = "REJECTED"
+	AppStoreStateRemovedFromSale            AppStoreState = "REMOVED_FROM_SALE"
+	AppStoreStateWaitingForExportCompliance AppStoreState = "WAITING_FOR_EXPORT_COMPLIANCE"
+	AppStoreStateWaitingForReview           AppStoreState = "WAITING_FOR_REVIEW"
+	AppStoreStateReplacedWithNewVersion     AppStoreState = "REPLACED_WITH_NEW_VERSION"
+	AppStoreStateNotApplicable              AppStoreState = "NOT_APPLICABLE"
+)
+
+// AppStoreStates are the values of AppStoreState.
+var AppStoreStates = []AppStoreState{
+	AppStoreStateAccepted,
+	AppStoreStateDeveloperRemovedFromSale,
+	AppStoreStateDeveloperRejected,
+	AppStoreStateInReview,
+	AppStoreStateInvalidBinary,
+	AppStoreStateMetadataRejected,
+	AppStoreStatePendingAppleRelease,
+	AppStoreStatePendingContract,
+	AppStoreStatePendingDeveloperRelease,
+	AppStoreStatePrepareForSubmission,
+	AppStoreStatePreorderReadyForSale,
+	AppStoreStateProcessingForAppStore,
+	AppStoreStateReadyForReview,
+	AppStoreStateReadyForSale,
+	AppStoreStateRejected,
+	AppStoreStateRemovedFromSale,
+	AppStoreStateWaitingForExportCompliance,
+	AppStoreStateWaitingForReview,
+	AppStoreStateReplacedWithNewVersion,
+	AppStoreStateNotApplicable,
+}
+
+// Valid reports whether s is one of AppStoreStates.
+func (s AppStoreState) Valid() bool { return slices.Contains(AppStoreStates, s) }
+
+// ReleaseType is how an App Store version is released once it is approved.
+type ReleaseType string
+
+// Release types.
+const (
+	ReleaseTypeManual        ReleaseType = "MANUAL"
+	ReleaseTypeAfterApproval ReleaseType = "AFTER_APPROVAL"
+	ReleaseTypeScheduled     ReleaseType = "SCHEDULED"
+)
+
+// ReleaseTypes are the values of ReleaseType.
+var ReleaseTypes = []ReleaseType{ReleaseTypeManual, ReleaseTypeAfterApproval, ReleaseTypeScheduled}
+
+// Valid reports whether t is one of ReleaseTypes.
+func (t ReleaseType) Valid() bool { return slices.Contains(ReleaseTypes, t) }
+
+// ProfileType is the kind of a provisioning profile.
+type ProfileType string
+
+// Profile types.
+const (
+	ProfileTypeIOSAppDevelopment         ProfileType = "IOS_APP_DEVELOPMENT"
+	ProfileTypeIOSAppStore               ProfileType = "IOS_APP_STORE"
+	ProfileTypeIOSAppAdHoc               ProfileType = "IOS_APP_ADHOC"
+	ProfileTypeIOSAppInHouse             ProfileType = "IOS_APP_INHOUSE"
+	ProfileTypeMacAppDevelopment         ProfileType = "MAC_APP_DEVELOPMENT"
+	ProfileTypeMacAppStore               ProfileType = "MAC_APP_STORE"
+	ProfileTypeMacAppDirect              ProfileType = "MAC_APP_DIRECT"
+	ProfileTypeTVOSAppDevelopment        ProfileType = "TVOS_APP_DEVELOPMENT"
+	ProfileTypeTVOSAppStore              ProfileType = "TVOS_APP_STORE"
+	ProfileTypeTVOSAppAdHoc              ProfileType = "TVOS_APP_ADHOC"
+	ProfileTypeTVOSAppInHouse            ProfileType = "TVOS_APP_INHOUSE"
+	ProfileTypeMacCatalystAppDevelopment ProfileType = "MAC_CATALYST_APP_DEVELOPMENT"
+	ProfileTypeMacCatalystAppStore       ProfileType = "MAC_CATALYST_APP_STORE"
+	ProfileTypeMacCatalystAppDirect      ProfileType = "MAC_CATALYST_APP_DIRECT"
+)
+
+// ProfileTypes are the values of ProfileType.
+var ProfileTypes = []ProfileType{
+	ProfileTypeIOSAppDevelopment,
+	ProfileTypeIOSAppStore,
+	ProfileTypeIOSAppAdHoc,
+	ProfileTypeIOSAppInHouse,
+	ProfileTypeMacAppDevelopment,
+	ProfileTypeMacAppStore,
+	ProfileTypeMacAppDirect,
+	ProfileTypeTVOSAppDevelopment,
+	ProfileTypeTVOSAppStore,
+	ProfileTypeTVOSAppAdHoc,
+	ProfileTypeTVOSAppInHouse,
+	ProfileTypeMacCatalystAppDevelopment,
+	ProfileTypeMacCatalystAppStore,
+	ProfileTypeMacCatalystAppDirect,
+}
+
+// Valid reports whether t is one of ProfileTypes.
+func (t ProfileType) Valid() bool { return slices.Contains(ProfileTypes, t) }
+
+// ProfileState is whether a provisioning profile can still be used.
+type ProfileState string
+
+// Profile states.
+const (
+	ProfileStateActive  ProfileState = "ACTIVE"
+	ProfileStateInvalid ProfileState = "INVALID"
+)
+
+// ProfileStates are the values of ProfileState.
+var ProfileStates = []ProfileState{ProfileStateActive, ProfileStateInvalid}
+
+// Valid reports whether s is one of ProfileStates.
+func (s ProfileState) Valid() bool { return slices.Contains(ProfileStates, s) }
+
+// CertificateType is the kind of a signing certificate.
+type CertificateType string
+
+// Certificate types.
+const (
+	CertificateTypeApplePay                 CertificateType = "APPLE_PAY"
+	CertificateTypeApplePayMerchantIdentity CertificateType = "APPLE_PAY_MERCHANT_IDENTITY"
+	CertificateTypeApplePayPSPIdentity      CertificateType = "APPLE_PAY_PSP_IDENTITY"
+	CertificateTypeApplePayRSA              CertificateType = "APPLE_PAY_RSA"
+	CertificateTypeDeveloperIDKext          CertificateType = "DEVELOPER_ID_KEXT"
+	CertificateTypeDeveloperIDKextG2        CertificateType = "DEVELOPER_ID_KEXT_G2"
+	CertificateTypeDeveloperIDApplication   CertificateType = "DEVELOPER_ID_APPLICATION"
+	CertificateTypeDeveloperIDApplicationG2 CertificateType = "DEVELOPER_ID_APPLICATION_G2"
+	CertificateTypeDevelopment              CertificateType = "DEVELOPMENT"
+	CertificateTypeDistribution             CertificateType = "DISTRIBUTION"
+	CertificateTypeIdentityAccess           CertificateType = "IDENTITY_ACCESS"
+	CertificateTypeIOSDevelopment           CertificateType = "IOS_DEVELOPMENT"
+	CertificateTypeIOSDistribution          CertificateType = "IOS_DISTRIBUTION"
+	CertificateTypeMacAppDistribution       CertificateType = "MAC_APP_DISTRIBUTION"
+	CertificateTypeMacInstallerDistribution CertificateType = "MAC_INSTALLER_DISTRIBUTION"
+	CertificateTypeMacAppDevelopment        CertificateType = "MAC_APP_DEVELOPMENT"
+	CertificateTypePassTypeID               CertificateType = "PASS_TYPE_ID"
+	CertificateTypePassTypeIDWithNFC        CertificateType = "PASS_TYPE_ID_WITH_NFC"
+)
+
+// CertificateTypes are the values of CertificateType.
+var CertificateTypes = []CertificateType{
+	CertificateTypeApplePay,
+	CertificateTypeApplePayMerchantIdentity,
+	CertificateTypeApplePayPSPIdentity,
+	CertificateTypeApplePayRSA,
+	CertificateTypeDeveloperIDKext,
+	CertificateTypeDeveloperIDKextG2,
+	CertificateTypeDeveloperIDApplication,
+	CertificateTypeDeveloperIDApplicationG2,
+	CertificateTypeDevelopment,
+	CertificateTypeDistribution,
+	CertificateTypeIdentityAccess,
+	CertificateTypeIOSDevelopment,
+	CertificateTypeIOSDistribution,
+	CertificateTypeMacAppDistribution,
+	CertificateTypeMacInstallerDistribution,
+	CertificateTypeMacAppDevelopment,
+	CertificateTypePassTypeID,
+	CertificateTypePassTypeIDWithNFC,
+}
+
+// Valid reports whether t is one of CertificateTypes.
+func (t CertificateType) Valid() bool { return slices.Contains(CertificateTypes, t) }
+
+// DeviceStatus is whether a registered device can be provisioned.
+type DeviceStatus string
+
+// Device statuses.
+const (
+	DeviceStatusEnabled  DeviceStatus = "ENABLED"
+	DeviceStatusDisabled DeviceStatus = "DISABLED"
+)
+
+// DeviceStatuses are the values of DeviceStatus.
+var DeviceStatuses = []DeviceStatus{DeviceStatusEnabled, DeviceStatusDisabled}
+
+// Valid reports whether s is one of DeviceStatuses.
+func (s DeviceStatus) Valid() bool { return slices.Contains(DeviceStatuses, s) }
+
+// DeviceClass is the kind of a registered device.
+type DeviceClass string
+
+// Device classes.
+const (
+	DeviceClassAppleVisionPro DeviceClass = "APPLE_VISION_PRO"
+	DeviceClassAppleWatch     DeviceClass = "APPLE_WATCH"
+	DeviceClassIPad           DeviceClass = "IPAD"
+	DeviceClassIPhone         DeviceClass = "IPHONE"
+	DeviceClassIPod           DeviceClass = "IPOD"
+	DeviceClassAppleTV        DeviceClass = "APPLE_TV"
+	DeviceClassMac            DeviceClass = "MAC"
+)
+
+// DeviceClasses are the values of DeviceClass.
+var DeviceClasses = []DeviceClass{
+	DeviceClassAppleVisionPro,
+	DeviceClassAppleWatch,
+	DeviceClassIPad,
+	DeviceClassIPhone,
+	DeviceClassIPod,
+	DeviceClassAppleTV,
+	DeviceClassMac,
+}
+
+// Valid reports whether c is one of DeviceClasses.
+func (c DeviceClass) Valid() bool { return slices.Contains(DeviceClasses, c) }
+
+// InAppPurchaseType is the kind of an in-app purchase.
+type InAppPurchaseType string
+
+// In-app purchase types.
+const (
+	InAppPurchaseTypeConsumable              InAppPurchaseType = "CONSUMABLE"
+	InAppPurchaseTypeNonConsumable           InAppPurchaseType = "NON_CONSUMABLE"
+	InAppPurchaseTypeNonRenewingSubscription InAppPurchaseType = "NON_RENEWING_SUBSCRIPTION"
+)
+
+// InAppPurchaseTypes are the values of InAppPurchaseType.
+var InAppPurchaseTypes = []InAppPurchaseType{InAppPurchaseTypeConsumable, InAppPurchaseTypeNonConsumable, InAppPurchaseTypeNonRenewingSubscription}
+
+// Valid reports whether t is one of InAppPurchaseTypes.
+func (t InAppPurchaseType) Valid() bool { return slices.Contains(InAppPurchaseTypes, t) }
+
+// PhasedReleaseState is the state of an App Store version's phased release.
+type PhasedReleaseState string
+
+// Phased release states.
+const (
+	PhasedReleaseStateInactive PhasedReleaseState = "INACTIVE"
+	PhasedReleaseStateActive   PhasedReleaseState = "ACTIVE"
+	PhasedReleaseStatePaused   PhasedReleaseState = "PAUSED"
+	PhasedReleaseStateComplete PhasedReleaseState = "COMPLETE"
+)
+
+// PhasedReleaseStates are the values of PhasedReleaseState.
+var PhasedReleaseStates = []PhasedReleaseState{PhasedReleaseStateInactive, PhasedReleaseStateActive, PhasedReleaseStatePaused, PhasedReleaseStateComplete}
+
+// Valid reports whether s is one of PhasedReleaseStates.
+func (s PhasedReleaseState) Valid() bool { return slices.Contains(PhasedReleaseStates, s) }
+
+// BetaReviewState is the state of a build's TestFlight beta app review.
+type BetaReviewState string
+
+// Beta review states.
+const (
+	BetaReviewStateWaitingForReview BetaReviewState = "WAITING_FOR_REVIEW"
+	BetaReviewStateInReview         BetaReviewState = "IN_REVIEW"
+	BetaReviewStateRejected         BetaReviewState = "REJECTED"
+	BetaReviewStateApproved         BetaReviewState = "APPROVED"
+)
+
+// BetaReviewStates are the values of BetaReviewState.
+var BetaReviewStates = []BetaReviewState{BetaReviewStateWaitingForReview, BetaReviewStateInReview, BetaReviewStateRejected, BetaReviewStateApproved}
+
+// Valid reports whether s is one of BetaReviewStates.
+func (s BetaReviewState) Valid() bool { return slices.Contains(BetaReviewStates, s) }
+
+// ParseEnum returns s as one of an enum type's values, ignoring case and
+// surrounding space, as in a tool argument such as "ios" for PlatformIOS.
+// The error lists the values.
+func ParseEnum[T ~string](s string, values []T) (T, error) {
+	v := T(strings.ToUpper(strings.TrimSpace(s)))
+	if !slices.Contains(values, v) {
+		return "", fmt.Errorf("%q is not one of %s", s, oneOf(EnumStrings(values)))
+	}
+	return v, nil
+}
+
+// EnumStrings returns an enum type's values as strings, as for the enum of
+// a tool's input schema property.
+func EnumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Error codes App Store Connect reports in APIError.Code. Codes are
+// dotted paths from general to specific, such as
+// "ENTITY_ERROR.ATTRIBUTE.INVALID"; Error.HasCode matches any prefix.
+const (
+	CodeNotAuthorized       = "NOT_AUTHORIZED"
+	CodeForbidden           = "FORBIDDEN_ERROR"
+	CodeNotFound            = "NOT_FOUND"
+	CodeParameterError      = "PARAMETER_ERROR"
+	CodeEntityError         = "ENTITY_ERROR"
+	CodeAttributeInvalid    = "ENTITY_ERROR.ATTRIBUTE.INVALID"
+	CodeAttributeRequired   = "ENTITY_ERROR.ATTRIBUTE.REQUIRED"
+	CodeRelationshipInvalid = "ENTITY_ERROR.RELATIONSHIP.INVALID"
+	CodeEntityUnprocessable = "ENTITY_UNPROCESSABLE"
+	CodeStateError          = "STATE_ERROR"
+	CodeRateLimitExceeded   = "RATE_LIMIT_EXCEEDED"
+)
+
+// maxErrorBodyBytes bounds how much of an unrecognized error body is quoted,
+// so a proxy's HTML error page does not flood the tool result.
+const maxErrorBodyBytes = 512
+
+// Error is a request the API answered with an error status. Use errors.As
+// to get it from an error returned by the client, or the Is* helpers.
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Errors are the entries of Apple's error document, if the body was one.
+	Errors []APIError
+
+	// RequestID is the ID the API gave the request, to quote when reporting
+	// the failure to Apple. It is empty if the response had none.
+	RequestID string
+
+	// message summarizes the errors, or quotes the body if it wasn't an
+	// error document.
+	message string
+}
+
+// Error formats the error as "API error (status): message", followed by the
+// request ID if there is one.
+func (e *Error) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error (%d): %s (request ID %s)", e.StatusCode, e.message, e.RequestID)
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.message)
+}
+
+// Codes returns the error codes of the error document's entries.
+func (e *Error) Codes() []string {
+	var codes []string
+	for _, entry := range e.Errors {
+		if entry.Code != "" {
+			codes = append(codes, entry.Code)
+		}
+	}
+	return codes
+}
+
+// HasCode reports whether an entry's code is code or more specific than it,
+// so CodeEntityError matches "ENTITY_ERROR.ATTRIBUTE.INVALID".
+func (e *Error) HasCode(code string) bool {
+	return slices.ContainsFunc(e.Codes(), func(c string) bool {
+		return c == code || strings.HasPrefix(c, code+".")
+	})
+}
+
+// responseError describes a failed response, with the request ID from its
+// header.
+func responseError(resp *http.Response, body []byte) error {
+	err := apiError(resp.StatusCode, body)
+	err.RequestID = ResponseRequestID(resp.Header)
+	return err
+}
+
+// ResponseRequestID returns the ID the API gave a request, from the response
+// header, or "" if it has none.
+func ResponseRequestID(header http.Header) string {
+	if id := header.Get("X-Request-ID"); id != "" {
+		return id
+	}
+	return header.Get("X-Apple-Request-UUID")
+}
+
+// apiError describes a failed response. Apple's error documents are
+// summarized; any other body is quoted, truncated and made valid UTF-8.
+func apiError(statusCode int, body []byte) *Error {
+	apiErr := &Error{StatusCode: statusCode}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Errors) > 0 {
+		apiErr.Errors = errResp.Errors
+		errMsgs := make([]string, 0, len(errResp.Errors))
+		for _, e := range errResp.Errors {
+			switch {
+			case e.Title != "" || e.Detail != "":
+				errMsgs = append(errMsgs, fmt.Sprintf("%s: %s", e.Title, e.Detail))
+			case e.Code != "":
+				errMsgs = append(errMsgs, e.Code)
+			}
+		}
+		if len(errMsgs) > 0 {
+			apiErr.message = strings.Join(errMsgs, "; ")
+			return apiErr
+		}
+	}
+
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxErrorBodyBytes {
+		text = text[:maxErrorBodyBytes] + "... (truncated)"
+	}
+	text = strings.ToValidUTF8(text, "\uFFFD")
+	if text == "" {
+		text = http.StatusText(statusCode)
+	}
+	apiErr.message = text
+	return apiErr
+}
+
+// RequestID returns the request ID of the API error in err's chain, or ""
+// if there is none or the response had no ID.
+func RequestID(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestID
+	}
+	return ""
+}
+
+// StatusCode returns the HTTP status of the API error in err's chain, or 0
+// if there is none.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether err is an API error with status 409, such as
+// a change the resource's state doesn't allow.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsForbidden reports whether err is an API error with status 403, usually
+// because the API key's role doesn't allow the request.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsUnauthorized reports whether err is an API error with status 401, when
+// the token was refused.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsRateLimited reports whether err is an API error with status 429.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
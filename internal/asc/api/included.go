@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// IncludedResource is an included resource of a type without a registered
+// struct. Its attributes and relationships are kept as raw JSON.
+type IncludedResource struct {
+	Type          string          `json:"type"`
+	ID            string          `json:"id"`
+	Attributes    json.RawMessage `json:"attributes,omitempty"`
+	Relationships json.RawMessage `json:"relationships,omitempty"`
+}
+
+// includedTypes maps the resource types that responses include to the
+// decoders of their structs.
+var includedTypes = map[string]func(json.RawMessage) (any, error){
+	"apps":                          decodeIncluded[App],
+	"appInfos":                      decodeIncluded[AppInfo],
+	"appEncryptionDeclarations":     decodeIncluded[AppEncryptionDeclaration],
+	"appStoreReviewAttachments":     decodeIncluded[AppStoreReviewAttachment],
+	"appStoreReviewDetails":         decodeIncluded[AppStoreReviewDetail],
+	"appStoreVersionLocalizations":  decodeIncluded[AppStoreVersionLocalization],
+	"appStoreVersionPhasedReleases": decodeIncluded[AppStoreVersionPhasedRelease],
+	"appStoreVersions":              decodeIncluded[AppStoreVersion],
+	"betaAppReviewSubmissions":      decodeIncluded[BetaAppReviewSubmission],
+	"betaBuildLocalizations":        decodeIncluded[BetaBuildLocalization],
+	"betaGroups":                    decodeIncluded[BetaGroup],
+	"betaTesters":                   decodeIncluded[BetaTester],
+	"buildBetaDetails":              decodeIncluded[BuildBetaDetail],
+	"builds":                        decodeIncluded[Build],
+	"ciProducts":                    decodeIncluded[CiProduct],
+	"ciWorkflows":                   decodeIncluded[CiWorkflow],
+	"customerReviewResponses":       decodeIncluded[CustomerReviewResponseV1],
+	"inAppPurchases":                decodeIncluded[InAppPurchase],
+	"preReleaseVersions":            decodeIncluded[PreReleaseVersion],
+	"subscriptions":                 decodeIncluded[Subscription],
+	"territories":                   decodeIncluded[Territory],
+}
+
+func decodeIncluded[T any](data json.RawMessage) (any, error) {
+	var resource T
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// DecodeIncluded decodes a response's included array. Each resource becomes
+// its type's struct, such as a Build for "builds", so callers can use a type
+// switch; resources of other types become an IncludedResource.
+func DecodeIncluded(included json.RawMessage) ([]any, error) {
+	if len(included) == 0 {
+		return nil, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(included, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal included resources: %w", err)
+	}
+
+	resources := make([]any, 0, len(raw))
+	for _, data := range raw {
+		var generic IncludedResource
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal included resource: %w", err)
+		}
+		decode, ok := includedTypes[generic.Type]
+		if !ok {
+			resources = append(resources, generic)
+			continue
+		}
+		resource, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal included %s %s: %w", generic.Type, generic.ID, err)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// IncludedOf returns the included resources that decode to T, such as the
+// builds of a response that included "builds".
+func IncludedOf[T any](included json.RawMessage) ([]T, error) {
+	resources, err := DecodeIncluded(included)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []T
+	for _, resource := range resources {
+		if match, ok := resource.(T); ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// includeQuery returns query parameters asking for the related resources
+// named by include, or nil if there are none.
+func includeQuery(include []string) url.Values {
+	if len(include) == 0 {
+		return nil
+	}
+	return url.Values{"include": {strings.Join(include, ",")}}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ListOptions are the query parameters that list endpoints share. Every
+// List method accepts them, along with the IDs in its path. The zero value
+// lists the API's default page. Later pages are requested with a context
+// from WithCursor, as Pages and ListAll do.
+type ListOptions struct {
+	// Filters are filter[...] parameters keyed by the name inside the
+	// brackets, such as "processingState" for filter[processingState].
+	// Multiple values are comma-separated, as the API expects.
+	Filters map[string]string
+
+	// Limit is the page size. Zero leaves it to the API.
+	Limit int
+
+	// Sort orders the results by an attribute, descending with a leading
+	// "-", such as "-uploadedDate".
+	Sort string
+
+	// Include names related resources to return in the response's
+	// Included, such as "app" or "betaGroups".
+	Include []string
+
+	// Fields limit the attributes returned for each resource type, keyed by
+	// type, such as {"builds": {"version", "processingState"}}.
+	Fields map[string][]string
+}
+
+// WithFilter returns a copy of o that also filters on name, such as
+// "email" for filter[email]. An empty value leaves o unchanged.
+func (o ListOptions) WithFilter(name, value string) ListOptions {
+	if value == "" {
+		return o
+	}
+	filters := maps.Clone(o.Filters)
+	if filters == nil {
+		filters = map[string]string{}
+	}
+	filters[name] = value
+	o.Filters = filters
+	return o
+}
+
+// WithSort returns a copy of o sorted by sort.
+func (o ListOptions) WithSort(sort string) ListOptions {
+	o.Sort = sort
+	return o
+}
+
+// WithInclude returns a copy of o that also includes the named related
+// resources.
+func (o ListOptions) WithInclude(include ...string) ListOptions {
+	o.Include = append(slices.Clip(o.Include), include...)
+	return o
+}
+
+// WithFields returns a copy of o that limits the attributes returned for
+// resources of resourceType to fields.
+func (o ListOptions) WithFields(resourceType string, fields ...string) ListOptions {
+	all := maps.Clone(o.Fields)
+	if all == nil {
+		all = map[string][]string{}
+	}
+	all[resourceType] = fields
+	o.Fields = all
+	return o
+}
+
+// query returns the options as query parameters.
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	for name, value := range o.Filters {
+		if value != "" {
+			query.Set("filter["+name+"]", value)
+		}
+	}
+	if o.Limit > 0 {
+		query.Set("limit", fmt.Sprintf("%d", o.Limit))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if len(o.Include) > 0 {
+		query.Set("include", strings.Join(o.Include, ","))
+	}
+	for resourceType, fields := range o.Fields {
+		if len(fields) > 0 {
+			query.Set("fields["+resourceType+"]", strings.Join(fields, ","))
+		}
+	}
+	return query
+}
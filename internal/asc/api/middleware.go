@@ -0,0 +1,46 @@
+package api
+
+import "net/http"
+
+// Middleware wraps the transport that sends the client's HTTP requests, to
+// log, measure or change them, or to answer them without the network in
+// tests. It returns a RoundTripper that calls next to send the request on.
+// Middlewares see every attempt, retries included, with the Authorization
+// header already set.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper, for writing
+// middlewares inline.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middlewares around the client's transport. Requests
+// pass through them in the order they are added, so the first sees each
+// request first and its response last. The option may be given more than
+// once.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// applyMiddlewares wraps the client's transport in its middlewares.
+func (c *Client) applyMiddlewares() {
+	if len(c.middlewares) == 0 {
+		return
+	}
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
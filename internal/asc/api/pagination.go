@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxPages is how many pages ListAll follows when maxPages is 0.
+const DefaultMaxPages = 50
+
+// maxPageSize is the largest limit list endpoints accept.
+const maxPageSize = 200
+
+// DefaultPageConcurrency is how many pages the client's ListAll methods
+// fetch at once unless set otherwise with WithPageConcurrency.
+const DefaultPageConcurrency = 4
+
+// WithPageConcurrency sets how many pages the client's ListAll methods fetch
+// at once when a list's first page reports its total. One fetches pages one
+// after another.
+func WithPageConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.pageConcurrency = max(n, 1)
+	}
+}
+
+// ErrTooManyPages is returned when a list has more pages than the cap given
+// to Pages or ListAll.
+var ErrTooManyPages = errors.New("too many pages")
+
+// Pages returns an iterator over the pages of a list request. list is called
+// for the first page with ctx, and for each later page with ctx resuming
+// from the previous page's next link, until a page has none. After maxPages
+// pages (DefaultMaxPages if 0) with more remaining, it yields ErrTooManyPages.
+// Iteration also stops at the first error.
+func Pages[R any](ctx context.Context, maxPages int, list func(ctx context.Context) (*R, error), links func(*R) PagedDocumentLinks) iter.Seq2[*R, error] {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	return func(yield func(*R, error) bool) {
+		pageCtx := ctx
+		for page := 1; ; page++ {
+			resp, err := list(pageCtx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(resp, nil) {
+				return
+			}
+
+			cursor := links(resp).NextCursor()
+			if cursor == "" {
+				return
+			}
+			if page == maxPages {
+				yield(nil, fmt.Errorf("%w: stopped after %d pages", ErrTooManyPages, maxPages))
+				return
+			}
+			pageCtx = WithCursor(ctx, cursor)
+		}
+	}
+}
+
+// ListAll returns the items of every page of a list request, as iterated by
+// Pages. page returns a response's items and links. If the list has more
+// than maxPages pages, the items read so far are returned with
+// ErrTooManyPages.
+func ListAll[R, T any](ctx context.Context, maxPages int, list func(ctx context.Context) (*R, error), page func(*R) ([]T, PagedDocumentLinks)) ([]T, error) {
+	links := func(resp *R) PagedDocumentLinks {
+		_, l := page(resp)
+		return l
+	}
+
+	var all []T
+	for resp, err := range Pages(ctx, maxPages, list, links) {
+		if err != nil {
+			return all, err
+		}
+		items, _ := page(resp)
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// ListAllConcurrent returns the items of every page of a list request, like
+// ListAll, but fetches pages concurrently when it can. page returns a
+// response's items, links and paging metadata. When the first page reports
+// the list's total and its next cursor encodes an offset, as App Store
+// Connect's do, the cursors of the remaining pages are derived from it and
+// up to concurrency pages are fetched at once. Otherwise pages are followed
+// one after another. If a page fails, the items of the pages before it are
+// returned with the error; if the list has more than maxPages pages, the
+// items of the first maxPages are returned with ErrTooManyPages.
+func ListAllConcurrent[R, T any](ctx context.Context, maxPages, concurrency int, list func(ctx context.Context) (*R, error), page func(*R) ([]T, PagedDocumentLinks, *PagingInformation)) ([]T, error) {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	items := func(resp *R) ([]T, PagedDocumentLinks) {
+		data, links, _ := page(resp)
+		return data, links
+	}
+
+	first, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, links, meta := page(first)
+	cursor := links.NextCursor()
+	if cursor == "" {
+		return all, nil
+	}
+	if maxPages == 1 {
+		return all, fmt.Errorf("%w: stopped after %d pages", ErrTooManyPages, maxPages)
+	}
+
+	pageSize := len(all)
+	if meta != nil && meta.Paging.Limit > 0 {
+		pageSize = meta.Paging.Limit
+	}
+	offset, ok := cursorOffset(cursor)
+	if concurrency <= 1 || meta == nil || pageSize == 0 || offset != pageSize || !ok || meta.Paging.Total <= pageSize {
+		rest, err := ListAll(WithCursor(ctx, cursor), maxPages-1, list, items)
+		return append(all, rest...), err
+	}
+
+	// Fetch the pages after the first by offset, keeping their order.
+	pages := (meta.Paging.Total + pageSize - 1) / pageSize
+	capped := pages > maxPages
+	pages = min(pages, maxPages)
+	results := make([]*R, pages-1)
+	errs := make([]error, pages-1)
+
+	// Once a page fails, the pages after it aren't needed, so those not
+	// yet started are skipped.
+	var mu sync.Mutex
+	failed := len(results)
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i := range results {
+		pageCursor, _ := cursorAtOffset(cursor, (i+1)*pageSize)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			mu.Lock()
+			skip := failed < i
+			mu.Unlock()
+			if skip {
+				return
+			}
+			results[i], errs[i] = list(WithCursor(ctx, pageCursor))
+			if errs[i] != nil {
+				mu.Lock()
+				failed = min(failed, i)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var last *R
+	for i, resp := range results {
+		if errs[i] != nil {
+			return all, errs[i]
+		}
+		data, _ := items(resp)
+		all = append(all, data...)
+		last = resp
+	}
+	if capped {
+		return all, fmt.Errorf("%w: stopped after %d pages", ErrTooManyPages, maxPages)
+	}
+
+	// Follow any pages added since the first page was read.
+	_, links = items(last)
+	if cursor := links.NextCursor(); cursor != "" {
+		if pages == maxPages {
+			return all, fmt.Errorf("%w: stopped after %d pages", ErrTooManyPages, maxPages)
+		}
+		rest, err := ListAll(WithCursor(ctx, cursor), maxPages-pages, list, items)
+		return append(all, rest...), err
+	}
+	return all, nil
+}
+
+// cursorOffset returns the offset encoded in a pagination cursor. App Store
+// Connect cursors are base64-encoded JSON objects such as {"offset":"200"}.
+func cursorOffset(cursor string) (int, bool) {
+	fields, _, ok := decodeCursor(cursor)
+	if !ok {
+		return 0, false
+	}
+	var offset string
+	if err := json.Unmarshal(fields["offset"], &offset); err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(offset)
+	return n, err == nil
+}
+
+// cursorAtOffset returns a cursor like cursor but resuming from offset.
+func cursorAtOffset(cursor string, offset int) (string, bool) {
+	fields, encoding, ok := decodeCursor(cursor)
+	if !ok {
+		return "", false
+	}
+	fields["offset"], _ = json.Marshal(strconv.Itoa(offset))
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", false
+	}
+	return encoding.EncodeToString(data), true
+}
+
+// decodeCursor decodes a pagination cursor into its JSON fields, returning
+// the base64 encoding it used.
+func decodeCursor(cursor string) (map[string]json.RawMessage, *base64.Encoding, bool) {
+	for _, encoding := range []*base64.Encoding{base64.RawURLEncoding, base64.RawStdEncoding} {
+		data, err := encoding.DecodeString(strings.TrimRight(cursor, "="))
+		if err != nil {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil || fields["offset"] == nil {
+			return nil, nil, false
+		}
+		if strings.HasSuffix(cursor, "=") {
+			encoding = encoding.WithPadding(base64.StdPadding)
+		}
+		return fields, encoding, true
+	}
+	return nil, nil, false
+}
+
+// ListAllApps returns every app, following pagination up to DefaultMaxPages
+// pages.
+func (c *Client) ListAllApps(ctx context.Context) ([]App, error) {
+	return ListAllConcurrent(ctx, 0, c.pageConcurrency,
+		func(ctx context.Context) (*AppsResponse, error) {
+			return c.ListApps(ctx, ListOptions{Limit: maxPageSize})
+		},
+		func(resp *AppsResponse) ([]App, PagedDocumentLinks, *PagingInformation) {
+			return resp.Data, resp.Links, resp.Meta
+		},
+	)
+}
+
+// ListAllBuilds returns every build of an app, or of all apps if appID is
+// empty, following pagination up to DefaultMaxPages pages.
+func (c *Client) ListAllBuilds(ctx context.Context, appID string) ([]Build, error) {
+	return ListAllConcurrent(ctx, 0, c.pageConcurrency,
+		func(ctx context.Context) (*BuildsResponse, error) {
+			return c.ListBuilds(ctx, appID, ListOptions{Limit: maxPageSize})
+		},
+		func(resp *BuildsResponse) ([]Build, PagedDocumentLinks, *PagingInformation) {
+			return resp.Data, resp.Links, resp.Meta
+		},
+	)
+}
+
+// ListAllBetaTesters returns every beta tester, or those of a beta group if
+// betaGroupID is set, following pagination up to DefaultMaxPages pages.
+func (c *Client) ListAllBetaTesters(ctx context.Context, betaGroupID string) ([]BetaTester, error) {
+	return ListAllConcurrent(ctx, 0, c.pageConcurrency,
+		func(ctx context.Context) (*BetaTestersResponse, error) {
+			return c.ListBetaTesters(ctx, betaGroupID, ListOptions{Limit: maxPageSize})
+		},
+		func(resp *BetaTestersResponse) ([]BetaTester, PagedDocumentLinks, *PagingInformation) {
+			return resp.Data, resp.Links, resp.Meta
+		},
+	)
+}
@@ -0,0 +1,40 @@
+package api
+
+import "context"
+
+// AddRelationships links resources to a to-many relationship of a resource,
+// such as testers to the betaTesters of a beta group, by posting their
+// linkage to /v1/{resourceType}/{id}/relationships/{relationship}. Linking a
+// resource that is already linked does nothing. With no resources, no
+// request is made.
+func (c *Client) AddRelationships(ctx context.Context, resourceType, id, relationship string, linked ...ResourceIdentifier) error {
+	if len(linked) == 0 {
+		return nil
+	}
+	_, err := c.Post(ctx, relationshipPath(resourceType, id, relationship), RelationshipDataList{Data: linked})
+	return err
+}
+
+// RemoveRelationships unlinks resources from a to-many relationship of a
+// resource, without deleting them, by sending their linkage in the body of a
+// DELETE. With no resources, no request is made.
+func (c *Client) RemoveRelationships(ctx context.Context, resourceType, id, relationship string, linked ...ResourceIdentifier) error {
+	if len(linked) == 0 {
+		return nil
+	}
+	return c.DeleteWithBody(ctx, relationshipPath(resourceType, id, relationship), RelationshipDataList{Data: linked})
+}
+
+// relationshipPath returns the path of a resource's relationship.
+func relationshipPath(resourceType, id, relationship string) string {
+	return "/v1/" + resourceType + "/" + id + "/relationships/" + relationship
+}
+
+// identifiers returns the identifiers of resources of one type.
+func identifiers(resourceType string, ids ...string) []ResourceIdentifier {
+	linked := make([]ResourceIdentifier, len(ids))
+	for i, id := range ids {
+		linked[i] = ResourceIdentifier{Type: resourceType, ID: id}
+	}
+	return linked
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// reportAccept is the media type sales and finance reports are served as.
+const reportAccept = "application/a-gzip"
+
+// Report is a downloaded sales or finance report.
+type Report struct {
+	// Body reads the report's tab-separated text, decompressed.
+	Body io.Reader
+
+	// Filename is the report's file name from the response, such as
+	// S_D_12345678_20240101.txt.gz, if the API sent one.
+	Filename string
+
+	// DownloadedBytes is the size of the report as downloaded, compressed
+	// if the API compressed it.
+	DownloadedBytes int
+
+	// Compressed reports whether the report was downloaded gzip-compressed.
+	Compressed bool
+}
+
+// newReport wraps a downloaded report, decompressing it if it is gzip data.
+func newReport(data []byte, header http.Header) (*Report, error) {
+	report := &Report{Body: bytes.NewReader(data), DownloadedBytes: len(data)}
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		report.Filename = params["filename"]
+	}
+
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress report: %w", err)
+		}
+		report.Body = gz
+		report.Compressed = true
+	}
+	return report, nil
+}
+
+// getReport downloads the report at path.
+func (c *Client) getReport(ctx context.Context, path string, query url.Values) (*Report, error) {
+	data, header, err := c.doRequestWithHeader(ctx, http.MethodGet, path, query, nil, http.Header{"Accept": {reportAccept}})
+	if err != nil {
+		return nil, err
+	}
+	return newReport(data, header)
+}
+
+// Sales and Finance API methods
+
+// GetSalesReport returns a sales and trends report, decompressed.
+func (c *Client) GetSalesReport(ctx context.Context, vendorNumber, reportType, reportSubType, frequency, reportDate string) (*Report, error) {
+	query := url.Values{}
+	query.Set("filter[vendorNumber]", vendorNumber)
+	query.Set("filter[reportType]", reportType)
+	query.Set("filter[reportSubType]", reportSubType)
+	query.Set("filter[frequency]", frequency)
+	query.Set("filter[reportDate]", reportDate)
+
+	return c.getReport(ctx, "/v1/salesReports", query)
+}
+
+// GetFinanceReport returns a finance report, decompressed.
+func (c *Client) GetFinanceReport(ctx context.Context, vendorNumber, regionCode, reportType, reportDate string) (*Report, error) {
+	query := url.Values{}
+	query.Set("filter[vendorNumber]", vendorNumber)
+	query.Set("filter[regionCode]", regionCode)
+	query.Set("filter[reportType]", reportType)
+	query.Set("filter[reportDate]", reportDate)
+
+	return c.getReport(ctx, "/v1/financeReports", query)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRateLimitWait is how long a request refused with a 429 may wait
+	// in total for retries before the error is returned.
+	DefaultRateLimitWait = time.Minute
+
+	// maxRateLimitRetries bounds the retries of one request, so a server that
+	// keeps answering with "Retry-After: 0" can't hold it forever.
+	maxRateLimitRetries = 5
+
+	// rateLimitBaseDelay is the first backoff delay when a 429 response
+	// doesn't say when to retry. It doubles with each retry.
+	rateLimitBaseDelay = time.Second
+
+	// maxRateLimitDelay caps a single backoff delay.
+	maxRateLimitDelay = 30 * time.Second
+)
+
+// RetryPolicy decides which requests that failed with a transient error are
+// sent again: those answered with one of StatusCodes, and those that got no
+// response at all, such as after a reset connection. Requests refused with
+// a 429 are retried separately, within the client's rate limit wait.
+type RetryPolicy struct {
+	// MaxAttempts is how many times a request is sent in total. One or
+	// less turns retries off.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry. It doubles with each
+	// retry, up to MaxDelay, and is jittered. A Retry-After header takes
+	// its place if it asks for no more than MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// StatusCodes are the response statuses that are retried.
+	StatusCodes []int
+
+	// RetryNonIdempotent also retries POST and PATCH requests. Without it
+	// only GET, HEAD, PUT and DELETE requests, which have the same effect
+	// when repeated, are retried, so a create that failed after the API
+	// applied it isn't applied twice.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy retries idempotent requests twice after a 500, 502,
+// 503 or 504 response or a network error.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	StatusCodes: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetryPolicy sets which requests that failed with a transient error
+// are sent again. Clients use DefaultRetryPolicy unless set otherwise.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// retryableStatus reports whether a response with status is retried.
+func (p RetryPolicy) retryableStatus(status int) bool {
+	return slices.Contains(p.StatusCodes, status)
+}
+
+// delay returns how long to wait before retrying a method request that has
+// failed retried+1 times, honoring header's Retry-After if any. It reports
+// false when the request should not be retried.
+func (p RetryPolicy) delay(method string, retried int, header http.Header) (time.Duration, bool) {
+	if retried+1 >= p.MaxAttempts {
+		return 0, false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		if !p.RetryNonIdempotent {
+			return 0, false
+		}
+	}
+
+	if delay, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+		return delay, delay <= p.MaxDelay
+	}
+	return backoff(p.BaseDelay, p.MaxDelay, retried), true
+}
+
+// transportError is a request that failed without a response.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "request failed: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// WithRateLimitWait sets how long a request refused with a 429 may wait in
+// total before it is retried for the last time. Zero returns 429s at once.
+// Clients wait up to DefaultRateLimitWait unless set otherwise.
+func WithRateLimitWait(wait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.rateLimitWait = max(wait, 0)
+	}
+}
+
+// rateLimitDelay returns how long to wait before retrying a request refused
+// with a 429 for the retry'th time, having already waited waited. It reports
+// false when the request should not be retried: the retries are used up or
+// the wait would exceed the client's budget.
+func (c *Client) rateLimitDelay(retry int, header http.Header, waited time.Duration) (time.Duration, bool) {
+	if retry >= maxRateLimitRetries {
+		return 0, false
+	}
+
+	delay, ok := parseRetryAfter(header.Get("Retry-After"), time.Now())
+	if !ok {
+		delay = backoff(rateLimitBaseDelay, maxRateLimitDelay, retry)
+	}
+	if waited+delay > c.rateLimitWait {
+		return 0, false
+	}
+	return delay, true
+}
+
+// parseRetryAfter parses a Retry-After header given either as seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// backoff returns the exponential delay from base before the retry'th retry,
+// capped at limit, with jitter so concurrent requests that failed together
+// don't retry together.
+func backoff(base, limit time.Duration, retry int) time.Duration {
+	delay := limit
+	if retry < 32 {
+		delay = min(base<<retry, limit)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// flightGroup coalesces identical GET requests made at the same time, so
+// tool calls fanning out to the same resource share one API call. Its zero
+// value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request in progress and, once done is closed, its result.
+type flight struct {
+	done   chan struct{}
+	body   []byte
+	header http.Header
+	err    error
+}
+
+// flightKey identifies a GET request by everything that can change its
+// response: the team's key and endpoint, path, query, extra header, and
+// whether the cache is bypassed.
+func flightKey(team Team, path string, query url.Values, header http.Header, refresh bool) string {
+	var sb strings.Builder
+	sb.WriteString(cacheKey(team, path, query))
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sb.WriteString("\n" + name + ": " + strings.Join(header[name], ", "))
+	}
+	if refresh {
+		sb.WriteString("\nrefresh")
+	}
+	return sb.String()
+}
+
+// do calls fetch for key unless a call for key is already in flight, in
+// which case it waits for that call and returns its result. A caller whose
+// shared call failed only because the caller that started it gave up makes
+// its own call instead.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func() ([]byte, http.Header, error)) ([]byte, http.Header, error) {
+	for {
+		g.mu.Lock()
+		if f, ok := g.flights[key]; ok {
+			g.mu.Unlock()
+			select {
+			case <-f.done:
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+			if f.err != nil && (errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+				continue
+			}
+			return f.body, f.header, f.err
+		}
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+		f := &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		g.mu.Unlock()
+
+		f.body, f.header, f.err = fetch()
+
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+		return f.body, f.header, f.err
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithStrictDecoding makes the client fail on responses its types don't
+// fully describe, instead of dropping what they don't know: members without
+// a field, such as a renamed attribute, and resources of another type than
+// the one requested. JSON:API links and meta members are still ignored, as
+// are included resources. It is off by default, because Apple adds
+// attributes without notice; turn it on to find out when payloads change.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// DecodeError is a response that doesn't match the type it was decoded
+// into, returned in strict decoding mode. Use errors.As to get it from an
+// error returned by the client.
+type DecodeError struct {
+	// Type is the type the response was decoded into, such as "AppsResponse".
+	Type string
+
+	// Reason describes the mismatch, such as `json: unknown field "name"`.
+	Reason string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("unexpected %s payload: %s", e.Type, e.Reason)
+}
+
+// resourceTypeOverrides are the JSON:API types of resource structs whose
+// names don't follow the convention resourceType applies.
+var resourceTypeOverrides = map[string]string{
+	"BundleID":                 "bundleIds",
+	"CustomerReviewResponseV1": "customerReviewResponses",
+}
+
+// decode unmarshals a response body into v, checking it against v's type in
+// strict decoding mode.
+func (c *Client) decode(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if !c.strictDecoding {
+		return nil
+	}
+	return checkStrict(data, reflect.TypeOf(v).Elem())
+}
+
+// checkStrict reports the members of a response document that t has no
+// field for, and the resources in its data of another type than t's.
+func checkStrict(data []byte, t reflect.Type) error {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		// Not a document; the caller's unmarshal already accepted it.
+		return nil
+	}
+
+	fail := func(format string, args ...any) error {
+		return &DecodeError{Type: t.Name(), Reason: fmt.Sprintf(format, args...)}
+	}
+	if field, ok := t.FieldByName("Data"); ok {
+		want := resourceType(field.Type)
+		switch data := doc["data"].(type) {
+		case map[string]any:
+			if got, _ := data["type"].(string); want != "" && got != want {
+				return fail("data.type is %q, want %q", got, want)
+			}
+			stripMembers(data)
+		case []any:
+			for i, resource := range data {
+				resource, _ := resource.(map[string]any)
+				if got, _ := resource["type"].(string); want != "" && got != want {
+					return fail("data[%d].type is %q, want %q", i, got, want)
+				}
+				stripMembers(resource)
+			}
+		}
+	}
+	for _, member := range []string{"links", "meta", "jsonapi"} {
+		if jsonFieldName(t, member) == "" {
+			delete(doc, member)
+		}
+	}
+
+	stripped, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(stripped))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(reflect.New(t).Interface()); err != nil {
+		return fail("%v", err)
+	}
+	return nil
+}
+
+// stripMembers removes the links and meta of a resource and of its
+// relationships, which the resource structs don't model, and the
+// relationships left without linkage data.
+func stripMembers(resource map[string]any) {
+	if resource == nil {
+		return
+	}
+	delete(resource, "links")
+	delete(resource, "meta")
+	relationships, _ := resource["relationships"].(map[string]any)
+	for name, relationship := range relationships {
+		relationship, _ := relationship.(map[string]any)
+		if _, ok := relationship["data"]; !ok {
+			delete(relationships, name)
+			continue
+		}
+		delete(relationship, "links")
+		delete(relationship, "meta")
+	}
+	if relationships != nil && len(relationships) == 0 {
+		delete(resource, "relationships")
+	}
+}
+
+// jsonFieldName returns the name of t's field tagged with the JSON member
+// name, or "" if there is none.
+func jsonFieldName(t reflect.Type, member string) string {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name == member {
+			return field.Name
+		}
+	}
+	return ""
+}
+
+// resourceType returns the JSON:API type of the resources a Data field
+// holds, such as "betaTesters" for BetaTester, or "" if they aren't
+// resource structs with a type member. By convention the type is the
+// struct's name in lower camel case and plural.
+func resourceType(t reflect.Type) string {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || jsonFieldName(t, "type") == "" || t == reflect.TypeFor[ResourceIdentifier]() {
+		return ""
+	}
+	if name, ok := resourceTypeOverrides[t.Name()]; ok {
+		return name
+	}
+
+	name := strings.ToLower(t.Name()[:1]) + t.Name()[1:]
+	if strings.HasSuffix(name, "y") && !strings.ContainsRune("aeiou", rune(name[len(name)-2])) {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
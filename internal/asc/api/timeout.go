@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestCategory groups requests that share a default time limit.
+type RequestCategory string
+
+const (
+	// CategoryDefault covers requests for a single resource and mutations.
+	CategoryDefault RequestCategory = "default"
+
+	// CategoryList covers GET requests for a collection, such as
+	// /v1/apps/{id}/builds, whose pages can be large.
+	CategoryList RequestCategory = "list"
+
+	// CategoryReport covers sales and finance report downloads.
+	CategoryReport RequestCategory = "report"
+
+	// CategoryDownload covers pre-signed downloads made with Download.
+	CategoryDownload RequestCategory = "download"
+)
+
+// DefaultRequestTimeouts are the time limits for one attempt of a request,
+// including reading its response, by category.
+var DefaultRequestTimeouts = map[RequestCategory]time.Duration{
+	CategoryDefault:  DefaultTimeout,
+	CategoryList:     2 * time.Minute,
+	CategoryReport:   5 * time.Minute,
+	CategoryDownload: 10 * time.Minute,
+}
+
+// reportPaths are the API paths whose responses are report files.
+var reportPaths = map[string]bool{
+	"/v1/salesReports":   true,
+	"/v1/financeReports": true,
+}
+
+// requestCategory returns the category of a request to the API.
+func requestCategory(method, path string) RequestCategory {
+	switch {
+	case reportPaths[path]:
+		return CategoryReport
+	case method == http.MethodGet && strings.Count(strings.Trim(path, "/"), "/")%2 == 1:
+		return CategoryList
+	default:
+		return CategoryDefault
+	}
+}
+
+// WithRequestTimeouts overrides the time limits of request categories,
+// DefaultRequestTimeouts unless set otherwise. Zero means no limit.
+func WithRequestTimeouts(timeouts map[RequestCategory]time.Duration) ClientOption {
+	return func(c *Client) {
+		merged := make(map[RequestCategory]time.Duration, len(c.timeouts)+len(timeouts))
+		for category, timeout := range c.timeouts {
+			merged[category] = timeout
+		}
+		for category, timeout := range timeouts {
+			merged[category] = timeout
+		}
+		c.timeouts = merged
+	}
+}
+
+// requestTimeoutKey is the context key for a per-call request time limit.
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a context whose requests may each take up to
+// timeout, whatever their category's limit. Zero means no limit. The
+// context's own deadline, if any, still applies.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// withAttemptDeadline returns a context for one attempt of a request in
+// category, limited by the context's or the client's timeout for it.
+func (c *Client) withAttemptDeadline(ctx context.Context, category RequestCategory) (context.Context, time.Duration, context.CancelFunc) {
+	timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	if !ok {
+		timeout = c.timeouts[category]
+	}
+	if timeout <= 0 {
+		return ctx, 0, func() {}
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	return attemptCtx, timeout, cancel
+}
+
+// timeoutError is returned when an attempt runs out of its time limit before
+// the caller's context is done.
+func timeoutError(category RequestCategory, timeout time.Duration) error {
+	return fmt.Errorf("no complete response within the %s request limit of %s", category, timeout)
+}
+
+// BodyProgress reports how many bytes of a response body have been read, and
+// its length, or -1 if the length is unknown.
+type BodyProgress func(read, total int64)
+
+// bodyProgressKey is the context key for a body progress callback.
+type bodyProgressKey struct{}
+
+// WithBodyProgress returns a context whose response bodies report their
+// progress to progress as they are read.
+func WithBodyProgress(ctx context.Context, progress BodyProgress) context.Context {
+	return context.WithValue(ctx, bodyProgressKey{}, progress)
+}
+
+// progressReader is a response body reporting the bytes read from it.
+type progressReader struct {
+	io.ReadCloser
+	read     int64
+	total    int64
+	progress BodyProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
+
+// withBodyProgress wraps resp.Body to report its progress to the context's
+// callback, if any.
+func withBodyProgress(ctx context.Context, resp *http.Response) {
+	if progress, ok := ctx.Value(bodyProgressKey{}).(BodyProgress); ok {
+		resp.Body = &progressReader{ReadCloser: resp.Body, total: resp.ContentLength, progress: progress}
+	}
+}
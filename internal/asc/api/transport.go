@@ -0,0 +1,182 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConnsPerHost is how many idle connections to a host the
+	// default transport keeps for reuse. The standard library keeps 2, so
+	// parallel page fetches and report downloads would otherwise open a new
+	// TLS connection for most requests.
+	DefaultMaxIdleConnsPerHost = 32
+
+	// DefaultIdleConnTimeout is how long the default transport keeps an
+	// idle connection open.
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// newTransport returns the transport of a client without WithHTTPClient:
+// the standard library's, with a pool sized for parallel requests.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	return transport
+}
+
+// WithHTTPClient sends requests with client instead of the default client.
+// The client isn't modified: WithProxy, WithTLSConfig and WithMiddleware
+// apply to a copy. Its Timeout, if any, applies on top of the request
+// timeouts.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithTimeout sets the time limit for each attempt of every request,
+// replacing the per-category DefaultRequestTimeouts. Zero means no limit.
+// Use WithRequestTimeouts to set categories separately.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		timeouts := make(map[RequestCategory]time.Duration, len(DefaultRequestTimeouts))
+		for category := range DefaultRequestTimeouts {
+			timeouts[category] = timeout
+		}
+		c.timeouts = timeouts
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL, such as
+// http://proxy.example.com:8080, instead of the one named by the
+// HTTPS_PROXY environment variable. It has no effect on a client given to
+// WithHTTPClient whose Transport isn't an *http.Transport.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(proxyURL)
+		})
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of connections to the API, for
+// example to trust a corporate CA. It has no effect on a client given to
+// WithHTTPClient whose Transport isn't an *http.Transport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.TLSClientConfig = config.Clone()
+		})
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections to each host are
+// kept for reuse, such as the API's and the download host's. Raise it when
+// more requests run at once than it allows, or connections are closed and
+// reopened between requests. Values below 1 are ignored. It has no effect
+// on a client given to WithHTTPClient whose Transport isn't an
+// *http.Transport.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if n < 1 {
+			return
+		}
+		c.configureTransport(func(t *http.Transport) {
+			t.MaxIdleConnsPerHost = n
+		})
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept open for
+// reuse. Zero keeps it until the server closes it. It has no effect on a
+// client given to WithHTTPClient whose Transport isn't an *http.Transport.
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.IdleConnTimeout = timeout
+		})
+	}
+}
+
+// WithHTTP2 turns HTTP/2 on or off. HTTP/2 is on by default and sends
+// concurrent requests to a host over one connection. Turning it off sends
+// them over separate HTTP/1.1 connections instead, which can move large
+// downloads faster and suits proxies that mishandle HTTP/2. It has no effect
+// on a client given to WithHTTPClient whose Transport isn't an
+// *http.Transport.
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.ForceAttemptHTTP2 = enabled
+			if enabled {
+				t.TLSNextProto = nil
+				return
+			}
+			t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+			// Cloning a transport can add h2 to its TLS config's protocols,
+			// which would have servers answer in HTTP/2.
+			if t.TLSClientConfig != nil {
+				t.TLSClientConfig.NextProtos = slices.DeleteFunc(slices.Clone(t.TLSClientConfig.NextProtos), func(proto string) bool {
+					return proto == "h2"
+				})
+			}
+		})
+	}
+}
+
+// TransportOptions returns the options that send requests through the proxy
+// at proxyURL and trust the PEM certificates in caFile as well as the
+// system's. Empty arguments are skipped.
+func TransportOptions(proxyURL, caFile string) ([]ClientOption, error) {
+	var opts []ClientOption
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		opts = append(opts, WithProxy(proxy))
+	}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates in CA file %s", caFile)
+		}
+		opts = append(opts, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	}
+	return opts, nil
+}
+
+// configureTransport applies configure to a copy of the client's transport.
+func (c *Client) configureTransport(configure func(*http.Transport)) {
+	var transport *http.Transport
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return
+	}
+	configure(transport)
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
@@ -1,7 +1,10 @@
 // Package api provides types for the App Store Connect API.
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Response wrapper types following JSON:API specification.
 
@@ -41,20 +44,28 @@ type AppsResponse struct {
 	Data     []App              `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppResponse represents a single app response.
 type AppResponse struct {
-	Data     App   `json:"data"`
-	Included []any `json:"included,omitempty"`
+	Data     App             `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // App represents an App Store Connect app.
 type App struct {
-	Type       string        `json:"type"`
-	ID         string        `json:"id"`
-	Attributes AppAttributes `json:"attributes"`
+	Type          string            `json:"type"`
+	ID            string            `json:"id"`
+	Attributes    AppAttributes     `json:"attributes"`
+	Relationships *AppRelationships `json:"relationships,omitempty"`
+}
+
+// AppRelationships contains app relationships.
+// Linkage data is only populated when the related resource is included.
+type AppRelationships struct {
+	AppStoreVersions *RelationshipDataList `json:"appStoreVersions,omitempty"`
+	BetaGroups       *RelationshipDataList `json:"betaGroups,omitempty"`
 }
 
 // AppAttributes contains app attributes.
@@ -75,35 +86,75 @@ type BuildsResponse struct {
 	Data     []Build            `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // BuildResponse represents a single build response.
 type BuildResponse struct {
-	Data     Build `json:"data"`
-	Included []any `json:"included,omitempty"`
+	Data     Build           `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // Build represents an App Store Connect build.
 type Build struct {
-	Type       string          `json:"type"`
-	ID         string          `json:"id"`
-	Attributes BuildAttributes `json:"attributes"`
+	Type          string              `json:"type"`
+	ID            string              `json:"id"`
+	Attributes    BuildAttributes     `json:"attributes"`
+	Relationships *BuildRelationships `json:"relationships,omitempty"`
+}
+
+// BuildRelationships contains build relationships.
+// Linkage data is only populated when the related resource is included.
+type BuildRelationships struct {
+	App               *RelationshipData `json:"app,omitempty"`
+	PreReleaseVersion *RelationshipData `json:"preReleaseVersion,omitempty"`
 }
 
 // BuildAttributes contains build attributes.
 type BuildAttributes struct {
-	Version                 string     `json:"version,omitempty"`
-	UploadedDate            *time.Time `json:"uploadedDate,omitempty"`
-	ExpirationDate          *time.Time `json:"expirationDate,omitempty"`
-	Expired                 bool       `json:"expired,omitempty"`
-	MinOsVersion            string     `json:"minOsVersion,omitempty"`
-	LsMinimumSystemVersion  string     `json:"lsMinimumSystemVersion,omitempty"`
-	ComputedMinMacOsVersion string     `json:"computedMinMacOsVersion,omitempty"`
-	IconAssetToken          any        `json:"iconAssetToken,omitempty"`
-	ProcessingState         string     `json:"processingState,omitempty"`
-	BuildAudienceType       string     `json:"buildAudienceType,omitempty"`
-	UsesNonExemptEncryption bool       `json:"usesNonExemptEncryption,omitempty"`
+	Version                 string          `json:"version,omitempty"`
+	UploadedDate            *time.Time      `json:"uploadedDate,omitempty"`
+	ExpirationDate          *time.Time      `json:"expirationDate,omitempty"`
+	Expired                 bool            `json:"expired,omitempty"`
+	MinOsVersion            string          `json:"minOsVersion,omitempty"`
+	LsMinimumSystemVersion  string          `json:"lsMinimumSystemVersion,omitempty"`
+	ComputedMinMacOsVersion string          `json:"computedMinMacOsVersion,omitempty"`
+	IconAssetToken          any             `json:"iconAssetToken,omitempty"`
+	ProcessingState         ProcessingState `json:"processingState,omitempty"`
+	BuildAudienceType       string          `json:"buildAudienceType,omitempty"`
+	UsesNonExemptEncryption bool            `json:"usesNonExemptEncryption,omitempty"`
+}
+
+// PreReleaseVersion represents the marketing version and platform that
+// builds belong to, as included with builds.
+type PreReleaseVersion struct {
+	Type       string                      `json:"type"`
+	ID         string                      `json:"id"`
+	Attributes PreReleaseVersionAttributes `json:"attributes"`
+}
+
+// PreReleaseVersionAttributes contains prerelease version attributes.
+type PreReleaseVersionAttributes struct {
+	Version  string   `json:"version,omitempty"`
+	Platform Platform `json:"platform,omitempty"`
+}
+
+// BuildUpdateRequest represents a request to update a build.
+type BuildUpdateRequest struct {
+	Data BuildUpdateData `json:"data"`
+}
+
+// BuildUpdateData contains the data for updating a build.
+type BuildUpdateData struct {
+	Type       string                `json:"type"`
+	ID         string                `json:"id"`
+	Attributes BuildUpdateAttributes `json:"attributes"`
+}
+
+// BuildUpdateAttributes contains attributes for updating a build.
+type BuildUpdateAttributes struct {
+	Expired                 *bool `json:"expired,omitempty"`
+	UsesNonExemptEncryption *bool `json:"usesNonExemptEncryption,omitempty"`
 }
 
 // AppStoreVersion types
@@ -113,32 +164,39 @@ type AppStoreVersionsResponse struct {
 	Data     []AppStoreVersion  `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppStoreVersionResponse represents a single app store version.
 type AppStoreVersionResponse struct {
 	Data     AppStoreVersion `json:"data"`
-	Included []any           `json:"included,omitempty"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppStoreVersion represents an App Store version.
 type AppStoreVersion struct {
-	Type       string                    `json:"type"`
-	ID         string                    `json:"id"`
-	Attributes AppStoreVersionAttributes `json:"attributes"`
+	Type          string                        `json:"type"`
+	ID            string                        `json:"id"`
+	Attributes    AppStoreVersionAttributes     `json:"attributes"`
+	Relationships *AppStoreVersionRelationships `json:"relationships,omitempty"`
+}
+
+// AppStoreVersionRelationships contains app store version relationships.
+// Linkage data is only populated when the related resource is included.
+type AppStoreVersionRelationships struct {
+	App *RelationshipData `json:"app,omitempty"`
 }
 
 // AppStoreVersionAttributes contains app store version attributes.
 type AppStoreVersionAttributes struct {
-	Platform            string     `json:"platform,omitempty"`
-	VersionString       string     `json:"versionString,omitempty"`
-	AppStoreState       string     `json:"appStoreState,omitempty"`
-	Copyright           string     `json:"copyright,omitempty"`
-	ReleaseType         string     `json:"releaseType,omitempty"`
-	EarliestReleaseDate *time.Time `json:"earliestReleaseDate,omitempty"`
-	Downloadable        bool       `json:"downloadable,omitempty"`
-	CreatedDate         *time.Time `json:"createdDate,omitempty"`
+	Platform            Platform      `json:"platform,omitempty"`
+	VersionString       string        `json:"versionString,omitempty"`
+	AppStoreState       AppStoreState `json:"appStoreState,omitempty"`
+	Copyright           string        `json:"copyright,omitempty"`
+	ReleaseType         ReleaseType   `json:"releaseType,omitempty"`
+	EarliestReleaseDate *time.Time    `json:"earliestReleaseDate,omitempty"`
+	Downloadable        bool          `json:"downloadable,omitempty"`
+	CreatedDate         *time.Time    `json:"createdDate,omitempty"`
 }
 
 // BetaGroup types
@@ -148,35 +206,125 @@ type BetaGroupsResponse struct {
 	Data     []BetaGroup        `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // BetaGroupResponse represents a single beta group.
 type BetaGroupResponse struct {
-	Data     BetaGroup `json:"data"`
-	Included []any     `json:"included,omitempty"`
+	Data     BetaGroup       `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // BetaGroup represents a TestFlight beta group.
 type BetaGroup struct {
-	Type       string              `json:"type"`
-	ID         string              `json:"id"`
-	Attributes BetaGroupAttributes `json:"attributes"`
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id"`
+	Attributes    BetaGroupAttributes     `json:"attributes"`
+	Relationships *BetaGroupRelationships `json:"relationships,omitempty"`
+}
+
+// BetaGroupRelationships contains beta group relationships.
+// Linkage data is only populated when the related resource is included.
+type BetaGroupRelationships struct {
+	App *RelationshipData `json:"app,omitempty"`
 }
 
 // BetaGroupAttributes contains beta group attributes.
 type BetaGroupAttributes struct {
-	Name                             string     `json:"name,omitempty"`
-	CreatedDate                      *time.Time `json:"createdDate,omitempty"`
-	IsInternalGroup                  bool       `json:"isInternalGroup,omitempty"`
-	HasAccessToAllBuilds             bool       `json:"hasAccessToAllBuilds,omitempty"`
-	PublicLinkEnabled                bool       `json:"publicLinkEnabled,omitempty"`
-	PublicLinkID                     string     `json:"publicLinkId,omitempty"`
-	PublicLinkLimitEnabled           bool       `json:"publicLinkLimitEnabled,omitempty"`
-	PublicLinkLimit                  int        `json:"publicLinkLimit,omitempty"`
-	PublicLink                       string     `json:"publicLink,omitempty"`
-	FeedbackEnabled                  bool       `json:"feedbackEnabled,omitempty"`
-	IosBuildsAvailableForTesterCount int        `json:"iosBuildsAvailableForTesterCount,omitempty"`
+	Name                   string     `json:"name,omitempty"`
+	CreatedDate            *time.Time `json:"createdDate,omitempty"`
+	IsInternalGroup        bool       `json:"isInternalGroup,omitempty"`
+	HasAccessToAllBuilds   bool       `json:"hasAccessToAllBuilds,omitempty"`
+	PublicLinkEnabled      bool       `json:"publicLinkEnabled,omitempty"`
+	PublicLinkID           string     `json:"publicLinkId,omitempty"`
+	PublicLinkLimitEnabled bool       `json:"publicLinkLimitEnabled,omitempty"`
+	PublicLinkLimit        int        `json:"publicLinkLimit,omitempty"`
+	PublicLink             string     `json:"publicLink,omitempty"`
+	FeedbackEnabled        bool       `json:"feedbackEnabled,omitempty"`
+}
+
+// BetaPublicLinkUsagesResponse represents public link usage metrics for a beta group.
+type BetaPublicLinkUsagesResponse struct {
+	Data  []BetaPublicLinkUsage `json:"data"`
+	Links PagedDocumentLinks    `json:"links"`
+	Meta  *PagingInformation    `json:"meta,omitempty"`
+}
+
+// BetaPublicLinkUsage contains public link usage for one reporting interval.
+type BetaPublicLinkUsage struct {
+	DataPoints BetaPublicLinkUsageDataPoints `json:"dataPoints"`
+}
+
+// BetaPublicLinkUsageDataPoints contains the interval and values of a public link usage metric.
+type BetaPublicLinkUsageDataPoints struct {
+	Start  *time.Time                `json:"start,omitempty"`
+	End    *time.Time                `json:"end,omitempty"`
+	Values BetaPublicLinkUsageValues `json:"values"`
+}
+
+// BetaPublicLinkUsageValues contains public link view and acceptance counts.
+type BetaPublicLinkUsageValues struct {
+	ViewCount               int     `json:"viewCount,omitempty"`
+	AcceptedCount           int     `json:"acceptedCount,omitempty"`
+	DidNotAcceptCount       int     `json:"didNotAcceptCount,omitempty"`
+	DidNotMeetCriteriaCount int     `json:"didNotMeetCriteriaCount,omitempty"`
+	NotRelevantRatio        float64 `json:"notRelevantRatio,omitempty"`
+	NotClearRatio           float64 `json:"notClearRatio,omitempty"`
+	NotInterestingRatio     float64 `json:"notInterestingRatio,omitempty"`
+}
+
+// BetaTesterUsagesResponse represents tester usage metrics for a beta group.
+type BetaTesterUsagesResponse struct {
+	Data  []BetaTesterUsage  `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// BetaTesterUsage contains tester usage for one reporting interval.
+type BetaTesterUsage struct {
+	DataPoints BetaTesterUsageDataPoints `json:"dataPoints"`
+}
+
+// BetaTesterUsageDataPoints contains the interval and values of a tester usage metric.
+type BetaTesterUsageDataPoints struct {
+	Start  *time.Time            `json:"start,omitempty"`
+	End    *time.Time            `json:"end,omitempty"`
+	Values BetaTesterUsageValues `json:"values"`
+}
+
+// BetaTesterUsageValues contains session, crash, and feedback counts.
+type BetaTesterUsageValues struct {
+	SessionCount  int `json:"sessionCount,omitempty"`
+	CrashCount    int `json:"crashCount,omitempty"`
+	FeedbackCount int `json:"feedbackCount,omitempty"`
+}
+
+// BetaBuildUsagesResponse represents TestFlight usage metrics for a build.
+type BetaBuildUsagesResponse struct {
+	Data  []BetaBuildUsage   `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// BetaBuildUsage contains a build's TestFlight usage for one reporting interval.
+type BetaBuildUsage struct {
+	DataPoints BetaBuildUsageDataPoints `json:"dataPoints"`
+}
+
+// BetaBuildUsageDataPoints contains the interval and values of a build usage metric.
+type BetaBuildUsageDataPoints struct {
+	Start  *time.Time           `json:"start,omitempty"`
+	End    *time.Time           `json:"end,omitempty"`
+	Values BetaBuildUsageValues `json:"values"`
+}
+
+// BetaBuildUsageValues contains install, session, crash, feedback, and invitation counts.
+type BetaBuildUsageValues struct {
+	InstallCount  int `json:"installCount,omitempty"`
+	SessionCount  int `json:"sessionCount,omitempty"`
+	CrashCount    int `json:"crashCount,omitempty"`
+	FeedbackCount int `json:"feedbackCount,omitempty"`
+	InviteCount   int `json:"inviteCount,omitempty"`
 }
 
 // BetaTester types
@@ -186,20 +334,28 @@ type BetaTestersResponse struct {
 	Data     []BetaTester       `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // BetaTesterResponse represents a single beta tester.
 type BetaTesterResponse struct {
-	Data     BetaTester `json:"data"`
-	Included []any      `json:"included,omitempty"`
+	Data     BetaTester      `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // BetaTester represents a TestFlight beta tester.
 type BetaTester struct {
-	Type       string               `json:"type"`
-	ID         string               `json:"id"`
-	Attributes BetaTesterAttributes `json:"attributes"`
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id"`
+	Attributes    BetaTesterAttributes     `json:"attributes"`
+	Relationships *BetaTesterRelationships `json:"relationships,omitempty"`
+}
+
+// BetaTesterRelationships contains beta tester relationships.
+// Linkage data is only populated when the related resource is included.
+type BetaTesterRelationships struct {
+	Apps       *RelationshipDataList `json:"apps,omitempty"`
+	BetaGroups *RelationshipDataList `json:"betaGroups,omitempty"`
 }
 
 // BetaTesterAttributes contains beta tester attributes.
@@ -218,13 +374,13 @@ type BundleIDsResponse struct {
 	Data     []BundleID         `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // BundleIDResponse represents a single bundle ID.
 type BundleIDResponse struct {
-	Data     BundleID `json:"data"`
-	Included []any    `json:"included,omitempty"`
+	Data     BundleID        `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // BundleID represents a registered bundle identifier.
@@ -236,10 +392,10 @@ type BundleID struct {
 
 // BundleIDAttributes contains bundle ID attributes.
 type BundleIDAttributes struct {
-	Name       string `json:"name,omitempty"`
-	Identifier string `json:"identifier,omitempty"`
-	Platform   string `json:"platform,omitempty"`
-	SeedID     string `json:"seedId,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	Identifier string           `json:"identifier,omitempty"`
+	Platform   BundleIDPlatform `json:"platform,omitempty"`
+	SeedID     string           `json:"seedId,omitempty"`
 }
 
 // Device types
@@ -249,13 +405,13 @@ type DevicesResponse struct {
 	Data     []Device           `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // DeviceResponse represents a single device.
 type DeviceResponse struct {
-	Data     Device `json:"data"`
-	Included []any  `json:"included,omitempty"`
+	Data     Device          `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // Device represents a registered device.
@@ -267,13 +423,13 @@ type Device struct {
 
 // DeviceAttributes contains device attributes.
 type DeviceAttributes struct {
-	Name        string     `json:"name,omitempty"`
-	DeviceClass string     `json:"deviceClass,omitempty"`
-	Model       string     `json:"model,omitempty"`
-	UDID        string     `json:"udid,omitempty"`
-	Platform    string     `json:"platform,omitempty"`
-	Status      string     `json:"status,omitempty"`
-	AddedDate   *time.Time `json:"addedDate,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	DeviceClass DeviceClass      `json:"deviceClass,omitempty"`
+	Model       string           `json:"model,omitempty"`
+	UDID        string           `json:"udid,omitempty"`
+	Platform    BundleIDPlatform `json:"platform,omitempty"`
+	Status      DeviceStatus     `json:"status,omitempty"`
+	AddedDate   *time.Time       `json:"addedDate,omitempty"`
 }
 
 // Certificate types
@@ -283,13 +439,13 @@ type CertificatesResponse struct {
 	Data     []Certificate      `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // CertificateResponse represents a single certificate.
 type CertificateResponse struct {
-	Data     Certificate `json:"data"`
-	Included []any       `json:"included,omitempty"`
+	Data     Certificate     `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // Certificate represents a signing certificate.
@@ -301,13 +457,13 @@ type Certificate struct {
 
 // CertificateAttributes contains certificate attributes.
 type CertificateAttributes struct {
-	Name               string     `json:"name,omitempty"`
-	CertificateType    string     `json:"certificateType,omitempty"`
-	DisplayName        string     `json:"displayName,omitempty"`
-	SerialNumber       string     `json:"serialNumber,omitempty"`
-	Platform           string     `json:"platform,omitempty"`
-	ExpirationDate     *time.Time `json:"expirationDate,omitempty"`
-	CertificateContent string     `json:"certificateContent,omitempty"`
+	Name               string           `json:"name,omitempty"`
+	CertificateType    CertificateType  `json:"certificateType,omitempty"`
+	DisplayName        string           `json:"displayName,omitempty"`
+	SerialNumber       string           `json:"serialNumber,omitempty"`
+	Platform           BundleIDPlatform `json:"platform,omitempty"`
+	ExpirationDate     *time.Time       `json:"expirationDate,omitempty"`
+	CertificateContent string           `json:"certificateContent,omitempty"`
 }
 
 // Profile types
@@ -317,13 +473,13 @@ type ProfilesResponse struct {
 	Data     []Profile          `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // ProfileResponse represents a single provisioning profile.
 type ProfileResponse struct {
-	Data     Profile `json:"data"`
-	Included []any   `json:"included,omitempty"`
+	Data     Profile         `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // Profile represents a provisioning profile.
@@ -335,14 +491,14 @@ type Profile struct {
 
 // ProfileAttributes contains provisioning profile attributes.
 type ProfileAttributes struct {
-	Name           string     `json:"name,omitempty"`
-	Platform       string     `json:"platform,omitempty"`
-	ProfileType    string     `json:"profileType,omitempty"`
-	ProfileState   string     `json:"profileState,omitempty"`
-	ProfileContent string     `json:"profileContent,omitempty"`
-	UUID           string     `json:"uuid,omitempty"`
-	CreatedDate    *time.Time `json:"createdDate,omitempty"`
-	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	Name           string           `json:"name,omitempty"`
+	Platform       BundleIDPlatform `json:"platform,omitempty"`
+	ProfileType    ProfileType      `json:"profileType,omitempty"`
+	ProfileState   ProfileState     `json:"profileState,omitempty"`
+	ProfileContent string           `json:"profileContent,omitempty"`
+	UUID           string           `json:"uuid,omitempty"`
+	CreatedDate    *time.Time       `json:"createdDate,omitempty"`
+	ExpirationDate *time.Time       `json:"expirationDate,omitempty"`
 }
 
 // Request types for creating/updating resources
@@ -361,7 +517,7 @@ type BetaGroupCreateData struct {
 
 // BetaGroupCreateAttributes contains attributes for creating a beta group.
 type BetaGroupCreateAttributes struct {
-	Name                   string `json:"name"`
+	Name                   string `json:"name" validate:"required"`
 	IsInternalGroup        bool   `json:"isInternalGroup,omitempty"`
 	HasAccessToAllBuilds   bool   `json:"hasAccessToAllBuilds,omitempty"`
 	PublicLinkEnabled      bool   `json:"publicLinkEnabled,omitempty"`
@@ -372,7 +528,7 @@ type BetaGroupCreateAttributes struct {
 
 // BetaGroupCreateRelationships contains relationships for creating a beta group.
 type BetaGroupCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // RelationshipData contains relationship data.
@@ -400,7 +556,7 @@ type BetaTesterCreateData struct {
 
 // BetaTesterCreateAttributes contains attributes for creating a beta tester.
 type BetaTesterCreateAttributes struct {
-	Email     string `json:"email"`
+	Email     string `json:"email" validate:"required"`
 	FirstName string `json:"firstName,omitempty"`
 	LastName  string `json:"lastName,omitempty"`
 }
@@ -429,9 +585,9 @@ type DeviceCreateData struct {
 
 // DeviceCreateAttributes contains attributes for registering a device.
 type DeviceCreateAttributes struct {
-	Name     string `json:"name"`
-	UDID     string `json:"udid"`
-	Platform string `json:"platform"`
+	Name     string           `json:"name" validate:"required"`
+	UDID     string           `json:"udid" validate:"required"`
+	Platform BundleIDPlatform `json:"platform" validate:"required,enum=bundleIdPlatform"`
 }
 
 // AppInfo types
@@ -441,13 +597,13 @@ type AppInfosResponse struct {
 	Data     []AppInfo          `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppInfoResponse represents a single app info.
 type AppInfoResponse struct {
-	Data     AppInfo `json:"data"`
-	Included []any   `json:"included,omitempty"`
+	Data     AppInfo         `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppInfo represents app information.
@@ -459,14 +615,14 @@ type AppInfo struct {
 
 // AppInfoAttributes contains app info attributes.
 type AppInfoAttributes struct {
-	AppStoreState     string `json:"appStoreState,omitempty"`
-	AppStoreAgeRating string `json:"appStoreAgeRating,omitempty"`
-	BrazilAgeRating   string `json:"brazilAgeRating,omitempty"`
-	KidsAgeBand       string `json:"kidsAgeBand,omitempty"`
-	BrazilAgeRatingV2 string `json:"brazilAgeRatingV2,omitempty"`
-	State             string `json:"state,omitempty"`
-	PrimaryCategory   string `json:"primaryCategory,omitempty"`
-	SecondaryCategory string `json:"secondaryCategory,omitempty"`
+	AppStoreState     AppStoreState `json:"appStoreState,omitempty"`
+	AppStoreAgeRating string        `json:"appStoreAgeRating,omitempty"`
+	BrazilAgeRating   string        `json:"brazilAgeRating,omitempty"`
+	KidsAgeBand       string        `json:"kidsAgeBand,omitempty"`
+	BrazilAgeRatingV2 string        `json:"brazilAgeRatingV2,omitempty"`
+	State             string        `json:"state,omitempty"`
+	PrimaryCategory   string        `json:"primaryCategory,omitempty"`
+	SecondaryCategory string        `json:"secondaryCategory,omitempty"`
 }
 
 // AppInfoLocalization types
@@ -476,13 +632,13 @@ type AppInfoLocalizationsResponse struct {
 	Data     []AppInfoLocalization `json:"data"`
 	Links    PagedDocumentLinks    `json:"links"`
 	Meta     *PagingInformation    `json:"meta,omitempty"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // AppInfoLocalizationResponse represents a single app info localization.
 type AppInfoLocalizationResponse struct {
 	Data     AppInfoLocalization `json:"data"`
-	Included []any               `json:"included,omitempty"`
+	Included json.RawMessage     `json:"included,omitempty"`
 }
 
 // AppInfoLocalization represents localized app information.
@@ -516,9 +672,9 @@ type AppInfoLocalizationCreateData struct {
 
 // AppInfoLocalizationCreateAttributes contains attributes for creating an app info localization.
 type AppInfoLocalizationCreateAttributes struct {
-	Locale            string `json:"locale"`
-	Name              string `json:"name"`
-	Subtitle          string `json:"subtitle,omitempty"`
+	Locale            string `json:"locale" validate:"required,locale"`
+	Name              string `json:"name" validate:"required,max=30"`
+	Subtitle          string `json:"subtitle,omitempty" validate:"max=30"`
 	PrivacyPolicyURL  string `json:"privacyPolicyUrl,omitempty"`
 	PrivacyChoicesURL string `json:"privacyChoicesUrl,omitempty"`
 	PrivacyPolicyText string `json:"privacyPolicyText,omitempty"`
@@ -526,7 +682,7 @@ type AppInfoLocalizationCreateAttributes struct {
 
 // AppInfoLocalizationCreateRelationships contains relationships for creating an app info localization.
 type AppInfoLocalizationCreateRelationships struct {
-	AppInfo RelationshipData `json:"appInfo"`
+	AppInfo RelationshipData `json:"appInfo" validate:"required"`
 }
 
 // AppInfoLocalizationUpdateRequest represents a request to update an app info localization.
@@ -543,8 +699,8 @@ type AppInfoLocalizationUpdateData struct {
 
 // AppInfoLocalizationUpdateAttributes contains attributes for updating an app info localization.
 type AppInfoLocalizationUpdateAttributes struct {
-	Name              string `json:"name,omitempty"`
-	Subtitle          string `json:"subtitle,omitempty"`
+	Name              string `json:"name,omitempty" validate:"max=30"`
+	Subtitle          string `json:"subtitle,omitempty" validate:"max=30"`
 	PrivacyPolicyURL  string `json:"privacyPolicyUrl,omitempty"`
 	PrivacyChoicesURL string `json:"privacyChoicesUrl,omitempty"`
 	PrivacyPolicyText string `json:"privacyPolicyText,omitempty"`
@@ -557,13 +713,13 @@ type AppStoreVersionLocalizationsResponse struct {
 	Data     []AppStoreVersionLocalization `json:"data"`
 	Links    PagedDocumentLinks            `json:"links"`
 	Meta     *PagingInformation            `json:"meta,omitempty"`
-	Included []any                         `json:"included,omitempty"`
+	Included json.RawMessage               `json:"included,omitempty"`
 }
 
 // AppStoreVersionLocalizationResponse represents a single version localization.
 type AppStoreVersionLocalizationResponse struct {
 	Data     AppStoreVersionLocalization `json:"data"`
-	Included []any                       `json:"included,omitempty"`
+	Included json.RawMessage             `json:"included,omitempty"`
 }
 
 // AppStoreVersionLocalization represents a localized app store version.
@@ -598,18 +754,18 @@ type AppStoreVersionLocalizationCreateData struct {
 
 // AppStoreVersionLocalizationCreateAttributes contains attributes for creating a version localization.
 type AppStoreVersionLocalizationCreateAttributes struct {
-	Locale          string `json:"locale"`
-	Description     string `json:"description,omitempty"`
-	Keywords        string `json:"keywords,omitempty"`
-	WhatsNew        string `json:"whatsNew,omitempty"`
-	PromotionalText string `json:"promotionalText,omitempty"`
+	Locale          string `json:"locale" validate:"required,locale"`
+	Description     string `json:"description,omitempty" validate:"max=4000"`
+	Keywords        string `json:"keywords,omitempty" validate:"max=100"`
+	WhatsNew        string `json:"whatsNew,omitempty" validate:"max=4000"`
+	PromotionalText string `json:"promotionalText,omitempty" validate:"max=170"`
 	MarketingURL    string `json:"marketingUrl,omitempty"`
 	SupportURL      string `json:"supportUrl,omitempty"`
 }
 
 // AppStoreVersionLocalizationCreateRelationships contains relationships for creating a version localization.
 type AppStoreVersionLocalizationCreateRelationships struct {
-	AppStoreVersion RelationshipData `json:"appStoreVersion"`
+	AppStoreVersion RelationshipData `json:"appStoreVersion" validate:"required"`
 }
 
 // AppStoreVersionLocalizationUpdateRequest represents a request to update a version localization.
@@ -626,10 +782,10 @@ type AppStoreVersionLocalizationUpdateData struct {
 
 // AppStoreVersionLocalizationUpdateAttributes contains attributes for updating a version localization.
 type AppStoreVersionLocalizationUpdateAttributes struct {
-	Description     string `json:"description,omitempty"`
-	Keywords        string `json:"keywords,omitempty"`
-	WhatsNew        string `json:"whatsNew,omitempty"`
-	PromotionalText string `json:"promotionalText,omitempty"`
+	Description     string `json:"description,omitempty" validate:"max=4000"`
+	Keywords        string `json:"keywords,omitempty" validate:"max=100"`
+	WhatsNew        string `json:"whatsNew,omitempty" validate:"max=4000"`
+	PromotionalText string `json:"promotionalText,omitempty" validate:"max=170"`
 	MarketingURL    string `json:"marketingUrl,omitempty"`
 	SupportURL      string `json:"supportUrl,omitempty"`
 }
@@ -641,13 +797,13 @@ type CustomerReviewsResponse struct {
 	Data     []CustomerReview   `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // CustomerReviewResponse represents a single customer review.
 type CustomerReviewResponse struct {
-	Data     CustomerReview `json:"data"`
-	Included []any          `json:"included,omitempty"`
+	Data     CustomerReview  `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // CustomerReview represents a customer review.
@@ -684,7 +840,7 @@ type CustomerReviewResponseV1Attributes struct {
 // CustomerReviewResponseV1Response represents a single review response.
 type CustomerReviewResponseV1Response struct {
 	Data     CustomerReviewResponseV1 `json:"data"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // CustomerReviewResponseCreateRequest represents a request to create a review response.
@@ -701,12 +857,12 @@ type CustomerReviewResponseCreateData struct {
 
 // CustomerReviewResponseCreateAttributes contains attributes for creating a review response.
 type CustomerReviewResponseCreateAttributes struct {
-	ResponseBody string `json:"responseBody"`
+	ResponseBody string `json:"responseBody" validate:"required,max=5970"`
 }
 
 // CustomerReviewResponseCreateRelationships contains relationships for creating a review response.
 type CustomerReviewResponseCreateRelationships struct {
-	Review RelationshipData `json:"review"`
+	Review RelationshipData `json:"review" validate:"required"`
 }
 
 // In-App Purchase types
@@ -716,13 +872,13 @@ type InAppPurchasesResponse struct {
 	Data     []InAppPurchase    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // InAppPurchaseResponse represents a single in-app purchase.
 type InAppPurchaseResponse struct {
-	Data     InAppPurchase `json:"data"`
-	Included []any         `json:"included,omitempty"`
+	Data     InAppPurchase   `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // InAppPurchase represents an in-app purchase.
@@ -734,14 +890,13 @@ type InAppPurchase struct {
 
 // InAppPurchaseAttributes contains in-app purchase attributes.
 type InAppPurchaseAttributes struct {
-	Name                      string `json:"name,omitempty"`
-	ProductID                 string `json:"productId,omitempty"`
-	InAppPurchaseType         string `json:"inAppPurchaseType,omitempty"`
-	State                     string `json:"state,omitempty"`
-	ReviewNote                string `json:"reviewNote,omitempty"`
-	FamilySharable            bool   `json:"familySharable,omitempty"`
-	ContentHosting            bool   `json:"contentHosting,omitempty"`
-	AvailableInAllTerritories bool   `json:"availableInAllTerritories,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	ProductID         string            `json:"productId,omitempty"`
+	InAppPurchaseType InAppPurchaseType `json:"inAppPurchaseType,omitempty"`
+	State             string            `json:"state,omitempty"`
+	ReviewNote        string            `json:"reviewNote,omitempty"`
+	FamilySharable    bool              `json:"familySharable,omitempty"`
+	ContentHosting    bool              `json:"contentHosting,omitempty"`
 }
 
 // InAppPurchaseCreateRequest represents a request to create an in-app purchase.
@@ -758,17 +913,16 @@ type InAppPurchaseCreateData struct {
 
 // InAppPurchaseCreateAttributes contains attributes for creating an in-app purchase.
 type InAppPurchaseCreateAttributes struct {
-	Name                      string `json:"name"`
-	ProductID                 string `json:"productId"`
-	InAppPurchaseType         string `json:"inAppPurchaseType"`
-	ReviewNote                string `json:"reviewNote,omitempty"`
-	FamilySharable            bool   `json:"familySharable,omitempty"`
-	AvailableInAllTerritories bool   `json:"availableInAllTerritories,omitempty"`
+	Name              string            `json:"name" validate:"required"`
+	ProductID         string            `json:"productId" validate:"required"`
+	InAppPurchaseType InAppPurchaseType `json:"inAppPurchaseType" validate:"required,enum=inAppPurchaseType"`
+	ReviewNote        string            `json:"reviewNote,omitempty"`
+	FamilySharable    bool              `json:"familySharable,omitempty"`
 }
 
 // InAppPurchaseCreateRelationships contains relationships for creating an in-app purchase.
 type InAppPurchaseCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // InAppPurchaseUpdateRequest represents a request to update an in-app purchase.
@@ -785,10 +939,9 @@ type InAppPurchaseUpdateData struct {
 
 // InAppPurchaseUpdateAttributes contains attributes for updating an in-app purchase.
 type InAppPurchaseUpdateAttributes struct {
-	Name                      string `json:"name,omitempty"`
-	ReviewNote                string `json:"reviewNote,omitempty"`
-	FamilySharable            *bool  `json:"familySharable,omitempty"`
-	AvailableInAllTerritories *bool  `json:"availableInAllTerritories,omitempty"`
+	Name           string `json:"name,omitempty"`
+	ReviewNote     string `json:"reviewNote,omitempty"`
+	FamilySharable *bool  `json:"familySharable,omitempty"`
 }
 
 // Subscription types
@@ -798,13 +951,13 @@ type SubscriptionsResponse struct {
 	Data     []Subscription     `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // SubscriptionResponse represents a single subscription.
 type SubscriptionResponse struct {
-	Data     Subscription `json:"data"`
-	Included []any        `json:"included,omitempty"`
+	Data     Subscription    `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // Subscription represents an auto-renewable subscription.
@@ -831,13 +984,13 @@ type SubscriptionGroupsResponse struct {
 	Data     []SubscriptionGroup `json:"data"`
 	Links    PagedDocumentLinks  `json:"links"`
 	Meta     *PagingInformation  `json:"meta,omitempty"`
-	Included []any               `json:"included,omitempty"`
+	Included json.RawMessage     `json:"included,omitempty"`
 }
 
 // SubscriptionGroupResponse represents a single subscription group.
 type SubscriptionGroupResponse struct {
 	Data     SubscriptionGroup `json:"data"`
-	Included []any             `json:"included,omitempty"`
+	Included json.RawMessage   `json:"included,omitempty"`
 }
 
 // SubscriptionGroup represents a subscription group.
@@ -857,7 +1010,7 @@ type SubscriptionGroupAttributes struct {
 // AppStoreVersionSubmissionResponse represents a version submission response.
 type AppStoreVersionSubmissionResponse struct {
 	Data     AppStoreVersionSubmission `json:"data"`
-	Included []any                     `json:"included,omitempty"`
+	Included json.RawMessage           `json:"included,omitempty"`
 }
 
 // AppStoreVersionSubmission represents a version submission.
@@ -896,16 +1049,16 @@ type AppStoreVersionCreateData struct {
 
 // AppStoreVersionCreateAttributes contains attributes for creating a version.
 type AppStoreVersionCreateAttributes struct {
-	Platform            string     `json:"platform"`
-	VersionString       string     `json:"versionString"`
-	Copyright           string     `json:"copyright,omitempty"`
-	ReleaseType         string     `json:"releaseType,omitempty"`
-	EarliestReleaseDate *time.Time `json:"earliestReleaseDate,omitempty"`
+	Platform            Platform    `json:"platform" validate:"required,enum=platform"`
+	VersionString       string      `json:"versionString" validate:"required"`
+	Copyright           string      `json:"copyright,omitempty"`
+	ReleaseType         ReleaseType `json:"releaseType,omitempty" validate:"enum=releaseType"`
+	EarliestReleaseDate *time.Time  `json:"earliestReleaseDate,omitempty"`
 }
 
 // AppStoreVersionCreateRelationships contains relationships for creating a version.
 type AppStoreVersionCreateRelationships struct {
-	App   RelationshipData  `json:"app"`
+	App   RelationshipData  `json:"app" validate:"required"`
 	Build *RelationshipData `json:"build,omitempty"`
 }
 
@@ -923,11 +1076,11 @@ type AppStoreVersionUpdateData struct {
 
 // AppStoreVersionUpdateAttributes contains attributes for updating a version.
 type AppStoreVersionUpdateAttributes struct {
-	VersionString       string     `json:"versionString,omitempty"`
-	Copyright           string     `json:"copyright,omitempty"`
-	ReleaseType         string     `json:"releaseType,omitempty"`
-	EarliestReleaseDate *time.Time `json:"earliestReleaseDate,omitempty"`
-	Downloadable        *bool      `json:"downloadable,omitempty"`
+	VersionString       string      `json:"versionString,omitempty"`
+	Copyright           string      `json:"copyright,omitempty"`
+	ReleaseType         ReleaseType `json:"releaseType,omitempty" validate:"enum=releaseType"`
+	EarliestReleaseDate *time.Time  `json:"earliestReleaseDate,omitempty"`
+	Downloadable        *bool       `json:"downloadable,omitempty"`
 }
 
 // App Store Review Detail types
@@ -935,7 +1088,7 @@ type AppStoreVersionUpdateAttributes struct {
 // AppStoreReviewDetailResponse represents app store review detail.
 type AppStoreReviewDetailResponse struct {
 	Data     AppStoreReviewDetail `json:"data"`
-	Included []any                `json:"included,omitempty"`
+	Included json.RawMessage      `json:"included,omitempty"`
 }
 
 // AppStoreReviewDetail represents review details for submission.
@@ -978,12 +1131,12 @@ type AppStoreReviewDetailCreateAttributes struct {
 	DemoAccountName     string `json:"demoAccountName,omitempty"`
 	DemoAccountPassword string `json:"demoAccountPassword,omitempty"`
 	DemoAccountRequired *bool  `json:"demoAccountRequired,omitempty"`
-	Notes               string `json:"notes,omitempty"`
+	Notes               string `json:"notes,omitempty" validate:"max=4000"`
 }
 
 // AppStoreReviewDetailCreateRelationships contains relationships for creating review details.
 type AppStoreReviewDetailCreateRelationships struct {
-	AppStoreVersion RelationshipData `json:"appStoreVersion"`
+	AppStoreVersion RelationshipData `json:"appStoreVersion" validate:"required"`
 }
 
 // AppStoreReviewDetailUpdateRequest represents a request to update review details.
@@ -1007,7 +1160,7 @@ type AppStoreReviewDetailUpdateAttributes struct {
 	DemoAccountName     string `json:"demoAccountName,omitempty"`
 	DemoAccountPassword string `json:"demoAccountPassword,omitempty"`
 	DemoAccountRequired *bool  `json:"demoAccountRequired,omitempty"`
-	Notes               string `json:"notes,omitempty"`
+	Notes               string `json:"notes,omitempty" validate:"max=4000"`
 }
 
 // Phased Release types
@@ -1015,7 +1168,7 @@ type AppStoreReviewDetailUpdateAttributes struct {
 // AppStoreVersionPhasedReleaseResponse represents a phased release response.
 type AppStoreVersionPhasedReleaseResponse struct {
 	Data     AppStoreVersionPhasedRelease `json:"data"`
-	Included []any                        `json:"included,omitempty"`
+	Included json.RawMessage              `json:"included,omitempty"`
 }
 
 // AppStoreVersionPhasedRelease represents a phased release.
@@ -1027,10 +1180,10 @@ type AppStoreVersionPhasedRelease struct {
 
 // AppStoreVersionPhasedReleaseAttributes contains phased release attributes.
 type AppStoreVersionPhasedReleaseAttributes struct {
-	PhasedReleaseState string     `json:"phasedReleaseState,omitempty"`
-	StartDate          *time.Time `json:"startDate,omitempty"`
-	TotalPauseDuration int        `json:"totalPauseDuration,omitempty"`
-	CurrentDayNumber   int        `json:"currentDayNumber,omitempty"`
+	PhasedReleaseState PhasedReleaseState `json:"phasedReleaseState,omitempty"`
+	StartDate          *time.Time         `json:"startDate,omitempty"`
+	TotalPauseDuration int                `json:"totalPauseDuration,omitempty"`
+	CurrentDayNumber   int                `json:"currentDayNumber,omitempty"`
 }
 
 // AppStoreVersionPhasedReleaseCreateRequest represents a request to create a phased release.
@@ -1047,12 +1200,12 @@ type AppStoreVersionPhasedReleaseCreateData struct {
 
 // AppStoreVersionPhasedReleaseCreateAttributes contains attributes for creating a phased release.
 type AppStoreVersionPhasedReleaseCreateAttributes struct {
-	PhasedReleaseState string `json:"phasedReleaseState,omitempty"`
+	PhasedReleaseState PhasedReleaseState `json:"phasedReleaseState,omitempty" validate:"enum=phasedReleaseState"`
 }
 
 // AppStoreVersionPhasedReleaseCreateRelationships contains relationships for creating a phased release.
 type AppStoreVersionPhasedReleaseCreateRelationships struct {
-	AppStoreVersion RelationshipData `json:"appStoreVersion"`
+	AppStoreVersion RelationshipData `json:"appStoreVersion" validate:"required"`
 }
 
 // AppStoreVersionPhasedReleaseUpdateRequest represents a request to update a phased release.
@@ -1069,7 +1222,7 @@ type AppStoreVersionPhasedReleaseUpdateData struct {
 
 // AppStoreVersionPhasedReleaseUpdateAttributes contains attributes for updating a phased release.
 type AppStoreVersionPhasedReleaseUpdateAttributes struct {
-	PhasedReleaseState string `json:"phasedReleaseState,omitempty"`
+	PhasedReleaseState PhasedReleaseState `json:"phasedReleaseState,omitempty" validate:"enum=phasedReleaseState"`
 }
 
 // App Screenshot types
@@ -1079,13 +1232,13 @@ type AppScreenshotSetsResponse struct {
 	Data     []AppScreenshotSet `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppScreenshotSetResponse represents a single screenshot set.
 type AppScreenshotSetResponse struct {
 	Data     AppScreenshotSet `json:"data"`
-	Included []any            `json:"included,omitempty"`
+	Included json.RawMessage  `json:"included,omitempty"`
 }
 
 // AppScreenshotSet represents a screenshot set.
@@ -1105,13 +1258,13 @@ type AppScreenshotsResponse struct {
 	Data     []AppScreenshot    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppScreenshotResponse represents a single screenshot.
 type AppScreenshotResponse struct {
-	Data     AppScreenshot `json:"data"`
-	Included []any         `json:"included,omitempty"`
+	Data     AppScreenshot   `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppScreenshot represents an app screenshot.
@@ -1157,9 +1310,15 @@ type RequestHeader struct {
 
 // AssetDeliveryState represents asset delivery state.
 type AssetDeliveryState struct {
-	Errors   []APIError `json:"errors,omitempty"`
-	Warnings []APIError `json:"warnings,omitempty"`
-	State    string     `json:"state,omitempty"`
+	Errors   []AppMediaStateError `json:"errors,omitempty"`
+	Warnings []AppMediaStateError `json:"warnings,omitempty"`
+	State    string               `json:"state,omitempty"`
+}
+
+// AppMediaStateError describes a problem found while processing an uploaded asset.
+type AppMediaStateError struct {
+	Code        string `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // AppScreenshotCreateRequest represents a request to create a screenshot.
@@ -1176,13 +1335,13 @@ type AppScreenshotCreateData struct {
 
 // AppScreenshotCreateAttributes contains attributes for creating a screenshot.
 type AppScreenshotCreateAttributes struct {
-	FileSize int    `json:"fileSize"`
-	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize" validate:"required"`
+	FileName string `json:"fileName" validate:"required"`
 }
 
 // AppScreenshotCreateRelationships contains relationships for creating a screenshot.
 type AppScreenshotCreateRelationships struct {
-	AppScreenshotSet RelationshipData `json:"appScreenshotSet"`
+	AppScreenshotSet RelationshipData `json:"appScreenshotSet" validate:"required"`
 }
 
 // AppScreenshotUpdateRequest represents a request to update a screenshot.
@@ -1210,13 +1369,13 @@ type AppPreviewSetsResponse struct {
 	Data     []AppPreviewSet    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppPreviewSetResponse represents a single preview set.
 type AppPreviewSetResponse struct {
-	Data     AppPreviewSet `json:"data"`
-	Included []any         `json:"included,omitempty"`
+	Data     AppPreviewSet   `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppPreviewSet represents a preview set.
@@ -1236,13 +1395,13 @@ type AppPreviewsResponse struct {
 	Data     []AppPreview       `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppPreviewResponse represents a single preview.
 type AppPreviewResponse struct {
-	Data     AppPreview `json:"data"`
-	Included []any      `json:"included,omitempty"`
+	Data     AppPreview      `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppPreview represents an app preview.
@@ -1279,23 +1438,42 @@ type AppPreviewCreateData struct {
 
 // AppPreviewCreateAttributes contains attributes for creating a preview.
 type AppPreviewCreateAttributes struct {
-	FileSize             int    `json:"fileSize"`
-	FileName             string `json:"fileName"`
+	FileSize             int    `json:"fileSize" validate:"required"`
+	FileName             string `json:"fileName" validate:"required"`
 	PreviewFrameTimeCode string `json:"previewFrameTimeCode,omitempty"`
 	MimeType             string `json:"mimeType,omitempty"`
 }
 
 // AppPreviewCreateRelationships contains relationships for creating a preview.
 type AppPreviewCreateRelationships struct {
-	AppPreviewSet RelationshipData `json:"appPreviewSet"`
+	AppPreviewSet RelationshipData `json:"appPreviewSet" validate:"required"`
+}
+
+// AppPreviewUpdateRequest represents a request to update a preview.
+type AppPreviewUpdateRequest struct {
+	Data AppPreviewUpdateData `json:"data"`
+}
+
+// AppPreviewUpdateData contains the data for updating a preview.
+type AppPreviewUpdateData struct {
+	Type       string                     `json:"type"`
+	ID         string                     `json:"id"`
+	Attributes AppPreviewUpdateAttributes `json:"attributes"`
+}
+
+// AppPreviewUpdateAttributes contains attributes for updating a preview.
+type AppPreviewUpdateAttributes struct {
+	SourceFileChecksum   string `json:"sourceFileChecksum,omitempty"`
+	PreviewFrameTimeCode string `json:"previewFrameTimeCode,omitempty"`
+	Uploaded             *bool  `json:"uploaded,omitempty"`
 }
 
 // App Pre-Order types
 
 // AppPreOrderResponse represents a pre-order response.
 type AppPreOrderResponse struct {
-	Data     AppPreOrder `json:"data"`
-	Included []any       `json:"included,omitempty"`
+	Data     AppPreOrder     `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppPreOrder represents an app pre-order.
@@ -1357,13 +1535,13 @@ type AppEventsResponse struct {
 	Data     []AppEvent         `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppEventResponse represents a single app event.
 type AppEventResponse struct {
-	Data     AppEvent `json:"data"`
-	Included []any    `json:"included,omitempty"`
+	Data     AppEvent        `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppEvent represents an app event.
@@ -1409,19 +1587,19 @@ type AppEventCreateData struct {
 
 // AppEventCreateAttributes contains attributes for creating an app event.
 type AppEventCreateAttributes struct {
-	ReferenceName       string              `json:"referenceName"`
-	Badge               string              `json:"badge,omitempty"`
+	ReferenceName       string              `json:"referenceName" validate:"required"`
+	Badge               string              `json:"badge,omitempty" validate:"enum=appEventBadge"`
 	DeepLink            string              `json:"deepLink,omitempty"`
 	PurchaseRequirement string              `json:"purchaseRequirement,omitempty"`
 	PrimaryLocale       string              `json:"primaryLocale,omitempty"`
-	Priority            string              `json:"priority,omitempty"`
-	Purpose             string              `json:"purpose,omitempty"`
+	Priority            string              `json:"priority,omitempty" validate:"enum=priority"`
+	Purpose             string              `json:"purpose,omitempty" validate:"enum=appEventPurpose"`
 	TerritorySchedules  []TerritorySchedule `json:"territorySchedules,omitempty"`
 }
 
 // AppEventCreateRelationships contains relationships for creating an app event.
 type AppEventCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // AppEventUpdateRequest represents a request to update an app event.
@@ -1439,15 +1617,122 @@ type AppEventUpdateData struct {
 // AppEventUpdateAttributes contains attributes for updating an app event.
 type AppEventUpdateAttributes struct {
 	ReferenceName       string              `json:"referenceName,omitempty"`
-	Badge               string              `json:"badge,omitempty"`
+	Badge               string              `json:"badge,omitempty" validate:"enum=appEventBadge"`
 	DeepLink            string              `json:"deepLink,omitempty"`
 	PurchaseRequirement string              `json:"purchaseRequirement,omitempty"`
 	PrimaryLocale       string              `json:"primaryLocale,omitempty"`
-	Priority            string              `json:"priority,omitempty"`
-	Purpose             string              `json:"purpose,omitempty"`
+	Priority            string              `json:"priority,omitempty" validate:"enum=priority"`
+	Purpose             string              `json:"purpose,omitempty" validate:"enum=appEventPurpose"`
 	TerritorySchedules  []TerritorySchedule `json:"territorySchedules,omitempty"`
 }
 
+// Review submission types
+
+// ReviewSubmissionsResponse represents a list of review submissions.
+type ReviewSubmissionsResponse struct {
+	Data     []ReviewSubmission `json:"data"`
+	Links    PagedDocumentLinks `json:"links"`
+	Meta     *PagingInformation `json:"meta,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
+}
+
+// ReviewSubmissionResponse represents a single review submission.
+type ReviewSubmissionResponse struct {
+	Data     ReviewSubmission `json:"data"`
+	Included json.RawMessage  `json:"included,omitempty"`
+}
+
+// ReviewSubmission represents a set of items, such as app events, submitted
+// to App Review together.
+type ReviewSubmission struct {
+	Type       string                     `json:"type"`
+	ID         string                     `json:"id"`
+	Attributes ReviewSubmissionAttributes `json:"attributes"`
+}
+
+// ReviewSubmissionAttributes contains review submission attributes.
+type ReviewSubmissionAttributes struct {
+	Platform      Platform   `json:"platform,omitempty"`
+	State         string     `json:"state,omitempty"`
+	SubmittedDate *time.Time `json:"submittedDate,omitempty"`
+}
+
+// ReviewSubmissionCreateRequest represents a request to create a review submission.
+type ReviewSubmissionCreateRequest struct {
+	Data ReviewSubmissionCreateData `json:"data"`
+}
+
+// ReviewSubmissionCreateData contains the data for creating a review submission.
+type ReviewSubmissionCreateData struct {
+	Type          string                              `json:"type"`
+	Attributes    ReviewSubmissionCreateAttributes    `json:"attributes"`
+	Relationships ReviewSubmissionCreateRelationships `json:"relationships"`
+}
+
+// ReviewSubmissionCreateAttributes contains attributes for creating a review submission.
+type ReviewSubmissionCreateAttributes struct {
+	Platform Platform `json:"platform" validate:"enum=platform"`
+}
+
+// ReviewSubmissionCreateRelationships contains relationships for creating a review submission.
+type ReviewSubmissionCreateRelationships struct {
+	App RelationshipData `json:"app" validate:"required"`
+}
+
+// ReviewSubmissionUpdateRequest represents a request to submit or cancel a review submission.
+type ReviewSubmissionUpdateRequest struct {
+	Data ReviewSubmissionUpdateData `json:"data"`
+}
+
+// ReviewSubmissionUpdateData contains the data for updating a review submission.
+type ReviewSubmissionUpdateData struct {
+	Type       string                           `json:"type"`
+	ID         string                           `json:"id"`
+	Attributes ReviewSubmissionUpdateAttributes `json:"attributes"`
+}
+
+// ReviewSubmissionUpdateAttributes contains attributes for updating a review submission.
+type ReviewSubmissionUpdateAttributes struct {
+	Submitted *bool `json:"submitted,omitempty"`
+	Canceled  *bool `json:"canceled,omitempty"`
+}
+
+// ReviewSubmissionItemResponse represents a single review submission item.
+type ReviewSubmissionItemResponse struct {
+	Data     ReviewSubmissionItem `json:"data"`
+	Included json.RawMessage      `json:"included,omitempty"`
+}
+
+// ReviewSubmissionItem represents one item of a review submission.
+type ReviewSubmissionItem struct {
+	Type       string                         `json:"type"`
+	ID         string                         `json:"id"`
+	Attributes ReviewSubmissionItemAttributes `json:"attributes"`
+}
+
+// ReviewSubmissionItemAttributes contains review submission item attributes.
+type ReviewSubmissionItemAttributes struct {
+	State string `json:"state,omitempty"`
+}
+
+// ReviewSubmissionItemCreateRequest represents a request to add an item to a review submission.
+type ReviewSubmissionItemCreateRequest struct {
+	Data ReviewSubmissionItemCreateData `json:"data"`
+}
+
+// ReviewSubmissionItemCreateData contains the data for creating a review submission item.
+type ReviewSubmissionItemCreateData struct {
+	Type          string                                  `json:"type"`
+	Relationships ReviewSubmissionItemCreateRelationships `json:"relationships"`
+}
+
+// ReviewSubmissionItemCreateRelationships contains relationships for creating a
+// review submission item. Exactly one item relationship is set.
+type ReviewSubmissionItemCreateRelationships struct {
+	ReviewSubmission RelationshipData  `json:"reviewSubmission" validate:"required"`
+	AppEvent         *RelationshipData `json:"appEvent,omitempty"`
+}
+
 // Analytics types
 
 // AnalyticsReportRequestsResponse represents a list of analytics report requests.
@@ -1455,13 +1740,13 @@ type AnalyticsReportRequestsResponse struct {
 	Data     []AnalyticsReportRequest `json:"data"`
 	Links    PagedDocumentLinks       `json:"links"`
 	Meta     *PagingInformation       `json:"meta,omitempty"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // AnalyticsReportRequestResponse represents a single analytics report request.
 type AnalyticsReportRequestResponse struct {
 	Data     AnalyticsReportRequest `json:"data"`
-	Included []any                  `json:"included,omitempty"`
+	Included json.RawMessage        `json:"included,omitempty"`
 }
 
 // AnalyticsReportRequest represents an analytics report request.
@@ -1473,8 +1758,8 @@ type AnalyticsReportRequest struct {
 
 // AnalyticsReportRequestAttributes contains analytics report request attributes.
 type AnalyticsReportRequestAttributes struct {
-	AccessType string `json:"accessType,omitempty"`
-	Stoppable  bool   `json:"stoppable,omitempty"`
+	AccessType             string `json:"accessType,omitempty"`
+	StoppedDueToInactivity bool   `json:"stoppedDueToInactivity,omitempty"`
 }
 
 // AnalyticsReportRequestCreateRequest represents a request to create an analytics report request.
@@ -1491,12 +1776,12 @@ type AnalyticsReportRequestCreateData struct {
 
 // AnalyticsReportRequestCreateAttributes contains attributes for creating an analytics report request.
 type AnalyticsReportRequestCreateAttributes struct {
-	AccessType string `json:"accessType"`
+	AccessType string `json:"accessType" validate:"required,enum=analyticsAccessType"`
 }
 
 // AnalyticsReportRequestCreateRelationships contains relationships for creating an analytics report request.
 type AnalyticsReportRequestCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // AnalyticsReportsResponse represents a list of analytics reports.
@@ -1504,13 +1789,13 @@ type AnalyticsReportsResponse struct {
 	Data     []AnalyticsReport  `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AnalyticsReportResponse represents a single analytics report.
 type AnalyticsReportResponse struct {
 	Data     AnalyticsReport `json:"data"`
-	Included []any           `json:"included,omitempty"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AnalyticsReport represents an analytics report.
@@ -1531,7 +1816,7 @@ type AnalyticsReportInstancesResponse struct {
 	Data     []AnalyticsReportInstance `json:"data"`
 	Links    PagedDocumentLinks        `json:"links"`
 	Meta     *PagingInformation        `json:"meta,omitempty"`
-	Included []any                     `json:"included,omitempty"`
+	Included json.RawMessage           `json:"included,omitempty"`
 }
 
 // AnalyticsReportInstance represents an analytics report instance.
@@ -1552,7 +1837,7 @@ type AnalyticsReportSegmentsResponse struct {
 	Data     []AnalyticsReportSegment `json:"data"`
 	Links    PagedDocumentLinks       `json:"links"`
 	Meta     *PagingInformation       `json:"meta,omitempty"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // AnalyticsReportSegment represents an analytics report segment.
@@ -1576,13 +1861,13 @@ type AppClipsResponse struct {
 	Data     []AppClip          `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppClipResponse represents a single app clip.
 type AppClipResponse struct {
-	Data     AppClip `json:"data"`
-	Included []any   `json:"included,omitempty"`
+	Data     AppClip         `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppClip represents an app clip.
@@ -1602,13 +1887,13 @@ type AppClipDefaultExperiencesResponse struct {
 	Data     []AppClipDefaultExperience `json:"data"`
 	Links    PagedDocumentLinks         `json:"links"`
 	Meta     *PagingInformation         `json:"meta,omitempty"`
-	Included []any                      `json:"included,omitempty"`
+	Included json.RawMessage            `json:"included,omitempty"`
 }
 
 // AppClipDefaultExperienceResponse represents a single default experience.
 type AppClipDefaultExperienceResponse struct {
 	Data     AppClipDefaultExperience `json:"data"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // AppClipDefaultExperience represents an app clip default experience.
@@ -1628,13 +1913,13 @@ type AppClipAdvancedExperiencesResponse struct {
 	Data     []AppClipAdvancedExperience `json:"data"`
 	Links    PagedDocumentLinks          `json:"links"`
 	Meta     *PagingInformation          `json:"meta,omitempty"`
-	Included []any                       `json:"included,omitempty"`
+	Included json.RawMessage             `json:"included,omitempty"`
 }
 
 // AppClipAdvancedExperienceResponse represents a single advanced experience.
 type AppClipAdvancedExperienceResponse struct {
 	Data     AppClipAdvancedExperience `json:"data"`
-	Included []any                     `json:"included,omitempty"`
+	Included json.RawMessage           `json:"included,omitempty"`
 }
 
 // AppClipAdvancedExperience represents an app clip advanced experience.
@@ -1708,13 +1993,13 @@ type GameCenterAchievementsResponse struct {
 	Data     []GameCenterAchievement `json:"data"`
 	Links    PagedDocumentLinks      `json:"links"`
 	Meta     *PagingInformation      `json:"meta,omitempty"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // GameCenterAchievementResponse represents a single achievement.
 type GameCenterAchievementResponse struct {
 	Data     GameCenterAchievement `json:"data"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // GameCenterAchievement represents a Game Center achievement.
@@ -1748,11 +2033,11 @@ type GameCenterAchievementCreateData struct {
 
 // GameCenterAchievementCreateAttributes contains attributes for creating an achievement.
 type GameCenterAchievementCreateAttributes struct {
-	ReferenceName    string `json:"referenceName"`
-	VendorIdentifier string `json:"vendorIdentifier"`
-	Points           int    `json:"points"`
-	ShowBeforeEarned bool   `json:"showBeforeEarned,omitempty"`
-	Repeatable       bool   `json:"repeatable,omitempty"`
+	ReferenceName    string `json:"referenceName" validate:"required"`
+	VendorIdentifier string `json:"vendorIdentifier" validate:"required"`
+	Points           int    `json:"points" validate:"required"`
+	ShowBeforeEarned bool   `json:"showBeforeEarned"`
+	Repeatable       bool   `json:"repeatable"`
 }
 
 // GameCenterAchievementCreateRelationships contains relationships for creating an achievement.
@@ -1786,13 +2071,13 @@ type GameCenterLeaderboardsResponse struct {
 	Data     []GameCenterLeaderboard `json:"data"`
 	Links    PagedDocumentLinks      `json:"links"`
 	Meta     *PagingInformation      `json:"meta,omitempty"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // GameCenterLeaderboardResponse represents a single leaderboard.
 type GameCenterLeaderboardResponse struct {
 	Data     GameCenterLeaderboard `json:"data"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // GameCenterLeaderboard represents a Game Center leaderboard.
@@ -1830,10 +2115,11 @@ type GameCenterLeaderboardCreateData struct {
 
 // GameCenterLeaderboardCreateAttributes contains attributes for creating a leaderboard.
 type GameCenterLeaderboardCreateAttributes struct {
-	ReferenceName       string     `json:"referenceName"`
-	VendorIdentifier    string     `json:"vendorIdentifier"`
-	SubmissionType      string     `json:"submissionType"`
-	ScoreSortType       string     `json:"scoreSortType"`
+	ReferenceName       string     `json:"referenceName" validate:"required"`
+	VendorIdentifier    string     `json:"vendorIdentifier" validate:"required"`
+	SubmissionType      string     `json:"submissionType" validate:"required,enum=leaderboardSubmissionType"`
+	ScoreSortType       string     `json:"scoreSortType" validate:"required,enum=scoreSortType"`
+	DefaultFormatter    string     `json:"defaultFormatter" validate:"required,enum=leaderboardFormatter"`
 	ScoreRangeStart     string     `json:"scoreRangeStart,omitempty"`
 	ScoreRangeEnd       string     `json:"scoreRangeEnd,omitempty"`
 	RecurrenceStartDate *time.Time `json:"recurrenceStartDate,omitempty"`
@@ -1861,8 +2147,8 @@ type GameCenterLeaderboardUpdateData struct {
 // GameCenterLeaderboardUpdateAttributes contains attributes for updating a leaderboard.
 type GameCenterLeaderboardUpdateAttributes struct {
 	ReferenceName       string     `json:"referenceName,omitempty"`
-	SubmissionType      string     `json:"submissionType,omitempty"`
-	ScoreSortType       string     `json:"scoreSortType,omitempty"`
+	SubmissionType      string     `json:"submissionType,omitempty" validate:"enum=leaderboardSubmissionType"`
+	ScoreSortType       string     `json:"scoreSortType,omitempty" validate:"enum=scoreSortType"`
 	ScoreRangeStart     string     `json:"scoreRangeStart,omitempty"`
 	ScoreRangeEnd       string     `json:"scoreRangeEnd,omitempty"`
 	RecurrenceStartDate *time.Time `json:"recurrenceStartDate,omitempty"`
@@ -1876,13 +2162,13 @@ type GameCenterDetailsResponse struct {
 	Data     []GameCenterDetail `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // GameCenterDetailResponse represents a single game center detail.
 type GameCenterDetailResponse struct {
 	Data     GameCenterDetail `json:"data"`
-	Included []any            `json:"included,omitempty"`
+	Included json.RawMessage  `json:"included,omitempty"`
 }
 
 // GameCenterDetail represents game center details for an app.
@@ -1905,20 +2191,29 @@ type CiBuildRunsResponse struct {
 	Data     []CiBuildRun       `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // CiBuildRunResponse represents a single build run.
 type CiBuildRunResponse struct {
-	Data     CiBuildRun `json:"data"`
-	Included []any      `json:"included,omitempty"`
+	Data     CiBuildRun      `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // CiBuildRun represents an Xcode Cloud build run.
 type CiBuildRun struct {
-	Type       string               `json:"type"`
-	ID         string               `json:"id"`
-	Attributes CiBuildRunAttributes `json:"attributes"`
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id"`
+	Attributes    CiBuildRunAttributes     `json:"attributes"`
+	Relationships *CiBuildRunRelationships `json:"relationships,omitempty"`
+}
+
+// CiBuildRunRelationships contains build run relationships.
+// Linkage data is only populated when the related resource is included.
+type CiBuildRunRelationships struct {
+	Workflow          *RelationshipData `json:"workflow,omitempty"`
+	SourceBranchOrTag *RelationshipData `json:"sourceBranchOrTag,omitempty"`
+	PullRequest       *RelationshipData `json:"pullRequest,omitempty"`
 }
 
 // CiBuildRunAttributes contains build run attributes.
@@ -1945,6 +2240,32 @@ type SourceCommit struct {
 	WebURL    string  `json:"webUrl,omitempty"`
 }
 
+// CiBuildRunCreateRequest represents a request to start a build run.
+type CiBuildRunCreateRequest struct {
+	Data CiBuildRunCreateData `json:"data"`
+}
+
+// CiBuildRunCreateData contains the data for starting a build run.
+type CiBuildRunCreateData struct {
+	Type          string                        `json:"type"`
+	Attributes    *CiBuildRunCreateAttributes   `json:"attributes,omitempty"`
+	Relationships CiBuildRunCreateRelationships `json:"relationships"`
+}
+
+// CiBuildRunCreateAttributes contains attributes for starting a build run.
+type CiBuildRunCreateAttributes struct {
+	Clean bool `json:"clean,omitempty"`
+}
+
+// CiBuildRunCreateRelationships contains relationships for starting a build run.
+// BuildRun references an existing run to rebuild with the same parameters.
+type CiBuildRunCreateRelationships struct {
+	Workflow          *RelationshipData `json:"workflow,omitempty"`
+	SourceBranchOrTag *RelationshipData `json:"sourceBranchOrTag,omitempty"`
+	PullRequest       *RelationshipData `json:"pullRequest,omitempty"`
+	BuildRun          *RelationshipData `json:"buildRun,omitempty"`
+}
+
 // Author represents a commit author.
 type Author struct {
 	DisplayName string `json:"displayName,omitempty"`
@@ -1955,13 +2276,13 @@ type CiWorkflowsResponse struct {
 	Data     []CiWorkflow       `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // CiWorkflowResponse represents a single workflow.
 type CiWorkflowResponse struct {
-	Data     CiWorkflow `json:"data"`
-	Included []any      `json:"included,omitempty"`
+	Data     CiWorkflow      `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // CiWorkflow represents an Xcode Cloud workflow.
@@ -2081,13 +2402,13 @@ type CiProductsResponse struct {
 	Data     []CiProduct        `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // CiProductResponse represents a single product.
 type CiProductResponse struct {
-	Data     CiProduct `json:"data"`
-	Included []any     `json:"included,omitempty"`
+	Data     CiProduct       `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // CiProduct represents an Xcode Cloud product.
@@ -2104,6 +2425,114 @@ type CiProductAttributes struct {
 	ProductType string     `json:"productType,omitempty"`
 }
 
+// CiBuildActionsResponse represents a list of build actions.
+type CiBuildActionsResponse struct {
+	Data     []CiBuildAction    `json:"data"`
+	Links    PagedDocumentLinks `json:"links"`
+	Meta     *PagingInformation `json:"meta,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
+}
+
+// CiBuildAction represents an action (build, test, analyze, archive) within a build run.
+type CiBuildAction struct {
+	Type       string                  `json:"type"`
+	ID         string                  `json:"id"`
+	Attributes CiBuildActionAttributes `json:"attributes"`
+}
+
+// CiBuildActionAttributes contains build action attributes.
+type CiBuildActionAttributes struct {
+	Name              string         `json:"name,omitempty"`
+	ActionType        string         `json:"actionType,omitempty"`
+	StartedDate       *time.Time     `json:"startedDate,omitempty"`
+	FinishedDate      *time.Time     `json:"finishedDate,omitempty"`
+	IssueCounts       *CiIssueCounts `json:"issueCounts,omitempty"`
+	ExecutionProgress string         `json:"executionProgress,omitempty"`
+	CompletionStatus  string         `json:"completionStatus,omitempty"`
+	IsRequiredToPass  bool           `json:"isRequiredToPass,omitempty"`
+}
+
+// CiIssueCounts contains issue counts for a build action.
+type CiIssueCounts struct {
+	AnalyzerWarnings int `json:"analyzerWarnings,omitempty"`
+	Errors           int `json:"errors,omitempty"`
+	TestFailures     int `json:"testFailures,omitempty"`
+	Warnings         int `json:"warnings,omitempty"`
+}
+
+// CiIssuesResponse represents a list of build action issues.
+type CiIssuesResponse struct {
+	Data  []CiIssue          `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// CiIssue represents an issue reported by a build action.
+type CiIssue struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Attributes CiIssueAttributes `json:"attributes"`
+}
+
+// CiIssueAttributes contains issue attributes.
+type CiIssueAttributes struct {
+	IssueType  string        `json:"issueType,omitempty"`
+	Message    string        `json:"message,omitempty"`
+	FileSource *FileLocation `json:"fileSource,omitempty"`
+	Category   string        `json:"category,omitempty"`
+}
+
+// FileLocation identifies a line in a source file.
+type FileLocation struct {
+	Path       string `json:"path,omitempty"`
+	LineNumber int    `json:"lineNumber,omitempty"`
+}
+
+// CiTestResultsResponse represents a list of test results.
+type CiTestResultsResponse struct {
+	Data  []CiTestResult     `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// CiTestResult represents the result of a single test.
+type CiTestResult struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Attributes CiTestResultAttributes `json:"attributes"`
+}
+
+// CiTestResultAttributes contains test result attributes.
+type CiTestResultAttributes struct {
+	ClassName  string        `json:"className,omitempty"`
+	Name       string        `json:"name,omitempty"`
+	Status     string        `json:"status,omitempty"`
+	FileSource *FileLocation `json:"fileSource,omitempty"`
+	Message    string        `json:"message,omitempty"`
+}
+
+// CiArtifactsResponse represents a list of build action artifacts.
+type CiArtifactsResponse struct {
+	Data  []CiArtifact       `json:"data"`
+	Links PagedDocumentLinks `json:"links"`
+	Meta  *PagingInformation `json:"meta,omitempty"`
+}
+
+// CiArtifact represents a downloadable artifact produced by a build action.
+type CiArtifact struct {
+	Type       string               `json:"type"`
+	ID         string               `json:"id"`
+	Attributes CiArtifactAttributes `json:"attributes"`
+}
+
+// CiArtifactAttributes contains artifact attributes.
+type CiArtifactAttributes struct {
+	FileType    string `json:"fileType,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	FileSize    int64  `json:"fileSize,omitempty"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
 // Sales and Finance types
 
 // SalesReportsResponse represents a list of sales reports.
@@ -2123,13 +2552,13 @@ type AppEncryptionDeclarationsResponse struct {
 	Data     []AppEncryptionDeclaration `json:"data"`
 	Links    PagedDocumentLinks         `json:"links"`
 	Meta     *PagingInformation         `json:"meta,omitempty"`
-	Included []any                      `json:"included,omitempty"`
+	Included json.RawMessage            `json:"included,omitempty"`
 }
 
 // AppEncryptionDeclarationResponse represents a single encryption declaration.
 type AppEncryptionDeclarationResponse struct {
 	Data     AppEncryptionDeclaration `json:"data"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // AppEncryptionDeclaration represents an encryption declaration.
@@ -2141,20 +2570,20 @@ type AppEncryptionDeclaration struct {
 
 // AppEncryptionDeclarationAttributes contains encryption declaration attributes.
 type AppEncryptionDeclarationAttributes struct {
-	AppDescription                  string `json:"appDescription,omitempty"`
-	CreatedDate                     string `json:"createdDate,omitempty"`
-	UsesEncryption                  bool   `json:"usesEncryption,omitempty"`
-	Exempt                          bool   `json:"exempt,omitempty"`
-	ContainsProprietaryCryptography bool   `json:"containsProprietaryCryptography,omitempty"`
-	ContainsThirdPartyCryptography  bool   `json:"containsThirdPartyCryptography,omitempty"`
-	AvailableOnFrenchStore          bool   `json:"availableOnFrenchStore,omitempty"`
-	Platform                        string `json:"platform,omitempty"`
-	UploadedDate                    string `json:"uploadedDate,omitempty"`
-	DocumentURL                     string `json:"documentUrl,omitempty"`
-	DocumentName                    string `json:"documentName,omitempty"`
-	DocumentType                    string `json:"documentType,omitempty"`
-	AppEncryptionDeclarationState   string `json:"appEncryptionDeclarationState,omitempty"`
-	CodeValue                       string `json:"codeValue,omitempty"`
+	AppDescription                  string   `json:"appDescription,omitempty"`
+	CreatedDate                     string   `json:"createdDate,omitempty"`
+	UsesEncryption                  bool     `json:"usesEncryption,omitempty"`
+	Exempt                          bool     `json:"exempt,omitempty"`
+	ContainsProprietaryCryptography bool     `json:"containsProprietaryCryptography,omitempty"`
+	ContainsThirdPartyCryptography  bool     `json:"containsThirdPartyCryptography,omitempty"`
+	AvailableOnFrenchStore          bool     `json:"availableOnFrenchStore,omitempty"`
+	Platform                        Platform `json:"platform,omitempty"`
+	UploadedDate                    string   `json:"uploadedDate,omitempty"`
+	DocumentURL                     string   `json:"documentUrl,omitempty"`
+	DocumentName                    string   `json:"documentName,omitempty"`
+	DocumentType                    string   `json:"documentType,omitempty"`
+	AppEncryptionDeclarationState   string   `json:"appEncryptionDeclarationState,omitempty"`
+	CodeValue                       string   `json:"codeValue,omitempty"`
 }
 
 // AppEncryptionDeclarationCreateRequest represents a request to create an encryption declaration.
@@ -2171,18 +2600,15 @@ type AppEncryptionDeclarationCreateData struct {
 
 // AppEncryptionDeclarationCreateAttributes contains attributes for creating an encryption declaration.
 type AppEncryptionDeclarationCreateAttributes struct {
-	AppDescription                  string `json:"appDescription,omitempty"`
-	UsesEncryption                  bool   `json:"usesEncryption"`
-	Exempt                          bool   `json:"exempt,omitempty"`
-	ContainsProprietaryCryptography bool   `json:"containsProprietaryCryptography,omitempty"`
-	ContainsThirdPartyCryptography  bool   `json:"containsThirdPartyCryptography,omitempty"`
-	AvailableOnFrenchStore          bool   `json:"availableOnFrenchStore,omitempty"`
-	CodeValue                       string `json:"codeValue,omitempty"`
+	AppDescription                  string `json:"appDescription" validate:"required"`
+	ContainsProprietaryCryptography bool   `json:"containsProprietaryCryptography"`
+	ContainsThirdPartyCryptography  bool   `json:"containsThirdPartyCryptography"`
+	AvailableOnFrenchStore          bool   `json:"availableOnFrenchStore"`
 }
 
 // AppEncryptionDeclarationCreateRelationships contains relationships for creating an encryption declaration.
 type AppEncryptionDeclarationCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // User types
@@ -2192,13 +2618,13 @@ type UsersResponse struct {
 	Data     []User             `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // UserResponse represents a single user.
 type UserResponse struct {
-	Data     User  `json:"data"`
-	Included []any `json:"included,omitempty"`
+	Data     User            `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // User represents an App Store Connect user.
@@ -2213,13 +2639,9 @@ type UserAttributes struct {
 	Username             string   `json:"username,omitempty"`
 	FirstName            string   `json:"firstName,omitempty"`
 	LastName             string   `json:"lastName,omitempty"`
-	Email                string   `json:"email,omitempty"`
-	PreferredCurrencyTerritory string `json:"preferredCurrencyTerritory,omitempty"`
-	AgreedToTerms        bool     `json:"agreedToTerms,omitempty"`
 	Roles                []string `json:"roles,omitempty"`
 	AllAppsVisible       bool     `json:"allAppsVisible,omitempty"`
 	ProvisioningAllowed  bool     `json:"provisioningAllowed,omitempty"`
-	ExpirationDate       *time.Time `json:"expirationDate,omitempty"`
 }
 
 // UserUpdateRequest represents a request to update a user.
@@ -2237,7 +2659,7 @@ type UserUpdateData struct {
 
 // UserUpdateAttributes contains attributes for updating a user.
 type UserUpdateAttributes struct {
-	Roles               []string `json:"roles,omitempty"`
+	Roles               []string `json:"roles,omitempty" validate:"enum=userRole"`
 	AllAppsVisible      *bool    `json:"allAppsVisible,omitempty"`
 	ProvisioningAllowed *bool    `json:"provisioningAllowed,omitempty"`
 }
@@ -2252,13 +2674,13 @@ type UserInvitationsResponse struct {
 	Data     []UserInvitation   `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // UserInvitationResponse represents a single user invitation.
 type UserInvitationResponse struct {
-	Data     UserInvitation `json:"data"`
-	Included []any          `json:"included,omitempty"`
+	Data     UserInvitation  `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // UserInvitation represents a user invitation.
@@ -2293,10 +2715,10 @@ type UserInvitationCreateData struct {
 
 // UserInvitationCreateAttributes contains attributes for creating a user invitation.
 type UserInvitationCreateAttributes struct {
-	Email               string   `json:"email"`
-	FirstName           string   `json:"firstName"`
-	LastName            string   `json:"lastName"`
-	Roles               []string `json:"roles"`
+	Email               string   `json:"email" validate:"required"`
+	FirstName           string   `json:"firstName" validate:"required"`
+	LastName            string   `json:"lastName" validate:"required"`
+	Roles               []string `json:"roles" validate:"required,enum=userRole"`
 	AllAppsVisible      bool     `json:"allAppsVisible,omitempty"`
 	ProvisioningAllowed bool     `json:"provisioningAllowed,omitempty"`
 }
@@ -2313,13 +2735,13 @@ type AppPriceSchedulesResponse struct {
 	Data     []AppPriceSchedule `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppPriceScheduleResponse represents a single app price schedule.
 type AppPriceScheduleResponse struct {
 	Data     AppPriceSchedule `json:"data"`
-	Included []any            `json:"included,omitempty"`
+	Included json.RawMessage  `json:"included,omitempty"`
 }
 
 // AppPriceSchedule represents an app price schedule.
@@ -2333,13 +2755,13 @@ type AppPricePointsResponse struct {
 	Data     []AppPricePoint    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppPricePointResponse represents a single app price point.
 type AppPricePointResponse struct {
-	Data     AppPricePoint `json:"data"`
-	Included []any         `json:"included,omitempty"`
+	Data     AppPricePoint   `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppPricePoint represents an app price point.
@@ -2357,8 +2779,8 @@ type AppPricePointAttributes struct {
 
 // TerritoryResponse represents a territory.
 type TerritoryResponse struct {
-	Data     Territory `json:"data"`
-	Included []any     `json:"included,omitempty"`
+	Data     Territory       `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // TerritoriesResponse represents a list of territories.
@@ -2366,7 +2788,7 @@ type TerritoriesResponse struct {
 	Data     []Territory        `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // Territory represents a territory.
@@ -2386,7 +2808,7 @@ type TerritoryAttributes struct {
 // AppAvailabilityResponse represents app availability.
 type AppAvailabilityResponse struct {
 	Data     AppAvailability `json:"data"`
-	Included []any           `json:"included,omitempty"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppAvailability represents app availability.
@@ -2429,7 +2851,7 @@ type TerritoryAvailabilitiesResponse struct {
 	Data     []TerritoryAvailability `json:"data"`
 	Links    PagedDocumentLinks      `json:"links"`
 	Meta     *PagingInformation      `json:"meta,omitempty"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // TerritoryAvailability represents territory availability.
@@ -2452,7 +2874,7 @@ type TerritoryAvailabilityAttributes struct {
 // AgeRatingDeclarationResponse represents an age rating declaration.
 type AgeRatingDeclarationResponse struct {
 	Data     AgeRatingDeclaration `json:"data"`
-	Included []any                `json:"included,omitempty"`
+	Included json.RawMessage      `json:"included,omitempty"`
 }
 
 // AgeRatingDeclaration represents an age rating declaration.
@@ -2479,7 +2901,6 @@ type AgeRatingDeclarationAttributes struct {
 	ViolenceCartoonOrFantasy                  string `json:"violenceCartoonOrFantasy,omitempty"`
 	ViolenceRealistic                         string `json:"violenceRealistic,omitempty"`
 	ViolenceRealisticProlongedGraphicOrSadistic string `json:"violenceRealisticProlongedGraphicOrSadistic,omitempty"`
-	SeventeenPlus                             bool   `json:"seventeenPlus,omitempty"`
 }
 
 // AgeRatingDeclarationUpdateRequest represents a request to update an age rating declaration.
@@ -2496,22 +2917,21 @@ type AgeRatingDeclarationUpdateData struct {
 
 // AgeRatingDeclarationUpdateAttributes contains attributes for updating an age rating declaration.
 type AgeRatingDeclarationUpdateAttributes struct {
-	AlcoholTobaccoOrDrugUseOrReferences      string `json:"alcoholTobaccoOrDrugUseOrReferences,omitempty"`
-	Contests                                  string `json:"contests,omitempty"`
+	AlcoholTobaccoOrDrugUseOrReferences      string `json:"alcoholTobaccoOrDrugUseOrReferences,omitempty" validate:"enum=contentIntensity"`
+	Contests                                  string `json:"contests,omitempty" validate:"enum=contentIntensity"`
 	Gambling                                  *bool  `json:"gambling,omitempty"`
-	GamblingSimulated                         string `json:"gamblingSimulated,omitempty"`
-	KidsAgeBand                               string `json:"kidsAgeBand,omitempty"`
-	MatureOrSuggestiveThemes                  string `json:"matureOrSuggestiveThemes,omitempty"`
-	MedicalOrTreatmentInformation             string `json:"medicalOrTreatmentInformation,omitempty"`
-	ProfanityOrCrudeHumor                     string `json:"profanityOrCrudeHumor,omitempty"`
-	SexualContentGraphicAndNudity             string `json:"sexualContentGraphicAndNudity,omitempty"`
-	SexualContentOrNudity                     string `json:"sexualContentOrNudity,omitempty"`
-	HorrorOrFearThemes                        string `json:"horrorOrFearThemes,omitempty"`
+	GamblingSimulated                         string `json:"gamblingSimulated,omitempty" validate:"enum=contentIntensity"`
+	KidsAgeBand                               string `json:"kidsAgeBand,omitempty" validate:"enum=kidsAgeBand"`
+	MatureOrSuggestiveThemes                  string `json:"matureOrSuggestiveThemes,omitempty" validate:"enum=contentIntensity"`
+	MedicalOrTreatmentInformation             string `json:"medicalOrTreatmentInformation,omitempty" validate:"enum=contentIntensity"`
+	ProfanityOrCrudeHumor                     string `json:"profanityOrCrudeHumor,omitempty" validate:"enum=contentIntensity"`
+	SexualContentGraphicAndNudity             string `json:"sexualContentGraphicAndNudity,omitempty" validate:"enum=contentIntensity"`
+	SexualContentOrNudity                     string `json:"sexualContentOrNudity,omitempty" validate:"enum=contentIntensity"`
+	HorrorOrFearThemes                        string `json:"horrorOrFearThemes,omitempty" validate:"enum=contentIntensity"`
 	UnrestrictedWebAccess                     *bool  `json:"unrestrictedWebAccess,omitempty"`
-	ViolenceCartoonOrFantasy                  string `json:"violenceCartoonOrFantasy,omitempty"`
-	ViolenceRealistic                         string `json:"violenceRealistic,omitempty"`
-	ViolenceRealisticProlongedGraphicOrSadistic string `json:"violenceRealisticProlongedGraphicOrSadistic,omitempty"`
-	SeventeenPlus                             *bool  `json:"seventeenPlus,omitempty"`
+	ViolenceCartoonOrFantasy                  string `json:"violenceCartoonOrFantasy,omitempty" validate:"enum=contentIntensity"`
+	ViolenceRealistic                         string `json:"violenceRealistic,omitempty" validate:"enum=contentIntensity"`
+	ViolenceRealisticProlongedGraphicOrSadistic string `json:"violenceRealisticProlongedGraphicOrSadistic,omitempty" validate:"enum=contentIntensity"`
 }
 
 // IDFA Declaration types (App Tracking Transparency)
@@ -2519,7 +2939,7 @@ type AgeRatingDeclarationUpdateAttributes struct {
 // IdfaDeclarationResponse represents an IDFA declaration.
 type IdfaDeclarationResponse struct {
 	Data     IdfaDeclaration `json:"data"`
-	Included []any           `json:"included,omitempty"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // IdfaDeclaration represents an IDFA declaration.
@@ -2587,7 +3007,7 @@ type IdfaDeclarationUpdateAttributes struct {
 // EndUserLicenseAgreementResponse represents an EULA.
 type EndUserLicenseAgreementResponse struct {
 	Data     EndUserLicenseAgreement `json:"data"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // EndUserLicenseAgreement represents an end user license agreement.
@@ -2616,13 +3036,13 @@ type EndUserLicenseAgreementCreateData struct {
 
 // EndUserLicenseAgreementCreateAttributes contains attributes for creating an EULA.
 type EndUserLicenseAgreementCreateAttributes struct {
-	AgreementText string `json:"agreementText"`
+	AgreementText string `json:"agreementText" validate:"required"`
 }
 
 // EndUserLicenseAgreementCreateRelationships contains relationships for creating an EULA.
 type EndUserLicenseAgreementCreateRelationships struct {
-	App         RelationshipData     `json:"app"`
-	Territories RelationshipDataList `json:"territories"`
+	App         RelationshipData     `json:"app" validate:"required"`
+	Territories RelationshipDataList `json:"territories" validate:"required"`
 }
 
 // EndUserLicenseAgreementUpdateRequest represents a request to update an EULA.
@@ -2655,13 +3075,13 @@ type BetaAppReviewSubmissionsResponse struct {
 	Data     []BetaAppReviewSubmission `json:"data"`
 	Links    PagedDocumentLinks        `json:"links"`
 	Meta     *PagingInformation        `json:"meta,omitempty"`
-	Included []any                     `json:"included,omitempty"`
+	Included json.RawMessage           `json:"included,omitempty"`
 }
 
 // BetaAppReviewSubmissionResponse represents a single beta app review submission.
 type BetaAppReviewSubmissionResponse struct {
 	Data     BetaAppReviewSubmission `json:"data"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // BetaAppReviewSubmission represents a beta app review submission.
@@ -2673,8 +3093,8 @@ type BetaAppReviewSubmission struct {
 
 // BetaAppReviewSubmissionAttributes contains beta app review submission attributes.
 type BetaAppReviewSubmissionAttributes struct {
-	BetaReviewState string     `json:"betaReviewState,omitempty"`
-	SubmittedDate   *time.Time `json:"submittedDate,omitempty"`
+	BetaReviewState BetaReviewState `json:"betaReviewState,omitempty"`
+	SubmittedDate   *time.Time      `json:"submittedDate,omitempty"`
 }
 
 // BetaAppReviewSubmissionCreateRequest represents a request to create a beta app review submission.
@@ -2690,7 +3110,7 @@ type BetaAppReviewSubmissionCreateData struct {
 
 // BetaAppReviewSubmissionCreateRelationships contains relationships for creating a beta app review submission.
 type BetaAppReviewSubmissionCreateRelationships struct {
-	Build RelationshipData `json:"build"`
+	Build RelationshipData `json:"build" validate:"required"`
 }
 
 // Beta License Agreement types
@@ -2698,7 +3118,7 @@ type BetaAppReviewSubmissionCreateRelationships struct {
 // BetaLicenseAgreementResponse represents a beta license agreement.
 type BetaLicenseAgreementResponse struct {
 	Data     BetaLicenseAgreement `json:"data"`
-	Included []any                `json:"included,omitempty"`
+	Included json.RawMessage      `json:"included,omitempty"`
 }
 
 // BetaLicenseAgreementsResponse represents a list of beta license agreements.
@@ -2706,7 +3126,7 @@ type BetaLicenseAgreementsResponse struct {
 	Data     []BetaLicenseAgreement `json:"data"`
 	Links    PagedDocumentLinks     `json:"links"`
 	Meta     *PagingInformation     `json:"meta,omitempty"`
-	Included []any                  `json:"included,omitempty"`
+	Included json.RawMessage        `json:"included,omitempty"`
 }
 
 // BetaLicenseAgreement represents a beta license agreement.
@@ -2745,13 +3165,13 @@ type SandboxTestersResponse struct {
 	Data     []SandboxTester    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // SandboxTesterResponse represents a single sandbox tester.
 type SandboxTesterResponse struct {
-	Data     SandboxTester `json:"data"`
-	Included []any         `json:"included,omitempty"`
+	Data     SandboxTester   `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // SandboxTester represents a sandbox tester.
@@ -2763,16 +3183,12 @@ type SandboxTester struct {
 
 // SandboxTesterAttributes contains sandbox tester attributes.
 type SandboxTesterAttributes struct {
-	FirstName         string     `json:"firstName,omitempty"`
-	LastName          string     `json:"lastName,omitempty"`
-	Email             string     `json:"email,omitempty"`
-	Password          string     `json:"password,omitempty"`
-	ConfirmPassword   string     `json:"confirmPassword,omitempty"`
-	SecretQuestion    string     `json:"secretQuestion,omitempty"`
-	SecretAnswer      string     `json:"secretAnswer,omitempty"`
-	BirthDate         string     `json:"birthDate,omitempty"`
-	AppStoreTerritory string     `json:"appStoreTerritory,omitempty"`
-	Interruptable     bool       `json:"interruptable,omitempty"`
+	FirstName               string `json:"firstName,omitempty"`
+	LastName                string `json:"lastName,omitempty"`
+	AcAccountName           string `json:"acAccountName,omitempty"`
+	Territory               string `json:"territory,omitempty"`
+	ApplePayCompatible      bool   `json:"applePayCompatible,omitempty"`
+	InterruptPurchases      bool   `json:"interruptPurchases,omitempty"`
 	SubscriptionRenewalRate string `json:"subscriptionRenewalRate,omitempty"`
 }
 
@@ -2814,9 +3230,9 @@ type SandboxTesterUpdateData struct {
 
 // SandboxTesterUpdateAttributes contains attributes for updating a sandbox tester.
 type SandboxTesterUpdateAttributes struct {
-	Interruptable           *bool  `json:"interruptable,omitempty"`
-	SubscriptionRenewalRate string `json:"subscriptionRenewalRate,omitempty"`
-	Territory               string `json:"territory,omitempty"`
+	InterruptPurchases      *bool  `json:"interruptPurchases,omitempty"`
+	SubscriptionRenewalRate string `json:"subscriptionRenewalRate,omitempty" validate:"enum=subscriptionRenewalRate"`
+	Territory               string `json:"territory,omitempty" validate:"territory"`
 }
 
 // Promoted Purchase types
@@ -2826,13 +3242,13 @@ type PromotedPurchasesResponse struct {
 	Data     []PromotedPurchase `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // PromotedPurchaseResponse represents a single promoted purchase.
 type PromotedPurchaseResponse struct {
 	Data     PromotedPurchase `json:"data"`
-	Included []any            `json:"included,omitempty"`
+	Included json.RawMessage  `json:"included,omitempty"`
 }
 
 // PromotedPurchase represents a promoted purchase.
@@ -2869,7 +3285,7 @@ type PromotedPurchaseCreateAttributes struct {
 
 // PromotedPurchaseCreateRelationships contains relationships for creating a promoted purchase.
 type PromotedPurchaseCreateRelationships struct {
-	App           RelationshipData `json:"app"`
+	App           RelationshipData `json:"app" validate:"required"`
 	InAppPurchase RelationshipData `json:"inAppPurchaseV2"`
 }
 
@@ -2898,13 +3314,13 @@ type SubscriptionOfferCodesResponse struct {
 	Data     []SubscriptionOfferCode `json:"data"`
 	Links    PagedDocumentLinks      `json:"links"`
 	Meta     *PagingInformation      `json:"meta,omitempty"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // SubscriptionOfferCodeResponse represents a single subscription offer code.
 type SubscriptionOfferCodeResponse struct {
 	Data     SubscriptionOfferCode `json:"data"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // SubscriptionOfferCode represents a subscription offer code.
@@ -2928,7 +3344,8 @@ type SubscriptionOfferCodeAttributes struct {
 
 // SubscriptionOfferCodeCreateRequest represents a request to create a subscription offer code.
 type SubscriptionOfferCodeCreateRequest struct {
-	Data SubscriptionOfferCodeCreateData `json:"data"`
+	Data     SubscriptionOfferCodeCreateData          `json:"data"`
+	Included []SubscriptionOfferCodePriceInlineCreate `json:"included,omitempty"`
 }
 
 // SubscriptionOfferCodeCreateData contains the data for creating a subscription offer code.
@@ -2940,17 +3357,31 @@ type SubscriptionOfferCodeCreateData struct {
 
 // SubscriptionOfferCodeCreateAttributes contains attributes for creating a subscription offer code.
 type SubscriptionOfferCodeCreateAttributes struct {
-	Name                string   `json:"name"`
-	CustomerEligibilities []string `json:"customerEligibilities"`
-	OfferEligibility    string   `json:"offerEligibility"`
-	Duration            string   `json:"duration"`
-	OfferMode           string   `json:"offerMode"`
-	NumberOfPeriods     int      `json:"numberOfPeriods"`
+	Name                string   `json:"name" validate:"required"`
+	CustomerEligibilities []string `json:"customerEligibilities" validate:"required,enum=customerEligibility"`
+	OfferEligibility    string   `json:"offerEligibility" validate:"required,enum=offerEligibility"`
+	Duration            string   `json:"duration" validate:"required,enum=offerDuration"`
+	OfferMode           string   `json:"offerMode" validate:"required,enum=offerMode"`
+	NumberOfPeriods     int      `json:"numberOfPeriods" validate:"required"`
 }
 
 // SubscriptionOfferCodeCreateRelationships contains relationships for creating a subscription offer code.
 type SubscriptionOfferCodeCreateRelationships struct {
-	Subscription RelationshipData `json:"subscription"`
+	Subscription RelationshipData     `json:"subscription" validate:"required"`
+	Prices       RelationshipDataList `json:"prices" validate:"required"`
+}
+
+// SubscriptionOfferCodePriceInlineCreate defines an offer code price created together with its offer code.
+type SubscriptionOfferCodePriceInlineCreate struct {
+	Type          string                                  `json:"type"`
+	ID            string                                  `json:"id"`
+	Relationships SubscriptionOfferCodePriceRelationships `json:"relationships"`
+}
+
+// SubscriptionOfferCodePriceRelationships contains relationships for an inline offer code price.
+type SubscriptionOfferCodePriceRelationships struct {
+	Territory              *RelationshipData `json:"territory,omitempty"`
+	SubscriptionPricePoint RelationshipData  `json:"subscriptionPricePoint"`
 }
 
 // SubscriptionOfferCodeUpdateRequest represents a request to update a subscription offer code.
@@ -2970,6 +3401,77 @@ type SubscriptionOfferCodeUpdateAttributes struct {
 	Active *bool `json:"active,omitempty"`
 }
 
+// SubscriptionOfferCodeCustomCodesResponse represents a list of an offer code's custom codes.
+type SubscriptionOfferCodeCustomCodesResponse struct {
+	Data  []SubscriptionOfferCodeCustomCode `json:"data"`
+	Links PagedDocumentLinks                `json:"links"`
+	Meta  *PagingInformation                `json:"meta,omitempty"`
+}
+
+// SubscriptionOfferCodeCustomCodeResponse represents a single custom offer code.
+type SubscriptionOfferCodeCustomCodeResponse struct {
+	Data SubscriptionOfferCodeCustomCode `json:"data"`
+}
+
+// SubscriptionOfferCodeCustomCode represents a custom (vanity) code of a
+// subscription offer code, which customers redeem up to NumberOfCodes times.
+type SubscriptionOfferCodeCustomCode struct {
+	Type       string                                    `json:"type"`
+	ID         string                                    `json:"id"`
+	Attributes SubscriptionOfferCodeCustomCodeAttributes `json:"attributes"`
+}
+
+// SubscriptionOfferCodeCustomCodeAttributes contains custom offer code attributes.
+type SubscriptionOfferCodeCustomCodeAttributes struct {
+	CustomCode     string     `json:"customCode,omitempty"`
+	NumberOfCodes  int        `json:"numberOfCodes,omitempty"`
+	CreatedDate    *time.Time `json:"createdDate,omitempty"`
+	ExpirationDate string     `json:"expirationDate,omitempty"`
+	Active         bool       `json:"active,omitempty"`
+}
+
+// SubscriptionOfferCodeCustomCodeCreateRequest represents a request to create a custom offer code.
+type SubscriptionOfferCodeCustomCodeCreateRequest struct {
+	Data SubscriptionOfferCodeCustomCodeCreateData `json:"data"`
+}
+
+// SubscriptionOfferCodeCustomCodeCreateData contains the data for creating a custom offer code.
+type SubscriptionOfferCodeCustomCodeCreateData struct {
+	Type          string                                             `json:"type"`
+	Attributes    SubscriptionOfferCodeCustomCodeCreateAttributes    `json:"attributes"`
+	Relationships SubscriptionOfferCodeCustomCodeCreateRelationships `json:"relationships"`
+}
+
+// SubscriptionOfferCodeCustomCodeCreateAttributes contains attributes for creating a custom offer code.
+// ExpirationDate is a YYYY-MM-DD date; without it the code doesn't expire.
+type SubscriptionOfferCodeCustomCodeCreateAttributes struct {
+	CustomCode     string `json:"customCode" validate:"required"`
+	NumberOfCodes  int    `json:"numberOfCodes" validate:"required"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+}
+
+// SubscriptionOfferCodeCustomCodeCreateRelationships contains relationships for creating a custom offer code.
+type SubscriptionOfferCodeCustomCodeCreateRelationships struct {
+	OfferCode RelationshipData `json:"offerCode" validate:"required"`
+}
+
+// SubscriptionOfferCodeCustomCodeUpdateRequest represents a request to update a custom offer code.
+type SubscriptionOfferCodeCustomCodeUpdateRequest struct {
+	Data SubscriptionOfferCodeCustomCodeUpdateData `json:"data"`
+}
+
+// SubscriptionOfferCodeCustomCodeUpdateData contains the data for updating a custom offer code.
+type SubscriptionOfferCodeCustomCodeUpdateData struct {
+	Type       string                                          `json:"type"`
+	ID         string                                          `json:"id"`
+	Attributes SubscriptionOfferCodeCustomCodeUpdateAttributes `json:"attributes"`
+}
+
+// SubscriptionOfferCodeCustomCodeUpdateAttributes contains attributes for updating a custom offer code.
+type SubscriptionOfferCodeCustomCodeUpdateAttributes struct {
+	Active *bool `json:"active,omitempty"`
+}
+
 // Subscription Price Point types
 
 // SubscriptionPricePointsResponse represents a list of subscription price points.
@@ -2977,13 +3479,13 @@ type SubscriptionPricePointsResponse struct {
 	Data     []SubscriptionPricePoint `json:"data"`
 	Links    PagedDocumentLinks       `json:"links"`
 	Meta     *PagingInformation       `json:"meta,omitempty"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // SubscriptionPricePointResponse represents a single subscription price point.
 type SubscriptionPricePointResponse struct {
 	Data     SubscriptionPricePoint `json:"data"`
-	Included []any                  `json:"included,omitempty"`
+	Included json.RawMessage        `json:"included,omitempty"`
 }
 
 // SubscriptionPricePoint represents a subscription price point.
@@ -3007,13 +3509,13 @@ type WinBackOffersResponse struct {
 	Data     []WinBackOffer     `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // WinBackOfferResponse represents a single win-back offer.
 type WinBackOfferResponse struct {
-	Data     WinBackOffer `json:"data"`
-	Included []any        `json:"included,omitempty"`
+	Data     WinBackOffer    `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // WinBackOffer represents a win-back offer.
@@ -3033,8 +3535,8 @@ type WinBackOfferAttributes struct {
 	CustomerEligibilityPaidSubscriptionDurationInMonths int `json:"customerEligibilityPaidSubscriptionDurationInMonths,omitempty"`
 	CustomerEligibilityTimeSinceLastSubscribedInMonths  *IntegerRange `json:"customerEligibilityTimeSinceLastSubscribedInMonths,omitempty"`
 	CustomerEligibilityWaitBetweenOffersInMonths       int `json:"customerEligibilityWaitBetweenOffersInMonths,omitempty"`
-	StartDate           *time.Time `json:"startDate,omitempty"`
-	EndDate             *time.Time `json:"endDate,omitempty"`
+	StartDate           string     `json:"startDate,omitempty"`
+	EndDate             string     `json:"endDate,omitempty"`
 	Priority            string     `json:"priority,omitempty"`
 	PromotionIntent     string     `json:"promotionIntent,omitempty"`
 }
@@ -3059,24 +3561,24 @@ type WinBackOfferCreateData struct {
 
 // WinBackOfferCreateAttributes contains attributes for creating a win-back offer.
 type WinBackOfferCreateAttributes struct {
-	ReferenceName       string        `json:"referenceName"`
-	OfferID             string        `json:"offerId"`
-	Duration            string        `json:"duration"`
-	OfferMode           string        `json:"offerMode"`
-	PeriodCount         int           `json:"periodCount"`
-	CustomerEligibilityPaidSubscriptionDurationInMonths int `json:"customerEligibilityPaidSubscriptionDurationInMonths"`
-	CustomerEligibilityTimeSinceLastSubscribedInMonths  *IntegerRange `json:"customerEligibilityTimeSinceLastSubscribedInMonths,omitempty"`
+	ReferenceName       string        `json:"referenceName" validate:"required"`
+	OfferID             string        `json:"offerId" validate:"required"`
+	Duration            string        `json:"duration" validate:"required,enum=offerDuration"`
+	OfferMode           string        `json:"offerMode" validate:"required,enum=offerMode"`
+	PeriodCount         int           `json:"periodCount" validate:"required"`
+	CustomerEligibilityPaidSubscriptionDurationInMonths int `json:"customerEligibilityPaidSubscriptionDurationInMonths" validate:"required"`
+	CustomerEligibilityTimeSinceLastSubscribedInMonths  IntegerRange `json:"customerEligibilityTimeSinceLastSubscribedInMonths" validate:"required"`
 	CustomerEligibilityWaitBetweenOffersInMonths       int `json:"customerEligibilityWaitBetweenOffersInMonths,omitempty"`
-	StartDate           *time.Time    `json:"startDate,omitempty"`
-	EndDate             *time.Time    `json:"endDate,omitempty"`
-	Priority            string        `json:"priority"`
-	PromotionIntent     string        `json:"promotionIntent,omitempty"`
+	StartDate           string        `json:"startDate" validate:"required"`
+	EndDate             string        `json:"endDate,omitempty"`
+	Priority            string        `json:"priority" validate:"required,enum=priority"`
+	PromotionIntent     string        `json:"promotionIntent,omitempty" validate:"enum=promotionIntent"`
 }
 
 // WinBackOfferCreateRelationships contains relationships for creating a win-back offer.
 type WinBackOfferCreateRelationships struct {
-	Subscription RelationshipData      `json:"subscription"`
-	Prices       RelationshipDataList  `json:"prices"`
+	Subscription RelationshipData      `json:"subscription" validate:"required"`
+	Prices       RelationshipDataList  `json:"prices" validate:"required"`
 }
 
 // WinBackOfferUpdateRequest represents a request to update a win-back offer.
@@ -3096,10 +3598,10 @@ type WinBackOfferUpdateAttributes struct {
 	CustomerEligibilityPaidSubscriptionDurationInMonths *int `json:"customerEligibilityPaidSubscriptionDurationInMonths,omitempty"`
 	CustomerEligibilityTimeSinceLastSubscribedInMonths  *IntegerRange `json:"customerEligibilityTimeSinceLastSubscribedInMonths,omitempty"`
 	CustomerEligibilityWaitBetweenOffersInMonths       *int `json:"customerEligibilityWaitBetweenOffersInMonths,omitempty"`
-	StartDate           *time.Time    `json:"startDate,omitempty"`
-	EndDate             *time.Time    `json:"endDate,omitempty"`
-	Priority            string        `json:"priority,omitempty"`
-	PromotionIntent     string        `json:"promotionIntent,omitempty"`
+	StartDate           string        `json:"startDate,omitempty"`
+	EndDate             string        `json:"endDate,omitempty"`
+	Priority            string        `json:"priority,omitempty" validate:"enum=priority"`
+	PromotionIntent     string        `json:"promotionIntent,omitempty" validate:"enum=promotionIntent"`
 }
 
 // App Store Version Experiment types (Product Page Optimization)
@@ -3109,13 +3611,13 @@ type AppStoreVersionExperimentsResponse struct {
 	Data     []AppStoreVersionExperiment `json:"data"`
 	Links    PagedDocumentLinks          `json:"links"`
 	Meta     *PagingInformation          `json:"meta,omitempty"`
-	Included []any                       `json:"included,omitempty"`
+	Included json.RawMessage             `json:"included,omitempty"`
 }
 
 // AppStoreVersionExperimentResponse represents a single experiment.
 type AppStoreVersionExperimentResponse struct {
 	Data     AppStoreVersionExperiment `json:"data"`
-	Included []any                     `json:"included,omitempty"`
+	Included json.RawMessage           `json:"included,omitempty"`
 }
 
 // AppStoreVersionExperiment represents an App Store version experiment.
@@ -3127,14 +3629,12 @@ type AppStoreVersionExperiment struct {
 
 // AppStoreVersionExperimentAttributes contains experiment attributes.
 type AppStoreVersionExperimentAttributes struct {
-	Name                    string     `json:"name,omitempty"`
-	TrafficProportion       int        `json:"trafficProportion,omitempty"`
-	State                   string     `json:"state,omitempty"`
-	ReviewRequired          bool       `json:"reviewRequired,omitempty"`
-	StartDate               *time.Time `json:"startDate,omitempty"`
-	EndDate                 *time.Time `json:"endDate,omitempty"`
-	LatestControlVersion    string     `json:"latestControlVersion,omitempty"`
-	ControlVersionStartDate *time.Time `json:"controlVersionStartDate,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	TrafficProportion int        `json:"trafficProportion,omitempty"`
+	State             string     `json:"state,omitempty"`
+	ReviewRequired    bool       `json:"reviewRequired,omitempty"`
+	StartDate         *time.Time `json:"startDate,omitempty"`
+	EndDate           *time.Time `json:"endDate,omitempty"`
 }
 
 // AppStoreVersionExperimentCreateRequest represents a request to create an experiment.
@@ -3151,13 +3651,13 @@ type AppStoreVersionExperimentCreateData struct {
 
 // AppStoreVersionExperimentCreateAttributes contains attributes for creating an experiment.
 type AppStoreVersionExperimentCreateAttributes struct {
-	Name              string `json:"name"`
-	TrafficProportion int    `json:"trafficProportion"`
+	Name              string `json:"name" validate:"required"`
+	TrafficProportion int    `json:"trafficProportion" validate:"required"`
 }
 
 // AppStoreVersionExperimentCreateRelationships contains relationships for creating an experiment.
 type AppStoreVersionExperimentCreateRelationships struct {
-	AppStoreVersion RelationshipData `json:"appStoreVersion"`
+	AppStoreVersion RelationshipData `json:"appStoreVersion" validate:"required"`
 }
 
 // AppStoreVersionExperimentUpdateRequest represents a request to update an experiment.
@@ -3186,13 +3686,13 @@ type AppCustomProductPagesResponse struct {
 	Data     []AppCustomProductPage `json:"data"`
 	Links    PagedDocumentLinks     `json:"links"`
 	Meta     *PagingInformation     `json:"meta,omitempty"`
-	Included []any                  `json:"included,omitempty"`
+	Included json.RawMessage        `json:"included,omitempty"`
 }
 
 // AppCustomProductPageResponse represents a single custom product page.
 type AppCustomProductPageResponse struct {
 	Data     AppCustomProductPage `json:"data"`
-	Included []any                `json:"included,omitempty"`
+	Included json.RawMessage      `json:"included,omitempty"`
 }
 
 // AppCustomProductPage represents a custom product page.
@@ -3223,12 +3723,12 @@ type AppCustomProductPageCreateData struct {
 
 // AppCustomProductPageCreateAttributes contains attributes for creating a custom product page.
 type AppCustomProductPageCreateAttributes struct {
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required"`
 }
 
 // AppCustomProductPageCreateRelationships contains relationships for creating a custom product page.
 type AppCustomProductPageCreateRelationships struct {
-	App                    RelationshipData  `json:"app"`
+	App                    RelationshipData  `json:"app" validate:"required"`
 	AppStoreVersionTemplate *RelationshipData `json:"appStoreVersionTemplate,omitempty"`
 }
 
@@ -3255,7 +3755,7 @@ type AppCustomProductPageUpdateAttributes struct {
 // RoutingAppCoverageResponse represents a routing app coverage.
 type RoutingAppCoverageResponse struct {
 	Data     RoutingAppCoverage `json:"data"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // RoutingAppCoverage represents routing app coverage.
@@ -3288,13 +3788,13 @@ type RoutingAppCoverageCreateData struct {
 
 // RoutingAppCoverageCreateAttributes contains attributes for creating routing app coverage.
 type RoutingAppCoverageCreateAttributes struct {
-	FileSize int    `json:"fileSize"`
-	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize" validate:"required"`
+	FileName string `json:"fileName" validate:"required"`
 }
 
 // RoutingAppCoverageCreateRelationships contains relationships for creating routing app coverage.
 type RoutingAppCoverageCreateRelationships struct {
-	AppStoreVersion RelationshipData `json:"appStoreVersion"`
+	AppStoreVersion RelationshipData `json:"appStoreVersion" validate:"required"`
 }
 
 // RoutingAppCoverageUpdateRequest represents a request to update routing app coverage.
@@ -3322,7 +3822,7 @@ type PerfPowerMetricsResponse struct {
 	Data     []PerfPowerMetric  `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // PerfPowerMetric represents a performance/power metric.
@@ -3344,7 +3844,7 @@ type DiagnosticLogsResponse struct {
 	Data     []DiagnosticLog    `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // DiagnosticLog represents a diagnostic log.
@@ -3364,7 +3864,7 @@ type DiagnosticSignaturesResponse struct {
 	Data     []DiagnosticSignature `json:"data"`
 	Links    PagedDocumentLinks    `json:"links"`
 	Meta     *PagingInformation    `json:"meta,omitempty"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // DiagnosticSignature represents a diagnostic signature.
@@ -3388,13 +3888,13 @@ type AppStoreReviewAttachmentsResponse struct {
 	Data     []AppStoreReviewAttachment `json:"data"`
 	Links    PagedDocumentLinks         `json:"links"`
 	Meta     *PagingInformation         `json:"meta,omitempty"`
-	Included []any                      `json:"included,omitempty"`
+	Included json.RawMessage            `json:"included,omitempty"`
 }
 
 // AppStoreReviewAttachmentResponse represents a single review attachment.
 type AppStoreReviewAttachmentResponse struct {
 	Data     AppStoreReviewAttachment `json:"data"`
-	Included []any                    `json:"included,omitempty"`
+	Included json.RawMessage          `json:"included,omitempty"`
 }
 
 // AppStoreReviewAttachment represents a review attachment.
@@ -3427,13 +3927,13 @@ type AppStoreReviewAttachmentCreateData struct {
 
 // AppStoreReviewAttachmentCreateAttributes contains attributes for creating a review attachment.
 type AppStoreReviewAttachmentCreateAttributes struct {
-	FileSize int    `json:"fileSize"`
-	FileName string `json:"fileName"`
+	FileSize int    `json:"fileSize" validate:"required"`
+	FileName string `json:"fileName" validate:"required"`
 }
 
 // AppStoreReviewAttachmentCreateRelationships contains relationships for creating a review attachment.
 type AppStoreReviewAttachmentCreateRelationships struct {
-	AppStoreReviewDetail RelationshipData `json:"appStoreReviewDetail"`
+	AppStoreReviewDetail RelationshipData `json:"appStoreReviewDetail" validate:"required"`
 }
 
 // AppStoreReviewAttachmentUpdateRequest represents a request to update a review attachment.
@@ -3461,13 +3961,13 @@ type AppCategoriesResponse struct {
 	Data     []AppCategory      `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // AppCategoryResponse represents a single app category.
 type AppCategoryResponse struct {
-	Data     AppCategory `json:"data"`
-	Included []any       `json:"included,omitempty"`
+	Data     AppCategory     `json:"data"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // AppCategory represents an app category.
@@ -3489,13 +3989,13 @@ type BetaAppLocalizationsResponse struct {
 	Data     []BetaAppLocalization `json:"data"`
 	Links    PagedDocumentLinks    `json:"links"`
 	Meta     *PagingInformation    `json:"meta,omitempty"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // BetaAppLocalizationResponse represents a single beta app localization.
 type BetaAppLocalizationResponse struct {
 	Data     BetaAppLocalization `json:"data"`
-	Included []any               `json:"included,omitempty"`
+	Included json.RawMessage     `json:"included,omitempty"`
 }
 
 // BetaAppLocalization represents a beta app localization.
@@ -3533,13 +4033,13 @@ type BetaAppLocalizationCreateAttributes struct {
 	MarketingURL     string `json:"marketingUrl,omitempty"`
 	PrivacyPolicyURL string `json:"privacyPolicyUrl,omitempty"`
 	TVOSPrivacyPolicy string `json:"tvOsPrivacyPolicy,omitempty"`
-	Description      string `json:"description,omitempty"`
-	Locale           string `json:"locale"`
+	Description      string `json:"description,omitempty" validate:"max=4000"`
+	Locale           string `json:"locale" validate:"required,locale"`
 }
 
 // BetaAppLocalizationCreateRelationships contains relationships for creating a beta app localization.
 type BetaAppLocalizationCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // BetaAppLocalizationUpdateRequest represents a request to update a beta app localization.
@@ -3560,7 +4060,7 @@ type BetaAppLocalizationUpdateAttributes struct {
 	MarketingURL     string `json:"marketingUrl,omitempty"`
 	PrivacyPolicyURL string `json:"privacyPolicyUrl,omitempty"`
 	TVOSPrivacyPolicy string `json:"tvOsPrivacyPolicy,omitempty"`
-	Description      string `json:"description,omitempty"`
+	Description      string `json:"description,omitempty" validate:"max=4000"`
 }
 
 // Beta Build Localization types
@@ -3570,13 +4070,13 @@ type BetaBuildLocalizationsResponse struct {
 	Data     []BetaBuildLocalization `json:"data"`
 	Links    PagedDocumentLinks      `json:"links"`
 	Meta     *PagingInformation      `json:"meta,omitempty"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // BetaBuildLocalizationResponse represents a single beta build localization.
 type BetaBuildLocalizationResponse struct {
 	Data     BetaBuildLocalization `json:"data"`
-	Included []any                 `json:"included,omitempty"`
+	Included json.RawMessage       `json:"included,omitempty"`
 }
 
 // BetaBuildLocalization represents a beta build localization.
@@ -3606,13 +4106,13 @@ type BetaBuildLocalizationCreateData struct {
 
 // BetaBuildLocalizationCreateAttributes contains attributes for creating a beta build localization.
 type BetaBuildLocalizationCreateAttributes struct {
-	WhatsNew string `json:"whatsNew,omitempty"`
-	Locale   string `json:"locale"`
+	WhatsNew string `json:"whatsNew,omitempty" validate:"max=4000"`
+	Locale   string `json:"locale" validate:"required,locale"`
 }
 
 // BetaBuildLocalizationCreateRelationships contains relationships for creating a beta build localization.
 type BetaBuildLocalizationCreateRelationships struct {
-	Build RelationshipData `json:"build"`
+	Build RelationshipData `json:"build" validate:"required"`
 }
 
 // BetaBuildLocalizationUpdateRequest represents a request to update a beta build localization.
@@ -3629,7 +4129,7 @@ type BetaBuildLocalizationUpdateData struct {
 
 // BetaBuildLocalizationUpdateAttributes contains attributes for updating a beta build localization.
 type BetaBuildLocalizationUpdateAttributes struct {
-	WhatsNew string `json:"whatsNew,omitempty"`
+	WhatsNew string `json:"whatsNew,omitempty" validate:"max=4000"`
 }
 
 // Build Beta Detail types
@@ -3637,7 +4137,7 @@ type BetaBuildLocalizationUpdateAttributes struct {
 // BuildBetaDetailResponse represents a build beta detail.
 type BuildBetaDetailResponse struct {
 	Data     BuildBetaDetail `json:"data"`
-	Included []any           `json:"included,omitempty"`
+	Included json.RawMessage `json:"included,omitempty"`
 }
 
 // BuildBetaDetailsResponse represents a list of build beta details.
@@ -3645,7 +4145,7 @@ type BuildBetaDetailsResponse struct {
 	Data     []BuildBetaDetail  `json:"data"`
 	Links    PagedDocumentLinks `json:"links"`
 	Meta     *PagingInformation `json:"meta,omitempty"`
-	Included []any              `json:"included,omitempty"`
+	Included json.RawMessage    `json:"included,omitempty"`
 }
 
 // BuildBetaDetail represents build beta details.
@@ -3684,7 +4184,7 @@ type BuildBetaDetailUpdateAttributes struct {
 // AlternativeDistributionPackageResponse represents an alternative distribution package.
 type AlternativeDistributionPackageResponse struct {
 	Data     AlternativeDistributionPackage `json:"data"`
-	Included []any                          `json:"included,omitempty"`
+	Included json.RawMessage                `json:"included,omitempty"`
 }
 
 // AlternativeDistributionPackagesResponse represents a list of alternative distribution packages.
@@ -3692,7 +4192,7 @@ type AlternativeDistributionPackagesResponse struct {
 	Data     []AlternativeDistributionPackage `json:"data"`
 	Links    PagedDocumentLinks               `json:"links"`
 	Meta     *PagingInformation               `json:"meta,omitempty"`
-	Included []any                            `json:"included,omitempty"`
+	Included json.RawMessage                  `json:"included,omitempty"`
 }
 
 // AlternativeDistributionPackage represents an alternative distribution package.
@@ -3710,7 +4210,7 @@ type AlternativeDistributionPackageAttributes struct {
 // AlternativeDistributionKeyResponse represents an alternative distribution key.
 type AlternativeDistributionKeyResponse struct {
 	Data     AlternativeDistributionKey `json:"data"`
-	Included []any                      `json:"included,omitempty"`
+	Included json.RawMessage            `json:"included,omitempty"`
 }
 
 // AlternativeDistributionKeysResponse represents a list of alternative distribution keys.
@@ -3718,7 +4218,7 @@ type AlternativeDistributionKeysResponse struct {
 	Data     []AlternativeDistributionKey `json:"data"`
 	Links    PagedDocumentLinks           `json:"links"`
 	Meta     *PagingInformation           `json:"meta,omitempty"`
-	Included []any                        `json:"included,omitempty"`
+	Included json.RawMessage              `json:"included,omitempty"`
 }
 
 // AlternativeDistributionKey represents an alternative distribution key.
@@ -3747,7 +4247,7 @@ type AlternativeDistributionKeyCreateData struct {
 
 // AlternativeDistributionKeyCreateAttributes contains attributes for creating an alternative distribution key.
 type AlternativeDistributionKeyCreateAttributes struct {
-	PublicKey string `json:"publicKey"`
+	PublicKey string `json:"publicKey" validate:"required"`
 }
 
 // AlternativeDistributionKeyCreateRelationships contains relationships for creating an alternative distribution key.
@@ -3760,7 +4260,7 @@ type AlternativeDistributionKeyCreateRelationships struct {
 // MarketplaceSearchDetailResponse represents marketplace search detail.
 type MarketplaceSearchDetailResponse struct {
 	Data     MarketplaceSearchDetail `json:"data"`
-	Included []any                   `json:"included,omitempty"`
+	Included json.RawMessage         `json:"included,omitempty"`
 }
 
 // MarketplaceSearchDetail represents marketplace search details.
@@ -3789,12 +4289,12 @@ type MarketplaceSearchDetailCreateData struct {
 
 // MarketplaceSearchDetailCreateAttributes contains attributes for creating marketplace search details.
 type MarketplaceSearchDetailCreateAttributes struct {
-	CatalogURL string `json:"catalogUrl"`
+	CatalogURL string `json:"catalogUrl" validate:"required"`
 }
 
 // MarketplaceSearchDetailCreateRelationships contains relationships for creating marketplace search details.
 type MarketplaceSearchDetailCreateRelationships struct {
-	App RelationshipData `json:"app"`
+	App RelationshipData `json:"app" validate:"required"`
 }
 
 // MarketplaceSearchDetailUpdateRequest represents a request to update marketplace search details.
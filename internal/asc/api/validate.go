@@ -0,0 +1,272 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError is a create or update request that App Store Connect would
+// reject, found before it is sent. Use errors.As to get it from an error
+// returned by the client.
+type ValidationError struct {
+	// Request is the type of the request, such as "AppStoreVersionCreateRequest".
+	Request string
+
+	// Problems describe what is wrong, one per field, such as
+	// "data.attributes.versionString is required".
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Request, strings.Join(e.Problems, "; "))
+}
+
+// requestEnums are the values App Store Connect accepts for the request
+// fields tagged with validate:"enum=<name>", as listed in its OpenAPI spec.
+var requestEnums = map[string][]string{
+	"analyticsAccessType": {"ONE_TIME_SNAPSHOT", "ONGOING"},
+	"appEventBadge": {
+		"LIVE_EVENT",
+		"PREMIERE",
+		"CHALLENGE",
+		"COMPETITION",
+		"NEW_SEASON",
+		"MAJOR_UPDATE",
+		"SPECIAL_EVENT",
+	},
+	"appEventPurpose": {
+		"APPROPRIATE_FOR_ALL_USERS",
+		"ATTRACT_NEW_USERS",
+		"KEEP_ACTIVE_USERS_INFORMED",
+		"BRING_BACK_LAPSED_USERS",
+	},
+	"bundleIdPlatform": EnumStrings(BundleIDPlatforms),
+	"contentIntensity": {
+		"NONE",
+		"INFREQUENT_OR_MILD",
+		"FREQUENT_OR_INTENSE",
+		"INFREQUENT",
+		"FREQUENT",
+	},
+	"customerEligibility": {"NEW", "EXISTING", "EXPIRED"},
+	"inAppPurchaseType":   EnumStrings(InAppPurchaseTypes),
+	"kidsAgeBand":         {"FIVE_AND_UNDER", "SIX_TO_EIGHT", "NINE_TO_ELEVEN"},
+	"leaderboardFormatter": {
+		"INTEGER",
+		"DECIMAL_POINT_1_PLACE",
+		"DECIMAL_POINT_2_PLACE",
+		"DECIMAL_POINT_3_PLACE",
+		"ELAPSED_TIME_CENTISECOND",
+		"ELAPSED_TIME_MINUTE",
+		"ELAPSED_TIME_SECOND",
+		"MONEY_POUND_DECIMAL",
+		"MONEY_POUND",
+		"MONEY_DOLLAR_DECIMAL",
+		"MONEY_DOLLAR",
+		"MONEY_EURO_DECIMAL",
+		"MONEY_EURO",
+		"MONEY_FRANC_DECIMAL",
+		"MONEY_FRANC",
+		"MONEY_KRONER_DECIMAL",
+		"MONEY_KRONER",
+		"MONEY_YEN",
+	},
+	"leaderboardSubmissionType": {"BEST_SCORE", "MOST_RECENT_SCORE"},
+	"offerDuration": {
+		"THREE_DAYS",
+		"ONE_WEEK",
+		"TWO_WEEKS",
+		"ONE_MONTH",
+		"TWO_MONTHS",
+		"THREE_MONTHS",
+		"SIX_MONTHS",
+		"ONE_YEAR",
+	},
+	"offerEligibility":   {"STACK_WITH_INTRO_OFFERS", "REPLACE_INTRO_OFFERS"},
+	"offerMode":          {"PAY_AS_YOU_GO", "PAY_UP_FRONT", "FREE_TRIAL"},
+	"phasedReleaseState": EnumStrings(PhasedReleaseStates),
+	"platform":           EnumStrings(Platforms),
+	"priority":           {"HIGH", "NORMAL"},
+	"promotionIntent":    {"NOT_PROMOTED", "USE_AUTO_GENERATED_ASSETS"},
+	"releaseType":        EnumStrings(ReleaseTypes),
+	"scoreSortType":      {"ASC", "DESC"},
+	"subscriptionRenewalRate": {
+		"MONTHLY_RENEWAL_EVERY_ONE_HOUR",
+		"MONTHLY_RENEWAL_EVERY_THIRTY_MINUTES",
+		"MONTHLY_RENEWAL_EVERY_FIFTEEN_MINUTES",
+		"MONTHLY_RENEWAL_EVERY_FIVE_MINUTES",
+		"MONTHLY_RENEWAL_EVERY_THREE_MINUTES",
+	},
+	"userRole": {
+		"ADMIN",
+		"FINANCE",
+		"ACCOUNT_HOLDER",
+		"SALES",
+		"MARKETING",
+		"APP_MANAGER",
+		"DEVELOPER",
+		"ACCESS_TO_REPORTS",
+		"CUSTOMER_SUPPORT",
+		"CREATE_APPS",
+		"CLOUD_MANAGED_DEVELOPER_ID",
+		"CLOUD_MANAGED_APP_DISTRIBUTION",
+		"GENERATE_INDIVIDUAL_KEYS",
+	}}
+
+var (
+	// localePattern matches the locale codes App Store Connect uses, such
+	// as "en-US", "fr" and "zh-Hans".
+	localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-[A-Z]{2})?$`)
+
+	// territoryPattern matches ISO 3166-1 alpha-3 territory codes.
+	territoryPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// validateRequest checks a request body against the validate tags of its
+// fields, so that requests App Store Connect would reject with an opaque
+// ENTITY_ERROR fail early, with every problem named. The rules are:
+//
+//   - required: a string, slice or pointer is set, a number isn't zero, a
+//     relationship has an ID, and a to-many relationship isn't empty
+//   - enum=<name>: a set string, or each element of a slice, is one of
+//     requestEnums[name]
+//   - max=<n>: a string has at most n characters
+//   - locale: a set string is a locale code
+//   - territory: a set string is a territory code
+//
+// Bodies that aren't structs, such as raw JSON, aren't checked.
+func validateRequest(body any) error {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var problems []string
+	validateStruct(v, "", &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Request: v.Type().Name(), Problems: problems}
+	}
+	return nil
+}
+
+// validateStruct appends the problems of v's fields, and of the structs they
+// hold, to problems. Field paths are JSON member paths under prefix.
+func validateStruct(v reflect.Value, prefix string, problems *[]string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		path := prefix + name
+		value := v.Field(i)
+
+		if rules := field.Tag.Get("validate"); rules != "" {
+			for _, rule := range strings.Split(rules, ",") {
+				if problem := checkRule(rule, path, value); problem != "" {
+					*problems = append(*problems, problem)
+				}
+			}
+		}
+
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			validateStruct(value, path+".", problems)
+		}
+	}
+}
+
+// checkRule returns the problem a validate rule finds with a field's value,
+// or "" if there is none.
+func checkRule(rule, path string, value reflect.Value) string {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if rule == "required" {
+				return path + " is required"
+			}
+			return ""
+		}
+		value = value.Elem()
+	}
+	name, arg, _ := strings.Cut(rule, "=")
+
+	switch name {
+	case "required":
+		switch r := value.Interface().(type) {
+		case RelationshipData:
+			if r.Data.ID == "" {
+				return path + " is required"
+			}
+		case RelationshipDataList:
+			if len(r.Data) == 0 {
+				return path + " needs at least one resource"
+			}
+		default:
+			if value.IsZero() || (value.Kind() == reflect.Slice && value.Len() == 0) {
+				return path + " is required"
+			}
+		}
+	case "enum":
+		allowed := requestEnums[arg]
+		for _, s := range stringValues(value) {
+			if s != "" && !slices.Contains(allowed, s) {
+				return fmt.Sprintf("%s is %q, must be one of %s", path, s, oneOf(allowed))
+			}
+		}
+	case "max":
+		limit, _ := strconv.Atoi(arg)
+		if value.Kind() == reflect.String {
+			if n := utf8.RuneCountInString(value.String()); n > limit {
+				return fmt.Sprintf("%s is %d characters long, the limit is %d", path, n, limit)
+			}
+		}
+	case "locale":
+		if s := value.String(); value.Kind() == reflect.String && s != "" && !localePattern.MatchString(s) {
+			return fmt.Sprintf("%s is %q, must be a locale code such as en-US or zh-Hans", path, s)
+		}
+	case "territory":
+		if s := value.String(); value.Kind() == reflect.String && s != "" && !territoryPattern.MatchString(s) {
+			return fmt.Sprintf("%s is %q, must be a three-letter territory code such as USA", path, s)
+		}
+	}
+	return ""
+}
+
+// stringValues returns the strings a string or string slice field holds.
+func stringValues(value reflect.Value) []string {
+	switch {
+	case value.Kind() == reflect.String:
+		return []string{value.String()}
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		values := make([]string, value.Len())
+		for i := range values {
+			values[i] = value.Index(i).String()
+		}
+		return values
+	}
+	return nil
+}
+
+// oneOf formats allowed values as "A, B or C".
+func oneOf(values []string) string {
+	if len(values) < 2 {
+		return strings.Join(values, "")
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
@@ -0,0 +1,154 @@
+// Package audit records tool calls in an append-only JSON Lines file, so
+// there is a record of what was changed in App Store Connect and by whom.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the values of secret arguments in the log.
+const Redacted = "[REDACTED]"
+
+// secretKeyParts are substrings of argument names whose values are redacted.
+var secretKeyParts = []string{"password", "secret", "token", "private_key", "privatekey", "credential", "authorization"}
+
+// Entry is one tool call.
+type Entry struct {
+	Time       time.Time       `json:"time"`
+	Session    string          `json:"session"`
+	Client     string          `json:"client,omitempty"`
+	RequestID  json.RawMessage `json:"requestId,omitempty"`
+	Tool       string          `json:"tool"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	DurationMs int64           `json:"durationMs"`
+	APICalls   []APICall       `json:"apiCalls"`
+}
+
+// Statuses of a tool call.
+const (
+	StatusOK        = "ok"
+	StatusError     = "error"
+	StatusCancelled = "cancelled"
+)
+
+// APICall is one App Store Connect API request made by a tool call.
+type APICall struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+
+	// APIRequestID is the ID App Store Connect gave the request, if any.
+	APIRequestID string `json:"apiRequestId,omitempty"`
+}
+
+// Log appends entries to a JSON Lines file. A nil Log discards them.
+type Log struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// Open opens the audit log at path for appending, creating it readable only
+// by the current user if it doesn't exist. An empty path returns a nil Log.
+func Open(path string) (*Log, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &Log{file: file}, nil
+}
+
+// Write appends an entry as one line, with secret arguments redacted.
+func (l *Log) Write(entry Entry) error {
+	if l == nil {
+		return nil
+	}
+	entry.Arguments = Redact(entry.Arguments)
+	if entry.APICalls == nil {
+		entry.APICalls = []APICall{}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the audit log file.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// Redact returns args with the values of secret-looking keys, at any depth,
+// replaced by Redacted. Arguments that aren't valid JSON are returned as a
+// JSON string so the log stays parseable.
+func Redact(args json.RawMessage) json.RawMessage {
+	if len(args) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(args, &value); err != nil {
+		quoted, _ := json.Marshal(string(args))
+		return quoted
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+// redactValue redacts secret keys in a decoded JSON value.
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSecretKey(key) {
+				v[key] = Redacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// isSecretKey reports whether an argument name looks like it holds a secret.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	entries := []Entry{
+		{
+			Time:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Session:   "s1",
+			Tool:      "update_app_review_detail",
+			Arguments: json.RawMessage(`{"detail_id": "d1", "demo_account_password": "hunter2", "nested": [{"api_token": "t"}]}`),
+			Status:    StatusOK,
+			APICalls:  []APICall{{Method: "PATCH", Path: "/v1/appStoreReviewDetails/d1", Status: 200}},
+		},
+		{Session: "s1", Tool: "list_apps", Status: StatusError, Error: "boom"},
+	}
+	for _, entry := range entries {
+		if err := log.Write(entry); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Reopening appends rather than truncating.
+	log, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := log.Write(Entry{Session: "s2", Tool: "get_app", Status: StatusCancelled}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	log.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer file.Close()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+
+	args := lines[0]["arguments"].(map[string]any)
+	if args["demo_account_password"] != Redacted || args["detail_id"] != "d1" {
+		t.Errorf("arguments = %v", args)
+	}
+	if nested := args["nested"].([]any)[0].(map[string]any); nested["api_token"] != Redacted {
+		t.Errorf("nested = %v", nested)
+	}
+	if calls := lines[1]["apiCalls"].([]any); len(calls) != 0 {
+		t.Errorf("apiCalls = %v", calls)
+	}
+	if lines[2]["session"] != "s2" || lines[2]["status"] != StatusCancelled {
+		t.Errorf("line = %v", lines[2])
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, %v", info.Mode(), err)
+	}
+}
+
+func TestLog_Nil(t *testing.T) {
+	log, err := Open("")
+	if err != nil || log != nil {
+		t.Fatalf("Open(\"\") = %v, %v", log, err)
+	}
+	if err := log.Write(Entry{Tool: "list_apps"}); err != nil {
+		t.Errorf("Write on nil log: %v", err)
+	}
+}
+
+func TestRedact_InvalidJSON(t *testing.T) {
+	if got := string(Redact(json.RawMessage(`{oops`))); got != `"{oops"` {
+		t.Errorf("Redact = %s", got)
+	}
+}
+
+func TestRead_Filter(t *testing.T) {
+	log := strings.Join([]string{
+		`{"time":"2024-09-30T23:59:59Z","session":"s1","tool":"update_app","arguments":{"app_id":"111"},"status":"ok","durationMs":10,"apiCalls":[{"method":"PATCH","path":"/v1/apps/111","status":200,"durationMs":9}]}`,
+		`{"time":"2024-10-02T08:00:00Z","session":"s1","tool":"list_builds","arguments":{"app_id":"111"},"status":"ok","durationMs":10,"apiCalls":[{"method":"GET","path":"/v1/builds","status":200,"durationMs":9}]}`,
+		``,
+		`{"time":"2024-10-03T09:30:00Z","session":"s2","client":"claude-ai","requestId":7,"tool":"update_version_localization","arguments":{"localization_id":"abc","whats_new":"Fixes, \"finally\""},"status":"ok","durationMs":412,"apiCalls":[{"method":"GET","path":"/v1/apps/111/appStoreVersions","status":200,"durationMs":100},{"method":"PATCH","path":"/v1/appStoreVersionLocalizations/abc","status":200,"durationMs":300}]}`,
+		`{"time":"2024-10-04T10:00:00Z","session":"s2","tool":"update_app","arguments":{"app_ids":["222"]},"status":"error","error":"API error (409): conflict","durationMs":10,"apiCalls":[{"method":"PATCH","path":"/v1/apps/222","status":409,"durationMs":9}]}`,
+		`{"time":"2024-11-01T00:00:00Z","session":"s3","tool":"update_app","arguments":{"app_id":"111"},"status":"ok","durationMs":10,"apiCalls":[{"method":"PATCH","path":"/v1/apps/111","status":200,"durationMs":9}]}`,
+	}, "\n")
+	october := Filter{
+		Since: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		filter func(f Filter) Filter
+		want   []string
+	}{
+		{"time range", func(f Filter) Filter { return f }, []string{"list_builds", "update_version_localization", "update_app"}},
+		{"app changes", func(f Filter) Filter { f.AppID, f.ChangesOnly = "111", true; return f }, []string{"update_version_localization"}},
+		{"app in app_ids", func(f Filter) Filter { f.AppID = "222"; return f }, []string{"update_app"}},
+		{"tool glob", func(f Filter) Filter { f.Tools = []string{"update_*"}; return f }, []string{"update_version_localization", "update_app"}},
+		{"resource type", func(f Filter) Filter { f.ResourceType = "appStoreVersionLocalizations"; return f }, []string{"update_version_localization"}},
+		{"status", func(f Filter) Filter { f.Status = StatusError; return f }, []string{"update_app"}},
+		{"no bounds", func(Filter) Filter { return Filter{AppID: "111", Tools: []string{"update_app"}} }, []string{"update_app", "update_app"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Read(strings.NewReader(log), tt.filter(october))
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Tool)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Read(strings.NewReader("{}\nnot json\n"), Filter{}); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Read of a corrupt log error = %v, want line 2", err)
+	}
+
+	entries, err := Read(strings.NewReader(log), Filter{ResourceType: "appStoreVersionLocalizations"})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, entries); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	want := [][]string{
+		csvHeader,
+		{"2024-10-03T09:30:00Z", "s2", "claude-ai", "7", "update_version_localization", "ok", "", "412",
+			`{"localization_id":"abc","whats_new":"Fixes, \"finally\""}`,
+			"GET /v1/apps/111/appStoreVersions 200; PATCH /v1/appStoreVersionLocalizations/abc 200"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV = %q, want %q", records, want)
+	}
+}
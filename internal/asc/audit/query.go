@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLineBytes bounds one line of the audit log when reading it back.
+const maxLineBytes = 16 << 20
+
+// Filter selects audit log entries. Zero fields match every entry.
+type Filter struct {
+	// Since and Until bound an entry's time. Since is inclusive and Until
+	// exclusive, so a month is Since its first day Until the next month's.
+	Since time.Time
+	Until time.Time
+
+	// Tools are names or globs, such as "update_*", of the tools to match.
+	Tools []string
+
+	// AppID matches calls whose app_id or app_ids argument names the app,
+	// or that requested one of its /v1/apps/{id} paths.
+	AppID string
+
+	// ResourceType matches calls that requested a resource type, such as
+	// "appStoreVersions", as the first segment of an API path.
+	ResourceType string
+
+	// Status matches StatusOK, StatusError or StatusCancelled.
+	Status string
+
+	// ChangesOnly matches calls that sent at least one request other than
+	// a GET, leaving out lookups.
+	ChangesOnly bool
+}
+
+// Match reports whether entry passes the filter.
+func (f Filter) Match(entry Entry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Time.Before(f.Until) {
+		return false
+	}
+	if len(f.Tools) > 0 && !slices.ContainsFunc(f.Tools, func(pattern string) bool {
+		matched, _ := path.Match(pattern, entry.Tool)
+		return matched
+	}) {
+		return false
+	}
+	if f.Status != "" && entry.Status != f.Status {
+		return false
+	}
+	if f.ChangesOnly && !slices.ContainsFunc(entry.APICalls, func(call APICall) bool {
+		return call.Method != http.MethodGet
+	}) {
+		return false
+	}
+	if f.ResourceType != "" && !slices.ContainsFunc(entry.APICalls, func(call APICall) bool {
+		return resourceType(call.Path) == f.ResourceType
+	}) {
+		return false
+	}
+	if f.AppID != "" && !mentionsApp(entry, f.AppID) {
+		return false
+	}
+	return true
+}
+
+// resourceType returns the resource type of an API path, such as "apps" for
+// "/v1/apps/123/builds".
+func resourceType(apiPath string) string {
+	segments := strings.Split(strings.TrimPrefix(apiPath, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[1]
+}
+
+// mentionsApp reports whether an entry's arguments or API paths name appID.
+func mentionsApp(entry Entry, appID string) bool {
+	var args struct {
+		AppID  string   `json:"app_id"`
+		AppIDs []string `json:"app_ids"`
+	}
+	if json.Unmarshal(entry.Arguments, &args) == nil {
+		if args.AppID == appID || slices.Contains(args.AppIDs, appID) {
+			return true
+		}
+	}
+	for _, call := range entry.APICalls {
+		segments := strings.Split(strings.TrimPrefix(call.Path, "/"), "/")
+		if len(segments) >= 3 && segments[1] == "apps" && segments[2] == appID {
+			return true
+		}
+	}
+	return false
+}
+
+// Read reads the entries of a JSON Lines audit log that pass filter, in the
+// order they were written.
+func Read(r io.Reader, filter Filter) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineBytes)
+
+	var entries []Entry
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid audit entry on line %d: %w", line, err)
+		}
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// csvHeader names the columns WriteCSV writes.
+var csvHeader = []string{"time", "session", "client", "request_id", "tool", "status", "error", "duration_ms", "arguments", "api_calls"}
+
+// WriteCSV writes entries as CSV with a header row. Arguments are kept as
+// JSON, and API calls are listed as "METHOD path status" separated by "; ".
+func WriteCSV(w io.Writer, entries []Entry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		calls := make([]string, len(entry.APICalls))
+		for i, call := range entry.APICalls {
+			calls[i] = fmt.Sprintf("%s %s %d", call.Method, call.Path, call.Status)
+		}
+		record := []string{
+			entry.Time.UTC().Format(time.RFC3339),
+			entry.Session,
+			entry.Client,
+			string(entry.RequestID),
+			entry.Tool,
+			entry.Status,
+			entry.Error,
+			strconv.FormatInt(entry.DurationMs, 10),
+			string(entry.Arguments),
+			strings.Join(calls, "; "),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,127 @@
+// Package cmd provides the command-line interface for asc-mcp.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/audit"
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+)
+
+var (
+	auditPath     string
+	auditSince    string
+	auditUntil    string
+	auditTools    []string
+	auditAppID    string
+	auditResource string
+	auditStatus   string
+	auditChanges  bool
+	auditFormat   string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Query the audit log",
+	Long: `Print the tool calls recorded in the audit log that match the given
+filters, as CSV for spreadsheets or as the log's own JSON Lines.
+
+The log is read from --log, or ASC_AUDIT_LOG if it isn't given. Times are
+RFC 3339 timestamps or dates; --since is inclusive and --until exclusive.
+For example, every change made to app 123456789 in October 2024:
+
+  asc-mcp audit --app 123456789 --changes \
+    --since 2024-10-01 --until 2024-11-01 > october.csv`,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditPath, "log", "", "audit log to read (default ASC_AUDIT_LOG)")
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "only calls at or after this time or date")
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", "only calls before this time or date")
+	auditCmd.Flags().StringSliceVar(&auditTools, "tool", nil, `only calls of these tools; globs such as "update_*" are allowed`)
+	auditCmd.Flags().StringVar(&auditAppID, "app", "", "only calls naming this app ID in app_id or app_ids, or requesting its /v1/apps paths")
+	auditCmd.Flags().StringVar(&auditResource, "resource", "", `only calls requesting this resource type, such as "appStoreVersions"`)
+	auditCmd.Flags().StringVar(&auditStatus, "status", "", `only calls with this status: "ok", "error" or "cancelled"`)
+	auditCmd.Flags().BoolVar(&auditChanges, "changes", false, "only calls that sent a request other than a GET")
+	auditCmd.Flags().StringVar(&auditFormat, "format", "csv", `"csv" or "jsonl"`)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	path := auditPath
+	if path == "" {
+		path = os.Getenv("ASC_AUDIT_LOG")
+	}
+	if path == "" {
+		return fmt.Errorf("--log or ASC_AUDIT_LOG is required")
+	}
+	path, err := config.ExpandPath(path)
+	if err != nil {
+		return fmt.Errorf("invalid audit log path: %w", err)
+	}
+	if auditFormat != "csv" && auditFormat != "jsonl" {
+		return fmt.Errorf("invalid --format value %q: must be csv or jsonl", auditFormat)
+	}
+	switch auditStatus {
+	case "", audit.StatusOK, audit.StatusError, audit.StatusCancelled:
+	default:
+		return fmt.Errorf("invalid --status value %q: must be ok, error or cancelled", auditStatus)
+	}
+
+	filter := audit.Filter{
+		Tools:        auditTools,
+		AppID:        auditAppID,
+		ResourceType: auditResource,
+		Status:       auditStatus,
+		ChangesOnly:  auditChanges,
+	}
+	if filter.Since, err = parseAuditTime(auditSince); err != nil {
+		return fmt.Errorf("invalid --since value: %w", err)
+	}
+	if filter.Until, err = parseAuditTime(auditUntil); err != nil {
+		return fmt.Errorf("invalid --until value: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := audit.Read(file, filter)
+	if err != nil {
+		return err
+	}
+
+	if auditFormat == "csv" {
+		return audit.WriteCSV(os.Stdout, entries)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAuditTime parses an RFC 3339 timestamp or a date, which means its
+// midnight in UTC. An empty value is the zero time.
+func parseAuditTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date or RFC 3339 time", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,78 @@
+// Package cmd provides the command-line interface for asc-mcp.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+	"github.com/antisynthesis/asc-mcp/internal/asc/doctor"
+)
+
+// doctorTimeout bounds all of doctor's API requests.
+const doctorTimeout = 2 * time.Minute
+
+var doctorJSON bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the setup against App Store Connect",
+	Long: `Run a series of checks of the configured credentials and environment:
+
+  - each profile's private key parses and signs a token
+  - one request to each major part of the API (apps, builds, TestFlight,
+    provisioning, users, Xcode Cloud, sales reports) shows whether the
+    key's role allows it
+  - the local clock agrees with Apple's, since tokens are rejected when
+    it is off by minutes
+  - the snapshot file and audit log can be written
+
+Unlike validate, doctor calls the API. It exits with an error if any check
+fails; warnings, such as a role that doesn't allow sales reports, don't.`,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "print the report as JSON")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	var report doctor.Report
+	cfg, err := config.Load()
+	if err != nil {
+		report.Checks = append(report.Checks, doctor.Check{Name: "configuration", Status: doctor.StatusFail, Detail: err.Error()})
+	} else {
+		report.Checks = append(report.Checks, doctor.Check{Name: "configuration", Status: doctor.StatusOK, Detail: fmt.Sprintf("%d profiles", len(cfg.Profiles)+1)})
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), doctorTimeout)
+		defer cancel()
+		report.Checks = append(report.Checks, doctor.Run(ctx, cfg).Checks...)
+	}
+
+	if doctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range report.Checks {
+			name := c.Name
+			if c.Profile != "" {
+				name = fmt.Sprintf("%s (%s)", c.Name, c.Profile)
+			}
+			fmt.Printf("%-7s%s: %s\n", "["+strings.ToUpper(c.Status)+"]", name, c.Detail)
+		}
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("doctor found problems")
+	}
+	return nil
+}
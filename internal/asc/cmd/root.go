@@ -28,5 +28,7 @@ func init() {
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(toolsCmd)
 }
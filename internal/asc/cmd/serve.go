@@ -2,8 +2,13 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,7 +19,8 @@ import (
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the MCP server",
-	Long: `Start the MCP server and listen for JSON-RPC requests on stdin/stdout.
+	Long: `Start the MCP server and listen for JSON-RPC requests on stdin/stdout,
+or with --transport unix, on a Unix domain socket.
 
 The server requires App Store Connect API credentials to be configured
 via environment variables:
@@ -23,6 +29,130 @@ via environment variables:
   ASC_KEY_ID           Your App Store Connect API Key ID
   ASC_PRIVATE_KEY_PATH Path to your .p8 private key file
 
+Instead of ASC_PRIVATE_KEY_PATH, the key can be passed inline:
+
+  ASC_PRIVATE_KEY_BASE64
+                       Base64-encoded contents of the .p8 file
+
+Optional:
+
+  ASC_ENABLE_RAW_API   Set to true to expose the asc_api_request tool
+                       (same as --enable-raw-api)
+  ASC_REQUIRE_CONFIRMATION
+                       Set to true to preview destructive tool calls until
+                       they are repeated with "confirm": true
+                       (same as --require-confirmation)
+  ASC_ALLOW_DESTRUCTIVE_APP_OPERATIONS
+                       Set to true to let tools delete App Store versions
+                       or remove apps from sale
+                       (same as --allow-destructive-app-operations)
+  ASC_BASE_URL         API base URL to use instead of the production API,
+                       e.g. a local mock or a gateway (same as --base-url;
+                       profiles: ASC_<NAME>_BASE_URL)
+  ASC_PROFILES         Comma-separated names of additional team profiles,
+                       each configured with ASC_<NAME>_ISSUER_ID,
+                       ASC_<NAME>_KEY_ID and ASC_<NAME>_PRIVATE_KEY_PATH
+                       or ASC_<NAME>_PRIVATE_KEY_BASE64
+  ASC_ACCOUNT_TYPE     "standard" (default) or "enterprise" for Enterprise
+                       (In-House) accounts, which hides App Store and
+                       TestFlight tools (same as --account-type)
+  ASC_TOOL_TIMEOUTS    Maximum tool call durations as pattern=duration
+                       pairs, e.g. "list_*=30s,get_sales_report=5m"
+                       (same as --tool-timeouts)
+  ASC_REQUEST_TIMEOUTS Time limits of one API request attempt as
+                       category=duration pairs for the default, list,
+                       report and download categories, e.g.
+                       "list=2m,report=10m"; 0 removes a limit (same as
+                       --request-timeouts)
+  ASC_LOG_LEVEL        Minimum level of MCP log notifications until the
+                       client sets one (default "info")
+  ASC_CONCURRENCY_LIMITS
+                       Maximum concurrent tool calls as pattern=limit
+                       pairs, e.g. "*=4,list_*=2" (same as
+                       --concurrency-limits)
+  ASC_SNAPSHOT_PATH    JSON file recording App Store version state changes
+                       for review turnaround statistics (default
+                       snapshots.json in %LocalAppData%\asc-mcp on Windows,
+                       ~/Library/Application Support/asc-mcp on macOS or
+                       ~/.config/asc-mcp elsewhere; empty keeps them in
+                       memory; same as --snapshot-path)
+  ASC_MAX_RESULT_BYTES Maximum size of a tool result's text before it is
+                       truncated and continued with get_result_continuation
+                       (default 100000; 0 turns truncation off; same as
+                       --max-result-bytes)
+  ASC_TOOL_PREFIX      Prefix added to tool names to avoid collisions with
+                       other servers (default "asc_"; empty for none; same
+                       as --tool-prefix)
+  ASC_TOOL_GROUPS      Set to true to add each tool's group after the
+                       prefix, e.g. asc_builds_list_builds (same as
+                       --tool-groups)
+  ASC_APP_GROUPS       Named sets of app IDs that tools taking several apps
+                       accept in place of an app ID, as name=ids pairs,
+                       e.g. "flagship=123,456;clients=789"
+  ASC_TRANSPORT        "stdio" (default) or "unix" to accept connections on
+                       a Unix domain socket (same as --transport)
+  ASC_SOCKET_PATH      Socket to listen on with the unix transport; it is
+                       created with mode 0600 (same as --socket)
+  ASC_METRICS_ADDR     host:port to serve Prometheus metrics on at /metrics,
+                       e.g. "127.0.0.1:9464" (same as --metrics-addr)
+  OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+                       OTLP/HTTP URL to export traces of tool calls and API
+                       requests to, e.g. "http://localhost:4318/v1/traces"
+                       (same as --otlp-endpoint); OTEL_EXPORTER_OTLP_ENDPOINT,
+                       OTEL_EXPORTER_OTLP_HEADERS and OTEL_SERVICE_NAME are
+                       also read
+  ASC_AUDIT_LOG        JSON Lines file every tool call is appended to, with
+                       its arguments (secrets redacted), API requests,
+                       status and duration (same as --audit-log)
+  ASC_RATE_LIMIT_WAIT  How long a request refused with a 429 may wait in
+                       total for retries, e.g. "2m" (default 1m; 0 returns
+                       rate limit errors at once; same as --rate-limit-wait)
+  ASC_MAX_ATTEMPTS     How many times a GET, PUT or DELETE request is sent
+                       when it fails with a 500, 502, 503 or 504 status or
+                       a network error (default 3; 1 turns retries off;
+                       same as --max-attempts)
+  ASC_PAGE_CONCURRENCY How many pages of a list are fetched at once when a
+                       tool reads all of them (default 4, up to 16; same as
+                       --page-concurrency)
+  ASC_TOKEN_REFRESH_BUFFER
+                       How long before its expiry the API token is signed
+                       again, e.g. "30s" (default 2m; same as
+                       --token-refresh-buffer)
+  ASC_CACHE_WARMUP     Set to "false" to skip prefetching apps, territories
+                       and beta groups in the background at startup (same
+                       as --no-cache-warmup)
+  ASC_ENUM_LABELS      Set to "false" to show enum values such as
+                       WAITING_FOR_REVIEW in tool results without readable
+                       phrases (same as --raw-enums)
+  ASC_ENUM_LABELS_FILE JSON file of enum values and the phrases to show for
+                       them, e.g. {"READY_FOR_SALE": "Prêt à la vente"}
+                       (same as --enum-labels-file)
+  ASC_STRICT_DECODING  Set to true to fail on API responses with fields the
+                       server doesn't know, to catch payload changes (same
+                       as --strict-decoding)
+  ASC_MAX_IDLE_CONNS_PER_HOST
+                       How many idle connections to each host are kept for
+                       reuse (default 32; same as --max-idle-conns-per-host)
+  ASC_IDLE_CONN_TIMEOUT
+                       How long an idle connection is kept open, e.g. "5m"
+                       (default 90s; 0 keeps it until the server closes it;
+                       same as --idle-conn-timeout)
+  ASC_HTTP2            Set to "false" to send API requests over separate
+                       HTTP/1.1 connections instead of one HTTP/2
+                       connection (same as --no-http2)
+  ASC_USER_AGENT_TAG   Identifier appended to the User-Agent of API
+                       requests, e.g. "release-bot/3", so proxies and Apple
+                       can attribute traffic (same as --user-agent-tag)
+  ASC_PROXY_URL        Proxy to send API requests through, e.g.
+                       "http://proxy.example.com:8080" (default
+                       HTTPS_PROXY; same as --proxy-url)
+  ASC_CA_FILE          PEM file of CA certificates to trust for API
+                       connections in addition to the system's, e.g. a
+                       TLS-inspecting proxy's (same as --ca-file)
+  ASC_CACHE_DIR        Directory to also keep cached API responses in, so
+                       they can be revalidated after a restart (same as
+                       --cache-dir)
+
 Example:
   export ASC_ISSUER_ID="xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
   export ASC_KEY_ID="XXXXXXXXXX"
@@ -31,17 +161,295 @@ Example:
 	RunE: runServe,
 }
 
+// capabilityProbeTimeout bounds the check of the API key's roles at startup.
+const capabilityProbeTimeout = 10 * time.Second
+
+// traceShutdownTimeout bounds the export of the last spans when serving stops.
+const traceShutdownTimeout = 5 * time.Second
+
+var (
+	enableRawAPI        bool
+	requireConfirmation bool
+	allowDestructive    bool
+	toolTimeouts        string
+	requestTimeouts     string
+	accountType         string
+	concurrencyLimits   string
+	snapshotPath        string
+	maxResultBytes      int
+	toolPrefix          string
+	toolGroups          bool
+	transport           string
+	socketPath          string
+	metricsAddr         string
+	otlpEndpoint        string
+	auditLogPath        string
+	rateLimitWait       time.Duration
+	maxAttempts         int
+	pageConcurrency     int
+	tokenRefreshBuffer  time.Duration
+	noCacheWarmup       bool
+	rawEnums            bool
+	strictDecoding      bool
+	enumLabelsFile      string
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	noHTTP2             bool
+	userAgentTag        string
+	proxyURL            string
+	caFile              string
+	cacheDir            string
+	baseURL             string
+)
+
+func init() {
+	serveCmd.Flags().BoolVar(&enableRawAPI, "enable-raw-api", false, "expose the asc_api_request tool for calling unwrapped API endpoints")
+	serveCmd.Flags().StringVar(&accountType, "account-type", "", `"standard" or "enterprise"; enterprise hides App Store and TestFlight tools`)
+	serveCmd.Flags().StringVar(&toolTimeouts, "tool-timeouts", "", `maximum tool call durations as pattern=duration pairs, e.g. "list_*=30s,get_sales_report=5m"`)
+	serveCmd.Flags().StringVar(&requestTimeouts, "request-timeouts", "", `time limits of one API request attempt as category=duration pairs, e.g. "list=2m,report=10m"`)
+	serveCmd.Flags().StringVar(&concurrencyLimits, "concurrency-limits", "", `maximum concurrent tool calls as pattern=limit pairs, e.g. "*=4,list_*=2"`)
+	serveCmd.Flags().BoolVar(&requireConfirmation, "require-confirmation", false, "preview destructive tool calls until they are repeated with confirm set to true")
+	serveCmd.Flags().BoolVar(&allowDestructive, "allow-destructive-app-operations", false, "let tools delete App Store versions or remove apps from sale")
+	serveCmd.Flags().StringVar(&snapshotPath, "snapshot-path", "", "JSON file recording App Store version state changes")
+	serveCmd.Flags().IntVar(&maxResultBytes, "max-result-bytes", 0, "maximum size of a tool result's text before it is truncated; 0 turns truncation off")
+	serveCmd.Flags().StringVar(&toolPrefix, "tool-prefix", "", `prefix added to tool names (default "asc_"); set to "" for none`)
+	serveCmd.Flags().BoolVar(&toolGroups, "tool-groups", false, "add each tool's group after the prefix, e.g. asc_builds_list_builds")
+	serveCmd.Flags().StringVar(&transport, "transport", "", `"stdio" (default) or "unix" to listen on the --socket path`)
+	serveCmd.Flags().StringVar(&socketPath, "socket", "", "Unix domain socket to listen on with --transport unix")
+	serveCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", `host:port to serve Prometheus metrics on at /metrics, e.g. "127.0.0.1:9464"`)
+	serveCmd.Flags().StringVar(&otlpEndpoint, "otlp-endpoint", "", `OTLP/HTTP URL to export traces to, e.g. "http://localhost:4318/v1/traces"`)
+	serveCmd.Flags().StringVar(&auditLogPath, "audit-log", "", "JSON Lines file every tool call is appended to")
+	serveCmd.Flags().DurationVar(&rateLimitWait, "rate-limit-wait", config.DefaultRateLimitWait, "how long a request refused with a 429 may wait in total for retries; 0 returns rate limit errors at once")
+	serveCmd.Flags().IntVar(&maxAttempts, "max-attempts", config.DefaultMaxAttempts, "how many times an idempotent request failing with a 5xx status or network error is sent; 1 turns retries off")
+	serveCmd.Flags().IntVar(&pageConcurrency, "page-concurrency", config.DefaultPageConcurrency, "how many pages of a list are fetched at once when a tool reads all of them")
+	serveCmd.Flags().DurationVar(&tokenRefreshBuffer, "token-refresh-buffer", config.DefaultTokenRefreshBuffer, "how long before its expiry the API token is signed again")
+	serveCmd.Flags().IntVar(&maxIdleConnsPerHost, "max-idle-conns-per-host", config.DefaultMaxIdleConnsPerHost, "how many idle connections to each host are kept for reuse")
+	serveCmd.Flags().DurationVar(&idleConnTimeout, "idle-conn-timeout", config.DefaultIdleConnTimeout, "how long an idle connection is kept open; 0 keeps it until the server closes it")
+	serveCmd.Flags().BoolVar(&noHTTP2, "no-http2", false, "send API requests over separate HTTP/1.1 connections instead of one HTTP/2 connection")
+	serveCmd.Flags().StringVar(&userAgentTag, "user-agent-tag", "", `identifier appended to the User-Agent of API requests, e.g. "release-bot/3"`)
+	serveCmd.Flags().StringVar(&proxyURL, "proxy-url", "", "proxy to send API requests through (default HTTPS_PROXY)")
+	serveCmd.Flags().StringVar(&baseURL, "base-url", "", "API base URL to use instead of the production API, e.g. a local mock or a gateway")
+	serveCmd.Flags().StringVar(&caFile, "ca-file", "", "PEM file of CA certificates to trust for API connections in addition to the system's")
+	serveCmd.Flags().BoolVar(&noCacheWarmup, "no-cache-warmup", false, "don't prefetch apps, territories and beta groups in the background at startup")
+	serveCmd.Flags().BoolVar(&rawEnums, "raw-enums", false, "show enum values in tool results without readable phrases")
+	serveCmd.Flags().BoolVar(&strictDecoding, "strict-decoding", false, "fail on API responses with fields the server doesn't know or resources of an unexpected type")
+	serveCmd.Flags().StringVar(&enumLabelsFile, "enum-labels-file", "", `JSON file of enum values and the phrases to show for them, e.g. {"READY_FOR_SALE": "Ready"}`)
+	serveCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "directory to also keep cached API responses in, so they can be revalidated after a restart")
+}
+
 func runServe(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return err
 	}
+	if enableRawAPI {
+		cfg.EnableRawAPI = true
+	}
+	if requireConfirmation {
+		cfg.RequireConfirmation = true
+	}
+	if allowDestructive {
+		cfg.AllowDestructiveAppOperations = true
+	}
+	if accountType != "" {
+		if cfg.AccountType, err = config.ParseAccountType(accountType); err != nil {
+			return fmt.Errorf("invalid --account-type value: %w", err)
+		}
+	}
+	if toolTimeouts != "" {
+		timeouts, err := config.ParseToolTimeouts(toolTimeouts)
+		if err != nil {
+			return fmt.Errorf("invalid --tool-timeouts value: %w", err)
+		}
+		if cfg.ToolTimeouts == nil {
+			cfg.ToolTimeouts = make(map[string]time.Duration)
+		}
+		for pattern, timeout := range timeouts {
+			cfg.ToolTimeouts[pattern] = timeout
+		}
+	}
+	if requestTimeouts != "" {
+		timeouts, err := config.ParseRequestTimeouts(requestTimeouts)
+		if err != nil {
+			return fmt.Errorf("invalid --request-timeouts value: %w", err)
+		}
+		if cfg.RequestTimeouts == nil {
+			cfg.RequestTimeouts = make(map[string]time.Duration)
+		}
+		for category, timeout := range timeouts {
+			cfg.RequestTimeouts[category] = timeout
+		}
+	}
+	if concurrencyLimits != "" {
+		limits, err := config.ParseConcurrencyLimits(concurrencyLimits)
+		if err != nil {
+			return fmt.Errorf("invalid --concurrency-limits value: %w", err)
+		}
+		if cfg.ConcurrencyLimits == nil {
+			cfg.ConcurrencyLimits = make(map[string]int)
+		}
+		for pattern, limit := range limits {
+			cfg.ConcurrencyLimits[pattern] = limit
+		}
+	}
+	if cmd.Flags().Changed("snapshot-path") {
+		if cfg.SnapshotPath, err = config.ExpandPath(snapshotPath); err != nil {
+			return fmt.Errorf("invalid --snapshot-path value: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("max-result-bytes") {
+		if maxResultBytes < 0 {
+			return fmt.Errorf("invalid --max-result-bytes value: must not be negative")
+		}
+		cfg.MaxResultBytes = maxResultBytes
+	}
+	if cmd.Flags().Changed("tool-prefix") {
+		if cfg.ToolPrefix, err = config.ParseToolPrefix(toolPrefix); err != nil {
+			return fmt.Errorf("invalid --tool-prefix value: %w", err)
+		}
+	}
+	if toolGroups {
+		cfg.ToolGroups = true
+	}
+	if transport != "" {
+		if cfg.Transport, err = config.ParseTransport(transport); err != nil {
+			return fmt.Errorf("invalid --transport value: %w", err)
+		}
+	}
+	if socketPath != "" {
+		if cfg.SocketPath, err = config.ExpandPath(socketPath); err != nil {
+			return fmt.Errorf("invalid --socket value: %w", err)
+		}
+	}
+	if metricsAddr != "" {
+		if cfg.MetricsAddr, err = config.ParseMetricsAddr(metricsAddr); err != nil {
+			return fmt.Errorf("invalid --metrics-addr value: %w", err)
+		}
+	}
+	if otlpEndpoint != "" {
+		if cfg.TracesEndpoint, err = config.ParseTracesEndpoint(otlpEndpoint); err != nil {
+			return fmt.Errorf("invalid --otlp-endpoint value: %w", err)
+		}
+	}
+	if auditLogPath != "" {
+		if cfg.AuditLogPath, err = config.ExpandPath(auditLogPath); err != nil {
+			return fmt.Errorf("invalid --audit-log value: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("rate-limit-wait") {
+		if rateLimitWait < 0 {
+			return fmt.Errorf("invalid --rate-limit-wait value: must not be negative")
+		}
+		cfg.RateLimitWait = rateLimitWait
+	}
+	if cmd.Flags().Changed("max-attempts") {
+		if maxAttempts < 1 {
+			return fmt.Errorf("invalid --max-attempts value: must be at least 1")
+		}
+		cfg.MaxAttempts = maxAttempts
+	}
+	if cmd.Flags().Changed("page-concurrency") {
+		if cfg.PageConcurrency, err = config.ParsePageConcurrency(fmt.Sprint(pageConcurrency)); err != nil {
+			return fmt.Errorf("invalid --page-concurrency value: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("token-refresh-buffer") {
+		if cfg.TokenRefreshBuffer, err = config.ParseTokenRefreshBuffer(tokenRefreshBuffer.String()); err != nil {
+			return fmt.Errorf("invalid --token-refresh-buffer value: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("max-idle-conns-per-host") {
+		if cfg.MaxIdleConnsPerHost, err = config.ParseMaxIdleConnsPerHost(fmt.Sprint(maxIdleConnsPerHost)); err != nil {
+			return fmt.Errorf("invalid --max-idle-conns-per-host value: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("idle-conn-timeout") {
+		if cfg.IdleConnTimeout, err = config.ParseIdleConnTimeout(idleConnTimeout.String()); err != nil {
+			return fmt.Errorf("invalid --idle-conn-timeout value: %w", err)
+		}
+	}
+	if noHTTP2 {
+		cfg.HTTP2 = false
+	}
+	if userAgentTag != "" {
+		if cfg.UserAgentTag, err = config.ParseUserAgentTag(userAgentTag); err != nil {
+			return fmt.Errorf("invalid --user-agent-tag value: %w", err)
+		}
+	}
+	if proxyURL != "" {
+		if cfg.ProxyURL, err = config.ParseProxyURL(proxyURL); err != nil {
+			return fmt.Errorf("invalid --proxy-url value: %w", err)
+		}
+	}
+	if baseURL != "" {
+		if cfg.BaseURL, err = config.ParseBaseURL(baseURL); err != nil {
+			return fmt.Errorf("invalid --base-url value: %w", err)
+		}
+	}
+	if caFile != "" {
+		if cfg.CAFile, err = config.ExpandPath(caFile); err != nil {
+			return fmt.Errorf("invalid --ca-file value: %w", err)
+		}
+	}
+	if noCacheWarmup {
+		cfg.CacheWarmup = false
+	}
+	if rawEnums {
+		cfg.EnumLabels = false
+	}
+	if strictDecoding {
+		cfg.StrictDecoding = true
+	}
+	if enumLabelsFile != "" {
+		if cfg.EnumLabelsPath, err = config.ExpandPath(enumLabelsFile); err != nil {
+			return fmt.Errorf("invalid --enum-labels-file value: %w", err)
+		}
+	}
+	if cacheDir != "" {
+		if cfg.CacheDir, err = config.ExpandPath(cacheDir); err != nil {
+			return fmt.Errorf("invalid --cache-dir value: %w", err)
+		}
+	}
+	if cfg.Transport == config.TransportUnix && cfg.SocketPath == "" {
+		return fmt.Errorf("--socket or ASC_SOCKET_PATH is required with the unix transport")
+	}
 
 	srv, err := server.New(cfg, os.Stdin, os.Stdout)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("failed to export the last traces: %v", err)
+		}
+	}()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(cmd.Context(), capabilityProbeTimeout)
+		defer cancel()
+		srv.ProbeCapabilities(ctx)
+	}()
+	srv.RefreshInstructionsInBackground()
+
+	if cfg.CacheWarmup {
+		go func() {
+			if err := srv.WarmCaches(cmd.Context()); err != nil {
+				log.Printf("cache warm-up stopped early: %v", err)
+			}
+		}()
+	}
+
+	if err := srv.ServeMetrics(cmd.Context(), cfg.MetricsAddr); err != nil {
+		return err
+	}
 
 	log.Printf("starting MCP server")
+	if cfg.Transport == config.TransportUnix {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return srv.ServeUnix(ctx, cfg.SocketPath)
+	}
 	return srv.Run()
 }
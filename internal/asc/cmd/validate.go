@@ -3,18 +3,19 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/config"
 )
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate configuration",
-	Long: `Validate that all required environment variables are set and the
-private key file exists and is readable.
+	Long: `Validate the configuration serve would load: that the required environment
+variables are set, and that each profile's private key, from a .p8 file or
+ASC_PRIVATE_KEY_BASE64, can be read and parsed.
 
 This command does not make any API calls - it only validates local configuration.`,
 	RunE: runValidate,
@@ -24,40 +25,29 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	fmt.Println("Validating configuration...")
 	fmt.Println()
 
-	// Check environment variables
-	issuerID := os.Getenv("ASC_ISSUER_ID")
-	keyID := os.Getenv("ASC_KEY_ID")
-	keyPath := os.Getenv("ASC_PRIVATE_KEY_PATH")
-
-	hasErrors := false
-
-	if issuerID == "" {
-		fmt.Println("[FAIL] ASC_ISSUER_ID is not set")
-		hasErrors = true
-	} else {
-		fmt.Printf("[OK]   ASC_ISSUER_ID is set (%s...)\n", issuerID[:8])
-	}
-
-	if keyID == "" {
-		fmt.Println("[FAIL] ASC_KEY_ID is not set")
-		hasErrors = true
-	} else {
-		fmt.Printf("[OK]   ASC_KEY_ID is set (%s)\n", keyID)
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("[FAIL] %v\n", err)
+		return fmt.Errorf("configuration validation failed")
 	}
 
-	if keyPath == "" {
-		fmt.Println("[FAIL] ASC_PRIVATE_KEY_PATH is not set")
-		hasErrors = true
-	} else {
-		if _, err := os.Stat(keyPath); os.IsNotExist(err) {
-			fmt.Printf("[FAIL] ASC_PRIVATE_KEY_PATH file not found: %s\n", keyPath)
-			hasErrors = true
-		} else if err != nil {
-			fmt.Printf("[FAIL] ASC_PRIVATE_KEY_PATH error: %v\n", err)
+	hasErrors := false
+	for _, profile := range cfg.AllProfiles() {
+		keyData, err := profile.KeyData()
+		if err == nil {
+			_, err = api.NewTokenProviderFromKey(profile.IssuerID, profile.KeyID, keyData)
+		}
+		if err != nil {
+			fmt.Printf("[FAIL] Profile %s: %v\n", profile.Name, err)
 			hasErrors = true
-		} else {
-			fmt.Printf("[OK]   ASC_PRIVATE_KEY_PATH exists (%s)\n", keyPath)
+			continue
+		}
+
+		source := "an inline base64 key"
+		if profile.PrivateKeyPath != "" {
+			source = profile.PrivateKeyPath
 		}
+		fmt.Printf("[OK]   Profile %s: key %s of issuer %s, from %s\n", profile.Name, profile.KeyID, profile.IssuerID, source)
 	}
 
 	fmt.Println()
@@ -66,13 +56,6 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("configuration validation failed")
 	}
 
-	// Try to load full config to validate key parsing
-	_, err := config.Load()
-	if err != nil {
-		fmt.Printf("[FAIL] Configuration load error: %v\n", err)
-		return err
-	}
-
 	fmt.Println("[OK]   Configuration is valid")
 	return nil
 }
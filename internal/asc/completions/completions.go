@@ -0,0 +1,210 @@
+// Package completions provides MCP argument completion for App Store Connect.
+package completions
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// cacheTTL is how long app and territory listings are reused between completions.
+const cacheTTL = 5 * time.Minute
+
+// Locales are the App Store Connect localization codes.
+var Locales = []string{
+	"ar-SA", "ca", "cs", "da", "de-DE", "el", "en-AU", "en-CA", "en-GB", "en-US",
+	"es-ES", "es-MX", "fi", "fr-CA", "fr-FR", "he", "hi", "hr", "hu", "id",
+	"it", "ja", "ko", "ms", "nl-NL", "no", "pl", "pt-BR", "pt-PT", "ro",
+	"ru", "sk", "sv", "th", "tr", "uk", "vi", "zh-Hans", "zh-Hant",
+}
+
+// Platforms are the App Store Connect platform values.
+var Platforms = api.EnumStrings(api.Platforms)
+
+// candidate is a completion value and the text it can be found by.
+type candidate struct {
+	value    string
+	keywords []string
+}
+
+// cachedList holds a listing fetched from the API.
+type cachedList struct {
+	candidates []candidate
+	fetched    time.Time
+}
+
+// Provider suggests argument values. App IDs and territories come from the
+// API and are cached; locales and platforms are fixed.
+type Provider struct {
+	client *api.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	apps        cachedList
+	territories cachedList
+}
+
+// NewProvider creates a new completion provider.
+func NewProvider(client *api.Client) *Provider {
+	return &Provider{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Complete returns suggestions for an argument given its partial value.
+// Arguments that aren't app IDs, locales, platforms, or territories get no
+// suggestions.
+func (p *Provider) Complete(ctx context.Context, argument, value string) (mcp.Completion, error) {
+	var candidates []candidate
+	switch argumentKind(argument) {
+	case "app":
+		var err error
+		candidates, err = p.cached(ctx, &p.apps, p.listApps)
+		if err != nil {
+			return mcp.Completion{}, err
+		}
+	case "territory":
+		var err error
+		candidates, err = p.cached(ctx, &p.territories, p.listTerritories)
+		if err != nil {
+			return mcp.Completion{}, err
+		}
+	case "locale":
+		candidates = staticCandidates(Locales)
+	case "platform":
+		candidates = staticCandidates(Platforms)
+	}
+
+	return match(candidates, value), nil
+}
+
+// Warm fetches the candidates of an argument that come from the API, such as
+// app_id, so its first completion doesn't wait for them. Other arguments
+// are ignored.
+func (p *Provider) Warm(ctx context.Context, argument string) error {
+	var err error
+	switch argumentKind(argument) {
+	case "app":
+		_, err = p.cached(ctx, &p.apps, p.listApps)
+	case "territory":
+		_, err = p.cached(ctx, &p.territories, p.listTerritories)
+	}
+	return err
+}
+
+// argumentKind classifies an argument by name, so app_id, appId, and
+// primary_locale all complete like their base argument.
+func argumentKind(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	switch {
+	case name == "appid":
+		return "app"
+	case strings.HasSuffix(name, "locale"):
+		return "locale"
+	case strings.HasSuffix(name, "platform"):
+		return "platform"
+	case name == "territory" || name == "territoryid":
+		return "territory"
+	}
+	return ""
+}
+
+// cached returns list's candidates, refreshing them with fetch once they expire.
+func (p *Provider) cached(ctx context.Context, list *cachedList, fetch func(context.Context) ([]candidate, error)) ([]candidate, error) {
+	p.mu.Lock()
+	if list.candidates != nil && p.now().Sub(list.fetched) < cacheTTL {
+		candidates := list.candidates
+		p.mu.Unlock()
+		return candidates, nil
+	}
+	p.mu.Unlock()
+
+	candidates, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	list.candidates = candidates
+	list.fetched = p.now()
+	p.mu.Unlock()
+
+	return candidates, nil
+}
+
+// listApps fetches app IDs, findable by name and bundle ID.
+func (p *Provider) listApps(ctx context.Context) ([]candidate, error) {
+	resp, err := p.client.ListApps(ctx, api.ListOptions{Limit: 200})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]candidate, 0, len(resp.Data))
+	for _, app := range resp.Data {
+		candidates = append(candidates, candidate{
+			value:    app.ID,
+			keywords: []string{app.Attributes.Name, app.Attributes.BundleID},
+		})
+	}
+	return candidates, nil
+}
+
+// listTerritories fetches territory codes such as USA and GBR.
+func (p *Provider) listTerritories(ctx context.Context) ([]candidate, error) {
+	resp, err := p.client.ListTerritories(ctx, api.ListOptions{Limit: 200})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]candidate, 0, len(resp.Data))
+	for _, territory := range resp.Data {
+		candidates = append(candidates, candidate{value: territory.ID})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].value < candidates[j].value })
+	return candidates, nil
+}
+
+// staticCandidates wraps fixed values as candidates.
+func staticCandidates(values []string) []candidate {
+	candidates := make([]candidate, len(values))
+	for i, v := range values {
+		candidates[i] = candidate{value: v}
+	}
+	return candidates
+}
+
+// match returns the candidates whose value starts with prefix, followed by
+// those with a keyword containing it, ignoring case. Results are capped at
+// mcp.MaxCompletionValues.
+func match(candidates []candidate, prefix string) mcp.Completion {
+	prefix = strings.ToLower(prefix)
+
+	values := []string{}
+	var keywordMatches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c.value), prefix) {
+			values = append(values, c.value)
+			continue
+		}
+		for _, keyword := range c.keywords {
+			if keyword != "" && strings.Contains(strings.ToLower(keyword), prefix) {
+				keywordMatches = append(keywordMatches, c.value)
+				break
+			}
+		}
+	}
+	values = append(values, keywordMatches...)
+
+	completion := mcp.Completion{Values: values, Total: len(values)}
+	if len(values) > mcp.MaxCompletionValues {
+		completion.Values = values[:mcp.MaxCompletionValues]
+		completion.HasMore = true
+	}
+	return completion
+}
@@ -0,0 +1,156 @@
+package completions
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// testProvider creates a provider backed by a mock server and counts its requests by path.
+func testProvider(t *testing.T, responses map[string]string) (*Provider, map[string]int) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	tokens, err := api.NewTokenProviderFromKey("test-issuer", "TESTKEY123", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}))
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewProvider(api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL))), requests
+}
+
+func TestArgumentKind(t *testing.T) {
+	tests := map[string]string{
+		"app_id":         "app",
+		"appId":          "app",
+		"locale":         "locale",
+		"primary_locale": "locale",
+		"platform":       "platform",
+		"territory":      "territory",
+		"territory_id":   "territory",
+		"build_id":       "",
+		"limit":          "",
+	}
+
+	for name, want := range tests {
+		if got := argumentKind(name); got != want {
+			t.Errorf("argumentKind(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestProvider_Complete_Apps(t *testing.T) {
+	provider, requests := testProvider(t, map[string]string{
+		"/v1/apps": `{"data": [
+			{"type": "apps", "id": "123", "attributes": {"name": "Weather", "bundleId": "com.example.weather"}},
+			{"type": "apps", "id": "456", "attributes": {"name": "Calculator", "bundleId": "com.example.calc"}}
+		]}`,
+	})
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", []string{"123", "456"}},
+		{"12", []string{"123"}},
+		{"weath", []string{"123"}},
+		{"com.example", []string{"123", "456"}},
+		{"missing", []string{}},
+	}
+
+	for _, tt := range tests {
+		completion, err := provider.Complete(context.Background(), "app_id", tt.value)
+		if err != nil {
+			t.Fatalf("Complete(%q) failed: %v", tt.value, err)
+		}
+		if strings.Join(completion.Values, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Complete(%q) = %v, want %v", tt.value, completion.Values, tt.want)
+		}
+	}
+
+	if requests["/v1/apps"] != 1 {
+		t.Errorf("apps fetched %d times, want 1", requests["/v1/apps"])
+	}
+
+	// Expired listings are fetched again.
+	provider.now = func() time.Time { return time.Now().Add(cacheTTL) }
+	if _, err := provider.Complete(context.Background(), "appId", ""); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if requests["/v1/apps"] != 2 {
+		t.Errorf("apps fetched %d times, want 2", requests["/v1/apps"])
+	}
+}
+
+func TestProvider_Complete_Territories(t *testing.T) {
+	provider, _ := testProvider(t, map[string]string{
+		"/v1/territories": `{"data": [
+			{"type": "territories", "id": "USA"},
+			{"type": "territories", "id": "GBR"},
+			{"type": "territories", "id": "UKR"}
+		]}`,
+	})
+
+	completion, err := provider.Complete(context.Background(), "territory", "u")
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if strings.Join(completion.Values, ",") != "UKR,USA" {
+		t.Errorf("Values = %v, want [UKR USA]", completion.Values)
+	}
+}
+
+func TestProvider_Complete_Error(t *testing.T) {
+	provider, _ := testProvider(t, nil)
+
+	if _, err := provider.Complete(context.Background(), "app_id", ""); err == nil {
+		t.Error("expected an error when apps can't be listed")
+	}
+}
+
+func TestMatch_Limit(t *testing.T) {
+	values := make([]string, mcp.MaxCompletionValues+10)
+	for i := range values {
+		values[i] = fmt.Sprintf("v%03d", i)
+	}
+
+	completion := match(staticCandidates(values), "v")
+	if len(completion.Values) != mcp.MaxCompletionValues {
+		t.Errorf("len(Values) = %d, want %d", len(completion.Values), mcp.MaxCompletionValues)
+	}
+	if completion.Total != len(values) || !completion.HasMore {
+		t.Errorf("Total = %d, HasMore = %v", completion.Total, completion.HasMore)
+	}
+}
@@ -2,8 +2,38 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// Account types accepted in ASC_ACCOUNT_TYPE.
+const (
+	// AccountTypeStandard is an Apple Developer Program account.
+	AccountTypeStandard = "standard"
+
+	// AccountTypeEnterprise is an Apple Developer Enterprise Program (In-House) account.
+	AccountTypeEnterprise = "enterprise"
+)
+
+// Transports accepted in ASC_TRANSPORT.
+const (
+	// TransportStdio serves one client over standard input and output.
+	TransportStdio = "stdio"
+
+	// TransportUnix serves clients that connect to a Unix domain socket.
+	TransportUnix = "unix"
 )
 
 // Config holds the configuration for the App Store Connect MCP server.
@@ -16,31 +46,950 @@ type Config struct {
 
 	// PrivateKeyPath is the path to the .p8 private key file.
 	PrivateKeyPath string
+
+	// PrivateKey is the PEM contents of the .p8 private key, decoded from
+	// ASC_PRIVATE_KEY_BASE64. When set, PrivateKeyPath is empty.
+	PrivateKey []byte
+
+	// BaseURL overrides the App Store Connect API base URL when set.
+	BaseURL string
+
+	// EnableRawAPI exposes the asc_api_request passthrough tool.
+	EnableRawAPI bool
+
+	// RequireConfirmation makes destructive tools preview their API requests
+	// until called again with confirm set to true.
+	RequireConfirmation bool
+
+	// AllowDestructiveAppOperations lets tools remove apps and versions
+	// from sale or delete them.
+	AllowDestructiveAppOperations bool
+
+	// AccountType is AccountTypeStandard or AccountTypeEnterprise.
+	AccountType string
+
+	// ToolTimeouts overrides the maximum duration of tool calls, keyed by
+	// tool name, name prefix ending in "*", or "*" for every tool.
+	ToolTimeouts map[string]time.Duration
+
+	// RequestTimeouts overrides the time limit of one attempt of an API
+	// request, keyed by request category: default, list, report or
+	// download. Zero means no limit.
+	RequestTimeouts map[string]time.Duration
+
+	// ConcurrencyLimits caps how many tool calls run at once, keyed by "*" for
+	// all calls or a glob over tool names.
+	ConcurrencyLimits map[string]int
+
+	// LogLevel is the minimum level of MCP log notifications until the client
+	// sets one. Empty means the server default.
+	LogLevel string
+
+	// Profiles holds credentials for additional teams, named in ASC_PROFILES.
+	// The credentials above form the "default" profile.
+	Profiles []Profile
+
+	// SnapshotPath is the JSON file in which observed state history, such as
+	// App Store version state changes, is kept. Empty keeps it in memory.
+	SnapshotPath string
+
+	// MaxResultBytes limits the size of a tool result's text. Longer results
+	// are truncated and continued with get_result_continuation. Zero turns
+	// truncation off.
+	MaxResultBytes int
+
+	// ToolPrefix is prepended to tool names, so they don't collide with other
+	// servers' tools in the same client. Empty leaves names as they are.
+	ToolPrefix string
+
+	// ToolGroups adds each tool's group after ToolPrefix, as in
+	// "asc_builds_list_builds".
+	ToolGroups bool
+
+	// AppGroups names sets of app IDs that tools working across several apps
+	// accept in place of an app ID.
+	AppGroups map[string][]string
+
+	// Transport is TransportStdio or TransportUnix.
+	Transport string
+
+	// SocketPath is the Unix domain socket to listen on with TransportUnix.
+	SocketPath string
+
+	// MetricsAddr is the host:port Prometheus metrics are served on, at
+	// /metrics. Empty turns metrics off.
+	MetricsAddr string
+
+	// TracesEndpoint is the OTLP/HTTP URL spans are exported to, such as
+	// http://localhost:4318/v1/traces. Empty turns tracing off.
+	TracesEndpoint string
+
+	// TracesHeaders are added to each trace export request, such as an
+	// API key for the collector.
+	TracesHeaders map[string]string
+
+	// ServiceName is the service.name spans are attributed to.
+	ServiceName string
+
+	// AuditLogPath is the JSON Lines file every tool call is appended to.
+	// Empty turns the audit log off.
+	AuditLogPath string
+
+	// RateLimitWait is how long an API request refused with a 429 may wait
+	// in total for retries. Zero returns rate limit errors at once.
+	RateLimitWait time.Duration
+
+	// MaxAttempts is how many times an idempotent API request is sent when
+	// it fails with a 5xx status or a network error. One turns retries off.
+	MaxAttempts int
+
+	// PageConcurrency is how many pages of a list are fetched at once when
+	// a tool reads all of them. One fetches pages one after another.
+	PageConcurrency int
+
+	// ProxyURL is the proxy API requests are sent through. Empty uses the
+	// one named by HTTPS_PROXY, if any.
+	ProxyURL string
+
+	// MaxIdleConnsPerHost is how many idle connections to each host are
+	// kept for reuse.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open. Zero
+	// keeps it until the server closes it.
+	IdleConnTimeout time.Duration
+
+	// HTTP2 sends concurrent API requests over one HTTP/2 connection
+	// instead of separate HTTP/1.1 connections.
+	HTTP2 bool
+
+	// UserAgentTag identifies the caller at the end of the User-Agent of
+	// API requests, after asc-mcp's own name and version.
+	UserAgentTag string
+
+	// CAFile is a PEM file of CA certificates trusted for API connections
+	// in addition to the system's, such as a corporate proxy's.
+	CAFile string
+
+	// CacheDir is a directory cached API responses are also kept in, so
+	// they can be revalidated after a restart. Empty keeps them in memory.
+	CacheDir string
+
+	// TokenRefreshBuffer is how long before its expiry the signed API
+	// token is replaced with a new one.
+	TokenRefreshBuffer time.Duration
+
+	// CacheWarmup prefetches apps, territories and beta groups in the
+	// background at startup.
+	CacheWarmup bool
+
+	// EnumLabels shows readable phrases next to the enum values in tool
+	// results, as in "Waiting for review (WAITING_FOR_REVIEW)".
+	EnumLabels bool
+
+	// StrictDecoding fails API responses with members the client's types
+	// don't describe, or resources of an unexpected type, instead of
+	// ignoring what they don't know.
+	StrictDecoding bool
+
+	// EnumLabelsPath is a JSON file of enum values and the phrases shown
+	// for them instead of the built-in ones. Empty uses the built-in ones.
+	EnumLabelsPath string
+}
+
+// DefaultServiceName is the service.name of spans when OTEL_SERVICE_NAME is
+// not set.
+const DefaultServiceName = "asc-mcp"
+
+// DefaultToolPrefix is the tool name prefix when ASC_TOOL_PREFIX is not set.
+const DefaultToolPrefix = "asc_"
+
+// appGroupNamePattern matches app group names. They start with a letter so
+// they can't be mistaken for app IDs.
+var appGroupNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// toolPrefixPattern matches prefixes that keep tool names valid.
+var toolPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// DefaultMaxResultBytes is the result size limit when ASC_MAX_RESULT_BYTES
+// is not set.
+const DefaultMaxResultBytes = 100_000
+
+// DefaultRateLimitWait is how long rate-limited requests are retried when
+// ASC_RATE_LIMIT_WAIT is not set.
+const DefaultRateLimitWait = time.Minute
+
+// DefaultTokenRefreshBuffer is how long before its expiry the API token is
+// replaced when ASC_TOKEN_REFRESH_BUFFER is not set.
+const DefaultTokenRefreshBuffer = 2 * time.Minute
+
+// maxTokenRefreshBuffer is the lifetime of an API token. A longer buffer
+// would replace the token on every request.
+const maxTokenRefreshBuffer = 15 * time.Minute
+
+// DefaultMaxAttempts is how many times a request failing with a transient
+// error is sent when ASC_MAX_ATTEMPTS is not set.
+const DefaultMaxAttempts = 3
+
+// DefaultPageConcurrency is how many pages of a list are fetched at once
+// when ASC_PAGE_CONCURRENCY is not set.
+const DefaultPageConcurrency = 4
+
+// maxPageConcurrency caps ASC_PAGE_CONCURRENCY, since every page in flight
+// counts toward the API's hourly rate limit at the same time.
+const maxPageConcurrency = 16
+
+// DefaultMaxIdleConnsPerHost is how many idle connections to each host are
+// kept when ASC_MAX_IDLE_CONNS_PER_HOST is not set.
+const DefaultMaxIdleConnsPerHost = 32
+
+// DefaultIdleConnTimeout is how long an idle connection is kept open when
+// ASC_IDLE_CONN_TIMEOUT is not set.
+const DefaultIdleConnTimeout = 90 * time.Second
+
+// DefaultProfile is the name of the profile formed by ASC_ISSUER_ID,
+// ASC_KEY_ID and the ASC_PRIVATE_KEY_* variables.
+const DefaultProfile = "default"
+
+// Profile holds the API key for one App Store Connect team.
+type Profile struct {
+	// Name identifies the profile in ASC_PROFILES and tool arguments.
+	Name string
+
+	// IssuerID is the team's API Issuer ID.
+	IssuerID string
+
+	// KeyID is the team's API Key ID.
+	KeyID string
+
+	// PrivateKeyPath is the path to the team's .p8 private key file.
+	PrivateKeyPath string
+
+	// PrivateKey is the PEM contents of the team's .p8 private key.
+	// When set, PrivateKeyPath is empty.
+	PrivateKey []byte
+
+	// BaseURL overrides the API base URL for the team when set.
+	BaseURL string
+}
+
+// KeyData returns the PEM contents of the profile's private key, read from
+// PrivateKeyPath unless PrivateKey is set.
+func (p Profile) KeyData() ([]byte, error) {
+	if len(p.PrivateKey) > 0 {
+		return p.PrivateKey, nil
+	}
+	data, err := os.ReadFile(p.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	return data, nil
+}
+
+// AllProfiles returns the default profile, formed by the ASC_ variables,
+// followed by the profiles in ASC_PROFILES.
+func (c *Config) AllProfiles() []Profile {
+	return append([]Profile{{
+		Name:           DefaultProfile,
+		IssuerID:       c.IssuerID,
+		KeyID:          c.KeyID,
+		PrivateKeyPath: c.PrivateKeyPath,
+		PrivateKey:     c.PrivateKey,
+		BaseURL:        c.BaseURL,
+	}}, c.Profiles...)
 }
 
+// profileNamePattern matches names that can be embedded in environment variable names.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 // Load loads configuration from environment variables.
 func Load() (*Config, error) {
+	defaultProfile, err := loadProfile(DefaultProfile, "ASC_")
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
-		IssuerID:       os.Getenv("ASC_ISSUER_ID"),
-		KeyID:          os.Getenv("ASC_KEY_ID"),
-		PrivateKeyPath: os.Getenv("ASC_PRIVATE_KEY_PATH"),
+		IssuerID:       defaultProfile.IssuerID,
+		KeyID:          defaultProfile.KeyID,
+		PrivateKeyPath: defaultProfile.PrivateKeyPath,
+		PrivateKey:     defaultProfile.PrivateKey,
+		BaseURL:        defaultProfile.BaseURL,
+		AccountType:    AccountTypeStandard,
+		MaxResultBytes: DefaultMaxResultBytes,
+		ToolPrefix:     DefaultToolPrefix,
+		Transport:      TransportStdio,
+		RateLimitWait:  DefaultRateLimitWait,
+		MaxAttempts:    DefaultMaxAttempts,
+
+		PageConcurrency:     DefaultPageConcurrency,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		HTTP2:               true,
+		ServiceName:         DefaultServiceName,
+		TokenRefreshBuffer:  DefaultTokenRefreshBuffer,
+		CacheWarmup:         true,
+		EnumLabels:          true,
+	}
+
+	if v := os.Getenv("ASC_PROFILES"); v != "" {
+		if cfg.Profiles, err = loadProfiles(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.EnableRawAPI, err = boolEnv("ASC_ENABLE_RAW_API"); err != nil {
+		return nil, err
+	}
+
+	if cfg.RequireConfirmation, err = boolEnv("ASC_REQUIRE_CONFIRMATION"); err != nil {
+		return nil, err
+	}
+
+	if cfg.AllowDestructiveAppOperations, err = boolEnv("ASC_ALLOW_DESTRUCTIVE_APP_OPERATIONS"); err != nil {
+		return nil, err
+	}
+
+	if v := os.Getenv("ASC_ACCOUNT_TYPE"); v != "" {
+		if cfg.AccountType, err = ParseAccountType(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_ACCOUNT_TYPE value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_TOOL_TIMEOUTS"); v != "" {
+		if cfg.ToolTimeouts, err = ParseToolTimeouts(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_TOOL_TIMEOUTS value: %w", err)
+		}
+	}
+	if v := os.Getenv("ASC_REQUEST_TIMEOUTS"); v != "" {
+		if cfg.RequestTimeouts, err = ParseRequestTimeouts(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_REQUEST_TIMEOUTS value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_LOG_LEVEL"); v != "" {
+		if mcp.LogLevelSeverity(v) < 0 {
+			return nil, fmt.Errorf("invalid ASC_LOG_LEVEL value %q: must be one of %s", v, strings.Join(mcp.LogLevels, ", "))
+		}
+		cfg.LogLevel = v
+	}
+
+	if v := os.Getenv("ASC_CONCURRENCY_LIMITS"); v != "" {
+		if cfg.ConcurrencyLimits, err = ParseConcurrencyLimits(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_CONCURRENCY_LIMITS value: %w", err)
+		}
+	}
+
+	if v, ok := os.LookupEnv("ASC_SNAPSHOT_PATH"); ok {
+		if cfg.SnapshotPath, err = ExpandPath(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_SNAPSHOT_PATH value: %w", err)
+		}
+	} else {
+		cfg.SnapshotPath = DefaultSnapshotPath()
+	}
+
+	if v := os.Getenv("ASC_MAX_RESULT_BYTES"); v != "" {
+		if cfg.MaxResultBytes, err = ParseMaxResultBytes(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_MAX_RESULT_BYTES value: %w", err)
+		}
+	}
+
+	if v, ok := os.LookupEnv("ASC_TOOL_PREFIX"); ok {
+		if cfg.ToolPrefix, err = ParseToolPrefix(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_TOOL_PREFIX value: %w", err)
+		}
+	}
+
+	if cfg.ToolGroups, err = boolEnv("ASC_TOOL_GROUPS"); err != nil {
+		return nil, err
+	}
+
+	if os.Getenv("ASC_CACHE_WARMUP") != "" {
+		if cfg.CacheWarmup, err = boolEnv("ASC_CACHE_WARMUP"); err != nil {
+			return nil, err
+		}
+	}
+
+	if os.Getenv("ASC_ENUM_LABELS") != "" {
+		if cfg.EnumLabels, err = boolEnv("ASC_ENUM_LABELS"); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.EnumLabelsPath, err = ExpandPath(os.Getenv("ASC_ENUM_LABELS_FILE")); err != nil {
+		return nil, fmt.Errorf("invalid ASC_ENUM_LABELS_FILE value: %w", err)
+	}
+
+	if cfg.StrictDecoding, err = boolEnv("ASC_STRICT_DECODING"); err != nil {
+		return nil, err
+	}
+
+	if v := os.Getenv("ASC_APP_GROUPS"); v != "" {
+		if cfg.AppGroups, err = ParseAppGroups(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_APP_GROUPS value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_TRANSPORT"); v != "" {
+		if cfg.Transport, err = ParseTransport(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_TRANSPORT value: %w", err)
+		}
+	}
+	if cfg.SocketPath, err = ExpandPath(os.Getenv("ASC_SOCKET_PATH")); err != nil {
+		return nil, fmt.Errorf("invalid ASC_SOCKET_PATH value: %w", err)
+	}
+	if v := os.Getenv("ASC_METRICS_ADDR"); v != "" {
+		if cfg.MetricsAddr, err = ParseMetricsAddr(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_METRICS_ADDR value: %w", err)
+		}
+	}
+	if err := loadTracing(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AuditLogPath, err = ExpandPath(os.Getenv("ASC_AUDIT_LOG")); err != nil {
+		return nil, fmt.Errorf("invalid ASC_AUDIT_LOG value: %w", err)
+	}
+
+	if v := os.Getenv("ASC_RATE_LIMIT_WAIT"); v != "" {
+		if cfg.RateLimitWait, err = ParseRateLimitWait(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_RATE_LIMIT_WAIT value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_TOKEN_REFRESH_BUFFER"); v != "" {
+		if cfg.TokenRefreshBuffer, err = ParseTokenRefreshBuffer(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_TOKEN_REFRESH_BUFFER value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_MAX_ATTEMPTS"); v != "" {
+		if cfg.MaxAttempts, err = ParseMaxAttempts(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_MAX_ATTEMPTS value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_PAGE_CONCURRENCY"); v != "" {
+		if cfg.PageConcurrency, err = ParsePageConcurrency(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_PAGE_CONCURRENCY value: %w", err)
+		}
 	}
 
-	if cfg.IssuerID == "" {
-		return nil, fmt.Errorf("ASC_ISSUER_ID environment variable is required")
+	if v := os.Getenv("ASC_MAX_IDLE_CONNS_PER_HOST"); v != "" {
+		if cfg.MaxIdleConnsPerHost, err = ParseMaxIdleConnsPerHost(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_MAX_IDLE_CONNS_PER_HOST value: %w", err)
+		}
 	}
 
-	if cfg.KeyID == "" {
-		return nil, fmt.Errorf("ASC_KEY_ID environment variable is required")
+	if v := os.Getenv("ASC_IDLE_CONN_TIMEOUT"); v != "" {
+		if cfg.IdleConnTimeout, err = ParseIdleConnTimeout(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_IDLE_CONN_TIMEOUT value: %w", err)
+		}
 	}
 
-	if cfg.PrivateKeyPath == "" {
-		return nil, fmt.Errorf("ASC_PRIVATE_KEY_PATH environment variable is required")
+	if os.Getenv("ASC_HTTP2") != "" {
+		if cfg.HTTP2, err = boolEnv("ASC_HTTP2"); err != nil {
+			return nil, err
+		}
 	}
 
-	if _, err := os.Stat(cfg.PrivateKeyPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("private key file not found: %s", cfg.PrivateKeyPath)
+	if v := os.Getenv("ASC_USER_AGENT_TAG"); v != "" {
+		if cfg.UserAgentTag, err = ParseUserAgentTag(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_USER_AGENT_TAG value: %w", err)
+		}
+	}
+
+	if v := os.Getenv("ASC_PROXY_URL"); v != "" {
+		if cfg.ProxyURL, err = ParseProxyURL(v); err != nil {
+			return nil, fmt.Errorf("invalid ASC_PROXY_URL value: %w", err)
+		}
+	}
+	if cfg.CAFile, err = ExpandPath(os.Getenv("ASC_CA_FILE")); err != nil {
+		return nil, fmt.Errorf("invalid ASC_CA_FILE value: %w", err)
+	}
+	if cfg.CacheDir, err = ExpandPath(os.Getenv("ASC_CACHE_DIR")); err != nil {
+		return nil, fmt.Errorf("invalid ASC_CACHE_DIR value: %w", err)
 	}
 
 	return cfg, nil
 }
+
+// ParseConcurrencyLimits parses a comma-separated list of pattern=limit pairs,
+// such as "*=4,list_*=2". Patterns are globs over tool names.
+func ParseConcurrencyLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, value, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%q is not pattern=limit", entry)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit for %s: %w", pattern, err)
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("limit for %s must be positive", pattern)
+		}
+		limits[pattern] = limit
+	}
+	return limits, nil
+}
+
+// ParseAppGroups parses a semicolon-separated list of name=ids pairs, where
+// ids is a comma-separated list of app IDs, such as
+// "flagship=123,456;clients=789".
+func ParseAppGroups(s string) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not name=app_id,app_id", entry)
+		}
+		if !appGroupNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("app group name %q must start with a letter and contain only letters, digits, underscores and hyphens", name)
+		}
+		var appIDs []string
+		for _, appID := range strings.Split(value, ",") {
+			if appID = strings.TrimSpace(appID); appID != "" {
+				appIDs = append(appIDs, appID)
+			}
+		}
+		if len(appIDs) == 0 {
+			return nil, fmt.Errorf("app group %s has no app IDs", name)
+		}
+		groups[name] = appIDs
+	}
+	return groups, nil
+}
+
+// ParseMaxResultBytes parses a result size limit in bytes. Zero turns
+// truncation off.
+func ParseMaxResultBytes(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative number of bytes", s)
+	}
+	return n, nil
+}
+
+// ParseRateLimitWait parses how long rate-limited requests are retried, as a
+// duration such as "2m". Zero turns retries off.
+func ParseRateLimitWait(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative duration", s)
+	}
+	return d, nil
+}
+
+// ParseTokenRefreshBuffer parses how long before its expiry the API token is
+// replaced, as a duration such as "30s" shorter than the token's 15-minute
+// lifetime.
+func ParseTokenRefreshBuffer(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil || d < 0 || d >= maxTokenRefreshBuffer {
+		return 0, fmt.Errorf("%q is not a duration from 0 to under %s", s, maxTokenRefreshBuffer)
+	}
+	return d, nil
+}
+
+// ParseMaxAttempts parses how many times a request failing with a transient
+// error is sent. One turns retries off.
+func ParseMaxAttempts(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number of attempts", s)
+	}
+	return n, nil
+}
+
+// ParsePageConcurrency parses how many pages of a list are fetched at once,
+// from 1 to 16.
+func ParsePageConcurrency(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 || n > maxPageConcurrency {
+		return 0, fmt.Errorf("%q is not a number of pages from 1 to %d", s, maxPageConcurrency)
+	}
+	return n, nil
+}
+
+// ParseMaxIdleConnsPerHost parses how many idle connections to each host
+// are kept for reuse, at least 1.
+func ParseMaxIdleConnsPerHost(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number of connections", s)
+	}
+	return n, nil
+}
+
+// ParseIdleConnTimeout parses how long an idle connection is kept open,
+// such as "2m". Zero keeps it until the server closes it.
+func ParseIdleConnTimeout(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative duration", s)
+	}
+	return d, nil
+}
+
+// ParseUserAgentTag validates a caller identifier for the User-Agent, such
+// as "release-bot/3" or "acme-ci (build 42)". It must be printable ASCII so
+// the header stays valid.
+func ParseUserAgentTag(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			return "", fmt.Errorf("%q has characters other than printable ASCII", s)
+		}
+	}
+	return s, nil
+}
+
+// ParseProxyURL validates a proxy URL such as http://proxy.example.com:8080.
+// The scheme may be http, https or socks5.
+func ParseProxyURL(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return "", fmt.Errorf("%q must be an http, https or socks5 URL", s)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", s)
+	}
+	return u.String(), nil
+}
+
+// ParseToolPrefix checks that a tool name prefix only uses the letters,
+// digits, underscores and hyphens allowed in tool names.
+func ParseToolPrefix(s string) (string, error) {
+	if !toolPrefixPattern.MatchString(s) {
+		return "", fmt.Errorf("%q may only contain letters, digits, underscores and hyphens", s)
+	}
+	return s, nil
+}
+
+// ParseAccountType validates an account type, ignoring case.
+func ParseAccountType(s string) (string, error) {
+	switch accountType := strings.ToLower(strings.TrimSpace(s)); accountType {
+	case AccountTypeStandard, AccountTypeEnterprise:
+		return accountType, nil
+	default:
+		return "", fmt.Errorf("%q is not %s or %s", s, AccountTypeStandard, AccountTypeEnterprise)
+	}
+}
+
+// ParseTransport validates a transport, ignoring case.
+func ParseTransport(s string) (string, error) {
+	switch transport := strings.ToLower(strings.TrimSpace(s)); transport {
+	case TransportStdio, TransportUnix:
+		return transport, nil
+	default:
+		return "", fmt.Errorf("%q is not %s or %s", s, TransportStdio, TransportUnix)
+	}
+}
+
+// ParseMetricsAddr validates a host:port address to serve metrics on, such
+// as "127.0.0.1:9464" or ":9464" for every interface.
+func ParseMetricsAddr(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", err
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return "", fmt.Errorf("%q has no valid port", s)
+	}
+	return s, nil
+}
+
+// loadTracing reads the standard OpenTelemetry exporter variables. Traces
+// are exported when OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, or
+// OTEL_EXPORTER_OTLP_ENDPOINT with /v1/traces appended, is set, unless
+// OTEL_SDK_DISABLED is true or OTEL_TRACES_EXPORTER is "none".
+func loadTracing(cfg *Config) error {
+	if v := os.Getenv("OTEL_SERVICE_NAME"); v != "" {
+		cfg.ServiceName = v
+	}
+
+	disabled, err := boolEnv("OTEL_SDK_DISABLED")
+	if err != nil {
+		return err
+	}
+	exporter := strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))
+	if disabled || exporter == "none" {
+		return nil
+	}
+	if exporter != "" && exporter != "otlp" {
+		return fmt.Errorf("invalid OTEL_TRACES_EXPORTER value: %q is not otlp or none", exporter)
+	}
+
+	for _, name := range []string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"} {
+		if v := os.Getenv(name); v != "" && v != "http/json" {
+			return fmt.Errorf("invalid %s value: %q is not supported; only http/json is", name, v)
+		}
+	}
+
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	name := "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	if endpoint == "" {
+		if base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+			endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+			name = "OTEL_EXPORTER_OTLP_ENDPOINT"
+		}
+	}
+	if endpoint != "" {
+		if cfg.TracesEndpoint, err = ParseTracesEndpoint(endpoint); err != nil {
+			return fmt.Errorf("invalid %s value: %w", name, err)
+		}
+	}
+
+	headers := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS")
+	name = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	if headers == "" {
+		headers = os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")
+		name = "OTEL_EXPORTER_OTLP_HEADERS"
+	}
+	if headers != "" {
+		if cfg.TracesHeaders, err = ParseOTLPHeaders(headers); err != nil {
+			return fmt.Errorf("invalid %s value: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ParseTracesEndpoint validates an OTLP/HTTP traces URL such as
+// http://localhost:4318/v1/traces.
+func ParseTracesEndpoint(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%q must be an http or https URL", s)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", s)
+	}
+	return u.String(), nil
+}
+
+// ParseOTLPHeaders parses comma-separated name=value pairs of OTLP export
+// headers, such as "api-key=abc123,x-team=ios". Values may be URL-encoded.
+func ParseOTLPHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for i, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			// The pair isn't quoted, as it may hold a secret.
+			return nil, fmt.Errorf("entry %d is not a name=value pair", i+1)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		headers[name] = decoded
+	}
+	return headers, nil
+}
+
+// ParseToolTimeouts parses a comma-separated list of pattern=duration pairs,
+// such as "list_*=30s,get_sales_report=5m".
+func ParseToolTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, value, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%q is not pattern=duration", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", pattern, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("duration for %s must be positive", pattern)
+		}
+		timeouts[pattern] = timeout
+	}
+	return timeouts, nil
+}
+
+// requestCategories are the API request categories that have their own
+// time limit.
+var requestCategories = []string{"default", "list", "report", "download"}
+
+// ParseRequestTimeouts parses a comma-separated list of category=duration
+// pairs, such as "list=2m,report=10m". A duration of 0 removes the limit.
+func ParseRequestTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		category, value, ok := strings.Cut(entry, "=")
+		category = strings.TrimSpace(category)
+		if !ok || !slices.Contains(requestCategories, category) {
+			return nil, fmt.Errorf("%q is not category=duration with a category of %s", entry, strings.Join(requestCategories, ", "))
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", category, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("duration for %s must not be negative", category)
+		}
+		timeouts[category] = timeout
+	}
+	return timeouts, nil
+}
+
+// loadProfile reads a profile's credentials from the environment variables
+// with the given prefix, such as ASC_ or ASC_ACME_.
+func loadProfile(name, prefix string) (Profile, error) {
+	keyPath, err := ExpandPath(os.Getenv(prefix + "PRIVATE_KEY_PATH"))
+	if err != nil {
+		return Profile{}, fmt.Errorf("invalid %sPRIVATE_KEY_PATH value: %w", prefix, err)
+	}
+	profile := Profile{
+		Name:           name,
+		IssuerID:       os.Getenv(prefix + "ISSUER_ID"),
+		KeyID:          os.Getenv(prefix + "KEY_ID"),
+		PrivateKeyPath: keyPath,
+	}
+
+	if profile.IssuerID == "" {
+		return Profile{}, fmt.Errorf("%sISSUER_ID environment variable is required", prefix)
+	}
+
+	if profile.KeyID == "" {
+		return Profile{}, fmt.Errorf("%sKEY_ID environment variable is required", prefix)
+	}
+
+	keyBase64 := os.Getenv(prefix + "PRIVATE_KEY_BASE64")
+	switch {
+	case profile.PrivateKeyPath != "" && keyBase64 != "":
+		return Profile{}, fmt.Errorf("set only one of %sPRIVATE_KEY_PATH and %sPRIVATE_KEY_BASE64", prefix, prefix)
+	case keyBase64 != "":
+		key, err := decodePrivateKey(prefix+"PRIVATE_KEY_BASE64", keyBase64)
+		if err != nil {
+			return Profile{}, err
+		}
+		profile.PrivateKey = key
+	case profile.PrivateKeyPath == "":
+		return Profile{}, fmt.Errorf("%sPRIVATE_KEY_PATH or %sPRIVATE_KEY_BASE64 environment variable is required", prefix, prefix)
+	default:
+		if _, err := os.Stat(profile.PrivateKeyPath); os.IsNotExist(err) {
+			return Profile{}, fmt.Errorf("private key file not found: %s", profile.PrivateKeyPath)
+		}
+	}
+
+	if v := os.Getenv(prefix + "BASE_URL"); v != "" {
+		baseURL, err := ParseBaseURL(v)
+		if err != nil {
+			return Profile{}, fmt.Errorf("invalid %sBASE_URL value: %w", prefix, err)
+		}
+		profile.BaseURL = baseURL
+	}
+
+	return profile, nil
+}
+
+// ParseBaseURL validates an API base URL such as https://api.example.com and
+// strips any trailing slash. The URL may have a path, such as a gateway's
+// https://gateway.example.com/asc.
+func ParseBaseURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("%q must be an http or https URL", s)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", s)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not have a query or fragment", s)
+	}
+	return strings.TrimSuffix(s, "/"), nil
+}
+
+// loadProfiles loads the comma-separated profile names in ASC_PROFILES. Each
+// profile's credentials use its upper-cased name in the variable prefix, so
+// "acme" reads ASC_ACME_ISSUER_ID, ASC_ACME_KEY_ID, and so on.
+func loadProfiles(names string) ([]Profile, error) {
+	seen := map[string]bool{DefaultProfile: true}
+	var profiles []Profile
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !profileNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid ASC_PROFILES value: profile name %q may only contain letters, digits and underscores", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid ASC_PROFILES value: duplicate or reserved profile name %q", name)
+		}
+		seen[name] = true
+
+		profile, err := loadProfile(name, "ASC_"+strings.ToUpper(name)+"_")
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// decodePrivateKey decodes a base64-encoded .p8 file read from the named
+// variable. Line breaks, as written by the base64 command, are ignored.
+func decodePrivateKey(variable, encoded string) ([]byte, error) {
+	encoded = strings.Join(strings.Fields(encoded), "")
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %w", variable, err)
+	}
+	if block, _ := pem.Decode(key); block == nil {
+		return nil, fmt.Errorf("invalid %s value: decoded key is not PEM; encode the whole .p8 file", variable)
+	}
+	return key, nil
+}
+
+// boolEnv parses an optional boolean environment variable. Unset means false.
+func boolEnv(name string) (bool, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value %q: %w", name, v, err)
+	}
+	return enabled, nil
+}
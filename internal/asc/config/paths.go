@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ExpandPath expands a leading "~" in path to the user's home directory and
+// removes double quotes around the whole path, which Windows Explorer's
+// "Copy as path" adds. "~/" is recognized everywhere and "~\" on Windows.
+// Other paths, including "~user/...", are returned unchanged.
+func ExpandPath(path string) (string, error) {
+	if len(path) >= 2 && strings.HasPrefix(path, `"`) && strings.HasSuffix(path, `"`) {
+		path = path[1 : len(path)-1]
+	}
+
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && rest[0] != '/' && !(runtime.GOOS == "windows" && rest[0] == '\\')) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot expand %s: %w", path, err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// Dir returns the directory asc-mcp keeps its files in by default, or "" if
+// the platform has none:
+//
+//   - Windows: %LocalAppData%\asc-mcp
+//   - macOS: ~/Library/Application Support/asc-mcp
+//   - Linux and others: $XDG_CONFIG_HOME/asc-mcp, or ~/.config/asc-mcp
+func Dir() string {
+	dir := platformDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "asc-mcp")
+}
+
+// DefaultSnapshotPath returns snapshots.json in Dir, or "" if there is none.
+// A snapshot file an earlier version kept elsewhere is used if there's none
+// in Dir yet.
+func DefaultSnapshotPath() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	path := filepath.Join(dir, "snapshots.json")
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	for _, legacy := range legacyDirs() {
+		legacyPath := filepath.Join(legacy, "asc-mcp", "snapshots.json")
+		if _, err := os.Stat(legacyPath); err == nil {
+			return legacyPath
+		}
+	}
+	return path
+}
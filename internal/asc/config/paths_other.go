@@ -0,0 +1,19 @@
+//go:build !windows
+
+package config
+
+import "os"
+
+// platformDir returns the user's configuration directory.
+func platformDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// legacyDirs returns directories earlier versions kept their files in.
+func legacyDirs() []string {
+	return nil
+}
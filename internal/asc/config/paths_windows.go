@@ -0,0 +1,24 @@
+//go:build windows
+
+package config
+
+import "os"
+
+// platformDir returns %LocalAppData%, so snapshots and logs stay on the
+// machine instead of roaming with the user's profile.
+func platformDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// legacyDirs returns %AppData%, where earlier versions kept their files.
+func legacyDirs() []string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil
+	}
+	return []string{dir}
+}
@@ -0,0 +1,238 @@
+// Package doctor checks that asc-mcp is set up to reach App Store Connect:
+// that each profile's key parses and signs tokens, which parts of the API the
+// key may use, that the local clock agrees with Apple's, and that the files
+// the server writes can be created.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+)
+
+// Check statuses.
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+const (
+	// maxClockSkewWarn is the clock difference from Apple's servers above
+	// which a warning is reported. Tokens are valid from their issue time.
+	maxClockSkewWarn = 30 * time.Second
+
+	// maxClockSkewFail is the clock difference at which tokens are likely to
+	// be rejected as not yet valid or expired.
+	maxClockSkewFail = 5 * time.Minute
+)
+
+// Check is the outcome of one check.
+type Check struct {
+	Name    string `json:"name"`
+	Profile string `json:"profile,omitempty"`
+	Status  string `json:"status"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// Report is the outcome of all checks, in the order they ran.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Failed reports whether any check failed.
+func (r Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// domainProbe is a request showing whether a key may use a part of the API.
+type domainProbe struct {
+	name  string
+	path  string
+	query url.Values
+
+	// expectError is set for probes that fail even when permitted, such as
+	// a report for a vendor that doesn't exist. Only a 401 or 403 counts.
+	expectError bool
+}
+
+// domainProbes are the parts of the API checked for each profile.
+var domainProbes = []domainProbe{
+	{name: "apps", path: "/v1/apps", query: url.Values{"limit": {"1"}}},
+	{name: "builds", path: "/v1/builds", query: url.Values{"limit": {"1"}}},
+	{name: "testflight", path: "/v1/betaGroups", query: url.Values{"limit": {"1"}}},
+	{name: "provisioning", path: "/v1/bundleIds", query: url.Values{"limit": {"1"}}},
+	{name: "users", path: "/v1/users", query: url.Values{"limit": {"1"}}},
+	{name: "xcode cloud", path: "/v1/ciProducts", query: url.Values{"limit": {"1"}}},
+	{name: "sales reports", path: "/v1/salesReports", query: url.Values{
+		"filter[vendorNumber]":  {"0"},
+		"filter[reportType]":    {"SALES"},
+		"filter[reportSubType]": {"SUMMARY"},
+		"filter[frequency]":     {"DAILY"},
+	}, expectError: true},
+}
+
+// Run runs every check for the default profile and each additional profile.
+func Run(ctx context.Context, cfg *config.Config) Report {
+	var report Report
+
+	transportOpts, err := api.TransportOptions(cfg.ProxyURL, cfg.CAFile)
+	if err != nil {
+		report.Checks = append(report.Checks, Check{Name: "proxy and CA file", Status: StatusFail, Detail: err.Error()})
+	}
+
+	for _, profile := range cfg.AllProfiles() {
+		if profile.BaseURL == "" {
+			profile.BaseURL = cfg.BaseURL
+		}
+		report.Checks = append(report.Checks, checkProfile(ctx, profile, transportOpts)...)
+	}
+
+	report.Checks = append(report.Checks,
+		checkWritable("snapshot file", cfg.SnapshotPath),
+		checkWritable("audit log", cfg.AuditLogPath),
+	)
+	return report
+}
+
+// checkProfile checks a profile's key, token, clock and API access, reaching
+// the API with transportOpts.
+func checkProfile(ctx context.Context, profile config.Profile, transportOpts []api.ClientOption) []Check {
+	check := func(name, status, detail string) Check {
+		return Check{Name: name, Profile: profile.Name, Status: status, Detail: detail}
+	}
+
+	keyData, err := profile.KeyData()
+	if err != nil {
+		return []Check{check("private key", StatusFail, err.Error())}
+	}
+	tokens, err := api.NewTokenProviderFromKey(profile.IssuerID, profile.KeyID, keyData)
+	if err != nil {
+		return []Check{check("private key", StatusFail, err.Error())}
+	}
+	checks := []Check{check("private key", StatusOK, fmt.Sprintf("key %s of issuer %s", profile.KeyID, profile.IssuerID))}
+
+	if _, err := tokens.GetToken(); err != nil {
+		return append(checks, check("token", StatusFail, err.Error()))
+	}
+	checks = append(checks, check("token", StatusOK, "signed an ES256 token"))
+
+	client := api.NewClientWithTokenProvider(tokens, append(transportOpts, api.WithBaseURL(profile.BaseURL))...)
+
+	var serverDate time.Time
+	var sent time.Time
+	observed := api.WithResponseObserver(ctx, func(resp api.Response) {
+		if serverDate.IsZero() && !resp.Date.IsZero() {
+			serverDate = resp.Date
+			sent = time.Now().Add(-resp.Duration / 2)
+		}
+	})
+
+	unauthorized := false
+	for _, probe := range domainProbes {
+		_, err := client.Get(observed, probe.path, probe.query)
+		status, detail := probeStatus(probe, err)
+		if api.IsUnauthorized(err) {
+			unauthorized = true
+		}
+		checks = append(checks, check("access: "+probe.name, status, detail))
+		if unauthorized || ctx.Err() != nil {
+			// Every other request fails the same way.
+			break
+		}
+	}
+
+	return append(checks, checkClock(profile.Name, serverDate, sent))
+}
+
+// probeStatus interprets the outcome of a domain probe.
+func probeStatus(probe domainProbe, err error) (string, string) {
+	switch {
+	case err == nil:
+		return StatusOK, "permitted"
+	case api.IsUnauthorized(err):
+		return StatusFail, err.Error()
+	case api.IsForbidden(err):
+		return StatusWarn, "the key's role doesn't allow it; its tools will fail"
+	case probe.expectError && api.StatusCode(err) != 0:
+		return StatusOK, "permitted"
+	default:
+		return StatusFail, err.Error()
+	}
+}
+
+// checkClock compares the local clock with Apple's Date header.
+func checkClock(profile string, serverDate, sent time.Time) Check {
+	check := Check{Name: "clock", Profile: profile}
+	if serverDate.IsZero() {
+		check.Status = StatusSkip
+		check.Detail = "no response with a Date header"
+		return check
+	}
+
+	// The Date header has whole seconds, so skew under a second isn't real.
+	skew := sent.Sub(serverDate).Round(time.Second)
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= maxClockSkewFail:
+		check.Status = StatusFail
+	case abs > maxClockSkewWarn:
+		check.Status = StatusWarn
+	default:
+		check.Status = StatusOK
+	}
+	switch {
+	case skew > 0:
+		check.Detail = fmt.Sprintf("local clock is %s ahead of Apple's", skew)
+	case skew < 0:
+		check.Detail = fmt.Sprintf("local clock is %s behind Apple's", -skew)
+	default:
+		check.Detail = "local clock matches Apple's"
+	}
+	return check
+}
+
+// checkWritable checks that a file the server appends to or replaces can be
+// written, creating its directory as the server would.
+func checkWritable(name, path string) Check {
+	check := Check{Name: name}
+	if path == "" {
+		check.Status = StatusSkip
+		check.Detail = "not configured"
+		return check
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		check.Status = StatusFail
+		check.Detail = err.Error()
+		return check
+	}
+	f, err := os.CreateTemp(dir, ".asc-mcp-doctor-*")
+	if err != nil {
+		check.Status = StatusFail
+		check.Detail = err.Error()
+		return check
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	check.Status = StatusOK
+	check.Detail = path
+	return check
+}
@@ -0,0 +1,126 @@
+package doctor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+}
+
+// statuses maps each check's profile and name to its status.
+func statuses(report Report) map[string]string {
+	got := make(map[string]string)
+	for _, c := range report.Checks {
+		got[c.Profile+"/"+c.Name] = c.Status
+	}
+	return got
+}
+
+func TestRun(t *testing.T) {
+	// The key may do everything but manage users; its clock is 2 minutes fast.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-2*time.Minute).UTC().Format(http.TimeFormat))
+		switch r.URL.Path {
+		case "/v1/users":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": [{"status": "403", "code": "FORBIDDEN_ERROR", "title": "The API key in use does not allow this request"}]}`))
+		case "/v1/salesReports":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "The vendor number is invalid"}]}`))
+		default:
+			w.Write([]byte(`{"data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	// The second team's key is revoked.
+	revoked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors": [{"status": "401", "code": "NOT_AUTHORIZED", "title": "Authentication credentials are missing or invalid."}]}`))
+	}))
+	defer revoked.Close()
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		IssuerID:     "issuer",
+		KeyID:        "KEY1",
+		PrivateKey:   testKey(t),
+		BaseURL:      server.URL,
+		SnapshotPath: filepath.Join(dir, "state", "snapshots.json"),
+		Profiles: []config.Profile{
+			{Name: "acme", IssuerID: "issuer2", KeyID: "KEY2", PrivateKey: testKey(t), BaseURL: revoked.URL},
+			{Name: "broken", IssuerID: "issuer3", KeyID: "KEY3", PrivateKey: []byte("not a key")},
+		},
+	}
+
+	report := Run(context.Background(), cfg)
+	got := statuses(report)
+	want := map[string]string{
+		"default/private key":           StatusOK,
+		"default/token":                 StatusOK,
+		"default/access: apps":          StatusOK,
+		"default/access: users":         StatusWarn,
+		"default/access: sales reports": StatusOK,
+		"default/clock":                 StatusWarn,
+		"acme/token":                    StatusOK,
+		"acme/access: apps":             StatusFail,
+		"broken/private key":            StatusFail,
+		"/snapshot file":                StatusOK,
+		"/audit log":                    StatusSkip,
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %q, want %q", key, got[key], status)
+		}
+	}
+	if _, ok := got["acme/access: builds"]; ok {
+		t.Error("checks continued after the key was refused")
+	}
+	if !report.Failed() {
+		t.Error("Failed() = false, want true")
+	}
+}
+
+func TestCheckClock(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		offset time.Duration
+		status string
+	}{
+		{0, StatusOK},
+		{20 * time.Second, StatusOK},
+		{-time.Minute, StatusWarn},
+		{10 * time.Minute, StatusFail},
+	}
+	for _, tt := range tests {
+		if got := checkClock("default", now.Add(-tt.offset), now); got.Status != tt.status {
+			t.Errorf("offset %s: status = %s (%s), want %s", tt.offset, got.Status, got.Detail, tt.status)
+		}
+	}
+	if got := checkClock("default", time.Time{}, now); got.Status != StatusSkip {
+		t.Errorf("no date: status = %s, want %s", got.Status, StatusSkip)
+	}
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaFor derives an object JSON Schema from a struct value, following its
+// json tags. Fields without omitempty are listed as required unless they are
+// pointers, slices, maps, or interfaces, which may encode as null.
+// It is used to declare tool output schemas from the Go types a tool returns.
+func SchemaFor(v any) *JSONSchema {
+	prop := propertyFor(reflect.TypeOf(v))
+	return &JSONSchema{
+		Type:       "object",
+		Properties: prop.Properties,
+		Required:   prop.Required,
+	}
+}
+
+// propertyFor derives the schema property for a Go type.
+func propertyFor(t reflect.Type) Property {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return Property{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Property{Type: "string"}
+	case reflect.Bool:
+		return Property{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Property{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Property{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		items := propertyFor(t.Elem())
+		return Property{Type: "array", Items: &items}
+	case reflect.Map:
+		return Property{Type: "object"}
+	case reflect.Struct:
+		return structProperty(t)
+	default:
+		return Property{}
+	}
+}
+
+// structProperty derives an object property from the exported fields of a struct.
+func structProperty(t reflect.Type) Property {
+	prop := Property{Type: "object", Properties: make(map[string]Property)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop.Properties[name] = propertyFor(field.Type)
+		if !strings.Contains(opts, "omitempty") && !nullable(field.Type) {
+			prop.Required = append(prop.Required, name)
+		}
+	}
+
+	return prop
+}
+
+// nullable reports whether values of a type may encode as JSON null.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
@@ -18,6 +18,9 @@ const (
 	ErrCodeMethodNotFound = -32601
 	ErrCodeInvalidParams  = -32602
 	ErrCodeInternal       = -32603
+
+	// ErrCodeResourceNotFound is the MCP error code for unknown resource URIs.
+	ErrCodeResourceNotFound = -32002
 )
 
 // Request represents a JSON-RPC 2.0 request.
@@ -36,6 +39,13 @@ type Response struct {
 	Error   *RPCError       `json:"error,omitempty"`
 }
 
+// Notification represents a JSON-RPC 2.0 notification sent by the server.
+type Notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
 // RPCError represents a JSON-RPC 2.0 error.
 type RPCError struct {
 	Code    int    `json:"code"`
@@ -50,10 +60,28 @@ type InitializeParams struct {
 	ClientInfo      ClientInfo       `json:"clientInfo"`
 }
 
+// ClientResponse represents a JSON-RPC 2.0 response from the client to a
+// request sent by the server.
+type ClientResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// ServerRequest represents a JSON-RPC 2.0 request sent by the server to the client.
+type ServerRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  any             `json:"params,omitempty"`
+}
+
 // ClientCapability represents client capabilities.
 type ClientCapability struct {
-	Roots    *RootsCapability    `json:"roots,omitempty"`
-	Sampling *SamplingCapability `json:"sampling,omitempty"`
+	Roots       *RootsCapability       `json:"roots,omitempty"`
+	Sampling    *SamplingCapability    `json:"sampling,omitempty"`
+	Elicitation *ElicitationCapability `json:"elicitation,omitempty"`
 }
 
 // RootsCapability represents roots capability.
@@ -64,6 +92,31 @@ type RootsCapability struct {
 // SamplingCapability represents sampling capability.
 type SamplingCapability struct{}
 
+// ElicitationCapability represents the client's ability to ask the user for
+// input on the server's behalf.
+type ElicitationCapability struct{}
+
+// Elicitation actions reported by the client.
+const (
+	ElicitActionAccept  = "accept"
+	ElicitActionDecline = "decline"
+	ElicitActionCancel  = "cancel"
+)
+
+// ElicitParams represents parameters for elicitation/create. The requested
+// schema may only hold properties of primitive types.
+type ElicitParams struct {
+	Message         string     `json:"message"`
+	RequestedSchema JSONSchema `json:"requestedSchema"`
+}
+
+// ElicitResult represents the result of elicitation/create. Content holds
+// the user's values when Action is ElicitActionAccept.
+type ElicitResult struct {
+	Action  string         `json:"action"`
+	Content map[string]any `json:"content,omitempty"`
+}
+
 // ClientInfo represents information about the client.
 type ClientInfo struct {
 	Name    string `json:"name"`
@@ -75,11 +128,16 @@ type InitializeResult struct {
 	ProtocolVersion string           `json:"protocolVersion"`
 	Capabilities    ServerCapability `json:"capabilities"`
 	ServerInfo      ServerInfo       `json:"serverInfo"`
+	Instructions    string           `json:"instructions,omitempty"`
 }
 
 // ServerCapability represents server capabilities.
 type ServerCapability struct {
-	Tools *ToolsCapability `json:"tools,omitempty"`
+	Tools       *ToolsCapability       `json:"tools,omitempty"`
+	Resources   *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts     *PromptsCapability     `json:"prompts,omitempty"`
+	Logging     *LoggingCapability     `json:"logging,omitempty"`
+	Completions *CompletionsCapability `json:"completions,omitempty"`
 }
 
 // ToolsCapability represents tools capability.
@@ -87,6 +145,48 @@ type ToolsCapability struct {
 	ListChanged bool `json:"listChanged,omitempty"`
 }
 
+// ResourcesCapability represents resources capability.
+type ResourcesCapability struct {
+	Subscribe   bool `json:"subscribe,omitempty"`
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// PromptsCapability represents prompts capability.
+type PromptsCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// LoggingCapability represents logging capability.
+type LoggingCapability struct{}
+
+// CompletionsCapability represents argument completion capability.
+type CompletionsCapability struct{}
+
+// LogLevels lists the log levels, from least to most severe.
+var LogLevels = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// LogLevelSeverity returns the rank of a log level in LogLevels, or -1 if it is unknown.
+func LogLevelSeverity(level string) int {
+	for i, l := range LogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetLevelParams represents parameters for logging/setLevel.
+type SetLevelParams struct {
+	Level string `json:"level"`
+}
+
+// LogMessageParams represents parameters for notifications/message.
+type LogMessageParams struct {
+	Level  string `json:"level"`
+	Logger string `json:"logger,omitempty"`
+	Data   any    `json:"data"`
+}
+
 // ServerInfo represents information about the server.
 type ServerInfo struct {
 	Name    string `json:"name"`
@@ -100,12 +200,25 @@ type ToolsListResult struct {
 
 // Tool represents an MCP tool definition.
 type Tool struct {
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	InputSchema JSONSchema `json:"inputSchema"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	InputSchema  JSONSchema       `json:"inputSchema"`
+	OutputSchema *JSONSchema      `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations `json:"annotations,omitempty"`
 }
 
-// JSONSchema represents a JSON Schema for tool input.
+// ToolAnnotations describes a tool's behavior, so clients can decide which
+// calls need the user's confirmation. The hints are advisory. DestructiveHint
+// and IdempotentHint only apply to tools that aren't read-only.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
+// JSONSchema represents a JSON Schema for tool input or output.
 type JSONSchema struct {
 	Type       string              `json:"type"`
 	Properties map[string]Property `json:"properties,omitempty"`
@@ -113,23 +226,83 @@ type JSONSchema struct {
 }
 
 // Property represents a JSON Schema property.
+// An empty Type accepts any JSON value.
 type Property struct {
-	Type        string   `json:"type"`
-	Description string   `json:"description,omitempty"`
-	Enum        []string `json:"enum,omitempty"`
-	Default     any      `json:"default,omitempty"`
+	Type        string              `json:"type,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	Enum        []string            `json:"enum,omitempty"`
+	EnumNames   []string            `json:"enumNames,omitempty"`
+	Default     any                 `json:"default,omitempty"`
+	Items       *Property           `json:"items,omitempty"`
+	Properties  map[string]Property `json:"properties,omitempty"`
+	Required    []string            `json:"required,omitempty"`
 }
 
 // ToolsCallParams represents parameters for tools/call.
 type ToolsCallParams struct {
 	Name      string          `json:"name"`
 	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Meta      *RequestMeta    `json:"_meta,omitempty"`
+}
+
+// RequestMeta represents the optional _meta object sent with a request.
+type RequestMeta struct {
+	ProgressToken json.RawMessage `json:"progressToken,omitempty"`
+
+	// TraceParent and TraceState carry the client's W3C trace context, so
+	// the call's spans join the client's trace.
+	TraceParent string `json:"traceparent,omitempty"`
+	TraceState  string `json:"tracestate,omitempty"`
+}
+
+// ProgressParams represents parameters for notifications/progress.
+type ProgressParams struct {
+	ProgressToken json.RawMessage `json:"progressToken"`
+	Progress      float64         `json:"progress"`
+	Total         float64         `json:"total,omitempty"`
+	Message       string          `json:"message,omitempty"`
+}
+
+// CancelledParams represents parameters for notifications/cancelled.
+type CancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+	Reason    string          `json:"reason,omitempty"`
 }
 
 // ToolsCallResult represents the result of tools/call.
+// StructuredContent, when set, is a JSON object that conforms to the tool's OutputSchema.
+// Err, when set, is the error a failed call ran into; it isn't sent to clients.
 type ToolsCallResult struct {
-	Content []ContentBlock `json:"content"`
-	IsError bool           `json:"isError,omitempty"`
+	Content           []ContentBlock `json:"content"`
+	StructuredContent any            `json:"structuredContent,omitempty"`
+	IsError           bool           `json:"isError,omitempty"`
+	Meta              map[string]any `json:"_meta,omitempty"`
+	Err               error          `json:"-"`
+}
+
+// MetaRateLimit is the _meta key under which tool results report the API
+// key's remaining hourly request budget.
+const MetaRateLimit = "rateLimit"
+
+// MetaError is the _meta key under which failed tool results classify the
+// failure, so clients can tell what to retry and what to show the user.
+const MetaError = "error"
+
+// ToolError classifies why a tool call failed.
+type ToolError struct {
+	// Class is the kind of failure, such as "auth" or "transient".
+	Class string `json:"class"`
+
+	// Status is the HTTP status of the failed API request, if any.
+	Status int `json:"status,omitempty"`
+
+	// RequestID is the ID the API gave the failed request, if any, to quote
+	// when reporting the failure to Apple.
+	RequestID string `json:"requestId,omitempty"`
+
+	// Retryable is set if the same call may succeed when retried later.
+	Retryable bool `json:"retryable"`
 }
 
 // ContentBlock represents a content block in tool results.
@@ -153,6 +326,15 @@ func NewSuccessResult(text string) *ToolsCallResult {
 	}
 }
 
+// NewStructuredResult creates a successful tool result carrying both a
+// human-readable summary and structured content for the tool's output schema.
+func NewStructuredResult(text string, structured any) *ToolsCallResult {
+	return &ToolsCallResult{
+		Content:           []ContentBlock{NewTextContent(text)},
+		StructuredContent: structured,
+	}
+}
+
 // NewErrorResult creates an error tool result.
 func NewErrorResult(text string) *ToolsCallResult {
 	return &ToolsCallResult{
@@ -160,3 +342,162 @@ func NewErrorResult(text string) *ToolsCallResult {
 		IsError: true,
 	}
 }
+
+// NewErrorResultWithError creates an error tool result for a call that
+// failed with err, so the failure can be classified without parsing text.
+func NewErrorResultWithError(text string, err error) *ToolsCallResult {
+	return &ToolsCallResult{
+		Content: []ContentBlock{NewTextContent(text)},
+		IsError: true,
+		Err:     err,
+	}
+}
+
+// Resource represents an MCP resource definition.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceTemplate represents a parameterized MCP resource definition.
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourcesListResult represents the result of resources/list.
+type ResourcesListResult struct {
+	Resources []Resource `json:"resources"`
+}
+
+// ResourceTemplatesListResult represents the result of resources/templates/list.
+type ResourceTemplatesListResult struct {
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+}
+
+// ResourcesReadParams represents parameters for resources/read.
+type ResourcesReadParams struct {
+	URI string `json:"uri"`
+}
+
+// ResourcesReadResult represents the result of resources/read.
+type ResourcesReadResult struct {
+	Contents []ResourceContents `json:"contents"`
+}
+
+// ResourceContents represents the contents of a resource.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// ResourcesSubscribeParams represents parameters for resources/subscribe and resources/unsubscribe.
+type ResourcesSubscribeParams struct {
+	URI string `json:"uri"`
+}
+
+// ResourceUpdatedParams represents parameters for notifications/resources/updated.
+type ResourceUpdatedParams struct {
+	URI string `json:"uri"`
+}
+
+// Prompt represents an MCP prompt definition.
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument represents an argument accepted by a prompt.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// PromptsListResult represents the result of prompts/list.
+type PromptsListResult struct {
+	Prompts []Prompt `json:"prompts"`
+}
+
+// PromptsGetParams represents parameters for prompts/get.
+type PromptsGetParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// PromptsGetResult represents the result of prompts/get.
+type PromptsGetResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// PromptMessage represents a message returned as part of a prompt.
+type PromptMessage struct {
+	Role    string       `json:"role"`
+	Content ContentBlock `json:"content"`
+}
+
+// NewUserPrompt creates a prompt result with a single user text message.
+func NewUserPrompt(description, text string) *PromptsGetResult {
+	return &PromptsGetResult{
+		Description: description,
+		Messages: []PromptMessage{
+			{Role: "user", Content: NewTextContent(text)},
+		},
+	}
+}
+
+// Reference types accepted by completion/complete.
+const (
+	RefPrompt   = "ref/prompt"
+	RefResource = "ref/resource"
+	RefTool     = "ref/tool"
+)
+
+// MaxCompletionValues is the most values a completion result may hold.
+const MaxCompletionValues = 100
+
+// CompleteParams represents parameters for completion/complete.
+type CompleteParams struct {
+	Ref      CompleteReference `json:"ref"`
+	Argument CompleteArgument  `json:"argument"`
+	Context  *CompleteContext  `json:"context,omitempty"`
+}
+
+// CompleteReference identifies the prompt, resource template, or tool whose
+// argument is being completed. Prompts and tools set Name; resources set URI.
+type CompleteReference struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// CompleteArgument is the argument being completed and its partial value.
+type CompleteArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CompleteContext carries arguments the client has already resolved.
+type CompleteContext struct {
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// CompleteResult represents the result of completion/complete.
+type CompleteResult struct {
+	Completion Completion `json:"completion"`
+}
+
+// Completion lists suggested values for an argument.
+type Completion struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total,omitempty"`
+	HasMore bool     `json:"hasMore,omitempty"`
+}
@@ -3,6 +3,7 @@ package mcp
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestConstants(t *testing.T) {
@@ -380,6 +381,71 @@ func TestNewSuccessResult(t *testing.T) {
 	}
 }
 
+func TestNewStructuredResult(t *testing.T) {
+	result := NewStructuredResult("Build 42 is VALID", map[string]string{"processingState": "VALID"})
+
+	if result.IsError {
+		t.Error("IsError should be false")
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var decoded map[string]any
+	json.Unmarshal(data, &decoded)
+	structured, ok := decoded["structuredContent"].(map[string]any)
+	if !ok {
+		t.Fatalf("structuredContent missing from %s", data)
+	}
+	if structured["processingState"] != "VALID" {
+		t.Errorf("structuredContent.processingState = %v, want VALID", structured["processingState"])
+	}
+}
+
+func TestSchemaFor(t *testing.T) {
+	type attributes struct {
+		State   string     `json:"state,omitempty"`
+		Count   int        `json:"count"`
+		Created *time.Time `json:"created,omitempty"`
+	}
+	type resource struct {
+		ID         string     `json:"id"`
+		Attributes attributes `json:"attributes"`
+		Tags       []string   `json:"tags"`
+		internal   string
+	}
+
+	schema := SchemaFor(resource{})
+
+	if schema.Type != "object" {
+		t.Errorf("Type = %q, want object", schema.Type)
+	}
+	if len(schema.Properties) != 3 {
+		t.Errorf("expected 3 properties, got %d", len(schema.Properties))
+	}
+	if got := schema.Properties["tags"]; got.Type != "array" || got.Items == nil || got.Items.Type != "string" {
+		t.Errorf("tags = %+v, want array of string", got)
+	}
+
+	attrs := schema.Properties["attributes"]
+	if attrs.Properties["count"].Type != "integer" {
+		t.Errorf("attributes.count type = %q, want integer", attrs.Properties["count"].Type)
+	}
+	if created := attrs.Properties["created"]; created.Type != "string" || created.Format != "date-time" {
+		t.Errorf("attributes.created = %+v, want date-time string", created)
+	}
+	if len(attrs.Required) != 1 || attrs.Required[0] != "count" {
+		t.Errorf("attributes.Required = %v, want [count]", attrs.Required)
+	}
+
+	// Slices may encode as null, so only id and attributes are required.
+	if len(schema.Required) != 2 || schema.Required[0] != "id" || schema.Required[1] != "attributes" {
+		t.Errorf("Required = %v, want [id attributes]", schema.Required)
+	}
+}
+
 func TestNewErrorResult(t *testing.T) {
 	result := NewErrorResult("Something went wrong")
 
@@ -497,3 +563,15 @@ func BenchmarkNewSuccessResult(b *testing.B) {
 		_ = NewSuccessResult(text)
 	}
 }
+
+func TestLogLevelSeverity(t *testing.T) {
+	if LogLevelSeverity("debug") >= LogLevelSeverity("warning") {
+		t.Error("debug should be less severe than warning")
+	}
+	if LogLevelSeverity("emergency") != len(LogLevels)-1 {
+		t.Error("emergency should be the most severe level")
+	}
+	if LogLevelSeverity("verbose") != -1 {
+		t.Error("unknown levels should return -1")
+	}
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metric types, as written in # TYPE lines.
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// family is a metric and its series, one per combination of label values.
+type family struct {
+	name    string
+	help    string
+	kind    string
+	buckets []float64
+	labels  []string
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// series is the value of a metric for one combination of label values.
+// Histograms count observations in counts, one per bucket, plus sum.
+type series struct {
+	values []string
+	value  float64
+	counts []uint64
+	count  uint64
+}
+
+// newFamily returns a metric with no series yet. buckets are the upper
+// bounds of a histogram's buckets, in increasing order.
+func newFamily(name, help, kind string, buckets []float64, labels ...string) *family {
+	return &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		buckets: buckets,
+		labels:  labels,
+		series:  make(map[string]*series),
+	}
+}
+
+// get returns the series of label values, creating it if needed. f.mu must be held.
+func (f *family) get(values []string) *series {
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: values}
+		if f.kind == histogramType {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// add adds delta to a counter.
+func (f *family) add(delta float64, values ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.get(values).value += delta
+}
+
+// set sets a gauge.
+func (f *family) set(v float64, values ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.get(values).value = v
+}
+
+// observe adds an observation to a histogram.
+func (f *family) observe(v float64, values ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.get(values)
+	for i, bound := range f.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.value += v
+}
+
+// write writes the metric in the text exposition format, with its series
+// sorted by label values. A metric without series is left out.
+func (f *family) write(sb *strings.Builder) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.series) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	for _, key := range keys {
+		s := f.series[key]
+		labels := labelPairs(f.labels, s.values)
+		if f.kind != histogramType {
+			fmt.Fprintf(sb, "%s{%s} %s\n", f.name, labels, formatFloat(s.value))
+			continue
+		}
+		for i, bound := range f.buckets {
+			fmt.Fprintf(sb, "%s_bucket{%s,le=\"%s\"} %d\n", f.name, labels, formatFloat(bound), s.counts[i])
+		}
+		fmt.Fprintf(sb, "%s_bucket{%s,le=\"%s\"} %d\n", f.name, labels, "+Inf", s.count)
+		fmt.Fprintf(sb, "%s_sum{%s} %s\n", f.name, labels, formatFloat(s.value))
+		fmt.Fprintf(sb, "%s_count{%s} %d\n", f.name, labels, s.count)
+	}
+}
@@ -0,0 +1,166 @@
+// Package metrics counts API requests and tool calls and exposes them in the
+// Prometheus text format, without a Prometheus client dependency.
+package metrics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// Tool call outcomes, the outcome label of asc_tool_calls_total.
+const (
+	OutcomeOK        = "ok"
+	OutcomeError     = "error"
+	OutcomeCancelled = "cancelled"
+)
+
+// apiBuckets are the upper bounds, in seconds, of API request durations.
+var apiBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// toolBuckets are the upper bounds, in seconds, of tool call durations, which
+// include waits of up to half an hour.
+var toolBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 900, 1800}
+
+// Metrics holds the server's metrics. Its methods are safe for concurrent use.
+type Metrics struct {
+	apiRequests        *family
+	apiErrors          *family
+	apiDuration        *family
+	rateLimitRemaining *family
+	rateLimitLimit     *family
+	toolCalls          *family
+	toolDuration       *family
+}
+
+// New returns metrics with nothing counted yet.
+func New() *Metrics {
+	return &Metrics{
+		apiRequests: newFamily("asc_api_requests_total", "App Store Connect API requests sent, retries included, by response status.",
+			counterType, nil, "method", "endpoint", "status"),
+		apiErrors: newFamily("asc_api_errors_total", "App Store Connect API requests that failed with a 4xx or 5xx status or a network error.",
+			counterType, nil, "method", "endpoint"),
+		apiDuration: newFamily("asc_api_request_duration_seconds", "Time to receive an App Store Connect API response's headers.",
+			histogramType, apiBuckets, "method", "endpoint"),
+		rateLimitRemaining: newFamily("asc_api_rate_limit_remaining", "Requests left in the hourly quota, as last reported for the API key.",
+			gaugeType, nil, "key_id"),
+		rateLimitLimit: newFamily("asc_api_rate_limit_limit", "The hourly request quota, as last reported for the API key.",
+			gaugeType, nil, "key_id"),
+		toolCalls: newFamily("asc_tool_calls_total", "Tool calls completed, by outcome: ok, error or cancelled.",
+			counterType, nil, "tool", "outcome"),
+		toolDuration: newFamily("asc_tool_call_duration_seconds", "Duration of tool calls.",
+			histogramType, toolBuckets, "tool"),
+	}
+}
+
+// Middleware returns an API client middleware that counts and times each
+// request attempt and records the rate limit responses report.
+func (m *Metrics) Middleware() api.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			m.observeRequest(req, resp, err, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// observeRequest records an API request attempt.
+func (m *Metrics) observeRequest(req *http.Request, resp *http.Response, err error, d time.Duration) {
+	endpoint := api.EndpointTemplate(req.URL.Path)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	m.apiRequests.add(1, req.Method, endpoint, status)
+	m.apiDuration.observe(d.Seconds(), req.Method, endpoint)
+	if err != nil || resp.StatusCode >= 400 {
+		m.apiErrors.add(1, req.Method, endpoint)
+	}
+	if err != nil {
+		return
+	}
+
+	rateLimit := api.ParseRateLimit(resp.Header.Get("X-Rate-Limit"))
+	if rateLimit == nil {
+		return
+	}
+	keyID := tokenKeyID(req.Header.Get("Authorization"))
+	m.rateLimitRemaining.set(float64(rateLimit.Remaining), keyID)
+	m.rateLimitLimit.set(float64(rateLimit.Limit), keyID)
+}
+
+// ObserveToolCall records a completed tool call.
+func (m *Metrics) ObserveToolCall(tool, outcome string, d time.Duration) {
+	m.toolCalls.add(1, tool, outcome)
+	m.toolDuration.observe(d.Seconds(), tool)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	for _, f := range []*family{m.apiRequests, m.apiErrors, m.apiDuration, m.rateLimitRemaining, m.rateLimitLimit, m.toolCalls, m.toolDuration} {
+		f.write(&sb)
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics to a Prometheus scrape.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// tokenKeyID returns the key ID in the header of the bearer token in an
+// Authorization header, or "" if it has none.
+func tokenKeyID(authorization string) string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return ""
+	}
+	header, _, _ := strings.Cut(token, ".")
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		KeyID string `json:"kid"`
+	}
+	if json.Unmarshal(data, &fields) != nil {
+		return ""
+	}
+	return fields.KeyID
+}
+
+// formatFloat formats a sample value or bucket bound.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeLabel escapes a label value for the text format.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// labelPairs formats label names and values as name="value" pairs.
+func labelPairs(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, escapeLabel(values[i]))
+	}
+	return strings.Join(pairs, ",")
+}
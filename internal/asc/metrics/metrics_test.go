@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3598;")
+		if r.URL.Path == "/v1/apps/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "code": "NOT_FOUND", "title": "Not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"type": "apps", "id": "1", "attributes": {"name": "Example"}}}`))
+	}))
+	defer server.Close()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	tokens, err := api.NewTokenProviderFromKey("test-issuer", "TESTKEY123", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}))
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+
+	m := New()
+	client := api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL), api.WithMiddleware(m.Middleware()))
+	ctx := context.Background()
+	if _, err := client.GetApp(ctx, "1"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if _, err := client.GetApp(ctx, "2"); err != nil {
+		t.Fatalf("GetApp failed: %v", err)
+	}
+	if _, err := client.GetApp(ctx, "missing"); err == nil {
+		t.Fatal("expected GetApp(missing) to fail")
+	}
+
+	var sb strings.Builder
+	m.WriteTo(&sb)
+	text := sb.String()
+	for _, want := range []string{
+		"# TYPE asc_api_requests_total counter\n",
+		`asc_api_requests_total{method="GET",endpoint="/v1/apps/{id}",status="200"} 2` + "\n",
+		`asc_api_requests_total{method="GET",endpoint="/v1/apps/{id}",status="404"} 1` + "\n",
+		`asc_api_errors_total{method="GET",endpoint="/v1/apps/{id}"} 1` + "\n",
+		`asc_api_request_duration_seconds_count{method="GET",endpoint="/v1/apps/{id}"} 3` + "\n",
+		`asc_api_request_duration_seconds_bucket{method="GET",endpoint="/v1/apps/{id}",le="+Inf"} 3` + "\n",
+		`asc_api_rate_limit_remaining{key_id="TESTKEY123"} 3598` + "\n",
+		`asc_api_rate_limit_limit{key_id="TESTKEY123"} 3600` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "asc_tool_calls_total") {
+		t.Errorf("metrics without tool calls list asc_tool_calls_total:\n%s", text)
+	}
+}
+
+func TestMetrics_ObserveToolCall(t *testing.T) {
+	m := New()
+	m.ObserveToolCall("list_apps", OutcomeOK, 300*time.Millisecond)
+	m.ObserveToolCall("list_apps", OutcomeError, 2*time.Second)
+
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	text := recorder.Body.String()
+	for _, want := range []string{
+		`asc_tool_calls_total{tool="list_apps",outcome="error"} 1` + "\n",
+		`asc_tool_calls_total{tool="list_apps",outcome="ok"} 1` + "\n",
+		`asc_tool_call_duration_seconds_bucket{tool="list_apps",le="0.25"} 0` + "\n",
+		`asc_tool_call_duration_seconds_bucket{tool="list_apps",le="0.5"} 1` + "\n",
+		`asc_tool_call_duration_seconds_bucket{tool="list_apps",le="2.5"} 2` + "\n",
+		`asc_tool_call_duration_seconds_sum{tool="list_apps"} 2.3` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got, want := escapeLabel("a\"b\\c\nd"), `a\"b\\c\nd`; got != want {
+		t.Errorf("escapeLabel() = %s, want %s", got, want)
+	}
+}
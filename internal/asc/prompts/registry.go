@@ -0,0 +1,89 @@
+// Package prompts provides MCP prompt implementations for App Store Connect.
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+var (
+	// ErrNotFound is returned when a prompt name is not registered.
+	ErrNotFound = errors.New("prompt not found")
+
+	// ErrInvalidArgument is returned when a required prompt argument is missing.
+	ErrInvalidArgument = errors.New("invalid prompt argument")
+)
+
+// PromptHandler assembles a prompt from its arguments.
+type PromptHandler func(ctx context.Context, args map[string]string) (*mcp.PromptsGetResult, error)
+
+// Registry manages prompt definitions and handlers.
+type Registry struct {
+	client   *api.Client
+	prompts  []mcp.Prompt
+	handlers map[string]PromptHandler
+	toolName func(string) string
+}
+
+// NewRegistry creates a new prompt registry.
+func NewRegistry(client *api.Client) *Registry {
+	r := &Registry{
+		client:   client,
+		prompts:  make([]mcp.Prompt, 0),
+		handlers: make(map[string]PromptHandler),
+	}
+
+	r.registerReleasePrompts()
+
+	return r
+}
+
+// SetToolNaming sets how prompts render tool names, so they match the names
+// tools are listed and called by. By default names are used unchanged.
+func (r *Registry) SetToolNaming(toolName func(string) string) {
+	r.toolName = toolName
+}
+
+// tool returns the name prompts refer to the tool registered as name by.
+func (r *Registry) tool(name string) string {
+	if r.toolName == nil {
+		return name
+	}
+	return r.toolName(name)
+}
+
+// ListPrompts returns all registered prompt definitions.
+func (r *Registry) ListPrompts() []mcp.Prompt {
+	return r.prompts
+}
+
+// GetPrompt validates the arguments and assembles a prompt by name.
+func (r *Registry) GetPrompt(ctx context.Context, name string, args map[string]string) (*mcp.PromptsGetResult, error) {
+	handler, ok := r.handlers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	for _, prompt := range r.prompts {
+		if prompt.Name != name {
+			continue
+		}
+		for _, arg := range prompt.Arguments {
+			if arg.Required && args[arg.Name] == "" {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidArgument, arg.Name)
+			}
+		}
+	}
+
+	return handler(ctx, args)
+}
+
+// register adds a prompt to the registry.
+func (r *Registry) register(prompt mcp.Prompt, handler PromptHandler) {
+	r.prompts = append(r.prompts, prompt)
+	r.handlers[prompt.Name] = handler
+}
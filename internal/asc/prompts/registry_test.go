@@ -0,0 +1,86 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+func TestRegistry_ListPrompts(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+
+	expected := map[string]bool{
+		"prepare_app_store_submission": false,
+		"triage_customer_reviews":      false,
+		"setup_testflight_group":       false,
+	}
+
+	for _, prompt := range registry.ListPrompts() {
+		if _, ok := expected[prompt.Name]; !ok {
+			t.Errorf("unexpected prompt: %s", prompt.Name)
+			continue
+		}
+		expected[prompt.Name] = true
+
+		if prompt.Description == "" {
+			t.Errorf("prompt %s has empty description", prompt.Name)
+		}
+	}
+
+	for name, found := range expected {
+		if !found {
+			t.Errorf("missing expected prompt: %s", name)
+		}
+	}
+}
+
+func TestRegistry_GetPrompt_Unknown(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+
+	_, err := registry.GetPrompt(context.Background(), "unknown_prompt", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRegistry_GetPrompt_MissingArgument(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+
+	_, err := registry.GetPrompt(context.Background(), "triage_customer_reviews", map[string]string{})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("err = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestMissingReviewDetailFields(t *testing.T) {
+	complete := api.AppStoreReviewDetailAttributes{
+		ContactFirstName: "Jane",
+		ContactLastName:  "Doe",
+		ContactEmail:     "jane@example.com",
+		ContactPhone:     "+1 555 0100",
+	}
+	if missing := missingReviewDetailFields(complete); len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+
+	demo := complete
+	demo.DemoAccountRequired = true
+	missing := missingReviewDetailFields(demo)
+	if len(missing) != 1 || missing[0] != "demo account credentials" {
+		t.Errorf("missing = %v, want [demo account credentials]", missing)
+	}
+}
+
+func TestRegistry_SetToolNaming(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+	if got := registry.tool("create_beta_group"); got != "create_beta_group" {
+		t.Errorf("tool() = %q, want the name unchanged", got)
+	}
+
+	registry.SetToolNaming(func(name string) string { return "asc_" + name })
+	if got := registry.tool("create_beta_group"); got != "asc_create_beta_group" {
+		t.Errorf("tool() = %q, want asc_create_beta_group", got)
+	}
+}
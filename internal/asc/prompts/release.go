@@ -0,0 +1,300 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// editableVersionStates are App Store version states whose metadata can still be changed.
+var editableVersionStates = map[api.AppStoreState]bool{
+	api.AppStoreStatePrepareForSubmission: true,
+	api.AppStoreStateDeveloperRejected:    true,
+	api.AppStoreStateRejected:             true,
+	api.AppStoreStateMetadataRejected:     true,
+	api.AppStoreStateInvalidBinary:        true,
+}
+
+// registerReleasePrompts registers prompts for common release workflows.
+func (r *Registry) registerReleasePrompts() {
+	r.register(mcp.Prompt{
+		Name:        "prepare_app_store_submission",
+		Description: "Review an App Store version's state, localizations, and review details and plan the remaining steps before submitting for review",
+		Arguments: []mcp.PromptArgument{
+			{Name: "app_id", Description: "The App Store Connect app ID", Required: true},
+			{Name: "version_id", Description: "The App Store version ID (defaults to the newest editable version)"},
+		},
+	}, r.promptPrepareSubmission)
+
+	r.register(mcp.Prompt{
+		Name:        "triage_customer_reviews",
+		Description: "Categorize recent customer reviews, surface recurring issues, and draft responses to the ones that need a reply",
+		Arguments: []mcp.PromptArgument{
+			{Name: "app_id", Description: "The App Store Connect app ID", Required: true},
+			{Name: "limit", Description: "Number of recent reviews to include (default 50, max 200)"},
+		},
+	}, r.promptTriageReviews)
+
+	r.register(mcp.Prompt{
+		Name:        "setup_testflight_group",
+		Description: "Plan the creation of a new TestFlight beta group using the app's existing groups, recent builds, and beta localizations",
+		Arguments: []mcp.PromptArgument{
+			{Name: "app_id", Description: "The App Store Connect app ID", Required: true},
+			{Name: "group_name", Description: "Name for the new beta group"},
+			{Name: "audience", Description: "internal or external (default external)"},
+		},
+	}, r.promptSetupTestFlightGroup)
+}
+
+func (r *Registry) promptPrepareSubmission(ctx context.Context, args map[string]string) (*mcp.PromptsGetResult, error) {
+	appID := args["app_id"]
+
+	app, err := r.client.GetApp(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %w", err)
+	}
+
+	version, err := r.findSubmissionVersion(ctx, appID, args["version_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("I am preparing %s (app ID %s) for App Store submission.\n\n", app.Data.Attributes.Name, appID))
+
+	sb.WriteString("## Version\n\n")
+	sb.WriteString(fmt.Sprintf("- ID: %s\n", version.ID))
+	sb.WriteString(fmt.Sprintf("- Version: %s (%s)\n", version.Attributes.VersionString, version.Attributes.Platform))
+	sb.WriteString(fmt.Sprintf("- State: %s\n", version.Attributes.AppStoreState))
+	sb.WriteString(fmt.Sprintf("- Release Type: %s\n", version.Attributes.ReleaseType))
+	if version.Attributes.Copyright == "" {
+		sb.WriteString("- Copyright: MISSING\n")
+	}
+	if !editableVersionStates[version.Attributes.AppStoreState] {
+		sb.WriteString("- Warning: this version is not in an editable state\n")
+	}
+
+	sb.WriteString("\n## Localizations\n\n")
+	locs, err := r.client.ListAppStoreVersionLocalizations(ctx, version.ID, api.ListOptions{})
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("Could not load localizations: %v\n", err))
+	} else if len(locs.Data) == 0 {
+		sb.WriteString("No localizations exist for this version.\n")
+	} else {
+		for _, loc := range locs.Data {
+			missing := missingLocalizationFields(loc.Attributes)
+			if len(missing) == 0 {
+				sb.WriteString(fmt.Sprintf("- %s (%s): complete\n", loc.Attributes.Locale, loc.ID))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s): missing %s\n", loc.Attributes.Locale, loc.ID, strings.Join(missing, ", ")))
+		}
+	}
+
+	sb.WriteString("\n## App Review Information\n\n")
+	detail, err := r.client.GetAppStoreReviewDetail(ctx, version.ID)
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("No review detail found (%v). One must be created before submitting.\n", err))
+	} else {
+		missing := missingReviewDetailFields(detail.Data.Attributes)
+		if len(missing) == 0 {
+			sb.WriteString(fmt.Sprintf("Review detail %s is complete.\n", detail.Data.ID))
+		} else {
+			sb.WriteString(fmt.Sprintf("Review detail %s is missing: %s\n", detail.Data.ID, strings.Join(missing, ", ")))
+		}
+	}
+
+	sb.WriteString("\n## Task\n\n")
+	sb.WriteString("Using the information above:\n")
+	sb.WriteString("1. List every blocker that would prevent submission, most severe first.\n")
+	sb.WriteString("2. For each missing localization field, propose text or ask me for it.\n")
+	sb.WriteString(fmt.Sprintf("3. Fill in missing review details with %s or %s once I confirm the values.\n", r.tool("update_app_store_review_detail"), r.tool("create_app_store_review_detail")))
+	sb.WriteString(fmt.Sprintf("4. When nothing is blocking, confirm with me before calling %s.\n", r.tool("submit_app_for_review")))
+
+	return mcp.NewUserPrompt("Prepare an App Store submission", sb.String()), nil
+}
+
+func (r *Registry) promptTriageReviews(ctx context.Context, args map[string]string) (*mcp.PromptsGetResult, error) {
+	appID := args["app_id"]
+
+	limit := 50
+	if v := args["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("limit must be an integer: %w", err)
+		}
+		limit = n
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	reviews, err := r.client.ListCustomerReviews(ctx, appID, api.ListOptions{Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer reviews: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Here are the %d most recent customer reviews for app %s.\n\n", len(reviews.Data), appID))
+
+	for _, review := range reviews.Data {
+		sb.WriteString(fmt.Sprintf("### %s (%d/5)\n", review.Attributes.Title, review.Attributes.Rating))
+		sb.WriteString(fmt.Sprintf("- ID: %s\n", review.ID))
+		sb.WriteString(fmt.Sprintf("- Territory: %s\n", review.Attributes.Territory))
+		if review.Attributes.CreatedDate != nil {
+			sb.WriteString(fmt.Sprintf("- Date: %s\n", review.Attributes.CreatedDate.Format("2006-01-02")))
+		}
+		sb.WriteString(fmt.Sprintf("\n%s\n\n", review.Attributes.Body))
+	}
+
+	sb.WriteString("## Task\n\n")
+	sb.WriteString("1. Group the reviews into bugs, feature requests, billing or account problems, and praise.\n")
+	sb.WriteString("2. Summarize recurring issues with the review IDs that mention them.\n")
+	sb.WriteString("3. For reviews rated 3 or lower that describe a concrete problem, draft a short, specific response.\n")
+	sb.WriteString(fmt.Sprintf("4. Only post responses with %s after I approve them.\n", r.tool("create_customer_review_response")))
+
+	return mcp.NewUserPrompt("Triage customer reviews", sb.String()), nil
+}
+
+func (r *Registry) promptSetupTestFlightGroup(ctx context.Context, args map[string]string) (*mcp.PromptsGetResult, error) {
+	appID := args["app_id"]
+
+	audience := strings.ToLower(args["audience"])
+	if audience == "" {
+		audience = "external"
+	}
+	if audience != "internal" && audience != "external" {
+		return nil, fmt.Errorf("audience must be internal or external")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("I want to set up a new %s TestFlight beta group for app %s", audience, appID))
+	if name := args["group_name"]; name != "" {
+		sb.WriteString(fmt.Sprintf(" named %q", name))
+	}
+	sb.WriteString(".\n\n")
+
+	sb.WriteString("## Existing Beta Groups\n\n")
+	groups, err := r.client.ListBetaGroups(ctx, appID, api.ListOptions{Limit: 50})
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("Could not load beta groups: %v\n", err))
+	} else if len(groups.Data) == 0 {
+		sb.WriteString("No beta groups exist yet.\n")
+	} else {
+		for _, group := range groups.Data {
+			kind := "external"
+			if group.Attributes.IsInternalGroup {
+				kind = "internal"
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s, %s, public link: %t)\n", group.Attributes.Name, group.ID, kind, group.Attributes.PublicLinkEnabled))
+		}
+	}
+
+	sb.WriteString("\n## Recent Builds\n\n")
+	builds, err := r.client.ListBuilds(ctx, appID, api.ListOptions{Limit: 10})
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("Could not load builds: %v\n", err))
+	} else if len(builds.Data) == 0 {
+		sb.WriteString("No builds have been uploaded.\n")
+	} else {
+		for _, build := range builds.Data {
+			sb.WriteString(fmt.Sprintf("- Build %s (%s): %s, expired: %t\n", build.Attributes.Version, build.ID, build.Attributes.ProcessingState, build.Attributes.Expired))
+		}
+	}
+
+	if audience == "external" {
+		sb.WriteString("\n## Beta App Localizations\n\n")
+		locs, err := r.client.ListBetaAppLocalizations(ctx, appID, api.ListOptions{Limit: 50})
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("Could not load beta app localizations: %v\n", err))
+		} else if len(locs.Data) == 0 {
+			sb.WriteString("No beta app localizations exist; external testing requires beta app description and feedback email.\n")
+		} else {
+			for _, loc := range locs.Data {
+				sb.WriteString(fmt.Sprintf("- %s (%s)\n", loc.Attributes.Locale, loc.ID))
+			}
+		}
+	}
+
+	sb.WriteString("\n## Task\n\n")
+	sb.WriteString("1. Check that the group name does not clash with an existing group.\n")
+	sb.WriteString("2. Pick the newest valid, unexpired build to distribute.\n")
+	if audience == "external" {
+		sb.WriteString("3. Point out anything needed for beta app review (localizations, beta review submission).\n")
+	} else {
+		sb.WriteString("3. Remind me that internal testers must already be App Store Connect users.\n")
+	}
+	sb.WriteString(fmt.Sprintf("4. After I confirm, create the group with %s and invite testers with %s and %s.\n", r.tool("create_beta_group"), r.tool("invite_beta_tester"), r.tool("add_tester_to_group")))
+
+	return mcp.NewUserPrompt("Set up a TestFlight beta group", sb.String()), nil
+}
+
+// findSubmissionVersion returns the requested version or the newest editable version of an app.
+func (r *Registry) findSubmissionVersion(ctx context.Context, appID, versionID string) (*api.AppStoreVersion, error) {
+	if versionID != "" {
+		resp, err := r.client.GetAppStoreVersion(ctx, versionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version: %w", err)
+		}
+		return &resp.Data, nil
+	}
+
+	resp, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: 10})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("app %s has no App Store versions", appID)
+	}
+
+	for i := range resp.Data {
+		if editableVersionStates[resp.Data[i].Attributes.AppStoreState] {
+			return &resp.Data[i], nil
+		}
+	}
+
+	return &resp.Data[0], nil
+}
+
+// missingLocalizationFields lists the submission-relevant fields that are empty.
+func missingLocalizationFields(attrs api.AppStoreVersionLocalizationAttributes) []string {
+	var missing []string
+	if attrs.Description == "" {
+		missing = append(missing, "description")
+	}
+	if attrs.Keywords == "" {
+		missing = append(missing, "keywords")
+	}
+	if attrs.SupportURL == "" {
+		missing = append(missing, "support URL")
+	}
+	if attrs.WhatsNew == "" {
+		missing = append(missing, "what's new")
+	}
+	return missing
+}
+
+// missingReviewDetailFields lists the App Review contact and demo account fields that are empty.
+func missingReviewDetailFields(attrs api.AppStoreReviewDetailAttributes) []string {
+	var missing []string
+	if attrs.ContactFirstName == "" || attrs.ContactLastName == "" {
+		missing = append(missing, "contact name")
+	}
+	if attrs.ContactEmail == "" {
+		missing = append(missing, "contact email")
+	}
+	if attrs.ContactPhone == "" {
+		missing = append(missing, "contact phone")
+	}
+	if attrs.DemoAccountRequired && (attrs.DemoAccountName == "" || attrs.DemoAccountPassword == "") {
+		missing = append(missing, "demo account credentials")
+	}
+	return missing
+}
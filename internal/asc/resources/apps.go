@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// registerAppResources registers app, build, and version resources.
+func (r *Registry) registerAppResources() {
+	r.register(mcp.Resource{
+		URI:         URIScheme + "apps",
+		Name:        "Apps",
+		Description: "All apps in the App Store Connect account",
+		MimeType:    "application/json",
+	}, r.readApps)
+
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: URIScheme + "apps/{app_id}",
+		Name:        "App",
+		Description: "A single app by its App Store Connect ID",
+		MimeType:    "application/json",
+	}, r.readApp)
+
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: URIScheme + "apps/{app_id}/builds",
+		Name:        "App builds",
+		Description: "Recent builds uploaded for an app",
+		MimeType:    "application/json",
+	}, r.readAppBuilds)
+
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: URIScheme + "apps/{app_id}/versions",
+		Name:        "App Store versions",
+		Description: "App Store versions for an app",
+		MimeType:    "application/json",
+	}, r.readAppVersions)
+
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: URIScheme + "builds/{build_id}",
+		Name:        "Build",
+		Description: "A single build by its App Store Connect ID",
+		MimeType:    "application/json",
+	}, r.readBuild)
+
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: URIScheme + "versions/{version_id}",
+		Name:        "App Store version",
+		Description: "A single App Store version by its App Store Connect ID",
+		MimeType:    "application/json",
+	}, r.readVersion)
+}
+
+func (r *Registry) readApps(ctx context.Context, vars map[string]string) (any, error) {
+	return r.client.ListAllApps(ctx)
+}
+
+func (r *Registry) readApp(ctx context.Context, vars map[string]string) (any, error) {
+	resp, err := r.client.GetApp(ctx, vars["app_id"])
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *Registry) readAppBuilds(ctx context.Context, vars map[string]string) (any, error) {
+	resp, err := r.client.ListBuilds(ctx, vars["app_id"], api.ListOptions{Limit: 50})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *Registry) readAppVersions(ctx context.Context, vars map[string]string) (any, error) {
+	resp, err := r.client.GetAppVersions(ctx, vars["app_id"], api.ListOptions{Limit: 50})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *Registry) readBuild(ctx context.Context, vars map[string]string) (any, error) {
+	resp, err := r.client.GetBuild(ctx, vars["build_id"])
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (r *Registry) readVersion(ctx context.Context, vars map[string]string) (any, error) {
+	resp, err := r.client.GetAppStoreVersion(ctx, vars["version_id"])
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
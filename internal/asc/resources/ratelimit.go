@@ -0,0 +1,30 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// rateLimitBudget is the contents of the rate limit resource. The quota
+// fields are omitted until a response has reported them.
+type rateLimitBudget struct {
+	Observed bool `json:"observed"`
+	*api.RateLimitStatus
+}
+
+// registerRateLimitResources registers the rate limit budget resource.
+func (r *Registry) registerRateLimitResources() {
+	r.register(mcp.Resource{
+		URI:         URIScheme + "rate-limit",
+		Name:        "Rate limit",
+		Description: "The API key's hourly request quota, requests used, and requests remaining, as last reported by App Store Connect",
+		MimeType:    "application/json",
+	}, r.readRateLimit)
+}
+
+func (r *Registry) readRateLimit(ctx context.Context, vars map[string]string) (any, error) {
+	status := r.client.RateLimit(ctx)
+	return rateLimitBudget{Observed: status != nil, RateLimitStatus: status}, nil
+}
@@ -0,0 +1,161 @@
+// Package resources provides MCP resource implementations for App Store Connect.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// URIScheme is the URI scheme used for all App Store Connect resources.
+const URIScheme = "asc://"
+
+// ErrNotFound is returned when a URI does not match any registered resource.
+var ErrNotFound = errors.New("resource not found")
+
+// ResourceHandler reads a resource given the variables extracted from its URI.
+type ResourceHandler func(ctx context.Context, vars map[string]string) (any, error)
+
+// template pairs a resource template definition with its handler.
+type template struct {
+	def     mcp.ResourceTemplate
+	handler ResourceHandler
+}
+
+// Registry manages resource definitions and handlers.
+type Registry struct {
+	client    *api.Client
+	resources []mcp.Resource
+	templates []template
+}
+
+// NewRegistry creates a new resource registry.
+func NewRegistry(client *api.Client) *Registry {
+	r := &Registry{
+		client:    client,
+		resources: make([]mcp.Resource, 0),
+		templates: make([]template, 0),
+	}
+
+	r.registerAppResources()
+	r.registerRateLimitResources()
+
+	return r
+}
+
+// ListResources returns the static resources plus one concrete resource per app.
+// If the app listing fails, only the static resources are returned.
+func (r *Registry) ListResources(ctx context.Context) []mcp.Resource {
+	resources := append([]mcp.Resource(nil), r.resources...)
+
+	apps, err := r.client.ListAllApps(ctx)
+	if err != nil {
+		return resources
+	}
+
+	for _, app := range apps {
+		resources = append(resources, mcp.Resource{
+			URI:         URIScheme + "apps/" + app.ID,
+			Name:        app.Attributes.Name,
+			Description: fmt.Sprintf("App %s (%s)", app.Attributes.Name, app.Attributes.BundleID),
+			MimeType:    "application/json",
+		})
+	}
+
+	return resources
+}
+
+// ListTemplates returns all parameterized resource templates.
+func (r *Registry) ListTemplates() []mcp.ResourceTemplate {
+	defs := make([]mcp.ResourceTemplate, 0, len(r.templates))
+	for _, t := range r.templates {
+		if !strings.Contains(t.def.URITemplate, "{") {
+			continue
+		}
+		defs = append(defs, t.def)
+	}
+	return defs
+}
+
+// ReadResource resolves a URI against the registered templates and returns its contents.
+func (r *Registry) ReadResource(ctx context.Context, uri string) (*mcp.ResourcesReadResult, error) {
+	for _, t := range r.templates {
+		vars, ok := matchTemplate(t.def.URITemplate, uri)
+		if !ok {
+			continue
+		}
+
+		value, err := t.handler(ctx, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource: %w", err)
+		}
+
+		return &mcp.ResourcesReadResult{
+			Contents: []mcp.ResourceContents{
+				{
+					URI:      uri,
+					MimeType: t.def.MimeType,
+					Text:     string(text),
+				},
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, uri)
+}
+
+// register adds a static resource and its handler to the registry.
+func (r *Registry) register(resource mcp.Resource, handler ResourceHandler) {
+	r.resources = append(r.resources, resource)
+	r.registerTemplate(mcp.ResourceTemplate{
+		URITemplate: resource.URI,
+		Name:        resource.Name,
+		Description: resource.Description,
+		MimeType:    resource.MimeType,
+	}, handler)
+}
+
+// registerTemplate adds a resource template to the registry.
+func (r *Registry) registerTemplate(def mcp.ResourceTemplate, handler ResourceHandler) {
+	r.templates = append(r.templates, template{def: def, handler: handler})
+}
+
+// matchTemplate matches a URI against a template such as "asc://apps/{app_id}/builds"
+// and returns the extracted variables.
+func matchTemplate(tmpl, uri string) (map[string]string, bool) {
+	if !strings.HasPrefix(tmpl, URIScheme) || !strings.HasPrefix(uri, URIScheme) {
+		return nil, false
+	}
+
+	tmplParts := strings.Split(strings.TrimPrefix(tmpl, URIScheme), "/")
+	uriParts := strings.Split(strings.TrimPrefix(uri, URIScheme), "/")
+	if len(tmplParts) != len(uriParts) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, part := range tmplParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if uriParts[i] == "" {
+				return nil, false
+			}
+			vars[part[1:len(part)-1]] = uriParts[i]
+			continue
+		}
+		if part != uriParts[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+func TestMatchTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		uri      string
+		wantOK   bool
+		wantVars map[string]string
+	}{
+		{
+			name:     "static match",
+			template: "asc://apps",
+			uri:      "asc://apps",
+			wantOK:   true,
+			wantVars: map[string]string{},
+		},
+		{
+			name:     "single variable",
+			template: "asc://apps/{app_id}",
+			uri:      "asc://apps/123",
+			wantOK:   true,
+			wantVars: map[string]string{"app_id": "123"},
+		},
+		{
+			name:     "variable with suffix",
+			template: "asc://apps/{app_id}/builds",
+			uri:      "asc://apps/123/builds",
+			wantOK:   true,
+			wantVars: map[string]string{"app_id": "123"},
+		},
+		{
+			name:     "segment count mismatch",
+			template: "asc://apps/{app_id}",
+			uri:      "asc://apps/123/builds",
+			wantOK:   false,
+		},
+		{
+			name:     "literal mismatch",
+			template: "asc://apps/{app_id}/builds",
+			uri:      "asc://apps/123/versions",
+			wantOK:   false,
+		},
+		{
+			name:     "empty variable",
+			template: "asc://apps/{app_id}",
+			uri:      "asc://apps/",
+			wantOK:   false,
+		},
+		{
+			name:     "wrong scheme",
+			template: "asc://apps/{app_id}",
+			uri:      "https://apps/123",
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, ok := matchTemplate(tt.template, tt.uri)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			for k, v := range tt.wantVars {
+				if vars[k] != v {
+					t.Errorf("vars[%q] = %q, want %q", k, vars[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_ListTemplates(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+
+	templates := registry.ListTemplates()
+	if len(templates) == 0 {
+		t.Fatal("expected templates to be registered")
+	}
+
+	for _, tmpl := range templates {
+		if tmpl.URITemplate == "asc://apps" {
+			t.Error("static resource should not be listed as a template")
+		}
+		if tmpl.Name == "" {
+			t.Errorf("template %s has empty name", tmpl.URITemplate)
+		}
+	}
+}
+
+func TestRegistry_ReadResource_NotFound(t *testing.T) {
+	registry := NewRegistry((*api.Client)(nil))
+
+	_, err := registry.ReadResource(context.Background(), "asc://unknown/1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRegistry_ReadResource_RateLimit(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	tokens, err := api.NewTokenProviderFromKey("test-issuer", "TESTKEY123", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}))
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3590;")
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL))
+	registry := NewRegistry(client)
+
+	read := func() map[string]any {
+		t.Helper()
+		result, err := registry.ReadResource(context.Background(), "asc://rate-limit")
+		if err != nil {
+			t.Fatalf("ReadResource failed: %v", err)
+		}
+		var budget map[string]any
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), &budget); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return budget
+	}
+
+	if budget := read(); budget["observed"] != false || budget["limit"] != nil {
+		t.Errorf("budget before any request = %v", budget)
+	}
+
+	if _, err := client.ListApps(context.Background(), api.ListOptions{Limit: 1}); err != nil {
+		t.Fatalf("ListApps failed: %v", err)
+	}
+
+	budget := read()
+	if budget["observed"] != true || budget["limit"] != 3600.0 || budget["remaining"] != 3590.0 || budget["used"] != 10.0 {
+		t.Errorf("budget = %v", budget)
+	}
+}
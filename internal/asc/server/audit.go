@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/audit"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// maxAuditErrorLength caps the error text recorded for a failed tool call.
+const maxAuditErrorLength = 1000
+
+// newSessionID returns a random ID distinguishing a session's tool calls in
+// the audit log.
+func newSessionID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// auditToolCall returns a context that records the API requests a tool call
+// makes, and a function that appends the call to the audit log when it ends.
+func (s *Server) auditToolCall(ctx context.Context, id json.RawMessage, params mcp.ToolsCallParams) (context.Context, func(result *mcp.ToolsCallResult, err error)) {
+	if s.audit == nil {
+		return ctx, func(*mcp.ToolsCallResult, error) {}
+	}
+
+	start := time.Now()
+	var mu sync.Mutex
+	calls := []audit.APICall{}
+	ctx = api.WithResponseObserver(ctx, func(resp api.Response) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, audit.APICall{
+			Method:     resp.Method,
+			Path:       resp.Path,
+			Status:     resp.StatusCode,
+			DurationMs: resp.Duration.Milliseconds(),
+
+			APIRequestID: resp.RequestID,
+		})
+	})
+
+	return ctx, func(result *mcp.ToolsCallResult, err error) {
+		entry := audit.Entry{
+			Time:       start.UTC(),
+			Session:    s.sessionID,
+			Client:     s.clientName,
+			RequestID:  id,
+			Tool:       s.registry.ResolveToolName(params.Name),
+			Arguments:  params.Arguments,
+			Status:     audit.StatusOK,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		switch {
+		case ctx.Err() != nil:
+			entry.Status = audit.StatusCancelled
+		case err != nil:
+			entry.Status = audit.StatusError
+			entry.Error = err.Error()
+		case result.IsError:
+			entry.Status = audit.StatusError
+			if len(result.Content) > 0 {
+				entry.Error = result.Content[0].Text
+			}
+		}
+		if len(entry.Error) > maxAuditErrorLength {
+			entry.Error = entry.Error[:maxAuditErrorLength]
+		}
+
+		mu.Lock()
+		entry.APICalls = append([]audit.APICall(nil), calls...)
+		mu.Unlock()
+
+		if err := s.audit.Write(entry); err != nil {
+			log.Printf("audit: %v", err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// handleComplete handles the completion/complete request. Besides the
+// ref/prompt and ref/resource references defined by MCP, ref/tool completes
+// tool arguments by the same argument names.
+func (s *Server) handleComplete(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	var params mcp.CompleteParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	if err := s.validateCompletionRef(params.Ref); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	completion, err := s.completions.Complete(context.Background(), params.Argument.Name, params.Argument.Value)
+	if err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInternal, "Failed to complete argument", err.Error())
+		return
+	}
+
+	s.sendResult(req.ID, mcp.CompleteResult{Completion: completion})
+}
+
+// validateCompletionRef checks that a completion reference names a registered
+// prompt, resource template, or tool.
+func (s *Server) validateCompletionRef(ref mcp.CompleteReference) error {
+	switch ref.Type {
+	case mcp.RefPrompt:
+		for _, prompt := range s.prompts.ListPrompts() {
+			if prompt.Name == ref.Name {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown prompt: %s", ref.Name)
+	case mcp.RefResource:
+		for _, tmpl := range s.resources.ListTemplates() {
+			if tmpl.URITemplate == ref.URI {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown resource template: %s", ref.URI)
+	case mcp.RefTool:
+		for _, tool := range s.registry.ListTools() {
+			if tool.Name == ref.Name {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown tool: %s", ref.Name)
+	}
+	return fmt.Errorf("unknown reference type: %s", ref.Type)
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// request sends a request to the client and waits for its response, or until
+// ctx is done.
+func (s *Server) request(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	s.pendingMu.Lock()
+	s.nextRequestID++
+	id := json.RawMessage(strconv.Quote(fmt.Sprintf("server-%d", s.nextRequestID)))
+	responses := make(chan mcp.ClientResponse, 1)
+	s.pending[string(id)] = responses
+	s.pendingMu.Unlock()
+
+	defer func() {
+		s.pendingMu.Lock()
+		delete(s.pending, string(id))
+		s.pendingMu.Unlock()
+	}()
+
+	s.send(mcp.ServerRequest{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+
+	select {
+	case resp := <-responses:
+		if resp.Error != nil {
+			return nil, fmt.Errorf("%s failed: %s", method, resp.Error.Message)
+		}
+		return resp.Result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handleResponse delivers a client's response to the request waiting for it.
+func (s *Server) handleResponse(line []byte) {
+	var resp mcp.ClientResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		log.Printf("invalid response from client: %v", err)
+		return
+	}
+
+	s.pendingMu.Lock()
+	responses, ok := s.pending[string(resp.ID)]
+	s.pendingMu.Unlock()
+	if !ok {
+		log.Printf("response to unknown request %s", resp.ID)
+		return
+	}
+	select {
+	case responses <- resp:
+	default:
+		// A duplicate response; the first one was already delivered.
+	}
+}
+
+// elicit asks the user for values through the client's elicitation/create.
+func (s *Server) elicit(ctx context.Context, message string, schema mcp.JSONSchema) (*mcp.ElicitResult, error) {
+	data, err := s.request(ctx, "elicitation/create", mcp.ElicitParams{
+		Message:         message,
+		RequestedSchema: schema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result mcp.ElicitResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid elicitation result: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+const (
+	// instructionsTTL is how long an account summary is reused before
+	// initialize refreshes it in the background.
+	instructionsTTL = time.Hour
+
+	// instructionsTimeout bounds the requests made to summarize the account.
+	instructionsTimeout = 10 * time.Second
+
+	// instructionsRetryDelay is how long to wait before summarizing the
+	// account again after a failure. It doubles with each failure in a row,
+	// up to instructionsTTL.
+	instructionsRetryDelay = time.Minute
+
+	// maxInstructionApps caps the apps listed by name in the instructions.
+	maxInstructionApps = 20
+)
+
+// instructionsCache holds the account summary sent as server instructions.
+// It is shared by all sessions of a server.
+type instructionsCache struct {
+	mu         sync.Mutex
+	team       string
+	summary    string
+	generated  time.Time
+	refreshing bool
+	failures   int
+	retryAt    time.Time
+}
+
+// RefreshInstructions summarizes the selected team's account for the
+// instructions sent to clients at initialize, replacing the cached summary.
+func (s *Server) RefreshInstructions(ctx context.Context) error {
+	team := s.client.ActiveTeam()
+	summary, err := s.summarizeAccount(api.WithRefresh(ctx))
+
+	c := s.instructions
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshing = false
+	if err != nil {
+		c.failures++
+		c.retryAt = time.Now().Add(min(instructionsRetryDelay<<(c.failures-1), instructionsTTL))
+		return fmt.Errorf("failed to summarize account: %w", err)
+	}
+	c.team = team
+	c.summary = summary
+	c.generated = time.Now()
+	c.failures = 0
+	c.retryAt = time.Time{}
+	return nil
+}
+
+// RefreshInstructionsInBackground starts summarizing the account for the
+// instructions, unless a refresh is already running.
+func (s *Server) RefreshInstructionsInBackground() {
+	c := s.instructions
+	c.mu.Lock()
+	running := c.refreshing
+	c.refreshing = true
+	c.mu.Unlock()
+
+	if running {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), instructionsTimeout)
+		defer cancel()
+		if err := s.RefreshInstructions(ctx); err != nil {
+			log.Printf("server instructions won't include an account summary yet: %v", err)
+		}
+	}()
+}
+
+// serverInstructions returns the instructions for initialize. A summary that
+// is missing, older than instructionsTTL, or of another team, is refreshed in
+// the background for later sessions; until then the instructions leave it
+// out. After a failed refresh, the next waits for a backoff.
+func (s *Server) serverInstructions() string {
+	team := s.client.ActiveTeam()
+
+	c := s.instructions
+	c.mu.Lock()
+	summary := ""
+	if c.team == team {
+		summary = c.summary
+	}
+	stale := c.generated.IsZero() || c.team != team || time.Since(c.generated) > instructionsTTL
+	refresh := stale && !c.refreshing && !time.Now().Before(c.retryAt)
+	c.mu.Unlock()
+
+	if refresh {
+		s.RefreshInstructionsInBackground()
+	}
+
+	return s.formatInstructions(team, summary)
+}
+
+// summarizeAccount describes the apps of the selected team.
+func (s *Server) summarizeAccount(ctx context.Context) (string, error) {
+	apps, err := s.client.ListApps(ctx, api.ListOptions{Limit: 200})
+	if err != nil {
+		return "", err
+	}
+
+	total := len(apps.Data)
+	if apps.Meta != nil && apps.Meta.Paging.Total > total {
+		total = apps.Meta.Paging.Total
+	}
+
+	var sb strings.Builder
+	switch total {
+	case 0:
+		sb.WriteString("The account has no apps yet.\n")
+	case 1:
+		sb.WriteString("The account has 1 app:\n")
+	default:
+		sb.WriteString(fmt.Sprintf("The account has %d apps:\n", total))
+	}
+	for i, app := range apps.Data {
+		if i == maxInstructionApps {
+			sb.WriteString(fmt.Sprintf("- and %d more; list them with %s\n", total-i, s.registry.PublicToolName("list_apps")))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s (app ID %s, bundle ID %s)\n", app.Attributes.Name, app.ID, app.Attributes.BundleID))
+	}
+	return sb.String(), nil
+}
+
+// formatInstructions writes the server instructions around an account
+// summary, which may be empty.
+func (s *Server) formatInstructions(team, summary string) string {
+	tool := s.registry.PublicToolName
+
+	var sb strings.Builder
+	sb.WriteString("This server manages apps in App Store Connect through its API.\n\n")
+
+	if teams := s.client.Teams(); len(teams) > 1 {
+		sb.WriteString(fmt.Sprintf("Team: %s is selected, out of %s. Switch with %s, or pass team to a single tool call.\n", team, strings.Join(teams, ", "), tool("select_team")))
+	} else {
+		sb.WriteString(fmt.Sprintf("Team: %s\n", team))
+	}
+	if summary != "" {
+		sb.WriteString(summary)
+	}
+
+	sb.WriteString("\nIdentifiers:\n")
+	sb.WriteString("- App IDs are numeric Apple IDs, such as 1234567890, not bundle IDs. Look them up with " + tool("list_apps") + ".\n")
+	sb.WriteString("- Bundle IDs are reverse-DNS strings, such as com.example.app.\n")
+	sb.WriteString("- Versions, builds, localizations, beta groups and most other resources have opaque IDs, usually UUIDs. Get them from the list tools rather than guessing.\n")
+	sb.WriteString("- Version strings (1.2.0) and build numbers (42) are not IDs; tools that take them say so.\n")
+	sb.WriteString("- Locales are codes such as en-US or de-DE, and territories are three-letter codes such as USA.\n")
+	sb.WriteString("- Dates are ISO 8601; report dates are YYYY-MM-DD.\n")
+	return sb.String()
+}
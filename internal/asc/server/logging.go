@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// defaultLogLevel is the minimum level of notifications/message events until
+// the client sends logging/setLevel.
+const defaultLogLevel = "info"
+
+// rateLimitWarningFraction is the share of the hourly quota below which API
+// responses are logged as warnings.
+const rateLimitWarningFraction = 0.1
+
+// handleSetLevel handles the logging/setLevel request.
+func (s *Server) handleSetLevel(req *mcp.Request) {
+	var params mcp.SetLevelParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	if mcp.LogLevelSeverity(params.Level) < 0 {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", fmt.Sprintf("unknown log level: %s", params.Level))
+		return
+	}
+
+	s.logLevelMu.Lock()
+	s.logLevel = params.Level
+	s.logLevelMu.Unlock()
+
+	s.sendResult(req.ID, struct{}{})
+}
+
+// logMessage sends a notifications/message event if level is at or above the
+// client's chosen level.
+func (s *Server) logMessage(level, logger string, data any) {
+	s.logLevelMu.Lock()
+	minLevel := s.logLevel
+	s.logLevelMu.Unlock()
+
+	if mcp.LogLevelSeverity(level) < mcp.LogLevelSeverity(minLevel) {
+		return
+	}
+
+	s.sendNotification("notifications/message", mcp.LogMessageParams{
+		Level:  level,
+		Logger: logger,
+		Data:   data,
+	})
+}
+
+// apiLogger returns a response observer that logs each API response of a tool call.
+// Responses are debug events, raised to warnings for errors and a low rate-limit quota.
+func (s *Server) apiLogger(id json.RawMessage, tool string) api.ResponseObserver {
+	return func(resp api.Response) {
+		level := "debug"
+		data := map[string]any{
+			"requestId":  id,
+			"tool":       tool,
+			"method":     resp.Method,
+			"path":       resp.Path,
+			"status":     resp.StatusCode,
+			"durationMs": resp.Duration.Milliseconds(),
+		}
+
+		if resp.RequestID != "" {
+			data["apiRequestId"] = resp.RequestID
+		}
+		if resp.RateLimit != nil {
+			data["rateLimitRemaining"] = resp.RateLimit.Remaining
+			data["rateLimit"] = resp.RateLimit.Limit
+			if float64(resp.RateLimit.Remaining) < float64(resp.RateLimit.Limit)*rateLimitWarningFraction {
+				level = "warning"
+			}
+		}
+		if resp.StatusCode >= 400 {
+			level = "warning"
+		}
+
+		s.logMessage(level, "api", data)
+	}
+}
+
+// logToolCall logs the start of a tool call and returns a function that logs its end.
+func (s *Server) logToolCall(id json.RawMessage, tool string) func(isError bool) {
+	start := time.Now()
+	s.logMessage("info", "tools", map[string]any{
+		"event":     "started",
+		"requestId": id,
+		"tool":      tool,
+	})
+
+	return func(isError bool) {
+		s.logMessage("info", "tools", map[string]any{
+			"event":      "finished",
+			"requestId":  id,
+			"tool":       tool,
+			"isError":    isError,
+			"durationMs": time.Since(start).Milliseconds(),
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/metrics"
+	"github.com/antisynthesis/asc-mcp/internal/asc/tools"
+)
+
+// metricsShutdownTimeout bounds how long the metrics listener waits for a
+// scrape in progress when serving stops.
+const metricsShutdownTimeout = 5 * time.Second
+
+// observeToolCall counts a completed tool call in the metrics. Calls to
+// unknown tools aren't counted, so clients can't add series at will.
+func (s *Server) observeToolCall(ctx context.Context, name string, result *mcp.ToolsCallResult, err error, d time.Duration) {
+	if s.metrics == nil || errors.Is(err, tools.ErrUnknownTool) {
+		return
+	}
+
+	outcome := metrics.OutcomeOK
+	switch {
+	case ctx.Err() != nil:
+		outcome = metrics.OutcomeCancelled
+	case err != nil || result.IsError:
+		outcome = metrics.OutcomeError
+	}
+	s.metrics.ObserveToolCall(s.registry.ResolveToolName(name), outcome, d)
+}
+
+// ServeMetrics starts serving Prometheus metrics at /metrics on addr in the
+// background, until ctx is cancelled. It only returns an error if addr can't
+// be listened on, and does nothing if metrics are off.
+func (s *Server) ServeMetrics(ctx context.Context, addr string) error {
+	if s.metrics == nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.metrics)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	context.AfterFunc(ctx, func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	})
+
+	log.Printf("serving metrics on http://%s/metrics", listener.Addr())
+	go func() {
+		if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics listener stopped: %v", err)
+		}
+	}()
+	return nil
+}
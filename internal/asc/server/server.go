@@ -3,16 +3,26 @@ package server
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/audit"
+	"github.com/antisynthesis/asc-mcp/internal/asc/completions"
 	"github.com/antisynthesis/asc-mcp/internal/asc/config"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/metrics"
+	"github.com/antisynthesis/asc-mcp/internal/asc/prompts"
+	"github.com/antisynthesis/asc-mcp/internal/asc/resources"
+	"github.com/antisynthesis/asc-mcp/internal/asc/snapshots"
 	"github.com/antisynthesis/asc-mcp/internal/asc/tools"
+	"github.com/antisynthesis/asc-mcp/internal/asc/tracing"
 )
 
 const (
@@ -29,35 +39,236 @@ type Server struct {
 	writeMu     sync.Mutex
 	initialized bool
 	registry    *tools.Registry
+	resources   *resources.Registry
+	prompts     *prompts.Registry
+	completions *completions.Provider
+
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]bool
+
+	// Tool calls run concurrently so that notifications/cancelled can reach
+	// them. calls holds the cancel function of each in-flight call by request ID.
+	callsMu sync.Mutex
+	calls   map[string]context.CancelFunc
+	callsWG sync.WaitGroup
+
+	// logLevel is the minimum level of notifications/message events.
+	logLevelMu sync.Mutex
+	logLevel   string
+
+	// Requests sent to the client wait in pending, by ID, for its response.
+	pendingMu     sync.Mutex
+	pending       map[string]chan mcp.ClientResponse
+	nextRequestID int
+
+	// elicitation is set if the client can ask the user for missing arguments.
+	elicitation bool
+
+	// Tool calls are appended to audit, if set, under the session's ID and
+	// the client's name.
+	audit      *audit.Log
+	sessionID  string
+	clientName string
+
+	// instructions caches the account summary sent at initialize.
+	instructions *instructionsCache
+
+	// metrics counts API requests and tool calls, if set.
+	metrics *metrics.Metrics
+
+	// tracer records spans of tool calls and API requests, if set.
+	tracer *tracing.Tracer
 }
 
 // New creates a new MCP server instance.
 func New(cfg *config.Config, r io.Reader, w io.Writer) (*Server, error) {
-	client, err := api.NewClient(cfg.IssuerID, cfg.KeyID, cfg.PrivateKeyPath)
+	var middlewares []api.Middleware
+	var tracer *tracing.Tracer
+	if cfg.TracesEndpoint != "" {
+		tracer = tracing.New(cfg.TracesEndpoint, cfg.TracesHeaders, cfg.ServiceName, serverVersion)
+		middlewares = append(middlewares, tracer.Middleware())
+	}
+	var m *metrics.Metrics
+	if cfg.MetricsAddr != "" {
+		m = metrics.New()
+		middlewares = append(middlewares, m.Middleware())
+	}
+
+	client, err := newClient(cfg, middlewares...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
+	store, err := snapshots.Open(cfg.SnapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog, err := audit.Open(cfg.AuditLogPath)
+	if err != nil {
+		return nil, err
+	}
+
 	registry := tools.NewRegistry(client)
+	registry.SetSnapshotStore(store)
+	registry.SetToolTimeouts(cfg.ToolTimeouts)
+	registry.SetConcurrencyLimits(cfg.ConcurrencyLimits)
+	registry.SetMaxResultBytes(cfg.MaxResultBytes)
+	registry.SetToolNaming(cfg.ToolPrefix, cfg.ToolGroups)
+	registry.SetAppGroups(cfg.AppGroups)
+	if cfg.EnumLabels {
+		labels := tools.DefaultEnumLabels()
+		if cfg.EnumLabelsPath != "" {
+			if labels, err = tools.LoadEnumLabels(cfg.EnumLabelsPath); err != nil {
+				return nil, err
+			}
+		}
+		registry.SetEnumLabels(labels)
+	}
+	if cfg.EnableRawAPI {
+		registry.EnableRawAPI()
+	}
+	if cfg.AccountType == config.AccountTypeEnterprise {
+		registry.EnableEnterpriseMode()
+	}
+	if len(cfg.Profiles) > 0 {
+		registry.EnableTeams()
+	}
+	if cfg.RequireConfirmation {
+		registry.EnableConfirmation()
+	}
+	if cfg.AllowDestructiveAppOperations {
+		registry.AllowDestructiveAppOperations()
+	}
+
+	logLevel := cfg.LogLevel
+	if logLevel == "" {
+		logLevel = defaultLogLevel
+	}
+
+	promptRegistry := prompts.NewRegistry(client)
+	promptRegistry.SetToolNaming(registry.PublicToolName)
 
 	return &Server{
-		cfg:      cfg,
-		client:   client,
-		reader:   bufio.NewReader(r),
-		writer:   w,
-		registry: registry,
+		cfg:           cfg,
+		client:        client,
+		reader:        bufio.NewReader(r),
+		writer:        w,
+		registry:      registry,
+		resources:     resources.NewRegistry(client),
+		prompts:       promptRegistry,
+		completions:   completions.NewProvider(client),
+		subscriptions: make(map[string]bool),
+		calls:         make(map[string]context.CancelFunc),
+		logLevel:      logLevel,
+		pending:       make(map[string]chan mcp.ClientResponse),
+		audit:         auditLog,
+		sessionID:     newSessionID(),
+		instructions:  &instructionsCache{},
+		metrics:       m,
+		tracer:        tracer,
 	}, nil
 }
 
+// ProbeCapabilities checks the roles of the API key and hides the tools it
+// can't use, as well as those of teams selected later.
+func (s *Server) ProbeCapabilities(ctx context.Context) {
+	s.registry.ProbeCapabilities(ctx)
+}
+
+// newClient creates an API client for the configured credentials. With
+// additional profiles, every profile becomes a team and the default is
+// selected. Requests pass through middlewares, in order.
+func newClient(cfg *config.Config, middlewares ...api.Middleware) (*api.Client, error) {
+	defaultProvider, err := newTokenProvider(cfg.AllProfiles()[0])
+	if err != nil {
+		return nil, err
+	}
+	defaultProvider.SetRefreshBuffer(cfg.TokenRefreshBuffer)
+
+	transportOpts, err := api.TransportOptions(cfg.ProxyURL, cfg.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	requestTimeouts := make(map[api.RequestCategory]time.Duration, len(cfg.RequestTimeouts))
+	for category, timeout := range cfg.RequestTimeouts {
+		requestTimeouts[api.RequestCategory(category)] = timeout
+	}
+
+	cacheOpt := api.WithResponseCache()
+	if cfg.CacheDir != "" {
+		cacheOpt = api.WithResponseCacheDir(cfg.CacheDir)
+	}
+
+	retryPolicy := api.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = cfg.MaxAttempts
+	opts := append(transportOpts,
+		api.WithBaseURL(cfg.BaseURL),
+		api.WithUserAgent(api.UserAgent(serverVersion, cfg.UserAgentTag)),
+		cacheOpt,
+		api.WithRequestTimeouts(requestTimeouts),
+		api.WithRateLimitWait(cfg.RateLimitWait),
+		api.WithRetryPolicy(retryPolicy),
+		api.WithPageConcurrency(cfg.PageConcurrency),
+		api.WithMaxIdleConnsPerHost(cfg.MaxIdleConnsPerHost),
+		api.WithIdleConnTimeout(cfg.IdleConnTimeout),
+	)
+	if !cfg.HTTP2 {
+		opts = append(opts, api.WithHTTP2(false))
+	}
+	if cfg.StrictDecoding {
+		opts = append(opts, api.WithStrictDecoding())
+	}
+	if len(middlewares) > 0 {
+		opts = append(opts, api.WithMiddleware(middlewares...))
+	}
+	client := api.NewClientWithTokenProvider(defaultProvider, opts...)
+	if len(cfg.Profiles) == 0 {
+		return client, nil
+	}
+
+	client.AddTeam(config.DefaultProfile, api.Team{TokenProvider: defaultProvider, BaseURL: cfg.BaseURL})
+	for _, profile := range cfg.Profiles {
+		tokenProvider, err := newTokenProvider(profile)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+		}
+		tokenProvider.SetRefreshBuffer(cfg.TokenRefreshBuffer)
+		client.AddTeam(profile.Name, api.Team{TokenProvider: tokenProvider, BaseURL: profile.BaseURL})
+	}
+
+	return client, nil
+}
+
+// newTokenProvider creates a token provider for a profile's key file or inline key.
+func newTokenProvider(profile config.Profile) (*api.TokenProvider, error) {
+	keyData, err := profile.KeyData()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token provider: %w", err)
+	}
+	tokenProvider, err := api.NewTokenProviderFromKey(profile.IssuerID, profile.KeyID, keyData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token provider: %w", err)
+	}
+	return tokenProvider, nil
+}
+
 // Run starts the MCP server and processes requests.
 func (s *Server) Run() error {
 	log.Printf("MCP server %s v%s starting", serverName, serverVersion)
 
+	stopToolsChanged := s.registry.OnToolsChanged(func() {
+		s.sendNotification("notifications/tools/list_changed", nil)
+	})
+	defer stopToolsChanged()
+
 	for {
 		line, err := s.reader.ReadBytes('\n')
 		if err != nil {
 			if err == io.EOF {
 				log.Printf("client disconnected")
+				s.callsWG.Wait()
 				return nil
 			}
 			return fmt.Errorf("failed to read request: %w", err)
@@ -73,6 +284,12 @@ func (s *Server) Run() error {
 			continue
 		}
 
+		// A message with an ID but no method answers a request the server sent.
+		if req.Method == "" && len(req.ID) > 0 {
+			s.handleResponse(line)
+			continue
+		}
+
 		s.handleRequest(&req)
 	}
 }
@@ -90,10 +307,30 @@ func (s *Server) handleRequest(req *mcp.Request) {
 	case "notifications/initialized":
 		// Client notification, no response needed
 		log.Printf("client initialized")
+	case "notifications/cancelled":
+		s.handleCancelled(req)
 	case "tools/list":
 		s.handleToolsList(req)
 	case "tools/call":
 		s.handleToolsCall(req)
+	case "resources/list":
+		s.handleResourcesList(req)
+	case "resources/templates/list":
+		s.handleResourceTemplatesList(req)
+	case "resources/read":
+		s.handleResourcesRead(req)
+	case "resources/subscribe":
+		s.handleResourcesSubscribe(req, true)
+	case "resources/unsubscribe":
+		s.handleResourcesSubscribe(req, false)
+	case "prompts/list":
+		s.handlePromptsList(req)
+	case "prompts/get":
+		s.handlePromptsGet(req)
+	case "logging/setLevel":
+		s.handleSetLevel(req)
+	case "completion/complete":
+		s.handleComplete(req)
 	default:
 		s.sendError(req.ID, mcp.ErrCodeMethodNotFound, "Method not found", req.Method)
 	}
@@ -115,16 +352,27 @@ func (s *Server) handleInitialize(req *mcp.Request) {
 		ProtocolVersion: mcp.ProtocolVersion,
 		Capabilities: mcp.ServerCapability{
 			Tools: &mcp.ToolsCapability{
+				ListChanged: true,
+			},
+			Resources: &mcp.ResourcesCapability{
+				Subscribe: true,
+			},
+			Prompts: &mcp.PromptsCapability{
 				ListChanged: false,
 			},
+			Logging:     &mcp.LoggingCapability{},
+			Completions: &mcp.CompletionsCapability{},
 		},
 		ServerInfo: mcp.ServerInfo{
 			Name:    serverName,
 			Version: serverVersion,
 		},
+		Instructions: s.serverInstructions(),
 	}
 
 	s.initialized = true
+	s.clientName = params.ClientInfo.Name
+	s.elicitation = params.Capabilities.Elicitation != nil
 	s.sendResult(req.ID, result)
 }
 
@@ -155,15 +403,257 @@ func (s *Server) handleToolsCall(req *mcp.Request) {
 		return
 	}
 
-	result, err := s.registry.CallTool(params.Name, params.Arguments)
+	var progress tools.ProgressFunc
+	if params.Meta != nil && len(params.Meta.ProgressToken) > 0 {
+		progress = s.progressNotifier(params.Meta.ProgressToken)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.callsMu.Lock()
+	s.calls[string(req.ID)] = cancel
+	s.callsMu.Unlock()
+
+	s.callsWG.Add(1)
+	go func() {
+		defer s.callsWG.Done()
+		defer s.finishCall(req.ID, cancel)
+		s.runToolCall(ctx, req.ID, params, progress)
+	}()
+}
+
+// runToolCall executes a tool and sends its result. Cancelled calls get no
+// response, as the client has already abandoned the request.
+func (s *Server) runToolCall(ctx context.Context, id json.RawMessage, params mcp.ToolsCallParams, progress tools.ProgressFunc) {
+	finished := s.logToolCall(id, params.Name)
+	ctx = api.WithResponseObserver(ctx, s.apiLogger(id, params.Name))
+	if s.elicitation {
+		ctx = tools.WithElicitation(ctx, s.elicit)
+	}
+	ctx, audited := s.auditToolCall(ctx, id, params)
+	ctx, traced := s.traceToolCall(ctx, params)
+
+	start := time.Now()
+	result, err := s.registry.CallToolWithProgress(ctx, params.Name, params.Arguments, progress)
+	audited(result, err)
+	traced(result, err)
+	s.observeToolCall(ctx, params.Name, result, err, time.Since(start))
+	if ctx.Err() != nil {
+		log.Printf("tool call %s (%s) cancelled", id, params.Name)
+		return
+	}
+	if err != nil {
+		finished(true)
+		s.sendToolError(id, err)
+		return
+	}
+
+	finished(result.IsError)
+
+	s.sendResult(id, result)
+
+	if !result.IsError && !tools.IsReadOnlyTool(s.registry.ResolveToolName(params.Name)) {
+		s.notifySubscribers()
+	}
+}
+
+// sendToolError answers a tool call that failed with err. Calls to unknown
+// tools and calls whose arguments can't be decoded are protocol errors. Any
+// other failure is a tool result error, classified in its _meta, so the model
+// can see it and correct its arguments.
+func (s *Server) sendToolError(id json.RawMessage, err error) {
+	if errors.Is(err, tools.ErrUnknownTool) || tools.IsArgumentDecodeError(err) {
+		s.sendError(id, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	result := mcp.NewErrorResult(err.Error())
+	result.Meta = map[string]any{mcp.MetaError: tools.ClassifyError(err)}
+	s.sendResult(id, result)
+}
+
+// finishCall releases an in-flight tool call.
+func (s *Server) finishCall(id json.RawMessage, cancel context.CancelFunc) {
+	s.callsMu.Lock()
+	delete(s.calls, string(id))
+	s.callsMu.Unlock()
+	cancel()
+}
+
+// handleCancelled handles the notifications/cancelled notification by
+// cancelling the in-flight tool call it names. Unknown IDs are ignored, since
+// the call may already have finished.
+func (s *Server) handleCancelled(req *mcp.Request) {
+	var params mcp.CancelledParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		log.Printf("invalid cancellation: %v", err)
+		return
+	}
+
+	s.callsMu.Lock()
+	cancel, ok := s.calls[string(params.RequestID)]
+	s.callsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	log.Printf("cancelling request %s: %s", params.RequestID, params.Reason)
+	cancel()
+}
+
+// progressNotifier returns a progress sink that emits notifications/progress for a token.
+func (s *Server) progressNotifier(token json.RawMessage) tools.ProgressFunc {
+	return func(progress, total float64, message string) {
+		s.sendNotification("notifications/progress", mcp.ProgressParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		})
+	}
+}
+
+// handleResourcesList handles the resources/list request.
+func (s *Server) handleResourcesList(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	result := mcp.ResourcesListResult{
+		Resources: s.resources.ListResources(context.Background()),
+	}
+
+	s.sendResult(req.ID, result)
+}
+
+// handleResourceTemplatesList handles the resources/templates/list request.
+func (s *Server) handleResourceTemplatesList(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	result := mcp.ResourceTemplatesListResult{
+		ResourceTemplates: s.resources.ListTemplates(),
+	}
+
+	s.sendResult(req.ID, result)
+}
+
+// handleResourcesRead handles the resources/read request.
+func (s *Server) handleResourcesRead(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	var params mcp.ResourcesReadParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	result, err := s.resources.ReadResource(context.Background(), params.URI)
 	if err != nil {
-		s.sendResult(req.ID, mcp.NewErrorResult(err.Error()))
+		if errors.Is(err, resources.ErrNotFound) {
+			s.sendError(req.ID, mcp.ErrCodeResourceNotFound, "Resource not found", params.URI)
+			return
+		}
+		s.sendError(req.ID, mcp.ErrCodeInternal, "Failed to read resource", err.Error())
 		return
 	}
 
 	s.sendResult(req.ID, result)
 }
 
+// handleResourcesSubscribe handles resources/subscribe and resources/unsubscribe.
+func (s *Server) handleResourcesSubscribe(req *mcp.Request, subscribe bool) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	var params mcp.ResourcesSubscribeParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	if params.URI == "" {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", "uri is required")
+		return
+	}
+
+	s.subscriptionsMu.Lock()
+	if subscribe {
+		s.subscriptions[params.URI] = true
+	} else {
+		delete(s.subscriptions, params.URI)
+	}
+	s.subscriptionsMu.Unlock()
+
+	s.sendResult(req.ID, struct{}{})
+}
+
+// handlePromptsList handles the prompts/list request.
+func (s *Server) handlePromptsList(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	result := mcp.PromptsListResult{
+		Prompts: s.prompts.ListPrompts(),
+	}
+
+	s.sendResult(req.ID, result)
+}
+
+// handlePromptsGet handles the prompts/get request.
+func (s *Server) handlePromptsGet(req *mcp.Request) {
+	if !s.initialized {
+		s.sendError(req.ID, mcp.ErrCodeInvalidRequest, "Not initialized", "initialize must be called first")
+		return
+	}
+
+	var params mcp.PromptsGetParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+		return
+	}
+
+	result, err := s.prompts.GetPrompt(context.Background(), params.Name, params.Arguments)
+	if err != nil {
+		if errors.Is(err, prompts.ErrNotFound) {
+			s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Unknown prompt", params.Name)
+			return
+		}
+		if errors.Is(err, prompts.ErrInvalidArgument) {
+			s.sendError(req.ID, mcp.ErrCodeInvalidParams, "Invalid params", err.Error())
+			return
+		}
+		s.sendError(req.ID, mcp.ErrCodeInternal, "Failed to get prompt", err.Error())
+		return
+	}
+
+	s.sendResult(req.ID, result)
+}
+
+// notifySubscribers tells the client that subscribed resources may have changed.
+// Mutating tools don't report which resources they touched, so every subscription is notified.
+func (s *Server) notifySubscribers() {
+	s.subscriptionsMu.Lock()
+	uris := make([]string, 0, len(s.subscriptions))
+	for uri := range s.subscriptions {
+		uris = append(uris, uri)
+	}
+	s.subscriptionsMu.Unlock()
+
+	for _, uri := range uris {
+		s.sendNotification("notifications/resources/updated", mcp.ResourceUpdatedParams{URI: uri})
+	}
+}
+
 // sendResult sends a successful response.
 func (s *Server) sendResult(id json.RawMessage, result any) {
 	resp := mcp.Response{
@@ -188,12 +678,21 @@ func (s *Server) sendError(id json.RawMessage, code int, message, data string) {
 	s.send(resp)
 }
 
-// send writes a response to the output.
-func (s *Server) send(resp mcp.Response) {
+// sendNotification sends a server-initiated notification.
+func (s *Server) sendNotification(method string, params any) {
+	s.send(mcp.Notification{
+		JSONRPC: mcp.JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// send writes a message to the output.
+func (s *Server) send(msg any) {
 	s.writeMu.Lock()
 	defer s.writeMu.Unlock()
 
-	data, err := json.Marshal(resp)
+	data, err := json.Marshal(msg)
 	if err != nil {
 		log.Printf("failed to marshal response: %v", err)
 		return
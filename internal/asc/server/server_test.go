@@ -2,19 +2,28 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"fmt"
 	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/config"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
@@ -213,9 +222,9 @@ func TestServer_HandleToolsList(t *testing.T) {
 		t.Error("expected tools to be returned")
 	}
 
-	// Should have 200 tools
-	if len(result.Tools) != 200 {
-		t.Errorf("expected 200 tools, got %d", len(result.Tools))
+	// Should have 239 tools
+	if len(result.Tools) != 239 {
+		t.Errorf("expected 239 tools, got %d", len(result.Tools))
 	}
 }
 
@@ -492,6 +501,349 @@ func TestServer_ConcurrentWrites(t *testing.T) {
 	}
 }
 
+func TestServer_HandleResourceTemplatesList(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.initialized = true
+
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`1`),
+		Method:  "resources/templates/list",
+	})
+
+	var resp mcp.Response
+	if err := json.NewDecoder(output).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	resultJSON, _ := json.Marshal(resp.Result)
+	var result mcp.ResourceTemplatesListResult
+	if err := json.Unmarshal(resultJSON, &result); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+
+	if len(result.ResourceTemplates) == 0 {
+		t.Error("expected resource templates to be returned")
+	}
+}
+
+func TestServer_HandleResourcesRead_NotFound(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.initialized = true
+
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`1`),
+		Method:  "resources/read",
+		Params:  json.RawMessage(`{"uri": "asc://nothing/here"}`),
+	})
+
+	var resp mcp.Response
+	if err := json.NewDecoder(output).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Error == nil {
+		t.Fatal("expected error for unknown resource")
+	}
+	if resp.Error.Code != mcp.ErrCodeResourceNotFound {
+		t.Errorf("Error.Code = %d, want %d", resp.Error.Code, mcp.ErrCodeResourceNotFound)
+	}
+}
+
+func TestServer_ResourcesSubscribe(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.initialized = true
+
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`1`),
+		Method:  "resources/subscribe",
+		Params:  json.RawMessage(`{"uri": "asc://apps/123"}`),
+	})
+
+	if !server.subscriptions["asc://apps/123"] {
+		t.Fatal("expected subscription to be recorded")
+	}
+
+	output.Reset()
+	server.notifySubscribers()
+	if !strings.Contains(output.String(), "notifications/resources/updated") {
+		t.Errorf("expected resource updated notification, got %q", output.String())
+	}
+
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`2`),
+		Method:  "resources/unsubscribe",
+		Params:  json.RawMessage(`{"uri": "asc://apps/123"}`),
+	})
+
+	if server.subscriptions["asc://apps/123"] {
+		t.Error("expected subscription to be removed")
+	}
+}
+
+func TestServer_ProgressNotifier(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	notify := server.progressNotifier(json.RawMessage(`"tok-1"`))
+	notify(30, 600, "PROCESSING")
+
+	var n struct {
+		Method string             `json:"method"`
+		Params mcp.ProgressParams `json:"params"`
+	}
+	if err := json.NewDecoder(output).Decode(&n); err != nil {
+		t.Fatalf("failed to decode notification: %v", err)
+	}
+
+	if n.Method != "notifications/progress" {
+		t.Errorf("Method = %q, want notifications/progress", n.Method)
+	}
+	if string(n.Params.ProgressToken) != `"tok-1"` {
+		t.Errorf("ProgressToken = %s, want \"tok-1\"", n.Params.ProgressToken)
+	}
+	if n.Params.Progress != 30 || n.Params.Total != 600 {
+		t.Errorf("progress = %v/%v, want 30/600", n.Params.Progress, n.Params.Total)
+	}
+}
+
+func TestNew_Profiles(t *testing.T) {
+	cfg := testSetup(t)
+	cfg.Profiles = []config.Profile{
+		{Name: "acme", IssuerID: "acme-issuer", KeyID: "ACMEKEY123", PrivateKeyPath: cfg.PrivateKeyPath},
+	}
+
+	server, err := New(cfg, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	if got := server.client.Teams(); len(got) != 2 || got[0] != "acme" || got[1] != config.DefaultProfile {
+		t.Errorf("Teams() = %v, want [acme default]", got)
+	}
+	if server.client.ActiveTeam() != config.DefaultProfile {
+		t.Errorf("ActiveTeam() = %q, want %s", server.client.ActiveTeam(), config.DefaultProfile)
+	}
+
+	var hasSelectTeam bool
+	for _, tool := range server.registry.ListTools() {
+		if tool.Name == "select_team" {
+			hasSelectTeam = true
+		}
+	}
+	if !hasSelectTeam {
+		t.Error("select_team should be registered when profiles are configured")
+	}
+
+	cfg.Profiles[0].PrivateKeyPath = "/nonexistent/key.p8"
+	if _, err := New(cfg, &bytes.Buffer{}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "profile acme") {
+		t.Errorf("err = %v, want profile acme error", err)
+	}
+}
+
+func TestServer_Logging(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// The default level is info, so debug events are dropped.
+	server.logMessage("debug", "api", "dropped")
+	server.logMessage("info", "tools", "kept")
+
+	var n struct {
+		Method string               `json:"method"`
+		Params mcp.LogMessageParams `json:"params"`
+	}
+	decoder := json.NewDecoder(output)
+	if err := decoder.Decode(&n); err != nil {
+		t.Fatalf("failed to decode notification: %v", err)
+	}
+	if n.Method != "notifications/message" || n.Params.Level != "info" || n.Params.Data != "kept" {
+		t.Errorf("notification = %+v", n)
+	}
+	if decoder.More() {
+		t.Error("expected only one notification")
+	}
+
+	output.Reset()
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`1`),
+		Method:  "logging/setLevel",
+		Params:  json.RawMessage(`{"level": "warning"}`),
+	})
+	var resp mcp.Response
+	if err := json.NewDecoder(output).Decode(&resp); err != nil || resp.Error != nil {
+		t.Fatalf("setLevel failed: %v %+v", err, resp.Error)
+	}
+
+	output.Reset()
+	observe := server.apiLogger(json.RawMessage(`2`), "list_apps")
+	observe(api.Response{Method: "GET", Path: "/v1/apps", StatusCode: 200, RateLimit: &api.RateLimit{Limit: 3600, Remaining: 3000}})
+	if output.Len() != 0 {
+		t.Errorf("successful response should be below warning, got %s", output.String())
+	}
+	observe(api.Response{Method: "GET", Path: "/v1/apps", StatusCode: 200, RateLimit: &api.RateLimit{Limit: 3600, Remaining: 100}})
+	if !strings.Contains(output.String(), `"rateLimitRemaining":100`) {
+		t.Errorf("expected low rate limit warning, got %s", output.String())
+	}
+
+	output.Reset()
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`3`),
+		Method:  "logging/setLevel",
+		Params:  json.RawMessage(`{"level": "loud"}`),
+	})
+	if err := json.NewDecoder(output).Decode(&resp); err != nil || resp.Error == nil {
+		t.Errorf("expected error for unknown level, got %+v", resp)
+	}
+}
+
+func TestServer_HandleComplete(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.initialized = true
+
+	tests := []struct {
+		name    string
+		params  string
+		want    []string
+		errCode int
+	}{
+		{
+			name:   "tool platform",
+			params: `{"ref": {"type": "ref/tool", "name": "list_builds"}, "argument": {"name": "platform", "value": "ma"}}`,
+			want:   []string{"MAC_OS"},
+		},
+		{
+			name:   "prompt locale",
+			params: `{"ref": {"type": "ref/prompt", "name": "prepare_app_store_submission"}, "argument": {"name": "locale", "value": "zh"}}`,
+			want:   []string{"zh-Hans", "zh-Hant"},
+		},
+		{
+			name:   "unrelated argument",
+			params: `{"ref": {"type": "ref/tool", "name": "list_builds"}, "argument": {"name": "limit", "value": "1"}}`,
+			want:   []string{},
+		},
+		{
+			name:    "unknown prompt",
+			params:  `{"ref": {"type": "ref/prompt", "name": "nope"}, "argument": {"name": "locale", "value": ""}}`,
+			errCode: mcp.ErrCodeInvalidParams,
+		},
+		{
+			name:    "unknown reference type",
+			params:  `{"ref": {"type": "ref/other"}, "argument": {"name": "locale", "value": ""}}`,
+			errCode: mcp.ErrCodeInvalidParams,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output.Reset()
+			server.handleRequest(&mcp.Request{
+				JSONRPC: mcp.JSONRPCVersion,
+				ID:      json.RawMessage(`1`),
+				Method:  "completion/complete",
+				Params:  json.RawMessage(tt.params),
+			})
+
+			var resp struct {
+				Result mcp.CompleteResult `json:"result"`
+				Error  *mcp.RPCError      `json:"error"`
+			}
+			if err := json.NewDecoder(output).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if tt.errCode != 0 {
+				if resp.Error == nil || resp.Error.Code != tt.errCode {
+					t.Errorf("Error = %+v, want code %d", resp.Error, tt.errCode)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %+v", resp.Error)
+			}
+			if strings.Join(resp.Result.Completion.Values, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Values = %v, want %v", resp.Result.Completion.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_HandleCancelled(t *testing.T) {
+	cfg := testSetup(t)
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server.calls["7"] = cancel
+
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		Method:  "notifications/cancelled",
+		Params:  json.RawMessage(`{"requestId": 7, "reason": "user aborted"}`),
+	})
+
+	if ctx.Err() == nil {
+		t.Error("expected in-flight call to be cancelled")
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected no response to a notification, got %s", output.String())
+	}
+
+	// Cancelling an unknown or finished request is a no-op.
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		Method:  "notifications/cancelled",
+		Params:  json.RawMessage(`{"requestId": 8}`),
+	})
+}
+
 func TestServer_NotificationsInitialized(t *testing.T) {
 	cfg := testSetup(t)
 
@@ -608,3 +960,592 @@ func BenchmarkServer_SendResult(b *testing.B) {
 		server.sendResult(json.RawMessage(`1`), result)
 	}
 }
+
+func TestServer_ServeUnix(t *testing.T) {
+	cfg := testSetup(t)
+
+	server, err := New(cfg, bytes.NewReader(nil), io.Discard)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// Socket paths are limited to about 100 bytes, which t.TempDir can exceed.
+	dir, err := os.MkdirTemp("", "asc")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mcp.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.ServeUnix(ctx, path) }()
+
+	var conn net.Conn
+	for i := 0; i < 100; i++ {
+		if conn, err = net.Dial("unix", path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	conn.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {}}` + "\n"))
+	var resp mcp.Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error != nil || string(resp.ID) != "1" {
+		t.Errorf("initialize response = %+v", resp)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("ServeUnix returned %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file still exists after shutdown: %v", err)
+	}
+}
+
+func TestServer_ElicitMissingArgument(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps":
+			w.Write([]byte(`{"data": [{"type": "apps", "id": "123", "attributes": {"name": "Weather"}}]}`))
+		case "/v1/apps/123":
+			w.Write([]byte(`{"data": {"type": "apps", "id": "123", "attributes": {"name": "Weather"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+
+	inputReader, inputWriter := io.Pipe()
+	outputReader, outputWriter := io.Pipe()
+	server, err := New(cfg, inputReader, outputWriter)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	go server.Run()
+	defer inputWriter.Close()
+
+	decoder := json.NewDecoder(outputReader)
+	write := func(msg string) {
+		if _, err := inputWriter.Write([]byte(msg + "\n")); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+	}
+	read := func() map[string]json.RawMessage {
+		var msg map[string]json.RawMessage
+		if err := decoder.Decode(&msg); err != nil {
+			t.Fatalf("failed to decode message: %v", err)
+		}
+		return msg
+	}
+
+	write(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"capabilities": {"elicitation": {}}}}`)
+	read()
+	write(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "get_app", "arguments": {}}}`)
+
+	// Skip log notifications until the elicitation request arrives.
+	request := read()
+	for string(request["method"]) == `"notifications/message"` {
+		request = read()
+	}
+	if method := string(request["method"]); method != `"elicitation/create"` {
+		t.Fatalf("expected elicitation/create request, got %s", method)
+	}
+	if !strings.Contains(string(request["params"]), `"app_id"`) {
+		t.Errorf("elicitation params = %s", request["params"])
+	}
+	write(`{"jsonrpc": "2.0", "id": ` + string(request["id"]) + `, "result": {"action": "accept", "content": {"app_id": "123"}}}`)
+
+	// Skip log notifications until the tool call's response arrives.
+	for {
+		msg := read()
+		if string(msg["id"]) != "2" {
+			continue
+		}
+		var result mcp.ToolsCallResult
+		if err := json.Unmarshal(msg["result"], &result); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		if result.IsError || !strings.Contains(result.Content[0].Text, "Weather") {
+			t.Errorf("result = %+v", result)
+		}
+		break
+	}
+}
+
+func TestServer_AuditLog(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/apps/123" {
+			w.Write([]byte(`{"data": {"type": "apps", "id": "123", "attributes": {"name": "Weather"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+	cfg.AuditLogPath = filepath.Join(t.TempDir(), "audit.jsonl")
+
+	server, err := New(cfg, &bytes.Buffer{}, io.Discard)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.clientName = "test-client"
+
+	server.runToolCall(context.Background(), json.RawMessage(`1`), mcp.ToolsCallParams{
+		Name:      "get_app",
+		Arguments: json.RawMessage(`{"app_id": "123", "api_token": "secret"}`),
+	}, nil)
+	server.runToolCall(context.Background(), json.RawMessage(`2`), mcp.ToolsCallParams{
+		Name:      "get_app",
+		Arguments: json.RawMessage(`{"app_id": "404"}`),
+	}, nil)
+
+	data, err := os.ReadFile(cfg.AuditLogPath)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d audit entries, want 2:\n%s", len(lines), data)
+	}
+
+	var entries [2]struct {
+		Session   string            `json:"session"`
+		Client    string            `json:"client"`
+		Tool      string            `json:"tool"`
+		Arguments map[string]string `json:"arguments"`
+		Status    string            `json:"status"`
+		Error     string            `json:"error"`
+		APICalls  []struct {
+			Method string `json:"method"`
+			Path   string `json:"path"`
+			Status int    `json:"status"`
+		} `json:"apiCalls"`
+	}
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &entries[i]); err != nil {
+			t.Fatalf("invalid audit entry %q: %v", line, err)
+		}
+	}
+
+	ok := entries[0]
+	if ok.Tool != "get_app" || ok.Status != "ok" || ok.Client != "test-client" || ok.Session != server.sessionID {
+		t.Errorf("entry = %+v", ok)
+	}
+	if ok.Arguments["app_id"] != "123" || ok.Arguments["api_token"] != "[REDACTED]" {
+		t.Errorf("arguments = %v", ok.Arguments)
+	}
+	if len(ok.APICalls) != 1 || ok.APICalls[0].Path != "/v1/apps/123" || ok.APICalls[0].Status != 200 {
+		t.Errorf("apiCalls = %+v", ok.APICalls)
+	}
+
+	failed := entries[1]
+	if failed.Status != "error" || failed.Error == "" || len(failed.APICalls) != 1 || failed.APICalls[0].Status != 404 {
+		t.Errorf("entry = %+v", failed)
+	}
+}
+
+func TestServer_Metrics(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/apps/123" {
+			w.Write([]byte(`{"data": {"type": "apps", "id": "123", "attributes": {"name": "Weather"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+	cfg.MetricsAddr = "127.0.0.1:0"
+
+	server, err := New(cfg, &bytes.Buffer{}, io.Discard)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	for i, args := range []string{`{"app_id": "123"}`, `{"app_id": "404"}`} {
+		server.runToolCall(context.Background(), json.RawMessage(strconv.Itoa(i+1)), mcp.ToolsCallParams{
+			Name:      "get_app",
+			Arguments: json.RawMessage(args),
+		}, nil)
+	}
+	server.runToolCall(context.Background(), json.RawMessage(`3`), mcp.ToolsCallParams{Name: "no_such_tool"}, nil)
+
+	var sb strings.Builder
+	server.metrics.WriteTo(&sb)
+	text := sb.String()
+	for _, want := range []string{
+		`asc_tool_calls_total{tool="get_app",outcome="ok"} 1`,
+		`asc_tool_calls_total{tool="get_app",outcome="error"} 1`,
+		`asc_api_requests_total{method="GET",endpoint="/v1/apps/{id}",status="404"} 1`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "no_such_tool") {
+		t.Errorf("metrics count a call to an unknown tool:\n%s", text)
+	}
+}
+
+func TestServer_Tracing(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"type": "apps", "id": "123", "attributes": {"name": "Weather"}}}`))
+	}))
+	defer apiServer.Close()
+
+	type span struct {
+		Name         string `json:"name"`
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+	}
+	var mu sync.Mutex
+	spans := map[string]span{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []span `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}))
+	defer collector.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+	cfg.TracesEndpoint = collector.URL + "/v1/traces"
+
+	server, err := New(cfg, &bytes.Buffer{}, io.Discard)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	server.runToolCall(context.Background(), json.RawMessage(`1`), mcp.ToolsCallParams{
+		Name:      "get_app",
+		Arguments: json.RawMessage(`{"app_id": "123"}`),
+		Meta:      &mcp.RequestMeta{TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}, nil)
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	call, request := spans["tools/call get_app"], spans["GET /v1/apps/{id}"]
+	if call.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || call.ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("tool call span = %+v", call)
+	}
+	if request.TraceID != call.TraceID || request.ParentSpanID != call.SpanID {
+		t.Errorf("request span = %+v, want a child of %s", request, call.SpanID)
+	}
+}
+
+func TestServer_Instructions(t *testing.T) {
+	var mu sync.Mutex
+	apps := `{"type": "apps", "id": "123", "attributes": {"name": "Weather", "bundleId": "com.example.weather"}}`
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/apps" {
+			w.Write([]byte(`{"data": [` + apps + `]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+
+	output := &bytes.Buffer{}
+	server, err := New(cfg, &bytes.Buffer{}, output)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// Before the account is summarized, the instructions still explain IDs.
+	instructions := server.serverInstructions()
+	if !strings.Contains(instructions, "App IDs are numeric Apple IDs") || strings.Contains(instructions, "The account has") {
+		t.Errorf("instructions without summary = %s", instructions)
+	}
+
+	if err := server.RefreshInstructions(context.Background()); err != nil {
+		t.Fatalf("RefreshInstructions failed: %v", err)
+	}
+	server.handleRequest(&mcp.Request{
+		JSONRPC: mcp.JSONRPCVersion,
+		ID:      json.RawMessage(`1`),
+		Method:  "initialize",
+		Params:  json.RawMessage(`{"protocolVersion": "2024-11-05", "capabilities": {}, "clientInfo": {"name": "test-client", "version": "1.0.0"}}`),
+	})
+	var resp struct {
+		Result mcp.InitializeResult `json:"result"`
+	}
+	if err := json.NewDecoder(output).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, want := range []string{"Team: default", "The account has 1 app:", "- Weather (app ID 123, bundle ID com.example.weather)"} {
+		if !strings.Contains(resp.Result.Instructions, want) {
+			t.Errorf("instructions missing %q:\n%s", want, resp.Result.Instructions)
+		}
+	}
+
+	// A stale summary is served while it is refreshed in the background.
+	mu.Lock()
+	apps += `, {"type": "apps", "id": "456", "attributes": {"name": "Tides", "bundleId": "com.example.tides"}}`
+	mu.Unlock()
+	server.instructions.mu.Lock()
+	server.instructions.generated = time.Now().Add(-2 * instructionsTTL)
+	server.instructions.mu.Unlock()
+
+	if instructions := server.serverInstructions(); !strings.Contains(instructions, "The account has 1 app:") {
+		t.Errorf("stale instructions = %s", instructions)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(server.serverInstructions(), "The account has 2 apps:") {
+		if time.Now().After(deadline) {
+			t.Fatalf("instructions not refreshed: %s", server.serverInstructions())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_InstructionsRetry(t *testing.T) {
+	var mu sync.Mutex
+	failing := true
+	requests := 0
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if failing {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": [{"status": "403", "title": "Forbidden"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"type": "apps", "id": "123", "attributes": {"name": "Weather", "bundleId": "com.example.weather"}}]}`))
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+
+	server, err := New(cfg, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// A failed refresh is retried, but not before its backoff.
+	if err := server.RefreshInstructions(context.Background()); err == nil {
+		t.Fatal("RefreshInstructions should fail")
+	}
+	server.instructions.mu.Lock()
+	retryAt := server.instructions.retryAt
+	server.instructions.mu.Unlock()
+	if wait := time.Until(retryAt); wait <= 0 || wait > instructionsRetryDelay {
+		t.Errorf("retry in %s, want within %s", wait, instructionsRetryDelay)
+	}
+
+	mu.Lock()
+	failing = false
+	before := requests
+	mu.Unlock()
+	server.serverInstructions()
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	if requests != before {
+		t.Errorf("refreshed %d times during the backoff", requests-before)
+	}
+	mu.Unlock()
+
+	server.instructions.mu.Lock()
+	server.instructions.retryAt = time.Now().Add(-time.Second)
+	server.instructions.mu.Unlock()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(server.serverInstructions(), "The account has 1 app:") {
+		if time.Now().After(deadline) {
+			t.Fatalf("instructions not refreshed after the backoff: %s", server.serverInstructions())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_WarmCaches(t *testing.T) {
+	defer func(interval time.Duration) { warmUpInterval = interval }(warmUpInterval)
+	warmUpInterval = time.Millisecond
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	remaining := 3000
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Rate-Limit", fmt.Sprintf("user-hour-lim:3600;user-hour-rem:%d;", remaining))
+		switch r.URL.Path {
+		case "/v1/apps":
+			w.Write([]byte(`{"data": [{"type": "apps", "id": "1", "attributes": {"name": "Weather"}}, {"type": "apps", "id": "2", "attributes": {"name": "Tides"}}]}`))
+		case "/v1/territories":
+			w.Write([]byte(`{"data": [{"type": "territories", "id": "USA", "attributes": {"currency": "USD"}}]}`))
+		case "/v1/betaGroups":
+			w.Write([]byte(`{"data": [{"type": "betaGroups", "id": "g` + r.URL.Query().Get("filter[app]") + `", "attributes": {"name": "Friends"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+	server, err := New(cfg, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	if err := server.WarmCaches(context.Background()); err != nil {
+		t.Fatalf("WarmCaches failed: %v", err)
+	}
+	want := map[string]int{"/v1/apps": 1, "/v1/territories": 1, "/v1/betaGroups": 2}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+
+	// Searching beta groups reuses the prefetched listing.
+	if _, err := server.client.ListBetaGroups(context.Background(), "2", api.ListOptions{Limit: warmUpPageSize}); err != nil {
+		t.Fatalf("ListBetaGroups failed: %v", err)
+	}
+	if requests["/v1/betaGroups"] != 2 {
+		t.Errorf("beta groups requested %d times, want 2", requests["/v1/betaGroups"])
+	}
+
+	// Warming stops when the rate limit runs low.
+	mu.Lock()
+	remaining = 100
+	mu.Unlock()
+	server.client.ListApps(api.WithRefresh(context.Background()), api.ListOptions{Limit: 1})
+	if err := server.WarmCaches(context.Background()); err == nil || !strings.Contains(err.Error(), "100 of 3600") {
+		t.Errorf("expected warm-up to stop, got %v", err)
+	}
+}
+
+func TestServer_ToolErrors(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/401":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors": [{"status": "401", "code": "NOT_AUTHORIZED", "title": "Authentication credentials are missing or invalid."}]}`))
+		case "/v1/apps/503":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors": [{"status": "503", "title": "Service Unavailable"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	cfg := testSetup(t)
+	cfg.BaseURL = apiServer.URL
+
+	tests := []struct {
+		name      string
+		tool      string
+		args      string
+		code      int
+		class     string
+		retryable bool
+	}{
+		{name: "unknown tool", tool: "no_such_tool", args: `{}`, code: mcp.ErrCodeInvalidParams},
+		{name: "undecodable arguments", tool: "get_app", args: `{"app_id": 123}`, code: mcp.ErrCodeInvalidParams},
+		{name: "missing argument", tool: "get_app", args: `{}`, class: "invalid_arguments"},
+		{name: "auth", tool: "get_app", args: `{"app_id": "401"}`, class: "auth"},
+		{name: "not found", tool: "get_app", args: `{"app_id": "404"}`, class: "not_found"},
+		{name: "transient", tool: "get_app", args: `{"app_id": "503"}`, class: "transient", retryable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			server, err := New(cfg, &bytes.Buffer{}, output)
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+
+			server.runToolCall(context.Background(), json.RawMessage(`1`), mcp.ToolsCallParams{
+				Name:      tt.tool,
+				Arguments: json.RawMessage(tt.args),
+			}, nil)
+
+			var resp struct {
+				ID     json.RawMessage `json:"id"`
+				Result *struct {
+					IsError bool `json:"isError"`
+					Meta    struct {
+						Error mcp.ToolError `json:"error"`
+					} `json:"_meta"`
+				} `json:"result"`
+				Error *mcp.RPCError `json:"error"`
+			}
+			// Skip the log notifications sent before the response.
+			decoder := json.NewDecoder(output)
+			for resp.ID == nil {
+				if err := decoder.Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+			}
+
+			if tt.code != 0 {
+				if resp.Error == nil || resp.Error.Code != tt.code {
+					t.Errorf("error = %+v, want code %d", resp.Error, tt.code)
+				}
+				return
+			}
+			if resp.Error != nil || resp.Result == nil || !resp.Result.IsError {
+				t.Fatalf("response = %+v, want an error result", resp)
+			}
+			if got := resp.Result.Meta.Error; got.Class != tt.class || got.Retryable != tt.retryable {
+				t.Errorf("_meta.error = %+v, want class %s, retryable %t", got, tt.class, tt.retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/tools"
+	"github.com/antisynthesis/asc-mcp/internal/asc/tracing"
+)
+
+// traceToolCall starts the span of a tool call, continuing the client's
+// trace if the call's _meta carries one, and returns a context for the
+// call's API request spans and a function that ends the span. Arguments and
+// result text are left out of the span.
+func (s *Server) traceToolCall(ctx context.Context, params mcp.ToolsCallParams) (context.Context, func(result *mcp.ToolsCallResult, err error)) {
+	if s.tracer == nil {
+		return ctx, func(*mcp.ToolsCallResult, error) {}
+	}
+
+	if params.Meta != nil {
+		ctx = tracing.ContextWithRemoteParent(ctx, params.Meta.TraceParent, params.Meta.TraceState)
+	}
+	tool := s.registry.ResolveToolName(params.Name)
+	ctx, span := s.tracer.Start(ctx, "tools/call "+tool, tracing.SpanKindServer,
+		tracing.String("mcp.method.name", "tools/call"),
+		tracing.String("gen_ai.tool.name", tool),
+		tracing.String("mcp.session.id", s.sessionID),
+	)
+
+	return ctx, func(result *mcp.ToolsCallResult, err error) {
+		defer span.End()
+
+		var toolErr *mcp.ToolError
+		switch {
+		case ctx.Err() != nil:
+			span.SetAttributes(tracing.String("error.type", "cancelled"))
+			span.SetError("tool call cancelled")
+			return
+		case errors.Is(err, tools.ErrUnknownTool):
+			span.SetAttributes(tracing.String("error.type", "unknown_tool"))
+			span.SetError("unknown tool")
+			return
+		case err != nil:
+			toolErr = tools.ClassifyError(err)
+		case result.IsError:
+			toolErr, _ = result.Meta[mcp.MetaError].(*mcp.ToolError)
+			if toolErr == nil {
+				toolErr = &mcp.ToolError{Class: "tool_error"}
+			}
+		default:
+			return
+		}
+
+		span.SetAttributes(tracing.String("error.type", toolErr.Class))
+		if toolErr.RequestID != "" {
+			span.SetAttributes(tracing.String("asc.request_id", toolErr.RequestID))
+		}
+		span.SetError("tool call failed: " + toolErr.Class)
+	}
+}
+
+// Shutdown exports the spans not yet exported, giving up when ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.tracer.Shutdown(ctx)
+}
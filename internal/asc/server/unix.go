@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// ServeUnix listens on a Unix domain socket at path and serves each
+// connection as its own MCP session until ctx is cancelled, which also closes
+// open connections. Sessions share the API client and tool registry, so
+// caches and concurrency limits apply across them. The socket is only
+// accessible to the current user and is removed when serving stops.
+func (s *Server) ServeUnix(ctx context.Context, path string) error {
+	listener, err := listenUnix(path)
+	if err != nil {
+		return err
+	}
+
+	defer listener.Close()
+	context.AfterFunc(ctx, func() { listener.Close() })
+
+	log.Printf("MCP server %s v%s listening on %s", serverName, serverVersion, path)
+
+	var sessions sync.WaitGroup
+	defer sessions.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			defer conn.Close()
+			stop := context.AfterFunc(ctx, func() { conn.Close() })
+			defer stop()
+			if err := s.newSession(conn).Run(); err != nil {
+				log.Printf("session ended: %v", err)
+			}
+		}()
+	}
+}
+
+// listenUnix listens on a Unix domain socket. A socket file left behind by a
+// server that is no longer running is replaced.
+func listenUnix(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, fmt.Errorf("a socket path is required for the unix transport")
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket %s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Windows has no permission bits for sockets; they inherit the
+	// directory's access control list instead.
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0600); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+		}
+	}
+	return listener, nil
+}
+
+// newSession returns a server for one connection. It shares s's client,
+// registries, audit log and instructions but has its own initialization,
+// subscriptions, in-flight calls, log level and audit session ID.
+func (s *Server) newSession(conn net.Conn) *Server {
+	return &Server{
+		cfg:           s.cfg,
+		client:        s.client,
+		reader:        bufio.NewReader(conn),
+		writer:        conn,
+		registry:      s.registry,
+		resources:     s.resources,
+		prompts:       s.prompts,
+		completions:   s.completions,
+		subscriptions: make(map[string]bool),
+		calls:         make(map[string]context.CancelFunc),
+		logLevel:      s.logLevel,
+		pending:       make(map[string]chan mcp.ClientResponse),
+		audit:         s.audit,
+		sessionID:     newSessionID(),
+		instructions:  s.instructions,
+		metrics:       s.metrics,
+		tracer:        s.tracer,
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+)
+
+// warmUpInterval is the pause before each warm-up request, so warming the
+// caches never competes with tool calls for the rate limit.
+var warmUpInterval = 500 * time.Millisecond
+
+const (
+	// warmUpMaxApps caps the apps whose beta groups are prefetched.
+	warmUpMaxApps = 10
+
+	// warmUpPageSize is the page size of prefetched listings. It matches
+	// the one completion and search use, so they reuse the cached pages.
+	warmUpPageSize = 200
+)
+
+// WarmCaches prefetches the selected team's apps, territories and the beta
+// groups of its first apps into the response cache, so the first lookups of
+// a session don't wait for them. Requests are spaced warmUpInterval apart,
+// and warming stops once less than a quarter of the hourly rate limit is
+// left or ctx is done.
+func (s *Server) WarmCaches(ctx context.Context) error {
+	if err := s.warmUpStep(ctx); err != nil {
+		return err
+	}
+	if err := s.completions.Warm(ctx, "app_id"); err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	if err := s.warmUpStep(ctx); err != nil {
+		return err
+	}
+	if err := s.completions.Warm(ctx, "territory"); err != nil {
+		return fmt.Errorf("failed to list territories: %w", err)
+	}
+
+	// TestFlight resources don't exist for Enterprise (In-House) accounts.
+	if s.cfg.AccountType == config.AccountTypeEnterprise {
+		return nil
+	}
+
+	apps, err := s.client.ListApps(ctx, api.ListOptions{Limit: warmUpPageSize})
+	if err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+	for i, app := range apps.Data {
+		if i == warmUpMaxApps {
+			break
+		}
+		if err := s.warmUpStep(ctx); err != nil {
+			return err
+		}
+		if _, err := s.client.ListBetaGroups(ctx, app.ID, api.ListOptions{Limit: warmUpPageSize}); err != nil {
+			return fmt.Errorf("failed to list beta groups of app %s: %w", app.ID, err)
+		}
+	}
+	return nil
+}
+
+// warmUpStep waits warmUpInterval before the next warm-up request, and
+// returns an error if it shouldn't be sent.
+func (s *Server) warmUpStep(ctx context.Context) error {
+	timer := time.NewTimer(warmUpInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	if status := s.client.RateLimit(ctx); status != nil && status.Remaining < status.Limit/4 {
+		return fmt.Errorf("stopped with %d of %d hourly requests left", status.Remaining, status.Limit)
+	}
+	return nil
+}
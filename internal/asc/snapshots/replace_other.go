@@ -0,0 +1,10 @@
+//go:build !windows
+
+package snapshots
+
+import "os"
+
+// replaceFile renames from over to, replacing it atomically.
+func replaceFile(from, to string) error {
+	return os.Rename(from, to)
+}
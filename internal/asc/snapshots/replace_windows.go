@@ -0,0 +1,42 @@
+//go:build windows
+
+package snapshots
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	// replaceAttempts and replaceRetryDelay bound how long replaceFile waits
+	// for another process to close the snapshot file.
+	replaceAttempts   = 10
+	replaceRetryDelay = 50 * time.Millisecond
+
+	// errorSharingViolation is ERROR_SHARING_VIOLATION, returned while
+	// another process has the file open.
+	errorSharingViolation syscall.Errno = 32
+)
+
+// replaceFile renames from over to. Windows refuses while another process,
+// such as a second server reading the file or a virus scanner, has it open,
+// so the rename is retried for up to half a second.
+func replaceFile(from, to string) error {
+	var err error
+	for attempt := 0; attempt < replaceAttempts; attempt++ {
+		if err = os.Rename(from, to); err == nil || !isFileInUse(err) {
+			return err
+		}
+		time.Sleep(replaceRetryDelay)
+	}
+	return err
+}
+
+// isFileInUse reports whether err is Windows refusing access to a file
+// another process has open.
+func isFileInUse(err error) bool {
+	var errno syscall.Errno
+	return errors.As(err, &errno) && (errno == syscall.ERROR_ACCESS_DENIED || errno == errorSharingViolation)
+}
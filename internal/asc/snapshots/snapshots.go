@@ -0,0 +1,305 @@
+// Package snapshots keeps a local history of App Store Connect state that the
+// API only reports as a current value, such as App Store version states.
+package snapshots
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// VersionHistory is the observed state history of one App Store version.
+type VersionHistory struct {
+	AppID         string       `json:"appId"`
+	VersionID     string       `json:"versionId"`
+	VersionString string       `json:"versionString"`
+	Platform      string       `json:"platform,omitempty"`
+	Transitions   []Transition `json:"transitions"`
+}
+
+// Transition is a state and when it was first observed.
+type Transition struct {
+	State string    `json:"state"`
+	At    time.Time `json:"at"`
+}
+
+// State returns the most recently observed state, or "" if there is none.
+func (h VersionHistory) State() string {
+	if len(h.Transitions) == 0 {
+		return ""
+	}
+	return h.Transitions[len(h.Transitions)-1].State
+}
+
+// FirstSeen returns when the version was first observed in one of states.
+func (h VersionHistory) FirstSeen(states ...string) (time.Time, bool) {
+	for _, t := range h.Transitions {
+		for _, state := range states {
+			if t.State == state {
+				return t.At, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// ReleaseTrain is the progress of one operation applied across several apps,
+// kept so an interrupted train can resume where it stopped. WhatsNewByLocale
+// overrides WhatsNew for the locales it lists.
+type ReleaseTrain struct {
+	Name             string               `json:"name"`
+	VersionString    string               `json:"versionString"`
+	Platform         string               `json:"platform"`
+	WhatsNew         string               `json:"whatsNew,omitempty"`
+	WhatsNewByLocale map[string]string    `json:"whatsNewByLocale,omitempty"`
+	Submit           bool                 `json:"submit,omitempty"`
+	Apps             map[string]*TrainApp `json:"apps"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+}
+
+// TrainApp is one app's progress in a release train.
+type TrainApp struct {
+	VersionID string               `json:"versionId,omitempty"`
+	Steps     map[string]TrainStep `json:"steps"`
+}
+
+// TrainStep is the outcome of one step of a release train for one app.
+type TrainStep struct {
+	Done  bool      `json:"done"`
+	Error string    `json:"error,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+// AssetState is the delivery state of an uploaded asset, such as a
+// screenshot, and when the asset was first seen in it.
+type AssetState struct {
+	State string    `json:"state"`
+	Since time.Time `json:"since"`
+}
+
+// data is the snapshot file contents.
+type data struct {
+	Versions map[string]*VersionHistory `json:"versions"`
+	Trains   map[string]*ReleaseTrain   `json:"trains,omitempty"`
+	Assets   map[string]*AssetState     `json:"assets,omitempty"`
+}
+
+// Store records snapshots in a JSON file. A store without a path keeps them
+// in memory only.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu   sync.Mutex
+	data data
+}
+
+// Open loads the snapshot file at path, which is created on the first write.
+// An empty path opens an in-memory store.
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		now:  time.Now,
+		data: data{
+			Versions: make(map[string]*VersionHistory),
+			Trains:   make(map[string]*ReleaseTrain),
+			Assets:   make(map[string]*AssetState),
+		},
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	if err := json.Unmarshal(contents, &s.data); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots %s: %w", path, err)
+	}
+	if s.data.Versions == nil {
+		s.data.Versions = make(map[string]*VersionHistory)
+	}
+	if s.data.Trains == nil {
+		s.data.Trains = make(map[string]*ReleaseTrain)
+	}
+	if s.data.Assets == nil {
+		s.data.Assets = make(map[string]*AssetState)
+	}
+	return s, nil
+}
+
+// NewMemoryStore returns a store that isn't saved to disk.
+func NewMemoryStore() *Store {
+	s, _ := Open("")
+	return s
+}
+
+// RecordVersionState records a version's current state. It reports whether
+// the state differs from the last one recorded for the version.
+func (s *Store) RecordVersionState(appID, versionID, versionString, platform, state string) (bool, error) {
+	if versionID == "" || state == "" {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, ok := s.data.Versions[versionID]
+	if !ok {
+		history = &VersionHistory{VersionID: versionID}
+		s.data.Versions[versionID] = history
+	}
+	history.AppID = appID
+	history.VersionString = versionString
+	history.Platform = platform
+	if history.State() == state {
+		return false, nil
+	}
+
+	history.Transitions = append(history.Transitions, Transition{State: state, At: s.now().UTC()})
+	return true, s.save()
+}
+
+// VersionHistories returns the recorded versions of an app, or of every app
+// if appID is empty, most recently changed first.
+func (s *Store) VersionHistories(appID string) []VersionHistory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	histories := make([]VersionHistory, 0, len(s.data.Versions))
+	for _, h := range s.data.Versions {
+		if appID != "" && h.AppID != appID {
+			continue
+		}
+		history := *h
+		history.Transitions = append([]Transition(nil), h.Transitions...)
+		histories = append(histories, history)
+	}
+
+	sort.Slice(histories, func(i, j int) bool {
+		return lastChange(histories[i]).After(lastChange(histories[j]))
+	})
+	return histories
+}
+
+// ReleaseTrain returns a copy of the named release train.
+func (s *Store) ReleaseTrain(name string) (ReleaseTrain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	train, ok := s.data.Trains[name]
+	if !ok {
+		return ReleaseTrain{}, false
+	}
+	return copyTrain(train), true
+}
+
+// SaveReleaseTrain records a release train's progress, replacing any earlier
+// record with the same name.
+func (s *Store) SaveReleaseTrain(train ReleaseTrain) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := copyTrain(&train)
+	saved.UpdatedAt = s.now().UTC()
+	s.data.Trains[train.Name] = &saved
+	return s.save()
+}
+
+// RecordAssetState records an asset's current delivery state and returns
+// when the asset was first seen in it.
+func (s *Store) RecordAssetState(assetID, state string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if recorded, ok := s.data.Assets[assetID]; ok && recorded.State == state {
+		return recorded.Since, nil
+	}
+	now := s.now().UTC()
+	s.data.Assets[assetID] = &AssetState{State: state, Since: now}
+	return now, s.save()
+}
+
+// ForgetAsset removes an asset's recorded state, once it is deleted or no
+// longer needs watching.
+func (s *Store) ForgetAsset(assetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Assets[assetID]; !ok {
+		return nil
+	}
+	delete(s.data.Assets, assetID)
+	return s.save()
+}
+
+// copyTrain deep-copies a release train, so callers can't change the store's
+// record without saving it.
+func copyTrain(train *ReleaseTrain) ReleaseTrain {
+	c := *train
+	if train.WhatsNewByLocale != nil {
+		c.WhatsNewByLocale = make(map[string]string, len(train.WhatsNewByLocale))
+		for locale, notes := range train.WhatsNewByLocale {
+			c.WhatsNewByLocale[locale] = notes
+		}
+	}
+	c.Apps = make(map[string]*TrainApp, len(train.Apps))
+	for appID, app := range train.Apps {
+		steps := make(map[string]TrainStep, len(app.Steps))
+		for name, step := range app.Steps {
+			steps[name] = step
+		}
+		c.Apps[appID] = &TrainApp{VersionID: app.VersionID, Steps: steps}
+	}
+	return c
+}
+
+// lastChange returns when a version's state last changed.
+func lastChange(h VersionHistory) time.Time {
+	if len(h.Transitions) == 0 {
+		return time.Time{}
+	}
+	return h.Transitions[len(h.Transitions)-1].At
+}
+
+// save writes the snapshots to a temporary file and renames it over the old
+// one, so a crash never leaves a truncated file. The caller holds s.mu.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".snapshots-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	if err := replaceFile(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	return nil
+}
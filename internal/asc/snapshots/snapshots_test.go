@@ -0,0 +1,165 @@
+package snapshots
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_RecordVersionState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "snapshots.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	steps := []struct {
+		state   string
+		changed bool
+	}{
+		{"PREPARE_FOR_SUBMISSION", true},
+		{"PREPARE_FOR_SUBMISSION", false},
+		{"WAITING_FOR_REVIEW", true},
+		{"IN_REVIEW", true},
+	}
+	for _, step := range steps {
+		changed, err := store.RecordVersionState("app1", "v1", "1.0", "IOS", step.state)
+		if err != nil {
+			t.Fatalf("RecordVersionState failed: %v", err)
+		}
+		if changed != step.changed {
+			t.Errorf("RecordVersionState(%s) changed = %v, want %v", step.state, changed, step.changed)
+		}
+		now = now.Add(time.Hour)
+	}
+	if _, err := store.RecordVersionState("app2", "v2", "2.0", "IOS", "READY_FOR_SALE"); err != nil {
+		t.Fatalf("RecordVersionState failed: %v", err)
+	}
+
+	// History survives reopening the file.
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	histories := reopened.VersionHistories("app1")
+	if len(histories) != 1 {
+		t.Fatalf("len(histories) = %d, want 1", len(histories))
+	}
+	h := histories[0]
+	if h.VersionString != "1.0" || h.State() != "IN_REVIEW" || len(h.Transitions) != 3 {
+		t.Errorf("history = %+v", h)
+	}
+	if at, ok := h.FirstSeen("WAITING_FOR_REVIEW"); !ok || !at.Equal(time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("FirstSeen(WAITING_FOR_REVIEW) = %v, %v", at, ok)
+	}
+
+	// All apps, most recently changed first.
+	all := reopened.VersionHistories("")
+	if len(all) != 2 || all[0].VersionID != "v2" {
+		t.Errorf("VersionHistories(\"\") = %+v", all)
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("expected an error for a corrupt snapshot file")
+	}
+
+	store, err := Open("")
+	if err != nil {
+		t.Fatalf("Open(\"\") failed: %v", err)
+	}
+	if _, err := store.RecordVersionState("app1", "v1", "1.0", "IOS", "IN_REVIEW"); err != nil {
+		t.Errorf("in-memory RecordVersionState failed: %v", err)
+	}
+}
+
+func TestStore_ReleaseTrain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if _, ok := store.ReleaseTrain("spring"); ok {
+		t.Error("expected no train before one is saved")
+	}
+
+	train := ReleaseTrain{
+		Name:          "spring",
+		VersionString: "2.0",
+		Platform:      "IOS",
+		Apps: map[string]*TrainApp{
+			"app1": {VersionID: "v1", Steps: map[string]TrainStep{"create_version": {Done: true}}},
+		},
+	}
+	if err := store.SaveReleaseTrain(train); err != nil {
+		t.Fatalf("SaveReleaseTrain failed: %v", err)
+	}
+
+	// Changing the caller's copy doesn't change the record.
+	train.Apps["app1"].Steps["submit"] = TrainStep{Done: true}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	got, ok := reopened.ReleaseTrain("spring")
+	if !ok {
+		t.Fatal("expected the saved train")
+	}
+	app := got.Apps["app1"]
+	if got.VersionString != "2.0" || app == nil || app.VersionID != "v1" || !app.Steps["create_version"].Done || len(app.Steps) != 1 {
+		t.Errorf("train = %+v, app1 = %+v", got, app)
+	}
+	if got.UpdatedAt.IsZero() {
+		t.Error("expected UpdatedAt to be set")
+	}
+}
+
+func TestStore_RecordAssetState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	store.now = func() time.Time { return now }
+
+	if since, err := store.RecordAssetState("shot-1", "AWAITING_UPLOAD"); err != nil || !since.Equal(start) {
+		t.Fatalf("RecordAssetState = %v, %v", since, err)
+	}
+	now = now.Add(3 * time.Hour)
+	if since, _ := store.RecordAssetState("shot-1", "AWAITING_UPLOAD"); !since.Equal(start) {
+		t.Errorf("unchanged state since = %v, want %v", since, start)
+	}
+	if since, _ := store.RecordAssetState("shot-1", "FAILED"); !since.Equal(now) {
+		t.Errorf("changed state since = %v, want %v", since, now)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if since, _ := reopened.RecordAssetState("shot-1", "FAILED"); !since.Equal(now) {
+		t.Errorf("reopened since = %v, want %v", since, now)
+	}
+
+	if err := reopened.ForgetAsset("shot-1"); err != nil {
+		t.Fatalf("ForgetAsset failed: %v", err)
+	}
+	reopened.now = func() time.Time { return start }
+	if since, _ := reopened.RecordAssetState("shot-1", "FAILED"); !since.Equal(start) {
+		t.Errorf("forgotten asset since = %v, want %v", since, start)
+	}
+}
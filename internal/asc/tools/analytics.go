@@ -27,6 +27,7 @@ func (r *Registry) registerAnalyticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of requests to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -99,6 +100,7 @@ func (r *Registry) registerAnalyticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of reports to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"request_id"},
 		},
@@ -119,6 +121,7 @@ func (r *Registry) registerAnalyticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of instances to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"report_id"},
 		},
@@ -139,16 +142,42 @@ func (r *Registry) registerAnalyticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of segments to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"instance_id"},
 		},
 	}, r.handleListAnalyticsReportSegments)
+
+	// Wait for analytics report instances
+	r.registerWithProgress(mcp.Tool{
+		Name:        "wait_for_analytics_report_instances",
+		Description: "Wait until an analytics report has generated instances, reporting progress while it polls",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"report_id": {
+					Type:        "string",
+					Description: "The analytics report ID",
+				},
+				"timeout_seconds": {
+					Type:        "integer",
+					Description: "Maximum time to wait (default 600, max 1800)",
+				},
+				"interval_seconds": {
+					Type:        "integer",
+					Description: "Delay between status checks (default 15)",
+				},
+			},
+			Required: []string{"report_id"},
+		},
+	}, r.handleWaitForAnalyticsReportInstances)
 }
 
-func (r *Registry) handleListAnalyticsReportRequests(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAnalyticsReportRequests(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -163,15 +192,15 @@ func (r *Registry) handleListAnalyticsReportRequests(args json.RawMessage) (*mcp
 		limit = 50
 	}
 
-	resp, err := r.client.ListAnalyticsReportRequests(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListAnalyticsReportRequests(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list analytics report requests: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list analytics report requests: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAnalyticsReportRequests(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAnalyticsReportRequests(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAnalyticsReportRequest(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAnalyticsReportRequest(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		RequestID string `json:"request_id"`
 	}
@@ -183,15 +212,15 @@ func (r *Registry) handleGetAnalyticsReportRequest(args json.RawMessage) (*mcp.T
 		return nil, fmt.Errorf("request_id is required")
 	}
 
-	resp, err := r.client.GetAnalyticsReportRequest(context.Background(), params.RequestID)
+	resp, err := r.client.GetAnalyticsReportRequest(ctx, params.RequestID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get analytics report request: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get analytics report request: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAnalyticsReportRequest(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateAnalyticsReportRequest(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAnalyticsReportRequest(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID      string `json:"app_id"`
 		AccessType string `json:"access_type"`
@@ -224,15 +253,15 @@ func (r *Registry) handleCreateAnalyticsReportRequest(args json.RawMessage) (*mc
 		},
 	}
 
-	resp, err := r.client.CreateAnalyticsReportRequest(context.Background(), req)
+	resp, err := r.client.CreateAnalyticsReportRequest(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create analytics report request: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create analytics report request: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created analytics report request: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteAnalyticsReportRequest(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAnalyticsReportRequest(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		RequestID string `json:"request_id"`
 	}
@@ -244,18 +273,19 @@ func (r *Registry) handleDeleteAnalyticsReportRequest(args json.RawMessage) (*mc
 		return nil, fmt.Errorf("request_id is required")
 	}
 
-	err := r.client.DeleteAnalyticsReportRequest(context.Background(), params.RequestID)
+	err := r.client.DeleteAnalyticsReportRequest(ctx, params.RequestID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete analytics report request: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete analytics report request: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Analytics report request deleted successfully"), nil
 }
 
-func (r *Registry) handleListAnalyticsReports(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAnalyticsReports(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		RequestID string `json:"request_id"`
 		Limit     int    `json:"limit"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -270,18 +300,19 @@ func (r *Registry) handleListAnalyticsReports(args json.RawMessage) (*mcp.ToolsC
 		limit = 50
 	}
 
-	resp, err := r.client.ListAnalyticsReports(context.Background(), params.RequestID, limit)
+	resp, err := r.client.ListAnalyticsReports(api.WithCursor(ctx, params.Cursor), params.RequestID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list analytics reports: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list analytics reports: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAnalyticsReports(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAnalyticsReports(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListAnalyticsReportInstances(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAnalyticsReportInstances(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ReportID string `json:"report_id"`
 		Limit    int    `json:"limit"`
+		Cursor   string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -296,18 +327,54 @@ func (r *Registry) handleListAnalyticsReportInstances(args json.RawMessage) (*mc
 		limit = 50
 	}
 
-	resp, err := r.client.ListAnalyticsReportInstances(context.Background(), params.ReportID, limit)
+	resp, err := r.client.ListAnalyticsReportInstances(api.WithCursor(ctx, params.Cursor), params.ReportID, api.ListOptions{Limit: limit})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list analytics report instances: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(withNextCursor(formatAnalyticsReportInstances(resp.Data), resp.Links)), nil
+}
+
+func (r *Registry) handleWaitForAnalyticsReportInstances(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		ReportID        string `json:"report_id"`
+		TimeoutSeconds  int    `json:"timeout_seconds"`
+		IntervalSeconds int    `json:"interval_seconds"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.ReportID == "" {
+		return nil, fmt.Errorf("report_id is required")
+	}
+
+	timeout, interval := pollDurations(params.TimeoutSeconds, params.IntervalSeconds)
+
+	var instances []api.AnalyticsReportInstance
+	_, err := pollUntil(ctx, timeout, interval, progress, func() (bool, string, error) {
+		resp, err := r.client.ListAnalyticsReportInstances(ctx, params.ReportID, api.ListOptions{Limit: 50})
+		if err != nil {
+			return false, "", err
+		}
+		instances = resp.Data
+		if len(instances) == 0 {
+			return false, "waiting for first report instance", nil
+		}
+		return true, fmt.Sprintf("%d instances available", len(instances)), nil
+	})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list analytics report instances: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed waiting for analytics report instances: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAnalyticsReportInstances(resp.Data)), nil
+	return mcp.NewSuccessResult(formatAnalyticsReportInstances(instances)), nil
 }
 
-func (r *Registry) handleListAnalyticsReportSegments(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAnalyticsReportSegments(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		InstanceID string `json:"instance_id"`
 		Limit      int    `json:"limit"`
+		Cursor     string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -322,12 +389,12 @@ func (r *Registry) handleListAnalyticsReportSegments(args json.RawMessage) (*mcp
 		limit = 50
 	}
 
-	resp, err := r.client.ListAnalyticsReportSegments(context.Background(), params.InstanceID, limit)
+	resp, err := r.client.ListAnalyticsReportSegments(api.WithCursor(ctx, params.Cursor), params.InstanceID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list analytics report segments: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list analytics report segments: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAnalyticsReportSegments(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAnalyticsReportSegments(resp.Data), resp.Links)), nil
 }
 
 func formatAnalyticsReportRequests(requests []api.AnalyticsReportRequest) string {
@@ -350,7 +417,9 @@ func formatAnalyticsReportRequest(req api.AnalyticsReportRequest) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("ID: %s\n", req.ID))
 	sb.WriteString(fmt.Sprintf("Access Type: %s\n", req.Attributes.AccessType))
-	sb.WriteString(fmt.Sprintf("Stoppable: %t\n", req.Attributes.Stoppable))
+	if req.Attributes.StoppedDueToInactivity {
+		sb.WriteString("Stopped: yes, due to inactivity\n")
+	}
 	return sb.String()
 }
 
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// additiveToolPrefixes identifies tools that only add data, leaving what
+// already exists unchanged.
+var additiveToolPrefixes = []string{"create_", "add_", "invite_", "register_", "start_", "retry_", "download_", "verify_"}
+
+// idempotentToolPrefixes identifies tools that have no further effect when
+// repeated with the same arguments.
+var idempotentToolPrefixes = []string{"update_", "delete_", "remove_", "add_", "assign_", "apply_", "cancel_", "select_", "verify_"}
+
+// localTools only act on the server's own state, not App Store Connect.
+var localTools = map[string]bool{
+	"select_team":    true,
+	continuationTool: true,
+}
+
+// toolAnnotations derives a tool's behavior hints from its name, following
+// the same verb conventions as read-only batching and confirmation mode.
+func toolAnnotations(name string) *mcp.ToolAnnotations {
+	annotations := &mcp.ToolAnnotations{
+		OpenWorldHint: hint(!localTools[name]),
+	}
+	if IsReadOnlyTool(name) || strings.HasPrefix(name, "wait_for_") {
+		annotations.ReadOnlyHint = hint(true)
+		return annotations
+	}
+
+	annotations.ReadOnlyHint = hint(false)
+	destructive := isDestructiveTool(name) || !hasAnyPrefix(name, additiveToolPrefixes)
+	annotations.DestructiveHint = hint(destructive && !localTools[name])
+	annotations.IdempotentHint = hint(hasAnyPrefix(name, idempotentToolPrefixes))
+	return annotations
+}
+
+// hasAnyPrefix reports whether name starts with one of prefixes.
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hint returns a pointer to an annotation value.
+func hint(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// SetAppGroups sets the named groups of app IDs that tools working across
+// several apps accept in place of an app ID.
+func (r *Registry) SetAppGroups(groups map[string][]string) {
+	r.appGroups = groups
+}
+
+// expandAppIDs replaces app group names in ids with the groups' app IDs,
+// keeping the order given. Duplicates are dropped.
+func (r *Registry) expandAppIDs(ids []string) []string {
+	seen := make(map[string]bool)
+	var appIDs []string
+	add := func(appID string) {
+		if appID != "" && !seen[appID] {
+			seen[appID] = true
+			appIDs = append(appIDs, appID)
+		}
+	}
+
+	for _, id := range ids {
+		group, ok := r.appGroups[id]
+		if !ok {
+			add(id)
+			continue
+		}
+		for _, appID := range group {
+			add(appID)
+		}
+	}
+	return appIDs
+}
+
+// registerAppGroupTools registers the tool listing configured app groups.
+func (r *Registry) registerAppGroupTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "list_app_groups",
+			Description: "List the named app groups configured with ASC_APP_GROUPS. A group name can be passed anywhere a tool takes a list of app IDs, such as run_release_train's app_ids.",
+			InputSchema: mcp.JSONSchema{
+				Type:       "object",
+				Properties: map[string]mcp.Property{},
+			},
+			OutputSchema: mcp.SchemaFor(appGroupsOutput{}),
+		},
+		r.handleListAppGroups,
+	)
+}
+
+// handleListAppGroups handles the list_app_groups tool.
+func (r *Registry) handleListAppGroups(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	names := make([]string, 0, len(r.appGroups))
+	for name := range r.appGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	output := appGroupsOutput{Groups: make([]appGroup, 0, len(names))}
+	for _, name := range names {
+		output.Groups = append(output.Groups, appGroup{Name: name, AppIDs: r.appGroups[name]})
+	}
+
+	if len(output.Groups) == 0 {
+		return mcp.NewStructuredResult("No app groups are configured. Set ASC_APP_GROUPS, e.g. \"flagship=123,456;clients=789\".", output), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d app groups:\n\n", len(output.Groups)))
+	for _, group := range output.Groups {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", group.Name, strings.Join(group.AppIDs, ", ")))
+	}
+	return mcp.NewStructuredResult(sb.String(), output), nil
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// appStoreURL returns the canonical App Store URL of an app. It opens the
+// app's product page once the app has been released.
+func appStoreURL(appID string) string {
+	return "https://apps.apple.com/app/id" + appID
+}
+
+// testFlightLinks returns the active TestFlight public links of groups.
+func testFlightLinks(groups []api.BetaGroup) []testFlightLink {
+	links := make([]testFlightLink, 0)
+	for _, group := range groups {
+		if !group.Attributes.PublicLinkEnabled || group.Attributes.PublicLink == "" {
+			continue
+		}
+		links = append(links, testFlightLink{
+			BetaGroupID:   group.ID,
+			BetaGroupName: group.Attributes.Name,
+			URL:           group.Attributes.PublicLink,
+		})
+	}
+	return links
+}
+
+// linkApps adds shareable links to apps fetched with their beta groups
+// included, as appLinkOptions requests.
+func linkApps(apps []api.App, included json.RawMessage) ([]linkedApp, error) {
+	groups, err := api.IncludedOf[api.BetaGroup](included)
+	if err != nil {
+		return nil, err
+	}
+	groupsByID := make(map[string]api.BetaGroup, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	linked := make([]linkedApp, len(apps))
+	for i, app := range apps {
+		var appGroups []api.BetaGroup
+		if app.Relationships != nil && app.Relationships.BetaGroups != nil {
+			for _, ref := range app.Relationships.BetaGroups.Data {
+				if group, ok := groupsByID[ref.ID]; ok {
+					appGroups = append(appGroups, group)
+				}
+			}
+		}
+		linked[i] = linkedApp{
+			Type:                  app.Type,
+			ID:                    app.ID,
+			Attributes:            app.Attributes,
+			AppStoreURL:           appStoreURL(app.ID),
+			TestFlightPublicLinks: testFlightLinks(appGroups),
+		}
+	}
+	return linked, nil
+}
+
+// appLinkOptions includes the beta group attributes linkApps needs.
+var appLinkOptions = api.ListOptions{}.
+	WithInclude("betaGroups").
+	WithFields("betaGroups", "name", "publicLinkEnabled", "publicLink")
+
+// formatAppLinks writes an app's links as list items with the given indent.
+func formatAppLinks(sb *strings.Builder, indent string, app linkedApp) {
+	sb.WriteString(fmt.Sprintf("%s- App Store: %s\n", indent, app.AppStoreURL))
+	for _, link := range app.TestFlightPublicLinks {
+		sb.WriteString(fmt.Sprintf("%s- TestFlight (%s): %s\n", indent, link.BetaGroupName, link.URL))
+	}
+}
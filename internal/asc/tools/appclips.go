@@ -27,6 +27,7 @@ func (r *Registry) registerAppClipTools() {
 					Type:        "integer",
 					Description: "Maximum number of app clips to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -63,6 +64,7 @@ func (r *Registry) registerAppClipTools() {
 					Type:        "integer",
 					Description: "Maximum number of experiences to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_clip_id"},
 		},
@@ -99,6 +101,7 @@ func (r *Registry) registerAppClipTools() {
 					Type:        "integer",
 					Description: "Maximum number of experiences to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_clip_id"},
 		},
@@ -121,10 +124,11 @@ func (r *Registry) registerAppClipTools() {
 	}, r.handleGetAppClipAdvancedExperience)
 }
 
-func (r *Registry) handleListAppClips(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppClips(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -139,15 +143,15 @@ func (r *Registry) handleListAppClips(args json.RawMessage) (*mcp.ToolsCallResul
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppClips(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListAppClips(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app clips: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app clips: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppClips(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppClips(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppClip(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppClip(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppClipID string `json:"app_clip_id"`
 	}
@@ -159,18 +163,19 @@ func (r *Registry) handleGetAppClip(args json.RawMessage) (*mcp.ToolsCallResult,
 		return nil, fmt.Errorf("app_clip_id is required")
 	}
 
-	resp, err := r.client.GetAppClip(context.Background(), params.AppClipID)
+	resp, err := r.client.GetAppClip(ctx, params.AppClipID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app clip: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app clip: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppClip(resp.Data)), nil
 }
 
-func (r *Registry) handleListAppClipDefaultExperiences(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppClipDefaultExperiences(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppClipID string `json:"app_clip_id"`
 		Limit     int    `json:"limit"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -185,15 +190,15 @@ func (r *Registry) handleListAppClipDefaultExperiences(args json.RawMessage) (*m
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppClipDefaultExperiences(context.Background(), params.AppClipID, limit)
+	resp, err := r.client.ListAppClipDefaultExperiences(api.WithCursor(ctx, params.Cursor), params.AppClipID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app clip default experiences: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app clip default experiences: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppClipDefaultExperiences(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppClipDefaultExperiences(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppClipDefaultExperience(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppClipDefaultExperience(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ExperienceID string `json:"experience_id"`
 	}
@@ -205,18 +210,19 @@ func (r *Registry) handleGetAppClipDefaultExperience(args json.RawMessage) (*mcp
 		return nil, fmt.Errorf("experience_id is required")
 	}
 
-	resp, err := r.client.GetAppClipDefaultExperience(context.Background(), params.ExperienceID)
+	resp, err := r.client.GetAppClipDefaultExperience(ctx, params.ExperienceID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app clip default experience: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app clip default experience: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppClipDefaultExperience(resp.Data)), nil
 }
 
-func (r *Registry) handleListAppClipAdvancedExperiences(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppClipAdvancedExperiences(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppClipID string `json:"app_clip_id"`
 		Limit     int    `json:"limit"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -231,15 +237,15 @@ func (r *Registry) handleListAppClipAdvancedExperiences(args json.RawMessage) (*
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppClipAdvancedExperiences(context.Background(), params.AppClipID, limit)
+	resp, err := r.client.ListAppClipAdvancedExperiences(api.WithCursor(ctx, params.Cursor), params.AppClipID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app clip advanced experiences: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app clip advanced experiences: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppClipAdvancedExperiences(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppClipAdvancedExperiences(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppClipAdvancedExperience(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppClipAdvancedExperience(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ExperienceID string `json:"experience_id"`
 	}
@@ -251,9 +257,9 @@ func (r *Registry) handleGetAppClipAdvancedExperience(args json.RawMessage) (*mc
 		return nil, fmt.Errorf("experience_id is required")
 	}
 
-	resp, err := r.client.GetAppClipAdvancedExperience(context.Background(), params.ExperienceID)
+	resp, err := r.client.GetAppClipAdvancedExperience(ctx, params.ExperienceID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app clip advanced experience: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app clip advanced experience: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppClipAdvancedExperience(resp.Data)), nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
 
@@ -15,7 +16,7 @@ func (r *Registry) registerAppTools() {
 	r.register(
 		mcp.Tool{
 			Name:        "list_apps",
-			Description: "List all apps in your App Store Connect account. Returns app name, bundle ID, SKU, and primary locale for each app.",
+			Description: "List all apps in your App Store Connect account. Returns app name, bundle ID, SKU, primary locale, App Store URL, and active TestFlight public links for each app.",
 			InputSchema: mcp.JSONSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -24,8 +25,11 @@ func (r *Registry) registerAppTools() {
 						Description: "Maximum number of apps to return (default: 50, max: 200)",
 						Default:     50,
 					},
+					"cursor":  cursorProperty,
+					"refresh": refreshProperty,
 				},
 			},
+			OutputSchema: mcp.SchemaFor(appsOutput{}),
 		},
 		r.handleListApps,
 	)
@@ -33,7 +37,7 @@ func (r *Registry) registerAppTools() {
 	r.register(
 		mcp.Tool{
 			Name:        "get_app",
-			Description: "Get detailed information about a specific app by its App Store Connect ID.",
+			Description: "Get detailed information about a specific app by its App Store Connect ID, including its App Store URL and active TestFlight public links.",
 			InputSchema: mcp.JSONSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -44,6 +48,7 @@ func (r *Registry) registerAppTools() {
 				},
 				Required: []string{"app_id"},
 			},
+			OutputSchema: mcp.SchemaFor(linkedApp{}),
 		},
 		r.handleGetApp,
 	)
@@ -51,7 +56,7 @@ func (r *Registry) registerAppTools() {
 	r.register(
 		mcp.Tool{
 			Name:        "get_app_versions",
-			Description: "Get all App Store versions for a specific app, including version string, platform, state, and release information.",
+			Description: "Get all App Store versions for a specific app, including version string, platform, state, and release information, with the app's App Store URL.",
 			InputSchema: mcp.JSONSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -67,15 +72,18 @@ func (r *Registry) registerAppTools() {
 				},
 				Required: []string{"app_id"},
 			},
+			OutputSchema: mcp.SchemaFor(appStoreVersionsOutput{}),
 		},
 		r.handleGetAppVersions,
 	)
 }
 
 // handleListApps handles the list_apps tool.
-func (r *Registry) handleListApps(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListApps(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
+		Refresh bool   `json:"refresh"`
 	}
 	params.Limit = 50
 
@@ -92,33 +100,41 @@ func (r *Registry) handleListApps(args json.RawMessage) (*mcp.ToolsCallResult, e
 		params.Limit = 200
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListApps(ctx, params.Limit)
+	opts := appLinkOptions
+	opts.Limit = params.Limit
+	resp, err := r.client.ListApps(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), opts)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list apps: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list apps: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
-		return mcp.NewSuccessResult("No apps found in your App Store Connect account."), nil
+		return mcp.NewStructuredResult("No apps found in your App Store Connect account.", appsOutput{Apps: []linkedApp{}}), nil
+	}
+
+	apps, err := linkApps(resp.Data, resp.Included)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read beta groups: %v", err), err), nil
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d apps:\n\n", len(resp.Data)))
+	sb.WriteString(fmt.Sprintf("Found %d apps:\n\n", len(apps)))
 
-	for _, app := range resp.Data {
+	for _, app := range apps {
 		sb.WriteString(fmt.Sprintf("**%s**\n", app.Attributes.Name))
 		sb.WriteString(fmt.Sprintf("  - ID: %s\n", app.ID))
 		sb.WriteString(fmt.Sprintf("  - Bundle ID: %s\n", app.Attributes.BundleID))
 		sb.WriteString(fmt.Sprintf("  - SKU: %s\n", app.Attributes.SKU))
 		sb.WriteString(fmt.Sprintf("  - Primary Locale: %s\n", app.Attributes.PrimaryLocale))
+		formatAppLinks(&sb, "  ", app)
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	output := appsOutput{Apps: apps, NextCursor: resp.Links.NextCursor()}
+	return mcp.NewStructuredResult(withNextCursor(sb.String(), resp.Links), output), nil
 }
 
 // handleGetApp handles the get_app tool.
-func (r *Registry) handleGetApp(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetApp(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -131,13 +147,16 @@ func (r *Registry) handleGetApp(args json.RawMessage) (*mcp.ToolsCallResult, err
 		return mcp.NewErrorResult("app_id is required"), nil
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.GetApp(ctx, params.AppID)
+	resp, err := r.client.GetApp(ctx, params.AppID, "betaGroups")
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app: %v", err), err), nil
 	}
 
-	app := resp.Data
+	linked, err := linkApps([]api.App{resp.Data}, resp.Included)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read beta groups: %v", err), err), nil
+	}
+	app := linked[0]
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("**%s**\n\n", app.Attributes.Name))
 	sb.WriteString(fmt.Sprintf("- ID: %s\n", app.ID))
@@ -149,12 +168,13 @@ func (r *Registry) handleGetApp(args json.RawMessage) (*mcp.ToolsCallResult, err
 	if app.Attributes.ContentRightsDeclaration != "" {
 		sb.WriteString(fmt.Sprintf("- Content Rights: %s\n", app.Attributes.ContentRightsDeclaration))
 	}
+	formatAppLinks(&sb, "", app)
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewStructuredResult(sb.String(), app), nil
 }
 
 // handleGetAppVersions handles the get_app_versions tool.
-func (r *Registry) handleGetAppVersions(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppVersions(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 		Limit int    `json:"limit"`
@@ -169,18 +189,19 @@ func (r *Registry) handleGetAppVersions(args json.RawMessage) (*mcp.ToolsCallRes
 		return mcp.NewErrorResult("app_id is required"), nil
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.GetAppVersions(ctx, params.AppID, params.Limit)
+	resp, err := r.client.GetAppVersions(ctx, params.AppID, api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app versions: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app versions: %v", err), err), nil
 	}
+	r.recordVersions(params.AppID, resp.Data)
 
 	if len(resp.Data) == 0 {
-		return mcp.NewSuccessResult("No versions found for this app."), nil
+		return mcp.NewStructuredResult("No versions found for this app.", appStoreVersionsOutput{Versions: []api.AppStoreVersion{}, AppStoreURL: appStoreURL(params.AppID)}), nil
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d versions:\n\n", len(resp.Data)))
+	sb.WriteString(fmt.Sprintf("Found %d versions:\n", len(resp.Data)))
+	sb.WriteString(fmt.Sprintf("App Store: %s\n\n", appStoreURL(params.AppID)))
 
 	for _, version := range resp.Data {
 		sb.WriteString(fmt.Sprintf("**Version %s** (%s)\n", version.Attributes.VersionString, version.Attributes.Platform))
@@ -194,5 +215,5 @@ func (r *Registry) handleGetAppVersions(args json.RawMessage) (*mcp.ToolsCallRes
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewStructuredResult(sb.String(), appStoreVersionsOutput{Versions: resp.Data, AppStoreURL: appStoreURL(params.AppID)}), nil
 }
@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// Asset delivery states reported for uploaded screenshots, previews and
+// attachments.
+const (
+	assetAwaitingUpload = "AWAITING_UPLOAD"
+	assetUploadComplete = "UPLOAD_COMPLETE"
+	assetComplete       = "COMPLETE"
+	assetFailed         = "FAILED"
+)
+
+// uploadedAsset is the part of an uploaded asset that verification reads.
+type uploadedAsset struct {
+	FileName string
+	Checksum string
+	State    *api.AssetDeliveryState
+}
+
+// assetAccessor fetches and commits one type of uploaded asset.
+type assetAccessor struct {
+	get    func(ctx context.Context, id string) (uploadedAsset, error)
+	commit func(ctx context.Context, id, checksum string) error
+}
+
+// assetAccessors are the asset types verify_asset_upload accepts.
+func (r *Registry) assetAccessors() map[string]assetAccessor {
+	uploaded := true
+	return map[string]assetAccessor{
+		"screenshot": {
+			get: func(ctx context.Context, id string) (uploadedAsset, error) {
+				resp, err := r.client.GetAppScreenshot(ctx, id)
+				if err != nil {
+					return uploadedAsset{}, err
+				}
+				a := resp.Data.Attributes
+				return uploadedAsset{FileName: a.FileName, Checksum: a.SourceFileChecksum, State: a.AssetDeliveryState}, nil
+			},
+			commit: func(ctx context.Context, id, checksum string) error {
+				_, err := r.client.UpdateAppScreenshot(ctx, id, &api.AppScreenshotUpdateRequest{
+					Data: api.AppScreenshotUpdateData{
+						Type:       "appScreenshots",
+						ID:         id,
+						Attributes: api.AppScreenshotUpdateAttributes{SourceFileChecksum: checksum, Uploaded: &uploaded},
+					},
+				})
+				return err
+			},
+		},
+		"preview": {
+			get: func(ctx context.Context, id string) (uploadedAsset, error) {
+				resp, err := r.client.GetAppPreview(ctx, id)
+				if err != nil {
+					return uploadedAsset{}, err
+				}
+				a := resp.Data.Attributes
+				return uploadedAsset{FileName: a.FileName, Checksum: a.SourceFileChecksum, State: a.AssetDeliveryState}, nil
+			},
+			commit: func(ctx context.Context, id, checksum string) error {
+				_, err := r.client.UpdateAppPreview(ctx, id, &api.AppPreviewUpdateRequest{
+					Data: api.AppPreviewUpdateData{
+						Type:       "appPreviews",
+						ID:         id,
+						Attributes: api.AppPreviewUpdateAttributes{SourceFileChecksum: checksum, Uploaded: &uploaded},
+					},
+				})
+				return err
+			},
+		},
+		"review_attachment": {
+			get: func(ctx context.Context, id string) (uploadedAsset, error) {
+				resp, err := r.client.GetAppStoreReviewAttachment(ctx, id)
+				if err != nil {
+					return uploadedAsset{}, err
+				}
+				a := resp.Data.Attributes
+				return uploadedAsset{FileName: a.FileName, Checksum: a.SourceFileChecksum, State: a.AssetDeliveryState}, nil
+			},
+			commit: func(ctx context.Context, id, checksum string) error {
+				_, err := r.client.UpdateAppStoreReviewAttachment(ctx, id, &api.AppStoreReviewAttachmentUpdateRequest{
+					Data: api.AppStoreReviewAttachmentUpdateData{
+						Type:       "appStoreReviewAttachments",
+						ID:         id,
+						Attributes: api.AppStoreReviewAttachmentUpdateAttributes{SourceFileChecksum: checksum, Uploaded: &uploaded},
+					},
+				})
+				return err
+			},
+		},
+	}
+}
+
+// registerAssetVerificationTools registers the upload verification tool.
+func (r *Registry) registerAssetVerificationTools() {
+	r.registerWithProgress(
+		mcp.Tool{
+			Name:        "verify_asset_upload",
+			Description: "Verify that an uploaded screenshot, app preview or App Review attachment was processed by Apple. Polls the asset until its delivery state is COMPLETE or FAILED, reporting Apple's processing errors with their codes. An asset never committed (AWAITING_UPLOAD), or stuck in UPLOAD_COMPLETE for half the timeout, is committed again once with its checksum.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"asset_type": {
+						Type:        "string",
+						Description: "The type of asset",
+						Enum:        []string{"screenshot", "preview", "review_attachment"},
+					},
+					"asset_id": {
+						Type:        "string",
+						Description: "The ID of the screenshot, preview or attachment returned when its upload was reserved",
+					},
+					"source_file_checksum": {
+						Type:        "string",
+						Description: "Optional: MD5 checksum of the uploaded file, used to commit the upload again (default: the checksum recorded on the asset)",
+					},
+					"retry_commit": {
+						Type:        "boolean",
+						Description: "Commit the upload again if it is awaiting upload or stuck (default: true)",
+						Default:     true,
+					},
+					"timeout_seconds": {
+						Type:        "integer",
+						Description: "Maximum time to wait (default: 600, max: 1800)",
+						Default:     600,
+					},
+					"interval_seconds": {
+						Type:        "integer",
+						Description: "Delay between status checks (default: 15)",
+						Default:     15,
+					},
+				},
+				Required: []string{"asset_type", "asset_id"},
+			},
+			OutputSchema: mcp.SchemaFor(assetVerificationOutput{}),
+		},
+		r.handleVerifyAssetUpload,
+	)
+}
+
+// handleVerifyAssetUpload handles the verify_asset_upload tool.
+func (r *Registry) handleVerifyAssetUpload(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AssetType          string `json:"asset_type"`
+		AssetID            string `json:"asset_id"`
+		SourceFileChecksum string `json:"source_file_checksum"`
+		RetryCommit        *bool  `json:"retry_commit"`
+		TimeoutSeconds     int    `json:"timeout_seconds"`
+		IntervalSeconds    int    `json:"interval_seconds"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AssetID == "" {
+		return mcp.NewErrorResult("asset_id is required"), nil
+	}
+	accessor, ok := r.assetAccessors()[params.AssetType]
+	if !ok {
+		return mcp.NewErrorResult("asset_type must be screenshot, preview or review_attachment"), nil
+	}
+	retryCommit := params.RetryCommit == nil || *params.RetryCommit
+
+	timeout, interval := pollDurations(params.TimeoutSeconds, params.IntervalSeconds)
+	output := assetVerificationOutput{
+		AssetType: params.AssetType,
+		AssetID:   params.AssetID,
+		Errors:    make([]assetDeliveryIssue, 0),
+		Warnings:  make([]assetDeliveryIssue, 0),
+	}
+
+	start := time.Now()
+	_, err := pollUntil(ctx, timeout, interval, progress, func() (bool, string, error) {
+		asset, err := accessor.get(ctx, params.AssetID)
+		if err != nil {
+			return false, "", err
+		}
+		output.FileName = asset.FileName
+		output.State = assetAwaitingUpload
+		if asset.State != nil {
+			output.State = asset.State.State
+			output.Errors = deliveryIssues(asset.State.Errors)
+			output.Warnings = deliveryIssues(asset.State.Warnings)
+		}
+
+		switch output.State {
+		case assetComplete, assetFailed:
+			return true, output.State, nil
+		case assetAwaitingUpload, assetUploadComplete:
+			stuck := output.State == assetAwaitingUpload || time.Since(start) >= timeout/2
+			if !stuck || !retryCommit || output.CommitRetried {
+				return false, output.State, nil
+			}
+			checksum := params.SourceFileChecksum
+			if checksum == "" {
+				checksum = asset.Checksum
+			}
+			if checksum == "" {
+				return false, output.State, fmt.Errorf("the upload isn't committed and no source_file_checksum is known to commit it")
+			}
+			output.CommitRetried = true
+			if err := accessor.commit(ctx, params.AssetID, checksum); err != nil {
+				return false, output.State, fmt.Errorf("failed to commit upload: %w", err)
+			}
+			return false, output.State + " (commit retried)", nil
+		default:
+			return false, output.State, nil
+		}
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		output.Error = err.Error()
+	}
+	output.Verified = output.State == assetComplete && err == nil
+
+	// An asset that didn't finish processing is reported as an error, with
+	// the state and Apple's error codes still available as structured content.
+	result := mcp.NewStructuredResult(formatAssetVerification(output), output)
+	result.IsError = !output.Verified
+	return result, nil
+}
+
+// deliveryIssues converts Apple's asset processing errors or warnings.
+func deliveryIssues(issues []api.AppMediaStateError) []assetDeliveryIssue {
+	converted := make([]assetDeliveryIssue, 0, len(issues))
+	for _, issue := range issues {
+		converted = append(converted, assetDeliveryIssue{Code: issue.Code, Description: issue.Description})
+	}
+	return converted
+}
+
+// formatAssetVerification describes the outcome of an upload verification.
+func formatAssetVerification(o assetVerificationOutput) string {
+	var sb strings.Builder
+	name := o.AssetID
+	if o.FileName != "" {
+		name = fmt.Sprintf("%s (%s)", o.FileName, o.AssetID)
+	}
+
+	switch {
+	case o.Verified:
+		sb.WriteString(fmt.Sprintf("Upload of %s %s verified: %s\n", strings.ReplaceAll(o.AssetType, "_", " "), name, o.State))
+	case o.State == assetFailed:
+		sb.WriteString(fmt.Sprintf("Apple failed to process %s %s\n", strings.ReplaceAll(o.AssetType, "_", " "), name))
+	default:
+		sb.WriteString(fmt.Sprintf("Upload of %s %s not verified (state: %s)\n", strings.ReplaceAll(o.AssetType, "_", " "), name, o.State))
+	}
+	if o.Error != "" {
+		sb.WriteString(fmt.Sprintf("Error: %s\n", o.Error))
+	}
+	if o.CommitRetried {
+		sb.WriteString("The upload was committed again.\n")
+	}
+
+	for _, e := range o.Errors {
+		sb.WriteString(fmt.Sprintf("- Error %s: %s\n", e.Code, e.Description))
+	}
+	for _, w := range o.Warnings {
+		sb.WriteString(fmt.Sprintf("- Warning %s: %s\n", w.Code, w.Description))
+	}
+	return sb.String()
+}
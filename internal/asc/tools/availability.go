@@ -67,13 +67,14 @@ func (r *Registry) registerAvailabilityTools() {
 					Type:        "integer",
 					Description: "Maximum number of results to return (default 100)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"availability_id"},
 		},
 	}, r.handleListTerritoryAvailabilities)
 }
 
-func (r *Registry) handleGetAppAvailability(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppAvailability(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -85,15 +86,15 @@ func (r *Registry) handleGetAppAvailability(args json.RawMessage) (*mcp.ToolsCal
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetAppAvailability(context.Background(), params.AppID)
+	resp, err := r.client.GetAppAvailability(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app availability: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app availability: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppAvailability(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateAppAvailability(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppAvailability(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID                     string   `json:"app_id"`
 		AvailableInNewTerritories *bool    `json:"available_in_new_territories"`
@@ -134,18 +135,19 @@ func (r *Registry) handleCreateAppAvailability(args json.RawMessage) (*mcp.Tools
 		},
 	}
 
-	resp, err := r.client.CreateAppAvailability(context.Background(), req)
+	resp, err := r.client.CreateAppAvailability(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create app availability: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create app availability: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("App availability created:\n%s", formatAppAvailability(resp.Data))), nil
 }
 
-func (r *Registry) handleListTerritoryAvailabilities(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListTerritoryAvailabilities(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AvailabilityID string `json:"availability_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -160,12 +162,12 @@ func (r *Registry) handleListTerritoryAvailabilities(args json.RawMessage) (*mcp
 		limit = 100
 	}
 
-	resp, err := r.client.ListTerritoryAvailabilities(context.Background(), params.AvailabilityID, limit)
+	resp, err := r.client.ListTerritoryAvailabilities(api.WithCursor(ctx, params.Cursor), params.AvailabilityID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list territory availabilities: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list territory availabilities: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatTerritoryAvailabilities(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatTerritoryAvailabilities(resp.Data), resp.Links)), nil
 }
 
 func formatAppAvailability(avail api.AppAvailability) string {
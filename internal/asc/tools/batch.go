@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+const (
+	// maxBatchOperations caps the operations in one asc_batch_get call.
+	maxBatchOperations = 20
+
+	// batchConcurrency is how many operations of a batch run at once.
+	batchConcurrency = 4
+)
+
+// readOnlyTools are the read-only tools whose names don't start with a read verb.
+var readOnlyTools = map[string]bool{
+	"search":                   true,
+	"asc_status":               true,
+	"asc_batch_get":            true,
+	"check_app_store_metadata": true,
+	"check_metadata_urls":      true,
+	"build_overview":           true,
+	"generate_digest":          true,
+}
+
+// IsReadOnlyTool reports whether a tool only reads data, based on its verb prefix.
+func IsReadOnlyTool(name string) bool {
+	return strings.HasPrefix(name, "list_") || strings.HasPrefix(name, "get_") || readOnlyTools[name]
+}
+
+// batchable reports whether a tool can run inside asc_batch_get. Continuation
+// tokens are single-use, so fetching one is left out along with nested batches.
+func batchable(name string) bool {
+	return IsReadOnlyTool(name) && name != "asc_batch_get" && name != continuationTool
+}
+
+// registerBatchTools registers the composite read tool.
+func (r *Registry) registerBatchTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "asc_batch_get",
+			Description: "Run several read-only tools in one call and return their results together, for example an app, its latest version, that version's localizations and its review details. Operations run concurrently and fail independently. Only list_*, get_* and other read-only tools can be batched, up to 20 operations per call.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"operations": {
+						Type:        "array",
+						Description: "The read operations to run",
+						Items: &mcp.Property{
+							Type: "object",
+							Properties: map[string]mcp.Property{
+								"id": {
+									Type:        "string",
+									Description: "Optional: Name for the operation's result (default: the tool name, numbered if repeated)",
+								},
+								"tool": {
+									Type:        "string",
+									Description: "Name of the read-only tool to call, e.g. get_app",
+								},
+								"arguments": {
+									Type:        "object",
+									Description: "Arguments for the tool",
+								},
+							},
+							Required: []string{"tool"},
+						},
+					},
+				},
+				Required: []string{"operations"},
+			},
+			OutputSchema: mcp.SchemaFor(batchOutput{}),
+		},
+		r.handleBatchGet,
+	)
+}
+
+// batchOperation is one tool call in an asc_batch_get request.
+type batchOperation struct {
+	ID        string          `json:"id"`
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// handleBatchGet handles the asc_batch_get tool.
+func (r *Registry) handleBatchGet(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Operations []batchOperation `json:"operations"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if len(params.Operations) == 0 {
+		return mcp.NewErrorResult("operations must list at least one tool call"), nil
+	}
+	if len(params.Operations) > maxBatchOperations {
+		return mcp.NewErrorResult(fmt.Sprintf("operations lists %d tool calls; at most %d are allowed", len(params.Operations), maxBatchOperations)), nil
+	}
+
+	seen := make(map[string]int)
+	for i, op := range params.Operations {
+		name := r.ResolveToolName(op.Tool)
+		if !batchable(name) {
+			return mcp.NewErrorResult(fmt.Sprintf("operations[%d]: %q is not a read-only tool that can be batched", i, op.Tool)), nil
+		}
+		if _, ok := r.handlers[name]; !ok {
+			return mcp.NewErrorResult(fmt.Sprintf("operations[%d]: unknown tool %q", i, op.Tool)), nil
+		}
+		if op.ID == "" {
+			params.Operations[i].ID = op.Tool
+			if n := seen[op.Tool]; n > 0 {
+				params.Operations[i].ID = fmt.Sprintf("%s_%d", op.Tool, n+1)
+			}
+			seen[op.Tool]++
+		}
+	}
+
+	output := batchOutput{Results: make([]batchResult, len(params.Operations))}
+	limit := newSemaphore(batchConcurrency)
+	var wg sync.WaitGroup
+	for i, op := range params.Operations {
+		wg.Add(1)
+		go func(i int, op batchOperation) {
+			defer wg.Done()
+			output.Results[i] = r.runBatchOperation(ctx, limit, op)
+		}(i, op)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	for _, result := range output.Results {
+		if result.IsError {
+			output.Failed++
+		}
+	}
+
+	return mcp.NewStructuredResult(formatBatch(output), output), nil
+}
+
+// runBatchOperation calls one operation's handler directly. The batch call
+// already holds its concurrency slots, so going through CallTool could wait
+// on itself.
+func (r *Registry) runBatchOperation(ctx context.Context, limit *semaphore, op batchOperation) batchResult {
+	out := batchResult{ID: op.ID, Tool: op.Tool}
+
+	if err := limit.acquire(ctx); err != nil {
+		out.IsError = true
+		out.Text = err.Error()
+		return out
+	}
+	defer limit.release()
+
+	args := op.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+
+	name := r.ResolveToolName(op.Tool)
+	result, err := recoverToolPanic(name, func() (*mcp.ToolsCallResult, error) {
+		return r.handlers[name](ctx, args)
+	})
+	if err != nil {
+		out.IsError = true
+		out.Text = err.Error()
+		return out
+	}
+
+	var texts []string
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			texts = append(texts, block.Text)
+		}
+	}
+	out.Text = strings.Join(texts, "\n")
+	out.IsError = result.IsError
+	out.Result = result.StructuredContent
+	return out
+}
+
+// formatBatch renders each operation's text under its ID.
+func formatBatch(o batchOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Ran %d operations", len(o.Results)))
+	if o.Failed > 0 {
+		sb.WriteString(fmt.Sprintf(", %d failed", o.Failed))
+	}
+	sb.WriteString("\n")
+
+	for _, result := range o.Results {
+		status := ""
+		if result.IsError {
+			status = " [failed]"
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s (%s)%s\n\n%s\n", result.ID, result.Tool, status, strings.TrimRight(result.Text, "\n")))
+	}
+	return sb.String()
+}
@@ -19,14 +19,51 @@ func (r *Registry) registerBetaReviewTools() {
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
+				"build_id": {
+					Type:        "string",
+					Description: "Optional: Filter by build ID",
+				},
+				"beta_review_state": {
+					Type:        "string",
+					Description: "Optional: Filter by review state",
+					Enum:        api.EnumStrings(api.BetaReviewStates),
+				},
 				"limit": {
 					Type:        "integer",
 					Description: "Maximum number of submissions to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListBetaAppReviewSubmissions)
 
+	// Get build beta review status
+	r.register(mcp.Tool{
+		Name:        "get_build_beta_review_status",
+		Description: "Check whether a build has passed beta app review and is approved for external testing. Identify the build by build_id, or by app_id and build_number.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"build_id": {
+					Type:        "string",
+					Description: "The build ID",
+				},
+				"app_id": {
+					Type:        "string",
+					Description: "The app ID (used with build_number)",
+				},
+				"build_number": {
+					Type:        "string",
+					Description: "The build number (CFBundleVersion), used with app_id",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Optional: Marketing version (CFBundleShortVersionString) to disambiguate build_number",
+				},
+			},
+		},
+	}, r.handleGetBuildBetaReviewStatus)
+
 	// Get beta app review submission
 	r.register(mcp.Tool{
 		Name:        "get_beta_app_review_submission",
@@ -59,6 +96,22 @@ func (r *Registry) registerBetaReviewTools() {
 		},
 	}, r.handleCreateBetaAppReviewSubmission)
 
+	// Withdraw beta app review submission
+	r.register(mcp.Tool{
+		Name:        "withdraw_beta_app_review_submission",
+		Description: "Pull a build out of pending beta app review. The API cannot delete review submissions, so this expires the build, which withdraws it from review and ends its TestFlight availability. Expiring a build cannot be undone.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"build_id": {
+					Type:        "string",
+					Description: "The build ID whose beta review submission should be withdrawn",
+				},
+			},
+			Required: []string{"build_id"},
+		},
+	}, r.handleWithdrawBetaAppReviewSubmission)
+
 	// Get beta license agreement
 	r.register(mcp.Tool{
 		Name:        "get_beta_license_agreement",
@@ -110,6 +163,7 @@ func (r *Registry) registerBetaReviewTools() {
 					Type:        "integer",
 					Description: "Maximum number of localizations to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -230,6 +284,7 @@ func (r *Registry) registerBetaReviewTools() {
 					Type:        "integer",
 					Description: "Maximum number of localizations to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"build_id"},
 		},
@@ -348,9 +403,12 @@ func (r *Registry) registerBetaReviewTools() {
 	}, r.handleUpdateBuildBetaDetail)
 }
 
-func (r *Registry) handleListBetaAppReviewSubmissions(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBetaAppReviewSubmissions(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		BuildID         string `json:"build_id"`
+		BetaReviewState string `json:"beta_review_state"`
+		Limit           int    `json:"limit"`
+		Cursor          string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -361,15 +419,116 @@ func (r *Registry) handleListBetaAppReviewSubmissions(args json.RawMessage) (*mc
 		limit = 50
 	}
 
-	resp, err := r.client.ListBetaAppReviewSubmissions(context.Background(), limit)
+	opts := api.ListOptions{Limit: limit}.
+		WithFilter("build", params.BuildID).
+		WithFilter("betaReviewState", params.BetaReviewState)
+	resp, err := r.client.ListBetaAppReviewSubmissions(api.WithCursor(ctx, params.Cursor), opts)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta app review submissions: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(withNextCursor(formatBetaAppReviewSubmissions(resp.Data), resp.Links)), nil
+}
+
+func (r *Registry) handleWithdrawBetaAppReviewSubmission(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildID string `json:"build_id"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildID == "" {
+		return nil, fmt.Errorf("build_id is required")
+	}
+
+	submission, err := r.client.GetBetaAppReviewSubmissionForBuild(ctx, params.BuildID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta app review submission: %v", err), err), nil
+	}
+	if submission == nil {
+		return mcp.NewErrorResult(fmt.Sprintf("Build %s has not been submitted for beta app review", params.BuildID)), nil
+	}
+
+	switch state := submission.Attributes.BetaReviewState; state {
+	case api.BetaReviewStateWaitingForReview, api.BetaReviewStateInReview:
+	default:
+		return mcp.NewErrorResult(fmt.Sprintf("Beta app review submission %s is %s; only pending submissions can be withdrawn", submission.ID, state)), nil
+	}
+
+	expired := true
+	req := &api.BuildUpdateRequest{
+		Data: api.BuildUpdateData{
+			Type:       "builds",
+			ID:         params.BuildID,
+			Attributes: api.BuildUpdateAttributes{Expired: &expired},
+		},
+	}
+
+	resp, err := r.client.UpdateBuild(ctx, params.BuildID, req)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to expire build: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Withdrew build %s (ID: %s) from beta app review by expiring it.\nThe submission was %s.",
+		resp.Data.Attributes.Version, resp.Data.ID, submission.Attributes.BetaReviewState)), nil
+}
+
+func (r *Registry) handleGetBuildBetaReviewStatus(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildID     string `json:"build_id"`
+		AppID       string `json:"app_id"`
+		BuildNumber string `json:"build_number"`
+		Version     string `json:"version"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildID == "" && (params.AppID == "" || params.BuildNumber == "") {
+		return nil, fmt.Errorf("either build_id or both app_id and build_number are required")
+	}
+
+	var build api.Build
+	if params.BuildID != "" {
+		resp, err := r.client.GetBuild(ctx, params.BuildID)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get build: %v", err), err), nil
+		}
+		build = resp.Data
+	} else {
+		opts := api.ListOptions{Limit: 2}.
+			WithFilter("version", params.BuildNumber).
+			WithFilter("preReleaseVersion.version", params.Version)
+		resp, err := r.client.ListBuilds(ctx, params.AppID, opts)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to find build: %v", err), err), nil
+		}
+		switch len(resp.Data) {
+		case 0:
+			return mcp.NewErrorResult(fmt.Sprintf("No build %s found for app %s", params.BuildNumber, params.AppID)), nil
+		case 1:
+			build = resp.Data[0]
+		default:
+			return mcp.NewErrorResult(fmt.Sprintf("Build number %s matches more than one build; pass version or build_id to disambiguate", params.BuildNumber)), nil
+		}
+	}
+
+	submission, err := r.client.GetBetaAppReviewSubmissionForBuild(ctx, build.ID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list beta app review submissions: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta app review submission: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatBetaAppReviewSubmissions(resp.Data)), nil
+	// The external build state is informative but not essential; ignore failures.
+	var externalState string
+	if detail, err := r.client.GetBuildBetaDetail(ctx, build.ID); err == nil {
+		externalState = detail.Data.Attributes.ExternalBuildState
+	}
+
+	return mcp.NewSuccessResult(formatBuildBetaReviewStatus(build, submission, externalState)), nil
 }
 
-func (r *Registry) handleGetBetaAppReviewSubmission(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBetaAppReviewSubmission(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubmissionID string `json:"submission_id"`
 	}
@@ -381,15 +540,15 @@ func (r *Registry) handleGetBetaAppReviewSubmission(args json.RawMessage) (*mcp.
 		return nil, fmt.Errorf("submission_id is required")
 	}
 
-	resp, err := r.client.GetBetaAppReviewSubmission(context.Background(), params.SubmissionID)
+	resp, err := r.client.GetBetaAppReviewSubmission(ctx, params.SubmissionID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get beta app review submission: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta app review submission: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatBetaAppReviewSubmission(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateBetaAppReviewSubmission(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateBetaAppReviewSubmission(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID string `json:"build_id"`
 	}
@@ -412,15 +571,15 @@ func (r *Registry) handleCreateBetaAppReviewSubmission(args json.RawMessage) (*m
 		},
 	}
 
-	resp, err := r.client.CreateBetaAppReviewSubmission(context.Background(), req)
+	resp, err := r.client.CreateBetaAppReviewSubmission(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create beta app review submission: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create beta app review submission: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta app review submission created:\n%s", formatBetaAppReviewSubmission(resp.Data))), nil
 }
 
-func (r *Registry) handleGetBetaLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBetaLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -432,15 +591,15 @@ func (r *Registry) handleGetBetaLicenseAgreement(args json.RawMessage) (*mcp.Too
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetBetaLicenseAgreement(context.Background(), params.AppID)
+	resp, err := r.client.GetBetaLicenseAgreement(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get beta license agreement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta license agreement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatBetaLicenseAgreement(resp.Data)), nil
 }
 
-func (r *Registry) handleUpdateBetaLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateBetaLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AgreementID   string `json:"agreement_id"`
 		AgreementText string `json:"agreement_text"`
@@ -463,18 +622,19 @@ func (r *Registry) handleUpdateBetaLicenseAgreement(args json.RawMessage) (*mcp.
 		},
 	}
 
-	resp, err := r.client.UpdateBetaLicenseAgreement(context.Background(), params.AgreementID, req)
+	resp, err := r.client.UpdateBetaLicenseAgreement(ctx, params.AgreementID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update beta license agreement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update beta license agreement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta license agreement updated:\n%s", formatBetaLicenseAgreement(resp.Data))), nil
 }
 
-func (r *Registry) handleListBetaAppLocalizations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBetaAppLocalizations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -489,15 +649,15 @@ func (r *Registry) handleListBetaAppLocalizations(args json.RawMessage) (*mcp.To
 		limit = 50
 	}
 
-	resp, err := r.client.ListBetaAppLocalizations(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListBetaAppLocalizations(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list beta app localizations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta app localizations: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatBetaAppLocalizations(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatBetaAppLocalizations(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetBetaAppLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBetaAppLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -509,15 +669,15 @@ func (r *Registry) handleGetBetaAppLocalization(args json.RawMessage) (*mcp.Tool
 		return nil, fmt.Errorf("localization_id is required")
 	}
 
-	resp, err := r.client.GetBetaAppLocalization(context.Background(), params.LocalizationID)
+	resp, err := r.client.GetBetaAppLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get beta app localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta app localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatBetaAppLocalization(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateBetaAppLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateBetaAppLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID            string `json:"app_id"`
 		Locale           string `json:"locale"`
@@ -552,15 +712,15 @@ func (r *Registry) handleCreateBetaAppLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	resp, err := r.client.CreateBetaAppLocalization(context.Background(), req)
+	resp, err := r.client.CreateBetaAppLocalization(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create beta app localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create beta app localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta app localization created:\n%s", formatBetaAppLocalization(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateBetaAppLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateBetaAppLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID   string `json:"localization_id"`
 		Description      string `json:"description"`
@@ -589,15 +749,15 @@ func (r *Registry) handleUpdateBetaAppLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	resp, err := r.client.UpdateBetaAppLocalization(context.Background(), params.LocalizationID, req)
+	resp, err := r.client.UpdateBetaAppLocalization(ctx, params.LocalizationID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update beta app localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update beta app localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta app localization updated:\n%s", formatBetaAppLocalization(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteBetaAppLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteBetaAppLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -609,18 +769,19 @@ func (r *Registry) handleDeleteBetaAppLocalization(args json.RawMessage) (*mcp.T
 		return nil, fmt.Errorf("localization_id is required")
 	}
 
-	err := r.client.DeleteBetaAppLocalization(context.Background(), params.LocalizationID)
+	err := r.client.DeleteBetaAppLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete beta app localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete beta app localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Beta app localization deleted"), nil
 }
 
-func (r *Registry) handleListBetaBuildLocalizations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBetaBuildLocalizations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID string `json:"build_id"`
 		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -635,15 +796,15 @@ func (r *Registry) handleListBetaBuildLocalizations(args json.RawMessage) (*mcp.
 		limit = 50
 	}
 
-	resp, err := r.client.ListBetaBuildLocalizations(context.Background(), params.BuildID, limit)
+	resp, err := r.client.ListBetaBuildLocalizations(api.WithCursor(ctx, params.Cursor), params.BuildID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list beta build localizations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta build localizations: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatBetaBuildLocalizations(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatBetaBuildLocalizations(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetBetaBuildLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBetaBuildLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -655,15 +816,15 @@ func (r *Registry) handleGetBetaBuildLocalization(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("localization_id is required")
 	}
 
-	resp, err := r.client.GetBetaBuildLocalization(context.Background(), params.LocalizationID)
+	resp, err := r.client.GetBetaBuildLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get beta build localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta build localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatBetaBuildLocalization(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateBetaBuildLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateBetaBuildLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID  string `json:"build_id"`
 		Locale   string `json:"locale"`
@@ -692,15 +853,15 @@ func (r *Registry) handleCreateBetaBuildLocalization(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.CreateBetaBuildLocalization(context.Background(), req)
+	resp, err := r.client.CreateBetaBuildLocalization(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create beta build localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create beta build localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta build localization created:\n%s", formatBetaBuildLocalization(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateBetaBuildLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateBetaBuildLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 		WhatsNew       string `json:"whats_new"`
@@ -723,15 +884,15 @@ func (r *Registry) handleUpdateBetaBuildLocalization(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.UpdateBetaBuildLocalization(context.Background(), params.LocalizationID, req)
+	resp, err := r.client.UpdateBetaBuildLocalization(ctx, params.LocalizationID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update beta build localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update beta build localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Beta build localization updated:\n%s", formatBetaBuildLocalization(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteBetaBuildLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteBetaBuildLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -743,15 +904,15 @@ func (r *Registry) handleDeleteBetaBuildLocalization(args json.RawMessage) (*mcp
 		return nil, fmt.Errorf("localization_id is required")
 	}
 
-	err := r.client.DeleteBetaBuildLocalization(context.Background(), params.LocalizationID)
+	err := r.client.DeleteBetaBuildLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete beta build localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete beta build localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Beta build localization deleted"), nil
 }
 
-func (r *Registry) handleGetBuildBetaDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBuildBetaDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID string `json:"build_id"`
 	}
@@ -763,15 +924,15 @@ func (r *Registry) handleGetBuildBetaDetail(args json.RawMessage) (*mcp.ToolsCal
 		return nil, fmt.Errorf("build_id is required")
 	}
 
-	resp, err := r.client.GetBuildBetaDetail(context.Background(), params.BuildID)
+	resp, err := r.client.GetBuildBetaDetail(ctx, params.BuildID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get build beta detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get build beta detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatBuildBetaDetail(resp.Data)), nil
 }
 
-func (r *Registry) handleUpdateBuildBetaDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateBuildBetaDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DetailID          string `json:"detail_id"`
 		AutoNotifyEnabled *bool  `json:"auto_notify_enabled"`
@@ -794,9 +955,9 @@ func (r *Registry) handleUpdateBuildBetaDetail(args json.RawMessage) (*mcp.Tools
 		},
 	}
 
-	resp, err := r.client.UpdateBuildBetaDetail(context.Background(), params.DetailID, req)
+	resp, err := r.client.UpdateBuildBetaDetail(ctx, params.DetailID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update build beta detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update build beta detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Build beta detail updated:\n%s", formatBuildBetaDetail(resp.Data))), nil
@@ -828,6 +989,36 @@ func formatBetaAppReviewSubmission(sub api.BetaAppReviewSubmission) string {
 	return sb.String()
 }
 
+func formatBuildBetaReviewStatus(build api.Build, submission *api.BetaAppReviewSubmission, externalState string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Build: %s (ID: %s)\n", build.Attributes.Version, build.ID))
+	sb.WriteString(fmt.Sprintf("Processing State: %s\n", build.Attributes.ProcessingState))
+	if externalState != "" {
+		sb.WriteString(fmt.Sprintf("External Build State: %s\n", externalState))
+	}
+
+	if submission == nil {
+		sb.WriteString("Beta Review: not submitted\n")
+		sb.WriteString("\nApproved for external testing: no (build has not been submitted for beta app review)\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Beta Review: %s (submission %s)\n", submission.Attributes.BetaReviewState, submission.ID))
+	if submission.Attributes.SubmittedDate != nil {
+		sb.WriteString(fmt.Sprintf("Submitted: %s\n", submission.Attributes.SubmittedDate.Format("2006-01-02 15:04")))
+	}
+
+	switch submission.Attributes.BetaReviewState {
+	case api.BetaReviewStateApproved:
+		sb.WriteString("\nApproved for external testing: yes\n")
+	case api.BetaReviewStateRejected:
+		sb.WriteString("\nApproved for external testing: no (rejected in beta app review)\n")
+	default:
+		sb.WriteString("\nApproved for external testing: not yet (review pending)\n")
+	}
+	return sb.String()
+}
+
 func formatBetaLicenseAgreement(agreement api.BetaLicenseAgreement) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("ID: %s\n", agreement.ID))
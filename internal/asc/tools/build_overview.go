@@ -0,0 +1,218 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// buildOverviewIncludes are the build relationships build_overview reads
+// from a single request.
+var buildOverviewIncludes = []string{
+	"preReleaseVersion",
+	"buildBetaDetail",
+	"betaAppReviewSubmission",
+	"appEncryptionDeclaration",
+	"betaGroups",
+	"betaBuildLocalizations",
+}
+
+// registerBuildOverviewTools registers the tool summarizing a build's state.
+func (r *Registry) registerBuildOverviewTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "build_overview",
+			Description: "Everything about a build's status in one call: processing state, export compliance, TestFlight internal and external states, beta app review, the beta groups it's assigned to, its What to Test notes, TestFlight usage (installs, sessions, crashes, feedback) and when it expires.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"build_id": {
+						Type:        "string",
+						Description: "The build ID",
+					},
+				},
+				Required: []string{"build_id"},
+			},
+			OutputSchema: mcp.SchemaFor(buildOverviewOutput{}),
+		},
+		r.handleBuildOverview,
+	)
+}
+
+// handleBuildOverview handles the build_overview tool.
+func (r *Registry) handleBuildOverview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildID string `json:"build_id"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildID == "" {
+		return mcp.NewErrorResult("build_id is required"), nil
+	}
+
+	resp, err := r.client.GetBuild(ctx, params.BuildID, buildOverviewIncludes...)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get build: %v", err), err), nil
+	}
+	included, err := api.DecodeIncluded(resp.Included)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read build relationships: %v", err), err), nil
+	}
+
+	output := newBuildOverview(resp.Data, included, time.Now())
+
+	usage, err := r.client.GetBuildBetaUsages(ctx, params.BuildID)
+	if err != nil {
+		output.Errors = append(output.Errors, fmt.Sprintf("TestFlight usage: %v", err))
+	} else {
+		output.Usage = sumBuildUsage(usage.Data)
+	}
+
+	return mcp.NewStructuredResult(formatBuildOverview(output), output), nil
+}
+
+// newBuildOverview assembles the overview of build from the resources
+// included with it, counting the days to expiration from now.
+func newBuildOverview(build api.Build, included []any, now time.Time) buildOverviewOutput {
+	attrs := build.Attributes
+	output := buildOverviewOutput{
+		BuildID:         build.ID,
+		Version:         attrs.Version,
+		ProcessingState: string(attrs.ProcessingState),
+		UploadedDate:    attrs.UploadedDate,
+		ExpirationDate:  attrs.ExpirationDate,
+		Expired:         attrs.Expired,
+		ExportCompliance: buildExportCompliance{
+			UsesNonExemptEncryption: attrs.UsesNonExemptEncryption,
+		},
+		BetaGroups:    []buildOverviewGroup{},
+		Localizations: []api.BetaBuildLocalizationAttributes{},
+	}
+	if attrs.ExpirationDate != nil && !attrs.Expired {
+		days := int(math.Ceil(attrs.ExpirationDate.Sub(now).Hours() / 24))
+		output.DaysUntilExpiration = &days
+	}
+
+	for _, resource := range included {
+		switch v := resource.(type) {
+		case api.PreReleaseVersion:
+			output.AppVersion = v.Attributes.Version
+			output.Platform = string(v.Attributes.Platform)
+		case api.BuildBetaDetail:
+			output.InternalBuildState = v.Attributes.InternalBuildState
+			output.ExternalBuildState = v.Attributes.ExternalBuildState
+		case api.BetaAppReviewSubmission:
+			output.BetaReviewState = string(v.Attributes.BetaReviewState)
+			output.BetaReviewSubmitted = v.Attributes.SubmittedDate
+		case api.AppEncryptionDeclaration:
+			output.ExportCompliance.DeclarationID = v.ID
+			output.ExportCompliance.DeclarationState = v.Attributes.AppEncryptionDeclarationState
+		case api.BetaGroup:
+			group := buildOverviewGroup{ID: v.ID, Name: v.Attributes.Name, Internal: v.Attributes.IsInternalGroup}
+			if v.Attributes.PublicLinkEnabled {
+				group.PublicLink = v.Attributes.PublicLink
+			}
+			output.BetaGroups = append(output.BetaGroups, group)
+		case api.BetaBuildLocalization:
+			output.Localizations = append(output.Localizations, v.Attributes)
+		}
+	}
+	output.ExportCompliance.Missing = output.InternalBuildState == "MISSING_EXPORT_COMPLIANCE" ||
+		output.ExternalBuildState == "MISSING_EXPORT_COMPLIANCE"
+	return output
+}
+
+// sumBuildUsage adds up a build's usage over the reporting intervals.
+func sumBuildUsage(usages []api.BetaBuildUsage) *api.BetaBuildUsageValues {
+	var total api.BetaBuildUsageValues
+	for _, usage := range usages {
+		values := usage.DataPoints.Values
+		total.InstallCount += values.InstallCount
+		total.SessionCount += values.SessionCount
+		total.CrashCount += values.CrashCount
+		total.FeedbackCount += values.FeedbackCount
+		total.InviteCount += values.InviteCount
+	}
+	return &total
+}
+
+// formatBuildOverview renders a build overview as text.
+func formatBuildOverview(o buildOverviewOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Build %s** (ID: %s)\n\n", o.Version, o.BuildID))
+	if o.AppVersion != "" {
+		sb.WriteString(fmt.Sprintf("- Version: %s (%s)\n", o.AppVersion, o.Platform))
+	}
+	sb.WriteString(fmt.Sprintf("- Processing State: %s\n", o.ProcessingState))
+	if o.UploadedDate != nil {
+		sb.WriteString(fmt.Sprintf("- Uploaded: %s\n", o.UploadedDate.Format("2006-01-02 15:04:05")))
+	}
+	switch {
+	case o.Expired:
+		sb.WriteString("- Expired: yes\n")
+	case o.DaysUntilExpiration != nil:
+		sb.WriteString(fmt.Sprintf("- Expires: %s (in %d days)\n", o.ExpirationDate.Format("2006-01-02"), *o.DaysUntilExpiration))
+	}
+
+	sb.WriteString("\n**Export compliance**\n")
+	switch {
+	case o.ExportCompliance.Missing:
+		sb.WriteString("- Missing: answer the export compliance questions before testers can install it\n")
+	default:
+		sb.WriteString(fmt.Sprintf("- Uses Non-Exempt Encryption: %v\n", o.ExportCompliance.UsesNonExemptEncryption))
+	}
+	if o.ExportCompliance.DeclarationID != "" {
+		sb.WriteString(fmt.Sprintf("- Encryption Declaration: %s (%s)\n", o.ExportCompliance.DeclarationID, o.ExportCompliance.DeclarationState))
+	}
+
+	sb.WriteString("\n**TestFlight**\n")
+	if o.InternalBuildState != "" {
+		sb.WriteString(fmt.Sprintf("- Internal State: %s\n", o.InternalBuildState))
+	}
+	if o.ExternalBuildState != "" {
+		sb.WriteString(fmt.Sprintf("- External State: %s\n", o.ExternalBuildState))
+	}
+	if o.BetaReviewState != "" {
+		line := fmt.Sprintf("- Beta App Review: %s", o.BetaReviewState)
+		if o.BetaReviewSubmitted != nil {
+			line += fmt.Sprintf(" (submitted %s)", o.BetaReviewSubmitted.Format("2006-01-02"))
+		}
+		sb.WriteString(line + "\n")
+	} else {
+		sb.WriteString("- Beta App Review: not submitted\n")
+	}
+	if len(o.BetaGroups) == 0 {
+		sb.WriteString("- Beta Groups: none\n")
+	}
+	for _, group := range o.BetaGroups {
+		kind := "external"
+		if group.Internal {
+			kind = "internal"
+		}
+		line := fmt.Sprintf("- Beta Group: %s (%s, ID: %s)", group.Name, kind, group.ID)
+		if group.PublicLink != "" {
+			line += " " + group.PublicLink
+		}
+		sb.WriteString(line + "\n")
+	}
+	for _, loc := range o.Localizations {
+		sb.WriteString(fmt.Sprintf("- What to Test (%s): %s\n", loc.Locale, truncateString(loc.WhatsNew, 200)))
+	}
+	if o.Usage != nil {
+		sb.WriteString(fmt.Sprintf("- Usage: %d installs, %d sessions, %d crashes, %d feedback, %d invites\n",
+			o.Usage.InstallCount, o.Usage.SessionCount, o.Usage.CrashCount, o.Usage.FeedbackCount, o.Usage.InviteCount))
+	}
+
+	for _, e := range o.Errors {
+		sb.WriteString(fmt.Sprintf("\nCouldn't get %s\n", e))
+	}
+	return sb.String()
+}
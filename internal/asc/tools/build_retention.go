@@ -0,0 +1,232 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// registerBuildRetentionTools registers the TestFlight build cleanup tool.
+func (r *Registry) registerBuildRetentionTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "expire_old_builds",
+			Description: "Expire an app's TestFlight builds that are older than older_than_days, or that aren't among the keep_latest newest builds of their version train (marketing version and platform). With both, a build is expired only when it is old and not among the newest. Expired builds can no longer be installed by testers, and expiring cannot be undone, so run with dry_run first to list the builds that would be expired.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"older_than_days": {
+						Type:        "number",
+						Description: "Expire builds uploaded at least this many days ago",
+					},
+					"keep_latest": {
+						Type:        "integer",
+						Description: "Keep this many of the newest builds of each version train",
+					},
+					"platform": {
+						Type:        "string",
+						Description: "Optional: Only consider builds for this platform",
+						Enum:        []string{"IOS", "MAC_OS", "TV_OS", "VISION_OS"},
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "List the builds that would be expired without expiring any (default: false)",
+					},
+				},
+				Required: []string{"app_id"},
+			},
+			OutputSchema: mcp.SchemaFor(buildRetentionOutput{}),
+		},
+		r.handleExpireOldBuilds,
+	)
+}
+
+// handleExpireOldBuilds handles the expire_old_builds tool.
+func (r *Registry) handleExpireOldBuilds(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID         string   `json:"app_id"`
+		OlderThanDays *float64 `json:"older_than_days"`
+		KeepLatest    *int     `json:"keep_latest"`
+		Platform      string   `json:"platform"`
+		DryRun        bool     `json:"dry_run"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" {
+		return mcp.NewErrorResult("app_id is required"), nil
+	}
+	if params.OlderThanDays == nil && params.KeepLatest == nil {
+		return mcp.NewErrorResult("older_than_days or keep_latest is required"), nil
+	}
+	if params.OlderThanDays != nil && *params.OlderThanDays < 0 {
+		return mcp.NewErrorResult("older_than_days must not be negative"), nil
+	}
+	if params.KeepLatest != nil && *params.KeepLatest < 0 {
+		return mcp.NewErrorResult("keep_latest must not be negative"), nil
+	}
+
+	builds, trains, err := r.listUnexpiredBuilds(ctx, params.AppID, params.Platform)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list builds: %v", err), err), nil
+	}
+
+	output := buildRetentionOutput{
+		AppID:         params.AppID,
+		OlderThanDays: params.OlderThanDays,
+		KeepLatest:    params.KeepLatest,
+		DryRun:        params.DryRun,
+		Expired:       make([]retainedBuild, 0),
+		Errors:        make([]string, 0),
+	}
+
+	byTrain := map[string][]api.Build{}
+	for _, b := range builds {
+		train := ""
+		if b.Relationships != nil && b.Relationships.PreReleaseVersion != nil {
+			train = b.Relationships.PreReleaseVersion.Data.ID
+		}
+		byTrain[train] = append(byTrain[train], b)
+	}
+
+	now := time.Now()
+	var candidates []retainedBuild
+	for trainID, trainBuilds := range byTrain {
+		// Newest first, so a build's index is its rank in the train.
+		slices.SortFunc(trainBuilds, func(a, b api.Build) int {
+			return compareUploaded(b, a)
+		})
+		train := trains[trainID]
+		for rank, b := range trainBuilds {
+			if params.KeepLatest != nil && rank < *params.KeepLatest {
+				output.Kept++
+				continue
+			}
+			// Builds still uploading have no date yet and are always kept.
+			uploaded := b.Attributes.UploadedDate
+			if uploaded == nil || params.OlderThanDays != nil && now.Sub(*uploaded) < time.Duration(*params.OlderThanDays*float64(24*time.Hour)) {
+				output.Kept++
+				continue
+			}
+
+			candidates = append(candidates, retainedBuild{
+				ID:           b.ID,
+				BuildNumber:  b.Attributes.Version,
+				Version:      train.Attributes.Version,
+				Platform:     string(train.Attributes.Platform),
+				UploadedDate: uploaded.Format(time.RFC3339),
+				AgeDays:      now.Sub(*uploaded).Hours() / 24,
+			})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b retainedBuild) int {
+		return cmp.Or(cmp.Compare(a.Version, b.Version), cmp.Compare(a.UploadedDate, b.UploadedDate))
+	})
+
+	expired := true
+	for _, c := range candidates {
+		if params.DryRun {
+			output.Expired = append(output.Expired, c)
+			continue
+		}
+		req := &api.BuildUpdateRequest{
+			Data: api.BuildUpdateData{
+				Type:       "builds",
+				ID:         c.ID,
+				Attributes: api.BuildUpdateAttributes{Expired: &expired},
+			},
+		}
+		if _, err := r.client.UpdateBuild(ctx, c.ID, req); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			output.Errors = append(output.Errors, fmt.Sprintf("Failed to expire build %s (%s): %v", c.BuildNumber, c.ID, err))
+			continue
+		}
+		output.Expired = append(output.Expired, c)
+	}
+
+	return mcp.NewStructuredResult(formatBuildRetention(output), output), nil
+}
+
+// listUnexpiredBuilds returns an app's unexpired builds, optionally for one
+// platform, and the prerelease versions they belong to by ID.
+func (r *Registry) listUnexpiredBuilds(ctx context.Context, appID, platform string) ([]api.Build, map[string]api.PreReleaseVersion, error) {
+	opts := api.ListOptions{Limit: 200}.
+		WithFilter("preReleaseVersion.platform", platform).
+		WithFilter("expired", "false").
+		WithInclude("preReleaseVersion")
+
+	var builds []api.Build
+	trains := map[string]api.PreReleaseVersion{}
+	list := func(ctx context.Context) (*api.BuildsResponse, error) {
+		return r.client.ListBuilds(ctx, appID, opts)
+	}
+	links := func(resp *api.BuildsResponse) api.PagedDocumentLinks { return resp.Links }
+	for resp, err := range api.Pages(ctx, 0, list, links) {
+		if err != nil {
+			return nil, nil, err
+		}
+		builds = append(builds, resp.Data...)
+		versions, err := api.IncludedOf[api.PreReleaseVersion](resp.Included)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range versions {
+			trains[v.ID] = v
+		}
+	}
+	return builds, trains, nil
+}
+
+// compareUploaded orders builds by upload date, with builds that have none
+// first.
+func compareUploaded(a, b api.Build) int {
+	switch ua, ub := a.Attributes.UploadedDate, b.Attributes.UploadedDate; {
+	case ua == nil && ub == nil:
+		return 0
+	case ua == nil:
+		return -1
+	case ub == nil:
+		return 1
+	default:
+		return ua.Compare(*ub)
+	}
+}
+
+// formatBuildRetention summarizes a build cleanup.
+func formatBuildRetention(o buildRetentionOutput) string {
+	var sb strings.Builder
+	if o.DryRun {
+		sb.WriteString(fmt.Sprintf("Dry run: would expire %d builds of app %s and keep %d\n", len(o.Expired), o.AppID, o.Kept))
+	} else {
+		sb.WriteString(fmt.Sprintf("Expired %d builds of app %s and kept %d\n", len(o.Expired), o.AppID, o.Kept))
+	}
+
+	if len(o.Expired) > 0 {
+		sb.WriteString("\n")
+		for _, b := range o.Expired {
+			sb.WriteString(fmt.Sprintf("- %s (%s) build %s, ID %s, uploaded %.0f days ago\n", b.Version, b.Platform, b.BuildNumber, b.ID, b.AgeDays))
+		}
+	}
+	if len(o.Errors) > 0 {
+		sb.WriteString("\nErrors:\n")
+		for _, e := range o.Errors {
+			sb.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+	}
+	return sb.String()
+}
@@ -5,17 +5,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
 
+// buildFields are the build attributes list_builds can limit its results to.
+var buildFields = []string{
+	"version", "uploadedDate", "expirationDate", "expired", "minOsVersion",
+	"lsMinimumSystemVersion", "computedMinMacOsVersion", "iconAssetToken",
+	"processingState", "buildAudienceType", "usesNonExemptEncryption",
+}
+
 // registerBuildTools registers build management tools.
 func (r *Registry) registerBuildTools() {
 	r.register(
 		mcp.Tool{
 			Name:        "list_builds",
-			Description: "List builds for your apps. Can filter by app ID. Returns version, processing state, upload date, and expiration information.",
+			Description: "List builds for your apps. Can filter by app, marketing version, build number, platform, processing state, beta review state, and expiry. Returns version, processing state, upload date, and expiration information.",
 			InputSchema: mcp.JSONSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -23,13 +33,45 @@ func (r *Registry) registerBuildTools() {
 						Type:        "string",
 						Description: "Optional: Filter builds by app ID",
 					},
+					"version": {
+						Type:        "string",
+						Description: "Optional: Filter by marketing version (e.g. 2.4.0)",
+					},
+					"build_number": {
+						Type:        "string",
+						Description: "Optional: Filter by build number (CFBundleVersion)",
+					},
+					"platform": {
+						Type:        "string",
+						Description: "Optional: Filter by platform",
+						Enum:        api.EnumStrings(api.Platforms),
+					},
+					"processing_state": {
+						Type:        "string",
+						Description: "Optional: Filter by processing state; comma-separate multiple values (PROCESSING, FAILED, INVALID, VALID)",
+					},
+					"beta_review_state": {
+						Type:        "string",
+						Description: "Optional: Filter by beta app review state; comma-separate multiple values (WAITING_FOR_REVIEW, IN_REVIEW, REJECTED, APPROVED)",
+					},
+					"expired": {
+						Type:        "boolean",
+						Description: "Optional: Only expired (true) or unexpired (false) builds",
+					},
 					"limit": {
 						Type:        "integer",
 						Description: "Maximum number of builds to return (default: 20, max: 200)",
 						Default:     20,
 					},
+					"fields": {
+						Type:        "array",
+						Description: "Optional: Only return these build attributes, to keep large listings small (version is always returned)",
+						Items:       &mcp.Property{Type: "string", Enum: buildFields},
+					},
+					"cursor": cursorProperty,
 				},
 			},
+			OutputSchema: mcp.SchemaFor(buildsOutput{}),
 		},
 		r.handleListBuilds,
 	)
@@ -48,16 +90,53 @@ func (r *Registry) registerBuildTools() {
 				},
 				Required: []string{"build_id"},
 			},
+			OutputSchema: mcp.SchemaFor(api.Build{}),
 		},
 		r.handleGetBuild,
 	)
+
+	r.registerWithProgress(
+		mcp.Tool{
+			Name:        "wait_for_build_processing",
+			Description: "Wait until an uploaded build finishes processing, reporting progress while it polls. Returns the final processing state (VALID, FAILED, or INVALID).",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"build_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the build",
+					},
+					"timeout_seconds": {
+						Type:        "integer",
+						Description: "Maximum time to wait (default: 600, max: 1800)",
+						Default:     600,
+					},
+					"interval_seconds": {
+						Type:        "integer",
+						Description: "Delay between status checks (default: 15)",
+						Default:     15,
+					},
+				},
+				Required: []string{"build_id"},
+			},
+		},
+		r.handleWaitForBuildProcessing,
+	)
 }
 
 // handleListBuilds handles the list_builds tool.
-func (r *Registry) handleListBuilds(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBuilds(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID           string   `json:"app_id"`
+		Version         string   `json:"version"`
+		BuildNumber     string   `json:"build_number"`
+		Platform        string   `json:"platform"`
+		ProcessingState string   `json:"processing_state"`
+		BetaReviewState string   `json:"beta_review_state"`
+		Expired         *bool    `json:"expired"`
+		Limit           int      `json:"limit"`
+		Fields          []string `json:"fields"`
+		Cursor          string   `json:"cursor"`
 	}
 	params.Limit = 20
 
@@ -73,15 +152,33 @@ func (r *Registry) handleListBuilds(args json.RawMessage) (*mcp.ToolsCallResult,
 	if params.Limit > 200 {
 		params.Limit = 200
 	}
+	if len(params.Fields) > 0 && !slices.Contains(params.Fields, "version") {
+		params.Fields = append(params.Fields, "version")
+	}
+	shown := func(field string) bool {
+		return len(params.Fields) == 0 || slices.Contains(params.Fields, field)
+	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListBuilds(ctx, params.AppID, params.Limit)
+	opts := api.ListOptions{Limit: params.Limit}.
+		WithFilter("version", params.BuildNumber).
+		WithFilter("preReleaseVersion.version", params.Version).
+		WithFilter("preReleaseVersion.platform", params.Platform).
+		WithFilter("processingState", params.ProcessingState).
+		WithFilter("betaAppReviewSubmission.betaReviewState", params.BetaReviewState)
+	if params.Expired != nil {
+		opts = opts.WithFilter("expired", strconv.FormatBool(*params.Expired))
+	}
+	if len(params.Fields) > 0 {
+		opts = opts.WithFields("builds", params.Fields...)
+	}
+
+	resp, err := r.client.ListBuilds(api.WithCursor(ctx, params.Cursor), params.AppID, opts)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list builds: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list builds: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
-		return mcp.NewSuccessResult("No builds found."), nil
+		return mcp.NewStructuredResult("No builds found.", buildsOutput{Builds: []api.Build{}}), nil
 	}
 
 	var sb strings.Builder
@@ -90,9 +187,15 @@ func (r *Registry) handleListBuilds(args json.RawMessage) (*mcp.ToolsCallResult,
 	for _, build := range resp.Data {
 		sb.WriteString(fmt.Sprintf("**Build %s**\n", build.Attributes.Version))
 		sb.WriteString(fmt.Sprintf("  - ID: %s\n", build.ID))
-		sb.WriteString(fmt.Sprintf("  - Processing State: %s\n", build.Attributes.ProcessingState))
-		sb.WriteString(fmt.Sprintf("  - Min OS Version: %s\n", build.Attributes.MinOsVersion))
-		sb.WriteString(fmt.Sprintf("  - Expired: %v\n", build.Attributes.Expired))
+		if shown("processingState") {
+			sb.WriteString(fmt.Sprintf("  - Processing State: %s\n", build.Attributes.ProcessingState))
+		}
+		if shown("minOsVersion") {
+			sb.WriteString(fmt.Sprintf("  - Min OS Version: %s\n", build.Attributes.MinOsVersion))
+		}
+		if shown("expired") {
+			sb.WriteString(fmt.Sprintf("  - Expired: %v\n", build.Attributes.Expired))
+		}
 		if build.Attributes.UploadedDate != nil {
 			sb.WriteString(fmt.Sprintf("  - Uploaded: %s\n", build.Attributes.UploadedDate.Format("2006-01-02 15:04")))
 		}
@@ -102,11 +205,12 @@ func (r *Registry) handleListBuilds(args json.RawMessage) (*mcp.ToolsCallResult,
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	output := buildsOutput{Builds: resp.Data, NextCursor: resp.Links.NextCursor()}
+	return mcp.NewStructuredResult(withNextCursor(sb.String(), resp.Links), output), nil
 }
 
 // handleGetBuild handles the get_build tool.
-func (r *Registry) handleGetBuild(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBuild(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID string `json:"build_id"`
 	}
@@ -119,16 +223,18 @@ func (r *Registry) handleGetBuild(args json.RawMessage) (*mcp.ToolsCallResult, e
 		return mcp.NewErrorResult("build_id is required"), nil
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.GetBuild(ctx, params.BuildID)
+	resp, err := r.client.GetBuild(ctx, params.BuildID, "preReleaseVersion")
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get build: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get build: %v", err), err), nil
 	}
 
 	build := resp.Data
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("**Build %s**\n\n", build.Attributes.Version))
 	sb.WriteString(fmt.Sprintf("- ID: %s\n", build.ID))
+	if versions, err := api.IncludedOf[api.PreReleaseVersion](resp.Included); err == nil && len(versions) > 0 {
+		sb.WriteString(fmt.Sprintf("- Version: %s (%s)\n", versions[0].Attributes.Version, versions[0].Attributes.Platform))
+	}
 	sb.WriteString(fmt.Sprintf("- Processing State: %s\n", build.Attributes.ProcessingState))
 	sb.WriteString(fmt.Sprintf("- Min OS Version: %s\n", build.Attributes.MinOsVersion))
 	sb.WriteString(fmt.Sprintf("- Build Audience Type: %s\n", build.Attributes.BuildAudienceType))
@@ -142,5 +248,40 @@ func (r *Registry) handleGetBuild(args json.RawMessage) (*mcp.ToolsCallResult, e
 		sb.WriteString(fmt.Sprintf("- Expires: %s\n", build.Attributes.ExpirationDate.Format("2006-01-02")))
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewStructuredResult(sb.String(), build), nil
+}
+
+// handleWaitForBuildProcessing handles the wait_for_build_processing tool.
+func (r *Registry) handleWaitForBuildProcessing(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildID         string `json:"build_id"`
+		TimeoutSeconds  int    `json:"timeout_seconds"`
+		IntervalSeconds int    `json:"interval_seconds"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildID == "" {
+		return mcp.NewErrorResult("build_id is required"), nil
+	}
+
+	timeout, interval := pollDurations(params.TimeoutSeconds, params.IntervalSeconds)
+
+	var version string
+	state, err := pollUntil(ctx, timeout, interval, progress, func() (bool, string, error) {
+		resp, err := r.client.GetBuild(ctx, params.BuildID)
+		if err != nil {
+			return false, "", err
+		}
+		version = resp.Data.Attributes.Version
+		state := resp.Data.Attributes.ProcessingState
+		return state != api.ProcessingStateProcessing, string(state), nil
+	})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed waiting for build %s: %v", params.BuildID, err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Build %s (%s) finished processing: %s", version, params.BuildID, state)), nil
 }
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// refreshProperty is the input schema property of list tools whose responses
+// the client caches.
+var refreshProperty = mcp.Property{
+	Type:        "boolean",
+	Description: "Optional: Fetch fresh data instead of reusing a cached response",
+}
+
+// withRefresh returns a context that bypasses the response cache if refresh is set.
+func withRefresh(ctx context.Context, refresh bool) context.Context {
+	if !refresh {
+		return ctx
+	}
+	return api.WithRefresh(ctx)
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// roleProbe checks whether an API key may call a group of tools, by making a
+// request that the API refuses with a 403 without the needed role.
+type roleProbe struct {
+	role  string
+	tools []string
+	probe func(ctx context.Context, client *api.Client) error
+}
+
+// roleProbes are the roles whose tools are hidden from keys without them.
+// The probes ask for a report of a vendor that doesn't exist, which fails
+// with a 403 before the vendor is looked up if the key lacks the role.
+var roleProbes = []roleProbe{
+	{
+		role:  "Sales",
+		tools: []string{"get_sales_report"},
+		probe: func(ctx context.Context, client *api.Client) error {
+			_, err := client.GetSalesReport(ctx, "0", "SALES", "SUMMARY", "DAILY", "")
+			return err
+		},
+	},
+	{
+		role:  "Finance",
+		tools: []string{"get_finance_report"},
+		probe: func(ctx context.Context, client *api.Client) error {
+			_, err := client.GetFinanceReport(ctx, "0", "ZZ", "FINANCIAL", "")
+			return err
+		},
+	},
+}
+
+// capabilities tracks which tools each team's API key is denied, and who to
+// tell when the tool list changes.
+type capabilities struct {
+	mu        sync.Mutex
+	probing   bool
+	denied    map[string][]string
+	listeners map[int]func()
+	nextID    int
+}
+
+// ProbeCapabilities checks which report roles the selected team's API key
+// has, and hides the tools it can't use from ListTools. Teams selected later
+// are probed too. A probe that fails for any other reason than a 403 leaves
+// its tools listed. Since it may finish after tools were listed, the
+// OnToolsChanged listeners are told when it hides any.
+func (r *Registry) ProbeCapabilities(ctx context.Context) {
+	r.capabilities.mu.Lock()
+	r.capabilities.probing = true
+	r.capabilities.mu.Unlock()
+
+	r.probeTeam(ctx, r.client.ActiveTeam())
+	if len(r.deniedTools()) > 0 {
+		r.notifyToolsChanged()
+	}
+}
+
+// probeTeam probes a team's API key, unless it has been probed already or
+// probing is off.
+func (r *Registry) probeTeam(ctx context.Context, team string) {
+	c := &r.capabilities
+	c.mu.Lock()
+	_, probed := c.denied[team]
+	probing := c.probing
+	c.mu.Unlock()
+	if probed || !probing {
+		return
+	}
+
+	ctx = api.WithTeam(ctx, team)
+	denied := make([]string, 0)
+	for _, p := range roleProbes {
+		err := p.probe(ctx, r.client)
+		if api.IsForbidden(err) {
+			log.Printf("team %s has no %s role; hiding %s", team, p.role, strings.Join(p.tools, ", "))
+			denied = append(denied, p.tools...)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.denied == nil {
+		c.denied = make(map[string][]string)
+	}
+	c.denied[team] = denied
+}
+
+// forgetTeamCapabilities drops what was probed of a team's API key, so the
+// next probeTeam checks its key again.
+func (r *Registry) forgetTeamCapabilities(team string) {
+	r.capabilities.mu.Lock()
+	defer r.capabilities.mu.Unlock()
+	delete(r.capabilities.denied, team)
+}
+
+// deniedTools returns the tools hidden for the selected team.
+func (r *Registry) deniedTools() []string {
+	if r.client == nil {
+		return nil
+	}
+	r.capabilities.mu.Lock()
+	defer r.capabilities.mu.Unlock()
+	return r.capabilities.denied[r.client.ActiveTeam()]
+}
+
+// listedTools returns the tools to list, leaving out those the selected
+// team's key is denied.
+func (r *Registry) listedTools() []mcp.Tool {
+	tools := r.publicTools()
+	denied := r.deniedTools()
+	if len(denied) == 0 {
+		return tools
+	}
+
+	listed := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if !slices.Contains(denied, r.ResolveToolName(tool.Name)) {
+			listed = append(listed, tool)
+		}
+	}
+	return listed
+}
+
+// OnToolsChanged registers fn to be called when the tools ListTools returns
+// may have changed, such as after selecting a team whose key has other
+// roles. It returns a function that removes fn.
+func (r *Registry) OnToolsChanged(fn func()) (remove func()) {
+	c := &r.capabilities
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.listeners == nil {
+		c.listeners = make(map[int]func())
+	}
+	id := c.nextID
+	c.nextID++
+	c.listeners[id] = fn
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.listeners, id)
+	}
+}
+
+// notifyToolsChanged calls the OnToolsChanged listeners.
+func (r *Registry) notifyToolsChanged() {
+	r.capabilities.mu.Lock()
+	listeners := make([]func(), 0, len(r.capabilities.listeners))
+	for _, fn := range r.capabilities.listeners {
+		listeners = append(listeners, fn)
+	}
+	r.capabilities.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
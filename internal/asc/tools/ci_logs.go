@@ -0,0 +1,394 @@
+package tools
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+const (
+	// maxLogBundleBytes caps the size of a downloaded log bundle.
+	maxLogBundleBytes = 100 << 20
+
+	// defaultMaxFailures is the default number of failures reported per category.
+	defaultMaxFailures = 50
+)
+
+var (
+	// compilerErrorPattern matches clang/swiftc diagnostics: "path:line[:col]: error: message".
+	compilerErrorPattern = regexp.MustCompile(`^(\S.*?):(\d+):(?:\d+:)?\s+(?:fatal )?error:\s+(.+)$`)
+
+	// bareErrorPattern matches file-less errors such as linker and xcodebuild failures.
+	bareErrorPattern = regexp.MustCompile(`^(?:ld: |clang: |xcodebuild: )?error:\s+(.+)$`)
+
+	// xctestFailurePattern matches "Test Case '-[Module.Class testName]' failed".
+	xctestFailurePattern = regexp.MustCompile(`Test [Cc]ase '-\[(\S+) (\S+)\]' failed`)
+
+	// xctestParallelFailurePattern matches "Test case 'Class.testName()' failed on ...".
+	xctestParallelFailurePattern = regexp.MustCompile(`Test [Cc]ase '([^']+)' failed`)
+
+	// swiftTestingFailurePattern matches swift-testing output such as `✘ Test name() failed`.
+	swiftTestingFailurePattern = regexp.MustCompile(`✘ Test (.+?) (?:failed|recorded an issue)`)
+)
+
+// logFailures holds compiler errors and failing tests extracted from build output.
+type logFailures struct {
+	Errors []string
+	Tests  []string
+}
+
+// registerCiLogTools registers Xcode Cloud build action and log tools.
+func (r *Registry) registerCiLogTools() {
+	// List CI build actions
+	r.register(mcp.Tool{
+		Name:        "list_ci_build_actions",
+		Description: "List the actions (build, test, analyze, archive) of an Xcode Cloud build run with their status and issue counts",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"build_run_id": {
+					Type:        "string",
+					Description: "The CI build run ID",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of actions to return (default 50)",
+				},
+				"cursor": cursorProperty,
+			},
+			Required: []string{"build_run_id"},
+		},
+	}, r.handleListCiBuildActions)
+
+	// Get CI build failures
+	r.register(mcp.Tool{
+		Name:        "get_ci_build_failures",
+		Description: "Summarize why an Xcode Cloud build run failed: compiler errors and failing tests from issue reports, test results, and optionally the downloaded log bundles",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"build_run_id": {
+					Type:        "string",
+					Description: "The CI build run ID",
+				},
+				"include_logs": {
+					Type:        "boolean",
+					Description: "Download log bundles and extract errors from them (slower; default false)",
+				},
+				"max_items": {
+					Type:        "integer",
+					Description: "Maximum number of errors and failing tests to report per category (default 50)",
+				},
+			},
+			Required: []string{"build_run_id"},
+		},
+	}, r.handleGetCiBuildFailures)
+}
+
+func (r *Registry) handleListCiBuildActions(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildRunID string `json:"build_run_id"`
+		Limit      int    `json:"limit"`
+		Cursor     string `json:"cursor"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildRunID == "" {
+		return nil, fmt.Errorf("build_run_id is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	resp, err := r.client.ListCiBuildActions(api.WithCursor(ctx, params.Cursor), params.BuildRunID, api.ListOptions{Limit: limit})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI build actions: %v", err), err), nil
+	}
+
+	if len(resp.Data) == 0 {
+		return mcp.NewSuccessResult("No CI build actions found"), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d CI build actions:\n\n", len(resp.Data)))
+
+	for _, action := range resp.Data {
+		sb.WriteString(fmt.Sprintf("ID: %s\n", action.ID))
+		sb.WriteString(fmt.Sprintf("Name: %s\n", action.Attributes.Name))
+		sb.WriteString(fmt.Sprintf("Type: %s\n", action.Attributes.ActionType))
+		sb.WriteString(fmt.Sprintf("Progress: %s\n", action.Attributes.ExecutionProgress))
+		if action.Attributes.CompletionStatus != "" {
+			sb.WriteString(fmt.Sprintf("Status: %s\n", action.Attributes.CompletionStatus))
+		}
+		sb.WriteString(fmt.Sprintf("Required To Pass: %t\n", action.Attributes.IsRequiredToPass))
+		if c := action.Attributes.IssueCounts; c != nil {
+			sb.WriteString(fmt.Sprintf("Issues: %d errors, %d test failures, %d warnings, %d analyzer warnings\n",
+				c.Errors, c.TestFailures, c.Warnings, c.AnalyzerWarnings))
+		}
+		sb.WriteString("\n---\n")
+	}
+
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
+}
+
+func (r *Registry) handleGetCiBuildFailures(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildRunID  string `json:"build_run_id"`
+		IncludeLogs bool   `json:"include_logs"`
+		MaxItems    int    `json:"max_items"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildRunID == "" {
+		return nil, fmt.Errorf("build_run_id is required")
+	}
+
+	maxItems := params.MaxItems
+	if maxItems <= 0 {
+		maxItems = defaultMaxFailures
+	}
+
+	actions, err := r.client.ListCiBuildActions(ctx, params.BuildRunID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI build actions: %v", err), err), nil
+	}
+
+	var sb strings.Builder
+	failedActions := 0
+
+	for _, action := range actions.Data {
+		if !actionHasFailures(action) {
+			continue
+		}
+		failedActions++
+
+		failures := logFailures{}
+		var notes []string
+
+		issues, err := r.client.ListCiIssues(ctx, action.ID, api.ListOptions{Limit: 200})
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("could not load issues: %v", err))
+		} else {
+			for _, issue := range issues.Data {
+				switch issue.Attributes.IssueType {
+				case "ERROR":
+					failures.Errors = append(failures.Errors, formatCiIssue(issue))
+				case "TEST_FAILURE":
+					failures.Tests = append(failures.Tests, formatCiIssue(issue))
+				}
+			}
+		}
+
+		if action.Attributes.ActionType == "TEST" {
+			results, err := r.client.ListCiTestResults(ctx, action.ID, api.ListOptions{Limit: 200})
+			if err != nil {
+				notes = append(notes, fmt.Sprintf("could not load test results: %v", err))
+			} else {
+				for _, result := range results.Data {
+					if result.Attributes.Status != "FAILURE" {
+						continue
+					}
+					failures.Tests = append(failures.Tests, formatCiTestResult(result))
+				}
+			}
+		}
+
+		if params.IncludeLogs {
+			logs, err := r.extractActionLogFailures(ctx, action.ID)
+			if err != nil {
+				notes = append(notes, fmt.Sprintf("could not extract logs: %v", err))
+			}
+			failures.Errors = append(failures.Errors, logs.Errors...)
+			failures.Tests = append(failures.Tests, logs.Tests...)
+		}
+
+		sb.WriteString(fmt.Sprintf("**%s** (%s, %s)\n\n", action.Attributes.Name, action.Attributes.ActionType, action.Attributes.CompletionStatus))
+		writeFailureList(&sb, "Errors", dedupe(failures.Errors), maxItems)
+		writeFailureList(&sb, "Failing Tests", dedupe(failures.Tests), maxItems)
+		for _, note := range notes {
+			sb.WriteString(fmt.Sprintf("Note: %s\n", note))
+		}
+		sb.WriteString("\n")
+	}
+
+	if failedActions == 0 {
+		return mcp.NewSuccessResult(fmt.Sprintf("No failed actions found for build run %s", params.BuildRunID)), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Found %d failed actions:\n\n%s", failedActions, sb.String())), nil
+}
+
+// extractActionLogFailures downloads an action's log bundles and extracts failures from them.
+func (r *Registry) extractActionLogFailures(ctx context.Context, actionID string) (logFailures, error) {
+	var failures logFailures
+
+	artifacts, err := r.client.ListCiArtifacts(ctx, actionID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return failures, err
+	}
+
+	for _, artifact := range artifacts.Data {
+		if artifact.Attributes.FileType != "LOG_BUNDLE" || artifact.Attributes.DownloadURL == "" {
+			continue
+		}
+
+		data, err := r.client.DownloadAll(ctx, artifact.Attributes.DownloadURL, maxLogBundleBytes)
+		if err != nil {
+			return failures, err
+		}
+
+		found, err := extractLogBundleFailures(data)
+		failures.Errors = append(failures.Errors, found.Errors...)
+		failures.Tests = append(failures.Tests, found.Tests...)
+		if err != nil {
+			return failures, fmt.Errorf("%s: %w", artifact.Attributes.FileName, err)
+		}
+	}
+
+	return failures, nil
+}
+
+// extractLogBundleFailures scans the plain-text logs inside a zipped log bundle.
+func extractLogBundleFailures(data []byte) (logFailures, error) {
+	var failures logFailures
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return failures, fmt.Errorf("invalid log bundle: %w", err)
+	}
+
+	for _, f := range zr.File {
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".log", ".txt":
+		default:
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return failures, err
+		}
+		found, err := extractLogFailures(rc)
+		rc.Close()
+
+		failures.Errors = append(failures.Errors, found.Errors...)
+		failures.Tests = append(failures.Tests, found.Tests...)
+		if err != nil {
+			return failures, fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+
+	return failures, nil
+}
+
+// extractLogFailures scans xcodebuild output for compiler errors and failing tests.
+// If the scan stops early, the failures found so far are returned with the error.
+func extractLogFailures(r io.Reader) (logFailures, error) {
+	var failures logFailures
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if m := compilerErrorPattern.FindStringSubmatch(line); m != nil {
+			failures.Errors = append(failures.Errors, fmt.Sprintf("%s:%s: %s", m[1], m[2], m[3]))
+			continue
+		}
+		if m := bareErrorPattern.FindStringSubmatch(line); m != nil {
+			failures.Errors = append(failures.Errors, m[1])
+			continue
+		}
+		if m := xctestFailurePattern.FindStringSubmatch(line); m != nil {
+			failures.Tests = append(failures.Tests, m[1]+"."+m[2])
+			continue
+		}
+		if m := xctestParallelFailurePattern.FindStringSubmatch(line); m != nil {
+			failures.Tests = append(failures.Tests, strings.TrimSuffix(m[1], "()"))
+			continue
+		}
+		if m := swiftTestingFailurePattern.FindStringSubmatch(line); m != nil {
+			failures.Tests = append(failures.Tests, strings.TrimSuffix(m[1], "()"))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return failures, fmt.Errorf("log only partly scanned: %w", err)
+	}
+
+	return failures, nil
+}
+
+// actionHasFailures reports whether a build action failed or reported errors.
+func actionHasFailures(action api.CiBuildAction) bool {
+	switch action.Attributes.CompletionStatus {
+	case "FAILED", "ERRORED":
+		return true
+	}
+	c := action.Attributes.IssueCounts
+	return c != nil && (c.Errors > 0 || c.TestFailures > 0)
+}
+
+func formatCiIssue(issue api.CiIssue) string {
+	if loc := issue.Attributes.FileSource; loc != nil && loc.Path != "" {
+		return fmt.Sprintf("%s:%d: %s", loc.Path, loc.LineNumber, issue.Attributes.Message)
+	}
+	return issue.Attributes.Message
+}
+
+func formatCiTestResult(result api.CiTestResult) string {
+	name := result.Attributes.Name
+	if result.Attributes.ClassName != "" {
+		name = result.Attributes.ClassName + "." + name
+	}
+	if result.Attributes.Message != "" {
+		return fmt.Sprintf("%s: %s", name, result.Attributes.Message)
+	}
+	return name
+}
+
+// writeFailureList writes up to max items under a heading, noting how many were omitted.
+func writeFailureList(sb *strings.Builder, title string, items []string, max int) {
+	if len(items) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("%s (%d):\n", title, len(items)))
+	for i, item := range items {
+		if i == max {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(items)-max))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  - %s\n", item))
+	}
+}
+
+// dedupe removes duplicate strings while preserving order.
+func dedupe(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		out = append(out, item)
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// destructiveToolPrefixes identifies tools that delete data or submit work
+// to Apple and therefore require confirmation when confirmation mode is on.
+var destructiveToolPrefixes = []string{"delete_", "remove_", "submit_", "withdraw_", "cancel_"}
+
+// destructiveTools lists destructive tools whose names don't follow the prefixes.
+var destructiveTools = map[string]bool{
+	"create_beta_app_review_submission":              true,
+	"asc_api_request":                                true,
+	"run_release_train":                              true,
+	"expire_old_builds":                              true,
+	"deactivate_subscription_offer_code_custom_code": true,
+	"archive_app_event":                              true,
+}
+
+// confirmProperty is added to the input schema of destructive tools in confirmation mode.
+var confirmProperty = mcp.Property{
+	Type:        "boolean",
+	Description: "Set to true to execute. Without it, the tool only previews the API request it would send.",
+}
+
+// EnableConfirmation makes destructive tools preview the API request they
+// would send, and only execute when called again with "confirm": true.
+func (r *Registry) EnableConfirmation() {
+	r.requireConfirmation = true
+	for i := range r.tools {
+		if isDestructiveTool(r.tools[i].Name) {
+			addProperty(&r.tools[i], "confirm", confirmProperty)
+		}
+	}
+}
+
+// isDestructiveTool reports whether a tool deletes data or submits work to Apple.
+func isDestructiveTool(name string) bool {
+	if destructiveTools[name] {
+		return true
+	}
+	for _, prefix := range destructiveToolPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfirmed reports whether the arguments include "confirm": true.
+func isConfirmed(args json.RawMessage) bool {
+	var params struct {
+		Confirm bool `json:"confirm"`
+	}
+	if len(args) == 0 {
+		return false
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return false
+	}
+	return params.Confirm
+}
+
+// previewToolCall runs a tool with mutating API requests captured instead of
+// sent, and describes the captured requests. Lookups made by the tool still run.
+// If the tool made no mutating request, for example because an argument was
+// invalid, its own result is returned.
+func previewToolCall(ctx context.Context, name string, handler ToolHandler, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	ctx, dryRun := api.WithDryRun(ctx)
+
+	result, err := handler(ctx, args)
+	planned := dryRun.Requests()
+	if len(planned) == 0 {
+		return result, err
+	}
+
+	return mcp.NewSuccessResult(formatPlannedRequests(name, planned)), nil
+}
+
+// formatPlannedRequests describes the requests a tool would send.
+func formatPlannedRequests(name string, planned []api.PlannedRequest) string {
+	var sb strings.Builder
+	sb.WriteString("Preview only. Nothing was changed.\n\n")
+	sb.WriteString(fmt.Sprintf("`%s` would send:\n\n", name))
+
+	for _, req := range planned {
+		path := req.Path
+		if len(req.Query) > 0 {
+			path += "?" + req.Query.Encode()
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", req.Method, path))
+
+		if req.Body != nil {
+			body, err := json.MarshalIndent(req.Body, "", "  ")
+			if err == nil {
+				sb.WriteString(fmt.Sprintf("```json\n%s\n```\n", body))
+			}
+		}
+	}
+
+	sb.WriteString("\nFurther requests may follow once this one succeeds.\n")
+	sb.WriteString(fmt.Sprintf("Check the IDs above, then call `%s` again with the same arguments and \"confirm\": true to execute.\n", name))
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// registerCredentialTools registers API key management tools.
+func (r *Registry) registerCredentialTools() {
+	r.register(mcp.Tool{
+		Name:        "rotate_credentials",
+		Description: "Switch a team's API key to a new one without restarting the server. The new key must sign a token that App Store Connect accepts before it replaces the old key; otherwise the old key stays in use. Calls in progress finish with the old key. The change lasts until the server restarts, so update the environment or secret store too.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"key_id": {
+					Type:        "string",
+					Description: "The new API key's Key ID",
+				},
+				"private_key_path": {
+					Type:        "string",
+					Description: "Path to the new key's .p8 file on the server's machine (this or private_key_base64)",
+				},
+				"private_key_base64": {
+					Type:        "string",
+					Description: "Base64-encoded contents of the new key's .p8 file (this or private_key_path)",
+				},
+				"issuer_id": {
+					Type:        "string",
+					Description: "Optional: Issuer ID of the new key, if it belongs to another issuer",
+				},
+			},
+			Required: []string{"key_id"},
+		},
+	}, r.handleRotateCredentials)
+}
+
+func (r *Registry) handleRotateCredentials(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		KeyID            string `json:"key_id"`
+		PrivateKeyPath   string `json:"private_key_path"`
+		PrivateKeyBase64 string `json:"private_key_base64"`
+		IssuerID         string `json:"issuer_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.KeyID == "" {
+		return mcp.NewErrorResult("key_id is required"), nil
+	}
+	if (params.PrivateKeyPath == "") == (params.PrivateKeyBase64 == "") {
+		return mcp.NewErrorResult("exactly one of private_key_path or private_key_base64 is required"), nil
+	}
+
+	var keyData []byte
+	var err error
+	if params.PrivateKeyPath != "" {
+		path, err := config.ExpandPath(params.PrivateKeyPath)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Invalid private_key_path: %v", err), err), nil
+		}
+		if keyData, err = os.ReadFile(path); err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read private key: %v", err), err), nil
+		}
+	} else {
+		if keyData, err = base64.StdEncoding.DecodeString(strings.TrimSpace(params.PrivateKeyBase64)); err != nil {
+			return mcp.NewErrorResult("private_key_base64 is not valid base64"), nil
+		}
+	}
+
+	previous, err := r.client.Credentials(ctx)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to rotate credentials: %v", err), err), nil
+	}
+	if err := r.client.RotateKey(ctx, params.IssuerID, params.KeyID, keyData); err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to rotate credentials; still using key %s: %v", previous.KeyID, err), err), nil
+	}
+
+	// The new key may have other roles than the old one.
+	denied := r.deniedTools()
+	r.forgetTeamCapabilities(previous.Team)
+	r.probeTeam(ctx, previous.Team)
+	if !slices.Equal(denied, r.deniedTools()) {
+		r.notifyToolsChanged()
+	}
+
+	current, err := r.client.Credentials(ctx)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Rotated credentials, but the new key can't sign tokens: %v", err), err), nil
+	}
+	return mcp.NewSuccessResult(fmt.Sprintf("Team %s now uses key %s of issuer %s (was key %s of issuer %s).",
+		current.Team, current.KeyID, current.IssuerID, previous.KeyID, previous.IssuerID)), nil
+}
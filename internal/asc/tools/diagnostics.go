@@ -27,6 +27,7 @@ func (r *Registry) registerDiagnosticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of metrics to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -47,6 +48,7 @@ func (r *Registry) registerDiagnosticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of signatures to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"build_id"},
 		},
@@ -67,6 +69,7 @@ func (r *Registry) registerDiagnosticsTools() {
 					Type:        "integer",
 					Description: "Maximum number of logs to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"signature_id"},
 		},
@@ -75,20 +78,24 @@ func (r *Registry) registerDiagnosticsTools() {
 	// List app store review attachments
 	r.register(mcp.Tool{
 		Name:        "list_app_store_review_attachments",
-		Description: "List App Store review submission attachments for a version",
+		Description: "List App Store review submission attachments for a version or review detail. The API reports each attachment's name, size, checksum and processing state, but offers no way to download an attachment's file.",
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
 				"version_id": {
 					Type:        "string",
-					Description: "The app store version ID",
+					Description: "The app store version ID (required unless review_detail_id is given)",
+				},
+				"review_detail_id": {
+					Type:        "string",
+					Description: "Optional: The app store review detail ID, instead of version_id",
 				},
 				"limit": {
 					Type:        "integer",
 					Description: "Maximum number of attachments to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
-			Required: []string{"version_id"},
 		},
 	}, r.handleListAppStoreReviewAttachments)
 
@@ -205,10 +212,11 @@ func (r *Registry) registerDiagnosticsTools() {
 	}, r.handleDeleteRoutingAppCoverage)
 }
 
-func (r *Registry) handleListPerfPowerMetrics(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListPerfPowerMetrics(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -223,18 +231,19 @@ func (r *Registry) handleListPerfPowerMetrics(args json.RawMessage) (*mcp.ToolsC
 		limit = 50
 	}
 
-	resp, err := r.client.ListPerfPowerMetrics(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListPerfPowerMetrics(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list performance metrics: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list performance metrics: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatPerfPowerMetrics(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatPerfPowerMetrics(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListDiagnosticSignatures(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListDiagnosticSignatures(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildID string `json:"build_id"`
 		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -249,18 +258,19 @@ func (r *Registry) handleListDiagnosticSignatures(args json.RawMessage) (*mcp.To
 		limit = 50
 	}
 
-	resp, err := r.client.ListDiagnosticSignatures(context.Background(), params.BuildID, limit)
+	resp, err := r.client.ListDiagnosticSignatures(api.WithCursor(ctx, params.Cursor), params.BuildID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list diagnostic signatures: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list diagnostic signatures: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatDiagnosticSignatures(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatDiagnosticSignatures(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListDiagnosticLogs(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListDiagnosticLogs(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SignatureID string `json:"signature_id"`
 		Limit       int    `json:"limit"`
+		Cursor      string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -275,25 +285,27 @@ func (r *Registry) handleListDiagnosticLogs(args json.RawMessage) (*mcp.ToolsCal
 		limit = 50
 	}
 
-	resp, err := r.client.ListDiagnosticLogs(context.Background(), params.SignatureID, limit)
+	resp, err := r.client.ListDiagnosticLogs(api.WithCursor(ctx, params.Cursor), params.SignatureID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list diagnostic logs: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list diagnostic logs: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatDiagnosticLogs(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatDiagnosticLogs(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListAppStoreReviewAttachments(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppStoreReviewAttachments(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		VersionID string `json:"version_id"`
-		Limit     int    `json:"limit"`
+		VersionID      string `json:"version_id"`
+		ReviewDetailID string `json:"review_detail_id"`
+		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
 	}
 
-	if params.VersionID == "" {
-		return nil, fmt.Errorf("version_id is required")
+	if params.VersionID == "" && params.ReviewDetailID == "" {
+		return nil, fmt.Errorf("version_id or review_detail_id is required")
 	}
 
 	limit := params.Limit
@@ -301,15 +313,39 @@ func (r *Registry) handleListAppStoreReviewAttachments(args json.RawMessage) (*m
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppStoreReviewAttachments(context.Background(), params.VersionID, limit)
+	// A version's attachments come included with its review detail; the
+	// detail's own attachment list is paged.
+	if params.ReviewDetailID == "" && params.Cursor == "" {
+		resp, err := r.client.GetAppStoreReviewDetailWithAttachments(ctx, params.VersionID, limit)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list review attachments: %v", err), err), nil
+		}
+		attachments, err := resp.Attachments()
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list review attachments: %v", err), err), nil
+		}
+		if len(attachments) < limit {
+			return mcp.NewSuccessResult(formatAppStoreReviewAttachments(attachments)), nil
+		}
+		params.ReviewDetailID = resp.Data.ID
+	}
+	if params.ReviewDetailID == "" {
+		detail, err := r.client.GetAppStoreReviewDetail(ctx, params.VersionID)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list review attachments: %v", err), err), nil
+		}
+		params.ReviewDetailID = detail.Data.ID
+	}
+
+	resp, err := r.client.ListAppStoreReviewAttachments(api.WithCursor(ctx, params.Cursor), params.ReviewDetailID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list review attachments: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list review attachments: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppStoreReviewAttachments(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppStoreReviewAttachments(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppStoreReviewAttachment(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppStoreReviewAttachment(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AttachmentID string `json:"attachment_id"`
 	}
@@ -321,15 +357,15 @@ func (r *Registry) handleGetAppStoreReviewAttachment(args json.RawMessage) (*mcp
 		return nil, fmt.Errorf("attachment_id is required")
 	}
 
-	resp, err := r.client.GetAppStoreReviewAttachment(context.Background(), params.AttachmentID)
+	resp, err := r.client.GetAppStoreReviewAttachment(ctx, params.AttachmentID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get review attachment: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get review attachment: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppStoreReviewAttachment(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateAppStoreReviewAttachment(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppStoreReviewAttachment(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ReviewDetailID string `json:"review_detail_id"`
 		FileName       string `json:"file_name"`
@@ -358,15 +394,15 @@ func (r *Registry) handleCreateAppStoreReviewAttachment(args json.RawMessage) (*
 		},
 	}
 
-	resp, err := r.client.CreateAppStoreReviewAttachment(context.Background(), req)
+	resp, err := r.client.CreateAppStoreReviewAttachment(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create review attachment: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create review attachment: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Review attachment reservation created:\n%s", formatAppStoreReviewAttachment(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteAppStoreReviewAttachment(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAppStoreReviewAttachment(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AttachmentID string `json:"attachment_id"`
 	}
@@ -378,15 +414,15 @@ func (r *Registry) handleDeleteAppStoreReviewAttachment(args json.RawMessage) (*
 		return nil, fmt.Errorf("attachment_id is required")
 	}
 
-	err := r.client.DeleteAppStoreReviewAttachment(context.Background(), params.AttachmentID)
+	err := r.client.DeleteAppStoreReviewAttachment(ctx, params.AttachmentID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete review attachment: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete review attachment: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Review attachment deleted"), nil
 }
 
-func (r *Registry) handleGetRoutingAppCoverage(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetRoutingAppCoverage(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -398,15 +434,15 @@ func (r *Registry) handleGetRoutingAppCoverage(args json.RawMessage) (*mcp.Tools
 		return nil, fmt.Errorf("version_id is required")
 	}
 
-	resp, err := r.client.GetRoutingAppCoverage(context.Background(), params.VersionID)
+	resp, err := r.client.GetRoutingAppCoverage(ctx, params.VersionID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get routing app coverage: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get routing app coverage: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatRoutingAppCoverage(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateRoutingAppCoverage(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateRoutingAppCoverage(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 		FileName  string `json:"file_name"`
@@ -435,15 +471,15 @@ func (r *Registry) handleCreateRoutingAppCoverage(args json.RawMessage) (*mcp.To
 		},
 	}
 
-	resp, err := r.client.CreateRoutingAppCoverage(context.Background(), req)
+	resp, err := r.client.CreateRoutingAppCoverage(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create routing app coverage: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create routing app coverage: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Routing app coverage reservation created:\n%s", formatRoutingAppCoverage(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteRoutingAppCoverage(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteRoutingAppCoverage(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		CoverageID string `json:"coverage_id"`
 	}
@@ -455,9 +491,9 @@ func (r *Registry) handleDeleteRoutingAppCoverage(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("coverage_id is required")
 	}
 
-	err := r.client.DeleteRoutingAppCoverage(context.Background(), params.CoverageID)
+	err := r.client.DeleteRoutingAppCoverage(ctx, params.CoverageID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete routing app coverage: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete routing app coverage: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Routing app coverage deleted"), nil
@@ -538,6 +574,9 @@ func formatAppStoreReviewAttachment(a api.AppStoreReviewAttachment) string {
 	sb.WriteString(fmt.Sprintf("ID: %s\n", a.ID))
 	sb.WriteString(fmt.Sprintf("File Name: %s\n", a.Attributes.FileName))
 	sb.WriteString(fmt.Sprintf("File Size: %d bytes\n", a.Attributes.FileSize))
+	if a.Attributes.SourceFileChecksum != "" {
+		sb.WriteString(fmt.Sprintf("Checksum: %s\n", a.Attributes.SourceFileChecksum))
+	}
 	if a.Attributes.AssetDeliveryState != nil {
 		sb.WriteString(fmt.Sprintf("State: %s\n", a.Attributes.AssetDeliveryState.State))
 	}
@@ -0,0 +1,475 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+const (
+	// digestPeriod is the span a digest covers, ending when it is generated.
+	digestPeriod = 7 * 24 * time.Hour
+
+	// digestMaxApps caps the apps a digest reads when no app IDs are given,
+	// keeping it within a few hundred requests.
+	digestMaxApps = 25
+
+	// digestConcurrency is how many apps a digest reads at once.
+	digestConcurrency = 4
+
+	// digestMaxReviews caps the reviews listed for each app. All of the
+	// week's reviews are counted.
+	digestMaxReviews = 20
+
+	// digestCertificateHorizon is how far ahead expiring certificates are reported.
+	digestCertificateHorizon = 30 * 24 * time.Hour
+)
+
+// registerDigestTools registers the account-wide weekly digest.
+func (r *Registry) registerDigestTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "generate_digest",
+			Description: "Assemble the past week across the account into one report to narrate: each app's new customer reviews with rating counts, App Store version state changes this server recorded, build uploads, certificates expiring within 30 days, and, given a vendor number, last week's sales units and proceeds compared with the week before. Parts that can't be read are listed in errors rather than failing the digest.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_ids": {
+						Type:        "array",
+						Description: fmt.Sprintf("Optional: App IDs or app group names to cover (default: the account's first %d apps)", digestMaxApps),
+						Items:       &mcp.Property{Type: "string"},
+					},
+					"vendor_number": {
+						Type:        "string",
+						Description: "Optional: Your vendor number, to compare last week's sales with the week before",
+					},
+				},
+			},
+			OutputSchema: mcp.SchemaFor(digestOutput{}),
+		},
+		r.handleGenerateDigest,
+	)
+}
+
+// handleGenerateDigest handles the generate_digest tool.
+func (r *Registry) handleGenerateDigest(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppIDs       []string `json:"app_ids"`
+		VendorNumber string   `json:"vendor_number"`
+	}
+	if args != nil {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	output := digestOutput{
+		Since:        now.Add(-digestPeriod).Format(time.RFC3339),
+		Until:        now.Format(time.RFC3339),
+		Apps:         make([]digestApp, 0),
+		Certificates: make([]digestCertificate, 0),
+		Errors:       make([]string, 0),
+	}
+
+	if len(params.AppIDs) > 0 {
+		for _, appID := range r.expandAppIDs(params.AppIDs) {
+			output.Apps = append(output.Apps, digestApp{AppID: appID})
+		}
+	} else {
+		apps, err := r.client.ListAllApps(ctx)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list apps: %v", err), err), nil
+		}
+		for i, app := range apps {
+			if i == digestMaxApps {
+				output.Errors = append(output.Errors, fmt.Sprintf("Only the first %d of %d apps are covered; pass app_ids for the others", digestMaxApps, len(apps)))
+				break
+			}
+			output.Apps = append(output.Apps, digestApp{AppID: app.ID, AppName: app.Attributes.Name})
+		}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, digestConcurrency)
+	for i := range output.Apps {
+		wg.Add(1)
+		go func(app *digestApp) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			r.digestApp(ctx, app, now)
+		}(&output.Apps[i])
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	certificates, err := r.client.ListCertificates(ctx, api.ListOptions{Limit: 200})
+	if err != nil {
+		output.Errors = append(output.Errors, fmt.Sprintf("Certificates: %v", err))
+	} else {
+		output.Certificates = expiringCertificates(certificates.Data, now)
+	}
+
+	if params.VendorNumber != "" {
+		sales, err := r.weeklySales(ctx, params.VendorNumber, now)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("Sales: %v", err))
+		}
+		output.Sales = sales
+	}
+
+	return mcp.NewStructuredResult(formatDigest(output), output), nil
+}
+
+// digestApp reads an app's reviews, version state changes and builds of the
+// week before now into app. Failures are recorded in app.Errors.
+func (r *Registry) digestApp(ctx context.Context, app *digestApp, now time.Time) {
+	since := now.Add(-digestPeriod)
+	app.Reviews = digestReviews{Recent: make([]digestReview, 0)}
+	app.VersionChanges = make([]digestVersionChange, 0)
+	app.Builds = make([]digestBuild, 0)
+
+	reviews, err := r.client.ListCustomerReviews(ctx, app.AppID, api.ListOptions{Sort: "-createdDate", Limit: 200})
+	if err != nil {
+		app.Errors = append(app.Errors, fmt.Sprintf("reviews: %v", err))
+	} else {
+		app.Reviews = summarizeReviews(reviews.Data, since)
+	}
+
+	// Reading the versions records their current state, so changes since
+	// they were last seen show up below.
+	versions, err := r.client.GetAppVersions(ctx, app.AppID, api.ListOptions{Limit: 10})
+	if err != nil {
+		app.Errors = append(app.Errors, fmt.Sprintf("versions: %v", err))
+	} else {
+		r.recordVersions(app.AppID, versions.Data)
+	}
+	for _, history := range r.snapshots.VersionHistories(app.AppID) {
+		for i := 1; i < len(history.Transitions); i++ {
+			t := history.Transitions[i]
+			if t.At.Before(since) {
+				continue
+			}
+			app.VersionChanges = append(app.VersionChanges, digestVersionChange{
+				VersionID:     history.VersionID,
+				VersionString: history.VersionString,
+				Platform:      history.Platform,
+				From:          history.Transitions[i-1].State,
+				To:            t.State,
+				At:            t.At.Format(time.RFC3339),
+			})
+		}
+	}
+	sort.Slice(app.VersionChanges, func(i, j int) bool {
+		return app.VersionChanges[i].At < app.VersionChanges[j].At
+	})
+
+	builds, err := r.client.ListBuilds(ctx, app.AppID, api.ListOptions{Sort: "-uploadedDate", Limit: 50})
+	if err != nil {
+		app.Errors = append(app.Errors, fmt.Sprintf("builds: %v", err))
+		return
+	}
+	for _, build := range builds.Data {
+		attrs := build.Attributes
+		if attrs.UploadedDate == nil || attrs.UploadedDate.Before(since) {
+			continue
+		}
+		app.Builds = append(app.Builds, digestBuild{
+			ID:              build.ID,
+			Version:         attrs.Version,
+			ProcessingState: string(attrs.ProcessingState),
+			UploadedDate:    attrs.UploadedDate.UTC().Format(time.RFC3339),
+		})
+	}
+}
+
+// summarizeReviews counts the reviews created since, newest first, and keeps
+// the first digestMaxReviews of them.
+func summarizeReviews(reviews []api.CustomerReview, since time.Time) digestReviews {
+	summary := digestReviews{Recent: make([]digestReview, 0)}
+	total := 0
+	for _, review := range reviews {
+		attrs := review.Attributes
+		if attrs.CreatedDate == nil || attrs.CreatedDate.Before(since) {
+			continue
+		}
+		summary.Count++
+		if attrs.Rating >= 1 && attrs.Rating <= 5 {
+			summary.ByRating[attrs.Rating-1]++
+			total += attrs.Rating
+		}
+		if len(summary.Recent) < digestMaxReviews {
+			summary.Recent = append(summary.Recent, digestReview{
+				ID:        review.ID,
+				Rating:    attrs.Rating,
+				Title:     attrs.Title,
+				Body:      truncateString(attrs.Body, 300),
+				Territory: attrs.Territory,
+				Created:   attrs.CreatedDate.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	if rated := summary.ByRating[0] + summary.ByRating[1] + summary.ByRating[2] + summary.ByRating[3] + summary.ByRating[4]; rated > 0 {
+		summary.AverageRating = math.Round(float64(total)/float64(rated)*100) / 100
+	}
+	return summary
+}
+
+// expiringCertificates returns the certificates that expire within
+// digestCertificateHorizon of now or expired during the past week, soonest first.
+func expiringCertificates(certificates []api.Certificate, now time.Time) []digestCertificate {
+	expiring := make([]digestCertificate, 0)
+	for _, cert := range certificates {
+		expires := cert.Attributes.ExpirationDate
+		if expires == nil || expires.After(now.Add(digestCertificateHorizon)) || expires.Before(now.Add(-digestPeriod)) {
+			continue
+		}
+		name := cert.Attributes.DisplayName
+		if name == "" {
+			name = cert.Attributes.Name
+		}
+		expiring = append(expiring, digestCertificate{
+			ID:             cert.ID,
+			Name:           name,
+			Type:           string(cert.Attributes.CertificateType),
+			ExpirationDate: expires.UTC().Format(time.RFC3339),
+			DaysLeft:       int(math.Floor(expires.Sub(now).Hours() / 24)),
+		})
+	}
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ExpirationDate < expiring[j].ExpirationDate
+	})
+	return expiring
+}
+
+// lastSalesWeek returns the Sunday ending the last complete sales week
+// before now. Weekly sales reports run Monday to Sunday.
+func lastSalesWeek(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	back := int(day.Weekday())
+	if back == 0 {
+		back = 7
+	}
+	return day.AddDate(0, 0, -back)
+}
+
+// weeklySales compares the summary sales reports of the last complete week
+// and the week before it.
+func (r *Registry) weeklySales(ctx context.Context, vendorNumber string, now time.Time) (*digestSales, error) {
+	week := lastSalesWeek(now)
+	previousWeek := week.AddDate(0, 0, -7)
+
+	current, err := r.salesWeek(ctx, vendorNumber, week)
+	if err != nil {
+		return nil, fmt.Errorf("week ending %s: %w", week.Format("2006-01-02"), err)
+	}
+	previous, err := r.salesWeek(ctx, vendorNumber, previousWeek)
+	if err != nil {
+		return nil, fmt.Errorf("week ending %s: %w", previousWeek.Format("2006-01-02"), err)
+	}
+
+	sales := &digestSales{
+		WeekEnding:         week.Format("2006-01-02"),
+		PreviousWeekEnding: previousWeek.Format("2006-01-02"),
+		Units:              current.units,
+		PreviousUnits:      previous.units,
+		UnitsChange:        current.units - previous.units,
+		Proceeds:           roundAmounts(current.proceeds),
+		PreviousProceeds:   roundAmounts(previous.proceeds),
+		Apps:               make([]digestAppSales, 0),
+	}
+	for appleID, units := range current.appUnits {
+		sales.Apps = append(sales.Apps, digestAppSales{
+			AppleID:       appleID,
+			Title:         current.titles[appleID],
+			Units:         units,
+			PreviousUnits: previous.appUnits[appleID],
+		})
+	}
+	for appleID, units := range previous.appUnits {
+		if _, ok := current.appUnits[appleID]; !ok {
+			sales.Apps = append(sales.Apps, digestAppSales{AppleID: appleID, Title: previous.titles[appleID], PreviousUnits: units})
+		}
+	}
+	sort.Slice(sales.Apps, func(i, j int) bool {
+		if sales.Apps[i].Units != sales.Apps[j].Units {
+			return sales.Apps[i].Units > sales.Apps[j].Units
+		}
+		return sales.Apps[i].AppleID < sales.Apps[j].AppleID
+	})
+	return sales, nil
+}
+
+// salesTotals sums one sales report.
+type salesTotals struct {
+	units    int
+	proceeds map[string]float64
+	appUnits map[string]int
+	titles   map[string]string
+}
+
+// salesWeek downloads and sums the weekly summary sales report of the week
+// ending on weekEnding.
+func (r *Registry) salesWeek(ctx context.Context, vendorNumber string, weekEnding time.Time) (*salesTotals, error) {
+	report, err := r.client.GetSalesReport(ctx, vendorNumber, "SALES", "SUMMARY", "WEEKLY", weekEnding.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	return sumSalesReport(report)
+}
+
+// sumSalesReport adds up a summary sales report's units, per app and in
+// total, and its proceeds per currency. Proceeds are reported per unit.
+func sumSalesReport(report *api.Report) (*salesTotals, error) {
+	totals := &salesTotals{
+		proceeds: make(map[string]float64),
+		appUnits: make(map[string]int),
+		titles:   make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(report.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	columns := map[string]int{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(columns) == 0 {
+			for i, name := range fields {
+				columns[strings.TrimSpace(name)] = i
+			}
+			for _, name := range []string{"Units", "Developer Proceeds", "Currency of Proceeds", "Apple Identifier"} {
+				if _, ok := columns[name]; !ok {
+					return nil, fmt.Errorf("report has no %s column", name)
+				}
+			}
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+		units, err := strconv.Atoi(field("Units"))
+		if err != nil {
+			continue
+		}
+		appleID := field("Apple Identifier")
+		totals.units += units
+		totals.appUnits[appleID] += units
+		if title := field("Title"); title != "" && totals.titles[appleID] == "" {
+			totals.titles[appleID] = title
+		}
+		if proceeds, err := strconv.ParseFloat(field("Developer Proceeds"), 64); err == nil && proceeds != 0 {
+			totals.proceeds[field("Currency of Proceeds")] += proceeds * float64(units)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// roundAmounts rounds currency amounts to cents.
+func roundAmounts(amounts map[string]float64) map[string]float64 {
+	rounded := make(map[string]float64, len(amounts))
+	for currency, amount := range amounts {
+		rounded[currency] = math.Round(amount*100) / 100
+	}
+	return rounded
+}
+
+// formatDigest renders a digest as text.
+func formatDigest(d digestOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Weekly digest** (%s to %s)\n", d.Since[:10], d.Until[:10]))
+
+	for _, app := range d.Apps {
+		name := app.AppName
+		if name == "" {
+			name = app.AppID
+		}
+		sb.WriteString(fmt.Sprintf("\n**%s** (ID: %s)\n", name, app.AppID))
+
+		reviews := app.Reviews
+		if reviews.Count == 0 {
+			sb.WriteString("- Reviews: none new\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("- Reviews: %d new, average %.2f (5★ %d, 4★ %d, 3★ %d, 2★ %d, 1★ %d)\n", reviews.Count, reviews.AverageRating,
+				reviews.ByRating[4], reviews.ByRating[3], reviews.ByRating[2], reviews.ByRating[1], reviews.ByRating[0]))
+			for _, review := range reviews.Recent {
+				sb.WriteString(fmt.Sprintf("  - %d★ %s (%s): %s\n", review.Rating, review.Title, review.Territory, truncateString(review.Body, 120)))
+			}
+		}
+
+		for _, change := range app.VersionChanges {
+			sb.WriteString(fmt.Sprintf("- Version %s (%s): %s → %s on %s\n", change.VersionString, change.Platform, change.From, change.To, change.At[:10]))
+		}
+
+		if len(app.Builds) == 0 {
+			sb.WriteString("- Builds: none uploaded\n")
+		}
+		for _, build := range app.Builds {
+			sb.WriteString(fmt.Sprintf("- Build %s uploaded %s (%s)\n", build.Version, build.UploadedDate[:10], build.ProcessingState))
+		}
+
+		for _, e := range app.Errors {
+			sb.WriteString(fmt.Sprintf("- Couldn't get %s\n", e))
+		}
+	}
+
+	sb.WriteString("\n**Certificates**\n")
+	if len(d.Certificates) == 0 {
+		sb.WriteString("- None expiring in the next 30 days\n")
+	}
+	for _, cert := range d.Certificates {
+		if cert.DaysLeft < 0 {
+			sb.WriteString(fmt.Sprintf("- %s (%s) expired on %s\n", cert.Name, cert.Type, cert.ExpirationDate[:10]))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- %s (%s) expires on %s, in %d days\n", cert.Name, cert.Type, cert.ExpirationDate[:10], cert.DaysLeft))
+	}
+
+	if s := d.Sales; s != nil {
+		sb.WriteString(fmt.Sprintf("\n**Sales** (week ending %s vs. %s)\n", s.WeekEnding, s.PreviousWeekEnding))
+		sb.WriteString(fmt.Sprintf("- Units: %d (%+d from %d)\n", s.Units, s.UnitsChange, s.PreviousUnits))
+		currencies := make([]string, 0, len(s.Proceeds))
+		for currency := range s.Proceeds {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			sb.WriteString(fmt.Sprintf("- Proceeds: %.2f %s (was %.2f)\n", s.Proceeds[currency], currency, s.PreviousProceeds[currency]))
+		}
+		for _, app := range s.Apps {
+			sb.WriteString(fmt.Sprintf("- %s (%s): %d units (was %d)\n", app.Title, app.AppleID, app.Units, app.PreviousUnits))
+		}
+	}
+
+	if len(d.Errors) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, e := range d.Errors {
+		sb.WriteString(fmt.Sprintf("Couldn't get %s\n", e))
+	}
+	return sb.String()
+}
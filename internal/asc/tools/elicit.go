@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// maxElicitCandidates caps the values offered when asking for an ID.
+const maxElicitCandidates = 50
+
+// ElicitFunc asks the user, through the client, for values matching schema.
+type ElicitFunc func(ctx context.Context, message string, schema mcp.JSONSchema) (*mcp.ElicitResult, error)
+
+type elicitKey struct{}
+
+// WithElicitation returns a context whose tool calls ask the user for
+// missing required arguments with elicit instead of failing.
+func WithElicitation(ctx context.Context, elicit ElicitFunc) context.Context {
+	return context.WithValue(ctx, elicitKey{}, elicit)
+}
+
+// elicitation returns the context's ElicitFunc, or nil if the client can't
+// ask the user.
+func elicitation(ctx context.Context) ElicitFunc {
+	elicit, _ := ctx.Value(elicitKey{}).(ElicitFunc)
+	return elicit
+}
+
+// elicitMissing asks the user for required arguments missing from a call and
+// returns the arguments with their answers. Well-known IDs are offered as a
+// list of candidates fetched from the API. If the user declines, it returns
+// an error result to send instead of calling the tool. Calls that can't be
+// asked about, because the client doesn't support it or a missing argument
+// isn't a plain value, are returned unchanged so the tool reports them.
+func (r *Registry) elicitMissing(ctx context.Context, name string, args json.RawMessage) (json.RawMessage, *mcp.ToolsCallResult) {
+	elicit := elicitation(ctx)
+	if elicit == nil {
+		return args, nil
+	}
+	tool, ok := r.tool(name)
+	if !ok || len(tool.InputSchema.Required) == 0 {
+		return args, nil
+	}
+
+	values := make(map[string]any)
+	if len(args) > 0 && string(args) != "null" {
+		if err := json.Unmarshal(args, &values); err != nil {
+			return args, nil
+		}
+	}
+
+	schema := mcp.JSONSchema{Type: "object", Properties: make(map[string]mcp.Property)}
+	for _, key := range tool.InputSchema.Required {
+		if value, ok := values[key]; ok && value != "" && value != nil {
+			continue
+		}
+		prop := tool.InputSchema.Properties[key]
+		switch prop.Type {
+		case "string", "integer", "number", "boolean":
+		default:
+			return args, nil
+		}
+		schema.Properties[key] = r.elicitProperty(ctx, key, prop, values)
+		schema.Required = append(schema.Required, key)
+	}
+	if len(schema.Required) == 0 {
+		return args, nil
+	}
+
+	message := fmt.Sprintf("%s needs %s.", r.publicName(name), strings.Join(schema.Required, ", "))
+	result, err := elicit(ctx, message, schema)
+	if err != nil || result == nil {
+		return args, nil
+	}
+	if result.Action != mcp.ElicitActionAccept {
+		return nil, mcp.NewErrorResult(fmt.Sprintf("%s was not called: %s was not provided.", r.publicName(name), strings.Join(schema.Required, ", ")))
+	}
+
+	for key, value := range result.Content {
+		if _, asked := schema.Properties[key]; asked {
+			values[key] = value
+		}
+	}
+	merged, err := json.Marshal(values)
+	if err != nil {
+		return args, nil
+	}
+	return merged, nil
+}
+
+// tool returns the definition of a registered tool.
+func (r *Registry) tool(name string) (mcp.Tool, bool) {
+	for _, tool := range r.tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return mcp.Tool{}, false
+}
+
+// elicitProperty describes a missing argument to the user, listing
+// candidates for app and version IDs. Candidates that can't be fetched are
+// left out, and the user types the value instead.
+func (r *Registry) elicitProperty(ctx context.Context, key string, prop mcp.Property, values map[string]any) mcp.Property {
+	asked := mcp.Property{Type: prop.Type, Description: prop.Description, Enum: prop.Enum}
+	if r.client == nil || len(prop.Enum) > 0 {
+		return asked
+	}
+
+	switch key {
+	case "app_id":
+		apps, err := r.client.ListApps(ctx, api.ListOptions{Limit: maxElicitCandidates})
+		if err != nil {
+			return asked
+		}
+		for _, app := range apps.Data {
+			asked.Enum = append(asked.Enum, app.ID)
+			asked.EnumNames = append(asked.EnumNames, fmt.Sprintf("%s (%s)", app.Attributes.Name, app.Attributes.BundleID))
+		}
+	case "version_id":
+		appID, _ := values["app_id"].(string)
+		if appID == "" {
+			return asked
+		}
+		versions, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: maxElicitCandidates})
+		if err != nil {
+			return asked
+		}
+		for _, v := range versions.Data {
+			asked.Enum = append(asked.Enum, v.ID)
+			asked.EnumNames = append(asked.EnumNames, fmt.Sprintf("%s (%s, %s)", v.Attributes.VersionString, v.Attributes.Platform, v.Attributes.AppStoreState))
+		}
+	}
+	return asked
+}
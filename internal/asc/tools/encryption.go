@@ -27,6 +27,7 @@ func (r *Registry) registerEncryptionTools() {
 					Type:        "integer",
 					Description: "Maximum number of declarations to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListEncryptionDeclarations)
@@ -58,14 +59,6 @@ func (r *Registry) registerEncryptionTools() {
 					Type:        "string",
 					Description: "The App ID",
 				},
-				"uses_encryption": {
-					Type:        "boolean",
-					Description: "Whether the app uses encryption",
-				},
-				"exempt": {
-					Type:        "boolean",
-					Description: "Whether the app is exempt from export regulations",
-				},
 				"contains_proprietary_cryptography": {
 					Type:        "boolean",
 					Description: "Whether the app contains proprietary cryptography",
@@ -82,12 +75,8 @@ func (r *Registry) registerEncryptionTools() {
 					Type:        "string",
 					Description: "Description of how the app uses encryption",
 				},
-				"code_value": {
-					Type:        "string",
-					Description: "CCATS code value if applicable",
-				},
 			},
-			Required: []string{"app_id", "uses_encryption"},
+			Required: []string{"app_id", "app_description", "contains_proprietary_cryptography", "contains_third_party_cryptography", "available_on_french_store"},
 		},
 	}, r.handleCreateEncryptionDeclaration)
 
@@ -112,10 +101,11 @@ func (r *Registry) registerEncryptionTools() {
 	}, r.handleAssignBuildToEncryptionDeclaration)
 }
 
-func (r *Registry) handleListEncryptionDeclarations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListEncryptionDeclarations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -126,15 +116,15 @@ func (r *Registry) handleListEncryptionDeclarations(args json.RawMessage) (*mcp.
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppEncryptionDeclarations(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListAppEncryptionDeclarations(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list encryption declarations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list encryption declarations: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatEncryptionDeclarations(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatEncryptionDeclarations(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetEncryptionDeclaration(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetEncryptionDeclaration(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DeclarationID string `json:"declaration_id"`
 	}
@@ -146,24 +136,21 @@ func (r *Registry) handleGetEncryptionDeclaration(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("declaration_id is required")
 	}
 
-	resp, err := r.client.GetAppEncryptionDeclaration(context.Background(), params.DeclarationID)
+	resp, err := r.client.GetAppEncryptionDeclaration(ctx, params.DeclarationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get encryption declaration: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get encryption declaration: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatEncryptionDeclaration(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateEncryptionDeclaration(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateEncryptionDeclaration(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID                           string `json:"app_id"`
-		UsesEncryption                  bool   `json:"uses_encryption"`
-		Exempt                          bool   `json:"exempt"`
 		ContainsProprietaryCryptography bool   `json:"contains_proprietary_cryptography"`
 		ContainsThirdPartyCryptography  bool   `json:"contains_third_party_cryptography"`
 		AvailableOnFrenchStore          bool   `json:"available_on_french_store"`
 		AppDescription                  string `json:"app_description"`
-		CodeValue                       string `json:"code_value"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -172,18 +159,18 @@ func (r *Registry) handleCreateEncryptionDeclaration(args json.RawMessage) (*mcp
 	if params.AppID == "" {
 		return nil, fmt.Errorf("app_id is required")
 	}
+	if params.AppDescription == "" {
+		return nil, fmt.Errorf("app_description is required")
+	}
 
 	req := &api.AppEncryptionDeclarationCreateRequest{
 		Data: api.AppEncryptionDeclarationCreateData{
 			Type: "appEncryptionDeclarations",
 			Attributes: api.AppEncryptionDeclarationCreateAttributes{
-				UsesEncryption:                  params.UsesEncryption,
-				Exempt:                          params.Exempt,
 				ContainsProprietaryCryptography: params.ContainsProprietaryCryptography,
 				ContainsThirdPartyCryptography:  params.ContainsThirdPartyCryptography,
 				AvailableOnFrenchStore:          params.AvailableOnFrenchStore,
 				AppDescription:                  params.AppDescription,
-				CodeValue:                       params.CodeValue,
 			},
 			Relationships: api.AppEncryptionDeclarationCreateRelationships{
 				App: api.RelationshipData{
@@ -196,15 +183,15 @@ func (r *Registry) handleCreateEncryptionDeclaration(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.CreateAppEncryptionDeclaration(context.Background(), req)
+	resp, err := r.client.CreateAppEncryptionDeclaration(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create encryption declaration: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create encryption declaration: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created encryption declaration: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleAssignBuildToEncryptionDeclaration(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleAssignBuildToEncryptionDeclaration(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DeclarationID string `json:"declaration_id"`
 		BuildID       string `json:"build_id"`
@@ -220,9 +207,9 @@ func (r *Registry) handleAssignBuildToEncryptionDeclaration(args json.RawMessage
 		return nil, fmt.Errorf("build_id is required")
 	}
 
-	err := r.client.AssignBuildToEncryptionDeclaration(context.Background(), params.DeclarationID, params.BuildID)
+	err := r.client.AssignBuildToEncryptionDeclaration(ctx, params.DeclarationID, params.BuildID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to assign build to encryption declaration: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to assign build to encryption declaration: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Build assigned to encryption declaration successfully"), nil
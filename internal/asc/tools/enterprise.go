@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// appStoreOnlyTools are the tool groups that depend on App Store or TestFlight
+// distribution, which Enterprise (In-House) program accounts don't have.
+var appStoreOnlyTools = []func(*Registry){
+	(*Registry).registerTestFlightTools,
+	(*Registry).registerBuildRetentionTools,
+	(*Registry).registerTesterRemovalTools,
+	(*Registry).registerBuildOverviewTools,
+	(*Registry).registerAppInfoLocalizationTools,
+	(*Registry).registerVersionLocalizationTools,
+	(*Registry).registerMetadataTemplateTools,
+	(*Registry).registerLocaleCoverageTools,
+	(*Registry).registerCustomerReviewTools,
+	(*Registry).registerInAppPurchaseTools,
+	(*Registry).registerSubscriptionTools,
+	(*Registry).registerVersionSubmissionTools,
+	(*Registry).registerPhasedReleaseTools,
+	(*Registry).registerReleaseStrategyTools,
+	(*Registry).registerReleaseNotesTools,
+	(*Registry).registerPrecheckTools,
+	(*Registry).registerLinkTools,
+	(*Registry).registerReviewHistoryTools,
+	(*Registry).registerReleaseTrainTools,
+	(*Registry).registerScreenshotTools,
+	(*Registry).registerAssetVerificationTools,
+	(*Registry).registerScreenshotArchiveTools,
+	(*Registry).registerStuckAssetTools,
+	(*Registry).registerPreOrderTools,
+	(*Registry).registerAppEventTools,
+	(*Registry).registerAnalyticsTools,
+	(*Registry).registerAppClipTools,
+	(*Registry).registerGameCenterTools,
+	(*Registry).registerReportsTools,
+	(*Registry).registerDigestTools,
+	(*Registry).registerEncryptionTools,
+	(*Registry).registerPricingTools,
+	(*Registry).registerAvailabilityTools,
+	(*Registry).registerAgeRatingTools,
+	(*Registry).registerBetaReviewTools,
+	(*Registry).registerSandboxTools,
+	(*Registry).registerPromotedPurchasesTools,
+	(*Registry).registerProductPagesTools,
+	(*Registry).registerDiagnosticsTools,
+	(*Registry).registerMiscTools,
+}
+
+// enterpriseHint is appended to permission and not-found errors in enterprise mode.
+const enterpriseHint = "\n\nThis server is configured for an Enterprise (In-House) account. " +
+	"Enterprise accounts have no App Store or TestFlight distribution, so this endpoint may not be available to them."
+
+// EnableEnterpriseMode removes the tools that need App Store or TestFlight
+// distribution, and explains likely causes when remaining tools are refused.
+func (r *Registry) EnableEnterpriseMode() {
+	r.enterprise = true
+
+	hidden := &Registry{
+		handlers:         make(map[string]ToolHandler),
+		progressHandlers: make(map[string]ProgressToolHandler),
+	}
+	for _, registerGroup := range appStoreOnlyTools {
+		registerGroup(hidden)
+	}
+
+	tools := make([]mcp.Tool, 0, len(r.tools))
+	for _, tool := range r.tools {
+		if _, ok := hidden.handlers[tool.Name]; ok {
+			delete(r.handlers, tool.Name)
+			delete(r.progressHandlers, tool.Name)
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	r.tools = tools
+}
+
+// withEnterpriseHint adds enterpriseHint to results that failed with a 403 or 404.
+func withEnterpriseHint(result *mcp.ToolsCallResult) *mcp.ToolsCallResult {
+	if result == nil || !result.IsError || len(result.Content) == 0 {
+		return result
+	}
+
+	if !api.IsForbidden(result.Err) && !api.IsNotFound(result.Err) {
+		return result
+	}
+
+	result.Content[0].Text += enterpriseHint
+	return result
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// defaultEnumLabels are the readable phrases of the multi-word App Store
+// Connect enum values that come up most in status answers. Single words such
+// as ACTIVE read well enough as they are.
+var defaultEnumLabels = map[string]string{
+	// App Store version states
+	"DEVELOPER_REMOVED_FROM_SALE":   "Developer removed from sale",
+	"DEVELOPER_REJECTED":            "Developer rejected",
+	"IN_REVIEW":                     "In review",
+	"INVALID_BINARY":                "Invalid binary",
+	"METADATA_REJECTED":             "Metadata rejected",
+	"PENDING_APPLE_RELEASE":         "Pending Apple release",
+	"PENDING_CONTRACT":              "Pending contract",
+	"PENDING_DEVELOPER_RELEASE":     "Pending developer release",
+	"PREPARE_FOR_SUBMISSION":        "Prepare for submission",
+	"PREORDER_READY_FOR_SALE":       "Pre-order ready for sale",
+	"PROCESSING_FOR_APP_STORE":      "Processing for App Store",
+	"READY_FOR_DISTRIBUTION":        "Ready for distribution",
+	"READY_FOR_REVIEW":              "Ready for review",
+	"READY_FOR_SALE":                "Ready for sale",
+	"REMOVED_FROM_SALE":             "Removed from sale",
+	"REPLACED_WITH_NEW_VERSION":     "Replaced with new version",
+	"WAITING_FOR_EXPORT_COMPLIANCE": "Waiting for export compliance",
+	"WAITING_FOR_REVIEW":            "Waiting for review",
+
+	// Review submission states
+	"UNRESOLVED_ISSUES": "Unresolved issues",
+
+	// Build processing and TestFlight states
+	"MISSING_EXPORT_COMPLIANCE":   "Missing export compliance",
+	"READY_FOR_BETA_TESTING":      "Ready for beta testing",
+	"IN_BETA_TESTING":             "In beta testing",
+	"IN_EXPORT_COMPLIANCE_REVIEW": "In export compliance review",
+	"READY_FOR_BETA_SUBMISSION":   "Ready for beta submission",
+	"WAITING_FOR_BETA_REVIEW":     "Waiting for beta review",
+	"IN_BETA_REVIEW":              "In beta review",
+	"BETA_REJECTED":               "Beta rejected",
+	"BETA_APPROVED":               "Beta approved",
+
+	// Release types
+	"AFTER_APPROVAL": "After approval",
+
+	// In-app purchase and subscription states
+	"MISSING_METADATA":        "Missing metadata",
+	"DEVELOPER_ACTION_NEEDED": "Developer action needed",
+	"PENDING_BINARY_APPROVAL": "Pending binary approval",
+}
+
+// enumValuePattern matches words that may be enum values: capitals, digits
+// and underscores, starting with a capital.
+var enumValuePattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]*[A-Z0-9]\b`)
+
+// unlabeledTools return text that must reach the client as it is: raw API
+// responses, and the rest of results that were labeled before truncation.
+var unlabeledTools = map[string]bool{
+	"asc_api_request": true,
+	continuationTool:  true,
+}
+
+// DefaultEnumLabels returns a copy of the built-in readable phrases of enum
+// values, keyed by value.
+func DefaultEnumLabels() map[string]string {
+	return maps.Clone(defaultEnumLabels)
+}
+
+// LoadEnumLabels reads a JSON object of enum values and their phrases from
+// path, such as {"READY_FOR_SALE": "Prêt à la vente"}, and returns the
+// built-in labels with those replaced or added. An empty phrase turns the
+// label of its value off.
+func LoadEnumLabels(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enum labels: %w", err)
+	}
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse enum labels %s: %w", path, err)
+	}
+
+	labels := DefaultEnumLabels()
+	for value, label := range overrides {
+		if label == "" {
+			delete(labels, value)
+			continue
+		}
+		labels[value] = label
+	}
+	return labels, nil
+}
+
+// SetEnumLabels makes tool results show the phrase of each enum value found
+// in labels, followed by the raw value in parentheses, as in "Waiting for
+// review (WAITING_FOR_REVIEW)". Structured content and results in the json
+// format keep the raw values only. Nil or empty labels show raw values.
+func (r *Registry) SetEnumLabels(labels map[string]string) {
+	r.enumLabels = labels
+}
+
+// labelEnums adds the phrases of the enum values in a result's text.
+func (r *Registry) labelEnums(name string, result *mcp.ToolsCallResult, format string) *mcp.ToolsCallResult {
+	if result == nil || len(r.enumLabels) == 0 || format == formatJSON || unlabeledTools[name] {
+		return result
+	}
+
+	labeled := *result
+	labeled.Content = make([]mcp.ContentBlock, len(result.Content))
+	for i, block := range result.Content {
+		if block.Type == "text" {
+			block.Text = labelEnumValues(block.Text, r.enumLabels)
+		}
+		labeled.Content[i] = block
+	}
+	return &labeled
+}
+
+// labelEnumValues replaces the enum values in text that have a label with
+// "label (VALUE)". Values already in parentheses are left alone, so text
+// is never labeled twice.
+func labelEnumValues(text string, labels map[string]string) string {
+	matches := enumValuePattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var out []byte
+	last := 0
+	for _, m := range matches {
+		value := text[m[0]:m[1]]
+		label, ok := labels[value]
+		if !ok || (m[0] > 0 && text[m[0]-1] == '(') {
+			continue
+		}
+		out = append(out, text[last:m[0]]...)
+		out = append(out, label+" ("+value+")"...)
+		last = m[1]
+	}
+	if out == nil {
+		return text
+	}
+	return string(append(out, text[last:]...))
+}
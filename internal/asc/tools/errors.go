@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// Error classes reported in the _meta of failed tool results.
+const (
+	ErrorClassInvalidArguments = "invalid_arguments"
+	ErrorClassInvalidRequest   = "invalid_request"
+	ErrorClassAuth             = "auth"
+	ErrorClassForbidden        = "forbidden"
+	ErrorClassNotFound         = "not_found"
+	ErrorClassConflict         = "conflict"
+	ErrorClassRateLimited      = "rate_limited"
+	ErrorClassTransient        = "transient"
+	ErrorClassInternal         = "internal"
+)
+
+// ErrUnknownTool is returned for calls to a tool that isn't registered.
+var ErrUnknownTool = errors.New("unknown tool")
+
+// argumentErrorPattern matches the messages of results rejecting a tool's
+// arguments, such as "app_id is required".
+var argumentErrorPattern = regexp.MustCompile(`\b(is|are) required\b|\bmust (be|not)\b|^(Unknown|Invalid|invalid) `)
+
+// transientErrorMarkers are parts of network error messages worth retrying.
+var transientErrorMarkers = []string{
+	"connection refused",
+	"connection reset",
+	"i/o timeout",
+	"no such host",
+	"TLS handshake timeout",
+	"unexpected EOF",
+	"timed out",
+	"Client.Timeout exceeded",
+}
+
+// IsArgumentDecodeError reports whether a tool call failed because its
+// arguments couldn't be decoded, as opposed to having invalid values.
+func IsArgumentDecodeError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "invalid arguments")
+}
+
+// ClassifyError classifies the error a tool call failed with. Errors that
+// aren't API, network or internal errors are argument errors, such as a
+// missing required argument.
+func ClassifyError(err error) *mcp.ToolError {
+	if err == nil {
+		return nil
+	}
+	if toolErr := classifyAPIError(err); toolErr != nil {
+		return toolErr
+	}
+	if toolErr := classifyErrorText(err.Error()); toolErr != nil {
+		return toolErr
+	}
+	return &mcp.ToolError{Class: ErrorClassInvalidArguments}
+}
+
+// classifyAPIError classifies err by the API error in its chain, or returns
+// nil if there is none.
+func classifyAPIError(err error) *mcp.ToolError {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	toolErr := classifyStatus(apiErr.StatusCode)
+	toolErr.RequestID = apiErr.RequestID
+	return toolErr
+}
+
+// classifyErrorText classifies an error that isn't an API error by its
+// message, or returns nil if the message doesn't tell.
+func classifyErrorText(text string) *mcp.ToolError {
+	if strings.Contains(text, "failed unexpectedly") {
+		return &mcp.ToolError{Class: ErrorClassInternal}
+	}
+	for _, marker := range transientErrorMarkers {
+		if strings.Contains(text, marker) {
+			return &mcp.ToolError{Class: ErrorClassTransient, Retryable: true}
+		}
+	}
+	if argumentErrorPattern.MatchString(text) {
+		return &mcp.ToolError{Class: ErrorClassInvalidArguments}
+	}
+	return nil
+}
+
+// classifyStatus classifies a failed API request by its HTTP status.
+func classifyStatus(status int) *mcp.ToolError {
+	toolErr := &mcp.ToolError{Status: status}
+	switch {
+	case status == 401:
+		toolErr.Class = ErrorClassAuth
+	case status == 403:
+		toolErr.Class = ErrorClassForbidden
+	case status == 404:
+		toolErr.Class = ErrorClassNotFound
+	case status == 409:
+		toolErr.Class = ErrorClassConflict
+	case status == 408 || status == 425 || status >= 500:
+		toolErr.Class = ErrorClassTransient
+		toolErr.Retryable = true
+	case status == 429:
+		toolErr.Class = ErrorClassRateLimited
+		toolErr.Retryable = true
+	default:
+		toolErr.Class = ErrorClassInvalidRequest
+	}
+	return toolErr
+}
+
+// withErrorClass classifies a failed result in its _meta, by the API error
+// it failed with or, failing that, if its message tells why it failed.
+func withErrorClass(result *mcp.ToolsCallResult) *mcp.ToolsCallResult {
+	if result == nil || !result.IsError || len(result.Content) == 0 {
+		return result
+	}
+	if _, ok := result.Meta[mcp.MetaError]; ok {
+		return result
+	}
+
+	toolErr := classifyAPIError(result.Err)
+	if toolErr == nil {
+		toolErr = classifyErrorText(result.Content[0].Text)
+	}
+	if toolErr == nil {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = make(map[string]any)
+	}
+	result.Meta[mcp.MetaError] = toolErr
+	return result
+}
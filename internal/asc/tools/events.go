@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
@@ -27,6 +28,7 @@ func (r *Registry) registerAppEventTools() {
 					Type:        "integer",
 					Description: "Maximum number of events to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -147,12 +149,70 @@ func (r *Registry) registerAppEventTools() {
 			Required: []string{"event_id"},
 		},
 	}, r.handleDeleteAppEvent)
+
+	// Submit app event
+	r.register(mcp.Tool{
+		Name:        "submit_app_event",
+		Description: "Submit an app event to App Review. The event is added to the app's open review submission for the platform, or a new one, which is then submitted. Items already in an open submission are submitted with it. The event needs its localizations and event card images first.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"app_id": {
+					Type:        "string",
+					Description: "The App ID",
+				},
+				"event_id": {
+					Type:        "string",
+					Description: "The app event ID",
+				},
+				"platform": {
+					Type:        "string",
+					Description: "Platform of the review submission (default: IOS)",
+					Enum:        api.EnumStrings(api.Platforms),
+				},
+			},
+			Required: []string{"app_id", "event_id"},
+		},
+	}, r.handleSubmitAppEvent)
+
+	// Archive app event
+	r.register(mcp.Tool{
+		Name:        "archive_app_event",
+		Description: "Archive an approved or published app event by ending it now in every territory, which takes it off the App Store. App Store Connect then lists it with its archived events. Delete events that were never approved instead.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"event_id": {
+					Type:        "string",
+					Description: "The app event ID",
+				},
+			},
+			Required: []string{"event_id"},
+		},
+	}, r.handleArchiveAppEvent)
+}
+
+// submittableEventStates are the app event states an event can be submitted
+// to App Review from.
+var submittableEventStates = map[string]bool{
+	"DRAFT":            true,
+	"READY_FOR_REVIEW": true,
+	"REJECTED":         true,
+}
+
+// archivableEventStates are the app event states of events that have been
+// approved and not yet ended.
+var archivableEventStates = map[string]bool{
+	"ACCEPTED":  true,
+	"APPROVED":  true,
+	"PUBLISHED": true,
 }
 
-func (r *Registry) handleListAppEvents(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppEvents(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -167,15 +227,15 @@ func (r *Registry) handleListAppEvents(args json.RawMessage) (*mcp.ToolsCallResu
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppEvents(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListAppEvents(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app events: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app events: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppEvents(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppEvents(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppEvent(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		EventID string `json:"event_id"`
 	}
@@ -187,15 +247,15 @@ func (r *Registry) handleGetAppEvent(args json.RawMessage) (*mcp.ToolsCallResult
 		return nil, fmt.Errorf("event_id is required")
 	}
 
-	resp, err := r.client.GetAppEvent(context.Background(), params.EventID)
+	resp, err := r.client.GetAppEvent(ctx, params.EventID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app event: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app event: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppEvent(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateAppEvent(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID               string `json:"app_id"`
 		ReferenceName       string `json:"reference_name"`
@@ -240,15 +300,15 @@ func (r *Registry) handleCreateAppEvent(args json.RawMessage) (*mcp.ToolsCallRes
 		},
 	}
 
-	resp, err := r.client.CreateAppEvent(context.Background(), req)
+	resp, err := r.client.CreateAppEvent(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create app event: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create app event: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created app event: %s (ID: %s)", resp.Data.Attributes.ReferenceName, resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateAppEvent(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		EventID             string `json:"event_id"`
 		ReferenceName       string `json:"reference_name"`
@@ -281,15 +341,15 @@ func (r *Registry) handleUpdateAppEvent(args json.RawMessage) (*mcp.ToolsCallRes
 		},
 	}
 
-	resp, err := r.client.UpdateAppEvent(context.Background(), params.EventID, req)
+	resp, err := r.client.UpdateAppEvent(ctx, params.EventID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update app event: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update app event: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated app event: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteAppEvent(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		EventID string `json:"event_id"`
 	}
@@ -301,14 +361,152 @@ func (r *Registry) handleDeleteAppEvent(args json.RawMessage) (*mcp.ToolsCallRes
 		return nil, fmt.Errorf("event_id is required")
 	}
 
-	err := r.client.DeleteAppEvent(context.Background(), params.EventID)
+	err := r.client.DeleteAppEvent(ctx, params.EventID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete app event: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete app event: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("App event deleted successfully"), nil
 }
 
+func (r *Registry) handleSubmitAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID    string       `json:"app_id"`
+		EventID  string       `json:"event_id"`
+		Platform api.Platform `json:"platform"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" || params.EventID == "" {
+		return mcp.NewErrorResult("app_id and event_id are required"), nil
+	}
+	if params.Platform == "" {
+		params.Platform = api.PlatformIOS
+	}
+
+	event, err := r.client.GetAppEvent(ctx, params.EventID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app event: %v", err), err), nil
+	}
+	if state := event.Data.Attributes.EventState; state != "" && !submittableEventStates[state] {
+		return mcp.NewErrorResult(fmt.Sprintf("App event %s is %s; only draft, ready for review or rejected events can be submitted", params.EventID, state)), nil
+	}
+
+	open, err := r.client.ListReviewSubmissions(ctx, api.ListOptions{Limit: 1}.
+		WithFilter("app", params.AppID).
+		WithFilter("platform", string(params.Platform)).
+		WithFilter("state", "READY_FOR_REVIEW"))
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list review submissions: %v", err), err), nil
+	}
+
+	var submissionID string
+	if len(open.Data) > 0 {
+		submissionID = open.Data[0].ID
+	} else {
+		created, err := r.client.CreateReviewSubmission(ctx, &api.ReviewSubmissionCreateRequest{
+			Data: api.ReviewSubmissionCreateData{
+				Type:       "reviewSubmissions",
+				Attributes: api.ReviewSubmissionCreateAttributes{Platform: params.Platform},
+				Relationships: api.ReviewSubmissionCreateRelationships{
+					App: api.RelationshipData{Data: api.ResourceIdentifier{Type: "apps", ID: params.AppID}},
+				},
+			},
+		})
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create review submission: %v", err), err), nil
+		}
+		submissionID = created.Data.ID
+	}
+
+	_, err = r.client.CreateReviewSubmissionItem(ctx, &api.ReviewSubmissionItemCreateRequest{
+		Data: api.ReviewSubmissionItemCreateData{
+			Type: "reviewSubmissionItems",
+			Relationships: api.ReviewSubmissionItemCreateRelationships{
+				ReviewSubmission: api.RelationshipData{Data: api.ResourceIdentifier{Type: "reviewSubmissions", ID: submissionID}},
+				AppEvent:         &api.RelationshipData{Data: api.ResourceIdentifier{Type: "appEvents", ID: params.EventID}},
+			},
+		},
+	})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to add app event to review submission %s: %v", submissionID, err), err), nil
+	}
+
+	submitted := true
+	resp, err := r.client.UpdateReviewSubmission(ctx, submissionID, &api.ReviewSubmissionUpdateRequest{
+		Data: api.ReviewSubmissionUpdateData{
+			Type:       "reviewSubmissions",
+			ID:         submissionID,
+			Attributes: api.ReviewSubmissionUpdateAttributes{Submitted: &submitted},
+		},
+	})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Added app event to review submission %s, but failed to submit it: %v", submissionID, err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Submitted app event %s (%s) for review in review submission %s (state: %s)",
+		event.Data.Attributes.ReferenceName, params.EventID, submissionID, resp.Data.Attributes.State)), nil
+}
+
+func (r *Registry) handleArchiveAppEvent(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.EventID == "" {
+		return mcp.NewErrorResult("event_id is required"), nil
+	}
+
+	event, err := r.client.GetAppEvent(ctx, params.EventID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app event: %v", err), err), nil
+	}
+	switch state := event.Data.Attributes.EventState; {
+	case state == "ARCHIVED" || state == "PAST":
+		return mcp.NewSuccessResult(fmt.Sprintf("App event %s has already ended (%s)", params.EventID, state)), nil
+	case !archivableEventStates[state]:
+		return mcp.NewErrorResult(fmt.Sprintf("App event %s is %s; only approved or published events can be archived. Use delete_app_event for events that were never approved.", params.EventID, state)), nil
+	}
+
+	schedules := endTerritorySchedules(event.Data.Attributes.TerritorySchedules, time.Now().UTC())
+	if len(schedules) == 0 {
+		return mcp.NewErrorResult(fmt.Sprintf("App event %s has no territory schedules to end", params.EventID)), nil
+	}
+
+	_, err = r.client.UpdateAppEvent(ctx, params.EventID, &api.AppEventUpdateRequest{
+		Data: api.AppEventUpdateData{
+			Type:       "appEvents",
+			ID:         params.EventID,
+			Attributes: api.AppEventUpdateAttributes{TerritorySchedules: schedules},
+		},
+	})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to archive app event: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Archived app event %s (%s): ended it in %d territory schedules", event.Data.Attributes.ReferenceName, params.EventID, len(schedules))), nil
+}
+
+// endTerritorySchedules returns schedules with every date after now moved to
+// now, so the event ends everywhere without a start after its end.
+func endTerritorySchedules(schedules []api.TerritorySchedule, now time.Time) []api.TerritorySchedule {
+	ended := make([]api.TerritorySchedule, len(schedules))
+	for i, schedule := range schedules {
+		for _, date := range []**time.Time{&schedule.PublishStart, &schedule.EventStart, &schedule.EventEnd} {
+			if *date == nil || (*date).After(now) {
+				*date = &now
+			}
+		}
+		ended[i] = schedule
+	}
+	return ended
+}
+
 func formatAppEvents(events []api.AppEvent) string {
 	if len(events) == 0 {
 		return "No app events found"
@@ -43,6 +43,7 @@ func (r *Registry) registerGameCenterTools() {
 					Type:        "integer",
 					Description: "Maximum number of achievements to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"game_center_detail_id"},
 		},
@@ -167,6 +168,7 @@ func (r *Registry) registerGameCenterTools() {
 					Type:        "integer",
 					Description: "Maximum number of leaderboards to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"game_center_detail_id"},
 		},
@@ -215,6 +217,17 @@ func (r *Registry) registerGameCenterTools() {
 					Type:        "string",
 					Description: "How scores are sorted (ASC, DESC)",
 				},
+				"default_formatter": {
+					Type:        "string",
+					Description: "How scores are displayed (default: INTEGER)",
+					Enum: []string{
+						"INTEGER", "DECIMAL_POINT_1_PLACE", "DECIMAL_POINT_2_PLACE", "DECIMAL_POINT_3_PLACE",
+						"ELAPSED_TIME_CENTISECOND", "ELAPSED_TIME_MINUTE", "ELAPSED_TIME_SECOND",
+						"MONEY_POUND_DECIMAL", "MONEY_POUND", "MONEY_DOLLAR_DECIMAL", "MONEY_DOLLAR",
+						"MONEY_EURO_DECIMAL", "MONEY_EURO", "MONEY_FRANC_DECIMAL", "MONEY_FRANC",
+						"MONEY_KRONER_DECIMAL", "MONEY_KRONER", "MONEY_YEN",
+					},
+				},
 				"score_range_start": {
 					Type:        "string",
 					Description: "Minimum valid score",
@@ -277,7 +290,7 @@ func (r *Registry) registerGameCenterTools() {
 	}, r.handleDeleteGameCenterLeaderboard)
 }
 
-func (r *Registry) handleGetGameCenterDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetGameCenterDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -289,18 +302,19 @@ func (r *Registry) handleGetGameCenterDetail(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetGameCenterDetail(context.Background(), params.AppID)
+	resp, err := r.client.GetGameCenterDetail(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get Game Center detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get Game Center detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatGameCenterDetail(resp.Data)), nil
 }
 
-func (r *Registry) handleListGameCenterAchievements(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListGameCenterAchievements(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GameCenterDetailID string `json:"game_center_detail_id"`
 		Limit              int    `json:"limit"`
+		Cursor             string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -315,15 +329,15 @@ func (r *Registry) handleListGameCenterAchievements(args json.RawMessage) (*mcp.
 		limit = 50
 	}
 
-	resp, err := r.client.ListGameCenterAchievements(context.Background(), params.GameCenterDetailID, limit)
+	resp, err := r.client.ListGameCenterAchievements(api.WithCursor(ctx, params.Cursor), params.GameCenterDetailID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list achievements: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list achievements: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatGameCenterAchievements(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatGameCenterAchievements(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetGameCenterAchievement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetGameCenterAchievement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AchievementID string `json:"achievement_id"`
 	}
@@ -335,15 +349,15 @@ func (r *Registry) handleGetGameCenterAchievement(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("achievement_id is required")
 	}
 
-	resp, err := r.client.GetGameCenterAchievement(context.Background(), params.AchievementID)
+	resp, err := r.client.GetGameCenterAchievement(ctx, params.AchievementID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get achievement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get achievement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatGameCenterAchievement(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateGameCenterAchievement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateGameCenterAchievement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GameCenterDetailID string `json:"game_center_detail_id"`
 		ReferenceName      string `json:"reference_name"`
@@ -387,15 +401,15 @@ func (r *Registry) handleCreateGameCenterAchievement(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.CreateGameCenterAchievement(context.Background(), req)
+	resp, err := r.client.CreateGameCenterAchievement(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create achievement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create achievement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created achievement: %s (ID: %s)", resp.Data.Attributes.ReferenceName, resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateGameCenterAchievement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateGameCenterAchievement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AchievementID    string `json:"achievement_id"`
 		ReferenceName    string `json:"reference_name"`
@@ -426,15 +440,15 @@ func (r *Registry) handleUpdateGameCenterAchievement(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.UpdateGameCenterAchievement(context.Background(), params.AchievementID, req)
+	resp, err := r.client.UpdateGameCenterAchievement(ctx, params.AchievementID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update achievement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update achievement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated achievement: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteGameCenterAchievement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteGameCenterAchievement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AchievementID string `json:"achievement_id"`
 	}
@@ -446,18 +460,19 @@ func (r *Registry) handleDeleteGameCenterAchievement(args json.RawMessage) (*mcp
 		return nil, fmt.Errorf("achievement_id is required")
 	}
 
-	err := r.client.DeleteGameCenterAchievement(context.Background(), params.AchievementID)
+	err := r.client.DeleteGameCenterAchievement(ctx, params.AchievementID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete achievement: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete achievement: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Achievement deleted successfully"), nil
 }
 
-func (r *Registry) handleListGameCenterLeaderboards(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListGameCenterLeaderboards(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GameCenterDetailID string `json:"game_center_detail_id"`
 		Limit              int    `json:"limit"`
+		Cursor             string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -472,15 +487,15 @@ func (r *Registry) handleListGameCenterLeaderboards(args json.RawMessage) (*mcp.
 		limit = 50
 	}
 
-	resp, err := r.client.ListGameCenterLeaderboards(context.Background(), params.GameCenterDetailID, limit)
+	resp, err := r.client.ListGameCenterLeaderboards(api.WithCursor(ctx, params.Cursor), params.GameCenterDetailID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list leaderboards: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list leaderboards: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatGameCenterLeaderboards(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatGameCenterLeaderboards(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetGameCenterLeaderboard(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetGameCenterLeaderboard(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LeaderboardID string `json:"leaderboard_id"`
 	}
@@ -492,21 +507,22 @@ func (r *Registry) handleGetGameCenterLeaderboard(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("leaderboard_id is required")
 	}
 
-	resp, err := r.client.GetGameCenterLeaderboard(context.Background(), params.LeaderboardID)
+	resp, err := r.client.GetGameCenterLeaderboard(ctx, params.LeaderboardID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get leaderboard: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get leaderboard: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatGameCenterLeaderboard(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateGameCenterLeaderboard(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateGameCenterLeaderboard(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GameCenterDetailID string `json:"game_center_detail_id"`
 		ReferenceName      string `json:"reference_name"`
 		VendorIdentifier   string `json:"vendor_identifier"`
 		SubmissionType     string `json:"submission_type"`
 		ScoreSortType      string `json:"score_sort_type"`
+		DefaultFormatter   string `json:"default_formatter"`
 		ScoreRangeStart    string `json:"score_range_start"`
 		ScoreRangeEnd      string `json:"score_range_end"`
 	}
@@ -529,6 +545,9 @@ func (r *Registry) handleCreateGameCenterLeaderboard(args json.RawMessage) (*mcp
 	if params.ScoreSortType == "" {
 		return nil, fmt.Errorf("score_sort_type is required")
 	}
+	if params.DefaultFormatter == "" {
+		params.DefaultFormatter = "INTEGER"
+	}
 
 	req := &api.GameCenterLeaderboardCreateRequest{
 		Data: api.GameCenterLeaderboardCreateData{
@@ -538,6 +557,7 @@ func (r *Registry) handleCreateGameCenterLeaderboard(args json.RawMessage) (*mcp
 				VendorIdentifier: params.VendorIdentifier,
 				SubmissionType:   params.SubmissionType,
 				ScoreSortType:    params.ScoreSortType,
+				DefaultFormatter: params.DefaultFormatter,
 				ScoreRangeStart:  params.ScoreRangeStart,
 				ScoreRangeEnd:    params.ScoreRangeEnd,
 			},
@@ -552,15 +572,15 @@ func (r *Registry) handleCreateGameCenterLeaderboard(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.CreateGameCenterLeaderboard(context.Background(), req)
+	resp, err := r.client.CreateGameCenterLeaderboard(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create leaderboard: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create leaderboard: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created leaderboard: %s (ID: %s)", resp.Data.Attributes.ReferenceName, resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateGameCenterLeaderboard(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateGameCenterLeaderboard(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LeaderboardID  string `json:"leaderboard_id"`
 		ReferenceName  string `json:"reference_name"`
@@ -589,15 +609,15 @@ func (r *Registry) handleUpdateGameCenterLeaderboard(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.UpdateGameCenterLeaderboard(context.Background(), params.LeaderboardID, req)
+	resp, err := r.client.UpdateGameCenterLeaderboard(ctx, params.LeaderboardID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update leaderboard: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update leaderboard: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated leaderboard: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteGameCenterLeaderboard(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteGameCenterLeaderboard(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LeaderboardID string `json:"leaderboard_id"`
 	}
@@ -609,9 +629,9 @@ func (r *Registry) handleDeleteGameCenterLeaderboard(args json.RawMessage) (*mcp
 		return nil, fmt.Errorf("leaderboard_id is required")
 	}
 
-	err := r.client.DeleteGameCenterLeaderboard(context.Background(), params.LeaderboardID)
+	err := r.client.DeleteGameCenterLeaderboard(ctx, params.LeaderboardID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete leaderboard: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete leaderboard: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Leaderboard deleted successfully"), nil
@@ -27,6 +27,7 @@ func (r *Registry) registerInAppPurchaseTools() {
 					Type:        "integer",
 					Description: "Maximum number of in-app purchases to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -70,6 +71,7 @@ func (r *Registry) registerInAppPurchaseTools() {
 				"iap_type": {
 					Type:        "string",
 					Description: "The type (CONSUMABLE, NON_CONSUMABLE, NON_RENEWING_SUBSCRIPTION)",
+					Enum:        api.EnumStrings(api.InAppPurchaseTypes),
 				},
 				"review_note": {
 					Type:        "string",
@@ -129,10 +131,11 @@ func (r *Registry) registerInAppPurchaseTools() {
 	}, r.handleDeleteInAppPurchase)
 }
 
-func (r *Registry) handleListInAppPurchases(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListInAppPurchases(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -147,15 +150,15 @@ func (r *Registry) handleListInAppPurchases(args json.RawMessage) (*mcp.ToolsCal
 		limit = 50
 	}
 
-	resp, err := r.client.ListInAppPurchases(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListInAppPurchases(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list in-app purchases: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list in-app purchases: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatInAppPurchases(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatInAppPurchases(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetInAppPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetInAppPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		IAPID string `json:"iap_id"`
 	}
@@ -167,22 +170,22 @@ func (r *Registry) handleGetInAppPurchase(args json.RawMessage) (*mcp.ToolsCallR
 		return nil, fmt.Errorf("iap_id is required")
 	}
 
-	resp, err := r.client.GetInAppPurchase(context.Background(), params.IAPID)
+	resp, err := r.client.GetInAppPurchase(ctx, params.IAPID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get in-app purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get in-app purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatInAppPurchase(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateInAppPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateInAppPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID          string `json:"app_id"`
-		Name           string `json:"name"`
-		ProductID      string `json:"product_id"`
-		IAPType        string `json:"iap_type"`
-		ReviewNote     string `json:"review_note"`
-		FamilySharable bool   `json:"family_sharable"`
+		AppID          string                `json:"app_id"`
+		Name           string                `json:"name"`
+		ProductID      string                `json:"product_id"`
+		IAPType        api.InAppPurchaseType `json:"iap_type"`
+		ReviewNote     string                `json:"review_note"`
+		FamilySharable bool                  `json:"family_sharable"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -222,15 +225,15 @@ func (r *Registry) handleCreateInAppPurchase(args json.RawMessage) (*mcp.ToolsCa
 		},
 	}
 
-	resp, err := r.client.CreateInAppPurchase(context.Background(), req)
+	resp, err := r.client.CreateInAppPurchase(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create in-app purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create in-app purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created in-app purchase: %s (ID: %s)", resp.Data.Attributes.Name, resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateInAppPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateInAppPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		IAPID          string `json:"iap_id"`
 		Name           string `json:"name"`
@@ -257,15 +260,15 @@ func (r *Registry) handleUpdateInAppPurchase(args json.RawMessage) (*mcp.ToolsCa
 		},
 	}
 
-	resp, err := r.client.UpdateInAppPurchase(context.Background(), params.IAPID, req)
+	resp, err := r.client.UpdateInAppPurchase(ctx, params.IAPID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update in-app purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update in-app purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated in-app purchase: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteInAppPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteInAppPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		IAPID string `json:"iap_id"`
 	}
@@ -277,9 +280,9 @@ func (r *Registry) handleDeleteInAppPurchase(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("iap_id is required")
 	}
 
-	err := r.client.DeleteInAppPurchase(context.Background(), params.IAPID)
+	err := r.client.DeleteInAppPurchase(ctx, params.IAPID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete in-app purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete in-app purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("In-app purchase deleted successfully"), nil
@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"sort"
+	"sync"
+)
+
+// defaultConcurrencyLimit is the number of tool calls that run at once unless
+// SetConcurrencyLimits sets a "*" limit.
+const defaultConcurrencyLimit = 4
+
+// pollingTools spend most of a call, up to maxPollTimeout, sleeping between
+// status checks. They don't take a slot under the "*" limit, so a few long
+// waits can't hold up every other call.
+var pollingTools = map[string]bool{
+	"wait_for_build_processing":           true,
+	"wait_for_analytics_report_instances": true,
+	"verify_asset_upload":                 true,
+}
+
+// semaphore limits concurrent holders and admits waiters in arrival order.
+type semaphore struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiters list.List // of chan struct{}
+}
+
+func newSemaphore(limit int) *semaphore {
+	return &semaphore{limit: limit}
+}
+
+// acquire waits for a slot or until ctx is done.
+func (s *semaphore) acquire(ctx context.Context) error {
+	s.mu.Lock()
+	if s.active < s.limit && s.waiters.Len() == 0 {
+		s.active++
+		s.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(ready)
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// The slot was handed over while we gave up; pass it on.
+			s.mu.Unlock()
+			s.release()
+		default:
+			s.waiters.Remove(elem)
+			s.mu.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it straight to the longest waiter if any.
+func (s *semaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if front := s.waiters.Front(); front != nil {
+		s.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	s.active--
+}
+
+// SetConcurrencyLimits sets how many tool calls may run at once. The "*" key
+// limits all calls; any other key is a glob over tool names, such as "list_*"
+// or "*_report", and limits the calls it matches. A call waits, in arrival
+// order, until every limit that applies to it has room. Polling tools are
+// only limited by the patterns that match them.
+func (r *Registry) SetConcurrencyLimits(limits map[string]int) {
+	for pattern, limit := range limits {
+		if pattern == "*" {
+			r.globalLimit = newSemaphore(limit)
+			continue
+		}
+		r.limits[pattern] = newSemaphore(limit)
+	}
+}
+
+// acquireSlots waits for room under every limit that applies to the named
+// tool and returns a function that releases them.
+func (r *Registry) acquireSlots(ctx context.Context, name string) (func(), error) {
+	// Acquire in a fixed order so that two calls never wait on each other.
+	var patterns []string
+	for pattern := range r.limits {
+		if matched, _ := path.Match(pattern, name); matched {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+
+	semaphores := make([]*semaphore, 0, len(patterns)+1)
+	for _, pattern := range patterns {
+		semaphores = append(semaphores, r.limits[pattern])
+	}
+	if r.globalLimit != nil && !pollingTools[name] {
+		semaphores = append(semaphores, r.globalLimit)
+	}
+
+	release := func(held []*semaphore) {
+		for i := len(held) - 1; i >= 0; i-- {
+			held[i].release()
+		}
+	}
+
+	for i, sem := range semaphores {
+		if err := sem.acquire(ctx); err != nil {
+			release(semaphores[:i])
+			return nil, err
+		}
+	}
+
+	return func() { release(semaphores) }, nil
+}
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// maxURLRedirects is how many redirects a metadata URL may take before it is
+// reported as broken.
+const maxURLRedirects = 10
+
+// errNotHTTP is reported for metadata URLs that can't be opened in a browser.
+var errNotHTTP = errors.New("not an http(s) URL")
+
+// urlCheckClient fetches metadata URLs, giving up well before the tool timeout.
+// It returns redirects instead of following them so each hop can be reported.
+var urlCheckClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// registerLinkTools registers the metadata link checker.
+func (r *Registry) registerLinkTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "check_metadata_urls",
+			Description: "Request every support, marketing, privacy policy and privacy choices URL in an app's App Info and version localizations. Reports the HTTP status and any redirects per locale; broken URLs are a common rejection cause.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"version_id": {
+						Type:        "string",
+						Description: "Optional: App Store version whose support and marketing URLs to check as well",
+					},
+				},
+				Required: []string{"app_id"},
+			},
+			OutputSchema: mcp.SchemaFor(linkCheckOutput{}),
+		},
+		r.handleCheckMetadataURLs,
+	)
+}
+
+// handleCheckMetadataURLs handles the check_metadata_urls tool.
+func (r *Registry) handleCheckMetadataURLs(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID     string `json:"app_id"`
+		VersionID string `json:"version_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" {
+		return mcp.NewErrorResult("app_id is required"), nil
+	}
+
+	fields, err := r.metadataFields(ctx, params.AppID, params.VersionID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read metadata: %v", err), err), nil
+	}
+
+	// The URLs are collected before checking starts, since the checks write
+	// their results into urls.
+	urls := make(map[string]urlCheck)
+	var unique []string
+	for _, f := range fields {
+		if _, seen := urls[f.value]; strings.HasSuffix(f.name, "Url") && f.value != "" && !seen {
+			urls[f.value] = urlCheck{}
+			unique = append(unique, f.value)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, u := range unique {
+		wg.Add(1)
+		go func(u string) {
+			defer wg.Done()
+			check := fetchURL(ctx, u)
+			mu.Lock()
+			urls[u] = check
+			mu.Unlock()
+		}(u)
+	}
+	wg.Wait()
+
+	output := linkCheckOutput{AppID: params.AppID, VersionID: params.VersionID, Links: []linkCheck{}}
+	for _, f := range fields {
+		if !strings.HasSuffix(f.name, "Url") || f.value == "" {
+			continue
+		}
+		check := urls[f.value]
+		link := linkCheck{
+			Locale:     f.locale,
+			Field:      f.name,
+			URL:        f.value,
+			StatusCode: check.statusCode,
+			Redirects:  check.redirects,
+			OK:         check.ok(),
+		}
+		if check.err != nil {
+			link.Error = check.err.Error()
+		}
+		output.Links = append(output.Links, link)
+
+		if !link.OK {
+			output.Broken++
+		}
+		if len(link.Redirects) > 0 {
+			output.Redirected++
+		}
+	}
+	sort.SliceStable(output.Links, func(i, j int) bool {
+		a, b := output.Links[i], output.Links[j]
+		if a.Locale != b.Locale {
+			return a.Locale < b.Locale
+		}
+		return a.Field < b.Field
+	})
+
+	return mcp.NewStructuredResult(formatLinkCheck(output), output), nil
+}
+
+// urlCheck is the outcome of requesting a URL.
+type urlCheck struct {
+	statusCode int
+	redirects  []string
+	err        error
+}
+
+// ok reports whether the URL loaded with a 2xx status.
+func (c urlCheck) ok() bool {
+	return c.err == nil && c.statusCode >= 200 && c.statusCode < 300
+}
+
+// problem describes why the URL looks broken to App Review, or "" if it loads.
+func (c urlCheck) problem() string {
+	switch {
+	case errors.Is(c.err, errNotHTTP):
+		return "is " + c.err.Error()
+	case c.err != nil:
+		return fmt.Sprintf("could not be reached: %v", c.err)
+	case !c.ok():
+		return fmt.Sprintf("returned HTTP %d", c.statusCode)
+	}
+	return ""
+}
+
+// checkURL returns why a URL looks broken to App Review, or "" if it loads.
+func checkURL(ctx context.Context, u string) string {
+	return fetchURL(ctx, u).problem()
+}
+
+// fetchURL requests u, following redirects. Servers that refuse HEAD are
+// retried with GET.
+func fetchURL(ctx context.Context, u string) urlCheck {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return urlCheck{err: errNotHTTP}
+	}
+
+	check := followURL(ctx, http.MethodHead, parsed)
+	switch check.statusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden:
+		check = followURL(ctx, http.MethodGet, parsed)
+	}
+	return check
+}
+
+// followURL sends method requests to u and the locations it redirects to.
+func followURL(ctx context.Context, method string, u *url.URL) urlCheck {
+	var check urlCheck
+	for {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+		if err != nil {
+			check.err = err
+			return check
+		}
+		resp, err := urlCheckClient.Do(req)
+		if err != nil {
+			check.err = err
+			return check
+		}
+		resp.Body.Close()
+		check.statusCode = resp.StatusCode
+
+		location := resp.Header.Get("Location")
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+			return check
+		}
+		next, err := u.Parse(location)
+		if err != nil {
+			check.err = fmt.Errorf("invalid redirect to %q", location)
+			return check
+		}
+		if len(check.redirects) == maxURLRedirects {
+			check.err = fmt.Errorf("more than %d redirects", maxURLRedirects)
+			return check
+		}
+		check.redirects = append(check.redirects, next.String())
+		u = next
+	}
+}
+
+// formatLinkCheck renders each URL's status by locale.
+func formatLinkCheck(c linkCheckOutput) string {
+	if len(c.Links) == 0 {
+		return "No URLs found in the app's metadata."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Checked %d URLs: %d broken, %d redirected\n", len(c.Links), c.Broken, c.Redirected))
+
+	locale := ""
+	for _, link := range c.Links {
+		if link.Locale != locale {
+			locale = link.Locale
+			sb.WriteString(fmt.Sprintf("\n%s:\n", locale))
+		}
+
+		status := "OK"
+		switch {
+		case link.Error != "":
+			status = "BROKEN: " + link.Error
+		case !link.OK:
+			status = fmt.Sprintf("BROKEN: HTTP %d", link.StatusCode)
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (%s)\n", link.Field, link.URL, status))
+		for _, hop := range link.Redirects {
+			sb.WriteString(fmt.Sprintf("      -> %s\n", hop))
+		}
+	}
+	return sb.String()
+}
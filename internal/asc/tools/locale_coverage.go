@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// localeCoverageConcurrency is how many apps a coverage report reads at once.
+const localeCoverageConcurrency = 4
+
+// Parts of an app's store metadata that a locale can be missing.
+const (
+	coverageAppInfo = "appInfo"
+	coverageVersion = "version"
+)
+
+// registerLocaleCoverageTools registers the portfolio locale coverage report.
+func (r *Registry) registerLocaleCoverageTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "get_locale_coverage",
+			Description: "Report, for every app in the account (or the given apps), which target locales have store metadata and which are missing, and return a localization backlog ordered by the priority of the target locales. A locale is covered when the app info has a name and the newest App Store version has a description for it.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"target_locales": {
+						Type:        "array",
+						Description: "Locales every app should have, most important first, e.g. [\"en-US\", \"de-DE\", \"ja\"]",
+						Items:       &mcp.Property{Type: "string"},
+					},
+					"app_ids": {
+						Type:        "array",
+						Description: "Optional: App IDs or app group names to report on (default: every app in the account)",
+						Items:       &mcp.Property{Type: "string"},
+					},
+				},
+				Required: []string{"target_locales"},
+			},
+			OutputSchema: mcp.SchemaFor(localeCoverageOutput{}),
+		},
+		r.handleGetLocaleCoverage,
+	)
+}
+
+// handleGetLocaleCoverage handles the get_locale_coverage tool.
+func (r *Registry) handleGetLocaleCoverage(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		TargetLocales []string `json:"target_locales"`
+		AppIDs        []string `json:"app_ids"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	var targets []string
+	for _, locale := range params.TargetLocales {
+		if locale = strings.TrimSpace(locale); locale != "" && !slices.Contains(targets, locale) {
+			targets = append(targets, locale)
+		}
+	}
+	if len(targets) == 0 {
+		return mcp.NewErrorResult("target_locales is required"), nil
+	}
+
+	apps := make([]localeCoverageApp, 0)
+	if len(params.AppIDs) > 0 {
+		for _, appID := range r.expandAppIDs(params.AppIDs) {
+			apps = append(apps, localeCoverageApp{AppID: appID})
+		}
+	} else {
+		all, err := r.client.ListAllApps(ctx)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list apps: %v", err), err), nil
+		}
+		for _, app := range all {
+			apps = append(apps, localeCoverageApp{AppID: app.ID, AppName: app.Attributes.Name})
+		}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, localeCoverageConcurrency)
+	for i := range apps {
+		wg.Add(1)
+		go func(app *localeCoverageApp) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if err := r.appLocaleCoverage(ctx, app, targets); err != nil {
+				app.Error = err.Error()
+			}
+		}(&apps[i])
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	output := localeCoverageOutput{
+		TargetLocales: targets,
+		Apps:          apps,
+		Locales:       make([]localeCoverageLocale, len(targets)),
+		Backlog:       make([]localeBacklogItem, 0),
+	}
+	for i, locale := range targets {
+		output.Locales[i].Locale = locale
+	}
+	for _, app := range apps {
+		if app.Error != "" {
+			continue
+		}
+		for i, locale := range targets {
+			gaps := app.gaps[locale]
+			if len(gaps) == 0 {
+				output.Locales[i].Covered++
+				continue
+			}
+			output.Locales[i].Missing++
+			output.Backlog = append(output.Backlog, localeBacklogItem{
+				Locale:  locale,
+				AppID:   app.AppID,
+				AppName: app.AppName,
+				Missing: gaps,
+			})
+		}
+	}
+
+	// Higher-priority locales come first; within a locale, apps missing
+	// everything come before apps that only lack one part.
+	priority := make(map[string]int, len(targets))
+	for i, locale := range targets {
+		priority[locale] = i
+	}
+	sort.SliceStable(output.Backlog, func(i, j int) bool {
+		a, b := output.Backlog[i], output.Backlog[j]
+		if priority[a.Locale] != priority[b.Locale] {
+			return priority[a.Locale] < priority[b.Locale]
+		}
+		if len(a.Missing) != len(b.Missing) {
+			return len(a.Missing) > len(b.Missing)
+		}
+		return a.AppName < b.AppName
+	})
+	for i := range output.Backlog {
+		output.Backlog[i].Priority = i + 1
+	}
+
+	return mcp.NewStructuredResult(formatLocaleCoverage(output), output), nil
+}
+
+// appLocaleCoverage records which parts of each target locale's metadata an
+// app is missing.
+func (r *Registry) appLocaleCoverage(ctx context.Context, app *localeCoverageApp, targets []string) error {
+	if app.AppName == "" {
+		resp, err := r.client.GetApp(ctx, app.AppID)
+		if err != nil {
+			return fmt.Errorf("failed to get app: %w", err)
+		}
+		app.AppName = resp.Data.Attributes.Name
+	}
+
+	infoLocales := make(map[string]bool)
+	appInfos, err := r.client.GetAppInfos(ctx, app.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to get app infos: %w", err)
+	}
+	if len(appInfos.Data) > 0 {
+		locs, err := r.client.ListAppInfoLocalizations(ctx, appInfos.Data[0].ID, api.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list app info localizations: %w", err)
+		}
+		for _, loc := range locs.Data {
+			if strings.TrimSpace(loc.Attributes.Name) != "" {
+				infoLocales[loc.Attributes.Locale] = true
+			}
+		}
+	}
+
+	versionLocales := make(map[string]bool)
+	versions, err := r.client.GetAppVersions(ctx, app.AppID, api.ListOptions{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("failed to get app versions: %w", err)
+	}
+	if len(versions.Data) > 0 {
+		version := versions.Data[0]
+		app.VersionID, app.VersionString = version.ID, version.Attributes.VersionString
+		locs, err := r.client.ListAppStoreVersionLocalizations(ctx, version.ID, api.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list version localizations: %w", err)
+		}
+		for _, loc := range locs.Data {
+			if strings.TrimSpace(loc.Attributes.Description) != "" {
+				versionLocales[loc.Attributes.Locale] = true
+			}
+		}
+	}
+
+	app.gaps = make(map[string][]string)
+	app.Covered, app.Partial, app.Missing = []string{}, []string{}, []string{}
+	for _, locale := range targets {
+		var gaps []string
+		if !infoLocales[locale] {
+			gaps = append(gaps, coverageAppInfo)
+		}
+		if !versionLocales[locale] {
+			gaps = append(gaps, coverageVersion)
+		}
+		app.gaps[locale] = gaps
+		switch len(gaps) {
+		case 0:
+			app.Covered = append(app.Covered, locale)
+		case 1:
+			app.Partial = append(app.Partial, locale)
+		default:
+			app.Missing = append(app.Missing, locale)
+		}
+	}
+	return nil
+}
+
+// formatLocaleCoverage renders the coverage per locale and the backlog.
+func formatLocaleCoverage(o localeCoverageOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Locale coverage across %d apps:\n\n", len(o.Apps)))
+	for _, l := range o.Locales {
+		sb.WriteString(fmt.Sprintf("- %s: %d covered, %d missing\n", l.Locale, l.Covered, l.Missing))
+	}
+
+	if len(o.Backlog) == 0 {
+		sb.WriteString("\nEvery app has metadata for every target locale.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("\nLocalization backlog (%d items):\n\n", len(o.Backlog)))
+		for _, item := range o.Backlog {
+			sb.WriteString(fmt.Sprintf("%d. %s for %s (%s): missing %s\n", item.Priority, item.Locale, item.AppName, item.AppID, strings.Join(item.Missing, " and ")))
+		}
+	}
+
+	for _, app := range o.Apps {
+		if app.Error != "" {
+			sb.WriteString(fmt.Sprintf("\nCould not check %s: %s\n", app.AppID, app.Error))
+		}
+	}
+	return sb.String()
+}
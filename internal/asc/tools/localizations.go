@@ -22,6 +22,7 @@ func (r *Registry) registerAppInfoLocalizationTools() {
 					Type:        "string",
 					Description: "The app info ID",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_info_id"},
 		},
@@ -159,6 +160,7 @@ func (r *Registry) registerVersionLocalizationTools() {
 					Type:        "string",
 					Description: "The app store version ID",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"version_id"},
 		},
@@ -279,7 +281,7 @@ func (r *Registry) registerVersionLocalizationTools() {
 
 // App Info Localization handlers
 
-func (r *Registry) handleGetAppInfos(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppInfos(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -291,19 +293,19 @@ func (r *Registry) handleGetAppInfos(args json.RawMessage) (*mcp.ToolsCallResult
 		return mcp.NewErrorResult("app_id is required"), nil
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.GetAppInfos(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app infos: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app infos: %v", err), err), nil
 	}
 
 	result := formatAppInfos(resp.Data)
 	return mcp.NewSuccessResult(result), nil
 }
 
-func (r *Registry) handleListAppInfoLocalizations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppInfoLocalizations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppInfoID string `json:"app_info_id"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -313,17 +315,16 @@ func (r *Registry) handleListAppInfoLocalizations(args json.RawMessage) (*mcp.To
 		return mcp.NewErrorResult("app_info_id is required"), nil
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListAppInfoLocalizations(ctx, params.AppInfoID)
+	resp, err := r.client.ListAppInfoLocalizations(api.WithCursor(ctx, params.Cursor), params.AppInfoID, api.ListOptions{})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app info localizations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app info localizations: %v", err), err), nil
 	}
 
 	result := formatAppInfoLocalizations(resp.Data)
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNextCursor(result, resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppInfoLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppInfoLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -335,17 +336,16 @@ func (r *Registry) handleGetAppInfoLocalization(args json.RawMessage) (*mcp.Tool
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.GetAppInfoLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app info localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app info localization: %v", err), err), nil
 	}
 
 	result := formatAppInfoLocalization(&resp.Data)
 	return mcp.NewSuccessResult(result), nil
 }
 
-func (r *Registry) handleCreateAppInfoLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppInfoLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppInfoID         string `json:"app_info_id"`
 		Locale            string `json:"locale"`
@@ -362,6 +362,14 @@ func (r *Registry) handleCreateAppInfoLocalization(args json.RawMessage) (*mcp.T
 	if params.AppInfoID == "" || params.Locale == "" || params.Name == "" {
 		return mcp.NewErrorResult("app_info_id, locale, and name are required"), nil
 	}
+	normalized, errResult := normalizeMetadataFields(map[string]*string{
+		"name":                &params.Name,
+		"subtitle":            &params.Subtitle,
+		"privacy_policy_text": &params.PrivacyPolicyText,
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	req := &api.AppInfoLocalizationCreateRequest{
 		Data: api.AppInfoLocalizationCreateData{
@@ -385,18 +393,17 @@ func (r *Registry) handleCreateAppInfoLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.CreateAppInfoLocalization(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create app info localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create app info localization: %v", err), err), nil
 	}
 
 	result := fmt.Sprintf("Created app info localization for locale '%s'\n\n%s",
 		params.Locale, formatAppInfoLocalization(&resp.Data))
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNormalizedNote(result, normalized)), nil
 }
 
-func (r *Registry) handleUpdateAppInfoLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateAppInfoLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID    string `json:"localization_id"`
 		Name              string `json:"name"`
@@ -412,6 +419,14 @@ func (r *Registry) handleUpdateAppInfoLocalization(args json.RawMessage) (*mcp.T
 	if params.LocalizationID == "" {
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
+	normalized, errResult := normalizeMetadataFields(map[string]*string{
+		"name":                &params.Name,
+		"subtitle":            &params.Subtitle,
+		"privacy_policy_text": &params.PrivacyPolicyText,
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	req := &api.AppInfoLocalizationUpdateRequest{
 		Data: api.AppInfoLocalizationUpdateData{
@@ -427,17 +442,16 @@ func (r *Registry) handleUpdateAppInfoLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.UpdateAppInfoLocalization(ctx, params.LocalizationID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update app info localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update app info localization: %v", err), err), nil
 	}
 
 	result := fmt.Sprintf("Updated app info localization\n\n%s", formatAppInfoLocalization(&resp.Data))
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNormalizedNote(result, normalized)), nil
 }
 
-func (r *Registry) handleDeleteAppInfoLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAppInfoLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -449,10 +463,9 @@ func (r *Registry) handleDeleteAppInfoLocalization(args json.RawMessage) (*mcp.T
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
 
-	ctx := context.Background()
 	err := r.client.DeleteAppInfoLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete app info localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete app info localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Successfully deleted app info localization"), nil
@@ -460,9 +473,10 @@ func (r *Registry) handleDeleteAppInfoLocalization(args json.RawMessage) (*mcp.T
 
 // Version Localization handlers
 
-func (r *Registry) handleListVersionLocalizations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListVersionLocalizations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -472,17 +486,16 @@ func (r *Registry) handleListVersionLocalizations(args json.RawMessage) (*mcp.To
 		return mcp.NewErrorResult("version_id is required"), nil
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListAppStoreVersionLocalizations(ctx, params.VersionID)
+	resp, err := r.client.ListAppStoreVersionLocalizations(api.WithCursor(ctx, params.Cursor), params.VersionID, api.ListOptions{})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list version localizations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list version localizations: %v", err), err), nil
 	}
 
 	result := formatVersionLocalizations(resp.Data)
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNextCursor(result, resp.Links)), nil
 }
 
-func (r *Registry) handleGetVersionLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetVersionLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -494,17 +507,16 @@ func (r *Registry) handleGetVersionLocalization(args json.RawMessage) (*mcp.Tool
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.GetAppStoreVersionLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get version localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get version localization: %v", err), err), nil
 	}
 
 	result := formatVersionLocalization(&resp.Data)
 	return mcp.NewSuccessResult(result), nil
 }
 
-func (r *Registry) handleCreateVersionLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateVersionLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID       string `json:"version_id"`
 		Locale          string `json:"locale"`
@@ -522,6 +534,15 @@ func (r *Registry) handleCreateVersionLocalization(args json.RawMessage) (*mcp.T
 	if params.VersionID == "" || params.Locale == "" {
 		return mcp.NewErrorResult("version_id and locale are required"), nil
 	}
+	normalized, errResult := normalizeMetadataFields(map[string]*string{
+		"description":      &params.Description,
+		"keywords":         &params.Keywords,
+		"whats_new":        &params.WhatsNew,
+		"promotional_text": &params.PromotionalText,
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	req := &api.AppStoreVersionLocalizationCreateRequest{
 		Data: api.AppStoreVersionLocalizationCreateData{
@@ -546,18 +567,17 @@ func (r *Registry) handleCreateVersionLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.CreateAppStoreVersionLocalization(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create version localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create version localization: %v", err), err), nil
 	}
 
 	result := fmt.Sprintf("Created version localization for locale '%s'\n\n%s",
 		params.Locale, formatVersionLocalization(&resp.Data))
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNormalizedNote(result, normalized)), nil
 }
 
-func (r *Registry) handleUpdateVersionLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateVersionLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID  string `json:"localization_id"`
 		Description     string `json:"description"`
@@ -574,6 +594,15 @@ func (r *Registry) handleUpdateVersionLocalization(args json.RawMessage) (*mcp.T
 	if params.LocalizationID == "" {
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
+	normalized, errResult := normalizeMetadataFields(map[string]*string{
+		"description":      &params.Description,
+		"keywords":         &params.Keywords,
+		"whats_new":        &params.WhatsNew,
+		"promotional_text": &params.PromotionalText,
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	req := &api.AppStoreVersionLocalizationUpdateRequest{
 		Data: api.AppStoreVersionLocalizationUpdateData{
@@ -590,17 +619,16 @@ func (r *Registry) handleUpdateVersionLocalization(args json.RawMessage) (*mcp.T
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.UpdateAppStoreVersionLocalization(ctx, params.LocalizationID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update version localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update version localization: %v", err), err), nil
 	}
 
 	result := fmt.Sprintf("Updated version localization\n\n%s", formatVersionLocalization(&resp.Data))
-	return mcp.NewSuccessResult(result), nil
+	return mcp.NewSuccessResult(withNormalizedNote(result, normalized)), nil
 }
 
-func (r *Registry) handleDeleteVersionLocalization(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteVersionLocalization(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 	}
@@ -612,10 +640,9 @@ func (r *Registry) handleDeleteVersionLocalization(args json.RawMessage) (*mcp.T
 		return mcp.NewErrorResult("localization_id is required"), nil
 	}
 
-	ctx := context.Background()
 	err := r.client.DeleteAppStoreVersionLocalization(ctx, params.LocalizationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete version localization: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete version localization: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Successfully deleted version localization"), nil
@@ -0,0 +1,280 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// editableVersionStates are App Store version states whose metadata can still be changed.
+var editableVersionStates = map[api.AppStoreState]bool{
+	api.AppStoreStatePrepareForSubmission: true,
+	api.AppStoreStateDeveloperRejected:    true,
+	api.AppStoreStateRejected:             true,
+	api.AppStoreStateMetadataRejected:     true,
+	api.AppStoreStateInvalidBinary:        true,
+}
+
+// metadataTemplateField is a version localization field that can be templated.
+type metadataTemplateField struct {
+	arg       string
+	attribute string
+	maxLength int
+	set       func(a *api.AppStoreVersionLocalizationUpdateAttributes, value string)
+}
+
+// metadataTemplateFields lists the templated fields and App Store Connect's
+// length limits for them. Zero means no limit is checked.
+var metadataTemplateFields = []metadataTemplateField{
+	{"description", "description", 4000, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.Description = v }},
+	{"keywords", "keywords", 100, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.Keywords = v }},
+	{"promotional_text", "promotionalText", 170, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.PromotionalText = v }},
+	{"whats_new", "whatsNew", 4000, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.WhatsNew = v }},
+	{"marketing_url", "marketingUrl", 0, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.MarketingURL = v }},
+	{"support_url", "supportUrl", 0, func(a *api.AppStoreVersionLocalizationUpdateAttributes, v string) { a.SupportURL = v }},
+}
+
+// registerMetadataTemplateTools registers the tool applying shared metadata across apps.
+func (r *Registry) registerMetadataTemplateTools() {
+	properties := map[string]mcp.Property{
+		"app_ids": {
+			Type:        "array",
+			Description: "App Store Connect IDs of the apps to update, or names of app groups from list_app_groups",
+			Items:       &mcp.Property{Type: "string"},
+		},
+		"locale": {
+			Type:        "string",
+			Description: "Locale of the localization to update in each app, e.g. en-US",
+		},
+		"platform": {
+			Type:        "string",
+			Description: "Platform of the versions to update (default: IOS)",
+			Enum:        api.EnumStrings(api.Platforms),
+		},
+		"variables": {
+			Type:        "object",
+			Description: "Optional: Variables shared by every app, e.g. {\"company\": \"Acme\"}",
+		},
+		"app_variables": {
+			Type:        "object",
+			Description: "Optional: Variables per app ID, overriding the shared ones, e.g. {\"123\": {\"brand\": \"Acme Weather\"}}",
+		},
+		"dry_run": {
+			Type:        "boolean",
+			Description: "Render the templates without updating any app (default: false)",
+		},
+	}
+	for _, f := range metadataTemplateFields {
+		properties[f.arg] = mcp.Property{
+			Type:        "string",
+			Description: fmt.Sprintf("Optional: Template for the %s field", f.attribute),
+		}
+	}
+
+	r.register(
+		mcp.Tool{
+			Name:        "apply_metadata_template",
+			Description: "Render App Store metadata (description, keywords, promotional text, What's New, URLs) from shared templates and per-app variables, and apply it to one locale of each app's editable version. Templates use Go template syntax, e.g. \"{{.brand}} keeps your notes in sync\". Every app also gets app_id, app_name and bundle_id. A missing variable fails that app without updating it. Use dry_run to preview the rendered text first.",
+			InputSchema: mcp.JSONSchema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"app_ids", "locale"},
+			},
+			OutputSchema: mcp.SchemaFor(metadataTemplateOutput{}),
+		},
+		r.handleApplyMetadataTemplate,
+	)
+}
+
+// handleApplyMetadataTemplate handles the apply_metadata_template tool.
+func (r *Registry) handleApplyMetadataTemplate(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppIDs       []string                     `json:"app_ids"`
+		Locale       string                       `json:"locale"`
+		Platform     api.Platform                 `json:"platform"`
+		Variables    map[string]string            `json:"variables"`
+		AppVariables map[string]map[string]string `json:"app_variables"`
+		DryRun       bool                         `json:"dry_run"`
+	}
+	var templateArgs map[string]any
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if err := json.Unmarshal(args, &templateArgs); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	appIDs := r.expandAppIDs(params.AppIDs)
+	if len(appIDs) == 0 || params.Locale == "" {
+		return mcp.NewErrorResult("app_ids and locale are required"), nil
+	}
+	if params.Platform == "" {
+		params.Platform = api.PlatformIOS
+	}
+
+	templates := make(map[string]*template.Template)
+	for _, f := range metadataTemplateFields {
+		text, _ := templateArgs[f.arg].(string)
+		if text == "" {
+			continue
+		}
+		tmpl, err := template.New(f.arg).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Invalid %s template: %v", f.arg, err), err), nil
+		}
+		templates[f.arg] = tmpl
+	}
+	if len(templates) == 0 {
+		return mcp.NewErrorResult("At least one template is required: description, keywords, promotional_text, whats_new, marketing_url or support_url"), nil
+	}
+
+	output := metadataTemplateOutput{Locale: params.Locale, DryRun: params.DryRun, Apps: make([]metadataTemplateApp, 0, len(appIDs))}
+	for _, appID := range appIDs {
+		app := metadataTemplateApp{AppID: appID}
+		err := r.applyMetadataTemplate(ctx, &app, params.Locale, params.Platform, templates, params.Variables, params.AppVariables[appID], params.DryRun)
+		if errors.Is(err, api.ErrDryRun) {
+			return mcp.NewErrorResultWithError(err.Error(), err), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			app.Error = err.Error()
+			output.Failed++
+		} else {
+			output.Succeeded++
+		}
+		output.Apps = append(output.Apps, app)
+	}
+
+	return mcp.NewStructuredResult(formatMetadataTemplate(output), output), nil
+}
+
+// applyMetadataTemplate renders the templates for one app and, unless
+// dryRun is set, writes them to the locale of its editable version.
+func (r *Registry) applyMetadataTemplate(ctx context.Context, app *metadataTemplateApp, locale string, platform api.Platform, templates map[string]*template.Template, shared, perApp map[string]string, dryRun bool) error {
+	appResp, err := r.client.GetApp(ctx, app.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+	app.AppName = appResp.Data.Attributes.Name
+
+	variables := map[string]string{
+		"app_id":    app.AppID,
+		"app_name":  appResp.Data.Attributes.Name,
+		"bundle_id": appResp.Data.Attributes.BundleID,
+	}
+	for key, value := range shared {
+		variables[key] = value
+	}
+	for key, value := range perApp {
+		variables[key] = value
+	}
+
+	var attributes api.AppStoreVersionLocalizationUpdateAttributes
+	app.Fields = make(map[string]string)
+	for _, f := range metadataTemplateFields {
+		tmpl, ok := templates[f.arg]
+		if !ok {
+			continue
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, variables); err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.arg, err)
+		}
+		value := strings.TrimSpace(sb.String())
+		if f.maxLength > 0 {
+			// Fields without a length limit are URLs, which are left as rendered.
+			if value, err = normalizeMetadataText(f.arg, value); err != nil {
+				return fmt.Errorf("rendered %w", err)
+			}
+		}
+		if f.maxLength > 0 && utf8.RuneCountInString(value) > f.maxLength {
+			return fmt.Errorf("rendered %s is %d characters, over the limit of %d", f.attribute, utf8.RuneCountInString(value), f.maxLength)
+		}
+		app.Fields[f.attribute] = value
+		f.set(&attributes, value)
+	}
+
+	versions, err := r.client.GetAppVersions(ctx, app.AppID, api.ListOptions{Limit: 10})
+	if err != nil {
+		return fmt.Errorf("failed to get app versions: %w", err)
+	}
+	r.recordVersions(app.AppID, versions.Data)
+	for _, v := range versions.Data {
+		if v.Attributes.Platform == platform && editableVersionStates[v.Attributes.AppStoreState] {
+			app.VersionID = v.ID
+			break
+		}
+	}
+	if app.VersionID == "" {
+		return fmt.Errorf("no editable %s version; create one with create_app_store_version", platform)
+	}
+
+	localizations, err := r.client.ListAppStoreVersionLocalizations(ctx, app.VersionID, api.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list version localizations: %w", err)
+	}
+	localizationID := ""
+	for _, loc := range localizations.Data {
+		if loc.Attributes.Locale == locale {
+			localizationID = loc.ID
+		}
+	}
+	if localizationID == "" {
+		return fmt.Errorf("version %s has no %s localization", app.VersionID, locale)
+	}
+
+	if dryRun {
+		return nil
+	}
+	_, err = r.client.UpdateAppStoreVersionLocalization(ctx, localizationID, &api.AppStoreVersionLocalizationUpdateRequest{
+		Data: api.AppStoreVersionLocalizationUpdateData{
+			Type:       "appStoreVersionLocalizations",
+			ID:         localizationID,
+			Attributes: attributes,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update localization: %w", err)
+	}
+	app.Updated = true
+	return nil
+}
+
+// formatMetadataTemplate renders the outcome of apply_metadata_template per app.
+func formatMetadataTemplate(o metadataTemplateOutput) string {
+	var sb strings.Builder
+	action := "Applied"
+	if o.DryRun {
+		action = "Rendered (dry run)"
+	}
+	sb.WriteString(fmt.Sprintf("%s %s metadata for %d apps: %d succeeded, %d failed\n\n", action, o.Locale, len(o.Apps), o.Succeeded, o.Failed))
+
+	for _, app := range o.Apps {
+		name := app.AppID
+		if app.AppName != "" {
+			name = fmt.Sprintf("%s (%s)", app.AppName, app.AppID)
+		}
+		sb.WriteString(fmt.Sprintf("**%s**\n", name))
+		if app.Error != "" {
+			sb.WriteString(fmt.Sprintf("  - Error: %s\n\n", app.Error))
+			continue
+		}
+		for _, f := range metadataTemplateFields {
+			if value, ok := app.Fields[f.attribute]; ok {
+				sb.WriteString(fmt.Sprintf("  - %s: %s\n", f.attribute, value))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
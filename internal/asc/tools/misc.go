@@ -96,6 +96,8 @@ func (r *Registry) registerMiscTools() {
 					Type:        "integer",
 					Description: "Maximum number of categories to return (default 100)",
 				},
+				"cursor":  cursorProperty,
+				"refresh": refreshProperty,
 			},
 		},
 	}, r.handleListAppCategories)
@@ -126,6 +128,7 @@ func (r *Registry) registerMiscTools() {
 					Type:        "integer",
 					Description: "Maximum number of keys to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListAlternativeDistributionKeys)
@@ -246,7 +249,7 @@ func (r *Registry) registerMiscTools() {
 }
 
 // EULA handlers
-func (r *Registry) handleGetEndUserLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetEndUserLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -258,15 +261,15 @@ func (r *Registry) handleGetEndUserLicenseAgreement(args json.RawMessage) (*mcp.
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetEndUserLicenseAgreement(context.Background(), params.AppID)
+	resp, err := r.client.GetEndUserLicenseAgreement(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get EULA: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get EULA: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatEndUserLicenseAgreement(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateEndUserLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateEndUserLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID         string   `json:"app_id"`
 		AgreementText string   `json:"agreement_text"`
@@ -302,15 +305,15 @@ func (r *Registry) handleCreateEndUserLicenseAgreement(args json.RawMessage) (*m
 		},
 	}
 
-	resp, err := r.client.CreateEndUserLicenseAgreement(context.Background(), req)
+	resp, err := r.client.CreateEndUserLicenseAgreement(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create EULA: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create EULA: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("EULA created:\n%s", formatEndUserLicenseAgreement(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateEndUserLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateEndUserLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		EULAID        string `json:"eula_id"`
 		AgreementText string `json:"agreement_text"`
@@ -333,15 +336,15 @@ func (r *Registry) handleUpdateEndUserLicenseAgreement(args json.RawMessage) (*m
 		},
 	}
 
-	resp, err := r.client.UpdateEndUserLicenseAgreement(context.Background(), params.EULAID, req)
+	resp, err := r.client.UpdateEndUserLicenseAgreement(ctx, params.EULAID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update EULA: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update EULA: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("EULA updated:\n%s", formatEndUserLicenseAgreement(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteEndUserLicenseAgreement(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteEndUserLicenseAgreement(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		EULAID string `json:"eula_id"`
 	}
@@ -353,18 +356,20 @@ func (r *Registry) handleDeleteEndUserLicenseAgreement(args json.RawMessage) (*m
 		return nil, fmt.Errorf("eula_id is required")
 	}
 
-	err := r.client.DeleteEndUserLicenseAgreement(context.Background(), params.EULAID)
+	err := r.client.DeleteEndUserLicenseAgreement(ctx, params.EULAID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete EULA: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete EULA: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("EULA deleted (reverted to standard Apple EULA)"), nil
 }
 
 // Category handlers
-func (r *Registry) handleListAppCategories(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppCategories(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
+		Refresh bool   `json:"refresh"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -375,15 +380,15 @@ func (r *Registry) handleListAppCategories(args json.RawMessage) (*mcp.ToolsCall
 		limit = 100
 	}
 
-	resp, err := r.client.ListAppCategories(context.Background(), limit)
+	resp, err := r.client.ListAppCategories(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app categories: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app categories: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppCategories(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppCategories(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAppCategory(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppCategory(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		CategoryID string `json:"category_id"`
 	}
@@ -395,18 +400,19 @@ func (r *Registry) handleGetAppCategory(args json.RawMessage) (*mcp.ToolsCallRes
 		return nil, fmt.Errorf("category_id is required")
 	}
 
-	resp, err := r.client.GetAppCategory(context.Background(), params.CategoryID)
+	resp, err := r.client.GetAppCategory(ctx, params.CategoryID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app category: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app category: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppCategory(resp.Data)), nil
 }
 
 // Alternative distribution handlers
-func (r *Registry) handleListAlternativeDistributionKeys(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAlternativeDistributionKeys(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -417,15 +423,15 @@ func (r *Registry) handleListAlternativeDistributionKeys(args json.RawMessage) (
 		limit = 50
 	}
 
-	resp, err := r.client.ListAlternativeDistributionKeys(context.Background(), limit)
+	resp, err := r.client.ListAlternativeDistributionKeys(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list alternative distribution keys: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list alternative distribution keys: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAlternativeDistributionKeys(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAlternativeDistributionKeys(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetAlternativeDistributionKey(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAlternativeDistributionKey(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		KeyID string `json:"key_id"`
 	}
@@ -437,15 +443,15 @@ func (r *Registry) handleGetAlternativeDistributionKey(args json.RawMessage) (*m
 		return nil, fmt.Errorf("key_id is required")
 	}
 
-	resp, err := r.client.GetAlternativeDistributionKey(context.Background(), params.KeyID)
+	resp, err := r.client.GetAlternativeDistributionKey(ctx, params.KeyID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get alternative distribution key: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get alternative distribution key: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAlternativeDistributionKey(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateAlternativeDistributionKey(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAlternativeDistributionKey(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -468,15 +474,15 @@ func (r *Registry) handleCreateAlternativeDistributionKey(args json.RawMessage)
 		},
 	}
 
-	resp, err := r.client.CreateAlternativeDistributionKey(context.Background(), req)
+	resp, err := r.client.CreateAlternativeDistributionKey(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create alternative distribution key: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create alternative distribution key: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Alternative distribution key created:\n%s", formatAlternativeDistributionKey(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteAlternativeDistributionKey(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAlternativeDistributionKey(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		KeyID string `json:"key_id"`
 	}
@@ -488,16 +494,16 @@ func (r *Registry) handleDeleteAlternativeDistributionKey(args json.RawMessage)
 		return nil, fmt.Errorf("key_id is required")
 	}
 
-	err := r.client.DeleteAlternativeDistributionKey(context.Background(), params.KeyID)
+	err := r.client.DeleteAlternativeDistributionKey(ctx, params.KeyID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete alternative distribution key: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete alternative distribution key: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Alternative distribution key deleted"), nil
 }
 
 // Marketplace search detail handlers
-func (r *Registry) handleGetMarketplaceSearchDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetMarketplaceSearchDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -509,15 +515,15 @@ func (r *Registry) handleGetMarketplaceSearchDetail(args json.RawMessage) (*mcp.
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetMarketplaceSearchDetail(context.Background(), params.AppID)
+	resp, err := r.client.GetMarketplaceSearchDetail(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get marketplace search detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get marketplace search detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatMarketplaceSearchDetail(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateMarketplaceSearchDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateMarketplaceSearchDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID      string `json:"app_id"`
 		CatalogURL string `json:"catalog_url"`
@@ -544,15 +550,15 @@ func (r *Registry) handleCreateMarketplaceSearchDetail(args json.RawMessage) (*m
 		},
 	}
 
-	resp, err := r.client.CreateMarketplaceSearchDetail(context.Background(), req)
+	resp, err := r.client.CreateMarketplaceSearchDetail(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create marketplace search detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create marketplace search detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Marketplace search detail created:\n%s", formatMarketplaceSearchDetail(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateMarketplaceSearchDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateMarketplaceSearchDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DetailID   string `json:"detail_id"`
 		CatalogURL string `json:"catalog_url"`
@@ -575,15 +581,15 @@ func (r *Registry) handleUpdateMarketplaceSearchDetail(args json.RawMessage) (*m
 		},
 	}
 
-	resp, err := r.client.UpdateMarketplaceSearchDetail(context.Background(), params.DetailID, req)
+	resp, err := r.client.UpdateMarketplaceSearchDetail(ctx, params.DetailID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update marketplace search detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update marketplace search detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Marketplace search detail updated:\n%s", formatMarketplaceSearchDetail(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteMarketplaceSearchDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteMarketplaceSearchDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DetailID string `json:"detail_id"`
 	}
@@ -595,9 +601,9 @@ func (r *Registry) handleDeleteMarketplaceSearchDetail(args json.RawMessage) (*m
 		return nil, fmt.Errorf("detail_id is required")
 	}
 
-	err := r.client.DeleteMarketplaceSearchDetail(context.Background(), params.DetailID)
+	err := r.client.DeleteMarketplaceSearchDetail(ctx, params.DetailID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete marketplace search detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete marketplace search detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Marketplace search detail deleted"), nil
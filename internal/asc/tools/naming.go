@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// SetToolNaming changes the names tools are listed and called by, so they
+// don't collide with other servers' tools in the same client. Names get
+// prefix, unless they already start with it, and with grouped set, the name
+// of the tool's group after the prefix, as in "asc_builds_list_builds".
+// Everything else, such as timeouts and confirmation, keeps using the
+// unprefixed names.
+func (r *Registry) SetToolNaming(prefix string, grouped bool) {
+	r.toolPrefix = prefix
+	r.groupedNames = grouped
+}
+
+// publicName returns the name a tool is listed and called by.
+func (r *Registry) publicName(name string) string {
+	if r.toolPrefix == "" && !r.groupedNames {
+		return name
+	}
+
+	base := strings.TrimPrefix(name, r.toolPrefix)
+	if group := r.groups[name]; r.groupedNames && group != "" {
+		return r.toolPrefix + group + "_" + base
+	}
+	return r.toolPrefix + base
+}
+
+// PublicToolName returns the name the tool registered as name is listed and
+// called by.
+func (r *Registry) PublicToolName(name string) string {
+	return r.publicName(name)
+}
+
+// ResolveToolName returns the registered name of the tool listed as name.
+// Names that aren't a listed name are returned unchanged, so registered names
+// keep working.
+func (r *Registry) ResolveToolName(name string) string {
+	if r.toolPrefix == "" && !r.groupedNames {
+		return name
+	}
+
+	for _, tool := range r.tools {
+		if r.publicName(tool.Name) == name {
+			return tool.Name
+		}
+	}
+	return name
+}
+
+// publicTools returns the tool definitions under their public names.
+func (r *Registry) publicTools() []mcp.Tool {
+	if r.toolPrefix == "" && !r.groupedNames {
+		return r.tools
+	}
+
+	tools := make([]mcp.Tool, len(r.tools))
+	for i, tool := range r.tools {
+		tool.Name = r.publicName(tool.Name)
+		tools[i] = tool
+	}
+	return tools
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// typographicReplacements map the typographic characters generated text
+// often contains to the plain characters App Store Connect expects.
+var typographicReplacements = strings.NewReplacer(
+	"\u2018", "'", // left single quotation mark
+	"\u2019", "'", // right single quotation mark
+	"\u201a", "'", // single low-9 quotation mark
+	"\u201b", "'", // single high-reversed-9 quotation mark
+	"\u2032", "'", // prime
+	"\u201c", `"`, // left double quotation mark
+	"\u201d", `"`, // right double quotation mark
+	"\u201e", `"`, // double low-9 quotation mark
+	"\u201f", `"`, // double high-reversed-9 quotation mark
+	"\u2033", `"`, // double prime
+	"\u00a0", " ", // no-break space
+	"\u202f", " ", // narrow no-break space
+	"\u2007", " ", // figure space
+	"\u3000", " ", // ideographic space
+	"\u2028", "\n", // line separator
+	"\u2029", "\n", // paragraph separator
+	"\u200b", "", // zero width space
+	"\u2060", "", // word joiner
+	"\ufeff", "", // zero width no-break space (byte order mark)
+	"\u00ad", "", // soft hyphen
+)
+
+// keywordSeparators are the commas of other scripts that keywords are
+// sometimes separated with instead of ",".
+var keywordSeparators = strings.NewReplacer(
+	"\uff0c", ",", // full-width comma
+	"\u3001", ",", // ideographic comma
+	"\u060c", ",", // Arabic comma
+	"\ufe50", ",", // small comma
+)
+
+// singleLineMetadataFields are the metadata fields that can't contain line
+// breaks or emoji.
+var singleLineMetadataFields = map[string]bool{
+	"name":     true,
+	"subtitle": true,
+	"keywords": true,
+}
+
+// normalizeMetadataText replaces typographic quotes and spaces in a
+// metadata field's value with plain ones and drops invisible characters.
+// Keywords are also split on full-width and ideographic commas, and the
+// spaces around them are dropped, since they count toward the 100-character
+// limit. It returns an error naming any character App Store Connect rejects
+// in the field.
+func normalizeMetadataText(field, value string) (string, error) {
+	normalized := typographicReplacements.Replace(value)
+	if field == "keywords" {
+		var keywords []string
+		for _, keyword := range strings.Split(keywordSeparators.Replace(normalized), ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		normalized = strings.Join(keywords, ",")
+	}
+
+	for i, r := range normalized {
+		if reason := rejectedCharacter(field, r); reason != "" {
+			return "", fmt.Errorf("%s contains %s (%U) at character %d, which App Store Connect rejects", field, reason, r, utf8.RuneCountInString(normalized[:i])+1)
+		}
+	}
+	return normalized, nil
+}
+
+// rejectedCharacter describes why App Store Connect rejects r in field, or
+// returns "" if it accepts it.
+func rejectedCharacter(field string, r rune) string {
+	switch {
+	case r == utf8.RuneError:
+		return "an invalid character"
+	case r == '\n' || r == '\r':
+		if singleLineMetadataFields[field] {
+			return "a line break"
+		}
+	case r == '\t':
+	case unicode.IsControl(r):
+		return "a control character"
+	case unicode.Is(unicode.Co, r):
+		return "a private use character"
+	case singleLineMetadataFields[field] && isEmoji(r):
+		return "an emoji"
+	}
+	return ""
+}
+
+// isEmoji reports whether r is in one of the blocks emoji are drawn from,
+// or is the variation selector that asks for emoji presentation.
+func isEmoji(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || r == 0xFE0F
+}
+
+// normalizeMetadataFields normalizes the metadata values in fields, keyed
+// by field name, in place. It returns the names of the fields it changed,
+// or an error result for the first field with a character App Store
+// Connect rejects.
+func normalizeMetadataFields(fields map[string]*string) ([]string, *mcp.ToolsCallResult) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changed []string
+	for _, name := range names {
+		value := fields[name]
+		if *value == "" {
+			continue
+		}
+		normalized, err := normalizeMetadataText(name, *value)
+		if err != nil {
+			return nil, mcp.NewErrorResultWithError(err.Error(), err)
+		}
+		if normalized != *value {
+			*value = normalized
+			changed = append(changed, name)
+		}
+	}
+	return changed, nil
+}
+
+// withNormalizedNote appends to a result the fields whose typographic
+// characters were replaced before they were sent.
+func withNormalizedNote(result string, changed []string) string {
+	if len(changed) == 0 {
+		return result
+	}
+	return fmt.Sprintf("%s\nNormalized quotes, spaces, commas and invisible characters in: %s\n", result, strings.Join(changed, ", "))
+}
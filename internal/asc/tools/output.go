@@ -0,0 +1,585 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// Structured outputs returned alongside the text summary by tools that declare
+// an output schema. Resources keep the App Store Connect API field names, so
+// clients can read attributes such as appStoreState or processingState directly.
+
+// appsOutput is the structured result of list_apps.
+type appsOutput struct {
+	Apps       []linkedApp `json:"apps"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// linkedApp is an app with its shareable links, the structured result of
+// get_app.
+type linkedApp struct {
+	Type                  string            `json:"type"`
+	ID                    string            `json:"id"`
+	Attributes            api.AppAttributes `json:"attributes"`
+	AppStoreURL           string            `json:"appStoreUrl"`
+	TestFlightPublicLinks []testFlightLink  `json:"testFlightPublicLinks"`
+}
+
+// testFlightLink is a beta group's active TestFlight public link.
+type testFlightLink struct {
+	BetaGroupID   string `json:"betaGroupId"`
+	BetaGroupName string `json:"betaGroupName"`
+	URL           string `json:"url"`
+}
+
+// buildsOutput is the structured result of list_builds and list_beta_group_builds.
+type buildsOutput struct {
+	Builds     []api.Build `json:"builds"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// buildOverviewOutput is the structured result of build_overview.
+type buildOverviewOutput struct {
+	BuildID             string                                `json:"buildId"`
+	Version             string                                `json:"version"`
+	AppVersion          string                                `json:"appVersion,omitempty"`
+	Platform            string                                `json:"platform,omitempty"`
+	ProcessingState     string                                `json:"processingState"`
+	UploadedDate        *time.Time                            `json:"uploadedDate,omitempty"`
+	ExpirationDate      *time.Time                            `json:"expirationDate,omitempty"`
+	Expired             bool                                  `json:"expired"`
+	DaysUntilExpiration *int                                  `json:"daysUntilExpiration,omitempty"`
+	ExportCompliance    buildExportCompliance                 `json:"exportCompliance"`
+	InternalBuildState  string                                `json:"internalBuildState,omitempty"`
+	ExternalBuildState  string                                `json:"externalBuildState,omitempty"`
+	BetaReviewState     string                                `json:"betaReviewState,omitempty"`
+	BetaReviewSubmitted *time.Time                            `json:"betaReviewSubmittedDate,omitempty"`
+	BetaGroups          []buildOverviewGroup                  `json:"betaGroups"`
+	Localizations       []api.BetaBuildLocalizationAttributes `json:"localizations"`
+	Usage               *api.BetaBuildUsageValues             `json:"usage,omitempty"`
+	Errors              []string                              `json:"errors,omitempty"`
+}
+
+// buildExportCompliance is a build's export compliance answers.
+type buildExportCompliance struct {
+	UsesNonExemptEncryption bool   `json:"usesNonExemptEncryption"`
+	Missing                 bool   `json:"missing"`
+	DeclarationID           string `json:"declarationId,omitempty"`
+	DeclarationState        string `json:"declarationState,omitempty"`
+}
+
+// buildOverviewGroup is a beta group a build is assigned to.
+type buildOverviewGroup struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Internal   bool   `json:"internal"`
+	PublicLink string `json:"publicLink,omitempty"`
+}
+
+// appStoreVersionsOutput is the structured result of get_app_versions and list_app_store_versions.
+type appStoreVersionsOutput struct {
+	Versions    []api.AppStoreVersion `json:"versions"`
+	AppStoreURL string                `json:"appStoreUrl"`
+	NextCursor  string                `json:"nextCursor,omitempty"`
+}
+
+// linkedAppStoreVersion is an App Store version with its app's App Store
+// URL, the structured result of get_app_store_version.
+type linkedAppStoreVersion struct {
+	Type        string                        `json:"type"`
+	ID          string                        `json:"id"`
+	Attributes  api.AppStoreVersionAttributes `json:"attributes"`
+	AppID       string                        `json:"appId,omitempty"`
+	AppStoreURL string                        `json:"appStoreUrl,omitempty"`
+}
+
+// searchOutput is the structured result of search.
+type searchOutput struct {
+	Query   string        `json:"query"`
+	Matches []searchMatch `json:"matches"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// searchMatch is one resource found by search.
+type searchMatch struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Detail       string `json:"detail,omitempty"`
+	MatchedField string `json:"matchedField"`
+	Fuzzy        bool   `json:"fuzzy,omitempty"`
+
+	matchedValue string
+}
+
+// statusOutput is the structured result of asc_status.
+type statusOutput struct {
+	Team           string           `json:"team,omitempty"`
+	IssuerID       string           `json:"issuerId,omitempty"`
+	KeyID          string           `json:"keyId,omitempty"`
+	BaseURL        string           `json:"baseUrl,omitempty"`
+	TokenValid     bool             `json:"tokenValid"`
+	TokenExpiresAt string           `json:"tokenExpiresAt,omitempty"`
+	TokenError     string           `json:"tokenError,omitempty"`
+	Reachable      bool             `json:"reachable"`
+	Authenticated  bool             `json:"authenticated"`
+	StatusCode     int              `json:"statusCode,omitempty"`
+	LatencyMs      int64            `json:"latencyMs,omitempty"`
+	APIError       string           `json:"apiError,omitempty"`
+	RateLimit      *rateLimitOutput `json:"rateLimit,omitempty"`
+	AdminAccess    *bool            `json:"adminAccess,omitempty"`
+}
+
+// rateLimitOutput is the hourly request quota reported by the API.
+type rateLimitOutput struct {
+	Limit     int `json:"limit"`
+	Remaining int `json:"remaining"`
+}
+
+// releaseNotesContextOutput is the structured result of get_release_notes_context.
+type releaseNotesContextOutput struct {
+	AppID            string                 `json:"appId"`
+	FromBuild        releaseNotesBuild      `json:"fromBuild"`
+	ToBuild          releaseNotesBuild      `json:"toBuild"`
+	Commits          []releaseNotesCommit   `json:"commits"`
+	Tickets          []string               `json:"tickets"`
+	PreviousWhatsNew []releaseNotesWhatsNew `json:"previousWhatsNew"`
+	Notes            []string               `json:"notes,omitempty"`
+}
+
+// releaseNotesBuild is a build and the Xcode Cloud run that produced it.
+type releaseNotesBuild struct {
+	BuildID   string `json:"buildId"`
+	RunID     string `json:"runId"`
+	RunNumber int    `json:"runNumber"`
+	CommitSha string `json:"commitSha,omitempty"`
+}
+
+// releaseNotesCommit is a commit built between two releases.
+type releaseNotesCommit struct {
+	Sha       string   `json:"sha"`
+	Message   string   `json:"message"`
+	Author    string   `json:"author,omitempty"`
+	WebURL    string   `json:"webUrl,omitempty"`
+	RunNumber int      `json:"runNumber"`
+	Tickets   []string `json:"tickets,omitempty"`
+}
+
+// releaseNotesWhatsNew is the What's New text of a previous version.
+type releaseNotesWhatsNew struct {
+	VersionID string `json:"versionId"`
+	Version   string `json:"version"`
+	State     string `json:"state,omitempty"`
+	Text      string `json:"text"`
+}
+
+// metadataCheckOutput is the check_app_store_metadata result.
+type metadataCheckOutput struct {
+	VersionID string            `json:"versionId"`
+	Errors    int               `json:"errors"`
+	Warnings  int               `json:"warnings"`
+	Findings  []metadataFinding `json:"findings"`
+}
+
+// metadataFinding is a likely rejection trigger in one localized field.
+type metadataFinding struct {
+	Severity string `json:"severity"` // error or warning
+	Locale   string `json:"locale"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// linkCheckOutput is the check_metadata_urls result.
+type linkCheckOutput struct {
+	AppID      string      `json:"appId"`
+	VersionID  string      `json:"versionId,omitempty"`
+	Broken     int         `json:"broken"`
+	Redirected int         `json:"redirected"`
+	Links      []linkCheck `json:"links"`
+}
+
+// linkCheck is the status of one URL field in one locale.
+type linkCheck struct {
+	Locale     string   `json:"locale"`
+	Field      string   `json:"field"`
+	URL        string   `json:"url"`
+	StatusCode int      `json:"statusCode,omitempty"`
+	Redirects  []string `json:"redirects,omitempty"`
+	OK         bool     `json:"ok"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// reviewTurnaroundOutput is the get_review_turnaround result.
+type reviewTurnaroundOutput struct {
+	AppID    string             `json:"appId,omitempty"`
+	Waiting  *durationStats     `json:"waiting,omitempty"`
+	InReview *durationStats     `json:"inReview,omitempty"`
+	Total    *durationStats     `json:"total,omitempty"`
+	Reviews  []reviewTurnaround `json:"reviews"`
+}
+
+// reviewTurnaround is one App Review cycle of a version. Durations are nil
+// when the step wasn't observed.
+type reviewTurnaround struct {
+	VersionID       string   `json:"versionId"`
+	VersionString   string   `json:"versionString"`
+	Platform        string   `json:"platform,omitempty"`
+	SubmittedAt     string   `json:"submittedAt"`
+	ReviewStartedAt string   `json:"reviewStartedAt,omitempty"`
+	DecidedAt       string   `json:"decidedAt,omitempty"`
+	Outcome         string   `json:"outcome"` // approved, rejected or pending
+	WaitingHours    *float64 `json:"waitingHours,omitempty"`
+	InReviewHours   *float64 `json:"inReviewHours,omitempty"`
+	TotalHours      *float64 `json:"totalHours,omitempty"`
+}
+
+// reviewEstimateOutput is the structured result of get_review_estimate. The
+// version fields are empty when no version is under review.
+type reviewEstimateOutput struct {
+	AppID              string         `json:"appId"`
+	Basis              string         `json:"basis"` // app or account
+	Reviews            int            `json:"reviews"`
+	Waiting            *durationStats `json:"waiting,omitempty"`
+	InReview           *durationStats `json:"inReview,omitempty"`
+	Total              *durationStats `json:"total,omitempty"`
+	VersionID          string         `json:"versionId,omitempty"`
+	VersionString      string         `json:"versionString,omitempty"`
+	State              string         `json:"state,omitempty"`
+	SubmittedAt        string         `json:"submittedAt,omitempty"`
+	ReviewStartedAt    string         `json:"reviewStartedAt,omitempty"`
+	ElapsedHours       *float64       `json:"elapsedHours,omitempty"`
+	ExpectedDecisionAt string         `json:"expectedDecisionAt,omitempty"`
+	LikelyDecisionBy   string         `json:"likelyDecisionBy,omitempty"`
+	Overdue            bool           `json:"overdue"`
+}
+
+// durationStats summarizes a set of durations in hours.
+type durationStats struct {
+	Count       int     `json:"count"`
+	MedianHours float64 `json:"medianHours"`
+	MeanHours   float64 `json:"meanHours"`
+	P90Hours    float64 `json:"p90Hours"`
+	MinHours    float64 `json:"minHours"`
+	MaxHours    float64 `json:"maxHours"`
+}
+
+// batchOutput is the structured result of asc_batch_get.
+type batchOutput struct {
+	Failed  int           `json:"failed"`
+	Results []batchResult `json:"results"`
+}
+
+// batchResult is the outcome of one asc_batch_get operation. Result holds the
+// tool's structured content, for tools that return it.
+type batchResult struct {
+	ID      string `json:"id"`
+	Tool    string `json:"tool"`
+	IsError bool   `json:"isError"`
+	Text    string `json:"text"`
+	Result  any    `json:"result,omitempty"`
+}
+
+// releaseTrainOutput is the structured result of run_release_train and get_release_train.
+type releaseTrainOutput struct {
+	Train         string            `json:"train"`
+	VersionString string            `json:"versionString"`
+	Platform      string            `json:"platform"`
+	Succeeded     int               `json:"succeeded"`
+	Failed        int               `json:"failed"`
+	Apps          []releaseTrainApp `json:"apps"`
+}
+
+// releaseTrainApp is one app's progress in a release train.
+type releaseTrainApp struct {
+	AppID     string             `json:"appId"`
+	VersionID string             `json:"versionId,omitempty"`
+	Steps     []releaseTrainStep `json:"steps"`
+}
+
+// releaseTrainStep is the status of one release train step for one app.
+type releaseTrainStep struct {
+	Step   string `json:"step"`
+	Status string `json:"status"` // done, failed, pending or skipped
+	Error  string `json:"error,omitempty"`
+}
+
+// appGroupsOutput is the structured result of list_app_groups.
+type appGroupsOutput struct {
+	Groups []appGroup `json:"groups"`
+}
+
+// appGroup is a named set of app IDs.
+type appGroup struct {
+	Name   string   `json:"name"`
+	AppIDs []string `json:"appIds"`
+}
+
+// metadataTemplateOutput is the structured result of apply_metadata_template.
+type metadataTemplateOutput struct {
+	Locale    string                `json:"locale"`
+	DryRun    bool                  `json:"dryRun"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Apps      []metadataTemplateApp `json:"apps"`
+}
+
+// metadataTemplateApp is the rendered metadata of one app. Fields are keyed
+// by the App Store Connect attribute name.
+type metadataTemplateApp struct {
+	AppID     string            `json:"appId"`
+	AppName   string            `json:"appName,omitempty"`
+	VersionID string            `json:"versionId,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Updated   bool              `json:"updated"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// localeCoverageOutput is the structured result of get_locale_coverage.
+type localeCoverageOutput struct {
+	TargetLocales []string               `json:"targetLocales"`
+	Locales       []localeCoverageLocale `json:"locales"`
+	Apps          []localeCoverageApp    `json:"apps"`
+	Backlog       []localeBacklogItem    `json:"backlog"`
+}
+
+// localeCoverageLocale counts the apps that have and lack one target locale.
+type localeCoverageLocale struct {
+	Locale  string `json:"locale"`
+	Covered int    `json:"covered"`
+	Missing int    `json:"missing"`
+}
+
+// localeCoverageApp is one app's coverage of the target locales. Partial
+// locales have either app info or version metadata, but not both.
+type localeCoverageApp struct {
+	AppID         string   `json:"appId"`
+	AppName       string   `json:"appName,omitempty"`
+	VersionID     string   `json:"versionId,omitempty"`
+	VersionString string   `json:"versionString,omitempty"`
+	Covered       []string `json:"covered,omitempty"`
+	Partial       []string `json:"partial,omitempty"`
+	Missing       []string `json:"missing,omitempty"`
+	Error         string   `json:"error,omitempty"`
+
+	// gaps lists the metadata parts each target locale lacks.
+	gaps map[string][]string
+}
+
+// localeBacklogItem is one locale an app still needs metadata for.
+type localeBacklogItem struct {
+	Priority int      `json:"priority"`
+	Locale   string   `json:"locale"`
+	AppID    string   `json:"appId"`
+	AppName  string   `json:"appName,omitempty"`
+	Missing  []string `json:"missing"`
+}
+
+// screenshotArchiveOutput is the structured result of
+// download_screenshot_archive, also written to the archive as manifest.json.
+type screenshotArchiveOutput struct {
+	AppID         string         `json:"appId"`
+	VersionID     string         `json:"versionId"`
+	VersionString string         `json:"versionString,omitempty"`
+	Platform      string         `json:"platform,omitempty"`
+	Directory     string         `json:"directory"`
+	CreatedAt     string         `json:"createdAt"`
+	Downloaded    int            `json:"downloaded"`
+	Failed        int            `json:"failed"`
+	TotalBytes    int64          `json:"totalBytes"`
+	Files         []archiveEntry `json:"files"`
+}
+
+// archiveEntry is one screenshot or preview in an archive. Path is relative
+// to the archive directory.
+type archiveEntry struct {
+	Kind        string `json:"kind"`
+	Locale      string `json:"locale"`
+	DisplayType string `json:"displayType"`
+	Position    int    `json:"position"`
+	ID          string `json:"id"`
+	Path        string `json:"path"`
+	FileName    string `json:"fileName,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Checksum    string `json:"checksum,omitempty"`
+	SHA256      string `json:"sha256,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// assetVerificationOutput is the structured result of verify_asset_upload.
+type assetVerificationOutput struct {
+	AssetType     string               `json:"assetType"`
+	AssetID       string               `json:"assetId"`
+	FileName      string               `json:"fileName,omitempty"`
+	State         string               `json:"state"`
+	Verified      bool                 `json:"verified"`
+	CommitRetried bool                 `json:"commitRetried"`
+	Errors        []assetDeliveryIssue `json:"errors"`
+	Warnings      []assetDeliveryIssue `json:"warnings"`
+	Error         string               `json:"error,omitempty"`
+}
+
+// assetDeliveryIssue is an error or warning from Apple's asset processing.
+type assetDeliveryIssue struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// stuckAssetsOutput is the structured result of delete_stuck_assets.
+type stuckAssetsOutput struct {
+	AppID          string       `json:"appId"`
+	VersionIDs     []string     `json:"versionIds"`
+	OlderThanHours float64      `json:"olderThanHours"`
+	DryRun         bool         `json:"dryRun"`
+	Deleted        []stuckAsset `json:"deleted"`
+	Waiting        []stuckAsset `json:"waiting"`
+	Errors         []string     `json:"errors"`
+}
+
+// buildRetentionOutput is the structured result of expire_old_builds.
+type buildRetentionOutput struct {
+	AppID         string          `json:"appId"`
+	OlderThanDays *float64        `json:"olderThanDays,omitempty"`
+	KeepLatest    *int            `json:"keepLatest,omitempty"`
+	DryRun        bool            `json:"dryRun"`
+	Expired       []retainedBuild `json:"expired"`
+	Kept          int             `json:"kept"`
+	Errors        []string        `json:"errors"`
+}
+
+// retainedBuild is a build that expire_old_builds expired, or would expire
+// in a dry run.
+type retainedBuild struct {
+	ID           string  `json:"id"`
+	BuildNumber  string  `json:"buildNumber"`
+	Version      string  `json:"version,omitempty"`
+	Platform     string  `json:"platform,omitempty"`
+	UploadedDate string  `json:"uploadedDate"`
+	AgeDays      float64 `json:"ageDays"`
+}
+
+// testerRemovalOutput is the structured result of remove_tester_everywhere.
+type testerRemovalOutput struct {
+	Email    string          `json:"email"`
+	DryRun   bool            `json:"dryRun"`
+	Testers  []removedTester `json:"testers"`
+	Verified bool            `json:"verified"`
+	Errors   []string        `json:"errors"`
+}
+
+// removedTester is a beta tester that remove_tester_everywhere found, with
+// the apps and groups it belonged to.
+type removedTester struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	State   string   `json:"state,omitempty"`
+	Apps    []string `json:"apps"`
+	Groups  []string `json:"groups"`
+	Deleted bool     `json:"deleted"`
+}
+
+// stuckAsset is a screenshot, preview or review attachment stuck in
+// FAILED or AWAITING_UPLOAD.
+type stuckAsset struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	FileName    string   `json:"fileName,omitempty"`
+	VersionID   string   `json:"versionId"`
+	Locale      string   `json:"locale,omitempty"`
+	DisplayType string   `json:"displayType,omitempty"`
+	State       string   `json:"state"`
+	ErrorCodes  []string `json:"errorCodes,omitempty"`
+	StuckSince  string   `json:"stuckSince,omitempty"`
+	StuckHours  float64  `json:"stuckHours"`
+}
+
+// digestOutput is the structured output of generate_digest: the week from
+// Since to Until across the account.
+type digestOutput struct {
+	Since        string              `json:"since"`
+	Until        string              `json:"until"`
+	Apps         []digestApp         `json:"apps"`
+	Certificates []digestCertificate `json:"certificates"`
+	Sales        *digestSales        `json:"sales,omitempty"`
+	Errors       []string            `json:"errors"`
+}
+
+// digestApp is one app's week in a digest.
+type digestApp struct {
+	AppID          string                `json:"appId"`
+	AppName        string                `json:"appName,omitempty"`
+	Reviews        digestReviews         `json:"reviews"`
+	VersionChanges []digestVersionChange `json:"versionChanges"`
+	Builds         []digestBuild         `json:"builds"`
+	Errors         []string              `json:"errors,omitempty"`
+}
+
+// digestReviews counts the week's customer reviews of an app. ByRating
+// holds the counts of 1 to 5 stars.
+type digestReviews struct {
+	Count         int            `json:"count"`
+	AverageRating float64        `json:"averageRating"`
+	ByRating      [5]int         `json:"byRating"`
+	Recent        []digestReview `json:"recent"`
+}
+
+// digestReview is a customer review in a digest, with its body shortened.
+type digestReview struct {
+	ID        string `json:"id"`
+	Rating    int    `json:"rating"`
+	Title     string `json:"title,omitempty"`
+	Body      string `json:"body,omitempty"`
+	Territory string `json:"territory,omitempty"`
+	Created   string `json:"created"`
+}
+
+// digestVersionChange is an App Store version state change this server recorded.
+type digestVersionChange struct {
+	VersionID     string `json:"versionId"`
+	VersionString string `json:"versionString"`
+	Platform      string `json:"platform,omitempty"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	At            string `json:"at"`
+}
+
+// digestBuild is a build uploaded during the week.
+type digestBuild struct {
+	ID              string `json:"id"`
+	Version         string `json:"version"`
+	ProcessingState string `json:"processingState,omitempty"`
+	UploadedDate    string `json:"uploadedDate"`
+}
+
+// digestCertificate is a certificate that expires soon or just expired.
+// DaysLeft is negative once it has expired.
+type digestCertificate struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	ExpirationDate string `json:"expirationDate"`
+	DaysLeft       int    `json:"daysLeft"`
+}
+
+// digestSales compares the sales of the last complete week with the week
+// before. Proceeds are totals per currency.
+type digestSales struct {
+	WeekEnding         string             `json:"weekEnding"`
+	PreviousWeekEnding string             `json:"previousWeekEnding"`
+	Units              int                `json:"units"`
+	PreviousUnits      int                `json:"previousUnits"`
+	UnitsChange        int                `json:"unitsChange"`
+	Proceeds           map[string]float64 `json:"proceeds"`
+	PreviousProceeds   map[string]float64 `json:"previousProceeds"`
+	Apps               []digestAppSales   `json:"apps"`
+}
+
+// digestAppSales is one product's units in the two weeks compared.
+type digestAppSales struct {
+	AppleID       string `json:"appleId"`
+	Title         string `json:"title,omitempty"`
+	Units         int    `json:"units"`
+	PreviousUnits int    `json:"previousUnits"`
+}
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// cursorProperty is the input schema property shared by all list tools.
+var cursorProperty = mcp.Property{
+	Type:        "string",
+	Description: "Optional: Pagination cursor from the nextCursor of a previous call",
+}
+
+// withNextCursor appends the cursor for the next page to a list result, if there is one.
+func withNextCursor(text string, links api.PagedDocumentLinks) string {
+	cursor := links.NextCursor()
+	if cursor == "" {
+		return text
+	}
+	return fmt.Sprintf("%s\n\nMore results available. nextCursor: %s\n", text, cursor)
+}
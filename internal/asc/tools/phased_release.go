@@ -41,6 +41,7 @@ func (r *Registry) registerPhasedReleaseTools() {
 				"state": {
 					Type:        "string",
 					Description: "Initial state (INACTIVE, ACTIVE)",
+					Enum:        api.EnumStrings([]api.PhasedReleaseState{api.PhasedReleaseStateInactive, api.PhasedReleaseStateActive}),
 				},
 			},
 			Required: []string{"version_id"},
@@ -61,6 +62,7 @@ func (r *Registry) registerPhasedReleaseTools() {
 				"state": {
 					Type:        "string",
 					Description: "New state (INACTIVE, ACTIVE, PAUSED, COMPLETE)",
+					Enum:        api.EnumStrings(api.PhasedReleaseStates),
 				},
 			},
 			Required: []string{"phased_release_id", "state"},
@@ -84,7 +86,7 @@ func (r *Registry) registerPhasedReleaseTools() {
 	}, r.handleDeletePhasedRelease)
 }
 
-func (r *Registry) handleGetPhasedRelease(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetPhasedRelease(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -96,18 +98,18 @@ func (r *Registry) handleGetPhasedRelease(args json.RawMessage) (*mcp.ToolsCallR
 		return nil, fmt.Errorf("version_id is required")
 	}
 
-	resp, err := r.client.GetAppStoreVersionPhasedRelease(context.Background(), params.VersionID)
+	resp, err := r.client.GetAppStoreVersionPhasedRelease(ctx, params.VersionID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get phased release: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get phased release: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatPhasedRelease(resp.Data)), nil
 }
 
-func (r *Registry) handleCreatePhasedRelease(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreatePhasedRelease(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		VersionID string `json:"version_id"`
-		State     string `json:"state"`
+		VersionID string                 `json:"version_id"`
+		State     api.PhasedReleaseState `json:"state"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -134,18 +136,18 @@ func (r *Registry) handleCreatePhasedRelease(args json.RawMessage) (*mcp.ToolsCa
 		},
 	}
 
-	resp, err := r.client.CreateAppStoreVersionPhasedRelease(context.Background(), req)
+	resp, err := r.client.CreateAppStoreVersionPhasedRelease(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create phased release: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create phased release: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created phased release: %s (state: %s)", resp.Data.ID, resp.Data.Attributes.PhasedReleaseState)), nil
 }
 
-func (r *Registry) handleUpdatePhasedRelease(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdatePhasedRelease(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		PhasedReleaseID string `json:"phased_release_id"`
-		State           string `json:"state"`
+		PhasedReleaseID string                 `json:"phased_release_id"`
+		State           api.PhasedReleaseState `json:"state"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -168,15 +170,15 @@ func (r *Registry) handleUpdatePhasedRelease(args json.RawMessage) (*mcp.ToolsCa
 		},
 	}
 
-	resp, err := r.client.UpdateAppStoreVersionPhasedRelease(context.Background(), params.PhasedReleaseID, req)
+	resp, err := r.client.UpdateAppStoreVersionPhasedRelease(ctx, params.PhasedReleaseID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update phased release: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update phased release: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated phased release: %s (state: %s)", resp.Data.ID, resp.Data.Attributes.PhasedReleaseState)), nil
 }
 
-func (r *Registry) handleDeletePhasedRelease(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeletePhasedRelease(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PhasedReleaseID string `json:"phased_release_id"`
 	}
@@ -188,9 +190,9 @@ func (r *Registry) handleDeletePhasedRelease(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("phased_release_id is required")
 	}
 
-	err := r.client.DeleteAppStoreVersionPhasedRelease(context.Background(), params.PhasedReleaseID)
+	err := r.client.DeleteAppStoreVersionPhasedRelease(ctx, params.PhasedReleaseID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete phased release: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete phased release: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Phased release deleted - app will release to all users"), nil
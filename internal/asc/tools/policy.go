@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// destructiveAppOperations recognize, by tool, the calls that remove an app
+// or one of its versions from sale or from App Store Connect. They are
+// refused unless destructive app operations are allowed. New tools that can
+// take an app off the store belong here.
+var destructiveAppOperations = map[string]func(args json.RawMessage) bool{
+	"delete_app_store_version": func(json.RawMessage) bool { return true },
+	"create_app_availability":  removesAppFromSale,
+	"asc_api_request":          isDestructiveRawAppRequest,
+}
+
+// destructiveRawAppResources are the resource types whose DELETE requests
+// through asc_api_request are destructive app operations.
+var destructiveRawAppResources = map[string]bool{
+	"apps":              true,
+	"appStoreVersions":  true,
+	"appAvailabilities": true,
+}
+
+// AllowDestructiveAppOperations lets tools remove apps and versions from
+// sale or delete them. Without it such calls are refused, whatever other
+// confirmation they carry.
+func (r *Registry) AllowDestructiveAppOperations() {
+	r.allowDestructiveAppOperations = true
+}
+
+// checkAppOperationPolicy returns an error result if a call is a destructive
+// app operation that isn't allowed, or nil if it may run.
+func (r *Registry) checkAppOperationPolicy(name string, args json.RawMessage) *mcp.ToolsCallResult {
+	if r.allowDestructiveAppOperations {
+		return nil
+	}
+	isDestructive, ok := destructiveAppOperations[name]
+	if !ok || !isDestructive(args) {
+		return nil
+	}
+	return mcp.NewErrorResult(fmt.Sprintf("%s would remove an app or version from sale or from App Store Connect, which this server doesn't allow. Start it with --allow-destructive-app-operations or ASC_ALLOW_DESTRUCTIVE_APP_OPERATIONS=true to allow it.", r.publicName(name)))
+}
+
+// removesAppFromSale reports whether a create_app_availability call leaves
+// the app available in no territory.
+func removesAppFromSale(args json.RawMessage) bool {
+	var params struct {
+		TerritoryIDs []string `json:"territory_ids"`
+	}
+	if json.Unmarshal(args, &params) != nil {
+		return false
+	}
+	return len(params.TerritoryIDs) == 0
+}
+
+// isDestructiveRawAppRequest reports whether an asc_api_request call deletes
+// an app, an App Store version or an app's availability itself, rather than
+// one of its relationships.
+func isDestructiveRawAppRequest(args json.RawMessage) bool {
+	var params struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+	}
+	if json.Unmarshal(args, &params) != nil || !strings.EqualFold(params.Method, http.MethodDelete) {
+		return false
+	}
+	segments := strings.Split(strings.Trim(params.Path, "/"), "/")
+	return len(segments) == 3 && destructiveRawAppResources[segments[1]]
+}
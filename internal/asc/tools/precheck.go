@@ -0,0 +1,316 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// placeholderPattern matches text left over from templates and drafts.
+var placeholderPattern = regexp.MustCompile(`(?i)\b(lorem|ipsum|placeholder|todo|tbd|fixme|xxx+|insert (text|description) here|coming soon)\b`)
+
+// prereleasePattern matches words App Review rejects in app names (guideline 2.2).
+var prereleasePattern = regexp.MustCompile(`(?i)\b(beta|alpha|test|demo|trial|preview)\b`)
+
+// trademarkTerms are other platforms and well-known apps that App Review
+// flags in keywords and descriptions (guidelines 2.3.7 and 2.3.10).
+var trademarkTerms = []string{
+	"android", "google play", "play store", "samsung", "huawei", "windows phone", "blackberry",
+	"facebook", "instagram", "whatsapp", "tiktok", "snapchat", "youtube", "spotify", "netflix", "uber",
+}
+
+// registerPrecheckTools registers submission pre-check tools.
+func (r *Registry) registerPrecheckTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "check_app_store_metadata",
+			Description: "Scan an App Store version's metadata for common rejection triggers before submitting: placeholder text (lorem ipsum, TODO), other platforms or competitor trademarks in keywords and descriptions, beta or test in the app name, and missing or unreachable support, marketing and privacy policy URLs. Findings are heuristics, not App Review's verdict.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"version_id": {
+						Type:        "string",
+						Description: "The App Store version ID to check",
+					},
+					"competitor_terms": {
+						Type:        "array",
+						Description: "Optional: Extra trademarks or competitor names to flag in keywords and descriptions",
+						Items:       &mcp.Property{Type: "string"},
+					},
+					"check_urls": {
+						Type:        "boolean",
+						Description: "Request each URL to check it is reachable (default: true)",
+						Default:     true,
+					},
+				},
+				Required: []string{"app_id", "version_id"},
+			},
+			OutputSchema: mcp.SchemaFor(metadataCheckOutput{}),
+		},
+		r.handleCheckAppStoreMetadata,
+	)
+}
+
+// metadataField is one localized metadata value to check.
+type metadataField struct {
+	locale string
+	name   string
+	value  string
+}
+
+// handleCheckAppStoreMetadata handles the check_app_store_metadata tool.
+func (r *Registry) handleCheckAppStoreMetadata(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID           string   `json:"app_id"`
+		VersionID       string   `json:"version_id"`
+		CompetitorTerms []string `json:"competitor_terms"`
+		CheckURLs       *bool    `json:"check_urls"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" || params.VersionID == "" {
+		return mcp.NewErrorResult("app_id and version_id are required"), nil
+	}
+
+	fields, err := r.metadataFields(ctx, params.AppID, params.VersionID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read metadata: %v", err), err), nil
+	}
+
+	findings := checkMetadataFields(fields, params.CompetitorTerms)
+	if params.CheckURLs == nil || *params.CheckURLs {
+		findings = append(findings, checkMetadataURLs(ctx, fields)...)
+	}
+	sortFindings(findings)
+
+	output := metadataCheckOutput{VersionID: params.VersionID, Findings: findings}
+	for _, f := range findings {
+		if f.Severity == "error" {
+			output.Errors++
+		} else {
+			output.Warnings++
+		}
+	}
+
+	return mcp.NewStructuredResult(formatMetadataCheck(output), output), nil
+}
+
+// metadataFields collects the localized fields of an app's editable app info
+// and, if versionID is set, of that App Store version.
+func (r *Registry) metadataFields(ctx context.Context, appID, versionID string) ([]metadataField, error) {
+	appInfos, err := r.client.GetAppInfos(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app infos: %w", err)
+	}
+
+	var fields []metadataField
+	if len(appInfos.Data) > 0 {
+		// The app info being edited alongside the version, if any.
+		appInfo := appInfos.Data[0]
+		for _, info := range appInfos.Data {
+			if info.Attributes.State != "READY_FOR_DISTRIBUTION" && info.Attributes.AppStoreState != api.AppStoreStateReadyForSale {
+				appInfo = info
+				break
+			}
+		}
+		infoLocs, err := r.client.ListAppInfoLocalizations(ctx, appInfo.ID, api.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list app info localizations: %w", err)
+		}
+		for _, loc := range infoLocs.Data {
+			a := loc.Attributes
+			fields = append(fields,
+				metadataField{a.Locale, "name", a.Name},
+				metadataField{a.Locale, "subtitle", a.Subtitle},
+				metadataField{a.Locale, "privacyPolicyUrl", a.PrivacyPolicyURL},
+				metadataField{a.Locale, "privacyChoicesUrl", a.PrivacyChoicesURL},
+			)
+		}
+	}
+
+	if versionID == "" {
+		return fields, nil
+	}
+	versionLocs, err := r.client.ListAppStoreVersionLocalizations(ctx, versionID, api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list version localizations: %w", err)
+	}
+	for _, loc := range versionLocs.Data {
+		a := loc.Attributes
+		fields = append(fields,
+			metadataField{a.Locale, "description", a.Description},
+			metadataField{a.Locale, "keywords", a.Keywords},
+			metadataField{a.Locale, "whatsNew", a.WhatsNew},
+			metadataField{a.Locale, "promotionalText", a.PromotionalText},
+			metadataField{a.Locale, "supportUrl", a.SupportURL},
+			metadataField{a.Locale, "marketingUrl", a.MarketingURL},
+		)
+	}
+
+	return fields, nil
+}
+
+// checkMetadataFields applies the text heuristics to each field.
+func checkMetadataFields(fields []metadataField, competitorTerms []string) []metadataFinding {
+	terms := append([]string(nil), trademarkTerms...)
+	for _, term := range competitorTerms {
+		if term = strings.TrimSpace(strings.ToLower(term)); term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	var findings []metadataFinding
+	supportURLs := make(map[string]bool)
+	for _, f := range fields {
+		if f.name == "supportUrl" {
+			supportURLs[f.locale] = supportURLs[f.locale] || f.value != ""
+		}
+		if f.value == "" {
+			continue
+		}
+
+		if match := placeholderPattern.FindString(f.value); match != "" {
+			findings = append(findings, metadataFinding{
+				Severity: "error", Locale: f.locale, Field: f.name,
+				Message: fmt.Sprintf("contains placeholder text %q (guideline 2.3)", match),
+			})
+		}
+
+		switch f.name {
+		case "name", "subtitle":
+			if match := prereleasePattern.FindString(f.value); match != "" {
+				findings = append(findings, metadataFinding{
+					Severity: "error", Locale: f.locale, Field: f.name,
+					Message: fmt.Sprintf("contains %q; pre-release wording isn't allowed on the App Store (guideline 2.2)", match),
+				})
+			}
+		case "keywords", "description", "promotionalText", "whatsNew":
+			severity := "warning"
+			if f.name == "keywords" {
+				severity = "error"
+			}
+			text := strings.ToLower(f.value)
+			for _, term := range terms {
+				if containsTerm(text, term) {
+					findings = append(findings, metadataFinding{
+						Severity: severity, Locale: f.locale, Field: f.name,
+						Message: fmt.Sprintf("mentions %q; other platforms and third-party trademarks are rejected (guidelines 2.3.7, 2.3.10)", term),
+					})
+				}
+			}
+		}
+	}
+
+	for locale, ok := range supportURLs {
+		if !ok {
+			findings = append(findings, metadataFinding{
+				Severity: "error", Locale: locale, Field: "supportUrl",
+				Message: "is missing; a support URL is required (guideline 1.5)",
+			})
+		}
+	}
+
+	return findings
+}
+
+// containsTerm reports whether term appears in text as whole words.
+func containsTerm(text, term string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], term)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(term)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isWordByte reports whether b is an ASCII letter or digit.
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// checkMetadataURLs requests each distinct URL once and reports the ones that
+// fail, for every field that uses them.
+func checkMetadataURLs(ctx context.Context, fields []metadataField) []metadataFinding {
+	urls := make(map[string][]metadataField)
+	for _, f := range fields {
+		if strings.HasSuffix(f.name, "Url") && f.value != "" {
+			urls[f.value] = append(urls[f.value], f)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var findings []metadataFinding
+	for u, uses := range urls {
+		wg.Add(1)
+		go func(u string, uses []metadataField) {
+			defer wg.Done()
+			problem := checkURL(ctx, u)
+			if problem == "" {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, f := range uses {
+				severity := "warning"
+				if f.name == "supportUrl" || f.name == "privacyPolicyUrl" {
+					severity = "error"
+				}
+				findings = append(findings, metadataFinding{
+					Severity: severity, Locale: f.locale, Field: f.name,
+					Message: fmt.Sprintf("%s %s", u, problem),
+				})
+			}
+		}(u, uses)
+	}
+	wg.Wait()
+
+	return findings
+}
+
+// sortFindings orders findings by severity, then locale and field.
+func sortFindings(findings []metadataFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == "error"
+		}
+		if a.Locale != b.Locale {
+			return a.Locale < b.Locale
+		}
+		return a.Field < b.Field
+	})
+}
+
+// formatMetadataCheck renders findings grouped by severity.
+func formatMetadataCheck(c metadataCheckOutput) string {
+	if len(c.Findings) == 0 {
+		return fmt.Sprintf("No common rejection triggers found in version %s metadata.\n", c.VersionID)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Version %s metadata: %d errors, %d warnings\n\n", c.VersionID, c.Errors, c.Warnings))
+	for _, f := range c.Findings {
+		sb.WriteString(fmt.Sprintf("- [%s] %s %s: %s\n", strings.ToUpper(f.Severity), f.Locale, f.Field, f.Message))
+	}
+	return sb.String()
+}
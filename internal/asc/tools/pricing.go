@@ -43,6 +43,8 @@ func (r *Registry) registerPricingTools() {
 					Type:        "integer",
 					Description: "Maximum number of price points to return (default 100)",
 				},
+				"cursor":  cursorProperty,
+				"refresh": refreshProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -59,6 +61,8 @@ func (r *Registry) registerPricingTools() {
 					Type:        "integer",
 					Description: "Maximum number of territories to return (default 200)",
 				},
+				"cursor":  cursorProperty,
+				"refresh": refreshProperty,
 			},
 		},
 	}, r.handleListTerritories)
@@ -78,13 +82,15 @@ func (r *Registry) registerPricingTools() {
 					Type:        "integer",
 					Description: "Maximum number of price points to return (default 100)",
 				},
+				"cursor":  cursorProperty,
+				"refresh": refreshProperty,
 			},
 			Required: []string{"subscription_id"},
 		},
 	}, r.handleListSubscriptionPricePoints)
 }
 
-func (r *Registry) handleGetAppPriceSchedule(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppPriceSchedule(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID string `json:"app_id"`
 	}
@@ -96,18 +102,20 @@ func (r *Registry) handleGetAppPriceSchedule(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("app_id is required")
 	}
 
-	resp, err := r.client.GetAppPriceSchedule(context.Background(), params.AppID)
+	resp, err := r.client.GetAppPriceSchedule(ctx, params.AppID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app price schedule: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app price schedule: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatAppPriceSchedule(resp.Data)), nil
 }
 
-func (r *Registry) handleListAppPricePoints(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppPricePoints(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID   string `json:"app_id"`
+		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
+		Refresh bool   `json:"refresh"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -122,17 +130,19 @@ func (r *Registry) handleListAppPricePoints(args json.RawMessage) (*mcp.ToolsCal
 		limit = 100
 	}
 
-	resp, err := r.client.ListAppPricePoints(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListAppPricePoints(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app price points: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app price points: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppPricePoints(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatAppPricePoints(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListTerritories(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListTerritories(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
+		Refresh bool   `json:"refresh"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -143,18 +153,20 @@ func (r *Registry) handleListTerritories(args json.RawMessage) (*mcp.ToolsCallRe
 		limit = 200
 	}
 
-	resp, err := r.client.ListTerritories(context.Background(), limit)
+	resp, err := r.client.ListTerritories(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list territories: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list territories: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatTerritories(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatTerritories(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListSubscriptionPricePoints(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSubscriptionPricePoints(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID string `json:"subscription_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
+		Refresh        bool   `json:"refresh"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -169,12 +181,12 @@ func (r *Registry) handleListSubscriptionPricePoints(args json.RawMessage) (*mcp
 		limit = 100
 	}
 
-	resp, err := r.client.ListSubscriptionPricePoints(context.Background(), params.SubscriptionID, limit)
+	resp, err := r.client.ListSubscriptionPricePoints(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), params.SubscriptionID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list subscription price points: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list subscription price points: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatSubscriptionPricePoints(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatSubscriptionPricePoints(resp.Data), resp.Links)), nil
 }
 
 func formatAppPriceSchedule(schedule api.AppPriceSchedule) string {
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+const (
+	// defaultPollInterval is the default delay between status checks in wait tools.
+	defaultPollInterval = 15 * time.Second
+
+	// defaultPollTimeout is the default maximum time a wait tool blocks.
+	defaultPollTimeout = 10 * time.Minute
+
+	// maxPollTimeout is the upper bound accepted for wait tool timeouts.
+	maxPollTimeout = 30 * time.Minute
+
+	// downloadProgressStep is how many bytes of a download are read between
+	// progress reports.
+	downloadProgressStep = 256 << 10
+)
+
+// pollCheck inspects the current state of a polled resource.
+// It returns whether polling is finished and a short human-readable status.
+type pollCheck func() (done bool, status string, err error)
+
+// pollUntil runs check every interval until it reports done, fails, the timeout
+// elapses, or ctx is done. Progress is reported as elapsed seconds against the timeout.
+func pollUntil(ctx context.Context, timeout, interval time.Duration, progress ProgressFunc, check pollCheck) (string, error) {
+	start := time.Now()
+	total := timeout.Seconds()
+
+	for {
+		done, status, err := check()
+		if err != nil {
+			return status, err
+		}
+
+		elapsed := time.Since(start)
+		if done {
+			progress(total, total, status)
+			return status, nil
+		}
+
+		progress(elapsed.Seconds(), total, status)
+
+		if elapsed+interval > timeout {
+			return status, fmt.Errorf("timed out after %s (last status: %s)", elapsed.Round(time.Second), status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollDurations converts wait tool arguments in seconds to bounded durations.
+func pollDurations(timeoutSeconds, intervalSeconds int) (time.Duration, time.Duration) {
+	timeout := defaultPollTimeout
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	if timeout > maxPollTimeout {
+		timeout = maxPollTimeout
+	}
+
+	interval := defaultPollInterval
+	if intervalSeconds > 0 {
+		interval = time.Duration(intervalSeconds) * time.Second
+	}
+	if interval > timeout {
+		interval = timeout
+	}
+
+	return timeout, interval
+}
+
+// withDownloadProgress returns a context whose API response bodies report
+// their progress in bytes as they download, every downloadProgressStep
+// bytes and when complete. The total is zero if the length is unknown.
+func withDownloadProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	var reported int64
+	return api.WithBodyProgress(ctx, func(read, total int64) {
+		if read-reported < downloadProgressStep && read != total {
+			return
+		}
+		reported = read
+		progress(float64(read), float64(max(total, 0)), fmt.Sprintf("Downloaded %d KB", read/1024))
+	})
+}
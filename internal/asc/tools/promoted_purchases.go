@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
@@ -27,6 +28,7 @@ func (r *Registry) registerPromotedPurchasesTools() {
 					Type:        "integer",
 					Description: "Maximum number of promoted purchases to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -131,6 +133,7 @@ func (r *Registry) registerPromotedPurchasesTools() {
 					Type:        "integer",
 					Description: "Maximum number of offer codes to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"subscription_id"},
 		},
@@ -195,8 +198,12 @@ func (r *Registry) registerPromotedPurchasesTools() {
 					Type:        "boolean",
 					Description: "Whether the offer code is active",
 				},
+				"price_point_ids": {
+					Type:        "array",
+					Description: "Subscription price point IDs, one per territory, that set the offer price",
+				},
 			},
-			Required: []string{"subscription_id", "name", "customer_eligibility"},
+			Required: []string{"subscription_id", "name", "customer_eligibility", "price_point_ids"},
 		},
 	}, r.handleCreateSubscriptionOfferCode)
 
@@ -220,6 +227,71 @@ func (r *Registry) registerPromotedPurchasesTools() {
 		},
 	}, r.handleUpdateSubscriptionOfferCode)
 
+	// List subscription offer code custom codes
+	r.register(mcp.Tool{
+		Name:        "list_subscription_offer_code_custom_codes",
+		Description: "List the custom codes (vanity codes like PODCAST20) of a subscription offer code",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"offer_code_id": {
+					Type:        "string",
+					Description: "The offer code ID",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of custom codes to return (default 50)",
+				},
+				"cursor": cursorProperty,
+			},
+			Required: []string{"offer_code_id"},
+		},
+	}, r.handleListSubscriptionOfferCodeCustomCodes)
+
+	// Create subscription offer code custom code
+	r.register(mcp.Tool{
+		Name:        "create_subscription_offer_code_custom_code",
+		Description: "Create a custom code for a subscription offer code, such as PODCAST20, that customers can redeem up to a set number of times",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"offer_code_id": {
+					Type:        "string",
+					Description: "The offer code ID",
+				},
+				"custom_code": {
+					Type:        "string",
+					Description: fmt.Sprintf("The code customers enter: up to %d letters and digits", maxCustomCodeLength),
+				},
+				"number_of_codes": {
+					Type:        "integer",
+					Description: "How many times the code can be redeemed",
+				},
+				"expiration_date": {
+					Type:        "string",
+					Description: "Last day the code can be redeemed (YYYY-MM-DD). Without it, the code doesn't expire",
+				},
+			},
+			Required: []string{"offer_code_id", "custom_code", "number_of_codes"},
+		},
+	}, r.handleCreateSubscriptionOfferCodeCustomCode)
+
+	// Deactivate subscription offer code custom code
+	r.register(mcp.Tool{
+		Name:        "deactivate_subscription_offer_code_custom_code",
+		Description: "Deactivate a custom offer code so it can no longer be redeemed. A deactivated code can't be activated again",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"custom_code_id": {
+					Type:        "string",
+					Description: "The custom code ID",
+				},
+			},
+			Required: []string{"custom_code_id"},
+		},
+	}, r.handleDeactivateSubscriptionOfferCodeCustomCode)
+
 	// List win-back offers
 	r.register(mcp.Tool{
 		Name:        "list_win_back_offers",
@@ -235,6 +307,7 @@ func (r *Registry) registerPromotedPurchasesTools() {
 					Type:        "integer",
 					Description: "Maximum number of offers to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"subscription_id"},
 		},
@@ -293,10 +366,30 @@ func (r *Registry) registerPromotedPurchasesTools() {
 				},
 				"promotion_intent": {
 					Type:        "string",
-					Description: "Promotion intent: NOT_PROMOTED, USE_CUSTOM_PRODUCT_PAGE",
+					Description: "Promotion intent: NOT_PROMOTED, USE_AUTO_GENERATED_ASSETS",
+				},
+				"paid_subscription_duration_months": {
+					Type:        "integer",
+					Description: "Minimum months the customer previously paid for the subscription",
+				},
+				"min_months_since_last_subscribed": {
+					Type:        "integer",
+					Description: "Minimum months since the customer's subscription lapsed",
+				},
+				"max_months_since_last_subscribed": {
+					Type:        "integer",
+					Description: "Maximum months since the customer's subscription lapsed",
+				},
+				"start_date": {
+					Type:        "string",
+					Description: "First day the offer is available (YYYY-MM-DD)",
+				},
+				"end_date": {
+					Type:        "string",
+					Description: "Last day the offer is available (YYYY-MM-DD)",
 				},
 			},
-			Required: []string{"subscription_id", "reference_name", "offer_id"},
+			Required: []string{"subscription_id", "reference_name", "offer_id", "start_date"},
 		},
 	}, r.handleCreateWinBackOffer)
 
@@ -341,10 +434,11 @@ func (r *Registry) registerPromotedPurchasesTools() {
 	}, r.handleDeleteWinBackOffer)
 }
 
-func (r *Registry) handleListPromotedPurchases(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListPromotedPurchases(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -359,15 +453,15 @@ func (r *Registry) handleListPromotedPurchases(args json.RawMessage) (*mcp.Tools
 		limit = 50
 	}
 
-	resp, err := r.client.ListPromotedPurchases(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListPromotedPurchases(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list promoted purchases: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list promoted purchases: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatPromotedPurchases(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatPromotedPurchases(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetPromotedPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetPromotedPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PromotedPurchaseID string `json:"promoted_purchase_id"`
 	}
@@ -379,15 +473,15 @@ func (r *Registry) handleGetPromotedPurchase(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("promoted_purchase_id is required")
 	}
 
-	resp, err := r.client.GetPromotedPurchase(context.Background(), params.PromotedPurchaseID)
+	resp, err := r.client.GetPromotedPurchase(ctx, params.PromotedPurchaseID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get promoted purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get promoted purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatPromotedPurchase(resp.Data)), nil
 }
 
-func (r *Registry) handleCreatePromotedPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreatePromotedPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID              string `json:"app_id"`
 		InAppPurchaseID    string `json:"in_app_purchase_id"`
@@ -430,15 +524,15 @@ func (r *Registry) handleCreatePromotedPurchase(args json.RawMessage) (*mcp.Tool
 		},
 	}
 
-	resp, err := r.client.CreatePromotedPurchase(context.Background(), req)
+	resp, err := r.client.CreatePromotedPurchase(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create promoted purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create promoted purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Promoted purchase created:\n%s", formatPromotedPurchase(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdatePromotedPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdatePromotedPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PromotedPurchaseID string `json:"promoted_purchase_id"`
 		Enabled            *bool  `json:"enabled"`
@@ -463,15 +557,15 @@ func (r *Registry) handleUpdatePromotedPurchase(args json.RawMessage) (*mcp.Tool
 		},
 	}
 
-	resp, err := r.client.UpdatePromotedPurchase(context.Background(), params.PromotedPurchaseID, req)
+	resp, err := r.client.UpdatePromotedPurchase(ctx, params.PromotedPurchaseID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update promoted purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update promoted purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Promoted purchase updated:\n%s", formatPromotedPurchase(resp.Data))), nil
 }
 
-func (r *Registry) handleDeletePromotedPurchase(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeletePromotedPurchase(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PromotedPurchaseID string `json:"promoted_purchase_id"`
 	}
@@ -483,18 +577,19 @@ func (r *Registry) handleDeletePromotedPurchase(args json.RawMessage) (*mcp.Tool
 		return nil, fmt.Errorf("promoted_purchase_id is required")
 	}
 
-	err := r.client.DeletePromotedPurchase(context.Background(), params.PromotedPurchaseID)
+	err := r.client.DeletePromotedPurchase(ctx, params.PromotedPurchaseID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete promoted purchase: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete promoted purchase: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Promoted purchase deleted"), nil
 }
 
-func (r *Registry) handleListSubscriptionOfferCodes(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSubscriptionOfferCodes(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID string `json:"subscription_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -509,15 +604,15 @@ func (r *Registry) handleListSubscriptionOfferCodes(args json.RawMessage) (*mcp.
 		limit = 50
 	}
 
-	resp, err := r.client.ListSubscriptionOfferCodes(context.Background(), params.SubscriptionID, limit)
+	resp, err := r.client.ListSubscriptionOfferCodes(api.WithCursor(ctx, params.Cursor), params.SubscriptionID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list subscription offer codes: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list subscription offer codes: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatSubscriptionOfferCodes(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatSubscriptionOfferCodes(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetSubscriptionOfferCode(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetSubscriptionOfferCode(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		OfferCodeID string `json:"offer_code_id"`
 	}
@@ -529,15 +624,15 @@ func (r *Registry) handleGetSubscriptionOfferCode(args json.RawMessage) (*mcp.To
 		return nil, fmt.Errorf("offer_code_id is required")
 	}
 
-	resp, err := r.client.GetSubscriptionOfferCode(context.Background(), params.OfferCodeID)
+	resp, err := r.client.GetSubscriptionOfferCode(ctx, params.OfferCodeID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get subscription offer code: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get subscription offer code: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatSubscriptionOfferCode(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateSubscriptionOfferCode(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateSubscriptionOfferCode(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID          string   `json:"subscription_id"`
 		Name                    string   `json:"name"`
@@ -546,6 +641,7 @@ func (r *Registry) handleCreateSubscriptionOfferCode(args json.RawMessage) (*mcp
 		Duration                string   `json:"duration"`
 		OfferMode               string   `json:"offer_mode"`
 		NumberOfPeriods         int      `json:"number_of_periods"`
+		PricePointIDs           []string `json:"price_point_ids"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -554,6 +650,26 @@ func (r *Registry) handleCreateSubscriptionOfferCode(args json.RawMessage) (*mcp
 	if params.SubscriptionID == "" || params.Name == "" {
 		return nil, fmt.Errorf("subscription_id and name are required")
 	}
+	if len(params.PricePointIDs) == 0 {
+		return nil, fmt.Errorf("price_point_ids is required")
+	}
+
+	// Prices are created inline, linked to the offer code by local IDs.
+	prices := make([]api.ResourceIdentifier, 0, len(params.PricePointIDs))
+	included := make([]api.SubscriptionOfferCodePriceInlineCreate, 0, len(params.PricePointIDs))
+	for i, pricePointID := range params.PricePointIDs {
+		localID := fmt.Sprintf("${price-%d}", i+1)
+		prices = append(prices, api.ResourceIdentifier{Type: "subscriptionOfferCodePrices", ID: localID})
+		included = append(included, api.SubscriptionOfferCodePriceInlineCreate{
+			Type: "subscriptionOfferCodePrices",
+			ID:   localID,
+			Relationships: api.SubscriptionOfferCodePriceRelationships{
+				SubscriptionPricePoint: api.RelationshipData{
+					Data: api.ResourceIdentifier{Type: "subscriptionPricePoints", ID: pricePointID},
+				},
+			},
+		})
+	}
 
 	req := &api.SubscriptionOfferCodeCreateRequest{
 		Data: api.SubscriptionOfferCodeCreateData{
@@ -570,19 +686,21 @@ func (r *Registry) handleCreateSubscriptionOfferCode(args json.RawMessage) (*mcp
 				Subscription: api.RelationshipData{
 					Data: api.ResourceIdentifier{Type: "subscriptions", ID: params.SubscriptionID},
 				},
+				Prices: api.RelationshipDataList{Data: prices},
 			},
 		},
+		Included: included,
 	}
 
-	resp, err := r.client.CreateSubscriptionOfferCode(context.Background(), req)
+	resp, err := r.client.CreateSubscriptionOfferCode(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create subscription offer code: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create subscription offer code: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Subscription offer code created:\n%s", formatSubscriptionOfferCode(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateSubscriptionOfferCode(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateSubscriptionOfferCode(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		OfferCodeID string `json:"offer_code_id"`
 		Active      *bool  `json:"active"`
@@ -605,18 +723,148 @@ func (r *Registry) handleUpdateSubscriptionOfferCode(args json.RawMessage) (*mcp
 		},
 	}
 
-	resp, err := r.client.UpdateSubscriptionOfferCode(context.Background(), params.OfferCodeID, req)
+	resp, err := r.client.UpdateSubscriptionOfferCode(ctx, params.OfferCodeID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update subscription offer code: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update subscription offer code: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Subscription offer code updated:\n%s", formatSubscriptionOfferCode(resp.Data))), nil
 }
 
-func (r *Registry) handleListWinBackOffers(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSubscriptionOfferCodeCustomCodes(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		OfferCodeID string `json:"offer_code_id"`
+		Limit       int    `json:"limit"`
+		Cursor      string `json:"cursor"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.OfferCodeID == "" {
+		return nil, fmt.Errorf("offer_code_id is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	resp, err := r.client.ListSubscriptionOfferCodeCustomCodes(api.WithCursor(ctx, params.Cursor), params.OfferCodeID, api.ListOptions{Limit: limit})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list custom offer codes: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(withNextCursor(formatSubscriptionOfferCodeCustomCodes(resp.Data), resp.Links)), nil
+}
+
+func (r *Registry) handleCreateSubscriptionOfferCodeCustomCode(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		OfferCodeID    string `json:"offer_code_id"`
+		CustomCode     string `json:"custom_code"`
+		NumberOfCodes  int    `json:"number_of_codes"`
+		ExpirationDate string `json:"expiration_date"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.OfferCodeID == "" || params.CustomCode == "" {
+		return nil, fmt.Errorf("offer_code_id and custom_code are required")
+	}
+	if err := validateCustomCode(params.CustomCode); err != nil {
+		return mcp.NewErrorResultWithError(err.Error(), err), nil
+	}
+	if params.NumberOfCodes <= 0 {
+		return mcp.NewErrorResult("number_of_codes must be at least 1"), nil
+	}
+	if params.ExpirationDate != "" {
+		date, err := time.Parse("2006-01-02", params.ExpirationDate)
+		if err != nil {
+			return mcp.NewErrorResult(fmt.Sprintf("expiration_date must be a YYYY-MM-DD date, not %q", params.ExpirationDate)), nil
+		}
+		if date.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+			return mcp.NewErrorResult(fmt.Sprintf("expiration_date %s is in the past", params.ExpirationDate)), nil
+		}
+	}
+
+	req := &api.SubscriptionOfferCodeCustomCodeCreateRequest{
+		Data: api.SubscriptionOfferCodeCustomCodeCreateData{
+			Type: "subscriptionOfferCodeCustomCodes",
+			Attributes: api.SubscriptionOfferCodeCustomCodeCreateAttributes{
+				CustomCode:     params.CustomCode,
+				NumberOfCodes:  params.NumberOfCodes,
+				ExpirationDate: params.ExpirationDate,
+			},
+			Relationships: api.SubscriptionOfferCodeCustomCodeCreateRelationships{
+				OfferCode: api.RelationshipData{
+					Data: api.ResourceIdentifier{Type: "subscriptionOfferCodes", ID: params.OfferCodeID},
+				},
+			},
+		},
+	}
+
+	resp, err := r.client.CreateSubscriptionOfferCodeCustomCode(ctx, req)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create custom offer code: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Custom offer code created:\n%s", formatSubscriptionOfferCodeCustomCode(resp.Data))), nil
+}
+
+func (r *Registry) handleDeactivateSubscriptionOfferCodeCustomCode(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		CustomCodeID string `json:"custom_code_id"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.CustomCodeID == "" {
+		return nil, fmt.Errorf("custom_code_id is required")
+	}
+
+	active := false
+	req := &api.SubscriptionOfferCodeCustomCodeUpdateRequest{
+		Data: api.SubscriptionOfferCodeCustomCodeUpdateData{
+			Type: "subscriptionOfferCodeCustomCodes",
+			ID:   params.CustomCodeID,
+			Attributes: api.SubscriptionOfferCodeCustomCodeUpdateAttributes{
+				Active: &active,
+			},
+		},
+	}
+
+	resp, err := r.client.UpdateSubscriptionOfferCodeCustomCode(ctx, params.CustomCodeID, req)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to deactivate custom offer code: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Custom offer code deactivated:\n%s", formatSubscriptionOfferCodeCustomCode(resp.Data))), nil
+}
+
+// maxCustomCodeLength is the longest custom offer code App Store Connect accepts.
+const maxCustomCodeLength = 64
+
+// validateCustomCode checks that a custom offer code is letters and digits
+// only and not too long, so a typo fails before reaching the API.
+func validateCustomCode(code string) error {
+	if len(code) > maxCustomCodeLength {
+		return fmt.Errorf("custom_code must be at most %d characters, not %d", maxCustomCodeLength, len(code))
+	}
+	for _, c := range code {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return fmt.Errorf("custom_code must contain only letters and digits, not %q", c)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) handleListWinBackOffers(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID string `json:"subscription_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -631,15 +879,15 @@ func (r *Registry) handleListWinBackOffers(args json.RawMessage) (*mcp.ToolsCall
 		limit = 50
 	}
 
-	resp, err := r.client.ListWinBackOffers(context.Background(), params.SubscriptionID, limit)
+	resp, err := r.client.ListWinBackOffers(api.WithCursor(ctx, params.Cursor), params.SubscriptionID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list win-back offers: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list win-back offers: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatWinBackOffers(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatWinBackOffers(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetWinBackOffer(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetWinBackOffer(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		OfferID string `json:"offer_id"`
 	}
@@ -651,15 +899,15 @@ func (r *Registry) handleGetWinBackOffer(args json.RawMessage) (*mcp.ToolsCallRe
 		return nil, fmt.Errorf("offer_id is required")
 	}
 
-	resp, err := r.client.GetWinBackOffer(context.Background(), params.OfferID)
+	resp, err := r.client.GetWinBackOffer(ctx, params.OfferID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get win-back offer: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get win-back offer: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatWinBackOffer(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateWinBackOffer(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateWinBackOffer(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID  string   `json:"subscription_id"`
 		ReferenceName   string   `json:"reference_name"`
@@ -670,6 +918,12 @@ func (r *Registry) handleCreateWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 		Priority        string   `json:"priority"`
 		PromotionIntent string   `json:"promotion_intent"`
 		PriceIDs        []string `json:"price_ids"`
+
+		PaidSubscriptionDurationMonths int    `json:"paid_subscription_duration_months"`
+		MinMonthsSinceLastSubscribed   int    `json:"min_months_since_last_subscribed"`
+		MaxMonthsSinceLastSubscribed   int    `json:"max_months_since_last_subscribed"`
+		StartDate                      string `json:"start_date"`
+		EndDate                        string `json:"end_date"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -678,6 +932,9 @@ func (r *Registry) handleCreateWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 	if params.SubscriptionID == "" || params.ReferenceName == "" || params.OfferID == "" {
 		return nil, fmt.Errorf("subscription_id, reference_name, and offer_id are required")
 	}
+	if params.StartDate == "" {
+		return nil, fmt.Errorf("start_date is required")
+	}
 
 	var prices []api.ResourceIdentifier
 	for _, pid := range params.PriceIDs {
@@ -695,6 +952,14 @@ func (r *Registry) handleCreateWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 				PeriodCount:     params.PeriodCount,
 				Priority:        params.Priority,
 				PromotionIntent: params.PromotionIntent,
+				StartDate:       params.StartDate,
+				EndDate:         params.EndDate,
+
+				CustomerEligibilityPaidSubscriptionDurationInMonths: params.PaidSubscriptionDurationMonths,
+				CustomerEligibilityTimeSinceLastSubscribedInMonths: api.IntegerRange{
+					Minimum: params.MinMonthsSinceLastSubscribed,
+					Maximum: params.MaxMonthsSinceLastSubscribed,
+				},
 			},
 			Relationships: api.WinBackOfferCreateRelationships{
 				Subscription: api.RelationshipData{
@@ -707,15 +972,15 @@ func (r *Registry) handleCreateWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 		},
 	}
 
-	resp, err := r.client.CreateWinBackOffer(context.Background(), req)
+	resp, err := r.client.CreateWinBackOffer(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create win-back offer: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create win-back offer: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Win-back offer created:\n%s", formatWinBackOffer(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateWinBackOffer(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateWinBackOffer(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		OfferID         string `json:"offer_id"`
 		Priority        string `json:"priority"`
@@ -740,15 +1005,15 @@ func (r *Registry) handleUpdateWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 		},
 	}
 
-	resp, err := r.client.UpdateWinBackOffer(context.Background(), params.OfferID, req)
+	resp, err := r.client.UpdateWinBackOffer(ctx, params.OfferID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update win-back offer: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update win-back offer: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Win-back offer updated:\n%s", formatWinBackOffer(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteWinBackOffer(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteWinBackOffer(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		OfferID string `json:"offer_id"`
 	}
@@ -760,9 +1025,9 @@ func (r *Registry) handleDeleteWinBackOffer(args json.RawMessage) (*mcp.ToolsCal
 		return nil, fmt.Errorf("offer_id is required")
 	}
 
-	err := r.client.DeleteWinBackOffer(context.Background(), params.OfferID)
+	err := r.client.DeleteWinBackOffer(ctx, params.OfferID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete win-back offer: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete win-back offer: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Win-back offer deleted"), nil
@@ -831,6 +1096,39 @@ func formatSubscriptionOfferCode(c api.SubscriptionOfferCode) string {
 	return sb.String()
 }
 
+func formatSubscriptionOfferCodeCustomCodes(codes []api.SubscriptionOfferCodeCustomCode) string {
+	if len(codes) == 0 {
+		return "No custom offer codes found"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d custom offer codes:\n\n", len(codes)))
+
+	for _, c := range codes {
+		sb.WriteString(formatSubscriptionOfferCodeCustomCode(c))
+		sb.WriteString("\n---\n")
+	}
+
+	return sb.String()
+}
+
+func formatSubscriptionOfferCodeCustomCode(c api.SubscriptionOfferCodeCustomCode) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("ID: %s\n", c.ID))
+	sb.WriteString(fmt.Sprintf("Code: %s\n", c.Attributes.CustomCode))
+	sb.WriteString(fmt.Sprintf("Active: %t\n", c.Attributes.Active))
+	if c.Attributes.NumberOfCodes > 0 {
+		sb.WriteString(fmt.Sprintf("Redemptions: %d\n", c.Attributes.NumberOfCodes))
+	}
+	if c.Attributes.ExpirationDate != "" {
+		sb.WriteString(fmt.Sprintf("Expires: %s\n", c.Attributes.ExpirationDate))
+	}
+	if c.Attributes.CreatedDate != nil {
+		sb.WriteString(fmt.Sprintf("Created: %s\n", c.Attributes.CreatedDate.Format("2006-01-02")))
+	}
+	return sb.String()
+}
+
 func formatWinBackOffers(offers []api.WinBackOffer) string {
 	if len(offers) == 0 {
 		return "No win-back offers found"
@@ -11,6 +11,9 @@ import (
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
 
+// devicePlatforms are the platforms a device can be registered for.
+var devicePlatforms = []api.BundleIDPlatform{api.BundleIDPlatformIOS, api.BundleIDPlatformMacOS}
+
 // registerProvisioningTools registers provisioning management tools.
 func (r *Registry) registerProvisioningTools() {
 	r.register(
@@ -25,6 +28,7 @@ func (r *Registry) registerProvisioningTools() {
 						Description: "Maximum number of bundle IDs to return (default: 50)",
 						Default:     50,
 					},
+					"cursor": cursorProperty,
 				},
 			},
 		},
@@ -61,6 +65,7 @@ func (r *Registry) registerProvisioningTools() {
 						Description: "Maximum number of certificates to return (default: 50)",
 						Default:     50,
 					},
+					"cursor": cursorProperty,
 				},
 			},
 		},
@@ -79,6 +84,7 @@ func (r *Registry) registerProvisioningTools() {
 						Description: "Maximum number of profiles to return (default: 50)",
 						Default:     50,
 					},
+					"cursor": cursorProperty,
 				},
 			},
 		},
@@ -97,6 +103,7 @@ func (r *Registry) registerProvisioningTools() {
 						Description: "Maximum number of devices to return (default: 50)",
 						Default:     50,
 					},
+					"cursor": cursorProperty,
 				},
 			},
 		},
@@ -121,7 +128,7 @@ func (r *Registry) registerProvisioningTools() {
 					"platform": {
 						Type:        "string",
 						Description: "The device platform",
-						Enum:        []string{"IOS", "MAC_OS"},
+						Enum:        api.EnumStrings(devicePlatforms),
 					},
 				},
 				Required: []string{"name", "udid", "platform"},
@@ -132,9 +139,10 @@ func (r *Registry) registerProvisioningTools() {
 }
 
 // handleListBundleIDs handles the list_bundle_ids tool.
-func (r *Registry) handleListBundleIDs(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBundleIDs(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	params.Limit = 50
 
@@ -144,10 +152,9 @@ func (r *Registry) handleListBundleIDs(args json.RawMessage) (*mcp.ToolsCallResu
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListBundleIDs(ctx, params.Limit)
+	resp, err := r.client.ListBundleIDs(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list bundle IDs: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list bundle IDs: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
@@ -168,11 +175,11 @@ func (r *Registry) handleListBundleIDs(args json.RawMessage) (*mcp.ToolsCallResu
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
 }
 
 // handleGetBundleID handles the get_bundle_id tool.
-func (r *Registry) handleGetBundleID(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetBundleID(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BundleIDID string `json:"bundle_id_id"`
 	}
@@ -185,10 +192,9 @@ func (r *Registry) handleGetBundleID(args json.RawMessage) (*mcp.ToolsCallResult
 		return mcp.NewErrorResult("bundle_id_id is required"), nil
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.GetBundleID(ctx, params.BundleIDID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get bundle ID: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get bundle ID: %v", err), err), nil
 	}
 
 	bundleID := resp.Data
@@ -205,9 +211,10 @@ func (r *Registry) handleGetBundleID(args json.RawMessage) (*mcp.ToolsCallResult
 }
 
 // handleListCertificates handles the list_certificates tool.
-func (r *Registry) handleListCertificates(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListCertificates(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	params.Limit = 50
 
@@ -217,10 +224,9 @@ func (r *Registry) handleListCertificates(args json.RawMessage) (*mcp.ToolsCallR
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListCertificates(ctx, params.Limit)
+	resp, err := r.client.ListCertificates(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list certificates: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list certificates: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
@@ -248,13 +254,14 @@ func (r *Registry) handleListCertificates(args json.RawMessage) (*mcp.ToolsCallR
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
 }
 
 // handleListProfiles handles the list_profiles tool.
-func (r *Registry) handleListProfiles(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListProfiles(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	params.Limit = 50
 
@@ -264,10 +271,9 @@ func (r *Registry) handleListProfiles(args json.RawMessage) (*mcp.ToolsCallResul
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListProfiles(ctx, params.Limit)
+	resp, err := r.client.ListProfiles(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list profiles: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list profiles: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
@@ -293,13 +299,14 @@ func (r *Registry) handleListProfiles(args json.RawMessage) (*mcp.ToolsCallResul
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
 }
 
 // handleListDevices handles the list_devices tool.
-func (r *Registry) handleListDevices(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListDevices(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	params.Limit = 50
 
@@ -309,10 +316,9 @@ func (r *Registry) handleListDevices(args json.RawMessage) (*mcp.ToolsCallResult
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListDevices(ctx, params.Limit)
+	resp, err := r.client.ListDevices(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list devices: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list devices: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
@@ -336,11 +342,11 @@ func (r *Registry) handleListDevices(args json.RawMessage) (*mcp.ToolsCallResult
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
 }
 
 // handleRegisterDevice handles the register_device tool.
-func (r *Registry) handleRegisterDevice(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleRegisterDevice(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		Name     string `json:"name"`
 		UDID     string `json:"udid"`
@@ -360,6 +366,10 @@ func (r *Registry) handleRegisterDevice(args json.RawMessage) (*mcp.ToolsCallRes
 	if params.Platform == "" {
 		return mcp.NewErrorResult("platform is required"), nil
 	}
+	platform, err := api.ParseEnum(params.Platform, devicePlatforms)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Invalid platform: %v", err), err), nil
+	}
 
 	req := &api.DeviceCreateRequest{
 		Data: api.DeviceCreateData{
@@ -367,15 +377,14 @@ func (r *Registry) handleRegisterDevice(args json.RawMessage) (*mcp.ToolsCallRes
 			Attributes: api.DeviceCreateAttributes{
 				Name:     params.Name,
 				UDID:     params.UDID,
-				Platform: params.Platform,
+				Platform: platform,
 			},
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.RegisterDevice(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to register device: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to register device: %v", err), err), nil
 	}
 
 	var sb strings.Builder
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// rawAPIPathPattern matches versioned API paths such as /v1/apps/123/builds.
+var rawAPIPathPattern = regexp.MustCompile(`^/v[0-9]+/[A-Za-z0-9/_.\-]*$`)
+
+// registerRawAPITools registers the raw API passthrough tool.
+func (r *Registry) registerRawAPITools() {
+	r.register(mcp.Tool{
+		Name:        "asc_api_request",
+		Description: "Send a raw request to any App Store Connect API endpoint. Use this only when no dedicated tool exists for the endpoint. Returns the response body as JSON.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"method": {
+					Type:        "string",
+					Description: "HTTP method",
+					Enum:        []string{"GET", "POST", "PATCH", "DELETE"},
+				},
+				"path": {
+					Type:        "string",
+					Description: "API path including the version, e.g. /v1/apps/123/appStoreVersions",
+				},
+				"query": {
+					Type:        "object",
+					Description: "Optional: Query parameters, e.g. {\"filter[platform]\": \"IOS\", \"limit\": \"10\"}",
+				},
+				"body": {
+					Type:        "object",
+					Description: "Optional: JSON:API request body for POST and PATCH",
+				},
+			},
+			Required: []string{"method", "path"},
+		},
+	}, r.handleAscAPIRequest)
+}
+
+func (r *Registry) handleAscAPIRequest(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Method string            `json:"method"`
+		Path   string            `json:"path"`
+		Query  map[string]string `json:"query"`
+		Body   json.RawMessage   `json:"body"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	method := strings.ToUpper(params.Method)
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("method must be one of GET, POST, PATCH, DELETE")
+	}
+
+	if err := validateRawAPIPath(params.Path); err != nil {
+		return nil, err
+	}
+
+	var query url.Values
+	if len(params.Query) > 0 {
+		query = url.Values{}
+		for key, value := range params.Query {
+			query.Set(key, value)
+		}
+	}
+
+	var body any
+	if len(params.Body) > 0 && string(params.Body) != "null" {
+		if method == http.MethodGet || method == http.MethodDelete {
+			return nil, fmt.Errorf("body is only allowed for POST and PATCH requests")
+		}
+		body = params.Body
+	}
+
+	data, err := r.client.Do(ctx, method, params.Path, query, body)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to call %s %s: %v", method, params.Path, err), err), nil
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return mcp.NewSuccessResult(fmt.Sprintf("%s %s succeeded with no content", method, params.Path)), nil
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, data, "", "  "); err != nil {
+		return mcp.NewSuccessResult(string(data)), nil
+	}
+
+	return mcp.NewSuccessResult(pretty.String()), nil
+}
+
+// validateRawAPIPath rejects anything other than a relative, versioned API path,
+// so the request can never be redirected to another host with the API token.
+func validateRawAPIPath(path string) error {
+	if !rawAPIPathPattern.MatchString(path) {
+		return fmt.Errorf("path must be a versioned API path such as /v1/apps, without a host or query string")
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("path must not contain . or .. segments")
+		}
+	}
+	return nil
+}
@@ -2,129 +2,346 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/snapshots"
 )
 
 // ToolHandler is a function that handles a tool call.
-type ToolHandler func(args json.RawMessage) (*mcp.ToolsCallResult, error)
+// The context carries cancellation and per-call request options to the API client.
+type ToolHandler func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error)
+
+// ProgressFunc reports the progress of a long-running tool call.
+// Total may be zero when the amount of remaining work is unknown.
+type ProgressFunc func(progress, total float64, message string)
+
+// ProgressToolHandler is a tool handler that reports progress while it runs.
+type ProgressToolHandler func(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error)
 
 // Registry manages tool definitions and handlers.
 type Registry struct {
-	client   *api.Client
-	tools    []mcp.Tool
-	handlers map[string]ToolHandler
+	client              *api.Client
+	tools               []mcp.Tool
+	handlers            map[string]ToolHandler
+	progressHandlers    map[string]ProgressToolHandler
+	timeouts            map[string]time.Duration
+	globalLimit         *semaphore
+	limits              map[string]*semaphore
+	snapshots           *snapshots.Store
+	continuations       *continuations
+	searchCache         *searchCache
+	appGroups           map[string][]string
+	enumLabels          map[string]string
+	maxResultBytes      int
+	group               string
+	groups              map[string]string
+	toolPrefix          string
+	groupedNames        bool
+	requireConfirmation bool
+	enterprise          bool
+	teams               bool
+	capabilities        capabilities
+
+	// allowDestructiveAppOperations lets destructiveAppOperations run.
+	allowDestructiveAppOperations bool
 }
 
 // NewRegistry creates a new tool registry.
 func NewRegistry(client *api.Client) *Registry {
 	r := &Registry{
-		client:   client,
-		tools:    make([]mcp.Tool, 0),
-		handlers: make(map[string]ToolHandler),
+		client:           client,
+		tools:            make([]mcp.Tool, 0),
+		handlers:         make(map[string]ToolHandler),
+		progressHandlers: make(map[string]ProgressToolHandler),
+		timeouts:         make(map[string]time.Duration),
+		globalLimit:      newSemaphore(defaultConcurrencyLimit),
+		limits:           make(map[string]*semaphore),
+		snapshots:        snapshots.NewMemoryStore(),
+		continuations:    newContinuations(),
+		searchCache:      newSearchCache(),
+		groups:           make(map[string]string),
 	}
 
 	// Core app management
-	r.registerAppTools()
-	r.registerBuildTools()
-	r.registerTestFlightTools()
-	r.registerProvisioningTools()
+	r.registerGroup("apps", r.registerAppTools)
+	r.registerGroup("builds", r.registerBuildTools)
+	r.registerGroup("builds", r.registerBuildRetentionTools)
+	r.registerGroup("builds", r.registerBuildOverviewTools)
+	r.registerGroup("testflight", r.registerTestFlightTools)
+	r.registerGroup("testflight", r.registerTesterRemovalTools)
+	r.registerGroup("provisioning", r.registerProvisioningTools)
+	r.registerGroup("core", r.registerSearchTools)
+	r.registerGroup("core", r.registerStatusTools)
+	r.registerGroup("core", r.registerContinuationTools)
+	r.registerGroup("core", r.registerBatchTools)
+	r.registerGroup("core", r.registerAppGroupTools)
+	r.registerGroup("core", r.registerCredentialTools)
 
 	// Localization
-	r.registerAppInfoLocalizationTools()
-	r.registerVersionLocalizationTools()
+	r.registerGroup("localizations", r.registerAppInfoLocalizationTools)
+	r.registerGroup("localizations", r.registerVersionLocalizationTools)
+	r.registerGroup("localizations", r.registerMetadataTemplateTools)
+	r.registerGroup("localizations", r.registerLocaleCoverageTools)
 
 	// Customer reviews
-	r.registerCustomerReviewTools()
+	r.registerGroup("reviews", r.registerCustomerReviewTools)
 
 	// In-app purchases and subscriptions
-	r.registerInAppPurchaseTools()
-	r.registerSubscriptionTools()
+	r.registerGroup("iap", r.registerInAppPurchaseTools)
+	r.registerGroup("subscriptions", r.registerSubscriptionTools)
 
 	// App Store versions and submissions
-	r.registerVersionSubmissionTools()
-	r.registerPhasedReleaseTools()
+	r.registerGroup("versions", r.registerVersionSubmissionTools)
+	r.registerGroup("versions", r.registerPhasedReleaseTools)
+	r.registerGroup("versions", r.registerReleaseStrategyTools)
+	r.registerGroup("versions", r.registerReleaseNotesTools)
+	r.registerGroup("versions", r.registerPrecheckTools)
+	r.registerGroup("versions", r.registerLinkTools)
+	r.registerGroup("versions", r.registerReviewHistoryTools)
+	r.registerGroup("versions", r.registerReleaseTrainTools)
 
 	// Screenshots and previews
-	r.registerScreenshotTools()
+	r.registerGroup("screenshots", r.registerScreenshotTools)
+	r.registerGroup("screenshots", r.registerScreenshotArchiveTools)
+	r.registerGroup("screenshots", r.registerAssetVerificationTools)
+	r.registerGroup("screenshots", r.registerStuckAssetTools)
 
 	// Pre-orders
-	r.registerPreOrderTools()
+	r.registerGroup("preorders", r.registerPreOrderTools)
 
 	// App events
-	r.registerAppEventTools()
+	r.registerGroup("events", r.registerAppEventTools)
 
 	// Analytics
-	r.registerAnalyticsTools()
+	r.registerGroup("analytics", r.registerAnalyticsTools)
 
 	// App clips
-	r.registerAppClipTools()
+	r.registerGroup("appclips", r.registerAppClipTools)
 
 	// Game Center
-	r.registerGameCenterTools()
+	r.registerGroup("gamecenter", r.registerGameCenterTools)
 
 	// Xcode Cloud
-	r.registerXcodeCloudTools()
+	r.registerGroup("xcode_cloud", r.registerXcodeCloudTools)
+	r.registerGroup("xcode_cloud", r.registerCiLogTools)
 
 	// Reports
-	r.registerReportsTools()
+	r.registerGroup("reports", r.registerReportsTools)
+	r.registerGroup("reports", r.registerDigestTools)
 
 	// Encryption
-	r.registerEncryptionTools()
+	r.registerGroup("encryption", r.registerEncryptionTools)
 
 	// Users and roles
-	r.registerUserTools()
+	r.registerGroup("users", r.registerUserTools)
 
 	// Pricing
-	r.registerPricingTools()
+	r.registerGroup("pricing", r.registerPricingTools)
 
 	// Availability
-	r.registerAvailabilityTools()
+	r.registerGroup("pricing", r.registerAvailabilityTools)
 
 	// Age rating and IDFA
-	r.registerAgeRatingTools()
+	r.registerGroup("age_rating", r.registerAgeRatingTools)
 
 	// Beta review and agreements
-	r.registerBetaReviewTools()
+	r.registerGroup("testflight", r.registerBetaReviewTools)
 
 	// Sandbox testers
-	r.registerSandboxTools()
+	r.registerGroup("sandbox", r.registerSandboxTools)
 
 	// Promoted purchases and offer codes
-	r.registerPromotedPurchasesTools()
+	r.registerGroup("iap", r.registerPromotedPurchasesTools)
 
 	// Product pages and experiments
-	r.registerProductPagesTools()
+	r.registerGroup("product_pages", r.registerProductPagesTools)
 
 	// Diagnostics and metrics
-	r.registerDiagnosticsTools()
+	r.registerGroup("diagnostics", r.registerDiagnosticsTools)
 
 	// Misc tools (EULA, categories, alternative distribution)
-	r.registerMiscTools()
+	r.registerGroup("misc", r.registerMiscTools)
 
 	return r
 }
 
-// ListTools returns all registered tool definitions.
+// EnableRawAPI registers the asc_api_request passthrough tool. It is not
+// registered by default because it can reach any endpoint the API key can.
+func (r *Registry) EnableRawAPI() {
+	r.registerGroup("raw", r.registerRawAPITools)
+}
+
+// ListTools returns the registered tool definitions, under the names set by
+// SetToolNaming, leaving out tools the selected team's API key was found to
+// lack the role for.
 func (r *Registry) ListTools() []mcp.Tool {
-	return r.tools
+	return r.listedTools()
 }
 
 // CallTool executes a tool by name.
-func (r *Registry) CallTool(name string, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) CallTool(ctx context.Context, name string, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	return r.CallToolWithProgress(ctx, name, args, nil)
+}
+
+// CallToolWithProgress executes a tool by name, forwarding progress reports
+// from tools that support them. Other tools run exactly as with CallTool.
+//
+// Missing required arguments are first asked for, if the context allows
+// eliciting them. The call then waits for room under the concurrency limits,
+// and is bounded by the tool's timeout. If ctx is cancelled, the call stops and ctx's error
+// is returned.
+func (r *Registry) CallToolWithProgress(ctx context.Context, name string, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	name = r.ResolveToolName(name)
 	handler, ok := r.handlers[name]
 	if !ok {
-		return nil, fmt.Errorf("unknown tool: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+
+	if r.teams {
+		team := teamArg(args)
+		if team == "" {
+			team = r.client.ActiveTeam()
+		}
+		if !r.client.HasTeam(team) {
+			return mcp.NewErrorResult(fmt.Sprintf("Unknown team %q. Available teams: %s", team, strings.Join(r.client.Teams(), ", "))), nil
+		}
+		ctx = api.WithTeam(ctx, team)
+	}
+
+	args, declined := r.elicitMissing(ctx, name, args)
+	if declined != nil {
+		return declined, nil
 	}
 
-	return handler(args)
+	format := formatArg(args)
+	switch format {
+	case "", formatText, formatJSON, formatMarkdown, formatSummary:
+	default:
+		return mcp.NewErrorResult(fmt.Sprintf("Unknown format %q. Use %s, %s, %s or %s.", format, formatText, formatJSON, formatMarkdown, formatSummary)), nil
+	}
+
+	release, err := r.acquireSlots(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	timeout := r.toolTimeout(name)
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := recoverToolPanic(name, func() (*mcp.ToolsCallResult, error) {
+		if refused := r.checkAppOperationPolicy(name, args); refused != nil {
+			return refused, nil
+		}
+		if r.requireConfirmation && isDestructiveTool(name) && !isConfirmed(args) {
+			return previewToolCall(callCtx, r.publicName(name), handler, args)
+		}
+		if progressHandler, ok := r.progressHandlers[name]; ok && progress != nil {
+			return progressHandler(callCtx, args, progress)
+		}
+		return handler(callCtx, args)
+	})
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return withErrorClass(mcp.NewErrorResult(fmt.Sprintf("%s timed out after %s", name, timeout))), nil
+	}
+	if r.enterprise {
+		result = withEnterpriseHint(result)
+	}
+	result = withErrorClass(result)
+	result = renderResult(result, format)
+	result = r.labelEnums(name, result, format)
+	result = r.truncateResult(name, result)
+	result = r.withRateLimit(ctx, result)
+
+	return result, err
+}
+
+// withRateLimit reports the API key's remaining hourly budget in the result's
+// _meta, so clients can slow down before requests start failing with 429.
+func (r *Registry) withRateLimit(ctx context.Context, result *mcp.ToolsCallResult) *mcp.ToolsCallResult {
+	if result == nil || r.client == nil {
+		return result
+	}
+	rateLimit := r.client.RateLimit(ctx)
+	if rateLimit == nil {
+		return result
+	}
+
+	if result.Meta == nil {
+		result.Meta = make(map[string]any)
+	}
+	result.Meta[mcp.MetaRateLimit] = rateLimit
+	return result
+}
+
+// recoverToolPanic runs a tool call, turning a panic into an error result so
+// an unexpected API payload fails one call instead of the whole server.
+func recoverToolPanic(name string, call func() (*mcp.ToolsCallResult, error)) (result *mcp.ToolsCallResult, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			result, err = mcp.NewErrorResult(fmt.Sprintf("%s failed unexpectedly: %v", name, p)), nil
+		}
+	}()
+	return call()
 }
 
 // register adds a tool to the registry.
 func (r *Registry) register(tool mcp.Tool, handler ToolHandler) {
+	if r.requireConfirmation && isDestructiveTool(tool.Name) {
+		addProperty(&tool, "confirm", confirmProperty)
+	}
+	if r.teams {
+		addProperty(&tool, "team", r.teamProperty())
+	}
+	if tool.OutputSchema != nil {
+		addProperty(&tool, "format", formatProperty)
+	}
+	if tool.Annotations == nil {
+		tool.Annotations = toolAnnotations(tool.Name)
+	}
+	if r.group != "" {
+		r.groups[tool.Name] = r.group
+	}
 	r.tools = append(r.tools, tool)
 	r.handlers[tool.Name] = handler
 }
+
+// registerGroup runs a tool group's registration, recording the group of each
+// tool it adds for grouped tool names.
+func (r *Registry) registerGroup(group string, registerTools func()) {
+	r.group = group
+	defer func() { r.group = "" }()
+	registerTools()
+}
+
+// addProperty adds an argument to a tool's input schema. The properties map is
+// copied, since tool definitions may share it.
+func addProperty(tool *mcp.Tool, name string, prop mcp.Property) {
+	properties := make(map[string]mcp.Property, len(tool.InputSchema.Properties)+1)
+	for key, existing := range tool.InputSchema.Properties {
+		properties[key] = existing
+	}
+	properties[name] = prop
+	tool.InputSchema.Properties = properties
+}
+
+// registerWithProgress adds a progress-reporting tool to the registry.
+// When called without a progress sink, reports are discarded.
+func (r *Registry) registerWithProgress(tool mcp.Tool, handler ProgressToolHandler) {
+	r.register(tool, func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+		return handler(ctx, args, func(float64, float64, string) {})
+	})
+	r.progressHandlers[tool.Name] = handler
+}
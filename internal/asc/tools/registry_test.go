@@ -6,57 +6,66 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/antisynthesis/asc-mcp/asctest"
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/snapshots"
 )
 
-// testClient creates a test API client with a mock server.
-func testClient(t *testing.T, handler http.Handler) *api.Client {
-	t.Helper()
-
-	server := httptest.NewServer(handler)
-	t.Cleanup(server.Close)
+// testKeyPEM creates a PEM-encoded private key to sign test tokens with.
+func testKeyPEM(tb testing.TB) []byte {
+	tb.Helper()
 
-	// Create test key
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
-		t.Fatalf("failed to generate key: %v", err)
+		tb.Fatalf("failed to generate key: %v", err)
 	}
-
 	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
-		t.Fatalf("failed to marshal key: %v", err)
-	}
-
-	pemBlock := &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: keyBytes,
+		tb.Fatalf("failed to marshal key: %v", err)
 	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+}
 
-	tmpDir := t.TempDir()
-	keyPath := filepath.Join(tmpDir, "test_key.p8")
-	if err := os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600); err != nil {
-		t.Fatalf("failed to write key: %v", err)
-	}
+// testTokens creates a token provider that signs with a new test key.
+func testTokens(tb testing.TB) *api.TokenProvider {
+	tb.Helper()
 
-	client, err := api.NewClient("test-issuer", "TESTKEY123", keyPath)
+	tokens, err := api.NewTokenProviderFromKey("test-issuer", "TESTKEY123", testKeyPEM(tb))
 	if err != nil {
-		t.Fatalf("failed to create client: %v", err)
+		tb.Fatalf("failed to create token provider: %v", err)
 	}
+	return tokens
+}
 
-	// Use reflection or a test helper to set the baseURL
-	// Since we can't access private fields, we'll create a wrapper
-	return client
+// testClient creates a test API client whose requests go to a mock server
+// running handler.
+func testClient(t *testing.T, handler http.Handler) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return api.NewClientWithTokenProvider(testTokens(t), api.WithBaseURL(server.URL))
 }
 
 // mockHandler creates a simple mock HTTP handler.
@@ -121,9 +130,9 @@ func TestRegistry_ListTools(t *testing.T) {
 
 	tools := registry.ListTools()
 
-	// Should have 200 tools total
-	if len(tools) != 200 {
-		t.Errorf("expected 200 tools, got %d", len(tools))
+	// Should have 239 tools total
+	if len(tools) != 239 {
+		t.Errorf("expected 239 tools, got %d", len(tools))
 	}
 
 	// Verify tool structure
@@ -133,16 +142,22 @@ func TestRegistry_ListTools(t *testing.T) {
 		"get_app":          false,
 		"get_app_versions": false,
 		// Build tools
-		"list_builds": false,
-		"get_build":   false,
+		"list_builds":               false,
+		"get_build":                 false,
+		"wait_for_build_processing": false,
+		"expire_old_builds":         false,
+		"build_overview":            false,
 		// TestFlight tools
-		"list_beta_groups":    false,
-		"create_beta_group":   false,
-		"delete_beta_group":   false,
-		"list_beta_testers":   false,
-		"invite_beta_tester":  false,
-		"remove_beta_tester":  false,
-		"add_tester_to_group": false,
+		"list_beta_groups":         false,
+		"create_beta_group":        false,
+		"delete_beta_group":        false,
+		"list_beta_group_builds":   false,
+		"get_beta_group_overview":  false,
+		"list_beta_testers":        false,
+		"invite_beta_tester":       false,
+		"remove_beta_tester":       false,
+		"remove_tester_everywhere": false,
+		"add_tester_to_group":      false,
 		// Provisioning tools
 		"list_bundle_ids":   false,
 		"get_bundle_id":     false,
@@ -150,6 +165,21 @@ func TestRegistry_ListTools(t *testing.T) {
 		"list_profiles":     false,
 		"list_devices":      false,
 		"register_device":   false,
+		// Search and status tools
+		"search":                  false,
+		"asc_status":              false,
+		"get_result_continuation": false,
+		"asc_batch_get":           false,
+		"list_app_groups":         false,
+		"rotate_credentials":      false,
+		// Release notes and pre-check tools
+		"get_release_notes_context": false,
+		"check_app_store_metadata":  false,
+		"check_metadata_urls":       false,
+		"get_review_turnaround":     false,
+		"get_review_estimate":       false,
+		"run_release_train":         false,
+		"get_release_train":         false,
 		// App Info Localization tools
 		"get_app_infos":                false,
 		"list_app_info_localizations":  false,
@@ -163,9 +193,12 @@ func TestRegistry_ListTools(t *testing.T) {
 		"create_version_localization": false,
 		"update_version_localization": false,
 		"delete_version_localization": false,
+		"apply_metadata_template":     false,
+		"get_locale_coverage":         false,
 		// Customer Reviews tools
 		"list_customer_reviews":           false,
 		"get_customer_review":             false,
+		"get_customer_reviews":            false,
 		"create_customer_review_response": false,
 		"delete_customer_review_response": false,
 		// In-App Purchase tools
@@ -194,34 +227,42 @@ func TestRegistry_ListTools(t *testing.T) {
 		"create_phased_release": false,
 		"update_phased_release": false,
 		"delete_phased_release": false,
+		// Release strategy tools
+		"change_release_strategy": false,
 		// Screenshot tools
-		"list_screenshot_sets": false,
-		"list_screenshots":     false,
-		"get_screenshot":       false,
-		"delete_screenshot":    false,
-		"list_preview_sets":    false,
-		"list_previews":        false,
-		"get_preview":          false,
-		"delete_preview":       false,
+		"list_screenshot_sets":        false,
+		"list_screenshots":            false,
+		"get_screenshot":              false,
+		"delete_screenshot":           false,
+		"list_preview_sets":           false,
+		"list_previews":               false,
+		"get_preview":                 false,
+		"delete_preview":              false,
+		"download_screenshot_archive": false,
+		"verify_asset_upload":         false,
+		"delete_stuck_assets":         false,
 		// Pre-Order tools
 		"get_pre_order":    false,
 		"create_pre_order": false,
 		"update_pre_order": false,
 		"delete_pre_order": false,
 		// App Event tools
-		"list_app_events":  false,
-		"get_app_event":    false,
-		"create_app_event": false,
-		"update_app_event": false,
-		"delete_app_event": false,
+		"list_app_events":   false,
+		"get_app_event":     false,
+		"create_app_event":  false,
+		"update_app_event":  false,
+		"delete_app_event":  false,
+		"submit_app_event":  false,
+		"archive_app_event": false,
 		// Analytics tools
-		"list_analytics_report_requests":  false,
-		"get_analytics_report_request":    false,
-		"create_analytics_report_request": false,
-		"delete_analytics_report_request": false,
-		"list_analytics_reports":          false,
-		"list_analytics_report_instances": false,
-		"list_analytics_report_segments":  false,
+		"list_analytics_report_requests":      false,
+		"get_analytics_report_request":        false,
+		"create_analytics_report_request":     false,
+		"delete_analytics_report_request":     false,
+		"list_analytics_reports":              false,
+		"list_analytics_report_instances":     false,
+		"list_analytics_report_segments":      false,
+		"wait_for_analytics_report_instances": false,
 		// App Clip tools
 		"list_app_clips":                     false,
 		"get_app_clip":                       false,
@@ -242,17 +283,23 @@ func TestRegistry_ListTools(t *testing.T) {
 		"update_game_center_leaderboard": false,
 		"delete_game_center_leaderboard": false,
 		// Xcode Cloud tools
-		"list_ci_products":    false,
-		"get_ci_product":      false,
-		"list_ci_workflows":   false,
-		"get_ci_workflow":     false,
-		"list_ci_build_runs":  false,
-		"get_ci_build_run":    false,
-		"start_ci_build_run":  false,
-		"cancel_ci_build_run": false,
+		"list_ci_products":               false,
+		"get_ci_product":                 false,
+		"list_ci_workflows":              false,
+		"get_ci_workflow":                false,
+		"list_ci_build_runs":             false,
+		"get_ci_build_run":               false,
+		"start_ci_build_run":             false,
+		"cancel_ci_build_run":            false,
+		"retry_build_run":                false,
+		"get_ci_workflow_duration_stats": false,
+		// Xcode Cloud log tools
+		"list_ci_build_actions": false,
+		"get_ci_build_failures": false,
 		// Reports tools
 		"get_sales_report":   false,
 		"get_finance_report": false,
+		"generate_digest":    false,
 		// Encryption tools
 		"list_encryption_declarations":           false,
 		"get_encryption_declaration":             false,
@@ -284,23 +331,25 @@ func TestRegistry_ListTools(t *testing.T) {
 		"update_idfa_declaration":       false,
 		"delete_idfa_declaration":       false,
 		// Beta Review and Agreements tools
-		"list_beta_app_review_submissions":  false,
-		"get_beta_app_review_submission":    false,
-		"create_beta_app_review_submission": false,
-		"get_beta_license_agreement":        false,
-		"update_beta_license_agreement":     false,
-		"list_beta_app_localizations":       false,
-		"get_beta_app_localization":         false,
-		"create_beta_app_localization":      false,
-		"update_beta_app_localization":      false,
-		"delete_beta_app_localization":      false,
-		"list_beta_build_localizations":     false,
-		"get_beta_build_localization":       false,
-		"create_beta_build_localization":    false,
-		"update_beta_build_localization":    false,
-		"delete_beta_build_localization":    false,
-		"get_build_beta_detail":             false,
-		"update_build_beta_detail":          false,
+		"list_beta_app_review_submissions":    false,
+		"get_beta_app_review_submission":      false,
+		"create_beta_app_review_submission":   false,
+		"get_build_beta_review_status":        false,
+		"withdraw_beta_app_review_submission": false,
+		"get_beta_license_agreement":          false,
+		"update_beta_license_agreement":       false,
+		"list_beta_app_localizations":         false,
+		"get_beta_app_localization":           false,
+		"create_beta_app_localization":        false,
+		"update_beta_app_localization":        false,
+		"delete_beta_app_localization":        false,
+		"list_beta_build_localizations":       false,
+		"get_beta_build_localization":         false,
+		"create_beta_build_localization":      false,
+		"update_beta_build_localization":      false,
+		"delete_beta_build_localization":      false,
+		"get_build_beta_detail":               false,
+		"update_build_beta_detail":            false,
 		// Sandbox Testers tools
 		"list_sandbox_testers":   false,
 		"create_sandbox_tester":  false,
@@ -316,6 +365,9 @@ func TestRegistry_ListTools(t *testing.T) {
 		"get_subscription_offer_code":  false,
 		"create_subscription_offer_code": false,
 		"update_subscription_offer_code": false,
+		"list_subscription_offer_code_custom_codes":      false,
+		"create_subscription_offer_code_custom_code":     false,
+		"deactivate_subscription_offer_code_custom_code": false,
 		"list_win_back_offers":         false,
 		"get_win_back_offer":           false,
 		"create_win_back_offer":        false,
@@ -390,6 +442,41 @@ func TestRegistry_ListTools(t *testing.T) {
 	}
 }
 
+func TestRegistry_MockServer(t *testing.T) {
+	server := asctest.NewServer()
+	defer server.Close()
+	tokens, err := api.NewTokenProviderFromKey(asctest.IssuerID, asctest.KeyID, asctest.NewPrivateKeyPEM())
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+	registry := NewRegistry(api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL)))
+
+	tests := []struct {
+		tool string
+		args string
+		want []string
+	}{
+		{"list_apps", `{}`, []string{"Example", "com.example.pro"}},
+		{"list_builds", `{"app_id": "` + asctest.AppID + `"}`, []string{"103", "PROCESSING", "102"}},
+		{"list_beta_groups", `{"app_id": "` + asctest.AppID + `"}`, []string{"Internal Testers", "Public Beta"}},
+		{"get_app_versions", `{"app_id": "` + asctest.AppID + `"}`, []string{"2.1", "PREPARE_FOR_SUBMISSION", "READY_FOR_SALE"}},
+	}
+	for _, tt := range tests {
+		result, err := registry.CallTool(context.Background(), tt.tool, json.RawMessage(tt.args))
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.tool, err)
+		}
+		if result.IsError {
+			t.Fatalf("%s returned an error: %s", tt.tool, result.Content[0].Text)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(result.Content[0].Text, want) {
+				t.Errorf("%s result missing %q:\n%s", tt.tool, want, result.Content[0].Text)
+			}
+		}
+	}
+}
+
 func TestRegistry_CallTool_UnknownTool(t *testing.T) {
 	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
@@ -402,7 +489,7 @@ func TestRegistry_CallTool_UnknownTool(t *testing.T) {
 	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
 	registry := NewRegistry(client)
 
-	_, err := registry.CallTool("unknown_tool", json.RawMessage(`{}`))
+	_, err := registry.CallTool(context.Background(), "unknown_tool", json.RawMessage(`{}`))
 
 	if err == nil {
 		t.Fatal("expected error for unknown tool")
@@ -436,7 +523,7 @@ func TestRegistry_Register(t *testing.T) {
 		InputSchema: mcp.JSONSchema{Type: "object"},
 	}
 
-	handler := func(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	handler := func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 		return mcp.NewSuccessResult("custom result"), nil
 	}
 
@@ -455,7 +542,7 @@ func TestRegistry_Register(t *testing.T) {
 	}
 
 	// Call the custom tool
-	result, err := registry.CallTool("custom_tool", json.RawMessage(`{}`))
+	result, err := registry.CallTool(context.Background(), "custom_tool", json.RawMessage(`{}`))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -526,86 +613,3357 @@ func TestToolInputSchemas(t *testing.T) {
 	}
 }
 
-// Integration-style tests with mock HTTP server
-
-func TestHandleListApps_Integration(t *testing.T) {
-	// This test requires a mock server - skipping for unit tests
-	// as it requires setting private baseURL field
-	t.Skip("requires mock server integration")
-}
-
-// Benchmarks
-
-func BenchmarkRegistry_ListTools(b *testing.B) {
+func TestToolOutputSchemas(t *testing.T) {
 	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
 	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
 
-	tmpDir := b.TempDir()
+	tmpDir := t.TempDir()
 	keyPath := filepath.Join(tmpDir, "test_key.p8")
 	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
 
 	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
 	registry := NewRegistry(client)
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = registry.ListTools()
+	// Each tool maps to the attribute clients are expected to read from its structured output.
+	expected := map[string]struct {
+		list      string
+		attribute string
+	}{
+		"list_apps":               {list: "apps", attribute: "bundleId"},
+		"get_app":                 {attribute: "bundleId"},
+		"list_builds":             {list: "builds", attribute: "processingState"},
+		"get_build":               {attribute: "processingState"},
+		"list_beta_group_builds":  {list: "builds", attribute: "processingState"},
+		"get_app_versions":        {list: "versions", attribute: "appStoreState"},
+		"list_app_store_versions": {list: "versions", attribute: "appStoreState"},
+		"get_app_store_version":   {attribute: "appStoreState"},
+	}
+
+	for _, tool := range registry.ListTools() {
+		want, ok := expected[tool.Name]
+		if !ok {
+			continue
+		}
+		if tool.OutputSchema == nil {
+			t.Errorf("tool %s should declare an output schema", tool.Name)
+			continue
+		}
+
+		resource := mcp.Property{Properties: tool.OutputSchema.Properties}
+		if want.list != "" {
+			list := tool.OutputSchema.Properties[want.list]
+			if list.Type != "array" || list.Items == nil {
+				t.Errorf("tool %s: %s should be an array", tool.Name, want.list)
+				continue
+			}
+			resource = *list.Items
+		}
+
+		if _, ok := resource.Properties["attributes"].Properties[want.attribute]; !ok {
+			t.Errorf("tool %s: output schema missing attributes.%s", tool.Name, want.attribute)
+		}
 	}
 }
 
-func BenchmarkRegistry_CallTool_Lookup(b *testing.B) {
-	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
-	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+func TestRegistry_EnableRawAPI(t *testing.T) {
+	registry := NewRegistry(nil)
 
-	tmpDir := b.TempDir()
-	keyPath := filepath.Join(tmpDir, "test_key.p8")
-	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
+	hasRawTool := func() bool {
+		for _, tool := range registry.ListTools() {
+			if tool.Name == "asc_api_request" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if hasRawTool() {
+		t.Fatal("asc_api_request should not be registered by default")
+	}
+
+	registry.EnableRawAPI()
+	if !hasRawTool() {
+		t.Error("asc_api_request should be registered after EnableRawAPI")
+	}
+}
+
+func TestRegistry_EnableConfirmation(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.EnableConfirmation()
+
+	for _, tool := range registry.ListTools() {
+		_, hasConfirm := tool.InputSchema.Properties["confirm"]
+		if hasConfirm != isDestructiveTool(tool.Name) {
+			t.Errorf("%s: confirm property = %v, destructive = %v", tool.Name, hasConfirm, isDestructiveTool(tool.Name))
+		}
+	}
+
+	result, err := registry.CallTool(context.Background(), "delete_beta_group", json.RawMessage(`{"beta_group_id": "group-1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("preview should not be an error: %s", result.Content[0].Text)
+	}
+	text := result.Content[0].Text
+	if !strings.Contains(text, "DELETE /v1/betaGroups/group-1") || !strings.Contains(text, `"confirm": true`) {
+		t.Errorf("unexpected preview:\n%s", text)
+	}
+
+	result, err = registry.CallTool(context.Background(), "delete_beta_group", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Error("invalid arguments should return the tool's own error, not a preview")
+	}
+}
+
+func TestRegistry_DestructiveAppOperationPolicy(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.EnableRawAPI()
+	registry.EnableConfirmation()
+
+	refused := []struct {
+		tool string
+		args string
+	}{
+		{"delete_app_store_version", `{"version_id": "v1"}`},
+		{"create_app_availability", `{"app_id": "app1", "territory_ids": []}`},
+		{"asc_api_request", `{"method": "delete", "path": "/v1/appStoreVersions/v1"}`},
+	}
+	for _, tt := range refused {
+		result, err := registry.CallTool(context.Background(), tt.tool, json.RawMessage(tt.args))
+		if err != nil {
+			t.Fatalf("%s: CallTool failed: %v", tt.tool, err)
+		}
+		if !result.IsError || !strings.Contains(result.Content[0].Text, "--allow-destructive-app-operations") {
+			t.Errorf("%s: expected the call to be refused, got %+v", tt.tool, result)
+		}
+	}
+
+	allowed := map[string]bool{
+		`{"app_id": "app1", "territory_ids": ["USA"]}`: false,
+		`{"app_id": "app1"}`:                           true,
+	}
+	for args, want := range allowed {
+		if got := removesAppFromSale(json.RawMessage(args)); got != want {
+			t.Errorf("removesAppFromSale(%s) = %v, want %v", args, got, want)
+		}
+	}
+	raw := map[string]bool{
+		`{"method": "DELETE", "path": "/v1/apps/app1"}`:                          true,
+		`{"method": "DELETE", "path": "/v1/apps/app1/relationships/betaTesters"}`: false,
+		`{"method": "PATCH", "path": "/v1/appStoreVersions/v1"}`:                 false,
+		`{"method": "DELETE", "path": "/v1/betaGroups/g1"}`:                      false,
+	}
+	for args, want := range raw {
+		if got := isDestructiveRawAppRequest(json.RawMessage(args)); got != want {
+			t.Errorf("isDestructiveRawAppRequest(%s) = %v, want %v", args, got, want)
+		}
+	}
+
+	// Once allowed, the call goes on to the confirmation preview.
+	registry.AllowDestructiveAppOperations()
+	result, err := registry.CallTool(context.Background(), "delete_app_store_version", json.RawMessage(`{"version_id": "v1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Content[0].Text, "DELETE /v1/appStoreVersions/v1") {
+		t.Errorf("expected a preview, got %+v", result)
+	}
+}
+
+func TestRegistry_EnableEnterpriseMode(t *testing.T) {
+	registry := NewRegistry(nil)
+	total := len(registry.ListTools())
+	registry.EnableEnterpriseMode()
+
+	if len(registry.ListTools()) >= total {
+		t.Fatalf("expected enterprise mode to hide tools, still have %d of %d", len(registry.ListTools()), total)
+	}
+
+	available := make(map[string]bool)
+	for _, tool := range registry.ListTools() {
+		available[tool.Name] = true
+	}
+	for _, name := range []string{"list_apps", "list_builds", "list_certificates", "list_users", "list_ci_products"} {
+		if !available[name] {
+			t.Errorf("%s should be available in enterprise mode", name)
+		}
+	}
+	for _, name := range []string{"list_app_store_versions", "list_beta_groups", "get_sales_report", "list_in_app_purchases", "expire_old_builds", "remove_tester_everywhere", "build_overview", "delete_stuck_assets", "apply_metadata_template", "get_locale_coverage", "download_screenshot_archive", "verify_asset_upload"} {
+		if available[name] {
+			t.Errorf("%s should be hidden in enterprise mode", name)
+		}
+		if _, err := registry.CallTool(context.Background(), name, nil); err == nil {
+			t.Errorf("%s should not be callable in enterprise mode", name)
+		}
+	}
+}
+
+func TestWithEnterpriseHint(t *testing.T) {
+	forbidden := &api.Error{StatusCode: 403}
+	result := withEnterpriseHint(mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list apps: %v", forbidden), forbidden))
+	if !strings.Contains(result.Content[0].Text, "Enterprise (In-House)") {
+		t.Errorf("expected enterprise hint, got %q", result.Content[0].Text)
+	}
+
+	serverErr := &api.Error{StatusCode: 500}
+	result = withEnterpriseHint(mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list apps: %v", serverErr), serverErr))
+	if strings.Contains(result.Content[0].Text, "Enterprise") {
+		t.Errorf("unexpected enterprise hint on server error: %q", result.Content[0].Text)
+	}
+}
+
+func TestRegistry_EnableTeams(t *testing.T) {
+	client := testClient(t, mockHandler(map[string]any{"data": []any{}}))
+	acme := testTokens(t)
+	client.AddTeam("default", api.Team{TokenProvider: acme})
+	client.AddTeam("acme", api.Team{TokenProvider: acme})
 
-	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
 	registry := NewRegistry(client)
+	registry.EnableTeams()
 
-	// Just benchmark the lookup, not the actual API call
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, exists := registry.handlers["list_apps"]
-		if !exists {
-			b.Fatal("handler not found")
+	for _, tool := range registry.ListTools() {
+		prop, ok := tool.InputSchema.Properties["team"]
+		if !ok {
+			t.Errorf("%s: missing team property", tool.Name)
+			continue
+		}
+		if len(prop.Enum) != 2 {
+			t.Errorf("%s: team enum = %v, want 2 teams", tool.Name, prop.Enum)
 		}
 	}
+
+	result, err := registry.CallTool(context.Background(), "select_team", json.RawMessage(`{"team": "acme"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("select_team failed: %s", result.Content[0].Text)
+	}
+	if client.ActiveTeam() != "acme" {
+		t.Errorf("ActiveTeam() = %q, want acme", client.ActiveTeam())
+	}
+
+	result, err = registry.CallTool(context.Background(), "list_apps", json.RawMessage(`{"team": "contoso"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "Unknown team") {
+		t.Errorf("expected unknown team error, got %+v", result)
+	}
 }
 
-func BenchmarkNewRegistry(b *testing.B) {
-	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
-	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+func TestIsDestructiveTool(t *testing.T) {
+	tests := map[string]bool{
+		"delete_beta_group":                              true,
+		"remove_beta_tester":                             true,
+		"submit_app_for_review":                          true,
+		"cancel_ci_build_run":                            true,
+		"create_beta_app_review_submission":              true,
+		"run_release_train":                              true,
+		"remove_tester_everywhere":                       true,
+		"deactivate_subscription_offer_code_custom_code": true,
+		"archive_app_event":                              true,
+		"create_beta_group":                              false,
+		"list_apps":                                      false,
+		"update_build":                                   false,
+	}
 
-	tmpDir := b.TempDir()
-	keyPath := filepath.Join(tmpDir, "test_key.p8")
-	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
+	for name, want := range tests {
+		if got := isDestructiveTool(name); got != want {
+			t.Errorf("isDestructiveTool(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
 
-	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
+func TestValidateRawAPIPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"/v1/apps", false},
+		{"/v1/apps/123/appStoreVersions", false},
+		{"/v2/inAppPurchases/abc-123", false},
+		{"v1/apps", true},
+		{"/apps", true},
+		{"https://example.com/v1/apps", true},
+		{"@example.com/v1/apps", true},
+		{"/v1/apps?limit=1", true},
+		{"/v1/../v2/apps", true},
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = NewRegistry(client)
+	for _, tt := range tests {
+		err := validateRawAPIPath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateRawAPIPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
 	}
 }
 
-// Helper to create a mock client for tool handler testing
-type mockClient struct {
-	handler func(ctx context.Context, method, path string) ([]byte, error)
+func TestSumPublicLinkUsages(t *testing.T) {
+	usages := []api.BetaPublicLinkUsage{
+		{DataPoints: api.BetaPublicLinkUsageDataPoints{Values: api.BetaPublicLinkUsageValues{ViewCount: 10, AcceptedCount: 4}}},
+		{DataPoints: api.BetaPublicLinkUsageDataPoints{Values: api.BetaPublicLinkUsageValues{ViewCount: 5, AcceptedCount: 1, DidNotMeetCriteriaCount: 2}}},
+	}
+
+	totals := sumPublicLinkUsages(usages)
+	if totals.ViewCount != 15 || totals.AcceptedCount != 5 || totals.DidNotMeetCriteriaCount != 2 {
+		t.Errorf("sumPublicLinkUsages() = %+v, want 15 views, 5 accepted, 2 did not meet criteria", totals)
+	}
 }
 
-// Context timeout test
-func TestToolHandler_ContextTimeout(t *testing.T) {
-	t.Skip("requires mock server integration")
+func TestExtractLogFailures(t *testing.T) {
+	log := strings.Join([]string{
+		"CompileSwift normal arm64 /src/App/View.swift",
+		"/src/App/View.swift:42:17: error: cannot find 'foo' in scope",
+		"/src/App/Model.swift:7: warning: unused variable",
+		"ld: error: symbol(s) not found for architecture arm64",
+		"Test Case '-[AppTests.LoginTests testInvalidPassword]' failed (0.012 seconds).",
+		"Test case 'CartTests.testCheckout()' failed on 'iPhone 15' (0.4 seconds)",
+		"✘ Test parsesEmptyInput() failed after 0.001 seconds with 1 issue.",
+		"Test Case '-[AppTests.LoginTests testValidPassword]' passed (0.010 seconds).",
+	}, "\n")
+
+	failures, err := extractLogFailures(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("extractLogFailures() error = %v", err)
+	}
 
-	// This would test that tool handlers respect context cancellation
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
-	defer cancel()
+	wantErrors := []string{
+		"/src/App/View.swift:42: cannot find 'foo' in scope",
+		"symbol(s) not found for architecture arm64",
+	}
+	if len(failures.Errors) != len(wantErrors) {
+		t.Fatalf("errors = %q, want %q", failures.Errors, wantErrors)
+	}
+	for i, want := range wantErrors {
+		if failures.Errors[i] != want {
+			t.Errorf("errors[%d] = %q, want %q", i, failures.Errors[i], want)
+		}
+	}
 
-	// Would call a tool and verify it respects the timeout
-	_ = ctx
+	wantTests := []string{
+		"AppTests.LoginTests.testInvalidPassword",
+		"CartTests.testCheckout",
+		"parsesEmptyInput",
+	}
+	if len(failures.Tests) != len(wantTests) {
+		t.Fatalf("tests = %q, want %q", failures.Tests, wantTests)
+	}
+	for i, want := range wantTests {
+		if failures.Tests[i] != want {
+			t.Errorf("tests[%d] = %q, want %q", i, failures.Tests[i], want)
+		}
+	}
+}
+
+func TestExtractLogFailures_LongLine(t *testing.T) {
+	log := "/src/App/View.swift:42:17: error: cannot find 'foo' in scope\n" + strings.Repeat("x", 2*1024*1024) + "\n"
+
+	failures, err := extractLogFailures(strings.NewReader(log))
+	if err == nil {
+		t.Fatal("expected an error for a line over the scanner limit")
+	}
+	if len(failures.Errors) != 1 {
+		t.Errorf("errors = %q, want the error found before the long line", failures.Errors)
+	}
+}
+
+func TestPercentileDuration(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 10; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Minute)
+	}
+
+	tests := []struct {
+		p    int
+		want time.Duration
+	}{
+		{p: 50, want: 5 * time.Minute},
+		{p: 90, want: 9 * time.Minute},
+		{p: 95, want: 10 * time.Minute},
+		{p: 100, want: 10 * time.Minute},
+		{p: 0, want: 1 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := percentileDuration(sorted, tt.p); got != tt.want {
+			t.Errorf("percentileDuration(p%d) = %s, want %s", tt.p, got, tt.want)
+		}
+	}
+
+	if got := percentileDuration(nil, 50); got != 0 {
+		t.Errorf("percentileDuration(nil) = %s, want 0", got)
+	}
+}
+
+func TestFormatBuildBetaReviewStatus(t *testing.T) {
+	build := api.Build{ID: "build1", Attributes: api.BuildAttributes{Version: "42", ProcessingState: "VALID"}}
+
+	tests := []struct {
+		name       string
+		submission *api.BetaAppReviewSubmission
+		want       string
+	}{
+		{"not submitted", nil, "Approved for external testing: no (build has not been submitted"},
+		{"approved", &api.BetaAppReviewSubmission{ID: "sub1", Attributes: api.BetaAppReviewSubmissionAttributes{BetaReviewState: "APPROVED"}}, "Approved for external testing: yes"},
+		{"rejected", &api.BetaAppReviewSubmission{ID: "sub1", Attributes: api.BetaAppReviewSubmissionAttributes{BetaReviewState: "REJECTED"}}, "Approved for external testing: no (rejected"},
+		{"in review", &api.BetaAppReviewSubmission{ID: "sub1", Attributes: api.BetaAppReviewSubmissionAttributes{BetaReviewState: "IN_REVIEW"}}, "Approved for external testing: not yet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatBuildBetaReviewStatus(build, tt.submission, "")
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("formatBuildBetaReviewStatus() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNextCursor(t *testing.T) {
+	links := api.PagedDocumentLinks{Next: "https://api.appstoreconnect.apple.com/v1/builds?cursor=ABC&limit=50"}
+	got := withNextCursor("Found 50 builds", links)
+	if !strings.Contains(got, "nextCursor: ABC") {
+		t.Errorf("withNextCursor() = %q, want it to contain nextCursor: ABC", got)
+	}
+
+	if got := withNextCursor("Found 3 builds", api.PagedDocumentLinks{}); got != "Found 3 builds" {
+		t.Errorf("withNextCursor() on last page = %q, want text unchanged", got)
+	}
+}
+
+func TestRegistry_CallToolWithProgress(t *testing.T) {
+	registry := &Registry{
+		tools:            make([]mcp.Tool, 0),
+		handlers:         make(map[string]ToolHandler),
+		progressHandlers: make(map[string]ProgressToolHandler),
+	}
+
+	registry.registerWithProgress(mcp.Tool{
+		Name:        "slow_tool",
+		Description: "A tool that reports progress",
+		InputSchema: mcp.JSONSchema{Type: "object"},
+	}, func(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+		progress(1, 2, "halfway")
+		progress(2, 2, "done")
+		return mcp.NewSuccessResult("finished"), nil
+	})
+
+	var reports []string
+	result, err := registry.CallToolWithProgress(context.Background(), "slow_tool", nil, func(progress, total float64, message string) {
+		reports = append(reports, message)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Content[0].Text != "finished" {
+		t.Errorf("result = %q, want finished", result.Content[0].Text)
+	}
+	if len(reports) != 2 {
+		t.Errorf("expected 2 progress reports, got %d", len(reports))
+	}
+
+	// Without a progress sink the tool still runs.
+	if _, err := registry.CallTool(context.Background(), "slow_tool", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRegistry_ToolTimeout(t *testing.T) {
+	registry := NewRegistry(nil)
+
+	tests := map[string]time.Duration{
+		"list_apps":                 listToolTimeout,
+		"get_sales_report":          reportToolTimeout,
+		"wait_for_build_processing": waitToolTimeout,
+		"create_beta_group":         defaultToolTimeout,
+		"list_beta_group_builds":    listToolTimeout,
+		"get_beta_group_overview":   defaultToolTimeout,
+	}
+	for name, want := range tests {
+		if got := registry.toolTimeout(name); got != want {
+			t.Errorf("toolTimeout(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	registry.SetToolTimeouts(map[string]time.Duration{
+		"*":           time.Minute,
+		"list_*":      45 * time.Second,
+		"list_beta_*": 10 * time.Second,
+		"list_builds": 5 * time.Second,
+	})
+
+	overrides := map[string]time.Duration{
+		"list_apps":              45 * time.Second,
+		"list_beta_group_builds": 10 * time.Second,
+		"list_builds":            5 * time.Second,
+		"get_sales_report":       time.Minute,
+	}
+	for name, want := range overrides {
+		if got := registry.toolTimeout(name); got != want {
+			t.Errorf("toolTimeout(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRegistry_CallTool_Timeout(t *testing.T) {
+	registry := &Registry{
+		tools:            make([]mcp.Tool, 0),
+		handlers:         make(map[string]ToolHandler),
+		progressHandlers: make(map[string]ProgressToolHandler),
+		timeouts:         map[string]time.Duration{"blocking_tool": 10 * time.Millisecond},
+	}
+
+	registry.register(mcp.Tool{
+		Name:        "blocking_tool",
+		Description: "A tool that blocks until its context is done",
+		InputSchema: mcp.JSONSchema{Type: "object"},
+	}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+		<-ctx.Done()
+		return mcp.NewErrorResult(ctx.Err().Error()), nil
+	})
+
+	result, err := registry.CallTool(context.Background(), "blocking_tool", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "timed out after 10ms") {
+		t.Errorf("result = %+v, want timeout error", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := registry.CallTool(ctx, "blocking_tool", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRegistry_CallTool_Panic(t *testing.T) {
+	registry := &Registry{
+		tools:            make([]mcp.Tool, 0),
+		handlers:         make(map[string]ToolHandler),
+		progressHandlers: make(map[string]ProgressToolHandler),
+	}
+
+	registry.register(mcp.Tool{
+		Name:        "panicking_tool",
+		Description: "A tool that panics on an unexpected payload",
+		InputSchema: mcp.JSONSchema{Type: "object"},
+	}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+		var builds []api.Build
+		_ = builds[0]
+		return mcp.NewSuccessResult("unreachable"), nil
+	})
+
+	result, err := registry.CallTool(context.Background(), "panicking_tool", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "panicking_tool failed unexpectedly") {
+		t.Errorf("result = %+v, want panic error", result)
+	}
+}
+
+func TestRegistry_Search(t *testing.T) {
+
+	responses := map[string]string{
+		"/v1/apps":       `{"data":[{"type":"apps","id":"1","attributes":{"name":"Calculator","bundleId":"com.example.calc"}},{"type":"apps","id":"2","attributes":{"name":"Weather Pro","bundleId":"com.example.weatherpro"}},{"type":"apps","id":"3","attributes":{"name":"Weather","bundleId":"com.example.weather"}}]}`,
+		"/v1/builds":     `{"data":[{"type":"builds","id":"b1","attributes":{"version":"42"}}]}`,
+		"/v1/betaGroups": `{"data":[{"type":"betaGroups","id":"g1","attributes":{"name":"Weather Beta","isInternalGroup":true}}]}`,
+		"/v1/bundleIds":  `{"data":[{"type":"bundleIds","id":"x1","attributes":{"name":"Weather","identifier":"com.example.weather"}}]}`,
+	}
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"status":"403","code":"FORBIDDEN_ERROR","title":"Forbidden"}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "search", json.RawMessage(`{"query":"my Weather app"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output, ok := result.StructuredContent.(searchOutput)
+	if !ok {
+		t.Fatalf("expected searchOutput, got %T", result.StructuredContent)
+	}
+
+	var got []string
+	for _, m := range output.Matches {
+		got = append(got, m.Type+"/"+m.ID+"/"+m.MatchedField)
+	}
+	want := []string{"apps/3/name", "apps/2/name", "betaGroups/g1/name", "bundleIds/x1/identifier"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+	if len(output.Errors) != 1 || !strings.HasPrefix(output.Errors[0], "betaTesters: ") {
+		t.Errorf("expected a betaTesters error, got %v", output.Errors)
+	}
+
+	result, err = registry.CallTool(context.Background(), "search", json.RawMessage(`{"query":"42","types":["builds"]}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if output := result.StructuredContent.(searchOutput); len(output.Matches) != 1 || output.Matches[0].ID != "b1" {
+		t.Errorf("expected build b1, got %+v", output.Matches)
+	}
+
+	result, err = registry.CallTool(context.Background(), "search", json.RawMessage(`{"query":"weather","types":["sandboxTesters"]}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected an error for an unknown type")
+	}
+}
+
+func TestRegistry_SearchVersionsReviewsAndTypos(t *testing.T) {
+
+	var mu sync.Mutex
+	requests := map[string]int{}
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps":
+			if got := r.URL.Query().Get("include"); got != "appStoreVersions" {
+				t.Errorf("include = %q, want appStoreVersions", got)
+			}
+			w.Write([]byte(`{"data": [
+				{"type": "apps", "id": "1", "attributes": {"name": "Weather"},
+					"relationships": {"appStoreVersions": {"data": [{"type": "appStoreVersions", "id": "v1"}, {"type": "appStoreVersions", "id": "v2"}]}}}
+			], "included": [
+				{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "2.1", "platform": "IOS", "appStoreState": "READY_FOR_SALE"}},
+				{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.2", "platform": "IOS", "appStoreState": "PREPARE_FOR_SUBMISSION"}}
+			]}`))
+		case "/v1/apps/1/customerReviews":
+			w.Write([]byte(`{"data": [
+				{"type": "customerReviews", "id": "r1", "attributes": {"rating": 1, "title": "Crashes on launch", "body": "Since the update it crashes", "reviewerNickname": "sam"}},
+				{"type": "customerReviews", "id": "r2", "attributes": {"rating": 5, "title": "Great", "body": "Accurate forecasts", "reviewerNickname": "alex"}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+	search := func(args string) searchOutput {
+		t.Helper()
+		result, err := registry.CallTool(context.Background(), "search", json.RawMessage(args))
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+		}
+		return result.StructuredContent.(searchOutput)
+	}
+
+	output := search(`{"query": "Weather 2.1", "types": ["versions"]}`)
+	if len(output.Matches) != 1 || output.Matches[0].ID != "v1" || output.Matches[0].MatchedField != "appVersion" {
+		t.Errorf("matches = %+v, want v1 by appVersion", output.Matches)
+	}
+
+	output = search(`{"query": "wether 2.2", "types": ["versions"]}`)
+	if len(output.Matches) != 1 || output.Matches[0].ID != "v2" || !output.Matches[0].Fuzzy {
+		t.Errorf("matches = %+v, want fuzzy v2", output.Matches)
+	}
+	if requests["/v1/apps"] != 1 {
+		t.Errorf("apps listed %d times, want 1 with the search cache", requests["/v1/apps"])
+	}
+
+	result, err := registry.CallTool(context.Background(), "search", json.RawMessage(`{"query": "crash", "types": ["reviews"]}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "app_id") {
+		t.Errorf("expected app_id to be required for reviews, got %+v", result)
+	}
+
+	output = search(`{"query": "crashes", "types": ["reviews"], "app_id": "1"}`)
+	if len(output.Matches) != 1 || output.Matches[0].ID != "r1" || output.Matches[0].MatchedField != "title" {
+		t.Errorf("matches = %+v, want r1 by title", output.Matches)
+	}
+}
+
+func TestRegistry_Status(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		appsStatus    int
+		usersStatus   int
+		authenticated bool
+		admin         *bool
+		text          string
+	}{
+		{name: "admin", appsStatus: http.StatusOK, usersStatus: http.StatusOK, authenticated: true, admin: boolPtr(true), text: "Role: Admin"},
+		{name: "not admin", appsStatus: http.StatusOK, usersStatus: http.StatusForbidden, authenticated: true, admin: boolPtr(false), text: "Role: not Admin"},
+		{name: "revoked", appsStatus: http.StatusUnauthorized, text: "The API rejected the token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3500;")
+				status := tt.appsStatus
+				if r.URL.Path == "/v1/users" {
+					status = tt.usersStatus
+				}
+				w.WriteHeader(status)
+				if status >= 400 {
+					w.Write([]byte(`{"errors": [{"status": "` + strconv.Itoa(status) + `", "title": "Denied", "detail": "Not allowed"}]}`))
+					return
+				}
+				w.Write([]byte(`{"data": []}`))
+			}))
+
+			registry := NewRegistry(client)
+			result, err := registry.CallTool(context.Background(), "asc_status", nil)
+			if err != nil {
+				t.Fatalf("CallTool failed: %v", err)
+			}
+
+			output := result.StructuredContent.(statusOutput)
+			if !output.TokenValid || !output.Reachable || output.KeyID != "TESTKEY123" {
+				t.Errorf("output = %+v", output)
+			}
+			if output.Authenticated != tt.authenticated {
+				t.Errorf("Authenticated = %v, want %v", output.Authenticated, tt.authenticated)
+			}
+			if (output.AdminAccess == nil) != (tt.admin == nil) || (tt.admin != nil && *output.AdminAccess != *tt.admin) {
+				t.Errorf("AdminAccess = %v, want %v", output.AdminAccess, tt.admin)
+			}
+			if output.RateLimit == nil || output.RateLimit.Remaining != 3500 {
+				t.Errorf("RateLimit = %+v", output.RateLimit)
+			}
+			if budget, ok := result.Meta[mcp.MetaRateLimit].(*api.RateLimitStatus); !ok || budget.Remaining != 3500 || budget.Used != 100 {
+				t.Errorf("_meta rateLimit = %+v", result.Meta[mcp.MetaRateLimit])
+			}
+			if !strings.Contains(result.Content[0].Text, tt.text) {
+				t.Errorf("text missing %q:\n%s", tt.text, result.Content[0].Text)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestRegistry_ReleaseNotesContext(t *testing.T) {
+
+	run := func(number int, workflow, sha, message string, pullRequest bool) string {
+		return fmt.Sprintf(`{"type": "ciBuildRuns", "id": "run-%d", "attributes": {"number": %d, "isPullRequestBuild": %t, "sourceCommit": {"commitSha": %q, "message": %q, "author": {"displayName": "Dev"}}}, "relationships": {"workflow": {"data": {"type": "ciWorkflows", "id": %q}}}}`,
+			number, number, pullRequest, sha, message, workflow)
+	}
+	runs := map[string]string{
+		"old": run(10, "wf1", "aaa", "Release 1.0", false),
+		"new": run(14, "wf1", "eee", "Fix crash on launch APP-12", false),
+	}
+	allRuns := strings.Join([]string{
+		run(15, "wf1", "fff", "Later work", false),
+		runs["new"],
+		run(13, "wf2", "ddd", "Nightly", false),
+		run(12, "wf1", "ccc", "PR build", true),
+		run(11, "wf1", "bbb", "Add widget (APP-7, APP-12)", false),
+		runs["old"],
+	}, ",")
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/ciProducts":
+			w.Write([]byte(`{"data": [{"type": "ciProducts", "id": "p1"}]}`))
+		case "/v1/ciProducts/p1/buildRuns":
+			switch r.URL.Query().Get("filter[builds]") {
+			case "build-old":
+				w.Write([]byte(`{"data": [` + runs["old"] + `]}`))
+			case "build-new":
+				w.Write([]byte(`{"data": [` + runs["new"] + `]}`))
+			case "":
+				w.Write([]byte(`{"data": [` + allRuns + `]}`))
+			default:
+				w.Write([]byte(`{"data": []}`))
+			}
+		case "/v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "1.0", "appStoreState": "REPLACED_WITH_NEW_VERSION", "createdDate": "2024-01-01T00:00:00Z"}},
+				{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "1.1", "appStoreState": "READY_FOR_SALE", "createdDate": "2024-03-01T00:00:00Z"}}
+			]}`))
+		case "/v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "l1", "attributes": {"locale": "en-US", "whatsNew": "First release"}}]}`))
+		case "/v1/appStoreVersions/v2/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "l2", "attributes": {"locale": "de-DE", "whatsNew": "Fehlerbehebungen"}}, {"type": "appStoreVersionLocalizations", "id": "l3", "attributes": {"locale": "en-US", "whatsNew": "Bug fixes"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "get_release_notes_context", json.RawMessage(`{"app_id": "app1", "from_build_id": "build-old", "to_build_id": "build-new"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(releaseNotesContextOutput)
+	var shas []string
+	for _, commit := range output.Commits {
+		shas = append(shas, commit.Sha)
+	}
+	if strings.Join(shas, ",") != "eee,bbb" {
+		t.Errorf("commits = %v, want [eee bbb]", shas)
+	}
+	if strings.Join(output.Tickets, ",") != "APP-12,APP-7" {
+		t.Errorf("tickets = %v, want [APP-12 APP-7]", output.Tickets)
+	}
+	if len(output.PreviousWhatsNew) != 2 || output.PreviousWhatsNew[0].Version != "1.1" || output.PreviousWhatsNew[0].Text != "Bug fixes" {
+		t.Errorf("previousWhatsNew = %+v", output.PreviousWhatsNew)
+	}
+
+	result, err = registry.CallTool(context.Background(), "get_release_notes_context", json.RawMessage(`{"app_id": "app1", "from_build_id": "build-new", "to_build_id": "build-old"}`))
+	if err != nil || !result.IsError {
+		t.Errorf("expected an error when from_build_id is newer, got %+v, %v", result, err)
+	}
+
+	result, err = registry.CallTool(context.Background(), "get_release_notes_context", json.RawMessage(`{"app_id": "app1", "from_build_id": "build-local", "to_build_id": "build-new"}`))
+	if err != nil || !result.IsError || !strings.Contains(result.Content[0].Text, "not built by Xcode Cloud") {
+		t.Errorf("expected an error for a build Xcode Cloud didn't produce, got %+v, %v", result, err)
+	}
+}
+
+func TestRegistry_CheckAppStoreMetadata(t *testing.T) {
+
+	// Stands in for the app's website: HEAD isn't allowed and /missing is a 404.
+	var methods []string
+	var mu sync.Mutex
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		switch {
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer site.Close()
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersionLocalizations", "id": "l1", "attributes": {"locale": "en-US", "description": "The best weather app. Also on Android.", "keywords": "weather,forecast,google play", "supportUrl": "` + site.URL + `/support", "marketingUrl": "` + site.URL + `/missing"}},
+				{"type": "appStoreVersionLocalizations", "id": "l2", "attributes": {"locale": "de-DE", "description": "Lorem ipsum dolor sit amet", "keywords": "wetter,androidx"}}
+			]}`))
+		case "/v1/apps/app1/appInfos":
+			w.Write([]byte(`{"data": [
+				{"type": "appInfos", "id": "live", "attributes": {"state": "READY_FOR_DISTRIBUTION"}},
+				{"type": "appInfos", "id": "edit", "attributes": {"state": "PREPARE_FOR_SUBMISSION"}}
+			]}`))
+		case "/v1/appInfos/edit/appInfoLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appInfoLocalizations", "id": "i1", "attributes": {"locale": "en-US", "name": "Weather Beta", "subtitle": "Forecasts", "privacyPolicyUrl": "` + site.URL + `/support"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "check_app_store_metadata", json.RawMessage(`{"app_id": "app1", "version_id": "v1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(metadataCheckOutput)
+	var got []string
+	for _, f := range output.Findings {
+		got = append(got, f.Severity+" "+f.Locale+" "+f.Field)
+	}
+	want := []string{
+		"error de-DE description",
+		"error de-DE supportUrl",
+		"error en-US keywords",
+		"error en-US name",
+		"warning en-US description",
+		"warning en-US marketingUrl",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+	if output.Errors != 4 || output.Warnings != 2 {
+		t.Errorf("errors = %d, warnings = %d", output.Errors, output.Warnings)
+	}
+
+	// Each URL is requested once, retrying with GET after HEAD is refused.
+	sort.Strings(methods)
+	if strings.Join(methods, ",") != "GET /missing,GET /support,HEAD /missing,HEAD /support" {
+		t.Errorf("requests = %v", methods)
+	}
+
+	result, err = registry.CallTool(context.Background(), "check_app_store_metadata", json.RawMessage(`{"app_id": "app1", "version_id": "v1", "check_urls": false, "competitor_terms": ["AccuWeather"]}`))
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool failed: %+v, %v", result, err)
+	}
+	output = result.StructuredContent.(metadataCheckOutput)
+	for _, f := range output.Findings {
+		if f.Field == "marketingUrl" {
+			t.Errorf("URL checked with check_urls false: %+v", f)
+		}
+	}
+}
+
+func TestRegistry_CheckMetadataURLs(t *testing.T) {
+
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/privacy", http.StatusMovedPermanently)
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer site.Close()
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/app1/appInfos":
+			w.Write([]byte(`{"data": [{"type": "appInfos", "id": "info1", "attributes": {"state": "PREPARE_FOR_SUBMISSION"}}]}`))
+		case "/v1/appInfos/info1/appInfoLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appInfoLocalizations", "id": "i1", "attributes": {"locale": "en-US", "privacyPolicyUrl": "` + site.URL + `/old"}}]}`))
+		case "/v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersionLocalizations", "id": "l1", "attributes": {"locale": "en-US", "supportUrl": "` + site.URL + `/support", "marketingUrl": "` + site.URL + `/gone"}},
+				{"type": "appStoreVersionLocalizations", "id": "l2", "attributes": {"locale": "de-DE", "supportUrl": "mailto:support@example.com"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "check_metadata_urls", json.RawMessage(`{"app_id": "app1", "version_id": "v1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(linkCheckOutput)
+	var got []string
+	for _, link := range output.Links {
+		got = append(got, fmt.Sprintf("%s %s %d %t", link.Locale, link.Field, link.StatusCode, link.OK))
+	}
+	want := []string{
+		"de-DE supportUrl 0 false",
+		"en-US marketingUrl 404 false",
+		"en-US privacyPolicyUrl 200 true",
+		"en-US supportUrl 200 true",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("links = %v, want %v", got, want)
+	}
+	if redirects := output.Links[2].Redirects; len(redirects) != 1 || redirects[0] != site.URL+"/privacy" {
+		t.Errorf("redirects = %v", redirects)
+	}
+	if output.Broken != 2 || output.Redirected != 1 {
+		t.Errorf("broken = %d, redirected = %d", output.Broken, output.Redirected)
+	}
+
+	// Without a version only App Info URLs are checked.
+	result, err = registry.CallTool(context.Background(), "check_metadata_urls", json.RawMessage(`{"app_id": "app1"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool failed: %+v, %v", result, err)
+	}
+	if links := result.StructuredContent.(linkCheckOutput).Links; len(links) != 1 || links[0].Field != "privacyPolicyUrl" {
+		t.Errorf("links = %+v", links)
+	}
+}
+
+func TestRegistry_ReviewTurnaround(t *testing.T) {
+
+	// 1.0 was rejected once; 2.0 is in review until the API reports it approved.
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	history := `{"versions": {
+		"v1": {"appId": "app1", "versionId": "v1", "versionString": "1.0", "platform": "IOS", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-05-01T00:00:00Z"},
+			{"state": "IN_REVIEW", "at": "2024-05-02T00:00:00Z"},
+			{"state": "REJECTED", "at": "2024-05-02T06:00:00Z"},
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-05-03T00:00:00Z"},
+			{"state": "IN_REVIEW", "at": "2024-05-03T12:00:00Z"},
+			{"state": "READY_FOR_SALE", "at": "2024-05-03T14:00:00Z"}
+		]},
+		"v2": {"appId": "app1", "versionId": "v2", "versionString": "2.0", "platform": "IOS", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-06-01T00:00:00Z"},
+			{"state": "IN_REVIEW", "at": "2024-06-01T10:00:00Z"}
+		]},
+		"other": {"appId": "app2", "versionId": "other", "versionString": "1.0", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-07-01T00:00:00Z"}
+		]}
+	}}`
+	if err := os.WriteFile(path, []byte(history), 0o600); err != nil {
+		t.Fatalf("failed to write snapshots: %v", err)
+	}
+	store, err := snapshots.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open snapshots: %v", err)
+	}
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [
+			{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.0", "platform": "IOS", "appStoreState": "PENDING_DEVELOPER_RELEASE"}},
+			{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "1.0", "platform": "IOS", "appStoreState": "READY_FOR_SALE"}}
+		]}`))
+	}))
+
+	registry := NewRegistry(client)
+	registry.SetSnapshotStore(store)
+
+	result, err := registry.CallTool(context.Background(), "get_review_turnaround", json.RawMessage(`{"app_id": "app1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(reviewTurnaroundOutput)
+	var got []string
+	for _, review := range output.Reviews {
+		got = append(got, review.VersionString+" "+review.Outcome)
+	}
+	if strings.Join(got, ",") != "2.0 approved,1.0 approved,1.0 rejected" {
+		t.Errorf("reviews = %v", got)
+	}
+	if output.Waiting == nil || output.Waiting.Count != 3 || output.Waiting.MedianHours != 12 || output.Waiting.MinHours != 10 || output.Waiting.MaxHours != 24 {
+		t.Errorf("waiting = %+v", output.Waiting)
+	}
+	if rejected := output.Reviews[2]; rejected.InReviewHours == nil || *rejected.InReviewHours != 6 || *rejected.TotalHours != 30 {
+		t.Errorf("rejected review = %+v", rejected)
+	}
+
+	// The approval seen through the API was recorded.
+	if h := store.VersionHistories("app1")[0]; h.VersionID != "v2" || h.State() != "PENDING_DEVELOPER_RELEASE" {
+		t.Errorf("latest history = %+v", h)
+	}
+}
+
+func TestRegistry_ResultTruncation(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.SetMaxResultBytes(100)
+
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("line %02d", i))
+	}
+	full := strings.Join(lines, "\n")
+	registry.register(mcp.Tool{Name: "big_tool"}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+		return mcp.NewStructuredResult(full, map[string]string{"text": full}), nil
+	})
+
+	tokenPattern := regexp.MustCompile(`continuation_token "([0-9a-f]+)"`)
+	result, err := registry.CallTool(context.Background(), "big_tool", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.StructuredContent != nil {
+		t.Error("oversized structured content should be dropped")
+	}
+
+	var got strings.Builder
+	for parts := 1; ; parts++ {
+		text := result.Content[0].Text
+		match := tokenPattern.FindStringSubmatch(text)
+		if match == nil {
+			got.WriteString(text)
+			break
+		}
+		part, _, _ := strings.Cut(text, "\n\n[Result truncated")
+		if len(part) > 100 {
+			t.Errorf("part %d is %d bytes, want at most 100", parts, len(part))
+		}
+		got.WriteString(part)
+
+		result, err = registry.CallTool(context.Background(), "get_result_continuation", json.RawMessage(`{"continuation_token":"`+match[1]+`"}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+		}
+
+		// A token can only be used once.
+		reused, _ := registry.CallTool(context.Background(), "get_result_continuation", json.RawMessage(`{"continuation_token":"`+match[1]+`"}`))
+		if !reused.IsError {
+			t.Error("expected an error reusing a continuation token")
+		}
+	}
+	if got.String() != full {
+		t.Errorf("reassembled result = %q, want %q", got.String(), full)
+	}
+
+	registry.SetMaxResultBytes(0)
+	result, _ = registry.CallTool(context.Background(), "big_tool", nil)
+	if result.Content[0].Text != full || result.StructuredContent == nil {
+		t.Error("result should be untouched with truncation turned off")
+	}
+}
+
+func TestTruncationPoint(t *testing.T) {
+	tests := []struct {
+		text string
+		max  int
+		want int
+	}{
+		{"abcdef\nghij", 9, 7},
+		{"ab\ncdefghij", 9, 9},
+		{"abcdefgh\u00e9", 9, 8},
+	}
+	for _, tt := range tests {
+		if got := truncationPoint(tt.text, tt.max); got != tt.want {
+			t.Errorf("truncationPoint(%q, %d) = %d, want %d", tt.text, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_ReviewEstimate(t *testing.T) {
+
+	// app1 has three completed reviews taking 10, 20 and 30 hours.
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	history := `{"versions": {
+		"v1": {"appId": "app1", "versionId": "v1", "versionString": "1.0", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-05-01T00:00:00Z"},
+			{"state": "READY_FOR_SALE", "at": "2024-05-01T10:00:00Z"}
+		]},
+		"v2": {"appId": "app1", "versionId": "v2", "versionString": "1.1", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-06-01T00:00:00Z"},
+			{"state": "IN_REVIEW", "at": "2024-06-01T18:00:00Z"},
+			{"state": "READY_FOR_SALE", "at": "2024-06-01T20:00:00Z"}
+		]},
+		"v3": {"appId": "app1", "versionId": "v3", "versionString": "1.2", "transitions": [
+			{"state": "WAITING_FOR_REVIEW", "at": "2024-07-01T00:00:00Z"},
+			{"state": "REJECTED", "at": "2024-07-02T06:00:00Z"}
+		]}
+	}}`
+	if err := os.WriteFile(path, []byte(history), 0o600); err != nil {
+		t.Fatalf("failed to write snapshots: %v", err)
+	}
+	store, err := snapshots.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open snapshots: %v", err)
+	}
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersions", "id": "v4", "attributes": {"versionString": "2.0", "appStoreState": "WAITING_FOR_REVIEW"}}]}`))
+		default:
+			w.Write([]byte(`{"data": []}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+	registry.SetSnapshotStore(store)
+
+	before := time.Now()
+	result, err := registry.CallTool(context.Background(), "get_review_estimate", json.RawMessage(`{"app_id": "app1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(reviewEstimateOutput)
+	if output.Basis != "app" || output.Reviews != 3 || output.VersionID != "v4" || output.State != "WAITING_FOR_REVIEW" {
+		t.Errorf("output = %+v", output)
+	}
+	if output.Total == nil || output.Total.MedianHours != 20 || output.Total.P90Hours != 30 {
+		t.Errorf("total = %+v", output.Total)
+	}
+	expected, err := time.Parse(time.RFC3339, output.ExpectedDecisionAt)
+	if err != nil {
+		t.Fatalf("expectedDecisionAt = %q: %v", output.ExpectedDecisionAt, err)
+	}
+	if d := expected.Sub(before); d < 19*time.Hour || d > 21*time.Hour {
+		t.Errorf("expected decision %s after the call, want about 20h", d)
+	}
+	if output.Overdue {
+		t.Error("a review that just started should not be overdue")
+	}
+
+	// app2 has no history of its own, so every recorded app is used.
+	result, err = registry.CallTool(context.Background(), "get_review_estimate", json.RawMessage(`{"app_id": "app2"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output = result.StructuredContent.(reviewEstimateOutput)
+	if output.Basis != "account" || output.Reviews != 3 || output.VersionID != "" {
+		t.Errorf("fallback output = %+v", output)
+	}
+
+	result, _ = registry.CallTool(context.Background(), "get_review_estimate", json.RawMessage(`{"app_id": "app1", "version_id": "v1"}`))
+	if !result.IsError {
+		t.Error("expected an error for a version that is not under review")
+	}
+}
+
+func TestRegistry_ReleaseTrain(t *testing.T) {
+
+	// app1 already has version 2.0; app2's release notes fail on the first run.
+	var mu sync.Mutex
+	var requests []string
+	notesFailures := 1
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "2.0", "platform": "IOS", "appStoreState": "PREPARE_FOR_SUBMISSION"}}]}`))
+		case "GET /v1/apps/app2/appStoreVersions":
+			w.Write([]byte(`{"data": []}`))
+		case "POST /v1/appStoreVersions":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.0", "platform": "IOS"}}}`))
+		case "GET /v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "loc1", "attributes": {"locale": "en-US"}}]}`))
+		case "GET /v1/appStoreVersions/v2/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "loc2", "attributes": {"locale": "de-DE"}}]}`))
+		case "PATCH /v1/appStoreVersionLocalizations/loc1", "PATCH /v1/appStoreVersionLocalizations/loc2":
+			if r.URL.Path == "/v1/appStoreVersionLocalizations/loc2" && notesFailures > 0 {
+				notesFailures--
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"errors": [{"status": "409", "title": "Conflict"}]}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"type": "appStoreVersionLocalizations", "id": "loc", "attributes": {}}}`))
+		case "POST /v1/appStoreVersionSubmissions":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"type": "appStoreVersionSubmissions", "id": "sub"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "run_release_train", json.RawMessage(`{
+		"train": "spring", "app_ids": ["app1", "app2"], "version_string": "2.0",
+		"whats_new": "Bug fixes", "whats_new_by_locale": {"de-DE": "Fehlerbehebungen"}, "submit": true
+	}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	status := func(output releaseTrainOutput) string {
+		var parts []string
+		for _, app := range output.Apps {
+			for _, step := range app.Steps {
+				parts = append(parts, app.AppID+":"+step.Step+"="+step.Status)
+			}
+		}
+		return strings.Join(parts, ",")
+	}
+
+	output := result.StructuredContent.(releaseTrainOutput)
+	want := "app1:create_version=done,app1:release_notes=done,app1:submit=done," +
+		"app2:create_version=done,app2:release_notes=failed,app2:submit=skipped"
+	if got := status(output); got != want {
+		t.Errorf("first run = %s, want %s", got, want)
+	}
+	if output.Failed != 1 || output.Apps[1].VersionID != "v2" {
+		t.Errorf("first run output = %+v", output)
+	}
+
+	// Resuming with just the train retries app2's release notes and submits it.
+	mu.Lock()
+	requests = nil
+	mu.Unlock()
+	result, err = registry.CallTool(context.Background(), "run_release_train", json.RawMessage(`{"train": "spring"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output = result.StructuredContent.(releaseTrainOutput)
+	if got := status(output); !strings.HasSuffix(got, "app2:release_notes=done,app2:submit=done") || output.Failed != 0 {
+		t.Errorf("resumed run = %s", got)
+	}
+	want = "GET /v1/appStoreVersions/v2/appStoreVersionLocalizations,PATCH /v1/appStoreVersionLocalizations/loc2,POST /v1/appStoreVersionSubmissions"
+	if got := strings.Join(requests, ","); got != want {
+		t.Errorf("resumed requests = %s, want %s", got, want)
+	}
+
+	result, _ = registry.CallTool(context.Background(), "get_release_train", json.RawMessage(`{"train": "spring"}`))
+	if output := result.StructuredContent.(releaseTrainOutput); output.Succeeded != 2 {
+		t.Errorf("get_release_train = %+v", output)
+	}
+
+	result, _ = registry.CallTool(context.Background(), "run_release_train", json.RawMessage(`{"train": "spring", "version_string": "3.0"}`))
+	if !result.IsError {
+		t.Error("expected an error resuming a train with another version")
+	}
+}
+
+func TestRegistry_ExpandAppIDs(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.SetAppGroups(map[string][]string{
+		"flagship": {"123", "456"},
+		"clients":  {"456", "789"},
+	})
+
+	got := registry.expandAppIDs([]string{"flagship", "111", "clients", "123"})
+	if want := "123,456,111,789"; strings.Join(got, ",") != want {
+		t.Errorf("expandAppIDs = %v, want %s", got, want)
+	}
+
+	result, err := registry.CallTool(context.Background(), "list_app_groups", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(appGroupsOutput)
+	if len(output.Groups) != 2 || output.Groups[0].Name != "clients" || output.Groups[1].Name != "flagship" {
+		t.Errorf("list_app_groups = %+v", output)
+	}
+}
+
+func TestRenderResult(t *testing.T) {
+	output := appsOutput{
+		Apps: []linkedApp{
+			{Type: "apps", ID: "1", Attributes: api.AppAttributes{Name: "Weather | Pro", BundleID: "com.example.weather"}},
+			{Type: "apps", ID: "2", Attributes: api.AppAttributes{Name: "Notes", BundleID: "com.example.notes"}},
+		},
+		NextCursor: "abc",
+	}
+	result := mcp.NewStructuredResult("Found 2 apps", output)
+
+	if got := renderResult(result, formatText); got != result {
+		t.Error("text format should return the result unchanged")
+	}
+
+	markdown := renderResult(result, formatMarkdown).Content[0].Text
+	for _, want := range []string{"nextCursor: abc", "| id | name | bundleId |", "| 1 | Weather \\| Pro | com.example.weather |"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+
+	summary := renderResult(result, formatSummary).Content[0].Text
+	if want := "2 apps\n- Weather \\| Pro (1)\n- Notes (2)\nnextCursor: abc\n"; summary != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+
+	jsonText := renderResult(result, formatJSON).Content[0].Text
+	var decoded appsOutput
+	if err := json.Unmarshal([]byte(jsonText), &decoded); err != nil || len(decoded.Apps) != 2 {
+		t.Errorf("json = %s (%v)", jsonText, err)
+	}
+	if result.Content[0].Text != "Found 2 apps" {
+		t.Error("rendering modified the original result")
+	}
+
+	single := renderResult(mcp.NewStructuredResult("", output.Apps[1]), formatMarkdown).Content[0].Text
+	if !strings.Contains(single, "| name | Notes |") {
+		t.Errorf("single resource markdown = %s", single)
+	}
+}
+
+func TestRegistry_ApplyMetadataTemplate(t *testing.T) {
+
+	// app1 has an editable version; app2 is missing the brand variable.
+	var mu sync.Mutex
+	var patched []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/apps/app1":
+			w.Write([]byte(`{"data": {"type": "apps", "id": "app1", "attributes": {"name": "Acme Notes", "bundleId": "com.acme.notes"}}}`))
+		case "GET /v1/apps/app2":
+			w.Write([]byte(`{"data": {"type": "apps", "id": "app2", "attributes": {"name": "Globex Notes"}}}`))
+		case "GET /v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersions", "id": "live", "attributes": {"platform": "IOS", "appStoreState": "READY_FOR_SALE"}},
+				{"type": "appStoreVersions", "id": "v1", "attributes": {"platform": "IOS", "appStoreState": "PREPARE_FOR_SUBMISSION"}}
+			]}`))
+		case "GET /v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "loc1", "attributes": {"locale": "en-US"}}]}`))
+		case "PATCH /v1/appStoreVersionLocalizations/loc1":
+			body, _ := io.ReadAll(r.Body)
+			patched = append(patched, string(body))
+			w.Write([]byte(`{"data": {"type": "appStoreVersionLocalizations", "id": "loc1", "attributes": {}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+	registry.SetAppGroups(map[string][]string{"clients": {"app1", "app2"}})
+
+	result, err := registry.CallTool(context.Background(), "apply_metadata_template", json.RawMessage(`{
+		"app_ids": ["clients"], "locale": "en-US",
+		"description": "{{.brand}} by {{.company}} ({{.bundle_id}})", "keywords": "{{.brand}},notes",
+		"variables": {"company": "Acme"}, "app_variables": {"app1": {"brand": "Notes Pro"}}
+	}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	output := result.StructuredContent.(metadataTemplateOutput)
+	if output.Succeeded != 1 || output.Failed != 1 {
+		t.Fatalf("output = %+v", output)
+	}
+	if got := output.Apps[0].Fields["description"]; got != "Notes Pro by Acme (com.acme.notes)" {
+		t.Errorf("app1 description = %q", got)
+	}
+	if !output.Apps[0].Updated || output.Apps[0].VersionID != "v1" {
+		t.Errorf("app1 = %+v", output.Apps[0])
+	}
+	if output.Apps[1].Updated || !strings.Contains(output.Apps[1].Error, "brand") {
+		t.Errorf("app2 = %+v", output.Apps[1])
+	}
+	if len(patched) != 1 || !strings.Contains(patched[0], `"keywords":"Notes Pro,notes"`) {
+		t.Errorf("patched = %v", patched)
+	}
+
+	// A dry run renders without updating.
+	patched = nil
+	result, _ = registry.CallTool(context.Background(), "apply_metadata_template", json.RawMessage(`{
+		"app_ids": ["app1"], "locale": "en-US", "keywords": "{{.app_name}}", "dry_run": true
+	}`))
+	output = result.StructuredContent.(metadataTemplateOutput)
+	if output.Apps[0].Fields["keywords"] != "Acme Notes" || output.Apps[0].Updated || len(patched) != 0 {
+		t.Errorf("dry run output = %+v, patched = %v", output, patched)
+	}
+}
+
+func TestRegistry_ElicitMissing(t *testing.T) {
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps":
+			w.Write([]byte(`{"data": [{"type": "apps", "id": "123", "attributes": {"name": "Weather", "bundleId": "com.example.weather"}}]}`))
+		case "/v1/apps/123":
+			w.Write([]byte(`{"data": {"type": "apps", "id": "123", "attributes": {"name": "Weather"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	var asked mcp.JSONSchema
+	accept := WithElicitation(context.Background(), func(ctx context.Context, message string, schema mcp.JSONSchema) (*mcp.ElicitResult, error) {
+		asked = schema
+		return &mcp.ElicitResult{Action: mcp.ElicitActionAccept, Content: map[string]any{"app_id": "123"}}, nil
+	})
+	result, err := registry.CallTool(accept, "get_app", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Content[0].Text, "Weather") {
+		t.Errorf("result = %+v", result)
+	}
+	prop := asked.Properties["app_id"]
+	if len(asked.Required) != 1 || len(prop.Enum) != 1 || prop.Enum[0] != "123" || prop.EnumNames[0] != "Weather (com.example.weather)" {
+		t.Errorf("asked schema = %+v", asked)
+	}
+
+	decline := WithElicitation(context.Background(), func(ctx context.Context, message string, schema mcp.JSONSchema) (*mcp.ElicitResult, error) {
+		return &mcp.ElicitResult{Action: mcp.ElicitActionDecline}, nil
+	})
+	result, _ = registry.CallTool(decline, "get_app", nil)
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "app_id was not provided") {
+		t.Errorf("declined result = %+v", result)
+	}
+
+	// Without elicitation the tool reports the missing argument itself.
+	result, _ = registry.CallTool(context.Background(), "get_app", json.RawMessage(`{}`))
+	if !result.IsError || result.Content[0].Text != "app_id is required" {
+		t.Errorf("result without elicitation = %+v", result)
+	}
+}
+
+func TestRegistry_LocaleCoverage(t *testing.T) {
+
+	// Alpha is fully localized in en-US and has a German name but no German
+	// description. Beta only has en-US.
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps":
+			w.Write([]byte(`{"data": [
+				{"type": "apps", "id": "a", "attributes": {"name": "Alpha"}},
+				{"type": "apps", "id": "b", "attributes": {"name": "Beta"}}
+			]}`))
+		case "/v1/apps/a/appInfos", "/v1/apps/b/appInfos":
+			id := strings.Split(r.URL.Path, "/")[3]
+			w.Write([]byte(`{"data": [{"type": "appInfos", "id": "info-` + id + `", "attributes": {}}]}`))
+		case "/v1/appInfos/info-a/appInfoLocalizations":
+			w.Write([]byte(`{"data": [
+				{"type": "appInfoLocalizations", "id": "1", "attributes": {"locale": "en-US", "name": "Alpha"}},
+				{"type": "appInfoLocalizations", "id": "2", "attributes": {"locale": "de-DE", "name": "Alpha"}}
+			]}`))
+		case "/v1/appInfos/info-b/appInfoLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appInfoLocalizations", "id": "3", "attributes": {"locale": "en-US", "name": "Beta"}}]}`))
+		case "/v1/apps/a/appStoreVersions", "/v1/apps/b/appStoreVersions":
+			id := strings.Split(r.URL.Path, "/")[3]
+			w.Write([]byte(`{"data": [{"type": "appStoreVersions", "id": "v-` + id + `", "attributes": {"versionString": "1.0"}}]}`))
+		case "/v1/appStoreVersions/v-a/appStoreVersionLocalizations", "/v1/appStoreVersions/v-b/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersionLocalizations", "id": "4", "attributes": {"locale": "en-US", "description": "An app"}},
+				{"type": "appStoreVersionLocalizations", "id": "5", "attributes": {"locale": "de-DE", "description": ""}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "get_locale_coverage", json.RawMessage(`{"target_locales": ["en-US", "de-DE"]}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(localeCoverageOutput)
+
+	if output.Locales[0].Covered != 2 || output.Locales[1].Missing != 2 {
+		t.Errorf("locales = %+v", output.Locales)
+	}
+	var backlog []string
+	for _, item := range output.Backlog {
+		backlog = append(backlog, fmt.Sprintf("%d:%s:%s:%s", item.Priority, item.Locale, item.AppName, strings.Join(item.Missing, "+")))
+	}
+	if got, want := strings.Join(backlog, ","), "1:de-DE:Beta:appInfo+version,2:de-DE:Alpha:version"; got != want {
+		t.Errorf("backlog = %s, want %s", got, want)
+	}
+	if len(output.Apps[0].Partial) != 1 || output.Apps[0].Partial[0] != "de-DE" {
+		t.Errorf("Alpha = %+v", output.Apps[0])
+	}
+}
+
+func TestRegistry_GenerateDigest(t *testing.T) {
+
+	now := time.Now().UTC()
+	daysAgo := func(days int) string { return now.AddDate(0, 0, -days).Format(time.RFC3339) }
+	week := lastSalesWeek(now).Format("2006-01-02")
+
+	// Two reviews and one build are from this week, one of each is older.
+	// Version 2.0 went to review; last week sold 7 units against 4.
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/app-1/customerReviews":
+			w.Write([]byte(`{"data": [
+				{"type": "customerReviews", "id": "r1", "attributes": {"rating": 5, "title": "Great", "body": "Love it", "territory": "USA", "createdDate": "` + daysAgo(1) + `"}},
+				{"type": "customerReviews", "id": "r2", "attributes": {"rating": 2, "title": "Crashes", "body": "On launch", "territory": "DEU", "createdDate": "` + daysAgo(3) + `"}},
+				{"type": "customerReviews", "id": "r3", "attributes": {"rating": 1, "title": "Old", "createdDate": "` + daysAgo(10) + `"}}
+			]}`))
+		case "/v1/apps/app-1/appStoreVersions":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.0", "platform": "IOS", "appStoreState": "WAITING_FOR_REVIEW"}}]}`))
+		case "/v1/builds":
+			w.Write([]byte(`{"data": [
+				{"type": "builds", "id": "b2", "attributes": {"version": "42", "processingState": "VALID", "uploadedDate": "` + daysAgo(2) + `"}},
+				{"type": "builds", "id": "b1", "attributes": {"version": "41", "processingState": "VALID", "uploadedDate": "` + daysAgo(20) + `"}}
+			]}`))
+		case "/v1/certificates":
+			w.Write([]byte(`{"data": [
+				{"type": "certificates", "id": "c1", "attributes": {"name": "Distribution", "certificateType": "DISTRIBUTION", "expirationDate": "` + now.AddDate(0, 0, 12).Format(time.RFC3339) + `"}},
+				{"type": "certificates", "id": "c2", "attributes": {"name": "Development", "certificateType": "DEVELOPMENT", "expirationDate": "` + now.AddDate(1, 0, 0).Format(time.RFC3339) + `"}}
+			]}`))
+		case "/v1/salesReports":
+			w.Header().Set("Content-Type", "application/a-gzip")
+			units := "4"
+			if r.URL.Query().Get("filter[reportDate]") == week {
+				units = "7"
+			}
+			w.Write([]byte("Title\tUnits\tDeveloper Proceeds\tCurrency of Proceeds\tApple Identifier\n" +
+				"Alpha\t" + units + "\t0.70\tUSD\t111\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+	if _, err := registry.snapshots.RecordVersionState("app-1", "v2", "2.0", "IOS", "PREPARE_FOR_SUBMISSION"); err != nil {
+		t.Fatalf("RecordVersionState failed: %v", err)
+	}
+
+	result, err := registry.CallTool(context.Background(), "generate_digest", json.RawMessage(`{"app_ids": ["app-1"], "vendor_number": "8"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(digestOutput)
+
+	if len(output.Errors) != 0 {
+		t.Fatalf("errors = %v", output.Errors)
+	}
+	app := output.Apps[0]
+	if app.Reviews.Count != 2 || app.Reviews.AverageRating != 3.5 || app.Reviews.ByRating != [5]int{0, 1, 0, 0, 1} {
+		t.Errorf("reviews = %+v", app.Reviews)
+	}
+	if len(app.VersionChanges) != 1 || app.VersionChanges[0].From != "PREPARE_FOR_SUBMISSION" || app.VersionChanges[0].To != "WAITING_FOR_REVIEW" {
+		t.Errorf("version changes = %+v", app.VersionChanges)
+	}
+	if len(app.Builds) != 1 || app.Builds[0].Version != "42" {
+		t.Errorf("builds = %+v", app.Builds)
+	}
+	if len(output.Certificates) != 1 || output.Certificates[0].ID != "c1" || output.Certificates[0].DaysLeft != 11 {
+		t.Errorf("certificates = %+v", output.Certificates)
+	}
+	sales := output.Sales
+	if sales == nil || sales.Units != 7 || sales.UnitsChange != 3 || sales.Proceeds["USD"] != 4.9 || sales.PreviousProceeds["USD"] != 2.8 {
+		t.Fatalf("sales = %+v", sales)
+	}
+	if len(sales.Apps) != 1 || sales.Apps[0].Title != "Alpha" || sales.Apps[0].PreviousUnits != 4 {
+		t.Errorf("sales apps = %+v", sales.Apps)
+	}
+}
+
+func TestLastSalesWeek(t *testing.T) {
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2025-06-11", "2025-06-08"}, // Wednesday
+		{"2025-06-09", "2025-06-08"}, // Monday
+		{"2025-06-08", "2025-06-01"}, // Sunday, whose week isn't over
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse("2006-01-02", tt.now)
+		if got := lastSalesWeek(now.Add(15 * time.Hour)).Format("2006-01-02"); got != tt.want {
+			t.Errorf("lastSalesWeek(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_DownloadScreenshotArchive(t *testing.T) {
+	tokens := testTokens(t)
+
+	// The live version has one en-US screenshot and one preview whose video
+	// is still processing.
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/app-1/appStoreVersions":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.0", "platform": "IOS", "appStoreState": "PREPARE_FOR_SUBMISSION"}},
+				{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "1.0", "platform": "IOS", "appStoreState": "READY_FOR_SALE"}}
+			]}`))
+		case "/v1/appStoreVersions/v1/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "loc-1", "attributes": {"locale": "en-US"}}]}`))
+		case "/v1/appStoreVersionLocalizations/loc-1/appScreenshotSets":
+			w.Write([]byte(`{"data": [{"type": "appScreenshotSets", "id": "set-1", "attributes": {"screenshotDisplayType": "APP_IPHONE_67"}}]}`))
+		case "/v1/appScreenshotSets/set-1/appScreenshots":
+			w.Write([]byte(`{"data": [{"type": "appScreenshots", "id": "shot-1", "attributes": {"fileName": "Home Screen.PNG", "imageAsset": {"templateUrl": "` + server.URL + `/images/shot-1/{w}x{h}.{f}", "width": 1290, "height": 2796}}}]}`))
+		case "/v1/appStoreVersionLocalizations/loc-1/appPreviewSets":
+			w.Write([]byte(`{"data": [{"type": "appPreviewSets", "id": "pset-1", "attributes": {"previewType": "IPHONE_67"}}]}`))
+		case "/v1/appPreviewSets/pset-1/appPreviews":
+			w.Write([]byte(`{"data": [{"type": "appPreviews", "id": "preview-1", "attributes": {"fileName": "tour.mov"}}]}`))
+		case "/images/shot-1/1290x2796.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png data"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL)))
+	dir := filepath.Join(t.TempDir(), "archive")
+	args, _ := json.Marshal(map[string]any{"app_id": "app-1", "output_dir": dir})
+
+	result, err := registry.CallTool(context.Background(), "download_screenshot_archive", args)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result.Content)
+	}
+	output := result.StructuredContent.(screenshotArchiveOutput)
+
+	if output.VersionID != "v1" || output.Downloaded != 1 || output.Failed != 1 {
+		t.Errorf("output = %+v", output)
+	}
+	shot := output.Files[0]
+	if shot.Path != "en-US/screenshots/APP_IPHONE_67/01-Home_Screen.png" || shot.Width != 1290 {
+		t.Errorf("screenshot = %+v", shot)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(shot.Path)))
+	if err != nil || string(data) != "png data" {
+		t.Errorf("screenshot file = %q, %v", data, err)
+	}
+	if output.Files[1].Kind != "preview" || output.Files[1].Error == "" {
+		t.Errorf("preview = %+v", output.Files[1])
+	}
+
+	var manifest screenshotArchiveOutput
+	data, err = os.ReadFile(filepath.Join(dir, archiveManifestName))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[0].SHA256 != shot.SHA256 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	// A second archive into the same directory is refused.
+	result, err = registry.CallTool(context.Background(), "download_screenshot_archive", args)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected an error for a non-empty output_dir")
+	}
+}
+
+func TestRegistry_ToolAnnotations(t *testing.T) {
+	for _, tool := range NewRegistry(nil).ListTools() {
+		if tool.Annotations == nil {
+			t.Errorf("%s: missing annotations", tool.Name)
+		}
+	}
+
+	tests := []struct {
+		name                              string
+		readOnly, destructive, idempotent bool
+		openWorld                         bool
+	}{
+		{name: "list_apps", readOnly: true, openWorld: true},
+		{name: "wait_for_build_processing", readOnly: true, openWorld: true},
+		{name: "build_overview", readOnly: true, openWorld: true},
+		{name: "generate_digest", readOnly: true, openWorld: true},
+		{name: "create_beta_group", openWorld: true},
+		{name: "update_app_info", destructive: true, idempotent: true, openWorld: true},
+		{name: "delete_beta_group", destructive: true, idempotent: true, openWorld: true},
+		{name: "submit_app_for_review", destructive: true, openWorld: true},
+		{name: "create_beta_app_review_submission", destructive: true, openWorld: true},
+		{name: "add_tester_to_group", idempotent: true, openWorld: true},
+		{name: "select_team", idempotent: true},
+		{name: "rotate_credentials", destructive: true, openWorld: true},
+	}
+	for _, tt := range tests {
+		a := toolAnnotations(tt.name)
+		if a.ReadOnlyHint == nil || a.OpenWorldHint == nil {
+			t.Fatalf("%s: annotations = %+v", tt.name, a)
+		}
+		if *a.ReadOnlyHint != tt.readOnly || *a.OpenWorldHint != tt.openWorld {
+			t.Errorf("%s: readOnly = %v, openWorld = %v", tt.name, *a.ReadOnlyHint, *a.OpenWorldHint)
+		}
+		if tt.readOnly {
+			if a.DestructiveHint != nil || a.IdempotentHint != nil {
+				t.Errorf("%s: read-only tool has write hints", tt.name)
+			}
+			continue
+		}
+		if *a.DestructiveHint != tt.destructive || *a.IdempotentHint != tt.idempotent {
+			t.Errorf("%s: destructive = %v, idempotent = %v", tt.name, *a.DestructiveHint, *a.IdempotentHint)
+		}
+	}
+}
+
+func TestRegistry_VerifyAssetUpload(t *testing.T) {
+
+	// The screenshot was uploaded but never committed; committing it completes
+	// processing. The preview was rejected by Apple.
+	var mu sync.Mutex
+	screenshotState := "AWAITING_UPLOAD"
+	var commit string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/appScreenshots/shot-1" && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			commit = string(body)
+			screenshotState = "COMPLETE"
+			w.Write([]byte(`{"data": {"type": "appScreenshots", "id": "shot-1", "attributes": {}}}`))
+		case r.URL.Path == "/v1/appScreenshots/shot-1":
+			w.Write([]byte(`{"data": {"type": "appScreenshots", "id": "shot-1", "attributes": {"fileName": "home.png", "sourceFileChecksum": "abc123", "assetDeliveryState": {"state": "` + screenshotState + `"}}}}`))
+		case r.URL.Path == "/v1/appPreviews/preview-1":
+			w.Write([]byte(`{"data": {"type": "appPreviews", "id": "preview-1", "attributes": {"fileName": "tour.mov", "assetDeliveryState": {"state": "FAILED", "errors": [{"code": "IMAGE_INCORRECT_DIMENSIONS", "description": "The dimensions are wrong"}]}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "verify_asset_upload", json.RawMessage(`{"asset_type": "screenshot", "asset_id": "shot-1", "interval_seconds": 1}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(assetVerificationOutput)
+	if result.IsError || !output.Verified || !output.CommitRetried || output.State != "COMPLETE" {
+		t.Errorf("screenshot = %+v", output)
+	}
+	if !strings.Contains(commit, `"uploaded":true`) || !strings.Contains(commit, `"sourceFileChecksum":"abc123"`) {
+		t.Errorf("commit = %s", commit)
+	}
+
+	result, err = registry.CallTool(context.Background(), "verify_asset_upload", json.RawMessage(`{"asset_type": "preview", "asset_id": "preview-1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output = result.StructuredContent.(assetVerificationOutput)
+	if !result.IsError || output.Verified || output.State != "FAILED" {
+		t.Errorf("preview = %+v", output)
+	}
+	if len(output.Errors) != 1 || output.Errors[0].Code != "IMAGE_INCORRECT_DIMENSIONS" {
+		t.Errorf("errors = %+v", output.Errors)
+	}
+	if !strings.Contains(result.Content[0].Text, "IMAGE_INCORRECT_DIMENSIONS") {
+		t.Errorf("text = %s", result.Content[0].Text)
+	}
+}
+
+func TestRegistry_ProbeCapabilities(t *testing.T) {
+	// The default team's key has the Finance role but not Sales; acme's has both.
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/salesReports" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": [{"status": "403", "code": "FORBIDDEN_ERROR", "title": "The API key in use does not allow this request"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer defaultServer.Close()
+	acmeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer acmeServer.Close()
+
+	key := testTokens(t)
+	client := api.NewClientWithTokenProvider(key, api.WithBaseURL(defaultServer.URL))
+	client.AddTeam("default", api.Team{TokenProvider: key, BaseURL: defaultServer.URL})
+	client.AddTeam("acme", api.Team{TokenProvider: key, BaseURL: acmeServer.URL})
+
+	registry := NewRegistry(client)
+	registry.EnableTeams()
+	listed := func() map[string]bool {
+		names := make(map[string]bool)
+		for _, tool := range registry.ListTools() {
+			names[tool.Name] = true
+		}
+		return names
+	}
+
+	changes := 0
+	remove := registry.OnToolsChanged(func() { changes++ })
+	defer remove()
+
+	if !listed()["get_sales_report"] {
+		t.Error("get_sales_report should be listed before probing")
+	}
+	registry.ProbeCapabilities(context.Background())
+	if names := listed(); names["get_sales_report"] || !names["get_finance_report"] {
+		t.Errorf("after probing: sales = %v, finance = %v", names["get_sales_report"], names["get_finance_report"])
+	}
+	if changes != 1 {
+		t.Errorf("probing changed tools %d times, want 1", changes)
+	}
+
+	if _, err := registry.CallTool(context.Background(), "select_team", json.RawMessage(`{"team": "acme"}`)); err != nil {
+		t.Fatalf("select_team failed: %v", err)
+	}
+	if !listed()["get_sales_report"] {
+		t.Error("get_sales_report should be listed for acme")
+	}
+	if changes != 2 {
+		t.Errorf("tools changed %d times, want 2", changes)
+	}
+
+	// Switching back uses the earlier probe and changes the list again.
+	registry.CallTool(context.Background(), "select_team", json.RawMessage(`{"team": "default"}`))
+	if listed()["get_sales_report"] || changes != 3 {
+		t.Errorf("after switching back: changes = %d", changes)
+	}
+}
+
+func TestRegistry_DeleteStuckAssets(t *testing.T) {
+
+	// shot-1 and the attachment were seen failing two days ago; shot-2 is
+	// stuck awaiting upload for the first time.
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	seen := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	history := `{"versions": {}, "assets": {
+		"shot-1": {"state": "FAILED", "since": "` + seen + `"},
+		"att-1": {"state": "FAILED", "since": "` + seen + `"},
+		"shot-3": {"state": "FAILED", "since": "` + seen + `"}
+	}}`
+	if err := os.WriteFile(path, []byte(history), 0o600); err != nil {
+		t.Fatalf("failed to write snapshots: %v", err)
+	}
+	store, err := snapshots.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open snapshots: %v", err)
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [
+				{"type": "appStoreVersions", "id": "v2", "attributes": {"versionString": "2.0", "platform": "IOS", "appStoreState": "PREPARE_FOR_SUBMISSION"}},
+				{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "1.0", "platform": "IOS", "appStoreState": "READY_FOR_SALE"}}
+			]}`))
+		case "/v1/appStoreVersions/v2/appStoreVersionLocalizations":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersionLocalizations", "id": "loc-1", "attributes": {"locale": "en-US"}}]}`))
+		case "/v1/appStoreVersionLocalizations/loc-1/appScreenshotSets":
+			w.Write([]byte(`{"data": [{"type": "appScreenshotSets", "id": "set-1", "attributes": {"screenshotDisplayType": "APP_IPHONE_67"}}]}`))
+		case "/v1/appScreenshotSets/set-1/appScreenshots":
+			w.Write([]byte(`{"data": [
+				{"type": "appScreenshots", "id": "shot-1", "attributes": {"fileName": "home.png", "assetDeliveryState": {"state": "FAILED", "errors": [{"code": "IMAGE_TOOL_FAILURE"}]}}},
+				{"type": "appScreenshots", "id": "shot-2", "attributes": {"fileName": "list.png", "assetDeliveryState": {"state": "AWAITING_UPLOAD"}}},
+				{"type": "appScreenshots", "id": "shot-3", "attributes": {"fileName": "done.png", "assetDeliveryState": {"state": "COMPLETE"}}}
+			]}`))
+		case "/v1/appStoreVersionLocalizations/loc-1/appPreviewSets":
+			w.Write([]byte(`{"data": []}`))
+		case "/v1/appStoreVersions/v2/appStoreReviewDetail":
+			w.Write([]byte(`{"data": {"type": "appStoreReviewDetails", "id": "detail-1", "attributes": {}}}`))
+		case "/v1/appStoreReviewDetails/detail-1/appStoreReviewAttachments":
+			w.Write([]byte(`{"data": [{"type": "appStoreReviewAttachments", "id": "att-1", "attributes": {"fileName": "demo.mov", "assetDeliveryState": {"state": "FAILED"}}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+	registry.SetSnapshotStore(store)
+
+	result, err := registry.CallTool(context.Background(), "delete_stuck_assets", json.RawMessage(`{"app_id": "app1", "dry_run": true}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(stuckAssetsOutput)
+	if result.IsError || len(output.Deleted) != 0 || len(output.Waiting) != 3 || len(deleted) != 0 {
+		t.Errorf("dry run = %+v, deleted %v", output, deleted)
+	}
+	if !slices.Equal(output.VersionIDs, []string{"v2"}) {
+		t.Errorf("versionIds = %v, want [v2]", output.VersionIDs)
+	}
+
+	result, err = registry.CallTool(context.Background(), "delete_stuck_assets", json.RawMessage(`{"app_id": "app1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output = result.StructuredContent.(stuckAssetsOutput)
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	want := []string{"/v1/appScreenshots/shot-1", "/v1/appStoreReviewAttachments/att-1"}
+	if !slices.Equal(deleted, want) {
+		t.Errorf("deleted %v, want %v", deleted, want)
+	}
+	if len(output.Waiting) != 1 || output.Waiting[0].ID != "shot-2" || output.Waiting[0].State != "AWAITING_UPLOAD" {
+		t.Errorf("waiting = %+v", output.Waiting)
+	}
+	if len(output.Deleted) != 2 || output.Deleted[0].Locale != "en-US" || output.Deleted[0].DisplayType != "APP_IPHONE_67" || !slices.Equal(output.Deleted[0].ErrorCodes, []string{"IMAGE_TOOL_FAILURE"}) {
+		t.Errorf("deleted = %+v", output.Deleted)
+	}
+
+	// Deleted and completed assets are no longer tracked.
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read snapshots: %v", err)
+	}
+	var recorded struct {
+		Assets map[string]snapshots.AssetState `json:"assets"`
+	}
+	if err := json.Unmarshal(saved, &recorded); err != nil {
+		t.Fatalf("failed to parse snapshots: %v", err)
+	}
+	if len(recorded.Assets) != 1 || recorded.Assets["shot-2"].State != "AWAITING_UPLOAD" {
+		t.Errorf("tracked assets = %+v", recorded.Assets)
+	}
+}
+
+func TestRegistry_ListAppStoreReviewAttachments(t *testing.T) {
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/appStoreVersions/v1/appStoreReviewDetail":
+			w.Write([]byte(`{
+				"data": {"type": "appStoreReviewDetails", "id": "detail-1", "attributes": {"notes": "Tap twice"}},
+				"included": [{"type": "appStoreReviewAttachments", "id": "att-1", "attributes": {"fileName": "demo.mov", "fileSize": 2048, "sourceFileChecksum": "abc123", "assetDeliveryState": {"state": "COMPLETE"}}}]
+			}`))
+		case "/v1/appStoreReviewDetails/detail-2/appStoreReviewAttachments":
+			w.Write([]byte(`{"data": [{"type": "appStoreReviewAttachments", "id": "att-2", "attributes": {"fileName": "login.pdf"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	for _, tc := range []struct {
+		tool, args string
+		want       []string
+	}{
+		{"list_app_store_review_attachments", `{"version_id": "v1"}`, []string{"ID: att-1", "Checksum: abc123", "State: COMPLETE"}},
+		{"list_app_store_review_attachments", `{"review_detail_id": "detail-2"}`, []string{"ID: att-2", "File Name: login.pdf"}},
+		{"get_app_store_review_detail", `{"version_id": "v1"}`, []string{"Notes: Tap twice", "Found 1 review attachments", "ID: att-1"}},
+	} {
+		result, err := registry.CallTool(context.Background(), tc.tool, json.RawMessage(tc.args))
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", tc.tool, tc.args, err)
+		}
+		if result.IsError {
+			t.Errorf("%s %s: %s", tc.tool, tc.args, result.Content[0].Text)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(result.Content[0].Text, want) {
+				t.Errorf("%s %s missing %q:\n%s", tc.tool, tc.args, want, result.Content[0].Text)
+			}
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	apiError := func(status int) error {
+		return fmt.Errorf("Failed to get app: %w", &api.Error{StatusCode: status})
+	}
+
+	tests := []struct {
+		err       error
+		class     string
+		status    int
+		retryable bool
+	}{
+		{errors.New("app_id is required"), ErrorClassInvalidArguments, 0, false},
+		{apiError(400), ErrorClassInvalidRequest, 400, false},
+		{apiError(401), ErrorClassAuth, 401, false},
+		{apiError(403), ErrorClassForbidden, 403, false},
+		{apiError(404), ErrorClassNotFound, 404, false},
+		{apiError(409), ErrorClassConflict, 409, false},
+		{apiError(429), ErrorClassRateLimited, 429, true},
+		{apiError(502), ErrorClassTransient, 502, true},
+		{errors.New("failed to get app infos: Get \"https://api.appstoreconnect.apple.com/v1/apps\": dial tcp: connection refused"), ErrorClassTransient, 0, true},
+		{errors.New("get_app failed unexpectedly: nil map"), ErrorClassInternal, 0, false},
+	}
+	for _, tt := range tests {
+		got := ClassifyError(tt.err)
+		if got.Class != tt.class || got.Status != tt.status || got.Retryable != tt.retryable {
+			t.Errorf("ClassifyError(%q) = %+v, want class %s, status %d, retryable %t", tt.err, got, tt.class, tt.status, tt.retryable)
+		}
+	}
+
+	// Error results are classified in their _meta as well, by the error they carry.
+	result := withErrorClass(mcp.NewErrorResultWithError("Failed to get app: not there", apiError(404)))
+	if toolErr, ok := result.Meta[mcp.MetaError].(*mcp.ToolError); !ok || toolErr.Class != ErrorClassNotFound {
+		t.Errorf("_meta = %+v", result.Meta)
+	}
+	if result := withErrorClass(mcp.NewSuccessResult("ok")); result.Meta != nil {
+		t.Errorf("success _meta = %+v", result.Meta)
+	}
+
+	// Text that only looks like an API error isn't classified as one.
+	result = withErrorClass(mcp.NewErrorResult("Build note: API error (404) in staging"))
+	if toolErr, ok := result.Meta[mcp.MetaError].(*mcp.ToolError); ok && toolErr.Status != 0 {
+		t.Errorf("_meta of untyped error = %+v", toolErr)
+	}
+
+	// The request ID of a failed API request is kept for support reports.
+	toolErr := ClassifyError(fmt.Errorf("Failed to get app: %w", &api.Error{StatusCode: 404, RequestID: "5F2A-11C0"}))
+	if toolErr.Class != ErrorClassNotFound || toolErr.RequestID != "5F2A-11C0" {
+		t.Errorf("ClassifyError with request ID = %+v", toolErr)
+	}
+}
+
+func TestRegistry_RotateCredentials(t *testing.T) {
+	var mu sync.Mutex
+	var keyIDs []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		header, _, _ := strings.Cut(token, ".")
+		data, _ := base64.RawURLEncoding.DecodeString(header)
+		var jwtHeader struct {
+			Kid string `json:"kid"`
+		}
+		json.Unmarshal(data, &jwtHeader)
+
+		mu.Lock()
+		keyIDs = append(keyIDs, jwtHeader.Kid)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if jwtHeader.Kid == "REVOKED" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors": [{"status": "401", "title": "Authentication credentials are missing or invalid."}]}`))
+			return
+		}
+		if jwtHeader.Kid == "NOACCESS" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": [{"status": "403", "title": "The API key in use does not allow this request"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": [], "links": {}, "meta": {"paging": {"total": 0}}}`))
+	}))
+	registry := NewRegistry(client)
+	ctx := context.Background()
+
+	keyPath := filepath.Join(t.TempDir(), "AuthKey_NEWKEY.p8")
+	if err := os.WriteFile(keyPath, testKeyPEM(t), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	// Neither or both key sources is refused without a request.
+	result, err := registry.CallTool(ctx, "rotate_credentials", json.RawMessage(`{"key_id": "NEWKEY"}`))
+	if err != nil || !result.IsError {
+		t.Fatalf("rotate_credentials without a key = %+v, %v", result, err)
+	}
+
+	// A key App Store Connect refuses leaves the old key in use.
+	args, _ := json.Marshal(map[string]string{"key_id": "REVOKED", "private_key_base64": base64.StdEncoding.EncodeToString(testKeyPEM(t))})
+	result, err = registry.CallTool(ctx, "rotate_credentials", args)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "still using key TESTKEY123") {
+		t.Fatalf("rotate_credentials to a refused key = %+v", result)
+	}
+
+	// So does a key that isn't allowed to list apps.
+	args, _ = json.Marshal(map[string]string{"key_id": "NOACCESS", "private_key_base64": base64.StdEncoding.EncodeToString(testKeyPEM(t))})
+	result, err = registry.CallTool(ctx, "rotate_credentials", args)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "still using key TESTKEY123") {
+		t.Fatalf("rotate_credentials to a forbidden key = %+v", result)
+	}
+
+	args, _ = json.Marshal(map[string]string{"key_id": "NEWKEY", "private_key_path": keyPath})
+	result, err = registry.CallTool(ctx, "rotate_credentials", args)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError || !strings.Contains(result.Content[0].Text, "now uses key NEWKEY of issuer test-issuer (was key TESTKEY123") {
+		t.Fatalf("rotate_credentials = %+v", result)
+	}
+
+	if _, err := client.Get(ctx, "/v1/apps", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if last := keyIDs[len(keyIDs)-1]; last != "NEWKEY" {
+		t.Errorf("request after rotation signed with %q, want NEWKEY (all: %v)", last, keyIDs)
+	}
+}
+
+func TestRegistry_ExpireOldBuilds(t *testing.T) {
+
+	day := func(n int) string {
+		return time.Now().Add(-time.Duration(n) * 24 * time.Hour).UTC().Format(time.RFC3339)
+	}
+	build := func(id, number, train string, age int) string {
+		return `{"type": "builds", "id": "` + id + `", "attributes": {"version": "` + number + `", "uploadedDate": "` + day(age) + `"},
+			"relationships": {"preReleaseVersion": {"data": {"type": "preReleaseVersions", "id": "` + train + `"}}}}`
+	}
+	body := `{"data": [` + strings.Join([]string{
+		build("b1", "1", "pre1", 90),
+		build("b2", "2", "pre1", 60),
+		build("b3", "3", "pre1", 5),
+		build("b4", "4", "pre2", 80),
+		`{"type": "builds", "id": "b5", "attributes": {"version": "5"}, "relationships": {"preReleaseVersion": {"data": {"type": "preReleaseVersions", "id": "pre2"}}}}`,
+	}, ",") + `], "included": [
+		{"type": "preReleaseVersions", "id": "pre1", "attributes": {"version": "1.0", "platform": "IOS"}},
+		{"type": "preReleaseVersions", "id": "pre2", "attributes": {"version": "2.0", "platform": "IOS"}}
+	]}`
+
+	var mu sync.Mutex
+	var expired []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			expired = append(expired, r.URL.Path)
+			w.Write([]byte(`{"data": {"type": "builds", "id": "x", "attributes": {"expired": true}}}`))
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("filter[expired]") != "false" || query.Get("include") != "preReleaseVersion" {
+			t.Errorf("query = %v", query)
+		}
+		w.Write([]byte(body))
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "expire_old_builds", json.RawMessage(`{"app_id": "app1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected an error without a rule")
+	}
+
+	result, err = registry.CallTool(context.Background(), "expire_old_builds", json.RawMessage(`{"app_id": "app1", "keep_latest": 1, "dry_run": true}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(buildRetentionOutput)
+	var ids []string
+	for _, b := range output.Expired {
+		ids = append(ids, b.ID)
+	}
+	if !slices.Equal(ids, []string{"b1", "b2"}) || output.Kept != 3 || len(expired) != 0 {
+		t.Errorf("dry run expired %v, kept %d, sent %v", ids, output.Kept, expired)
+	}
+	if output.Expired[0].Version != "1.0" || output.Expired[0].Platform != "IOS" || output.Expired[0].BuildNumber != "1" {
+		t.Errorf("expired[0] = %+v", output.Expired[0])
+	}
+
+	result, err = registry.CallTool(context.Background(), "expire_old_builds", json.RawMessage(`{"app_id": "app1", "older_than_days": 70}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	slices.Sort(expired)
+	if want := []string{"/v1/builds/b1", "/v1/builds/b4"}; !slices.Equal(expired, want) {
+		t.Errorf("expired %v, want %v", expired, want)
+	}
+}
+
+func TestRegistry_RemoveTesterEverywhere(t *testing.T) {
+
+	var mu sync.Mutex
+	var deleted []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if got := r.URL.Query().Get("filter[email]"); got != "Jane@example.com" {
+			t.Errorf("filter[email] = %q", got)
+		}
+		if len(deleted) > 0 {
+			w.Write([]byte(`{"data": []}`))
+			return
+		}
+		w.Write([]byte(`{"data": [
+			{"type": "betaTesters", "id": "t1", "attributes": {"firstName": "Jane", "lastName": "Doe", "email": "jane@example.com", "state": "ACCEPTED"},
+				"relationships": {"apps": {"data": [{"type": "apps", "id": "a1"}]}, "betaGroups": {"data": [{"type": "betaGroups", "id": "g1"}, {"type": "betaGroups", "id": "g2"}]}}},
+			{"type": "betaTesters", "id": "t2", "attributes": {"email": "jane@example.com.au"}}
+		], "included": [
+			{"type": "apps", "id": "a1", "attributes": {"name": "Weather"}},
+			{"type": "betaGroups", "id": "g1", "attributes": {"name": "Friends"}},
+			{"type": "betaGroups", "id": "g2", "attributes": {"name": "Staff"}}
+		]}`))
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "remove_tester_everywhere", json.RawMessage(`{"email": "Jane@example.com", "dry_run": true}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	output := result.StructuredContent.(testerRemovalOutput)
+	if result.IsError || len(output.Testers) != 1 || len(deleted) != 0 {
+		t.Fatalf("dry run = %+v, deleted %v", output, deleted)
+	}
+	tester := output.Testers[0]
+	if tester.ID != "t1" || tester.Name != "Jane Doe" || !slices.Equal(tester.Apps, []string{"Weather (a1)"}) || !slices.Equal(tester.Groups, []string{"Friends (g1)", "Staff (g2)"}) {
+		t.Errorf("tester = %+v", tester)
+	}
+
+	result, err = registry.CallTool(context.Background(), "remove_tester_everywhere", json.RawMessage(`{"email": "Jane@example.com"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	output = result.StructuredContent.(testerRemovalOutput)
+	if !slices.Equal(deleted, []string{"/v1/betaTesters/t1"}) || !output.Verified || !output.Testers[0].Deleted {
+		t.Errorf("output = %+v, deleted %v", output, deleted)
+	}
+}
+
+func TestRegistry_AppLinks(t *testing.T) {
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps":
+			if got := r.URL.Query().Get("include"); got != "betaGroups" {
+				t.Errorf("include = %q, want betaGroups", got)
+			}
+			w.Write([]byte(`{"data": [
+				{"type": "apps", "id": "111", "attributes": {"name": "Weather"},
+					"relationships": {"betaGroups": {"data": [{"type": "betaGroups", "id": "g1"}, {"type": "betaGroups", "id": "g2"}]}}},
+				{"type": "apps", "id": "222", "attributes": {"name": "Notes"}}
+			], "included": [
+				{"type": "betaGroups", "id": "g1", "attributes": {"name": "Public", "publicLinkEnabled": true, "publicLink": "https://testflight.apple.com/join/abc"}},
+				{"type": "betaGroups", "id": "g2", "attributes": {"name": "Staff", "publicLink": "https://testflight.apple.com/join/old"}}
+			]}`))
+		case "/v1/appStoreVersions/v1":
+			w.Write([]byte(`{"data": {"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "2.0"}},
+				"included": [{"type": "apps", "id": "111", "attributes": {"name": "Weather"}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "list_apps", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	apps := result.StructuredContent.(appsOutput).Apps
+	if len(apps) != 2 {
+		t.Fatalf("apps = %+v", apps)
+	}
+	if apps[0].AppStoreURL != "https://apps.apple.com/app/id111" {
+		t.Errorf("appStoreUrl = %q", apps[0].AppStoreURL)
+	}
+	want := []testFlightLink{{BetaGroupID: "g1", BetaGroupName: "Public", URL: "https://testflight.apple.com/join/abc"}}
+	if !slices.Equal(apps[0].TestFlightPublicLinks, want) {
+		t.Errorf("testFlightPublicLinks = %+v, want %+v", apps[0].TestFlightPublicLinks, want)
+	}
+	if len(apps[1].TestFlightPublicLinks) != 0 {
+		t.Errorf("app without groups has links %+v", apps[1].TestFlightPublicLinks)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, "- TestFlight (Public): https://testflight.apple.com/join/abc") || strings.Contains(text, "join/old") {
+		t.Errorf("unexpected text:\n%s", text)
+	}
+
+	result, err = registry.CallTool(context.Background(), "get_app_store_version", json.RawMessage(`{"version_id": "v1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	version := result.StructuredContent.(linkedAppStoreVersion)
+	if version.AppID != "111" || version.AppStoreURL != "https://apps.apple.com/app/id111" {
+		t.Errorf("get_app_store_version = %+v", version)
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	var tsv strings.Builder
+	tsv.WriteString("Provider\tSKU\tUnits\n")
+	for i := 0; i < 25; i++ {
+		tsv.WriteString(fmt.Sprintf("APPLE\tsku%d\t%d\n", i, i))
+	}
+
+	text, err := formatReport("Sales", &api.Report{Body: strings.NewReader(tsv.String()), Filename: "S_D_1.txt.gz", DownloadedBytes: 120, Compressed: true})
+	if err != nil {
+		t.Fatalf("formatReport failed: %v", err)
+	}
+	for _, want := range []string{"as S_D_1.txt.gz (120 bytes compressed)", "25 rows", "Provider\tSKU\tUnits\n", "sku19\t19\n", "... 5 more rows"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report text missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "sku20") {
+		t.Errorf("report text shows more than %d rows:\n%s", reportPreviewRows, text)
+	}
+
+	text, _ = formatReport("Finance", &api.Report{Body: strings.NewReader("")})
+	if !strings.Contains(text, "empty") {
+		t.Errorf("empty report text = %q", text)
+	}
+}
+
+func TestNormalizeMetadataText(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"description", "It\u2019s \u201cfast\u201d and\u200b light", `It's "fast" and light`, ""},
+		{"keywords", "weather， forecast、 rain , ,radar", "weather,forecast,rain,radar", ""},
+		{"whats_new", "Fixes: - crash\n- typo", "Fixes:\n- crash\n- typo", ""},
+		{"description", "Sunny ☀️ days", "Sunny ☀️ days", ""},
+		{"keywords", "weather,\U0001F326", "", "keywords contains an emoji (U+1F326) at character 9"},
+		{"subtitle", "Line\none", "", "a line break"},
+		{"description", "Bell\a", "", "a control character"},
+		{"description", "Logo \ue000", "", "a private use character"},
+	}
+	for _, tt := range tests {
+		got, err := normalizeMetadataText(tt.field, tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("normalizeMetadataText(%q, %q) error = %v, want %q", tt.field, tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeMetadataText(%q, %q) = %q, %v, want %q", tt.field, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestRegistry_UpdateVersionLocalizationNormalizes(t *testing.T) {
+	var sent api.AppStoreVersionLocalizationUpdateRequest
+
+	requests := 0
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"data": {"type": "appStoreVersionLocalizations", "id": "l1", "attributes": {"locale": "en-US"}}}`))
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "update_version_localization",
+		json.RawMessage(`{"localization_id": "l1", "keywords": "weather， rain", "description": "It’s here"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if sent.Data.Attributes.Keywords != "weather,rain" || sent.Data.Attributes.Description != "It's here" {
+		t.Errorf("sent attributes = %+v", sent.Data.Attributes)
+	}
+	if !strings.Contains(result.Content[0].Text, "Normalized quotes, spaces, commas and invisible characters in: description, keywords") {
+		t.Errorf("unexpected text:\n%s", result.Content[0].Text)
+	}
+
+	result, err = registry.CallTool(context.Background(), "update_version_localization",
+		json.RawMessage(`{"localization_id": "l1", "keywords": "weather,🌦"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "emoji") {
+		t.Errorf("expected an emoji error, got %+v", result)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestRegistry_SubmitAppEvent(t *testing.T) {
+
+	var requests []string
+	var item api.ReviewSubmissionItemCreateRequest
+	var update api.ReviewSubmissionUpdateRequest
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/appEvents/e1":
+			w.Write([]byte(`{"data": {"type": "appEvents", "id": "e1", "attributes": {"referenceName": "Summer", "eventState": "DRAFT"}}}`))
+		case "GET /v1/reviewSubmissions":
+			if r.URL.Query().Get("filter[app]") != "111" || r.URL.Query().Get("filter[state]") != "READY_FOR_REVIEW" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": []}`))
+		case "POST /v1/reviewSubmissions":
+			w.Write([]byte(`{"data": {"type": "reviewSubmissions", "id": "rs1", "attributes": {"platform": "IOS", "state": "READY_FOR_REVIEW"}}}`))
+		case "POST /v1/reviewSubmissionItems":
+			json.NewDecoder(r.Body).Decode(&item)
+			w.Write([]byte(`{"data": {"type": "reviewSubmissionItems", "id": "i1"}}`))
+		case "PATCH /v1/reviewSubmissions/rs1":
+			json.NewDecoder(r.Body).Decode(&update)
+			w.Write([]byte(`{"data": {"type": "reviewSubmissions", "id": "rs1", "attributes": {"state": "WAITING_FOR_REVIEW"}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "submit_app_event", json.RawMessage(`{"app_id": "111", "event_id": "e1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if len(requests) != 5 {
+		t.Errorf("requests = %v", requests)
+	}
+	if item.Data.Relationships.AppEvent == nil || item.Data.Relationships.AppEvent.Data.ID != "e1" || item.Data.Relationships.ReviewSubmission.Data.ID != "rs1" {
+		t.Errorf("review submission item = %+v", item.Data.Relationships)
+	}
+	if update.Data.Attributes.Submitted == nil || !*update.Data.Attributes.Submitted {
+		t.Errorf("review submission update = %+v", update.Data.Attributes)
+	}
+	if !strings.Contains(result.Content[0].Text, "WAITING_FOR_REVIEW") {
+		t.Errorf("unexpected text:\n%s", result.Content[0].Text)
+	}
+
+	// A draft event can't be archived.
+	result, err = registry.CallTool(context.Background(), "archive_app_event", json.RawMessage(`{"event_id": "e1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "delete_app_event") {
+		t.Errorf("expected an error for a draft event, got %+v", result)
+	}
+}
+
+func TestRegistry_GetCustomerReviews(t *testing.T) {
+
+	var mu sync.Mutex
+	var requests []string
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Query().Get("include") != "response" {
+			t.Errorf("include = %q, want response", r.URL.Query().Get("include"))
+		}
+		switch r.URL.Path {
+		case "/v1/customerReviews/r1":
+			w.Write([]byte(`{"data": {"type": "customerReviews", "id": "r1", "attributes": {"rating": 2, "title": "Crashes"}},
+				"included": [{"type": "customerReviewResponses", "id": "resp1", "attributes": {"responseBody": "Fixed in 2.1", "state": "PUBLISHED"}}]}`))
+		case "/v1/customerReviews/r2":
+			w.Write([]byte(`{"data": {"type": "customerReviews", "id": "r2", "attributes": {"rating": 5, "title": "Love it"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "get_customer_reviews", json.RawMessage(`{"review_ids": ["r1", "r2", "r1", "gone"]}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	text := result.Content[0].Text
+	for _, want := range []string{"Got 2 of 3 customer reviews", "Title: Crashes", "Response (PUBLISHED): Fixed in 2.1", "Title: Love it", "Response: none", "Review ID: gone\nFailed to get customer review"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "Crashes") > strings.Index(text, "Love it") {
+		t.Errorf("reviews out of order:\n%s", text)
+	}
+	if len(requests) != 3 {
+		t.Errorf("requests = %v, want one per distinct review", requests)
+	}
+}
+
+func TestParseReleaseStrategy(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		releaseType, date string
+		wantType          api.ReleaseType
+		wantDate          bool
+		errContains       string
+	}{
+		{releaseType: "", wantType: ""},
+		{releaseType: "after_approval", wantType: "AFTER_APPROVAL"},
+		{releaseType: "SCHEDULED", date: "2026-07-02T09:00:00-07:00", wantType: "SCHEDULED", wantDate: true},
+		{releaseType: "SCHEDULED", errContains: "earliest_release_date is required"},
+		{releaseType: "SCHEDULED", date: "2026-06-30T09:00:00Z", errContains: "not in the future"},
+		{releaseType: "SCHEDULED", date: "July 2", errContains: "RFC 3339"},
+		{releaseType: "MANUAL", date: "2026-07-02T09:00:00Z", errContains: "requires release_type SCHEDULED"},
+		{releaseType: "LATER", errContains: "must be one of"},
+	}
+	for _, tt := range tests {
+		releaseType, date, err := parseReleaseStrategy(tt.releaseType, tt.date, now)
+		if tt.errContains != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("parseReleaseStrategy(%q, %q) error = %v, want %q", tt.releaseType, tt.date, err, tt.errContains)
+			}
+			continue
+		}
+		if err != nil || releaseType != tt.wantType || (date != nil) != tt.wantDate {
+			t.Errorf("parseReleaseStrategy(%q, %q) = %q, %v, %v", tt.releaseType, tt.date, releaseType, date, err)
+		}
+	}
+}
+
+func TestRegistry_ChangeReleaseStrategy(t *testing.T) {
+
+	state := "PREPARE_FOR_SUBMISSION"
+	var update api.AppStoreVersionUpdateRequest
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/appStoreVersions/v1":
+			w.Write([]byte(`{"data": {"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "2.0", "appStoreState": "` + state + `", "releaseType": "MANUAL"}}}`))
+		case "PATCH /v1/appStoreVersions/v1":
+			json.NewDecoder(r.Body).Decode(&update)
+			w.Write([]byte(`{"data": {"type": "appStoreVersions", "id": "v1", "attributes": {}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	date := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+	args := fmt.Sprintf(`{"version_id": "v1", "release_type": "SCHEDULED", "earliest_release_date": %q}`, date.Format(time.RFC3339))
+	result, err := registry.CallTool(context.Background(), "change_release_strategy", json.RawMessage(args))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	attrs := update.Data.Attributes
+	if attrs.ReleaseType != "SCHEDULED" || attrs.EarliestReleaseDate == nil || !attrs.EarliestReleaseDate.Equal(date) {
+		t.Errorf("version update = %+v", attrs)
+	}
+	if !strings.Contains(result.Content[0].Text, "(was manually)") {
+		t.Errorf("unexpected text:\n%s", result.Content[0].Text)
+	}
+
+	// A version waiting for review can't be changed.
+	state = "WAITING_FOR_REVIEW"
+	result, err = registry.CallTool(context.Background(), "change_release_strategy", json.RawMessage(`{"version_id": "v1", "release_type": "MANUAL"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "WAITING_FOR_REVIEW") {
+		t.Errorf("expected an error for a version in review, got %+v", result)
+	}
+}
+
+func TestRegistry_RegisterDevicePlatform(t *testing.T) {
+
+	var created api.DeviceCreateRequest
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /v1/devices" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&created)
+		w.Write([]byte(`{"data": {"type": "devices", "id": "d1", "attributes": {"name": "Test iPhone", "platform": "IOS"}}}`))
+	}))
+
+	registry := NewRegistry(client)
+
+	// Platforms are read regardless of case.
+	result, err := registry.CallTool(context.Background(), "register_device", json.RawMessage(`{"name": "Test iPhone", "udid": "00008030-001A", "platform": "ios"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError || created.Data.Attributes.Platform != api.BundleIDPlatformIOS {
+		t.Errorf("register_device = %+v, sent platform %q", result, created.Data.Attributes.Platform)
+	}
+
+	// Devices can't be registered as universal.
+	result, err = registry.CallTool(context.Background(), "register_device", json.RawMessage(`{"name": "Test iPhone", "udid": "00008030-001A", "platform": "UNIVERSAL"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "IOS or MAC_OS") {
+		t.Errorf("expected an error for a universal device, got %+v", result)
+	}
+}
+
+func TestRegistry_CreateSubscriptionOfferCodeCustomCode(t *testing.T) {
+
+	var create api.SubscriptionOfferCodeCustomCodeCreateRequest
+	var update api.SubscriptionOfferCodeCustomCodeUpdateRequest
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/subscriptionOfferCodeCustomCodes":
+			json.NewDecoder(r.Body).Decode(&create)
+			w.Write([]byte(`{"data": {"type": "subscriptionOfferCodeCustomCodes", "id": "cc1", "attributes": {"customCode": "PODCAST20", "numberOfCodes": 500, "active": true}}}`))
+		case "PATCH /v1/subscriptionOfferCodeCustomCodes/cc1":
+			json.NewDecoder(r.Body).Decode(&update)
+			w.Write([]byte(`{"data": {"type": "subscriptionOfferCodeCustomCodes", "id": "cc1", "attributes": {"customCode": "PODCAST20", "active": false}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "create_subscription_offer_code_custom_code",
+		json.RawMessage(`{"offer_code_id": "oc1", "custom_code": "PODCAST20", "number_of_codes": 500}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if create.Data.Attributes.CustomCode != "PODCAST20" || create.Data.Attributes.NumberOfCodes != 500 ||
+		create.Data.Relationships.OfferCode.Data.ID != "oc1" {
+		t.Errorf("create request = %+v", create.Data)
+	}
+
+	// Invalid codes are refused before reaching the API.
+	for _, args := range []string{
+		`{"offer_code_id": "oc1", "custom_code": "PODCAST-20", "number_of_codes": 500}`,
+		`{"offer_code_id": "oc1", "custom_code": "PODCAST20", "number_of_codes": 0}`,
+		`{"offer_code_id": "oc1", "custom_code": "PODCAST20", "number_of_codes": 5, "expiration_date": "2020-01-01"}`,
+	} {
+		result, err := registry.CallTool(context.Background(), "create_subscription_offer_code_custom_code", json.RawMessage(args))
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("expected an error for %s", args)
+		}
+	}
+
+	result, err = registry.CallTool(context.Background(), "deactivate_subscription_offer_code_custom_code", json.RawMessage(`{"custom_code_id": "cc1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	if active := update.Data.Attributes.Active; active == nil || *active {
+		t.Errorf("update active = %v, want false", active)
+	}
+}
+
+func TestLabelEnumValues(t *testing.T) {
+	labels := DefaultEnumLabels()
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"State: WAITING_FOR_REVIEW\n", "State: Waiting for review (WAITING_FOR_REVIEW)\n"},
+		{"READY_FOR_SALE, IN_REVIEW", "Ready for sale (READY_FOR_SALE), In review (IN_REVIEW)"},
+		{"Ready for sale (READY_FOR_SALE)", "Ready for sale (READY_FOR_SALE)"},
+		{"State: SOME_NEW_STATE, ACTIVE", "State: SOME_NEW_STATE, ACTIVE"},
+		{"release_type=MY_READY_FOR_SALE_X", "release_type=MY_READY_FOR_SALE_X"},
+	}
+	for _, tt := range tests {
+		if got := labelEnumValues(tt.text, labels); got != tt.want {
+			t.Errorf("labelEnumValues(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_LabelEnums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels.json")
+	if err := os.WriteFile(path, []byte(`{"READY_FOR_SALE": "Prêt à la vente", "IN_REVIEW": ""}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	labels, err := LoadEnumLabels(path)
+	if err != nil {
+		t.Fatalf("LoadEnumLabels failed: %v", err)
+	}
+
+	registry := NewRegistry(nil)
+	registry.SetEnumLabels(labels)
+	result := mcp.NewStructuredResult("READY_FOR_SALE, IN_REVIEW, WAITING_FOR_REVIEW", map[string]string{"state": "READY_FOR_SALE"})
+
+	labeled := registry.labelEnums("get_app_store_version", result, "")
+	want := "Prêt à la vente (READY_FOR_SALE), IN_REVIEW, Waiting for review (WAITING_FOR_REVIEW)"
+	if got := labeled.Content[0].Text; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+	if result.Content[0].Text != "READY_FOR_SALE, IN_REVIEW, WAITING_FOR_REVIEW" {
+		t.Errorf("original result changed: %q", result.Content[0].Text)
+	}
+	if state := labeled.StructuredContent.(map[string]string)["state"]; state != "READY_FOR_SALE" {
+		t.Errorf("structured state = %q, want the raw value", state)
+	}
+
+	// The json format and raw API responses keep raw values.
+	if got := registry.labelEnums("get_app_store_version", result, formatJSON); got != result {
+		t.Error("json format was labeled")
+	}
+	if got := registry.labelEnums("asc_api_request", result, ""); got != result {
+		t.Error("raw API response was labeled")
+	}
+}
+
+func TestEndTerritorySchedules(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-48 * time.Hour)
+	future := now.Add(48 * time.Hour)
+
+	schedules := []api.TerritorySchedule{
+		{Territories: []string{"USA"}, PublishStart: &past, EventStart: &past, EventEnd: &future},
+		{Territories: []string{"FRA"}, PublishStart: &future, EventStart: &future, EventEnd: &future},
+	}
+	ended := endTerritorySchedules(schedules, now)
+
+	if !ended[0].PublishStart.Equal(past) || !ended[0].EventStart.Equal(past) || !ended[0].EventEnd.Equal(now) {
+		t.Errorf("running schedule = %+v", ended[0])
+	}
+	if !ended[1].PublishStart.Equal(now) || !ended[1].EventStart.Equal(now) || !ended[1].EventEnd.Equal(now) {
+		t.Errorf("upcoming schedule = %+v", ended[1])
+	}
+	if !schedules[1].EventEnd.Equal(future) {
+		t.Errorf("input schedule was changed: %+v", schedules[1])
+	}
+}
+
+func TestRegistry_BuildOverview(t *testing.T) {
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/builds/b1":
+			if include := r.URL.Query().Get("include"); !strings.Contains(include, "betaGroups") || !strings.Contains(include, "buildBetaDetail") {
+				t.Errorf("include = %q", include)
+			}
+			w.Write([]byte(`{"data": {"type": "builds", "id": "b1", "attributes": {"version": "1234", "processingState": "VALID", "expirationDate": "2099-01-01T00:00:00Z"}},
+				"included": [
+					{"type": "preReleaseVersions", "id": "p1", "attributes": {"version": "2.1", "platform": "IOS"}},
+					{"type": "buildBetaDetails", "id": "b1", "attributes": {"internalBuildState": "MISSING_EXPORT_COMPLIANCE", "externalBuildState": "MISSING_EXPORT_COMPLIANCE"}},
+					{"type": "betaGroups", "id": "g1", "attributes": {"name": "Staff", "isInternalGroup": true}},
+					{"type": "betaGroups", "id": "g2", "attributes": {"name": "Public", "publicLinkEnabled": true, "publicLink": "https://testflight.apple.com/join/abc"}},
+					{"type": "betaBuildLocalizations", "id": "l1", "attributes": {"locale": "en-US", "whatsNew": "Try the new widget"}}
+				]}`))
+		case "/v1/builds/b1/metrics/betaBuildUsages":
+			w.Write([]byte(`{"data": [
+				{"dataPoints": {"values": {"installCount": 3, "sessionCount": 10, "crashCount": 1}}},
+				{"dataPoints": {"values": {"installCount": 2, "feedbackCount": 4}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "build_overview", json.RawMessage(`{"build_id": "b1"}`))
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	output := result.StructuredContent.(buildOverviewOutput)
+	if output.AppVersion != "2.1" || !output.ExportCompliance.Missing || output.InternalBuildState != "MISSING_EXPORT_COMPLIANCE" {
+		t.Errorf("overview = %+v", output)
+	}
+	if len(output.BetaGroups) != 2 || output.BetaGroups[1].PublicLink != "https://testflight.apple.com/join/abc" {
+		t.Errorf("beta groups = %+v", output.BetaGroups)
+	}
+	if len(output.Localizations) != 1 || output.Localizations[0].WhatsNew != "Try the new widget" {
+		t.Errorf("localizations = %+v", output.Localizations)
+	}
+	if output.Usage == nil || output.Usage.InstallCount != 5 || output.Usage.FeedbackCount != 4 {
+		t.Errorf("usage = %+v", output.Usage)
+	}
+	if output.DaysUntilExpiration == nil || *output.DaysUntilExpiration <= 0 {
+		t.Errorf("daysUntilExpiration = %v", output.DaysUntilExpiration)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, "Beta Group: Staff (internal") || !strings.Contains(text, "Beta App Review: not submitted") {
+		t.Errorf("unexpected text:\n%s", text)
+	}
+}
+
+func TestRegistry_ToolNaming(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.SetMaxResultBytes(10)
+	registry.register(mcp.Tool{Name: "get_big"}, func(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+		return mcp.NewSuccessResult(strings.Repeat("x", 20)), nil
+	})
+
+	names := func() map[string]bool {
+		names := make(map[string]bool)
+		for _, tool := range registry.ListTools() {
+			names[tool.Name] = true
+		}
+		return names
+	}
+
+	registry.SetToolNaming("asc_", false)
+	listed := names()
+	for _, name := range []string{"asc_list_apps", "asc_status", "asc_batch_get", "asc_get_big"} {
+		if !listed[name] {
+			t.Errorf("expected %s to be listed", name)
+		}
+	}
+	if listed["list_apps"] || listed["asc_asc_status"] {
+		t.Error("expected every name to carry the prefix once")
+	}
+
+	// Calls resolve listed names, and text naming another tool uses its listed name.
+	result, err := registry.CallTool(context.Background(), "asc_get_big", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result.Content[0].Text, "asc_get_result_continuation") {
+		t.Errorf("truncated text = %q", result.Content[0].Text)
+	}
+
+	registry.SetToolNaming("asc_", true)
+	listed = names()
+	for _, name := range []string{"asc_apps_list_apps", "asc_builds_get_build", "asc_core_status", "asc_versions_get_review_estimate"} {
+		if !listed[name] {
+			t.Errorf("expected %s to be listed", name)
+		}
+	}
+	if got := registry.ResolveToolName("asc_apps_list_apps"); got != "list_apps" {
+		t.Errorf("ResolveToolName(asc_apps_list_apps) = %q, want list_apps", got)
+	}
+	if len(listed) != len(registry.tools) {
+		t.Errorf("%d distinct names for %d tools", len(listed), len(registry.tools))
+	}
+
+	registry.SetToolNaming("", false)
+	if !names()["list_apps"] {
+		t.Error("expected plain names with naming turned off")
+	}
+}
+
+func TestRegistry_BatchGet(t *testing.T) {
+
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/app1":
+			w.Write([]byte(`{"data": {"type": "apps", "id": "app1", "attributes": {"name": "My App", "bundleId": "com.example.app"}}}`))
+		case "/v1/apps/app1/appStoreVersions":
+			w.Write([]byte(`{"data": [{"type": "appStoreVersions", "id": "v1", "attributes": {"versionString": "1.0", "appStoreState": "PREPARE_FOR_SUBMISSION"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+		}
+	}))
+
+	registry := NewRegistry(client)
+
+	result, err := registry.CallTool(context.Background(), "asc_batch_get", json.RawMessage(`{"operations": [
+		{"id": "app", "tool": "get_app", "arguments": {"app_id": "app1"}},
+		{"tool": "get_app_versions", "arguments": {"app_id": "app1"}},
+		{"tool": "get_app", "arguments": {"app_id": "missing"}}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+
+	output := result.StructuredContent.(batchOutput)
+	var ids []string
+	for _, r := range output.Results {
+		ids = append(ids, r.ID)
+	}
+	if strings.Join(ids, ",") != "app,get_app_versions,get_app" {
+		t.Errorf("ids = %v", ids)
+	}
+	if output.Failed != 1 || !output.Results[2].IsError || output.Results[0].IsError || output.Results[1].IsError {
+		t.Errorf("results = %+v", output.Results)
+	}
+	if output.Results[0].Result == nil {
+		t.Error("expected get_app's structured content")
+	}
+	if !strings.Contains(output.Results[1].Text, "1.0") {
+		t.Errorf("versions text = %q", output.Results[1].Text)
+	}
+
+	for _, args := range []string{
+		`{"operations": [{"tool": "delete_app_event", "arguments": {"event_id": "e1"}}]}`,
+		`{"operations": [{"tool": "asc_batch_get"}]}`,
+		`{"operations": []}`,
+	} {
+		result, err := registry.CallTool(context.Background(), "asc_batch_get", json.RawMessage(args))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("expected error result for %s", args)
+		}
+	}
+}
+
+func TestSemaphore_FIFO(t *testing.T) {
+	sem := newSemaphore(1)
+	if err := sem.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem.acquire(context.Background())
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			sem.release()
+		}(i)
+		// Let each waiter queue before starting the next.
+		for {
+			sem.mu.Lock()
+			queued := sem.waiters.Len()
+			sem.mu.Unlock()
+			if queued == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	sem.release()
+	wg.Wait()
+
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Errorf("order = %v, want [0 1 2]", order)
+	}
+}
+
+func TestSemaphore_CancelWhileQueued(t *testing.T) {
+	sem := newSemaphore(1)
+	sem.acquire(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sem.acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	sem.release()
+	if sem.active != 0 || sem.waiters.Len() != 0 {
+		t.Errorf("active = %d, waiters = %d; want 0, 0", sem.active, sem.waiters.Len())
+	}
+}
+
+func TestRegistry_ConcurrencyLimits(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.SetConcurrencyLimits(map[string]int{"*": 3, "list_*": 1})
+
+	release, err := registry.acquireSlots(context.Background(), "list_apps")
+	if err != nil {
+		t.Fatalf("acquireSlots failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := registry.acquireSlots(ctx, "list_builds"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second list call err = %v, want it to wait for the list_* limit", err)
+	}
+
+	other, err := registry.acquireSlots(context.Background(), "get_app")
+	if err != nil {
+		t.Fatalf("get_app should not be limited by list_*: %v", err)
+	}
+
+	other()
+	release()
+	if registry.globalLimit.active != 0 || registry.limits["list_*"].active != 0 {
+		t.Errorf("slots not released: global %d, list_* %d", registry.globalLimit.active, registry.limits["list_*"].active)
+	}
+}
+
+func TestRegistry_ConcurrencyLimits_PollingTools(t *testing.T) {
+
+	polled := make(chan struct{}, 100)
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/builds/"):
+			polled <- struct{}{}
+			w.Write([]byte(`{"data":{"type":"builds","id":"b1","attributes":{"version":"42","processingState":"PROCESSING"}}}`))
+		case r.URL.Path == "/v1/apps/1":
+			w.Write([]byte(`{"data":{"type":"apps","id":"1","attributes":{"name":"Weather"}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	registry := NewRegistry(client)
+	for name := range pollingTools {
+		if _, ok := registry.progressHandlers[name]; !ok {
+			t.Errorf("polling tool %s is not a registered progress tool", name)
+		}
+	}
+
+	// Fill every default slot with a wait that won't finish on its own.
+	waitCtx, stopWaits := context.WithCancel(context.Background())
+	var waits sync.WaitGroup
+	for i := range defaultConcurrencyLimit {
+		waits.Add(1)
+		go func() {
+			defer waits.Done()
+			args := fmt.Sprintf(`{"build_id":"b%d","timeout_seconds":600,"interval_seconds":60}`, i)
+			registry.CallTool(waitCtx, "wait_for_build_processing", json.RawMessage(args))
+		}()
+	}
+	for range defaultConcurrencyLimit {
+		<-polled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := registry.CallTool(ctx, "get_app", json.RawMessage(`{"app_id":"1"}`))
+	if err != nil {
+		t.Fatalf("get_app behind %d waits: %v", defaultConcurrencyLimit, err)
+	}
+	if result.IsError {
+		t.Errorf("get_app failed: %s", result.Content[0].Text)
+	}
+
+	stopWaits()
+	waits.Wait()
+}
+
+func TestPollUntil(t *testing.T) {
+	calls := 0
+	var reports int
+	status, err := pollUntil(context.Background(), time.Second, time.Millisecond, func(float64, float64, string) { reports++ }, func() (bool, string, error) {
+		calls++
+		if calls < 3 {
+			return false, "PROCESSING", nil
+		}
+		return true, "VALID", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != "VALID" {
+		t.Errorf("status = %q, want VALID", status)
+	}
+	if reports != 3 {
+		t.Errorf("expected 3 progress reports, got %d", reports)
+	}
+
+	_, err = pollUntil(context.Background(), 5*time.Millisecond, time.Millisecond, func(float64, float64, string) {}, func() (bool, string, error) {
+		return false, "PROCESSING", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("err = %v, want timeout", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pollUntil(ctx, time.Minute, time.Second, func(float64, float64, string) {}, func() (bool, string, error) {
+		return false, "PROCESSING", nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+// Integration-style tests with mock HTTP server
+
+func TestHandleListApps_Integration(t *testing.T) {
+	// This test requires a mock server - skipping for unit tests
+	// as it requires setting private baseURL field
+	t.Skip("requires mock server integration")
+}
+
+// Benchmarks
+
+func BenchmarkRegistry_ListTools(b *testing.B) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+
+	tmpDir := b.TempDir()
+	keyPath := filepath.Join(tmpDir, "test_key.p8")
+	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
+
+	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
+	registry := NewRegistry(client)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = registry.ListTools()
+	}
+}
+
+func BenchmarkRegistry_CallTool_Lookup(b *testing.B) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+
+	tmpDir := b.TempDir()
+	keyPath := filepath.Join(tmpDir, "test_key.p8")
+	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
+
+	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
+	registry := NewRegistry(client)
+
+	// Just benchmark the lookup, not the actual API call
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, exists := registry.handlers["list_apps"]
+		if !exists {
+			b.Fatal("handler not found")
+		}
+	}
+}
+
+func BenchmarkNewRegistry(b *testing.B) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	keyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	pemBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+
+	tmpDir := b.TempDir()
+	keyPath := filepath.Join(tmpDir, "test_key.p8")
+	os.WriteFile(keyPath, pem.EncodeToMemory(pemBlock), 0600)
+
+	client, _ := api.NewClient("test-issuer", "TESTKEY123", keyPath)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = NewRegistry(client)
+	}
+}
+
+// Helper to create a mock client for tool handler testing
+type mockClient struct {
+	handler func(ctx context.Context, method, path string) ([]byte, error)
+}
+
+// Context timeout test
+func TestToolHandler_ContextTimeout(t *testing.T) {
+	t.Skip("requires mock server integration")
+
+	// This would test that tool handlers respect context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	defer cancel()
+
+	// Would call a tool and verify it respects the timeout
+	_ = ctx
+}
+
+// fuzzSkippedTools cannot run against a fuzzed payload: wait tools poll for
+// minutes, and get_ci_build_failures follows download URLs from the response.
+var fuzzSkippedTools = map[string]bool{
+	"wait_for_build_processing":           true,
+	"wait_for_analytics_report_instances": true,
+	"get_ci_build_failures":               true,
+}
+
+// fuzzArguments builds arguments that satisfy a tool's required properties.
+func fuzzArguments(tool mcp.Tool) json.RawMessage {
+	args := make(map[string]any)
+	for _, name := range tool.InputSchema.Required {
+		switch prop := tool.InputSchema.Properties[name]; {
+		case len(prop.Enum) > 0:
+			args[name] = prop.Enum[0]
+		case prop.Type == "integer" || prop.Type == "number":
+			args[name] = 1
+		case prop.Type == "boolean":
+			args[name] = true
+		case prop.Type == "array":
+			args[name] = []string{"1"}
+		case prop.Type == "object":
+			args[name] = map[string]any{}
+		default:
+			args[name] = "1"
+		}
+	}
+	data, _ := json.Marshal(args)
+	return data
+}
+
+// FuzzToolResponses serves the same malformed payload to every API call and
+// checks that each tool answers with a result or error instead of panicking.
+func FuzzToolResponses(f *testing.F) {
+	seeds := []string{
+		``,
+		`null`,
+		`[]`,
+		`{}`,
+		`{"data":null,"links":null,"meta":null}`,
+		`{"data":[null],"included":null}`,
+		`{"data":{"type":"apps","id":"1","attributes":null,"relationships":null}}`,
+		`{"data":[{"type":"apps","id":"1","attributes":{"name":null},"relationships":{"app":{"data":null}}}]}`,
+		`{"data":[{"type":"builds","id":"1"}],"included":[{"type":"apps","id":"2"},null,42,"x",{"type":"unknown"}]}`,
+		`{"data":[{"type":"apps","id":"1","attr`,
+		`{"data":{"id":1,"attributes":[]},"links":{"next":7}}`,
+		`{"errors":[{"status":"500","title":null}]}`,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	tokens := testTokens(f)
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(body)
+		}))
+		defer server.Close()
+
+		registry := NewRegistry(api.NewClientWithTokenProvider(tokens, api.WithBaseURL(server.URL)))
+		registry.SetToolTimeouts(map[string]time.Duration{"*": 5 * time.Second})
+
+		for _, tool := range registry.ListTools() {
+			if fuzzSkippedTools[tool.Name] {
+				continue
+			}
+			result, err := registry.CallTool(context.Background(), tool.Name, fuzzArguments(tool))
+			if err != nil {
+				continue
+			}
+			if result == nil || len(result.Content) == 0 {
+				t.Errorf("%s returned an empty result", tool.Name)
+				continue
+			}
+			if strings.Contains(result.Content[0].Text, "failed unexpectedly") {
+				t.Errorf("%s panicked: %s", tool.Name, result.Content[0].Text)
+			}
+		}
+	})
 }
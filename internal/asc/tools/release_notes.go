@@ -0,0 +1,327 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// releaseNotesMaxRunPages bounds how many pages of build runs are scanned for
+// commits between two builds.
+const releaseNotesMaxRunPages = 5
+
+// ticketPattern matches Jira-style ticket IDs such as APP-123.
+var ticketPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-[1-9][0-9]*\b`)
+
+// registerReleaseNotesTools registers release notes tools.
+func (r *Registry) registerReleaseNotesTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "get_release_notes_context",
+			Description: "Gather context for drafting release notes: the commits Xcode Cloud built between two builds, ticket IDs (such as APP-123) found in their messages, and the What's New text of previous versions. Both builds must have been built by Xcode Cloud. Only commits that started a build run are listed; pull request builds are skipped.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"from_build_id": {
+						Type:        "string",
+						Description: "The build the previous release shipped; its commits are excluded",
+					},
+					"to_build_id": {
+						Type:        "string",
+						Description: "The build being released",
+					},
+					"locale": {
+						Type:        "string",
+						Description: "Locale of the previous What's New texts (default: en-US)",
+						Default:     "en-US",
+					},
+					"previous_versions": {
+						Type:        "integer",
+						Description: "Number of previous What's New texts to include (default: 3, max: 10)",
+						Default:     3,
+					},
+				},
+				Required: []string{"app_id", "from_build_id", "to_build_id"},
+			},
+			OutputSchema: mcp.SchemaFor(releaseNotesContextOutput{}),
+		},
+		r.handleGetReleaseNotesContext,
+	)
+}
+
+// handleGetReleaseNotesContext handles the get_release_notes_context tool.
+func (r *Registry) handleGetReleaseNotesContext(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID            string `json:"app_id"`
+		FromBuildID      string `json:"from_build_id"`
+		ToBuildID        string `json:"to_build_id"`
+		Locale           string `json:"locale"`
+		PreviousVersions int    `json:"previous_versions"`
+	}
+	params.Locale = "en-US"
+	params.PreviousVersions = 3
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" || params.FromBuildID == "" || params.ToBuildID == "" {
+		return mcp.NewErrorResult("app_id, from_build_id and to_build_id are required"), nil
+	}
+	if params.Locale == "" {
+		params.Locale = "en-US"
+	}
+	if params.PreviousVersions < 0 {
+		params.PreviousVersions = 0
+	}
+	if params.PreviousVersions > 10 {
+		params.PreviousVersions = 10
+	}
+
+	products, err := r.client.ListCiProducts(ctx, params.AppID, api.ListOptions{Limit: 1})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get Xcode Cloud product: %v", err), err), nil
+	}
+	if len(products.Data) == 0 {
+		return mcp.NewErrorResult(fmt.Sprintf("App %s has no Xcode Cloud product, so commits can't be resolved.", params.AppID)), nil
+	}
+	productID := products.Data[0].ID
+
+	fromRun, err := r.buildRunForBuild(ctx, productID, params.FromBuildID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to resolve from_build_id: %v", err), err), nil
+	}
+	toRun, err := r.buildRunForBuild(ctx, productID, params.ToBuildID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to resolve to_build_id: %v", err), err), nil
+	}
+	if fromRun.Attributes.Number >= toRun.Attributes.Number {
+		return mcp.NewErrorResult(fmt.Sprintf("from_build_id was built by run #%d, which is not older than run #%d for to_build_id.", fromRun.Attributes.Number, toRun.Attributes.Number)), nil
+	}
+
+	output := releaseNotesContextOutput{
+		AppID:            params.AppID,
+		FromBuild:        releaseNotesBuildOf(params.FromBuildID, fromRun),
+		ToBuild:          releaseNotesBuildOf(params.ToBuildID, toRun),
+		Commits:          []releaseNotesCommit{},
+		Tickets:          []string{},
+		PreviousWhatsNew: []releaseNotesWhatsNew{},
+	}
+	workflowID := runWorkflowID(toRun)
+	if workflowID != "" && workflowID != runWorkflowID(fromRun) {
+		output.Notes = append(output.Notes, "The two builds came from different workflows; only runs of the to_build_id workflow are included.")
+	}
+
+	commits, complete, err := r.commitsBetween(ctx, productID, workflowID, fromRun, toRun)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list build runs: %v", err), err), nil
+	}
+	if !complete {
+		output.Notes = append(output.Notes, fmt.Sprintf("Stopped after %d pages of build runs; older commits may be missing.", releaseNotesMaxRunPages))
+	}
+	output.Commits = commits
+
+	tickets := make(map[string]bool)
+	for _, commit := range commits {
+		for _, ticket := range commit.Tickets {
+			tickets[ticket] = true
+		}
+	}
+	for ticket := range tickets {
+		output.Tickets = append(output.Tickets, ticket)
+	}
+	sort.Strings(output.Tickets)
+
+	if params.PreviousVersions > 0 {
+		whatsNew, err := r.previousWhatsNew(ctx, params.AppID, params.Locale, params.PreviousVersions)
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("Previous What's New texts unavailable: %v", err))
+		}
+		output.PreviousWhatsNew = whatsNew
+	}
+
+	return mcp.NewStructuredResult(formatReleaseNotesContext(output), output), nil
+}
+
+// buildRunForBuild returns the Xcode Cloud build run that produced a build.
+func (r *Registry) buildRunForBuild(ctx context.Context, productID, buildID string) (*api.CiBuildRun, error) {
+	resp, err := r.client.ListCiProductBuildRuns(ctx, productID, api.ListOptions{Limit: 1}.WithFilter("builds", buildID))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("build %s was not built by Xcode Cloud", buildID)
+	}
+	return &resp.Data[0], nil
+}
+
+// commitsBetween returns the commits of the workflow's runs after from and up
+// to and including to, newest first. It reports false if it stopped paging
+// before reaching from.
+func (r *Registry) commitsBetween(ctx context.Context, productID, workflowID string, from, to *api.CiBuildRun) ([]releaseNotesCommit, bool, error) {
+	commits := []releaseNotesCommit{}
+	seen := make(map[string]bool)
+	cursor := ""
+
+	for page := 0; page < releaseNotesMaxRunPages; page++ {
+		resp, err := r.client.ListCiProductBuildRuns(api.WithCursor(ctx, cursor), productID, api.ListOptions{Sort: "-number", Limit: 200})
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, run := range resp.Data {
+			number := run.Attributes.Number
+			if number <= from.Attributes.Number {
+				return commits, true, nil
+			}
+			if number > to.Attributes.Number || run.Attributes.IsPullRequestBuild {
+				continue
+			}
+			if workflowID != "" && runWorkflowID(&run) != workflowID {
+				continue
+			}
+			commit := run.Attributes.SourceCommit
+			if commit == nil || commit.CommitSha == "" || seen[commit.CommitSha] {
+				continue
+			}
+			seen[commit.CommitSha] = true
+
+			entry := releaseNotesCommit{
+				Sha:       commit.CommitSha,
+				Message:   strings.TrimSpace(commit.Message),
+				WebURL:    commit.WebURL,
+				RunNumber: number,
+				Tickets:   uniqueTickets(commit.Message),
+			}
+			if commit.Author != nil {
+				entry.Author = commit.Author.DisplayName
+			}
+			commits = append(commits, entry)
+		}
+
+		cursor = resp.Links.NextCursor()
+		if cursor == "" {
+			return commits, true, nil
+		}
+	}
+
+	return commits, false, nil
+}
+
+// previousWhatsNew returns the What's New text of the newest versions that
+// have one in locale.
+func (r *Registry) previousWhatsNew(ctx context.Context, appID, locale string, count int) ([]releaseNotesWhatsNew, error) {
+	whatsNew := []releaseNotesWhatsNew{}
+
+	versions, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return whatsNew, err
+	}
+	sort.SliceStable(versions.Data, func(i, j int) bool {
+		a, b := versions.Data[i].Attributes.CreatedDate, versions.Data[j].Attributes.CreatedDate
+		return a != nil && (b == nil || a.After(*b))
+	})
+
+	for _, version := range versions.Data {
+		if len(whatsNew) == count {
+			break
+		}
+		localizations, err := r.client.ListAppStoreVersionLocalizations(ctx, version.ID, api.ListOptions{})
+		if err != nil {
+			return whatsNew, err
+		}
+		for _, loc := range localizations.Data {
+			if loc.Attributes.Locale != locale || strings.TrimSpace(loc.Attributes.WhatsNew) == "" {
+				continue
+			}
+			whatsNew = append(whatsNew, releaseNotesWhatsNew{
+				VersionID: version.ID,
+				Version:   version.Attributes.VersionString,
+				State:     string(version.Attributes.AppStoreState),
+				Text:      loc.Attributes.WhatsNew,
+			})
+		}
+	}
+
+	return whatsNew, nil
+}
+
+// runWorkflowID returns the ID of a build run's workflow, if it was included.
+func runWorkflowID(run *api.CiBuildRun) string {
+	if run.Relationships == nil || run.Relationships.Workflow == nil {
+		return ""
+	}
+	return run.Relationships.Workflow.Data.ID
+}
+
+// releaseNotesBuildOf describes a build by the run that produced it.
+func releaseNotesBuildOf(buildID string, run *api.CiBuildRun) releaseNotesBuild {
+	build := releaseNotesBuild{BuildID: buildID, RunID: run.ID, RunNumber: run.Attributes.Number}
+	if run.Attributes.SourceCommit != nil {
+		build.CommitSha = run.Attributes.SourceCommit.CommitSha
+	}
+	return build
+}
+
+// uniqueTickets returns the ticket IDs in a commit message, in order of appearance.
+func uniqueTickets(message string) []string {
+	var tickets []string
+	seen := make(map[string]bool)
+	for _, ticket := range ticketPattern.FindAllString(message, -1) {
+		if !seen[ticket] {
+			seen[ticket] = true
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets
+}
+
+// formatReleaseNotesContext renders the context as text for the model.
+func formatReleaseNotesContext(c releaseNotesContextOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Release notes context: run #%d to run #%d\n\n", c.FromBuild.RunNumber, c.ToBuild.RunNumber))
+
+	sb.WriteString(fmt.Sprintf("**Commits (%d)**\n", len(c.Commits)))
+	if len(c.Commits) == 0 {
+		sb.WriteString("  No commits found between these builds.\n")
+	}
+	for _, commit := range c.Commits {
+		sha := commit.Sha
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		sb.WriteString(fmt.Sprintf("  - %s %s", sha, subject))
+		if commit.Author != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", commit.Author))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(c.Tickets) > 0 {
+		sb.WriteString(fmt.Sprintf("\n**Tickets:** %s\n", strings.Join(c.Tickets, ", ")))
+	}
+
+	if len(c.PreviousWhatsNew) > 0 {
+		sb.WriteString("\n**Previous What's New**\n")
+		for _, w := range c.PreviousWhatsNew {
+			sb.WriteString(fmt.Sprintf("\n%s (%s):\n%s\n", w.Version, w.State, w.Text))
+		}
+	}
+
+	for _, note := range c.Notes {
+		sb.WriteString(fmt.Sprintf("\nNote: %s\n", note))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// releaseTypeProperty is the input schema property of a version's release type.
+var releaseTypeProperty = mcp.Property{
+	Type:        "string",
+	Description: "How the version is released once approved: MANUAL (you release it), AFTER_APPROVAL (automatically as soon as it's approved) or SCHEDULED (automatically once approved and earliest_release_date has passed)",
+	Enum:        api.EnumStrings(api.ReleaseTypes),
+}
+
+// earliestReleaseDateProperty is the input schema property of a scheduled
+// version's release date.
+var earliestReleaseDateProperty = mcp.Property{
+	Type:        "string",
+	Description: "With release_type SCHEDULED, the earliest release date and time as RFC 3339, e.g. 2025-06-01T09:00:00-07:00. Must be in the future",
+}
+
+// parseReleaseStrategy validates a release type and earliest release date,
+// either of which may be empty. A date requires the SCHEDULED type, which
+// requires a date after now.
+func parseReleaseStrategy(value, earliestReleaseDate string, now time.Time) (api.ReleaseType, *time.Time, error) {
+	releaseType := api.ReleaseType(strings.ToUpper(strings.TrimSpace(value)))
+	if releaseType != "" && !releaseType.Valid() {
+		return "", nil, fmt.Errorf("release_type must be one of %s, not %q", strings.Join(api.EnumStrings(api.ReleaseTypes), ", "), releaseType)
+	}
+
+	if earliestReleaseDate == "" {
+		if releaseType == api.ReleaseTypeScheduled {
+			return "", nil, fmt.Errorf("earliest_release_date is required with release_type SCHEDULED")
+		}
+		return releaseType, nil, nil
+	}
+	if releaseType != api.ReleaseTypeScheduled {
+		return "", nil, fmt.Errorf("earliest_release_date requires release_type SCHEDULED")
+	}
+	date, err := time.Parse(time.RFC3339, earliestReleaseDate)
+	if err != nil {
+		return "", nil, fmt.Errorf("earliest_release_date must be an RFC 3339 date and time, e.g. 2025-06-01T09:00:00Z: %w", err)
+	}
+	if !date.After(now) {
+		return "", nil, fmt.Errorf("earliest_release_date %s is not in the future", date.Format(time.RFC3339))
+	}
+	return releaseType, &date, nil
+}
+
+// registerReleaseStrategyTools registers the tool changing how a version is released.
+func (r *Registry) registerReleaseStrategyTools() {
+	r.register(mcp.Tool{
+		Name:        "change_release_strategy",
+		Description: "Change how an App Store version is released once approved: manually, automatically after approval, or automatically on a scheduled date. The version must still be editable (prepare for submission or rejected).",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"version_id": {
+					Type:        "string",
+					Description: "The App Store version ID",
+				},
+				"release_type":          releaseTypeProperty,
+				"earliest_release_date": earliestReleaseDateProperty,
+			},
+			Required: []string{"version_id", "release_type"},
+		},
+	}, r.handleChangeReleaseStrategy)
+}
+
+// handleChangeReleaseStrategy handles the change_release_strategy tool.
+func (r *Registry) handleChangeReleaseStrategy(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		VersionID           string `json:"version_id"`
+		ReleaseType         string `json:"release_type"`
+		EarliestReleaseDate string `json:"earliest_release_date"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.VersionID == "" {
+		return mcp.NewErrorResult("version_id is required"), nil
+	}
+	if params.ReleaseType == "" {
+		return mcp.NewErrorResult("release_type is required"), nil
+	}
+	releaseType, earliest, err := parseReleaseStrategy(params.ReleaseType, params.EarliestReleaseDate, time.Now())
+	if err != nil {
+		return mcp.NewErrorResultWithError(err.Error(), err), nil
+	}
+
+	version, err := r.client.GetAppStoreVersion(ctx, params.VersionID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app store version: %v", err), err), nil
+	}
+	attrs := version.Data.Attributes
+	if !editableVersionStates[attrs.AppStoreState] {
+		return mcp.NewErrorResult(fmt.Sprintf("Version %s is %s, so its release strategy can no longer be changed.", attrs.VersionString, attrs.AppStoreState)), nil
+	}
+
+	req := &api.AppStoreVersionUpdateRequest{
+		Data: api.AppStoreVersionUpdateData{
+			Type: "appStoreVersions",
+			ID:   params.VersionID,
+			Attributes: api.AppStoreVersionUpdateAttributes{
+				ReleaseType:         releaseType,
+				EarliestReleaseDate: earliest,
+			},
+		},
+	}
+	if _, err := r.client.UpdateAppStoreVersion(ctx, params.VersionID, req); err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to change release strategy: %v", err), err), nil
+	}
+
+	text := fmt.Sprintf("Version %s will be released %s", attrs.VersionString, describeReleaseStrategy(releaseType, earliest))
+	if attrs.ReleaseType != "" {
+		text += fmt.Sprintf(" (was %s)", describeReleaseStrategy(attrs.ReleaseType, attrs.EarliestReleaseDate))
+	}
+	return mcp.NewSuccessResult(text + "."), nil
+}
+
+// describeReleaseStrategy describes a release type in words.
+func describeReleaseStrategy(releaseType api.ReleaseType, earliest *time.Time) string {
+	switch releaseType {
+	case api.ReleaseTypeManual:
+		return "manually"
+	case api.ReleaseTypeAfterApproval:
+		return "automatically after approval"
+	case api.ReleaseTypeScheduled:
+		if earliest != nil {
+			return "automatically after approval, no earlier than " + earliest.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		return "automatically on a scheduled date"
+	}
+	return string(releaseType)
+}
@@ -0,0 +1,424 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/snapshots"
+)
+
+// Release train steps, in the order they run for each app.
+const (
+	stepCreateVersion = "create_version"
+	stepReleaseNotes  = "release_notes"
+	stepSubmit        = "submit"
+)
+
+// trainSteps lists the release train steps in order.
+var trainSteps = []string{stepCreateVersion, stepReleaseNotes, stepSubmit}
+
+// registerReleaseTrainTools registers tools that apply a release across several apps.
+func (r *Registry) registerReleaseTrainTools() {
+	r.registerWithProgress(
+		mcp.Tool{
+			Name:        "run_release_train",
+			Description: "Ship the same release across several apps: create (or reuse) App Store version X.Y for each app, set its What's New text, and optionally submit it for review. Progress and arguments are saved under the train's name, so calling this again with just the train resumes where it stopped and retries only failed steps. A failure in one app doesn't stop the others.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"train": {
+						Type:        "string",
+						Description: "Name of the release train, used to track and resume its progress (e.g. spring-2025)",
+					},
+					"app_ids": {
+						Type:        "array",
+						Description: "App Store Connect IDs of the apps in the train, or names of app groups from list_app_groups. Required when starting a train; when resuming, defaults to the train's apps and any new IDs are added.",
+						Items:       &mcp.Property{Type: "string"},
+					},
+					"version_string": {
+						Type:        "string",
+						Description: "Version to create in every app, e.g. 2.1. Required when starting a train.",
+					},
+					"platform": {
+						Type:        "string",
+						Description: "Platform of the versions (default: IOS)",
+						Enum:        api.EnumStrings(api.Platforms),
+					},
+					"whats_new": {
+						Type:        "string",
+						Description: "Optional: What's New text for every localization of each version",
+					},
+					"whats_new_by_locale": {
+						Type:        "object",
+						Description: "Optional: What's New text keyed by locale (e.g. {\"de-DE\": \"...\"}), overriding whats_new for those locales",
+					},
+					"submit": {
+						Type:        "boolean",
+						Description: "Submit each version for review once its release notes are set (default: false)",
+					},
+				},
+				Required: []string{"train"},
+			},
+			OutputSchema: mcp.SchemaFor(releaseTrainOutput{}),
+		},
+		r.handleRunReleaseTrain,
+	)
+
+	r.register(
+		mcp.Tool{
+			Name:        "get_release_train",
+			Description: "Get the saved progress of a release train started with run_release_train: each app's version and the outcome of each step.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"train": {
+						Type:        "string",
+						Description: "Name of the release train",
+					},
+				},
+				Required: []string{"train"},
+			},
+			OutputSchema: mcp.SchemaFor(releaseTrainOutput{}),
+		},
+		r.handleGetReleaseTrain,
+	)
+}
+
+// releaseTrainParams are the arguments of run_release_train.
+type releaseTrainParams struct {
+	Train            string            `json:"train"`
+	AppIDs           []string          `json:"app_ids"`
+	VersionString    string            `json:"version_string"`
+	Platform         string            `json:"platform"`
+	WhatsNew         string            `json:"whats_new"`
+	WhatsNewByLocale map[string]string `json:"whats_new_by_locale"`
+	Submit           bool              `json:"submit"`
+}
+
+// handleRunReleaseTrain handles the run_release_train tool.
+func (r *Registry) handleRunReleaseTrain(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	var params releaseTrainParams
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.Train == "" {
+		return mcp.NewErrorResult("train is required"), nil
+	}
+
+	train, resuming := r.snapshots.ReleaseTrain(params.Train)
+	if resuming {
+		if params.VersionString != "" && params.VersionString != train.VersionString {
+			return mcp.NewErrorResult(fmt.Sprintf("Release train %q ships version %s, not %s. Use a new train name for another version.", params.Train, train.VersionString, params.VersionString)), nil
+		}
+		if params.Platform != "" && params.Platform != train.Platform {
+			return mcp.NewErrorResult(fmt.Sprintf("Release train %q ships on %s, not %s.", params.Train, train.Platform, params.Platform)), nil
+		}
+		// A resumed train keeps its release notes unless new ones are given.
+		if params.WhatsNew == "" && len(params.WhatsNewByLocale) == 0 {
+			params.WhatsNew, params.WhatsNewByLocale = train.WhatsNew, train.WhatsNewByLocale
+		}
+		params.Submit = params.Submit || train.Submit
+	} else {
+		if len(params.AppIDs) == 0 {
+			return mcp.NewErrorResult("app_ids is required when starting a release train"), nil
+		}
+		if params.VersionString == "" {
+			return mcp.NewErrorResult("version_string is required when starting a release train"), nil
+		}
+		if params.Platform == "" {
+			params.Platform = string(api.PlatformIOS)
+		}
+		train = snapshots.ReleaseTrain{
+			Name:          params.Train,
+			VersionString: params.VersionString,
+			Platform:      params.Platform,
+			Apps:          make(map[string]*snapshots.TrainApp),
+		}
+	}
+
+	params.AppIDs = r.expandAppIDs(params.AppIDs)
+	train.WhatsNew, train.WhatsNewByLocale, train.Submit = params.WhatsNew, params.WhatsNewByLocale, params.Submit
+
+	appIDs := trainAppIDs(train, params.AppIDs)
+	for _, appID := range appIDs {
+		if train.Apps[appID] == nil {
+			train.Apps[appID] = &snapshots.TrainApp{Steps: make(map[string]snapshots.TrainStep)}
+		}
+	}
+
+	for i, appID := range appIDs {
+		progress(float64(i), float64(len(appIDs)), fmt.Sprintf("Releasing %s in app %s", train.VersionString, appID))
+
+		err := r.runTrainApp(ctx, train, appID, params)
+		if errors.Is(err, api.ErrDryRun) {
+			// A confirmation preview: nothing was sent, so nothing is recorded.
+			return mcp.NewErrorResultWithError(err.Error(), err), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := r.snapshots.SaveReleaseTrain(train); err != nil {
+			log.Printf("failed to save release train %s: %v", train.Name, err)
+		}
+	}
+	progress(float64(len(appIDs)), float64(len(appIDs)), "Release train finished")
+
+	requested := map[string]bool{
+		stepCreateVersion: true,
+		stepReleaseNotes:  params.WhatsNew != "" || len(params.WhatsNewByLocale) > 0,
+		stepSubmit:        params.Submit,
+	}
+	output := releaseTrainOutputFor(train, appIDs, requested)
+	return mcp.NewStructuredResult(formatReleaseTrain(output), output), nil
+}
+
+// trainAppIDs returns the apps a run covers: those requested, in order, after
+// those already in the train. Duplicates are dropped.
+func trainAppIDs(train snapshots.ReleaseTrain, requested []string) []string {
+	seen := make(map[string]bool)
+	var appIDs []string
+	add := func(appID string) {
+		if appID != "" && !seen[appID] {
+			seen[appID] = true
+			appIDs = append(appIDs, appID)
+		}
+	}
+
+	var existing []string
+	for appID := range train.Apps {
+		existing = append(existing, appID)
+	}
+	sort.Strings(existing)
+	for _, appID := range existing {
+		add(appID)
+	}
+	for _, appID := range requested {
+		add(appID)
+	}
+	return appIDs
+}
+
+// runTrainApp runs the outstanding steps for one app, recording each outcome
+// in train. A failed step stops the app's later steps. It returns
+// api.ErrDryRun if a request was only previewed.
+func (r *Registry) runTrainApp(ctx context.Context, train snapshots.ReleaseTrain, appID string, params releaseTrainParams) error {
+	app := train.Apps[appID]
+
+	for _, step := range trainSteps {
+		if app.Steps[step].Done {
+			continue
+		}
+
+		var err error
+		switch step {
+		case stepCreateVersion:
+			app.VersionID, err = r.ensureTrainVersion(ctx, train, appID)
+		case stepReleaseNotes:
+			if params.WhatsNew == "" && len(params.WhatsNewByLocale) == 0 {
+				continue
+			}
+			err = r.setTrainReleaseNotes(ctx, app.VersionID, params)
+		case stepSubmit:
+			if !params.Submit {
+				continue
+			}
+			err = r.submitTrainVersion(ctx, app.VersionID)
+		}
+		if errors.Is(err, api.ErrDryRun) {
+			return err
+		}
+
+		result := snapshots.TrainStep{Done: err == nil, At: time.Now().UTC()}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		app.Steps[step] = result
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureTrainVersion returns the ID of the app's version for the train,
+// creating it unless a version with that string already exists.
+func (r *Registry) ensureTrainVersion(ctx context.Context, train snapshots.ReleaseTrain, appID string) (string, error) {
+	versions, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return "", fmt.Errorf("failed to get app versions: %w", err)
+	}
+	r.recordVersions(appID, versions.Data)
+	for _, v := range versions.Data {
+		if v.Attributes.VersionString == train.VersionString && string(v.Attributes.Platform) == train.Platform {
+			return v.ID, nil
+		}
+	}
+
+	resp, err := r.client.CreateAppStoreVersion(ctx, &api.AppStoreVersionCreateRequest{
+		Data: api.AppStoreVersionCreateData{
+			Type: "appStoreVersions",
+			Attributes: api.AppStoreVersionCreateAttributes{
+				Platform:      api.Platform(train.Platform),
+				VersionString: train.VersionString,
+			},
+			Relationships: api.AppStoreVersionCreateRelationships{
+				App: api.RelationshipData{
+					Data: api.ResourceIdentifier{Type: "apps", ID: appID},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create app store version: %w", err)
+	}
+	return resp.Data.ID, nil
+}
+
+// setTrainReleaseNotes sets What's New on every localization of a version
+// that has text for its locale.
+func (r *Registry) setTrainReleaseNotes(ctx context.Context, versionID string, params releaseTrainParams) error {
+	localizations, err := r.client.ListAppStoreVersionLocalizations(ctx, versionID, api.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list version localizations: %w", err)
+	}
+
+	for _, loc := range localizations.Data {
+		notes, ok := params.WhatsNewByLocale[loc.Attributes.Locale]
+		if !ok {
+			notes = params.WhatsNew
+		}
+		if notes == "" || notes == loc.Attributes.WhatsNew {
+			continue
+		}
+
+		_, err := r.client.UpdateAppStoreVersionLocalization(ctx, loc.ID, &api.AppStoreVersionLocalizationUpdateRequest{
+			Data: api.AppStoreVersionLocalizationUpdateData{
+				Type:       "appStoreVersionLocalizations",
+				ID:         loc.ID,
+				Attributes: api.AppStoreVersionLocalizationUpdateAttributes{WhatsNew: notes},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update %s release notes: %w", loc.Attributes.Locale, err)
+		}
+	}
+	return nil
+}
+
+// submitTrainVersion submits a version for review.
+func (r *Registry) submitTrainVersion(ctx context.Context, versionID string) error {
+	_, err := r.client.CreateAppStoreVersionSubmission(ctx, &api.AppStoreVersionSubmissionCreateRequest{
+		Data: api.AppStoreVersionSubmissionCreateData{
+			Type: "appStoreVersionSubmissions",
+			Relationships: api.AppStoreVersionSubmissionCreateRelationships{
+				AppStoreVersion: api.RelationshipData{
+					Data: api.ResourceIdentifier{Type: "appStoreVersions", ID: versionID},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit for review: %w", err)
+	}
+	return nil
+}
+
+// handleGetReleaseTrain handles the get_release_train tool.
+func (r *Registry) handleGetReleaseTrain(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Train string `json:"train"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.Train == "" {
+		return mcp.NewErrorResult("train is required"), nil
+	}
+
+	train, ok := r.snapshots.ReleaseTrain(params.Train)
+	if !ok {
+		return mcp.NewErrorResult(fmt.Sprintf("No release train named %q has been run.", params.Train)), nil
+	}
+
+	output := releaseTrainOutputFor(train, trainAppIDs(train, nil), nil)
+	return mcp.NewStructuredResult(formatReleaseTrain(output), output), nil
+}
+
+// releaseTrainOutputFor summarizes a train's apps. Steps that haven't run
+// are reported as pending, or skipped when an earlier step failed or the run
+// didn't ask for them. A nil requested reports every step that hasn't run as
+// pending.
+func releaseTrainOutputFor(train snapshots.ReleaseTrain, appIDs []string, requested map[string]bool) releaseTrainOutput {
+	output := releaseTrainOutput{
+		Train:         train.Name,
+		VersionString: train.VersionString,
+		Platform:      train.Platform,
+		Apps:          make([]releaseTrainApp, 0, len(appIDs)),
+	}
+
+	for _, appID := range appIDs {
+		app := train.Apps[appID]
+		out := releaseTrainApp{AppID: appID, VersionID: app.VersionID}
+		failed := false
+		for _, step := range trainSteps {
+			result, ran := app.Steps[step]
+			status := "pending"
+			switch {
+			case ran && result.Done:
+				status = "done"
+			case ran:
+				status = "failed"
+				failed = true
+			case failed || (requested != nil && !requested[step]):
+				status = "skipped"
+			}
+			out.Steps = append(out.Steps, releaseTrainStep{Step: step, Status: status, Error: result.Error})
+		}
+		if failed {
+			output.Failed++
+		} else {
+			output.Succeeded++
+		}
+		output.Apps = append(output.Apps, out)
+	}
+	return output
+}
+
+// formatReleaseTrain renders a release train's progress per app.
+func formatReleaseTrain(o releaseTrainOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Release train %q: version %s (%s) across %d apps, %d succeeded, %d failed\n\n",
+		o.Train, o.VersionString, o.Platform, len(o.Apps), o.Succeeded, o.Failed))
+
+	for _, app := range o.Apps {
+		sb.WriteString(fmt.Sprintf("- App %s", app.AppID))
+		if app.VersionID != "" {
+			sb.WriteString(fmt.Sprintf(" (version ID: %s)", app.VersionID))
+		}
+		sb.WriteString("\n")
+		for _, step := range app.Steps {
+			sb.WriteString(fmt.Sprintf("  - %s: %s", step.Step, step.Status))
+			if step.Error != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", step.Error))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if o.Failed > 0 {
+		sb.WriteString(fmt.Sprintf("\nCall run_release_train with train %q again to retry the failed steps.\n", o.Train))
+	}
+	return sb.String()
+}
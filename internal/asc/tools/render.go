@@ -0,0 +1,361 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// Output formats accepted in a tool's format argument.
+const (
+	// formatText keeps the tool's own text summary. It is the default.
+	formatText = "text"
+
+	// formatJSON replaces the text with the structured content as JSON.
+	formatJSON = "json"
+
+	// formatMarkdown renders lists as markdown tables and single resources as
+	// field tables.
+	formatMarkdown = "markdown"
+
+	// formatSummary renders one line per listed item.
+	formatSummary = "summary"
+)
+
+const (
+	// maxTableColumns caps the columns of a rendered markdown table.
+	maxTableColumns = 8
+
+	// maxCellLength caps the length of a rendered value.
+	maxCellLength = 80
+)
+
+// formatProperty is the input schema property added to tools that return
+// structured content.
+var formatProperty = mcp.Property{
+	Type:        "string",
+	Description: "Optional: How to render the result text: text (default), json for the raw structured result, markdown for tables, or summary for one line per item",
+	Enum:        []string{formatText, formatJSON, formatMarkdown, formatSummary},
+}
+
+// summaryNameKeys are the fields that name an item in a summary line, in
+// order of preference.
+var summaryNameKeys = []string{"name", "referenceName", "versionString", "version", "title", "locale", "email", "username", "productId", "bundleId"}
+
+// formatArg returns the format argument of a tool call, if any.
+func formatArg(args json.RawMessage) string {
+	var params struct {
+		Format string `json:"format"`
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return ""
+	}
+	return params.Format
+}
+
+// renderResult replaces a result's text with its structured content rendered
+// in format. Errors, results without structured content and the default
+// format are returned unchanged.
+func renderResult(result *mcp.ToolsCallResult, format string) *mcp.ToolsCallResult {
+	if result == nil || result.IsError || result.StructuredContent == nil || format == "" || format == formatText {
+		return result
+	}
+
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return result
+	}
+
+	var text string
+	switch format {
+	case formatJSON:
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			return result
+		}
+		text = indented.String()
+	case formatMarkdown, formatSummary:
+		value, err := decodeOrdered(data)
+		if err != nil {
+			return result
+		}
+		if format == formatMarkdown {
+			text = renderMarkdown(value)
+		} else {
+			text = renderSummary(value)
+		}
+	default:
+		return result
+	}
+
+	rendered := *result
+	rendered.Content = []mcp.ContentBlock{mcp.NewTextContent(text)}
+	return &rendered
+}
+
+// field is one member of a JSON object, kept in document order.
+type field struct {
+	key   string
+	value any
+}
+
+// decodeOrdered decodes JSON into []field for objects, []any for arrays and
+// json.Number, string, bool or nil for scalars, keeping the order of object
+// members so tables follow the order of the struct fields.
+func decodeOrdered(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrderedValue(dec)
+}
+
+// decodeOrderedValue decodes the next value from dec.
+func decodeOrderedValue(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		fields := []field{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	default:
+		return token, nil
+	}
+}
+
+// mainList returns the first list of objects in a structured result and its
+// name, along with the result's other scalar fields.
+func mainList(value any) (name string, items []any, rest []field) {
+	if list, ok := value.([]any); ok {
+		return "items", list, nil
+	}
+
+	fields, _ := value.([]field)
+	for _, f := range fields {
+		if list, ok := f.value.([]any); ok && items == nil && isObjectList(list) {
+			name, items = f.key, list
+			continue
+		}
+		if isScalar(f.value) {
+			rest = append(rest, f)
+		}
+	}
+	return name, items, rest
+}
+
+// isObjectList reports whether a list is empty or holds objects.
+func isObjectList(list []any) bool {
+	for _, item := range list {
+		if _, ok := item.([]field); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenFields returns an item's scalar fields. Attributes of API resources
+// are listed as if they were top-level fields; type, links and
+// relationships are left out.
+func flattenFields(item any) []field {
+	fields, _ := item.([]field)
+	var flat []field
+	for _, f := range fields {
+		switch {
+		case f.key == "type" || f.key == "links" || f.key == "relationships":
+		case f.key == "attributes":
+			if attributes, ok := f.value.([]field); ok {
+				for _, attribute := range attributes {
+					if isScalar(attribute.value) {
+						flat = append(flat, attribute)
+					}
+				}
+			}
+		case isScalar(f.value):
+			flat = append(flat, f)
+		}
+	}
+	return flat
+}
+
+// isScalar reports whether a decoded value is a string, number, bool or null.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case []field, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+// renderMarkdown renders a result's main list as a table, or a single
+// resource as a field table.
+func renderMarkdown(value any) string {
+	var sb strings.Builder
+	name, items, rest := mainList(value)
+
+	if items == nil {
+		sb.WriteString("| Field | Value |\n|-------|-------|\n")
+		for _, f := range flattenFields(value) {
+			if text := cellText(f.value); text != "" {
+				sb.WriteString(fmt.Sprintf("| %s | %s |\n", f.key, text))
+			}
+		}
+		return sb.String()
+	}
+
+	for _, f := range rest {
+		if text := cellText(f.value); text != "" {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", f.key, text))
+		}
+	}
+	if len(items) == 0 {
+		sb.WriteString(fmt.Sprintf("No %s.\n", name))
+		return sb.String()
+	}
+	if len(rest) > 0 {
+		sb.WriteString("\n")
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := make(map[string]string)
+		for _, f := range flattenFields(item) {
+			text := cellText(f.value)
+			if text == "" {
+				continue
+			}
+			row[f.key] = text
+			if !seen[f.key] && len(columns) < maxTableColumns {
+				seen[f.key] = true
+				columns = append(columns, f.key)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	sb.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat("---|", len(columns)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = row[column]
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// renderSummary renders a count and one line per listed item, naming each by
+// its most descriptive field and ID.
+func renderSummary(value any) string {
+	var sb strings.Builder
+	name, items, rest := mainList(value)
+
+	if items == nil {
+		var parts []string
+		for _, f := range flattenFields(value) {
+			if text := cellText(f.value); text != "" {
+				parts = append(parts, fmt.Sprintf("%s: %s", f.key, text))
+			}
+		}
+		sb.WriteString(strings.Join(parts, "; ") + "\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%d %s\n", len(items), name))
+	for _, item := range items {
+		sb.WriteString("- " + summaryLine(flattenFields(item)) + "\n")
+	}
+	for _, f := range rest {
+		if text := cellText(f.value); text != "" {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", f.key, text))
+		}
+	}
+	return sb.String()
+}
+
+// summaryLine names an item by its most descriptive field, followed by its ID.
+func summaryLine(fields []field) string {
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.key] = cellText(f.value)
+	}
+
+	label := ""
+	for _, key := range summaryNameKeys {
+		if values[key] != "" {
+			label = values[key]
+			break
+		}
+	}
+	if label == "" {
+		for _, f := range fields {
+			if text, ok := f.value.(string); ok && text != "" && f.key != "id" {
+				label = cellText(text)
+				break
+			}
+		}
+	}
+
+	switch {
+	case label == "":
+		return values["id"]
+	case values["id"] == "":
+		return label
+	default:
+		return fmt.Sprintf("%s (%s)", label, values["id"])
+	}
+}
+
+// cellText renders a scalar for a table cell, on one line and escaped for
+// markdown tables. Nulls and empty strings render as "".
+func cellText(value any) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		text = v
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.ReplaceAll(text, "|", `\|`)
+	if runes := []rune(text); len(runes) > maxCellLength {
+		text = string(runes[:maxCellLength-1]) + "…"
+	}
+	return text
+}
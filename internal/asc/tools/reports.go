@@ -1,17 +1,23 @@
 package tools
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
 
+// reportPreviewRows is how many rows of a report are shown after its header.
+const reportPreviewRows = 20
+
 // registerReportsTools registers sales and finance report tools.
 func (r *Registry) registerReportsTools() {
 	// Get sales report
-	r.register(mcp.Tool{
+	r.registerWithProgress(mcp.Tool{
 		Name:        "get_sales_report",
 		Description: "Download sales and trends reports",
 		InputSchema: mcp.JSONSchema{
@@ -43,7 +49,7 @@ func (r *Registry) registerReportsTools() {
 	}, r.handleGetSalesReport)
 
 	// Get finance report
-	r.register(mcp.Tool{
+	r.registerWithProgress(mcp.Tool{
 		Name:        "get_finance_report",
 		Description: "Download financial reports",
 		InputSchema: mcp.JSONSchema{
@@ -71,7 +77,7 @@ func (r *Registry) registerReportsTools() {
 	}, r.handleGetFinanceReport)
 }
 
-func (r *Registry) handleGetSalesReport(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetSalesReport(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VendorNumber  string `json:"vendor_number"`
 		ReportType    string `json:"report_type"`
@@ -99,16 +105,19 @@ func (r *Registry) handleGetSalesReport(args json.RawMessage) (*mcp.ToolsCallRes
 		return nil, fmt.Errorf("report_date is required")
 	}
 
-	data, err := r.client.GetSalesReport(context.Background(), params.VendorNumber, params.ReportType, params.ReportSubType, params.Frequency, params.ReportDate)
+	report, err := r.client.GetSalesReport(withDownloadProgress(ctx, progress), params.VendorNumber, params.ReportType, params.ReportSubType, params.Frequency, params.ReportDate)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get sales report: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get sales report: %v", err), err), nil
 	}
 
-	// Sales reports are typically gzip-compressed TSV files
-	return mcp.NewSuccessResult(fmt.Sprintf("Sales report downloaded (%d bytes). Data is gzip-compressed TSV format.\n\nFirst 1000 bytes:\n%s", len(data), truncateString(string(data), 1000))), nil
+	text, err := formatReport("Sales", report)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read sales report: %v", err), err), nil
+	}
+	return mcp.NewSuccessResult(text), nil
 }
 
-func (r *Registry) handleGetFinanceReport(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetFinanceReport(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VendorNumber string `json:"vendor_number"`
 		RegionCode   string `json:"region_code"`
@@ -132,13 +141,61 @@ func (r *Registry) handleGetFinanceReport(args json.RawMessage) (*mcp.ToolsCallR
 		return nil, fmt.Errorf("report_date is required")
 	}
 
-	data, err := r.client.GetFinanceReport(context.Background(), params.VendorNumber, params.RegionCode, params.ReportType, params.ReportDate)
+	report, err := r.client.GetFinanceReport(withDownloadProgress(ctx, progress), params.VendorNumber, params.RegionCode, params.ReportType, params.ReportDate)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get finance report: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get finance report: %v", err), err), nil
+	}
+
+	text, err := formatReport("Finance", report)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read finance report: %v", err), err), nil
+	}
+	return mcp.NewSuccessResult(text), nil
+}
+
+// formatReport reads a report's rows, showing its header and first rows and
+// counting the rest.
+func formatReport(kind string, report *api.Report) (string, error) {
+	var preview strings.Builder
+	rows := 0
+	scanner := bufio.NewScanner(report.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if rows == 0 && line == "" {
+			continue
+		}
+		if rows <= reportPreviewRows {
+			preview.WriteString(line + "\n")
+		}
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
 	}
 
-	// Finance reports are typically gzip-compressed TSV files
-	return mcp.NewSuccessResult(fmt.Sprintf("Finance report downloaded (%d bytes). Data is gzip-compressed TSV format.\n\nFirst 1000 bytes:\n%s", len(data), truncateString(string(data), 1000))), nil
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s report downloaded", kind))
+	if report.Filename != "" {
+		sb.WriteString(fmt.Sprintf(" as %s", report.Filename))
+	}
+	sb.WriteString(fmt.Sprintf(" (%d bytes", report.DownloadedBytes))
+	if report.Compressed {
+		sb.WriteString(" compressed")
+	}
+	sb.WriteString(").\n")
+	if rows == 0 {
+		sb.WriteString("\nThe report is empty.\n")
+		return sb.String(), nil
+	}
+
+	dataRows := rows - 1
+	sb.WriteString(fmt.Sprintf("%d rows of tab-separated data.\n\n", dataRows))
+	sb.WriteString(preview.String())
+	if dataRows > reportPreviewRows {
+		sb.WriteString(fmt.Sprintf("... %d more rows\n", dataRows-reportPreviewRows))
+	}
+	return sb.String(), nil
 }
 
 func truncateString(s string, maxLen int) string {
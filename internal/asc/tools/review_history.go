@@ -0,0 +1,440 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+	"github.com/antisynthesis/asc-mcp/internal/asc/snapshots"
+)
+
+// Version states that mark the steps of an App Review cycle.
+var (
+	submittedStates = map[api.AppStoreState]bool{
+		api.AppStoreStateWaitingForReview: true,
+		api.AppStoreStateReadyForReview:   true,
+	}
+	approvedStates = map[api.AppStoreState]bool{
+		api.AppStoreStatePendingDeveloperRelease: true,
+		api.AppStoreStatePendingAppleRelease:     true,
+		api.AppStoreStateProcessingForAppStore:   true,
+		api.AppStoreStateReadyForSale:            true,
+		api.AppStoreStateAccepted:                true,
+	}
+	rejectedStates = map[api.AppStoreState]bool{
+		api.AppStoreStateRejected:         true,
+		api.AppStoreStateMetadataRejected: true,
+		api.AppStoreStateInvalidBinary:    true,
+	}
+)
+
+// minEstimateReviews is how many completed reviews of an app an estimate
+// needs before it stops falling back to every recorded app.
+const minEstimateReviews = 3
+
+// SetSnapshotStore replaces the in-memory store in which version state
+// changes are recorded.
+func (r *Registry) SetSnapshotStore(store *snapshots.Store) {
+	r.snapshots = store
+}
+
+// recordVersions records the current state of an app's versions, so review
+// turnaround can be measured later.
+func (r *Registry) recordVersions(appID string, versions []api.AppStoreVersion) {
+	for _, v := range versions {
+		_, err := r.snapshots.RecordVersionState(appID, v.ID, v.Attributes.VersionString, string(v.Attributes.Platform), string(v.Attributes.AppStoreState))
+		if err != nil {
+			log.Printf("failed to record version %s state: %v", v.ID, err)
+			return
+		}
+	}
+}
+
+// registerReviewHistoryTools registers tools that report on recorded version history.
+func (r *Registry) registerReviewHistoryTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "get_review_turnaround",
+			Description: "Report how long App Review took for recent releases: time waiting for review, time in review, and total time from submission to a decision, with median, mean, min and max. Based on version state changes this server has recorded, so timings are only as precise as how often versions were checked.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "Optional: The App Store Connect ID of the app. Its versions are checked first. Omit to report on every recorded app.",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Number of most recent reviews to include (default: 10)",
+						Default:     10,
+					},
+				},
+			},
+			OutputSchema: mcp.SchemaFor(reviewTurnaroundOutput{}),
+		},
+		r.handleGetReviewTurnaround,
+	)
+
+	r.register(
+		mcp.Tool{
+			Name:        "get_review_estimate",
+			Description: "Estimate when App Review will decide on a version that is waiting for or in review, from how long this app's recorded reviews took. Falls back to every recorded app when the app has fewer than 3 completed reviews. Reports the typical (median) decision time and a likely-by time that 90% of recorded reviews beat. Without a version under review, reports the typical durations only.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"version_id": {
+						Type:        "string",
+						Description: "Optional: The App Store version to estimate. Defaults to the version currently waiting for or in review.",
+					},
+				},
+				Required: []string{"app_id"},
+			},
+			OutputSchema: mcp.SchemaFor(reviewEstimateOutput{}),
+		},
+		r.handleGetReviewEstimate,
+	)
+}
+
+// handleGetReviewTurnaround handles the get_review_turnaround tool.
+func (r *Registry) handleGetReviewTurnaround(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID string `json:"app_id"`
+		Limit int    `json:"limit"`
+	}
+
+	if args != nil {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+
+	if params.AppID != "" {
+		versions, err := r.client.GetAppVersions(ctx, params.AppID, api.ListOptions{Limit: 50})
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app versions: %v", err), err), nil
+		}
+		r.recordVersions(params.AppID, versions.Data)
+	}
+
+	var reviews []reviewTurnaround
+	for _, history := range r.snapshots.VersionHistories(params.AppID) {
+		reviews = append(reviews, reviewCycles(history)...)
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].SubmittedAt > reviews[j].SubmittedAt
+	})
+	if len(reviews) > params.Limit {
+		reviews = reviews[:params.Limit]
+	}
+
+	output := reviewTurnaroundOutput{AppID: params.AppID, Reviews: reviews}
+	if output.Reviews == nil {
+		output.Reviews = []reviewTurnaround{}
+	}
+	var waiting, inReview, total []float64
+	for _, review := range reviews {
+		if review.WaitingHours != nil {
+			waiting = append(waiting, *review.WaitingHours)
+		}
+		if review.InReviewHours != nil {
+			inReview = append(inReview, *review.InReviewHours)
+		}
+		if review.TotalHours != nil {
+			total = append(total, *review.TotalHours)
+		}
+	}
+	output.Waiting = durationStatsFor(waiting)
+	output.InReview = durationStatsFor(inReview)
+	output.Total = durationStatsFor(total)
+
+	return mcp.NewStructuredResult(formatReviewTurnaround(output), output), nil
+}
+
+// handleGetReviewEstimate handles the get_review_estimate tool.
+func (r *Registry) handleGetReviewEstimate(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID     string `json:"app_id"`
+		VersionID string `json:"version_id"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" {
+		return mcp.NewErrorResult("app_id is required"), nil
+	}
+
+	versions, err := r.client.GetAppVersions(ctx, params.AppID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app versions: %v", err), err), nil
+	}
+	r.recordVersions(params.AppID, versions.Data)
+
+	output := reviewEstimateOutput{AppID: params.AppID, Basis: "app"}
+	var current *reviewTurnaround
+	var completed []reviewTurnaround
+	for _, history := range r.snapshots.VersionHistories(params.AppID) {
+		cycles := reviewCycles(history)
+		for i, cycle := range cycles {
+			if cycle.DecidedAt != "" {
+				completed = append(completed, cycle)
+				continue
+			}
+			// Only the latest cycle of a version can still be under review.
+			if i == len(cycles)-1 && current == nil && underReview(api.AppStoreState(history.State())) &&
+				(params.VersionID == "" || params.VersionID == history.VersionID) {
+				current = &cycles[i]
+				output.State = history.State()
+			}
+		}
+	}
+	if params.VersionID != "" && current == nil {
+		return mcp.NewErrorResult(fmt.Sprintf("Version %s is not waiting for or in review.", params.VersionID)), nil
+	}
+
+	if len(completed) < minEstimateReviews {
+		output.Basis = "account"
+		completed = nil
+		for _, history := range r.snapshots.VersionHistories("") {
+			for _, cycle := range reviewCycles(history) {
+				if cycle.DecidedAt != "" {
+					completed = append(completed, cycle)
+				}
+			}
+		}
+	}
+
+	var waiting, inReview, total []float64
+	for _, review := range completed {
+		if review.WaitingHours != nil {
+			waiting = append(waiting, *review.WaitingHours)
+		}
+		if review.InReviewHours != nil {
+			inReview = append(inReview, *review.InReviewHours)
+		}
+		total = append(total, *review.TotalHours)
+	}
+	output.Reviews = len(completed)
+	output.Waiting = durationStatsFor(waiting)
+	output.InReview = durationStatsFor(inReview)
+	output.Total = durationStatsFor(total)
+
+	if current != nil {
+		estimateDecision(&output, current, time.Now())
+	}
+
+	return mcp.NewStructuredResult(formatReviewEstimate(output), output), nil
+}
+
+// underReview reports whether a version state is waiting for or in review.
+func underReview(state api.AppStoreState) bool {
+	return submittedStates[state] || state == api.AppStoreStateInReview
+}
+
+// estimateDecision fills in the estimate for a version under review. Once
+// review has started, the estimate runs from the review start using the
+// in-review durations; before that it runs from submission using total times.
+func estimateDecision(o *reviewEstimateOutput, current *reviewTurnaround, now time.Time) {
+	o.VersionID = current.VersionID
+	o.VersionString = current.VersionString
+	o.SubmittedAt = current.SubmittedAt
+	o.ReviewStartedAt = current.ReviewStartedAt
+
+	from, stats := current.SubmittedAt, o.Total
+	if current.ReviewStartedAt != "" && o.InReview != nil {
+		from, stats = current.ReviewStartedAt, o.InReview
+	}
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return
+	}
+	o.ElapsedHours = hoursBetween(start, now)
+	if stats == nil {
+		return
+	}
+
+	expected := start.Add(time.Duration(stats.MedianHours * float64(time.Hour)))
+	likely := start.Add(time.Duration(stats.P90Hours * float64(time.Hour)))
+	o.ExpectedDecisionAt = expected.UTC().Format(time.RFC3339)
+	o.LikelyDecisionBy = likely.UTC().Format(time.RFC3339)
+	o.Overdue = now.After(likely)
+}
+
+// formatReviewEstimate renders a review estimate.
+func formatReviewEstimate(o reviewEstimateOutput) string {
+	var sb strings.Builder
+
+	basis := "this app's"
+	if o.Basis == "account" {
+		basis = "all recorded apps'"
+	}
+	if o.Total == nil {
+		sb.WriteString("No completed App Review submissions recorded yet, so there is nothing to estimate from. Version states are recorded whenever versions are listed.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Based on %s %d completed reviews: submission to decision median %.1fh, 90%% within %.1fh", basis, o.Reviews, o.Total.MedianHours, o.Total.P90Hours))
+		if o.InReview != nil {
+			sb.WriteString(fmt.Sprintf("; in review median %.1fh", o.InReview.MedianHours))
+		}
+		sb.WriteString("\n")
+	}
+
+	if o.VersionID == "" {
+		sb.WriteString("No version is waiting for or in review.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nVersion %s is %s, submitted %s", o.VersionString, o.State, o.SubmittedAt))
+	if o.ReviewStartedAt != "" {
+		sb.WriteString(fmt.Sprintf(", in review since %s", o.ReviewStartedAt))
+	}
+	sb.WriteString("\n")
+	if o.ExpectedDecisionAt != "" {
+		sb.WriteString(fmt.Sprintf("Expected decision around %s, likely by %s\n", o.ExpectedDecisionAt, o.LikelyDecisionBy))
+	}
+	if o.Overdue {
+		sb.WriteString("This review is taking longer than 90% of recorded reviews.\n")
+	}
+	return sb.String()
+}
+
+// reviewCycles splits a version's history into review cycles. A cycle starts
+// when the version is seen waiting for review (or already in review) and ends
+// at the first approval or rejection; a rejected version that is resubmitted
+// starts another cycle. Reviews withdrawn by the developer are left out.
+func reviewCycles(h snapshots.VersionHistory) []reviewTurnaround {
+	var cycles []reviewTurnaround
+	var current *reviewTurnaround
+	var submitted, started time.Time
+
+	for _, t := range h.Transitions {
+		state := api.AppStoreState(t.State)
+		switch {
+		case submittedStates[state] || (state == api.AppStoreStateInReview && current == nil):
+			if current == nil {
+				current = &reviewTurnaround{
+					VersionID:     h.VersionID,
+					VersionString: h.VersionString,
+					Platform:      h.Platform,
+					SubmittedAt:   t.At.Format(time.RFC3339),
+					Outcome:       "pending",
+				}
+				submitted, started = t.At, time.Time{}
+			}
+			if state == api.AppStoreStateInReview {
+				started = t.At
+				current.ReviewStartedAt = t.At.Format(time.RFC3339)
+			}
+		case state == api.AppStoreStateInReview:
+			if started.IsZero() {
+				started = t.At
+				current.ReviewStartedAt = t.At.Format(time.RFC3339)
+				current.WaitingHours = hoursBetween(submitted, started)
+			}
+		case current != nil && state == api.AppStoreStateDeveloperRejected:
+			// Withdrawn from review; there is no decision to time.
+			current = nil
+		case current != nil && (approvedStates[state] || rejectedStates[state]):
+			current.Outcome = "approved"
+			if rejectedStates[state] {
+				current.Outcome = "rejected"
+			}
+			current.DecidedAt = t.At.Format(time.RFC3339)
+			if !started.IsZero() {
+				current.InReviewHours = hoursBetween(started, t.At)
+			}
+			current.TotalHours = hoursBetween(submitted, t.At)
+			cycles = append(cycles, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		cycles = append(cycles, *current)
+	}
+	return cycles
+}
+
+// hoursBetween returns the hours from start to end, rounded to a tenth.
+func hoursBetween(start, end time.Time) *float64 {
+	hours := math.Round(end.Sub(start).Hours()*10) / 10
+	return &hours
+}
+
+// durationStatsFor summarizes durations in hours, or returns nil if there are none.
+func durationStatsFor(hours []float64) *durationStats {
+	if len(hours) == 0 {
+		return nil
+	}
+
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, h := range sorted {
+		sum += h
+	}
+	p90 := sorted[int(math.Ceil(0.9*float64(len(sorted))))-1]
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+
+	return &durationStats{
+		Count:       len(sorted),
+		MedianHours: math.Round(median*10) / 10,
+		MeanHours:   math.Round(sum/float64(len(sorted))*10) / 10,
+		P90Hours:    p90,
+		MinHours:    sorted[0],
+		MaxHours:    sorted[len(sorted)-1],
+	}
+}
+
+// formatReviewTurnaround renders the turnaround statistics and each review.
+func formatReviewTurnaround(o reviewTurnaroundOutput) string {
+	if len(o.Reviews) == 0 {
+		return "No App Review submissions recorded yet. Version states are recorded whenever versions are listed; check again after submitting a version for review."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("App Review turnaround over %d reviews\n\n", len(o.Reviews)))
+
+	for _, s := range []struct {
+		label string
+		stats *durationStats
+	}{
+		{"Waiting for review", o.Waiting},
+		{"In review", o.InReview},
+		{"Submission to decision", o.Total},
+	} {
+		if s.stats == nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: median %.1fh, mean %.1fh, range %.1fh-%.1fh (%d reviews)\n",
+			s.label, s.stats.MedianHours, s.stats.MeanHours, s.stats.MinHours, s.stats.MaxHours, s.stats.Count))
+	}
+
+	sb.WriteString("\n")
+	for _, review := range o.Reviews {
+		sb.WriteString(fmt.Sprintf("- %s (%s) submitted %s: %s", review.VersionString, review.Platform, review.SubmittedAt, review.Outcome))
+		if review.TotalHours != nil {
+			sb.WriteString(fmt.Sprintf(" after %.1fh", *review.TotalHours))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
 )
 
+// customerReviewFields are the review attributes list_customer_reviews can
+// limit its results to.
+var customerReviewFields = []string{"rating", "title", "body", "reviewerNickname", "createdDate", "territory"}
+
+// maxBatchReviews caps the reviews get_customer_reviews fetches in one call.
+const maxBatchReviews = 50
+
 // registerCustomerReviewTools registers customer review tools.
 func (r *Registry) registerCustomerReviewTools() {
 	// List customer reviews
@@ -27,6 +35,12 @@ func (r *Registry) registerCustomerReviewTools() {
 					Type:        "integer",
 					Description: "Maximum number of reviews to return (default 50)",
 				},
+				"fields": {
+					Type:        "array",
+					Description: "Optional: Only return these review attributes, to keep large listings small",
+					Items:       &mcp.Property{Type: "string", Enum: customerReviewFields},
+				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -48,6 +62,23 @@ func (r *Registry) registerCustomerReviewTools() {
 		},
 	}, r.handleGetCustomerReview)
 
+	// Get several customer reviews
+	r.register(mcp.Tool{
+		Name:        "get_customer_reviews",
+		Description: "Get a set of customer reviews by ID in one call, such as those shortlisted from a listing, each with its developer response if there is one. Reviews are fetched concurrently; one that can't be fetched is reported without failing the others.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"review_ids": {
+					Type:        "array",
+					Description: fmt.Sprintf("The customer review IDs (at most %d)", maxBatchReviews),
+					Items:       &mcp.Property{Type: "string"},
+				},
+			},
+			Required: []string{"review_ids"},
+		},
+	}, r.handleGetCustomerReviews)
+
 	// Create customer review response
 	r.register(mcp.Tool{
 		Name:        "create_customer_review_response",
@@ -85,10 +116,12 @@ func (r *Registry) registerCustomerReviewTools() {
 	}, r.handleDeleteCustomerReviewResponse)
 }
 
-func (r *Registry) handleListCustomerReviews(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListCustomerReviews(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string   `json:"app_id"`
+		Limit  int      `json:"limit"`
+		Fields []string `json:"fields"`
+		Cursor string   `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -103,15 +136,19 @@ func (r *Registry) handleListCustomerReviews(args json.RawMessage) (*mcp.ToolsCa
 		limit = 50
 	}
 
-	resp, err := r.client.ListCustomerReviews(context.Background(), params.AppID, limit)
+	opts := api.ListOptions{Limit: limit}
+	if len(params.Fields) > 0 {
+		opts = opts.WithFields("customerReviews", params.Fields...)
+	}
+	resp, err := r.client.ListCustomerReviews(api.WithCursor(ctx, params.Cursor), params.AppID, opts)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list customer reviews: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list customer reviews: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatCustomerReviews(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatCustomerReviews(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetCustomerReview(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetCustomerReview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ReviewID string `json:"review_id"`
 	}
@@ -123,15 +160,65 @@ func (r *Registry) handleGetCustomerReview(args json.RawMessage) (*mcp.ToolsCall
 		return nil, fmt.Errorf("review_id is required")
 	}
 
-	resp, err := r.client.GetCustomerReview(context.Background(), params.ReviewID)
+	resp, err := r.client.GetCustomerReview(ctx, params.ReviewID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get customer review: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get customer review: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatCustomerReview(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateCustomerReviewResponse(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetCustomerReviews(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		ReviewIDs []string `json:"review_ids"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	var ids []string
+	for _, id := range params.ReviewIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("review_ids is required")
+	}
+	if len(ids) > maxBatchReviews {
+		return mcp.NewErrorResult(fmt.Sprintf("At most %d reviews can be fetched at once, got %d.", maxBatchReviews, len(ids))), nil
+	}
+
+	results := r.client.GetCustomerReviews(ctx, ids)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var sb strings.Builder
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			sb.WriteString(fmt.Sprintf("Review ID: %s\nFailed to get customer review: %v\n---\n", result.ID, result.Err))
+			continue
+		}
+		sb.WriteString(formatCustomerReview(*result.Review))
+		if result.Response != nil {
+			sb.WriteString(fmt.Sprintf("Response ID: %s\n", result.Response.ID))
+			sb.WriteString(fmt.Sprintf("Response (%s): %s\n", result.Response.Attributes.State, result.Response.Attributes.ResponseBody))
+		} else {
+			sb.WriteString("Response: none\n")
+		}
+		sb.WriteString("---\n")
+	}
+	if failed == len(results) {
+		return mcp.NewErrorResult(sb.String()), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Got %d of %d customer reviews:\n\n%s", len(results)-failed, len(results), sb.String())), nil
+}
+
+func (r *Registry) handleCreateCustomerReviewResponse(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ReviewID     string `json:"review_id"`
 		ResponseBody string `json:"response_body"`
@@ -164,15 +251,15 @@ func (r *Registry) handleCreateCustomerReviewResponse(args json.RawMessage) (*mc
 		},
 	}
 
-	resp, err := r.client.CreateCustomerReviewResponse(context.Background(), req)
+	resp, err := r.client.CreateCustomerReviewResponse(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create review response: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create review response: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created review response: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteCustomerReviewResponse(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteCustomerReviewResponse(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ResponseID string `json:"response_id"`
 	}
@@ -184,9 +271,9 @@ func (r *Registry) handleDeleteCustomerReviewResponse(args json.RawMessage) (*mc
 		return nil, fmt.Errorf("response_id is required")
 	}
 
-	err := r.client.DeleteCustomerReviewResponse(context.Background(), params.ResponseID)
+	err := r.client.DeleteCustomerReviewResponse(ctx, params.ResponseID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete review response: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete review response: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Review response deleted successfully"), nil
@@ -211,7 +298,9 @@ func formatCustomerReviews(reviews []api.CustomerReview) string {
 func formatCustomerReview(review api.CustomerReview) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Review ID: %s\n", review.ID))
-	sb.WriteString(fmt.Sprintf("Rating: %d/5\n", review.Attributes.Rating))
+	if review.Attributes.Rating > 0 {
+		sb.WriteString(fmt.Sprintf("Rating: %d/5\n", review.Attributes.Rating))
+	}
 	if review.Attributes.Title != "" {
 		sb.WriteString(fmt.Sprintf("Title: %s\n", review.Attributes.Title))
 	}
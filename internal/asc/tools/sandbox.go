@@ -23,6 +23,7 @@ func (r *Registry) registerSandboxTools() {
 					Type:        "integer",
 					Description: "Maximum number of testers to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListSandboxTesters)
@@ -74,7 +75,7 @@ func (r *Registry) registerSandboxTools() {
 	// Update sandbox tester
 	r.register(mcp.Tool{
 		Name:        "update_sandbox_tester",
-		Description: "Update a sandbox tester's territory or interruptable setting",
+		Description: "Update a sandbox tester's territory, purchase interruption or renewal rate",
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
@@ -86,7 +87,7 @@ func (r *Registry) registerSandboxTools() {
 					Type:        "string",
 					Description: "New App Store territory code",
 				},
-				"interruptable": {
+				"interrupt_purchases": {
 					Type:        "boolean",
 					Description: "Whether purchases can be interrupted for testing",
 				},
@@ -116,9 +117,10 @@ func (r *Registry) registerSandboxTools() {
 	}, r.handleDeleteSandboxTester)
 }
 
-func (r *Registry) handleListSandboxTesters(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSandboxTesters(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -129,15 +131,15 @@ func (r *Registry) handleListSandboxTesters(args json.RawMessage) (*mcp.ToolsCal
 		limit = 50
 	}
 
-	resp, err := r.client.ListSandboxTesters(context.Background(), limit)
+	resp, err := r.client.ListSandboxTesters(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list sandbox testers: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list sandbox testers: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatSandboxTesters(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatSandboxTesters(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleCreateSandboxTester(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateSandboxTester(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		Email             string `json:"email"`
 		Password          string `json:"password"`
@@ -172,19 +174,19 @@ func (r *Registry) handleCreateSandboxTester(args json.RawMessage) (*mcp.ToolsCa
 		},
 	}
 
-	resp, err := r.client.CreateSandboxTester(context.Background(), req)
+	resp, err := r.client.CreateSandboxTester(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create sandbox tester: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create sandbox tester: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Sandbox tester created:\n%s", formatSandboxTester(resp.Data))), nil
 }
 
-func (r *Registry) handleUpdateSandboxTester(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateSandboxTester(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		TesterID                string `json:"tester_id"`
 		Territory               string `json:"territory"`
-		Interruptable           *bool  `json:"interruptable"`
+		InterruptPurchases      *bool  `json:"interrupt_purchases"`
 		SubscriptionRenewalRate string `json:"subscription_renewal_rate"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
@@ -201,21 +203,21 @@ func (r *Registry) handleUpdateSandboxTester(args json.RawMessage) (*mcp.ToolsCa
 			ID:   params.TesterID,
 			Attributes: api.SandboxTesterUpdateAttributes{
 				Territory:               params.Territory,
-				Interruptable:           params.Interruptable,
+				InterruptPurchases:      params.InterruptPurchases,
 				SubscriptionRenewalRate: params.SubscriptionRenewalRate,
 			},
 		},
 	}
 
-	resp, err := r.client.UpdateSandboxTester(context.Background(), params.TesterID, req)
+	resp, err := r.client.UpdateSandboxTester(ctx, params.TesterID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update sandbox tester: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update sandbox tester: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Sandbox tester updated:\n%s", formatSandboxTester(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteSandboxTester(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteSandboxTester(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		TesterID string `json:"tester_id"`
 	}
@@ -227,9 +229,9 @@ func (r *Registry) handleDeleteSandboxTester(args json.RawMessage) (*mcp.ToolsCa
 		return nil, fmt.Errorf("tester_id is required")
 	}
 
-	err := r.client.DeleteSandboxTester(context.Background(), params.TesterID)
+	err := r.client.DeleteSandboxTester(ctx, params.TesterID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete sandbox tester: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete sandbox tester: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Sandbox tester deleted"), nil
@@ -254,11 +256,11 @@ func formatSandboxTesters(testers []api.SandboxTester) string {
 func formatSandboxTester(tester api.SandboxTester) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("ID: %s\n", tester.ID))
-	sb.WriteString(fmt.Sprintf("Email: %s\n", tester.Attributes.Email))
+	sb.WriteString(fmt.Sprintf("Email: %s\n", tester.Attributes.AcAccountName))
 	sb.WriteString(fmt.Sprintf("Name: %s %s\n", tester.Attributes.FirstName, tester.Attributes.LastName))
-	if tester.Attributes.AppStoreTerritory != "" {
-		sb.WriteString(fmt.Sprintf("Territory: %s\n", tester.Attributes.AppStoreTerritory))
+	if tester.Attributes.Territory != "" {
+		sb.WriteString(fmt.Sprintf("Territory: %s\n", tester.Attributes.Territory))
 	}
-	sb.WriteString(fmt.Sprintf("Interruptable: %t\n", tester.Attributes.Interruptable))
+	sb.WriteString(fmt.Sprintf("Interrupt Purchases: %t\n", tester.Attributes.InterruptPurchases))
 	return sb.String()
 }
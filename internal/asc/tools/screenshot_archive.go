@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/config"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+const (
+	// maxScreenshotBytes caps the size of a downloaded screenshot.
+	maxScreenshotBytes = 50 << 20
+
+	// maxPreviewBytes caps the size of a downloaded app preview video.
+	maxPreviewBytes = 500 << 20
+
+	// archiveManifestName is the manifest file written at the archive's root.
+	archiveManifestName = "manifest.json"
+)
+
+// unsafeFileNameChars matches characters replaced in archive file names.
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// reservedFileNames matches names Windows reserves for devices, with or
+// without an extension, such as "CON" or "nul.png".
+var reservedFileNames = regexp.MustCompile(`(?i)^(CON|PRN|AUX|NUL|COM[0-9]|LPT[0-9])(\.|$)`)
+
+// registerScreenshotArchiveTools registers the screenshot archive download tool.
+func (r *Registry) registerScreenshotArchiveTools() {
+	r.registerWithProgress(
+		mcp.Tool{
+			Name:        "download_screenshot_archive",
+			Description: "Download every screenshot and app preview of an App Store version, for all locales and display types, into a local directory laid out as <locale>/screenshots/<display type>/ and <locale>/previews/<preview type>/, with a manifest.json listing each file's position, dimensions and SHA-256. For backups, compliance records and design review. Defaults to the version live on the App Store.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"version_id": {
+						Type:        "string",
+						Description: "Optional: The App Store version to archive (default: the live version, or the newest if none is live)",
+					},
+					"output_dir": {
+						Type:        "string",
+						Description: "Optional: Directory to write the archive to; it must be empty or not exist (default: a new directory in the system temp directory)",
+					},
+					"include_previews": {
+						Type:        "boolean",
+						Description: "Also download app preview videos (default: true)",
+						Default:     true,
+					},
+				},
+				Required: []string{"app_id"},
+			},
+			OutputSchema: mcp.SchemaFor(screenshotArchiveOutput{}),
+		},
+		r.handleDownloadScreenshotArchive,
+	)
+}
+
+// archiveAsset is a screenshot or preview to download.
+type archiveAsset struct {
+	entry archiveEntry
+	url   string
+	max   int64
+}
+
+// handleDownloadScreenshotArchive handles the download_screenshot_archive tool.
+func (r *Registry) handleDownloadScreenshotArchive(ctx context.Context, args json.RawMessage, progress ProgressFunc) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID           string `json:"app_id"`
+		VersionID       string `json:"version_id"`
+		OutputDir       string `json:"output_dir"`
+		IncludePreviews *bool  `json:"include_previews"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" {
+		return mcp.NewErrorResult("app_id is required"), nil
+	}
+	includePreviews := params.IncludePreviews == nil || *params.IncludePreviews
+
+	version, err := r.archiveVersion(ctx, params.AppID, params.VersionID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(err.Error(), err), nil
+	}
+
+	dir, err := archiveDir(params.OutputDir, params.AppID, version.Attributes.VersionString)
+	if err != nil {
+		return mcp.NewErrorResultWithError(err.Error(), err), nil
+	}
+
+	progress(0, 0, "Listing screenshots")
+	assets, err := r.archiveAssets(ctx, version.ID, includePreviews)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list screenshots: %v", err), err), nil
+	}
+
+	manifest := screenshotArchiveOutput{
+		AppID:         params.AppID,
+		VersionID:     version.ID,
+		VersionString: version.Attributes.VersionString,
+		Platform:      string(version.Attributes.Platform),
+		Directory:     dir,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+		Files:         make([]archiveEntry, 0, len(assets)),
+	}
+
+	for i, asset := range assets {
+		progress(float64(i), float64(len(assets)), fmt.Sprintf("Downloading %s", asset.entry.Path))
+		entry := asset.entry
+		if err := r.downloadArchiveAsset(ctx, dir, &entry, asset.url, asset.max); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			entry.Error = err.Error()
+			manifest.Failed++
+		} else {
+			manifest.Downloaded++
+			manifest.TotalBytes += entry.Size
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+	progress(float64(len(assets)), float64(len(assets)), "Archive complete")
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, archiveManifestName), data, 0o644); err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to write manifest: %v", err), err), nil
+	}
+
+	return mcp.NewStructuredResult(formatScreenshotArchive(manifest), manifest), nil
+}
+
+// archiveVersion returns the requested version, or the app's live version,
+// or its newest version if none is live.
+func (r *Registry) archiveVersion(ctx context.Context, appID, versionID string) (api.AppStoreVersion, error) {
+	versions, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return api.AppStoreVersion{}, fmt.Errorf("Failed to get app versions: %v", err)
+	}
+	r.recordVersions(appID, versions.Data)
+	if len(versions.Data) == 0 {
+		return api.AppStoreVersion{}, fmt.Errorf("App %s has no App Store versions", appID)
+	}
+
+	for _, v := range versions.Data {
+		if versionID != "" && v.ID == versionID {
+			return v, nil
+		}
+		if versionID == "" && v.Attributes.AppStoreState == api.AppStoreStateReadyForSale {
+			return v, nil
+		}
+	}
+	if versionID != "" {
+		return api.AppStoreVersion{}, fmt.Errorf("Version %s is not one of app %s's versions", versionID, appID)
+	}
+	return versions.Data[0], nil
+}
+
+// archiveDir creates the archive directory. An existing directory must be
+// empty, so an archive never mixes with other files.
+func archiveDir(dir, appID, versionString string) (string, error) {
+	if dir == "" {
+		name := fmt.Sprintf("asc-screenshots-%s-%s-", safeFileName(appID), safeFileName(versionString))
+		created, err := os.MkdirTemp("", name)
+		if err != nil {
+			return "", fmt.Errorf("Failed to create archive directory: %v", err)
+		}
+		return created, nil
+	}
+
+	dir, err := config.ExpandPath(dir)
+	if err != nil {
+		return "", fmt.Errorf("Invalid output_dir: %v", err)
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", fmt.Errorf("Invalid output_dir: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", fmt.Errorf("Failed to create archive directory: %v", err)
+		}
+	case err != nil:
+		return "", fmt.Errorf("Failed to read output_dir: %v", err)
+	case len(entries) > 0:
+		return "", fmt.Errorf("output_dir %s is not empty", dir)
+	}
+	return dir, nil
+}
+
+// archiveAssets lists the screenshots and, optionally, previews of every
+// localization of a version, in their App Store order.
+func (r *Registry) archiveAssets(ctx context.Context, versionID string, includePreviews bool) ([]archiveAsset, error) {
+	localizations, err := r.client.ListAppStoreVersionLocalizations(ctx, versionID, api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []archiveAsset
+	for _, loc := range localizations.Data {
+		locale := loc.Attributes.Locale
+
+		sets, err := r.client.ListAppScreenshotSets(ctx, loc.ID, api.ListOptions{Limit: 50})
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range sets.Data {
+			screenshots, err := r.client.ListAppScreenshots(ctx, set.ID, api.ListOptions{Limit: 50})
+			if err != nil {
+				return nil, err
+			}
+			for i, s := range screenshots.Data {
+				entry := archiveEntry{
+					Kind:        "screenshot",
+					Locale:      locale,
+					DisplayType: set.Attributes.ScreenshotDisplayType,
+					Position:    i + 1,
+					ID:          s.ID,
+					FileName:    s.Attributes.FileName,
+					Checksum:    s.Attributes.SourceFileChecksum,
+				}
+				url := ""
+				if image := s.Attributes.ImageAsset; image != nil {
+					entry.Width, entry.Height = image.Width, image.Height
+					url = imageAssetURL(image, "png")
+				}
+				entry.Path = archivePath(locale, "screenshots", entry.DisplayType, entry.Position, entry.FileName, ".png")
+				assets = append(assets, archiveAsset{entry: entry, url: url, max: maxScreenshotBytes})
+			}
+		}
+
+		if !includePreviews {
+			continue
+		}
+		previewSets, err := r.client.ListAppPreviewSets(ctx, loc.ID, api.ListOptions{Limit: 50})
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range previewSets.Data {
+			previews, err := r.client.ListAppPreviews(ctx, set.ID, api.ListOptions{Limit: 50})
+			if err != nil {
+				return nil, err
+			}
+			for i, p := range previews.Data {
+				entry := archiveEntry{
+					Kind:        "preview",
+					Locale:      locale,
+					DisplayType: set.Attributes.PreviewType,
+					Position:    i + 1,
+					ID:          p.ID,
+					FileName:    p.Attributes.FileName,
+					Checksum:    p.Attributes.SourceFileChecksum,
+				}
+				if image := p.Attributes.PreviewImage; image != nil {
+					entry.Width, entry.Height = image.Width, image.Height
+				}
+				entry.Path = archivePath(locale, "previews", entry.DisplayType, entry.Position, entry.FileName, ".mp4")
+				assets = append(assets, archiveAsset{entry: entry, url: p.Attributes.VideoURL, max: maxPreviewBytes})
+			}
+		}
+	}
+	return assets, nil
+}
+
+// imageAssetURL fills in an image asset's URL template at full size.
+func imageAssetURL(image *api.ImageAsset, format string) string {
+	if image.TemplateURL == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{w}", fmt.Sprint(image.Width),
+		"{h}", fmt.Sprint(image.Height),
+		"{f}", format,
+	).Replace(image.TemplateURL)
+}
+
+// archivePath returns a file's slash-separated path inside the archive. The
+// position prefix keeps files in App Store order.
+func archivePath(locale, kind, displayType string, position int, fileName, defaultExt string) string {
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == "" {
+		ext = defaultExt
+	}
+	if base == "" {
+		base = kind
+	}
+	name := fmt.Sprintf("%02d-%s%s", position, safeFileName(base), ext)
+	return strings.Join([]string{safeFileName(locale), kind, safeFileName(displayType), name}, "/")
+}
+
+// safeFileName replaces characters that aren't safe in file names on every
+// platform.
+func safeFileName(s string) string {
+	s = unsafeFileNameChars.ReplaceAllString(s, "_")
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	if reservedFileNames.MatchString(s) {
+		s = "_" + s
+	}
+	// Windows drops trailing dots from file names.
+	if strings.HasSuffix(s, ".") {
+		s = strings.TrimRight(s, ".") + "_"
+	}
+	return s
+}
+
+// downloadArchiveAsset streams one file into the archive and records its
+// size and SHA-256 in entry.
+func (r *Registry) downloadArchiveAsset(ctx context.Context, dir string, entry *archiveEntry, url string, maxBytes int64) error {
+	if url == "" {
+		return fmt.Errorf("no download URL; the asset may still be processing")
+	}
+
+	stream, err := r.client.Download(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	if stream.Size > maxBytes {
+		return fmt.Errorf("download exceeds %d bytes", maxBytes)
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(entry.Path))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	written, err := io.Copy(file, io.LimitReader(stream, maxBytes+1))
+	if err != nil {
+		err = fmt.Errorf("failed to download: %w", err)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write file: %w", closeErr)
+	}
+	if err == nil && written > maxBytes {
+		err = fmt.Errorf("download exceeds %d bytes", maxBytes)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	entry.SHA256 = stream.SHA256()
+	entry.Size = written
+	return nil
+}
+
+// formatScreenshotArchive summarizes an archive download.
+func formatScreenshotArchive(o screenshotArchiveOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Archived version %s (%s) of app %s to %s\n\n", o.VersionString, o.Platform, o.AppID, o.Directory))
+	sb.WriteString(fmt.Sprintf("- Files downloaded: %d (%d bytes)\n", o.Downloaded, o.TotalBytes))
+	if o.Failed > 0 {
+		sb.WriteString(fmt.Sprintf("- Files failed: %d\n", o.Failed))
+	}
+	sb.WriteString(fmt.Sprintf("- Manifest: %s\n", filepath.Join(o.Directory, archiveManifestName)))
+
+	if o.Failed > 0 {
+		sb.WriteString("\nFailed files:\n")
+		for _, f := range o.Files {
+			if f.Error != "" {
+				sb.WriteString(fmt.Sprintf("- %s: %s\n", f.Path, f.Error))
+			}
+		}
+	}
+	return sb.String()
+}
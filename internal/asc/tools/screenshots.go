@@ -27,6 +27,7 @@ func (r *Registry) registerScreenshotTools() {
 					Type:        "integer",
 					Description: "Maximum number of sets to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"localization_id"},
 		},
@@ -47,6 +48,7 @@ func (r *Registry) registerScreenshotTools() {
 					Type:        "integer",
 					Description: "Maximum number of screenshots to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"screenshot_set_id"},
 		},
@@ -99,6 +101,7 @@ func (r *Registry) registerScreenshotTools() {
 					Type:        "integer",
 					Description: "Maximum number of sets to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"localization_id"},
 		},
@@ -119,6 +122,7 @@ func (r *Registry) registerScreenshotTools() {
 					Type:        "integer",
 					Description: "Maximum number of previews to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"preview_set_id"},
 		},
@@ -157,10 +161,11 @@ func (r *Registry) registerScreenshotTools() {
 	}, r.handleDeletePreview)
 }
 
-func (r *Registry) handleListScreenshotSets(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListScreenshotSets(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -175,18 +180,19 @@ func (r *Registry) handleListScreenshotSets(args json.RawMessage) (*mcp.ToolsCal
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppScreenshotSets(context.Background(), params.LocalizationID, limit)
+	resp, err := r.client.ListAppScreenshotSets(api.WithCursor(ctx, params.Cursor), params.LocalizationID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list screenshot sets: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list screenshot sets: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatScreenshotSets(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatScreenshotSets(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListScreenshots(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListScreenshots(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ScreenshotSetID string `json:"screenshot_set_id"`
 		Limit           int    `json:"limit"`
+		Cursor          string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -201,15 +207,15 @@ func (r *Registry) handleListScreenshots(args json.RawMessage) (*mcp.ToolsCallRe
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppScreenshots(context.Background(), params.ScreenshotSetID, limit)
+	resp, err := r.client.ListAppScreenshots(api.WithCursor(ctx, params.Cursor), params.ScreenshotSetID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list screenshots: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list screenshots: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatScreenshots(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatScreenshots(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetScreenshot(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetScreenshot(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ScreenshotID string `json:"screenshot_id"`
 	}
@@ -221,15 +227,15 @@ func (r *Registry) handleGetScreenshot(args json.RawMessage) (*mcp.ToolsCallResu
 		return nil, fmt.Errorf("screenshot_id is required")
 	}
 
-	resp, err := r.client.GetAppScreenshot(context.Background(), params.ScreenshotID)
+	resp, err := r.client.GetAppScreenshot(ctx, params.ScreenshotID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get screenshot: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get screenshot: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatScreenshot(resp.Data)), nil
 }
 
-func (r *Registry) handleDeleteScreenshot(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteScreenshot(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ScreenshotID string `json:"screenshot_id"`
 	}
@@ -241,18 +247,19 @@ func (r *Registry) handleDeleteScreenshot(args json.RawMessage) (*mcp.ToolsCallR
 		return nil, fmt.Errorf("screenshot_id is required")
 	}
 
-	err := r.client.DeleteAppScreenshot(context.Background(), params.ScreenshotID)
+	err := r.client.DeleteAppScreenshot(ctx, params.ScreenshotID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete screenshot: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete screenshot: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Screenshot deleted successfully"), nil
 }
 
-func (r *Registry) handleListPreviewSets(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListPreviewSets(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		LocalizationID string `json:"localization_id"`
 		Limit          int    `json:"limit"`
+		Cursor         string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -267,18 +274,19 @@ func (r *Registry) handleListPreviewSets(args json.RawMessage) (*mcp.ToolsCallRe
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppPreviewSets(context.Background(), params.LocalizationID, limit)
+	resp, err := r.client.ListAppPreviewSets(api.WithCursor(ctx, params.Cursor), params.LocalizationID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list preview sets: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list preview sets: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatPreviewSets(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatPreviewSets(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleListPreviews(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListPreviews(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PreviewSetID string `json:"preview_set_id"`
 		Limit        int    `json:"limit"`
+		Cursor       string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -293,15 +301,15 @@ func (r *Registry) handleListPreviews(args json.RawMessage) (*mcp.ToolsCallResul
 		limit = 50
 	}
 
-	resp, err := r.client.ListAppPreviews(context.Background(), params.PreviewSetID, limit)
+	resp, err := r.client.ListAppPreviews(api.WithCursor(ctx, params.Cursor), params.PreviewSetID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list previews: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list previews: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatPreviews(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatPreviews(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetPreview(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetPreview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PreviewID string `json:"preview_id"`
 	}
@@ -313,15 +321,15 @@ func (r *Registry) handleGetPreview(args json.RawMessage) (*mcp.ToolsCallResult,
 		return nil, fmt.Errorf("preview_id is required")
 	}
 
-	resp, err := r.client.GetAppPreview(context.Background(), params.PreviewID)
+	resp, err := r.client.GetAppPreview(ctx, params.PreviewID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get preview: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get preview: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatPreview(resp.Data)), nil
 }
 
-func (r *Registry) handleDeletePreview(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeletePreview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		PreviewID string `json:"preview_id"`
 	}
@@ -333,9 +341,9 @@ func (r *Registry) handleDeletePreview(args json.RawMessage) (*mcp.ToolsCallResu
 		return nil, fmt.Errorf("preview_id is required")
 	}
 
-	err := r.client.DeleteAppPreview(context.Background(), params.PreviewID)
+	err := r.client.DeleteAppPreview(ctx, params.PreviewID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete preview: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete preview: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Preview deleted successfully"), nil
@@ -0,0 +1,618 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// searchPageSize is how many resources of each type search fetches and matches.
+const searchPageSize = 200
+
+// searchCacheTTL is how long search reuses the resources it listed, so
+// follow-up searches in a conversation don't list them again.
+const searchCacheTTL = 2 * time.Minute
+
+// searchTypes are the resource types search covers, in the order results are listed.
+var searchTypes = []string{"apps", "versions", "builds", "betaGroups", "betaTesters", "reviews", "bundleIds"}
+
+// searchAppTypes are the types that need an app_id to be searched.
+var searchAppTypes = []string{"reviews"}
+
+// searchFillerWords are dropped from queries so "my Weather app" matches an
+// app named "Weather".
+var searchFillerWords = map[string]bool{
+	"a": true, "an": true, "the": true, "my": true, "our": true,
+	"app": true, "apps": true, "build": true, "builds": true,
+}
+
+// registerSearchTools registers the cross-resource search tool.
+func (r *Registry) registerSearchTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "search",
+			Description: "Search apps (name, bundle ID, SKU), App Store versions (version string, with or without the app name), builds (build number), beta groups (name), beta testers (email, name), customer reviews (title, text, reviewer) and bundle IDs (identifier, name) in one call. Use it first to resolve something like \"my Weather app\" or \"Weather 2.1\" to IDs before calling other tools. Words may be misspelled by a letter or two; such matches are marked fuzzy and listed after exact ones. Matches the first 200 resources of each type; with app_id, versions, builds, beta groups and testers are those of that app, and reviews are searched. Listed resources are reused for 2 minutes.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"query": {
+						Type:        "string",
+						Description: "Free-text query; every word must appear in a field for it to match (case-insensitive, tolerating small typos)",
+					},
+					"types": {
+						Type:        "array",
+						Description: "Resource types to search (default: all; reviews need app_id)",
+						Items: &mcp.Property{
+							Type: "string",
+							Enum: searchTypes,
+						},
+					},
+					"app_id": {
+						Type:        "string",
+						Description: "Optional: Only search this app's versions, builds, beta groups, testers and reviews",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of matches per type (default: 10)",
+						Default:     10,
+					},
+					"refresh": refreshProperty,
+				},
+				Required: []string{"query"},
+			},
+			OutputSchema: mcp.SchemaFor(searchOutput{}),
+		},
+		r.handleSearch,
+	)
+}
+
+// searchRecord is a listed resource and the fields search matches it on.
+type searchRecord struct {
+	match searchMatch
+
+	// fields are name/value pairs, in the order they are tried.
+	fields []string
+}
+
+// searchSource lists the resources of one type, of the app with appID if
+// set, for matching.
+type searchSource func(ctx context.Context, appID string) ([]searchRecord, error)
+
+// handleSearch handles the search tool.
+func (r *Registry) handleSearch(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Query   string   `json:"query"`
+		Types   []string `json:"types"`
+		AppID   string   `json:"app_id"`
+		Limit   int      `json:"limit"`
+		Refresh bool     `json:"refresh"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return mcp.NewErrorResult("query is required"), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+
+	sources := map[string]searchSource{
+		"apps":      r.searchApps,
+		"versions":  r.searchVersions,
+		"builds":    r.searchBuilds,
+		"reviews":   r.searchReviews,
+		"bundleIds": r.searchBundleIDs,
+	}
+	// TestFlight resources don't exist for Enterprise (In-House) accounts.
+	if !r.enterprise {
+		sources["betaGroups"] = r.searchBetaGroups
+		sources["betaTesters"] = r.searchBetaTesters
+	}
+
+	types := params.Types
+	if len(types) == 0 {
+		types = searchTypes
+		if params.AppID == "" {
+			types = slices.DeleteFunc(slices.Clone(types), func(t string) bool {
+				return slices.Contains(searchAppTypes, t)
+			})
+		}
+	}
+	for _, t := range types {
+		if !slices.Contains(searchTypes, t) {
+			return mcp.NewErrorResult(fmt.Sprintf("unknown type %q (expected one of: %s)", t, strings.Join(searchTypes, ", "))), nil
+		}
+		if params.AppID == "" && slices.Contains(searchAppTypes, t) {
+			return mcp.NewErrorResult(fmt.Sprintf("app_id is required to search %s", t)), nil
+		}
+	}
+
+	creds, err := r.client.Credentials(ctx)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to search: %v", err), err), nil
+	}
+	scope := creds.IssuerID + "/" + creds.KeyID + " " + creds.BaseURL
+
+	terms := searchTerms(params.Query)
+	results := make([][]searchRecord, len(types))
+	errs := make([]error, len(types))
+
+	var wg sync.WaitGroup
+	for i, t := range types {
+		source, ok := sources[t]
+		if !ok {
+			errs[i] = fmt.Errorf("not available for Enterprise (In-House) accounts")
+			continue
+		}
+		key := scope + " " + t + " " + params.AppID
+		if records, ok := r.searchCache.get(key); ok && !params.Refresh {
+			results[i] = records
+			continue
+		}
+		wg.Add(1)
+		go func(i int, key string, source searchSource) {
+			defer wg.Done()
+			results[i], errs[i] = source(withRefresh(ctx, params.Refresh), params.AppID)
+			if errs[i] == nil {
+				r.searchCache.put(key, results[i])
+			}
+		}(i, key, source)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	output := searchOutput{Query: params.Query, Matches: []searchMatch{}}
+	for i, t := range types {
+		if errs[i] != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", t, errs[i]))
+			continue
+		}
+		matches := rankSearchMatches(matchSearchRecords(results[i], terms), terms)
+		if len(matches) > params.Limit {
+			matches = matches[:params.Limit]
+		}
+		output.Matches = append(output.Matches, matches...)
+	}
+
+	if len(output.Errors) == len(types) {
+		return mcp.NewErrorResult(fmt.Sprintf("Failed to search: %s", strings.Join(output.Errors, "; "))), nil
+	}
+
+	var sb strings.Builder
+	if len(output.Matches) == 0 {
+		sb.WriteString(fmt.Sprintf("No matches for %q.\n", params.Query))
+	} else {
+		sb.WriteString(fmt.Sprintf("Found %d matches for %q:\n\n", len(output.Matches), params.Query))
+		for _, m := range output.Matches {
+			sb.WriteString(fmt.Sprintf("- [%s] **%s** (ID: %s)", m.Type, m.Name, m.ID))
+			if m.Detail != "" {
+				sb.WriteString(" - " + m.Detail)
+			}
+			if m.Fuzzy {
+				sb.WriteString(fmt.Sprintf(" [matched %s, fuzzy]\n", m.MatchedField))
+			} else {
+				sb.WriteString(fmt.Sprintf(" [matched %s]\n", m.MatchedField))
+			}
+		}
+	}
+	for _, e := range output.Errors {
+		sb.WriteString(fmt.Sprintf("\nCould not search %s\n", e))
+	}
+
+	return mcp.NewStructuredResult(sb.String(), output), nil
+}
+
+// searchApps lists apps to match by name, bundle ID, and SKU.
+func (r *Registry) searchApps(ctx context.Context, _ string) ([]searchRecord, error) {
+	resp, err := r.client.ListApps(ctx, api.ListOptions{Limit: searchPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, app := range resp.Data {
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "apps",
+				ID:     app.ID,
+				Name:   app.Attributes.Name,
+				Detail: app.Attributes.BundleID,
+			},
+			fields: []string{
+				"name", app.Attributes.Name,
+				"bundleId", app.Attributes.BundleID,
+				"sku", app.Attributes.SKU,
+			},
+		})
+	}
+	return records, nil
+}
+
+// searchVersions lists App Store versions to match by version string, alone
+// or after the app's name. Without appID, they are those included with the
+// first page of apps.
+func (r *Registry) searchVersions(ctx context.Context, appID string) ([]searchRecord, error) {
+	if appID != "" {
+		app, err := r.client.GetApp(ctx, appID)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := r.client.GetAppVersions(ctx, appID, api.ListOptions{Limit: searchPageSize})
+		if err != nil {
+			return nil, err
+		}
+		return versionSearchRecords(app.Data.Attributes.Name, resp.Data), nil
+	}
+
+	resp, err := r.client.ListApps(ctx, api.ListOptions{Limit: searchPageSize}.WithInclude("appStoreVersions"))
+	if err != nil {
+		return nil, err
+	}
+	included, err := api.IncludedOf[api.AppStoreVersion](resp.Included)
+	if err != nil {
+		return nil, err
+	}
+	versionsByID := make(map[string]api.AppStoreVersion, len(included))
+	for _, version := range included {
+		versionsByID[version.ID] = version
+	}
+
+	var records []searchRecord
+	for _, app := range resp.Data {
+		if app.Relationships == nil || app.Relationships.AppStoreVersions == nil {
+			continue
+		}
+		var versions []api.AppStoreVersion
+		for _, ref := range app.Relationships.AppStoreVersions.Data {
+			if version, ok := versionsByID[ref.ID]; ok {
+				versions = append(versions, version)
+			}
+		}
+		records = append(records, versionSearchRecords(app.Attributes.Name, versions)...)
+	}
+	return records, nil
+}
+
+// versionSearchRecords returns the search records of an app's versions.
+func versionSearchRecords(appName string, versions []api.AppStoreVersion) []searchRecord {
+	records := make([]searchRecord, 0, len(versions))
+	for _, version := range versions {
+		versionString := version.Attributes.VersionString
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "versions",
+				ID:     version.ID,
+				Name:   strings.TrimSpace(appName + " " + versionString),
+				Detail: fmt.Sprintf("%s, %s", version.Attributes.Platform, version.Attributes.AppStoreState),
+			},
+			fields: []string{
+				"versionString", versionString,
+				"appVersion", appName + " " + versionString,
+			},
+		})
+	}
+	return records
+}
+
+// searchBuilds lists builds to match by build number.
+func (r *Registry) searchBuilds(ctx context.Context, appID string) ([]searchRecord, error) {
+	resp, err := r.client.ListBuilds(ctx, appID, api.ListOptions{Limit: searchPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, build := range resp.Data {
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "builds",
+				ID:     build.ID,
+				Name:   build.Attributes.Version,
+				Detail: string(build.Attributes.ProcessingState),
+			},
+			fields: []string{"version", build.Attributes.Version},
+		})
+	}
+	return records, nil
+}
+
+// searchBetaGroups lists beta groups to match by name.
+func (r *Registry) searchBetaGroups(ctx context.Context, appID string) ([]searchRecord, error) {
+	resp, err := r.client.ListBetaGroups(ctx, appID, api.ListOptions{Limit: searchPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, group := range resp.Data {
+		detail := "external"
+		if group.Attributes.IsInternalGroup {
+			detail = "internal"
+		}
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "betaGroups",
+				ID:     group.ID,
+				Name:   group.Attributes.Name,
+				Detail: detail,
+			},
+			fields: []string{"name", group.Attributes.Name},
+		})
+	}
+	return records, nil
+}
+
+// searchBetaTesters lists beta testers to match by email and name.
+func (r *Registry) searchBetaTesters(ctx context.Context, appID string) ([]searchRecord, error) {
+	resp, err := r.client.ListBetaTesters(ctx, "", api.ListOptions{Limit: searchPageSize}.WithFilter("apps", appID))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, tester := range resp.Data {
+		name := strings.TrimSpace(tester.Attributes.FirstName + " " + tester.Attributes.LastName)
+		displayName := name
+		if displayName == "" {
+			displayName = tester.Attributes.Email
+		}
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "betaTesters",
+				ID:     tester.ID,
+				Name:   displayName,
+				Detail: tester.Attributes.Email,
+			},
+			fields: []string{"email", tester.Attributes.Email, "name", name},
+		})
+	}
+	return records, nil
+}
+
+// searchReviews lists an app's most recent customer reviews to match by
+// title, text and reviewer.
+func (r *Registry) searchReviews(ctx context.Context, appID string) ([]searchRecord, error) {
+	resp, err := r.client.ListCustomerReviews(ctx, appID, api.ListOptions{Limit: searchPageSize}.WithSort("-createdDate"))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, review := range resp.Data {
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "reviews",
+				ID:     review.ID,
+				Name:   review.Attributes.Title,
+				Detail: fmt.Sprintf("%d★ by %s", review.Attributes.Rating, review.Attributes.ReviewerName),
+			},
+			fields: []string{
+				"title", review.Attributes.Title,
+				"body", review.Attributes.Body,
+				"reviewerNickname", review.Attributes.ReviewerName,
+			},
+		})
+	}
+	return records, nil
+}
+
+// searchBundleIDs lists bundle IDs to match by identifier and name.
+func (r *Registry) searchBundleIDs(ctx context.Context, _ string) ([]searchRecord, error) {
+	resp, err := r.client.ListBundleIDs(ctx, api.ListOptions{Limit: searchPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]searchRecord, 0, len(resp.Data))
+	for _, bundleID := range resp.Data {
+		records = append(records, searchRecord{
+			match: searchMatch{
+				Type:   "bundleIds",
+				ID:     bundleID.ID,
+				Name:   bundleID.Attributes.Name,
+				Detail: bundleID.Attributes.Identifier,
+			},
+			fields: []string{
+				"identifier", bundleID.Attributes.Identifier,
+				"name", bundleID.Attributes.Name,
+			},
+		})
+	}
+	return records, nil
+}
+
+// matchSearchRecords returns the matches among records for terms.
+func matchSearchRecords(records []searchRecord, terms []string) []searchMatch {
+	var matches []searchMatch
+	for _, record := range records {
+		field, value, fuzzy := matchSearchFields(terms, record.fields...)
+		if field == "" {
+			continue
+		}
+		match := record.match
+		match.MatchedField = field
+		match.Fuzzy = fuzzy
+		match.matchedValue = value
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// searchTerms lowercases a query and splits it into words, dropping filler
+// words unless nothing else is left.
+func searchTerms(query string) []string {
+	words := strings.Fields(strings.ToLower(query))
+
+	var terms []string
+	for _, w := range words {
+		if !searchFillerWords[w] {
+			terms = append(terms, w)
+		}
+	}
+	if len(terms) == 0 {
+		return words
+	}
+	return terms
+}
+
+// matchSearchFields takes name/value pairs and returns the first field whose
+// value contains every term, or "" if none does. Failing that, it returns the
+// first field where every term is in the value or close to one of its words,
+// and reports the match as fuzzy.
+func matchSearchFields(terms []string, fields ...string) (string, string, bool) {
+	for _, fuzzy := range []bool{false, true} {
+		for i := 0; i+1 < len(fields); i += 2 {
+			value := strings.ToLower(fields[i+1])
+			if value == "" {
+				continue
+			}
+			if matchSearchTerms(terms, value, fuzzy) {
+				return fields[i], fields[i+1], fuzzy
+			}
+		}
+	}
+	return "", "", false
+}
+
+// matchSearchTerms reports whether every term is in value or, if fuzzy, is
+// close to one of its words.
+func matchSearchTerms(terms []string, value string, fuzzy bool) bool {
+	var words []string
+	if fuzzy {
+		words = strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+	}
+	for _, term := range terms {
+		if strings.Contains(value, term) {
+			continue
+		}
+		if !fuzzy || !slices.ContainsFunc(words, func(word string) bool { return closeSearchWord(term, word) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// closeSearchWord reports whether term is a misspelling of word: one edit
+// away for terms of 4 to 7 letters, two for longer ones. Shorter terms, such
+// as build numbers, must match exactly.
+func closeSearchWord(term, word string) bool {
+	a, b := []rune(term), []rune(word)
+	maxEdits := 1
+	switch {
+	case len(a) < 4:
+		return false
+	case len(a) >= 8:
+		maxEdits = 2
+	}
+	if d := len(a) - len(b); d > maxEdits || d < -maxEdits {
+		return false
+	}
+
+	// Levenshtein distance, keeping one row.
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			prev, row[j] = row[j], min(row[j]+1, row[j-1]+1, prev+cost)
+		}
+	}
+	return row[len(b)] <= maxEdits
+}
+
+// rankSearchMatches orders exact matches first, then prefix matches, then
+// other matches and fuzzy matches last, keeping the API order otherwise.
+func rankSearchMatches(matches []searchMatch, terms []string) []searchMatch {
+	query := strings.Join(terms, " ")
+	rank := func(m searchMatch) int {
+		value := strings.ToLower(m.matchedValue)
+		switch {
+		case m.Fuzzy:
+			return 3
+		case value == query:
+			return 0
+		case strings.HasPrefix(value, query):
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return rank(matches[i]) < rank(matches[j])
+	})
+	return matches
+}
+
+// searchCache holds the resources search listed, by API key, type and app,
+// until searchCacheTTL passes.
+type searchCache struct {
+	mu      sync.Mutex
+	entries map[string]searchCacheEntry
+	now     func() time.Time
+}
+
+// searchCacheEntry is a cached listing and when it stops being used.
+type searchCacheEntry struct {
+	records []searchRecord
+	expires time.Time
+}
+
+// newSearchCache returns an empty search cache.
+func newSearchCache() *searchCache {
+	return &searchCache{
+		entries: make(map[string]searchCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the records cached under key that haven't expired.
+func (c *searchCache) get(key string) ([]searchRecord, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.records, true
+}
+
+// put caches records under key, dropping expired entries.
+func (c *searchCache) put(key string, records []searchRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = searchCacheEntry{records: records, expires: now.Add(searchCacheTTL)}
+}
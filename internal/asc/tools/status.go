@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// registerStatusTools registers the credential and health status tool.
+func (r *Registry) registerStatusTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "asc_status",
+			Description: "Check the API key and connection before doing real work. Reports whether a token can be signed and when it expires, whether the API accepts it, request latency, the remaining hourly rate limit, and whether the key has Admin access. Call this first when other tools fail with 401 or 403 errors.",
+			InputSchema: mcp.JSONSchema{
+				Type:       "object",
+				Properties: map[string]mcp.Property{},
+			},
+			OutputSchema: mcp.SchemaFor(statusOutput{}),
+		},
+		r.handleStatus,
+	)
+}
+
+// handleStatus handles the asc_status tool.
+func (r *Registry) handleStatus(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var output statusOutput
+
+	creds, err := r.client.Credentials(ctx)
+	if err != nil {
+		output.TokenError = err.Error()
+		return mcp.NewStructuredResult(formatStatus(output), output), nil
+	}
+	output.Team = creds.Team
+	output.IssuerID = creds.IssuerID
+	output.KeyID = creds.KeyID
+	output.BaseURL = creds.BaseURL
+	output.TokenValid = true
+	output.TokenExpiresAt = creds.TokenExpiresAt.UTC().Format(time.RFC3339)
+
+	// Any key can list apps, so this request checks authentication and reachability.
+	resp, err := probe(ctx, func(ctx context.Context) error {
+		_, err := r.client.ListApps(ctx, api.ListOptions{Limit: 1})
+		return err
+	})
+	if resp != nil {
+		output.Reachable = true
+		output.StatusCode = resp.StatusCode
+		output.LatencyMs = resp.Duration.Milliseconds()
+		if resp.RateLimit != nil {
+			output.RateLimit = &rateLimitOutput{Limit: resp.RateLimit.Limit, Remaining: resp.RateLimit.Remaining}
+		}
+	}
+	if err != nil {
+		output.APIError = err.Error()
+		return mcp.NewStructuredResult(formatStatus(output), output), nil
+	}
+	output.Authenticated = true
+
+	// The API doesn't report a key's role; only Admin keys can list users.
+	resp, err = probe(ctx, func(ctx context.Context) error {
+		_, err := r.client.ListUsers(ctx, api.ListOptions{Limit: 1})
+		return err
+	})
+	switch {
+	case err == nil:
+		admin := true
+		output.AdminAccess = &admin
+	case resp != nil && resp.StatusCode == http.StatusForbidden:
+		admin := false
+		output.AdminAccess = &admin
+	}
+	if resp != nil && resp.RateLimit != nil {
+		output.RateLimit = &rateLimitOutput{Limit: resp.RateLimit.Limit, Remaining: resp.RateLimit.Remaining}
+	}
+
+	return mcp.NewStructuredResult(formatStatus(output), output), nil
+}
+
+// probe runs call and returns the last API response it received, which is nil
+// if the request never got a response.
+func probe(ctx context.Context, call func(ctx context.Context) error) (*api.Response, error) {
+	var last *api.Response
+	observed := api.WithResponseObserver(ctx, func(resp api.Response) {
+		last = &resp
+	})
+	err := call(observed)
+	return last, err
+}
+
+// formatStatus renders a status report with a hint for the most likely fix.
+func formatStatus(s statusOutput) string {
+	var sb strings.Builder
+	sb.WriteString("App Store Connect status\n\n")
+
+	if !s.TokenValid {
+		sb.WriteString(fmt.Sprintf("Token: cannot be signed (%s)\n", s.TokenError))
+		sb.WriteString("\nCheck the team name and the profile's ISSUER_ID, KEY_ID and private key settings.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Team: %s\n", s.Team))
+	sb.WriteString(fmt.Sprintf("Issuer ID: %s\n", s.IssuerID))
+	sb.WriteString(fmt.Sprintf("Key ID: %s\n", s.KeyID))
+	sb.WriteString(fmt.Sprintf("API: %s\n", s.BaseURL))
+	sb.WriteString(fmt.Sprintf("Token: valid, expires %s\n", s.TokenExpiresAt))
+
+	if !s.Reachable {
+		sb.WriteString(fmt.Sprintf("Reachable: no (%s)\n", s.APIError))
+		sb.WriteString("\nThe API could not be reached. Check the network connection, proxy settings and BASE_URL.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Reachable: yes, HTTP %d in %dms\n", s.StatusCode, s.LatencyMs))
+	if s.RateLimit != nil {
+		sb.WriteString(fmt.Sprintf("Rate limit: %d of %d requests left this hour\n", s.RateLimit.Remaining, s.RateLimit.Limit))
+	}
+
+	if !s.Authenticated {
+		sb.WriteString(fmt.Sprintf("Authenticated: no (%s)\n", s.APIError))
+		switch s.StatusCode {
+		case http.StatusUnauthorized:
+			sb.WriteString("\nThe API rejected the token. The key may be revoked, or the key ID and issuer ID may not belong together.\n")
+		case http.StatusForbidden:
+			sb.WriteString("\nThe key was accepted but is not allowed to list apps. Check its role and app access in Users and Access.\n")
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("Authenticated: yes\n")
+	switch {
+	case s.AdminAccess == nil:
+		sb.WriteString("Role: unknown (the user list could not be checked)\n")
+	case *s.AdminAccess:
+		sb.WriteString("Role: Admin\n")
+	default:
+		sb.WriteString("Role: not Admin (user management tools will be refused)\n")
+	}
+
+	return sb.String()
+}
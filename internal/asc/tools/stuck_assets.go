@@ -0,0 +1,301 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// defaultStuckAssetHours is how long an asset must have been stuck before
+// delete_stuck_assets removes it, unless older_than_hours is given.
+const defaultStuckAssetHours = 24
+
+// stuckAssetStates are the delivery states of assets that will never finish
+// processing on their own.
+var stuckAssetStates = map[string]bool{
+	assetAwaitingUpload: true,
+	assetFailed:         true,
+}
+
+// stuckAssetCandidate is an uploaded asset found while scanning a version.
+type stuckAssetCandidate struct {
+	asset  stuckAsset
+	delete func(ctx context.Context) error
+}
+
+// registerStuckAssetTools registers the cleanup tool for failed uploads.
+func (r *Registry) registerStuckAssetTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "delete_stuck_assets",
+			Description: "Find screenshots, app previews and App Review attachments of an app's editable versions that are stuck in FAILED or AWAITING_UPLOAD, and delete those stuck for longer than older_than_hours. Stuck assets clutter sets, block reordering and can hold up a submission. The API doesn't report when an asset got stuck, so the age counts from when this server first saw it stuck; run the tool once to start the clock, or pass older_than_hours 0 to delete every stuck asset now.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"app_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the app",
+					},
+					"version_id": {
+						Type:        "string",
+						Description: "Optional: Only check this App Store version (default: every editable version of the app)",
+					},
+					"older_than_hours": {
+						Type:        "number",
+						Description: "Only delete assets first seen stuck at least this many hours ago (default: 24)",
+						Default:     defaultStuckAssetHours,
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "List the stuck assets without deleting any (default: false)",
+					},
+				},
+				Required: []string{"app_id"},
+			},
+			OutputSchema: mcp.SchemaFor(stuckAssetsOutput{}),
+		},
+		r.handleDeleteStuckAssets,
+	)
+}
+
+// handleDeleteStuckAssets handles the delete_stuck_assets tool.
+func (r *Registry) handleDeleteStuckAssets(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		AppID          string   `json:"app_id"`
+		VersionID      string   `json:"version_id"`
+		OlderThanHours *float64 `json:"older_than_hours"`
+		DryRun         bool     `json:"dry_run"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.AppID == "" {
+		return mcp.NewErrorResult("app_id is required"), nil
+	}
+	hours := float64(defaultStuckAssetHours)
+	if params.OlderThanHours != nil {
+		hours = *params.OlderThanHours
+	}
+	if hours < 0 {
+		return mcp.NewErrorResult("older_than_hours must not be negative"), nil
+	}
+	threshold := time.Duration(hours * float64(time.Hour))
+
+	versions, err := r.client.GetAppVersions(ctx, params.AppID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app versions: %v", err), err), nil
+	}
+	r.recordVersions(params.AppID, versions.Data)
+
+	output := stuckAssetsOutput{
+		AppID:          params.AppID,
+		OlderThanHours: hours,
+		DryRun:         params.DryRun,
+		VersionIDs:     make([]string, 0),
+		Deleted:        make([]stuckAsset, 0),
+		Waiting:        make([]stuckAsset, 0),
+		Errors:         make([]string, 0),
+	}
+
+	for _, v := range versions.Data {
+		if params.VersionID != "" && v.ID != params.VersionID {
+			continue
+		}
+		if params.VersionID == "" && !editableVersionStates[v.Attributes.AppStoreState] {
+			continue
+		}
+		output.VersionIDs = append(output.VersionIDs, v.ID)
+
+		candidates, errs := r.versionAssets(ctx, v.ID)
+		output.Errors = append(output.Errors, errs...)
+
+		now := time.Now()
+		for _, c := range candidates {
+			if !stuckAssetStates[c.asset.State] {
+				r.forgetAsset(c.asset.ID)
+				continue
+			}
+			since, err := r.snapshots.RecordAssetState(c.asset.ID, c.asset.State)
+			if err != nil {
+				log.Printf("failed to record asset %s state: %v", c.asset.ID, err)
+			}
+			c.asset.StuckSince = since.Format(time.RFC3339)
+			c.asset.StuckHours = now.Sub(since).Hours()
+
+			if now.Sub(since) < threshold || params.DryRun {
+				output.Waiting = append(output.Waiting, c.asset)
+				continue
+			}
+			if err := c.delete(ctx); err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("Failed to delete %s %s: %v", c.asset.Type, c.asset.ID, err))
+				continue
+			}
+			r.forgetAsset(c.asset.ID)
+			output.Deleted = append(output.Deleted, c.asset)
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if params.VersionID != "" && len(output.VersionIDs) == 0 {
+		return mcp.NewErrorResult(fmt.Sprintf("Version %s is not one of app %s's versions", params.VersionID, params.AppID)), nil
+	}
+
+	return mcp.NewStructuredResult(formatStuckAssets(output), output), nil
+}
+
+// versionAssets lists the screenshots, previews and review attachments of a
+// version. Lookups that fail are reported and skipped.
+func (r *Registry) versionAssets(ctx context.Context, versionID string) ([]stuckAssetCandidate, []string) {
+	var candidates []stuckAssetCandidate
+	var errs []string
+
+	localizations, err := r.client.ListAppStoreVersionLocalizations(ctx, versionID, api.ListOptions{})
+	if err != nil {
+		return nil, []string{fmt.Sprintf("Failed to list localizations of version %s: %v", versionID, err)}
+	}
+	for _, loc := range localizations.Data {
+		locale := loc.Attributes.Locale
+
+		sets, err := r.client.ListAppScreenshotSets(ctx, loc.ID, api.ListOptions{Limit: 50})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to list %s screenshot sets: %v", locale, err))
+		} else {
+			for _, set := range sets.Data {
+				screenshots, err := r.client.ListAppScreenshots(ctx, set.ID, api.ListOptions{Limit: 50})
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("Failed to list screenshots in set %s: %v", set.ID, err))
+					continue
+				}
+				for _, s := range screenshots.Data {
+					id := s.ID
+					candidates = append(candidates, stuckAssetCandidate{
+						asset:  newStuckAsset("screenshot", id, s.Attributes.FileName, versionID, locale, set.Attributes.ScreenshotDisplayType, s.Attributes.AssetDeliveryState),
+						delete: func(ctx context.Context) error { return r.client.DeleteAppScreenshot(ctx, id) },
+					})
+				}
+			}
+		}
+
+		previewSets, err := r.client.ListAppPreviewSets(ctx, loc.ID, api.ListOptions{Limit: 50})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to list %s preview sets: %v", locale, err))
+			continue
+		}
+		for _, set := range previewSets.Data {
+			previews, err := r.client.ListAppPreviews(ctx, set.ID, api.ListOptions{Limit: 50})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Failed to list previews in set %s: %v", set.ID, err))
+				continue
+			}
+			for _, p := range previews.Data {
+				id := p.ID
+				candidates = append(candidates, stuckAssetCandidate{
+					asset:  newStuckAsset("preview", id, p.Attributes.FileName, versionID, locale, set.Attributes.PreviewType, p.Attributes.AssetDeliveryState),
+					delete: func(ctx context.Context) error { return r.client.DeleteAppPreview(ctx, id) },
+				})
+			}
+		}
+	}
+
+	// A version without review details has no attachments.
+	detail, err := r.client.GetAppStoreReviewDetail(ctx, versionID)
+	if err != nil || detail.Data.ID == "" {
+		return candidates, errs
+	}
+	attachments, err := r.client.ListAppStoreReviewAttachments(ctx, detail.Data.ID, api.ListOptions{Limit: 50})
+	if err != nil {
+		return candidates, append(errs, fmt.Sprintf("Failed to list review attachments: %v", err))
+	}
+	for _, a := range attachments.Data {
+		id := a.ID
+		candidates = append(candidates, stuckAssetCandidate{
+			asset:  newStuckAsset("review_attachment", id, a.Attributes.FileName, versionID, "", "", a.Attributes.AssetDeliveryState),
+			delete: func(ctx context.Context) error { return r.client.DeleteAppStoreReviewAttachment(ctx, id) },
+		})
+	}
+	return candidates, errs
+}
+
+// newStuckAsset describes an asset and its delivery state.
+func newStuckAsset(assetType, id, fileName, versionID, locale, displayType string, state *api.AssetDeliveryState) stuckAsset {
+	asset := stuckAsset{
+		Type:        assetType,
+		ID:          id,
+		FileName:    fileName,
+		VersionID:   versionID,
+		Locale:      locale,
+		DisplayType: displayType,
+	}
+	if state != nil {
+		asset.State = state.State
+		for _, e := range state.Errors {
+			asset.ErrorCodes = append(asset.ErrorCodes, e.Code)
+		}
+	}
+	return asset
+}
+
+// forgetAsset stops tracking an asset that is no longer stuck.
+func (r *Registry) forgetAsset(assetID string) {
+	if err := r.snapshots.ForgetAsset(assetID); err != nil {
+		log.Printf("failed to forget asset %s: %v", assetID, err)
+	}
+}
+
+// formatStuckAssets summarizes a stuck asset cleanup.
+func formatStuckAssets(o stuckAssetsOutput) string {
+	var sb strings.Builder
+	if o.DryRun {
+		sb.WriteString(fmt.Sprintf("Dry run: found %d stuck assets in %d versions of app %s\n", len(o.Waiting), len(o.VersionIDs), o.AppID))
+	} else {
+		sb.WriteString(fmt.Sprintf("Deleted %d stuck assets in %d versions of app %s\n", len(o.Deleted), len(o.VersionIDs), o.AppID))
+	}
+
+	writeAsset := func(a stuckAsset) {
+		where := a.Locale
+		if a.DisplayType != "" {
+			where = fmt.Sprintf("%s %s", a.Locale, a.DisplayType)
+		}
+		if where != "" {
+			where = ", " + where
+		}
+		codes := ""
+		if len(a.ErrorCodes) > 0 {
+			codes = fmt.Sprintf(" [%s]", strings.Join(a.ErrorCodes, ", "))
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s (%s%s): %s for %.1fh%s\n", a.Type, a.ID, a.FileName, where, a.State, a.StuckHours, codes))
+	}
+
+	if len(o.Deleted) > 0 {
+		sb.WriteString("\nDeleted:\n")
+		for _, a := range o.Deleted {
+			writeAsset(a)
+		}
+	}
+	if len(o.Waiting) > 0 {
+		if o.DryRun {
+			sb.WriteString("\nStuck:\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("\nStuck for less than %gh, kept:\n", o.OlderThanHours))
+		}
+		for _, a := range o.Waiting {
+			writeAsset(a)
+		}
+	}
+	if len(o.Errors) > 0 {
+		sb.WriteString("\nErrors:\n")
+		for _, e := range o.Errors {
+			sb.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+	}
+	return sb.String()
+}
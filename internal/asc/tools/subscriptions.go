@@ -27,6 +27,7 @@ func (r *Registry) registerSubscriptionTools() {
 					Type:        "integer",
 					Description: "Maximum number of groups to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
@@ -63,6 +64,7 @@ func (r *Registry) registerSubscriptionTools() {
 					Type:        "integer",
 					Description: "Maximum number of subscriptions to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"group_id"},
 		},
@@ -85,10 +87,11 @@ func (r *Registry) registerSubscriptionTools() {
 	}, r.handleGetSubscription)
 }
 
-func (r *Registry) handleListSubscriptionGroups(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSubscriptionGroups(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -103,15 +106,15 @@ func (r *Registry) handleListSubscriptionGroups(args json.RawMessage) (*mcp.Tool
 		limit = 50
 	}
 
-	resp, err := r.client.ListSubscriptionGroups(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListSubscriptionGroups(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list subscription groups: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list subscription groups: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatSubscriptionGroups(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatSubscriptionGroups(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetSubscriptionGroup(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetSubscriptionGroup(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GroupID string `json:"group_id"`
 	}
@@ -123,18 +126,19 @@ func (r *Registry) handleGetSubscriptionGroup(args json.RawMessage) (*mcp.ToolsC
 		return nil, fmt.Errorf("group_id is required")
 	}
 
-	resp, err := r.client.GetSubscriptionGroup(context.Background(), params.GroupID)
+	resp, err := r.client.GetSubscriptionGroup(ctx, params.GroupID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get subscription group: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get subscription group: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatSubscriptionGroup(resp.Data)), nil
 }
 
-func (r *Registry) handleListSubscriptions(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListSubscriptions(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		GroupID string `json:"group_id"`
 		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -149,15 +153,15 @@ func (r *Registry) handleListSubscriptions(args json.RawMessage) (*mcp.ToolsCall
 		limit = 50
 	}
 
-	resp, err := r.client.ListSubscriptions(context.Background(), params.GroupID, limit)
+	resp, err := r.client.ListSubscriptions(api.WithCursor(ctx, params.Cursor), params.GroupID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list subscriptions: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list subscriptions: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatSubscriptions(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatSubscriptions(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetSubscription(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetSubscription(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		SubscriptionID string `json:"subscription_id"`
 	}
@@ -169,9 +173,9 @@ func (r *Registry) handleGetSubscription(args json.RawMessage) (*mcp.ToolsCallRe
 		return nil, fmt.Errorf("subscription_id is required")
 	}
 
-	resp, err := r.client.GetSubscription(context.Background(), params.SubscriptionID)
+	resp, err := r.client.GetSubscription(ctx, params.SubscriptionID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get subscription: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get subscription: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatSubscription(resp.Data)), nil
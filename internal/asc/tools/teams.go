@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// EnableTeams registers the select_team tool and adds an optional team
+// argument to every tool. It is used when the client holds several teams.
+func (r *Registry) EnableTeams() {
+	prop := r.teamProperty()
+	for i := range r.tools {
+		addProperty(&r.tools[i], "team", prop)
+	}
+
+	r.registerGroup("teams", r.registerTeamTools)
+	r.teams = true
+}
+
+// teamProperty describes the per-call team argument.
+func (r *Registry) teamProperty() mcp.Property {
+	return mcp.Property{
+		Type:        "string",
+		Description: "Optional: Team profile to use for this call instead of the selected team",
+		Enum:        r.client.Teams(),
+	}
+}
+
+// registerTeamTools registers team selection tools.
+func (r *Registry) registerTeamTools() {
+	r.register(mcp.Tool{
+		Name:        "select_team",
+		Description: "Select the App Store Connect team whose API key later tool calls use. A single call can still pass team to act as another team.",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"team": {
+					Type:        "string",
+					Description: "Team profile name",
+					Enum:        r.client.Teams(),
+				},
+			},
+			Required: []string{"team"},
+		},
+	}, r.handleSelectTeam)
+}
+
+func (r *Registry) handleSelectTeam(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Team string `json:"team"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.Team == "" {
+		return mcp.NewErrorResult("team is required"), nil
+	}
+
+	previous := r.client.ActiveTeam()
+	denied := r.deniedTools()
+	if err := r.client.SelectTeam(params.Team); err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to select team: %v", err), err), nil
+	}
+
+	// The new team's key may lack roles the previous one had, or the reverse.
+	r.probeTeam(ctx, params.Team)
+	if !slices.Equal(denied, r.deniedTools()) {
+		r.notifyToolsChanged()
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Selected team %s (was %s). Later tool calls use its API key unless they pass team.", params.Team, previous)), nil
+}
+
+// teamArg returns the team argument of a tool call, if any.
+func teamArg(args json.RawMessage) string {
+	var params struct {
+		Team string `json:"team"`
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return ""
+	}
+	return params.Team
+}
@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+// registerTesterRemovalTools registers the tool for data deletion requests.
+func (r *Registry) registerTesterRemovalTools() {
+	r.register(
+		mcp.Tool{
+			Name:        "remove_tester_everywhere",
+			Description: "Find every TestFlight beta tester with an email address and delete them, which removes them from all of the team's apps and beta groups and stops their access to builds. Use it to act on a tester's request to delete their data. The result lists the apps and groups they were removed from, and checks that the email no longer matches any tester. With an audit log configured, the call and its DELETE requests are recorded there. Run with dry_run first to see what would be removed.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"email": {
+						Type:        "string",
+						Description: "The tester's email address",
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "List the testers, apps and groups without deleting anything (default: false)",
+					},
+				},
+				Required: []string{"email"},
+			},
+			OutputSchema: mcp.SchemaFor(testerRemovalOutput{}),
+		},
+		r.handleRemoveTesterEverywhere,
+	)
+}
+
+// handleRemoveTesterEverywhere handles the remove_tester_everywhere tool.
+func (r *Registry) handleRemoveTesterEverywhere(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		Email  string `json:"email"`
+		DryRun bool   `json:"dry_run"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	email := strings.TrimSpace(params.Email)
+	if email == "" {
+		return mcp.NewErrorResult("email is required"), nil
+	}
+
+	testers, err := r.findTestersByEmail(ctx, email)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to find beta testers: %v", err), err), nil
+	}
+
+	output := testerRemovalOutput{
+		Email:   email,
+		DryRun:  params.DryRun,
+		Testers: testers,
+		Errors:  make([]string, 0),
+	}
+	if len(testers) == 0 {
+		output.Verified = true
+		return mcp.NewStructuredResult(formatTesterRemoval(output), output), nil
+	}
+	if params.DryRun {
+		return mcp.NewStructuredResult(formatTesterRemoval(output), output), nil
+	}
+
+	for i, tester := range output.Testers {
+		if err := r.client.DeleteBetaTester(ctx, tester.ID); err != nil && !api.IsNotFound(err) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			output.Errors = append(output.Errors, fmt.Sprintf("Failed to delete beta tester %s: %v", tester.ID, err))
+			continue
+		}
+		output.Testers[i].Deleted = true
+	}
+
+	// Deletions can take a moment to show up, so a remaining match is
+	// reported rather than retried.
+	remaining, err := r.findTestersByEmail(ctx, email)
+	switch {
+	case err != nil:
+		output.Errors = append(output.Errors, fmt.Sprintf("Failed to check that the testers are gone: %v", err))
+	case len(remaining) > 0:
+		ids := make([]string, len(remaining))
+		for i, tester := range remaining {
+			ids[i] = tester.ID
+		}
+		output.Errors = append(output.Errors, fmt.Sprintf("Beta testers still found after deleting: %s", strings.Join(ids, ", ")))
+	default:
+		output.Verified = true
+	}
+
+	result := mcp.NewStructuredResult(formatTesterRemoval(output), output)
+	result.IsError = len(output.Errors) > 0
+	return result, nil
+}
+
+// findTestersByEmail returns the beta testers whose email is email, ignoring
+// case, with the names of the apps and groups they belong to.
+func (r *Registry) findTestersByEmail(ctx context.Context, email string) ([]removedTester, error) {
+	opts := api.ListOptions{Limit: 200}.WithFilter("email", email).WithInclude("apps", "betaGroups")
+	resp, err := r.client.ListBetaTesters(ctx, "", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	included, err := api.DecodeIncluded(resp.Included)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range included {
+		switch resource := resource.(type) {
+		case api.App:
+			names["apps/"+resource.ID] = resource.Attributes.Name
+		case api.BetaGroup:
+			names["betaGroups/"+resource.ID] = resource.Attributes.Name
+		}
+	}
+	describe := func(linkage *api.RelationshipDataList) []string {
+		described := make([]string, 0)
+		if linkage == nil {
+			return described
+		}
+		for _, ref := range linkage.Data {
+			if name := names[ref.Type+"/"+ref.ID]; name != "" {
+				described = append(described, fmt.Sprintf("%s (%s)", name, ref.ID))
+			} else {
+				described = append(described, ref.ID)
+			}
+		}
+		return described
+	}
+
+	testers := make([]removedTester, 0)
+	for _, t := range resp.Data {
+		if !strings.EqualFold(t.Attributes.Email, email) {
+			continue
+		}
+		var apps, groups *api.RelationshipDataList
+		if t.Relationships != nil {
+			apps, groups = t.Relationships.Apps, t.Relationships.BetaGroups
+		}
+		testers = append(testers, removedTester{
+			ID:     t.ID,
+			Name:   strings.TrimSpace(t.Attributes.FirstName + " " + t.Attributes.LastName),
+			State:  t.Attributes.State,
+			Apps:   describe(apps),
+			Groups: describe(groups),
+		})
+	}
+	return testers, nil
+}
+
+// formatTesterRemoval summarizes a tester removal.
+func formatTesterRemoval(o testerRemovalOutput) string {
+	var sb strings.Builder
+	switch {
+	case len(o.Testers) == 0:
+		sb.WriteString(fmt.Sprintf("No beta testers found with email %s\n", o.Email))
+		return sb.String()
+	case o.DryRun:
+		sb.WriteString(fmt.Sprintf("Dry run: would delete %d beta testers with email %s\n", len(o.Testers), o.Email))
+	default:
+		deleted := 0
+		for _, t := range o.Testers {
+			if t.Deleted {
+				deleted++
+			}
+		}
+		sb.WriteString(fmt.Sprintf("Deleted %d of %d beta testers with email %s\n", deleted, len(o.Testers), o.Email))
+	}
+
+	for _, t := range o.Testers {
+		name := t.Name
+		if name == "" {
+			name = "(no name)"
+		}
+		sb.WriteString(fmt.Sprintf("\n**%s** (ID: %s, %s)\n", name, t.ID, t.State))
+		if len(t.Apps) > 0 {
+			sb.WriteString(fmt.Sprintf("- Apps: %s\n", strings.Join(t.Apps, ", ")))
+		}
+		if len(t.Groups) > 0 {
+			sb.WriteString(fmt.Sprintf("- Groups: %s\n", strings.Join(t.Groups, ", ")))
+		}
+	}
+
+	if o.Verified && !o.DryRun {
+		sb.WriteString(fmt.Sprintf("\nVerified: no beta tester has email %s anymore.\n", o.Email))
+	}
+	if len(o.Errors) > 0 {
+		sb.WriteString("\nErrors:\n")
+		for _, e := range o.Errors {
+			sb.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+	}
+	return sb.String()
+}
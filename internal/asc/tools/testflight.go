@@ -29,6 +29,8 @@ func (r *Registry) registerTestFlightTools() {
 						Description: "Maximum number of beta groups to return (default: 50)",
 						Default:     50,
 					},
+					"cursor":  cursorProperty,
+					"refresh": refreshProperty,
 				},
 			},
 		},
@@ -83,10 +85,59 @@ func (r *Registry) registerTestFlightTools() {
 		r.handleDeleteBetaGroup,
 	)
 
+	r.register(
+		mcp.Tool{
+			Name:        "list_beta_group_builds",
+			Description: "List the builds a TestFlight beta group currently has access to. Returns build number, processing state, and upload and expiration dates for each build.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"beta_group_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the beta group",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of builds to return (default: 50)",
+						Default:     50,
+					},
+					"cursor": cursorProperty,
+				},
+				Required: []string{"beta_group_id"},
+			},
+			OutputSchema: mcp.SchemaFor(buildsOutput{}),
+		},
+		r.handleListBetaGroupBuilds,
+	)
+
+	r.register(
+		mcp.Tool{
+			Name:        "get_beta_group_overview",
+			Description: "Get an overview of a TestFlight beta group: tester and build counts, public link settings, public link views and acceptances, and tester sessions, crashes, and feedback over a period. Useful for tracking the growth of open betas.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"beta_group_id": {
+						Type:        "string",
+						Description: "The App Store Connect ID of the beta group",
+					},
+					"period": {
+						Type:        "string",
+						Description: "Reporting period for tester usage metrics (default: P30D)",
+						Enum:        []string{"P7D", "P30D", "P90D", "P365D"},
+						Default:     "P30D",
+					},
+				},
+				Required: []string{"beta_group_id"},
+			},
+		},
+		r.handleGetBetaGroupOverview,
+	)
+
 	r.register(
 		mcp.Tool{
 			Name:        "list_beta_testers",
-			Description: "List TestFlight beta testers. Can filter by beta group ID. Returns tester email, name, invite status, and state.",
+			Description: "List TestFlight beta testers. Can filter by beta group ID. Returns tester email, name, invite status, and state. Set all to return every tester at once instead of a page.",
 			InputSchema: mcp.JSONSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -99,6 +150,12 @@ func (r *Registry) registerTestFlightTools() {
 						Description: "Maximum number of testers to return (default: 50)",
 						Default:     50,
 					},
+					"cursor": cursorProperty,
+					"all": {
+						Type:        "boolean",
+						Description: "Return every tester, fetching pages in parallel; limit and cursor are ignored (default: false)",
+						Default:     false,
+					},
 				},
 			},
 		},
@@ -177,10 +234,12 @@ func (r *Registry) registerTestFlightTools() {
 }
 
 // handleListBetaGroups handles the list_beta_groups tool.
-func (r *Registry) handleListBetaGroups(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBetaGroups(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID   string `json:"app_id"`
+		Limit   int    `json:"limit"`
+		Cursor  string `json:"cursor"`
+		Refresh bool   `json:"refresh"`
 	}
 	params.Limit = 50
 
@@ -190,10 +249,9 @@ func (r *Registry) handleListBetaGroups(args json.RawMessage) (*mcp.ToolsCallRes
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListBetaGroups(ctx, params.AppID, params.Limit)
+	resp, err := r.client.ListBetaGroups(api.WithCursor(withRefresh(ctx, params.Refresh), params.Cursor), params.AppID, api.ListOptions{Limit: params.Limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list beta groups: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta groups: %v", err), err), nil
 	}
 
 	if len(resp.Data) == 0 {
@@ -219,11 +277,11 @@ func (r *Registry) handleListBetaGroups(args json.RawMessage) (*mcp.ToolsCallRes
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), resp.Links)), nil
 }
 
 // handleCreateBetaGroup handles the create_beta_group tool.
-func (r *Registry) handleCreateBetaGroup(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateBetaGroup(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		AppID             string `json:"app_id"`
 		Name              string `json:"name"`
@@ -262,10 +320,9 @@ func (r *Registry) handleCreateBetaGroup(args json.RawMessage) (*mcp.ToolsCallRe
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.CreateBetaGroup(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create beta group: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create beta group: %v", err), err), nil
 	}
 
 	var sb strings.Builder
@@ -278,7 +335,7 @@ func (r *Registry) handleCreateBetaGroup(args json.RawMessage) (*mcp.ToolsCallRe
 }
 
 // handleDeleteBetaGroup handles the delete_beta_group tool.
-func (r *Registry) handleDeleteBetaGroup(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteBetaGroup(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BetaGroupID string `json:"beta_group_id"`
 	}
@@ -291,19 +348,178 @@ func (r *Registry) handleDeleteBetaGroup(args json.RawMessage) (*mcp.ToolsCallRe
 		return mcp.NewErrorResult("beta_group_id is required"), nil
 	}
 
-	ctx := context.Background()
 	if err := r.client.DeleteBetaGroup(ctx, params.BetaGroupID); err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete beta group: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete beta group: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Successfully deleted beta group %s", params.BetaGroupID)), nil
 }
 
+// handleListBetaGroupBuilds handles the list_beta_group_builds tool.
+func (r *Registry) handleListBetaGroupBuilds(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BetaGroupID string `json:"beta_group_id"`
+		Limit       int    `json:"limit"`
+		Cursor      string `json:"cursor"`
+	}
+	params.Limit = 50
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BetaGroupID == "" {
+		return mcp.NewErrorResult("beta_group_id is required"), nil
+	}
+
+	resp, err := r.client.ListBetaGroupBuilds(api.WithCursor(ctx, params.Cursor), params.BetaGroupID, api.ListOptions{Limit: params.Limit})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta group builds: %v", err), err), nil
+	}
+
+	if len(resp.Data) == 0 {
+		return mcp.NewStructuredResult("This beta group has no builds.", buildsOutput{Builds: []api.Build{}}), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Beta group has access to %d builds:\n\n", len(resp.Data)))
+
+	for _, build := range resp.Data {
+		sb.WriteString(fmt.Sprintf("**Build %s**\n", build.Attributes.Version))
+		sb.WriteString(fmt.Sprintf("  - ID: %s\n", build.ID))
+		sb.WriteString(fmt.Sprintf("  - Processing State: %s\n", build.Attributes.ProcessingState))
+		sb.WriteString(fmt.Sprintf("  - Expired: %v\n", build.Attributes.Expired))
+		if build.Attributes.UploadedDate != nil {
+			sb.WriteString(fmt.Sprintf("  - Uploaded: %s\n", build.Attributes.UploadedDate.Format("2006-01-02 15:04")))
+		}
+		if build.Attributes.ExpirationDate != nil {
+			sb.WriteString(fmt.Sprintf("  - Expires: %s\n", build.Attributes.ExpirationDate.Format("2006-01-02")))
+		}
+		sb.WriteString("\n")
+	}
+
+	output := buildsOutput{Builds: resp.Data, NextCursor: resp.Links.NextCursor()}
+	return mcp.NewStructuredResult(withNextCursor(sb.String(), resp.Links), output), nil
+}
+
+// handleGetBetaGroupOverview handles the get_beta_group_overview tool.
+func (r *Registry) handleGetBetaGroupOverview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BetaGroupID string `json:"beta_group_id"`
+		Period      string `json:"period"`
+	}
+	params.Period = "P30D"
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BetaGroupID == "" {
+		return mcp.NewErrorResult("beta_group_id is required"), nil
+	}
+
+	resp, err := r.client.GetBetaGroup(ctx, params.BetaGroupID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get beta group: %v", err), err), nil
+	}
+	group := resp.Data
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", group.Attributes.Name))
+	sb.WriteString(fmt.Sprintf("- ID: %s\n", group.ID))
+	sb.WriteString(fmt.Sprintf("- Internal Group: %v\n", group.Attributes.IsInternalGroup))
+
+	// Counts and metrics are best effort: internal groups, for example, have no public link metrics.
+	if testers, err := r.client.ListBetaTesters(ctx, params.BetaGroupID, api.ListOptions{Limit: 1}); err == nil {
+		sb.WriteString(fmt.Sprintf("- Testers: %s\n", pagingTotal(testers.Meta, len(testers.Data))))
+	}
+	if group.Attributes.HasAccessToAllBuilds {
+		sb.WriteString("- Builds: all builds\n")
+	} else if builds, err := r.client.ListBetaGroupBuilds(ctx, params.BetaGroupID, api.ListOptions{Limit: 1}); err == nil {
+		sb.WriteString(fmt.Sprintf("- Builds: %s\n", pagingTotal(builds.Meta, len(builds.Data))))
+	}
+
+	sb.WriteString("\n**Public Link**\n")
+	if !group.Attributes.PublicLinkEnabled {
+		sb.WriteString("- Enabled: false\n")
+	} else {
+		sb.WriteString("- Enabled: true\n")
+		if group.Attributes.PublicLink != "" {
+			sb.WriteString(fmt.Sprintf("- Link: %s\n", group.Attributes.PublicLink))
+		}
+		if group.Attributes.PublicLinkLimitEnabled {
+			sb.WriteString(fmt.Sprintf("- Tester Limit: %d\n", group.Attributes.PublicLinkLimit))
+		}
+
+		usages, err := r.client.GetBetaGroupPublicLinkUsages(ctx, params.BetaGroupID)
+		if err != nil {
+			sb.WriteString("- Usage: unavailable\n")
+		} else {
+			totals := sumPublicLinkUsages(usages.Data)
+			sb.WriteString(fmt.Sprintf("- Views: %d\n", totals.ViewCount))
+			sb.WriteString(fmt.Sprintf("- Accepted: %d\n", totals.AcceptedCount))
+			sb.WriteString(fmt.Sprintf("- Did Not Accept: %d\n", totals.DidNotAcceptCount))
+			sb.WriteString(fmt.Sprintf("- Did Not Meet Criteria: %d\n", totals.DidNotMeetCriteriaCount))
+			if totals.ViewCount > 0 {
+				sb.WriteString(fmt.Sprintf("- Acceptance Rate: %.1f%%\n", float64(totals.AcceptedCount)/float64(totals.ViewCount)*100))
+			}
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**Tester Usage (%s)**\n", params.Period))
+	usages, err := r.client.GetBetaGroupTesterUsages(ctx, params.BetaGroupID, params.Period)
+	if err != nil {
+		sb.WriteString("- Usage: unavailable\n")
+	} else {
+		totals := sumTesterUsages(usages.Data)
+		sb.WriteString(fmt.Sprintf("- Sessions: %d\n", totals.SessionCount))
+		sb.WriteString(fmt.Sprintf("- Crashes: %d\n", totals.CrashCount))
+		sb.WriteString(fmt.Sprintf("- Feedback: %d\n", totals.FeedbackCount))
+	}
+
+	return mcp.NewSuccessResult(sb.String()), nil
+}
+
+// pagingTotal formats the total from paging metadata, falling back to the page size.
+func pagingTotal(meta *api.PagingInformation, pageSize int) string {
+	if meta == nil {
+		return fmt.Sprintf("%d+", pageSize)
+	}
+	return fmt.Sprintf("%d", meta.Paging.Total)
+}
+
+// sumPublicLinkUsages adds up public link usage across reporting intervals.
+func sumPublicLinkUsages(usages []api.BetaPublicLinkUsage) api.BetaPublicLinkUsageValues {
+	var totals api.BetaPublicLinkUsageValues
+	for _, usage := range usages {
+		values := usage.DataPoints.Values
+		totals.ViewCount += values.ViewCount
+		totals.AcceptedCount += values.AcceptedCount
+		totals.DidNotAcceptCount += values.DidNotAcceptCount
+		totals.DidNotMeetCriteriaCount += values.DidNotMeetCriteriaCount
+	}
+	return totals
+}
+
+// sumTesterUsages adds up tester usage across reporting intervals.
+func sumTesterUsages(usages []api.BetaTesterUsage) api.BetaTesterUsageValues {
+	var totals api.BetaTesterUsageValues
+	for _, usage := range usages {
+		values := usage.DataPoints.Values
+		totals.SessionCount += values.SessionCount
+		totals.CrashCount += values.CrashCount
+		totals.FeedbackCount += values.FeedbackCount
+	}
+	return totals
+}
+
 // handleListBetaTesters handles the list_beta_testers tool.
-func (r *Registry) handleListBetaTesters(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListBetaTesters(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BetaGroupID string `json:"beta_group_id"`
 		Limit       int    `json:"limit"`
+		Cursor      string `json:"cursor"`
+		All         bool   `json:"all"`
 	}
 	params.Limit = 50
 
@@ -313,20 +529,30 @@ func (r *Registry) handleListBetaTesters(args json.RawMessage) (*mcp.ToolsCallRe
 		}
 	}
 
-	ctx := context.Background()
-	resp, err := r.client.ListBetaTesters(ctx, params.BetaGroupID, params.Limit)
-	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list beta testers: %v", err)), nil
+	var testers []api.BetaTester
+	var links api.PagedDocumentLinks
+	if params.All {
+		all, err := r.client.ListAllBetaTesters(ctx, params.BetaGroupID)
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta testers: %v", err), err), nil
+		}
+		testers = all
+	} else {
+		resp, err := r.client.ListBetaTesters(api.WithCursor(ctx, params.Cursor), params.BetaGroupID, api.ListOptions{Limit: params.Limit})
+		if err != nil {
+			return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list beta testers: %v", err), err), nil
+		}
+		testers, links = resp.Data, resp.Links
 	}
 
-	if len(resp.Data) == 0 {
+	if len(testers) == 0 {
 		return mcp.NewSuccessResult("No beta testers found."), nil
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d beta testers:\n\n", len(resp.Data)))
+	sb.WriteString(fmt.Sprintf("Found %d beta testers:\n\n", len(testers)))
 
-	for _, tester := range resp.Data {
+	for _, tester := range testers {
 		name := tester.Attributes.Email
 		if tester.Attributes.FirstName != "" || tester.Attributes.LastName != "" {
 			name = fmt.Sprintf("%s %s (%s)", tester.Attributes.FirstName, tester.Attributes.LastName, tester.Attributes.Email)
@@ -338,11 +564,11 @@ func (r *Registry) handleListBetaTesters(args json.RawMessage) (*mcp.ToolsCallRe
 		sb.WriteString("\n")
 	}
 
-	return mcp.NewSuccessResult(sb.String()), nil
+	return mcp.NewSuccessResult(withNextCursor(sb.String(), links)), nil
 }
 
 // handleInviteBetaTester handles the invite_beta_tester tool.
-func (r *Registry) handleInviteBetaTester(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleInviteBetaTester(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		Email        string   `json:"email"`
 		FirstName    string   `json:"first_name"`
@@ -384,10 +610,9 @@ func (r *Registry) handleInviteBetaTester(args json.RawMessage) (*mcp.ToolsCallR
 		}
 	}
 
-	ctx := context.Background()
 	resp, err := r.client.CreateBetaTester(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to invite beta tester: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to invite beta tester: %v", err), err), nil
 	}
 
 	var sb strings.Builder
@@ -399,7 +624,7 @@ func (r *Registry) handleInviteBetaTester(args json.RawMessage) (*mcp.ToolsCallR
 }
 
 // handleRemoveBetaTester handles the remove_beta_tester tool.
-func (r *Registry) handleRemoveBetaTester(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleRemoveBetaTester(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BetaTesterID string `json:"beta_tester_id"`
 	}
@@ -412,16 +637,15 @@ func (r *Registry) handleRemoveBetaTester(args json.RawMessage) (*mcp.ToolsCallR
 		return mcp.NewErrorResult("beta_tester_id is required"), nil
 	}
 
-	ctx := context.Background()
 	if err := r.client.DeleteBetaTester(ctx, params.BetaTesterID); err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to remove beta tester: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to remove beta tester: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Successfully removed beta tester %s", params.BetaTesterID)), nil
 }
 
 // handleAddTesterToGroup handles the add_tester_to_group tool.
-func (r *Registry) handleAddTesterToGroup(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleAddTesterToGroup(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BetaGroupID  string `json:"beta_group_id"`
 		BetaTesterID string `json:"beta_tester_id"`
@@ -438,9 +662,8 @@ func (r *Registry) handleAddTesterToGroup(args json.RawMessage) (*mcp.ToolsCallR
 		return mcp.NewErrorResult("beta_tester_id is required"), nil
 	}
 
-	ctx := context.Background()
 	if err := r.client.AddBetaTesterToGroup(ctx, params.BetaGroupID, params.BetaTesterID); err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to add tester to group: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to add tester to group: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Successfully added beta tester %s to group %s", params.BetaTesterID, params.BetaGroupID)), nil
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// defaultToolTimeout bounds a tool call that has no more specific limit.
+	defaultToolTimeout = 2 * time.Minute
+
+	// listToolTimeout bounds list tools, which fetch a single page.
+	listToolTimeout = 30 * time.Second
+
+	// reportToolTimeout bounds sales and finance report downloads.
+	reportToolTimeout = 5 * time.Minute
+
+	// waitToolTimeout bounds wait tools. It leaves room past maxPollTimeout
+	// so a wait tool reports its own timeout with the last status it saw.
+	waitToolTimeout = maxPollTimeout + time.Minute
+)
+
+// reportTools lists the tools that download potentially large reports.
+var reportTools = map[string]bool{
+	"get_sales_report":   true,
+	"get_finance_report": true,
+	"generate_digest":    true,
+}
+
+// SetToolTimeouts overrides the maximum duration of tool calls. Keys are a
+// tool name, a name prefix ending in "*" such as "list_*", or "*" for every
+// tool. An exact name wins over a prefix, and a longer prefix over a shorter one.
+func (r *Registry) SetToolTimeouts(timeouts map[string]time.Duration) {
+	for pattern, timeout := range timeouts {
+		r.timeouts[pattern] = timeout
+	}
+}
+
+// toolTimeout returns the maximum duration of a call to the named tool.
+func (r *Registry) toolTimeout(name string) time.Duration {
+	if timeout, ok := r.timeouts[name]; ok {
+		return timeout
+	}
+
+	best := -1
+	var timeout time.Duration
+	for pattern, d := range r.timeouts {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if !ok || !strings.HasPrefix(name, prefix) || len(prefix) <= best {
+			continue
+		}
+		best = len(prefix)
+		timeout = d
+	}
+	if best >= 0 {
+		return timeout
+	}
+
+	switch {
+	case reportTools[name]:
+		return reportToolTimeout
+	case r.progressHandlers[name] != nil:
+		return waitToolTimeout
+	case strings.HasPrefix(name, "list_"):
+		return listToolTimeout
+	default:
+		return defaultToolTimeout
+	}
+}
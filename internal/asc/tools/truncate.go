@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
+)
+
+const (
+	// continuationTTL is how long the remainder of a truncated result is kept.
+	continuationTTL = 30 * time.Minute
+
+	// maxContinuations caps how many truncated results are kept at once. The
+	// oldest is dropped first.
+	maxContinuations = 32
+
+	// continuationTool is the name of the tool that returns the remainder of
+	// a truncated result. Its own results are already sized to the budget.
+	continuationTool = "get_result_continuation"
+)
+
+// continuation is the unread remainder of a truncated tool result.
+type continuation struct {
+	text    string
+	total   int
+	created time.Time
+}
+
+// continuations holds the remainders of truncated results by token.
+type continuations struct {
+	mu      sync.Mutex
+	entries map[string]*continuation
+	now     func() time.Time
+}
+
+// newContinuations returns an empty continuation store.
+func newContinuations() *continuations {
+	return &continuations{
+		entries: make(map[string]*continuation),
+		now:     time.Now,
+	}
+}
+
+// put stores a remainder and returns its token.
+func (c *continuations) put(text string, total int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	for len(c.entries) >= maxContinuations {
+		var oldest string
+		for token, entry := range c.entries {
+			if oldest == "" || entry.created.Before(c.entries[oldest].created) {
+				oldest = token
+			}
+		}
+		delete(c.entries, oldest)
+	}
+
+	token := newContinuationToken()
+	c.entries[token] = &continuation{text: text, total: total, created: c.now()}
+	return token
+}
+
+// take removes and returns the remainder stored under token.
+func (c *continuations) take(token string) (*continuation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	entry, ok := c.entries[token]
+	if ok {
+		delete(c.entries, token)
+	}
+	return entry, ok
+}
+
+// expire drops remainders older than continuationTTL. The caller holds c.mu.
+func (c *continuations) expire() {
+	cutoff := c.now().Add(-continuationTTL)
+	for token, entry := range c.entries {
+		if entry.created.Before(cutoff) {
+			delete(c.entries, token)
+		}
+	}
+}
+
+// newContinuationToken returns a random token.
+func newContinuationToken() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// SetMaxResultBytes limits the size of a tool result's text. Longer text is
+// cut at the limit and the rest is returned by get_result_continuation.
+// Zero or less turns truncation off.
+func (r *Registry) SetMaxResultBytes(n int) {
+	r.maxResultBytes = n
+}
+
+// registerContinuationTools registers the tool that pages through truncated results.
+func (r *Registry) registerContinuationTools() {
+	r.register(
+		mcp.Tool{
+			Name:        continuationTool,
+			Description: "Get the next part of a tool result that was cut short because it was too large. Pass the continuation_token from the end of the truncated result. Each token can be used once; a part that is still too large ends with a new token. Tokens expire after 30 minutes.",
+			InputSchema: mcp.JSONSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"continuation_token": {
+						Type:        "string",
+						Description: "The continuation_token from the end of a truncated result",
+					},
+				},
+				Required: []string{"continuation_token"},
+			},
+		},
+		r.handleGetResultContinuation,
+	)
+}
+
+// handleGetResultContinuation handles the get_result_continuation tool.
+func (r *Registry) handleGetResultContinuation(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		ContinuationToken string `json:"continuation_token"`
+	}
+
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.ContinuationToken == "" {
+		return mcp.NewErrorResult("continuation_token is required"), nil
+	}
+
+	entry, ok := r.continuations.take(params.ContinuationToken)
+	if !ok {
+		return mcp.NewErrorResult("Unknown or expired continuation_token. Call the original tool again."), nil
+	}
+
+	return mcp.NewSuccessResult(r.truncateText(entry.text, entry.total)), nil
+}
+
+// truncateResult cuts a result whose text exceeds the size limit and stores
+// the rest for get_result_continuation. Structured content can't be cut
+// without breaking its schema, so it is dropped if it is over the limit too.
+func (r *Registry) truncateResult(name string, result *mcp.ToolsCallResult) *mcp.ToolsCallResult {
+	if result == nil || r.maxResultBytes <= 0 || name == continuationTool {
+		return result
+	}
+
+	for i, block := range result.Content {
+		if block.Type == "text" && len(block.Text) > r.maxResultBytes {
+			result.Content[i].Text = r.truncateText(block.Text, len(block.Text))
+		}
+	}
+
+	if result.StructuredContent != nil {
+		if data, err := json.Marshal(result.StructuredContent); err == nil && len(data) > r.maxResultBytes {
+			result.StructuredContent = nil
+		}
+	}
+	return result
+}
+
+// truncateText returns the first part of text that fits the size limit. If
+// any text is left over, it is stored and the part ends with its token.
+// total is the size of the whole result, for reporting progress.
+func (r *Registry) truncateText(text string, total int) string {
+	if r.maxResultBytes <= 0 || len(text) <= r.maxResultBytes {
+		return text
+	}
+
+	cut := truncationPoint(text, r.maxResultBytes)
+	token := r.continuations.put(text[cut:], total)
+	shown := total - len(text) + cut
+
+	return fmt.Sprintf("%s\n\n[Result truncated: %d of %d bytes shown. Call %s with continuation_token %q for the rest.]\n",
+		text[:cut], shown, total, r.publicName(continuationTool), token)
+}
+
+// truncationPoint returns where to cut text so the first part is at most max
+// bytes. It prefers the end of a line in the second half of the limit, and
+// never splits a UTF-8 character.
+func truncationPoint(text string, max int) int {
+	if i := strings.LastIndexByte(text[:max], '\n'); i >= max/2 {
+		return i + 1
+	}
+
+	cut := max
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		return max
+	}
+	return cut
+}
@@ -23,6 +23,7 @@ func (r *Registry) registerUserTools() {
 					Type:        "integer",
 					Description: "Maximum number of users to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListUsers)
@@ -94,6 +95,7 @@ func (r *Registry) registerUserTools() {
 					Type:        "integer",
 					Description: "Maximum number of invitations to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListUserInvitations)
@@ -163,9 +165,10 @@ func (r *Registry) registerUserTools() {
 	}, r.handleDeleteUserInvitation)
 }
 
-func (r *Registry) handleListUsers(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListUsers(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -176,15 +179,15 @@ func (r *Registry) handleListUsers(args json.RawMessage) (*mcp.ToolsCallResult,
 		limit = 50
 	}
 
-	resp, err := r.client.ListUsers(context.Background(), limit)
+	resp, err := r.client.ListUsers(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list users: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list users: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatUsers(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatUsers(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetUser(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetUser(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		UserID string `json:"user_id"`
 	}
@@ -196,15 +199,15 @@ func (r *Registry) handleGetUser(args json.RawMessage) (*mcp.ToolsCallResult, er
 		return nil, fmt.Errorf("user_id is required")
 	}
 
-	resp, err := r.client.GetUser(context.Background(), params.UserID)
+	resp, err := r.client.GetUser(ctx, params.UserID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get user: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get user: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatUser(resp.Data)), nil
 }
 
-func (r *Registry) handleUpdateUser(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateUser(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		UserID         string   `json:"user_id"`
 		Roles          []string `json:"roles"`
@@ -229,15 +232,15 @@ func (r *Registry) handleUpdateUser(args json.RawMessage) (*mcp.ToolsCallResult,
 		},
 	}
 
-	resp, err := r.client.UpdateUser(context.Background(), params.UserID, req)
+	resp, err := r.client.UpdateUser(ctx, params.UserID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update user: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update user: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("User updated successfully:\n%s", formatUser(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteUser(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteUser(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		UserID string `json:"user_id"`
 	}
@@ -249,17 +252,18 @@ func (r *Registry) handleDeleteUser(args json.RawMessage) (*mcp.ToolsCallResult,
 		return nil, fmt.Errorf("user_id is required")
 	}
 
-	err := r.client.DeleteUser(context.Background(), params.UserID)
+	err := r.client.DeleteUser(ctx, params.UserID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete user: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete user: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("User removed successfully"), nil
 }
 
-func (r *Registry) handleListUserInvitations(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListUserInvitations(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		Limit int `json:"limit"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -270,15 +274,15 @@ func (r *Registry) handleListUserInvitations(args json.RawMessage) (*mcp.ToolsCa
 		limit = 50
 	}
 
-	resp, err := r.client.ListUserInvitations(context.Background(), limit)
+	resp, err := r.client.ListUserInvitations(api.WithCursor(ctx, params.Cursor), api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list user invitations: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list user invitations: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatUserInvitations(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatUserInvitations(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetUserInvitation(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetUserInvitation(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		InvitationID string `json:"invitation_id"`
 	}
@@ -290,15 +294,15 @@ func (r *Registry) handleGetUserInvitation(args json.RawMessage) (*mcp.ToolsCall
 		return nil, fmt.Errorf("invitation_id is required")
 	}
 
-	resp, err := r.client.GetUserInvitation(context.Background(), params.InvitationID)
+	resp, err := r.client.GetUserInvitation(ctx, params.InvitationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get user invitation: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get user invitation: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatUserInvitation(resp.Data)), nil
 }
 
-func (r *Registry) handleCreateUserInvitation(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateUserInvitation(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		Email          string   `json:"email"`
 		FirstName      string   `json:"first_name"`
@@ -336,15 +340,15 @@ func (r *Registry) handleCreateUserInvitation(args json.RawMessage) (*mcp.ToolsC
 		},
 	}
 
-	resp, err := r.client.CreateUserInvitation(context.Background(), req)
+	resp, err := r.client.CreateUserInvitation(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create user invitation: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create user invitation: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("User invitation sent:\n%s", formatUserInvitation(resp.Data))), nil
 }
 
-func (r *Registry) handleDeleteUserInvitation(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteUserInvitation(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		InvitationID string `json:"invitation_id"`
 	}
@@ -356,9 +360,9 @@ func (r *Registry) handleDeleteUserInvitation(args json.RawMessage) (*mcp.ToolsC
 		return nil, fmt.Errorf("invitation_id is required")
 	}
 
-	err := r.client.DeleteUserInvitation(context.Background(), params.InvitationID)
+	err := r.client.DeleteUserInvitation(ctx, params.InvitationID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete user invitation: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete user invitation: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("User invitation deleted"), nil
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
@@ -15,7 +16,7 @@ func (r *Registry) registerVersionSubmissionTools() {
 	// List app store versions
 	r.register(mcp.Tool{
 		Name:        "list_app_store_versions",
-		Description: "List App Store versions for an app",
+		Description: "List App Store versions for an app, with the app's App Store URL",
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
@@ -27,15 +28,17 @@ func (r *Registry) registerVersionSubmissionTools() {
 					Type:        "integer",
 					Description: "Maximum number of versions to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"app_id"},
 		},
+		OutputSchema: mcp.SchemaFor(appStoreVersionsOutput{}),
 	}, r.handleListAppStoreVersions)
 
 	// Get app store version
 	r.register(mcp.Tool{
 		Name:        "get_app_store_version",
-		Description: "Get details of a specific App Store version",
+		Description: "Get details of a specific App Store version, with its app's App Store URL",
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
@@ -46,6 +49,7 @@ func (r *Registry) registerVersionSubmissionTools() {
 			},
 			Required: []string{"version_id"},
 		},
+		OutputSchema: mcp.SchemaFor(linkedAppStoreVersion{}),
 	}, r.handleGetAppStoreVersion)
 
 	// Create app store version
@@ -66,15 +70,14 @@ func (r *Registry) registerVersionSubmissionTools() {
 				"platform": {
 					Type:        "string",
 					Description: "The platform (IOS, MAC_OS, TV_OS, VISION_OS)",
+					Enum:        api.EnumStrings(api.Platforms),
 				},
 				"copyright": {
 					Type:        "string",
 					Description: "The copyright text",
 				},
-				"release_type": {
-					Type:        "string",
-					Description: "Release type (MANUAL, AFTER_APPROVAL, SCHEDULED)",
-				},
+				"release_type":          releaseTypeProperty,
+				"earliest_release_date": earliestReleaseDateProperty,
 			},
 			Required: []string{"app_id", "version_string", "platform"},
 		},
@@ -99,10 +102,8 @@ func (r *Registry) registerVersionSubmissionTools() {
 					Type:        "string",
 					Description: "The updated copyright text",
 				},
-				"release_type": {
-					Type:        "string",
-					Description: "Release type (MANUAL, AFTER_APPROVAL, SCHEDULED)",
-				},
+				"release_type":          releaseTypeProperty,
+				"earliest_release_date": earliestReleaseDateProperty,
 			},
 			Required: []string{"version_id"},
 		},
@@ -143,7 +144,7 @@ func (r *Registry) registerVersionSubmissionTools() {
 	// Get review detail
 	r.register(mcp.Tool{
 		Name:        "get_app_store_review_detail",
-		Description: "Get App Store review details for a version",
+		Description: "Get App Store review details for a version, including its review attachments",
 		InputSchema: mcp.JSONSchema{
 			Type: "object",
 			Properties: map[string]mcp.Property{
@@ -253,10 +254,11 @@ func (r *Registry) registerVersionSubmissionTools() {
 	}, r.handleUpdateAppStoreReviewDetail)
 }
 
-func (r *Registry) handleListAppStoreVersions(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListAppStoreVersions(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -271,15 +273,18 @@ func (r *Registry) handleListAppStoreVersions(args json.RawMessage) (*mcp.ToolsC
 		limit = 50
 	}
 
-	resp, err := r.client.GetAppVersions(context.Background(), params.AppID, limit)
+	resp, err := r.client.GetAppVersions(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list app store versions: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list app store versions: %v", err), err), nil
 	}
+	r.recordVersions(params.AppID, resp.Data)
 
-	return mcp.NewSuccessResult(formatAppStoreVersions(resp.Data)), nil
+	output := appStoreVersionsOutput{Versions: resp.Data, AppStoreURL: appStoreURL(params.AppID), NextCursor: resp.Links.NextCursor()}
+	text := fmt.Sprintf("App Store: %s\n\n%s", output.AppStoreURL, formatAppStoreVersions(resp.Data))
+	return mcp.NewStructuredResult(withNextCursor(text, resp.Links), output), nil
 }
 
-func (r *Registry) handleGetAppStoreVersion(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppStoreVersion(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -291,21 +296,29 @@ func (r *Registry) handleGetAppStoreVersion(args json.RawMessage) (*mcp.ToolsCal
 		return nil, fmt.Errorf("version_id is required")
 	}
 
-	resp, err := r.client.GetAppStoreVersion(context.Background(), params.VersionID)
+	resp, err := r.client.GetAppStoreVersion(ctx, params.VersionID, "app")
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get app store version: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get app store version: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatAppStoreVersion(resp.Data)), nil
+	output := linkedAppStoreVersion{Type: resp.Data.Type, ID: resp.Data.ID, Attributes: resp.Data.Attributes}
+	text := formatAppStoreVersion(resp.Data)
+	if apps, err := api.IncludedOf[api.App](resp.Included); err == nil && len(apps) > 0 {
+		output.AppID = apps[0].ID
+		output.AppStoreURL = appStoreURL(apps[0].ID)
+		text += fmt.Sprintf("App Store: %s\n", output.AppStoreURL)
+	}
+	return mcp.NewStructuredResult(text, output), nil
 }
 
-func (r *Registry) handleCreateAppStoreVersion(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppStoreVersion(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID         string `json:"app_id"`
-		VersionString string `json:"version_string"`
-		Platform      string `json:"platform"`
-		Copyright     string `json:"copyright"`
-		ReleaseType   string `json:"release_type"`
+		AppID               string `json:"app_id"`
+		VersionString       string `json:"version_string"`
+		Platform            string `json:"platform"`
+		Copyright           string `json:"copyright"`
+		ReleaseType         string `json:"release_type"`
+		EarliestReleaseDate string `json:"earliest_release_date"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -320,15 +333,24 @@ func (r *Registry) handleCreateAppStoreVersion(args json.RawMessage) (*mcp.Tools
 	if params.Platform == "" {
 		return nil, fmt.Errorf("platform is required")
 	}
+	platform, err := api.ParseEnum(params.Platform, api.Platforms)
+	if err != nil {
+		return nil, fmt.Errorf("invalid platform: %w", err)
+	}
+	releaseType, earliest, err := parseReleaseStrategy(params.ReleaseType, params.EarliestReleaseDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
 
 	req := &api.AppStoreVersionCreateRequest{
 		Data: api.AppStoreVersionCreateData{
 			Type: "appStoreVersions",
 			Attributes: api.AppStoreVersionCreateAttributes{
-				Platform:      params.Platform,
-				VersionString: params.VersionString,
-				Copyright:     params.Copyright,
-				ReleaseType:   params.ReleaseType,
+				Platform:            platform,
+				VersionString:       params.VersionString,
+				Copyright:           params.Copyright,
+				ReleaseType:         releaseType,
+				EarliestReleaseDate: earliest,
 			},
 			Relationships: api.AppStoreVersionCreateRelationships{
 				App: api.RelationshipData{
@@ -341,20 +363,21 @@ func (r *Registry) handleCreateAppStoreVersion(args json.RawMessage) (*mcp.Tools
 		},
 	}
 
-	resp, err := r.client.CreateAppStoreVersion(context.Background(), req)
+	resp, err := r.client.CreateAppStoreVersion(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create app store version: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create app store version: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created app store version: %s (ID: %s)", resp.Data.Attributes.VersionString, resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateAppStoreVersion(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateAppStoreVersion(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		VersionID     string `json:"version_id"`
-		VersionString string `json:"version_string"`
-		Copyright     string `json:"copyright"`
-		ReleaseType   string `json:"release_type"`
+		VersionID           string `json:"version_id"`
+		VersionString       string `json:"version_string"`
+		Copyright           string `json:"copyright"`
+		ReleaseType         string `json:"release_type"`
+		EarliestReleaseDate string `json:"earliest_release_date"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -363,28 +386,33 @@ func (r *Registry) handleUpdateAppStoreVersion(args json.RawMessage) (*mcp.Tools
 	if params.VersionID == "" {
 		return nil, fmt.Errorf("version_id is required")
 	}
+	releaseType, earliest, err := parseReleaseStrategy(params.ReleaseType, params.EarliestReleaseDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
 
 	req := &api.AppStoreVersionUpdateRequest{
 		Data: api.AppStoreVersionUpdateData{
 			Type: "appStoreVersions",
 			ID:   params.VersionID,
 			Attributes: api.AppStoreVersionUpdateAttributes{
-				VersionString: params.VersionString,
-				Copyright:     params.Copyright,
-				ReleaseType:   params.ReleaseType,
+				VersionString:       params.VersionString,
+				Copyright:           params.Copyright,
+				ReleaseType:         releaseType,
+				EarliestReleaseDate: earliest,
 			},
 		},
 	}
 
-	resp, err := r.client.UpdateAppStoreVersion(context.Background(), params.VersionID, req)
+	resp, err := r.client.UpdateAppStoreVersion(ctx, params.VersionID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update app store version: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update app store version: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated app store version: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleDeleteAppStoreVersion(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleDeleteAppStoreVersion(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -396,15 +424,15 @@ func (r *Registry) handleDeleteAppStoreVersion(args json.RawMessage) (*mcp.Tools
 		return nil, fmt.Errorf("version_id is required")
 	}
 
-	err := r.client.DeleteAppStoreVersion(context.Background(), params.VersionID)
+	err := r.client.DeleteAppStoreVersion(ctx, params.VersionID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to delete app store version: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to delete app store version: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("App store version deleted successfully"), nil
 }
 
-func (r *Registry) handleSubmitAppForReview(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleSubmitAppForReview(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -430,15 +458,15 @@ func (r *Registry) handleSubmitAppForReview(args json.RawMessage) (*mcp.ToolsCal
 		},
 	}
 
-	resp, err := r.client.CreateAppStoreVersionSubmission(context.Background(), req)
+	resp, err := r.client.CreateAppStoreVersionSubmission(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to submit app for review: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to submit app for review: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("App submitted for review (submission ID: %s)", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleGetAppStoreReviewDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetAppStoreReviewDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID string `json:"version_id"`
 	}
@@ -450,15 +478,23 @@ func (r *Registry) handleGetAppStoreReviewDetail(args json.RawMessage) (*mcp.Too
 		return nil, fmt.Errorf("version_id is required")
 	}
 
-	resp, err := r.client.GetAppStoreReviewDetail(context.Background(), params.VersionID)
+	resp, err := r.client.GetAppStoreReviewDetailWithAttachments(ctx, params.VersionID, 50)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get review detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get review detail: %v", err), err), nil
+	}
+	attachments, err := resp.Attachments()
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to read review attachments: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatReviewDetail(resp.Data)), nil
+	text := formatReviewDetail(resp.Data)
+	if len(attachments) > 0 {
+		text += "\n" + formatAppStoreReviewAttachments(attachments)
+	}
+	return mcp.NewSuccessResult(text), nil
 }
 
-func (r *Registry) handleCreateAppStoreReviewDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCreateAppStoreReviewDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		VersionID           string `json:"version_id"`
 		ContactFirstName    string `json:"contact_first_name"`
@@ -502,15 +538,15 @@ func (r *Registry) handleCreateAppStoreReviewDetail(args json.RawMessage) (*mcp.
 		},
 	}
 
-	resp, err := r.client.CreateAppStoreReviewDetail(context.Background(), req)
+	resp, err := r.client.CreateAppStoreReviewDetail(ctx, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to create review detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to create review detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Created review detail: %s", resp.Data.ID)), nil
 }
 
-func (r *Registry) handleUpdateAppStoreReviewDetail(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleUpdateAppStoreReviewDetail(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		DetailID            string `json:"detail_id"`
 		ContactFirstName    string `json:"contact_first_name"`
@@ -547,9 +583,9 @@ func (r *Registry) handleUpdateAppStoreReviewDetail(args json.RawMessage) (*mcp.
 		},
 	}
 
-	resp, err := r.client.UpdateAppStoreReviewDetail(context.Background(), params.DetailID, req)
+	resp, err := r.client.UpdateAppStoreReviewDetail(ctx, params.DetailID, req)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to update review detail: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to update review detail: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Updated review detail: %s", resp.Data.ID)), nil
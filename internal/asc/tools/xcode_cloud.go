@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/antisynthesis/asc-mcp/internal/asc/api"
 	"github.com/antisynthesis/asc-mcp/internal/asc/mcp"
@@ -27,6 +29,7 @@ func (r *Registry) registerXcodeCloudTools() {
 					Type:        "integer",
 					Description: "Maximum number of products to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 		},
 	}, r.handleListCiProducts)
@@ -62,6 +65,7 @@ func (r *Registry) registerXcodeCloudTools() {
 					Type:        "integer",
 					Description: "Maximum number of workflows to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"product_id"},
 		},
@@ -98,6 +102,7 @@ func (r *Registry) registerXcodeCloudTools() {
 					Type:        "integer",
 					Description: "Maximum number of build runs to return (default 50)",
 				},
+				"cursor": cursorProperty,
 			},
 			Required: []string{"workflow_id"},
 		},
@@ -150,12 +155,53 @@ func (r *Registry) registerXcodeCloudTools() {
 			Required: []string{"build_run_id"},
 		},
 	}, r.handleCancelCiBuildRun)
+
+	// Retry CI build run
+	r.register(mcp.Tool{
+		Name:        "retry_build_run",
+		Description: "Retry a failed Xcode Cloud build run, starting a new run of the same workflow and source ref",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"build_run_id": {
+					Type:        "string",
+					Description: "The failed CI build run ID to retry",
+				},
+				"clean": {
+					Type:        "boolean",
+					Description: "Perform a clean build without cached derived data (default false)",
+				},
+			},
+			Required: []string{"build_run_id"},
+		},
+	}, r.handleRetryBuildRun)
+
+	// CI workflow duration statistics
+	r.register(mcp.Tool{
+		Name:        "get_ci_workflow_duration_stats",
+		Description: "Compute average and percentile queue and run durations for recent Xcode Cloud build runs of a workflow, with completion status counts",
+		InputSchema: mcp.JSONSchema{
+			Type: "object",
+			Properties: map[string]mcp.Property{
+				"workflow_id": {
+					Type:        "string",
+					Description: "The CI workflow ID",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of recent build runs to analyze (default 50, max 200)",
+				},
+			},
+			Required: []string{"workflow_id"},
+		},
+	}, r.handleGetCiWorkflowDurationStats)
 }
 
-func (r *Registry) handleListCiProducts(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListCiProducts(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
-		AppID string `json:"app_id"`
-		Limit int    `json:"limit"`
+		AppID  string `json:"app_id"`
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -166,15 +212,15 @@ func (r *Registry) handleListCiProducts(args json.RawMessage) (*mcp.ToolsCallRes
 		limit = 50
 	}
 
-	resp, err := r.client.ListCiProducts(context.Background(), params.AppID, limit)
+	resp, err := r.client.ListCiProducts(api.WithCursor(ctx, params.Cursor), params.AppID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list CI products: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI products: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatCiProducts(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatCiProducts(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetCiProduct(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetCiProduct(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ProductID string `json:"product_id"`
 	}
@@ -186,18 +232,19 @@ func (r *Registry) handleGetCiProduct(args json.RawMessage) (*mcp.ToolsCallResul
 		return nil, fmt.Errorf("product_id is required")
 	}
 
-	resp, err := r.client.GetCiProduct(context.Background(), params.ProductID)
+	resp, err := r.client.GetCiProduct(ctx, params.ProductID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get CI product: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get CI product: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatCiProduct(resp.Data)), nil
 }
 
-func (r *Registry) handleListCiWorkflows(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListCiWorkflows(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		ProductID string `json:"product_id"`
 		Limit     int    `json:"limit"`
+		Cursor    string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -212,15 +259,15 @@ func (r *Registry) handleListCiWorkflows(args json.RawMessage) (*mcp.ToolsCallRe
 		limit = 50
 	}
 
-	resp, err := r.client.ListCiWorkflows(context.Background(), params.ProductID, limit)
+	resp, err := r.client.ListCiWorkflows(api.WithCursor(ctx, params.Cursor), params.ProductID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list CI workflows: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI workflows: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatCiWorkflows(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatCiWorkflows(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetCiWorkflow(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetCiWorkflow(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		WorkflowID string `json:"workflow_id"`
 	}
@@ -232,18 +279,19 @@ func (r *Registry) handleGetCiWorkflow(args json.RawMessage) (*mcp.ToolsCallResu
 		return nil, fmt.Errorf("workflow_id is required")
 	}
 
-	resp, err := r.client.GetCiWorkflow(context.Background(), params.WorkflowID)
+	resp, err := r.client.GetCiWorkflow(ctx, params.WorkflowID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get CI workflow: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get CI workflow: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatCiWorkflow(resp.Data)), nil
 }
 
-func (r *Registry) handleListCiBuildRuns(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleListCiBuildRuns(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		WorkflowID string `json:"workflow_id"`
 		Limit      int    `json:"limit"`
+		Cursor     string `json:"cursor"`
 	}
 	if err := json.Unmarshal(args, &params); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
@@ -258,15 +306,15 @@ func (r *Registry) handleListCiBuildRuns(args json.RawMessage) (*mcp.ToolsCallRe
 		limit = 50
 	}
 
-	resp, err := r.client.ListCiBuildRuns(context.Background(), params.WorkflowID, limit)
+	resp, err := r.client.ListCiBuildRuns(api.WithCursor(ctx, params.Cursor), params.WorkflowID, api.ListOptions{Limit: limit})
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to list CI build runs: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI build runs: %v", err), err), nil
 	}
 
-	return mcp.NewSuccessResult(formatCiBuildRuns(resp.Data)), nil
+	return mcp.NewSuccessResult(withNextCursor(formatCiBuildRuns(resp.Data), resp.Links)), nil
 }
 
-func (r *Registry) handleGetCiBuildRun(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleGetCiBuildRun(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildRunID string `json:"build_run_id"`
 	}
@@ -278,15 +326,15 @@ func (r *Registry) handleGetCiBuildRun(args json.RawMessage) (*mcp.ToolsCallResu
 		return nil, fmt.Errorf("build_run_id is required")
 	}
 
-	resp, err := r.client.GetCiBuildRun(context.Background(), params.BuildRunID)
+	resp, err := r.client.GetCiBuildRun(ctx, params.BuildRunID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to get CI build run: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get CI build run: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(formatCiBuildRun(resp.Data)), nil
 }
 
-func (r *Registry) handleStartCiBuildRun(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleStartCiBuildRun(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		WorkflowID string `json:"workflow_id"`
 	}
@@ -298,15 +346,15 @@ func (r *Registry) handleStartCiBuildRun(args json.RawMessage) (*mcp.ToolsCallRe
 		return nil, fmt.Errorf("workflow_id is required")
 	}
 
-	resp, err := r.client.StartCiBuildRun(context.Background(), params.WorkflowID)
+	resp, err := r.client.StartCiBuildRun(ctx, params.WorkflowID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to start CI build run: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to start CI build run: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult(fmt.Sprintf("Started build run: %s (build #%d)", resp.Data.ID, resp.Data.Attributes.Number)), nil
 }
 
-func (r *Registry) handleCancelCiBuildRun(args json.RawMessage) (*mcp.ToolsCallResult, error) {
+func (r *Registry) handleCancelCiBuildRun(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
 	var params struct {
 		BuildRunID string `json:"build_run_id"`
 	}
@@ -318,14 +366,176 @@ func (r *Registry) handleCancelCiBuildRun(args json.RawMessage) (*mcp.ToolsCallR
 		return nil, fmt.Errorf("build_run_id is required")
 	}
 
-	err := r.client.CancelCiBuildRun(context.Background(), params.BuildRunID)
+	err := r.client.CancelCiBuildRun(ctx, params.BuildRunID)
 	if err != nil {
-		return mcp.NewErrorResult(fmt.Sprintf("Failed to cancel CI build run: %v", err)), nil
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to cancel CI build run: %v", err), err), nil
 	}
 
 	return mcp.NewSuccessResult("Build run cancelled successfully"), nil
 }
 
+func (r *Registry) handleRetryBuildRun(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		BuildRunID string `json:"build_run_id"`
+		Clean      bool   `json:"clean"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.BuildRunID == "" {
+		return nil, fmt.Errorf("build_run_id is required")
+	}
+
+	existing, err := r.client.GetCiBuildRun(ctx, params.BuildRunID)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to get CI build run: %v", err), err), nil
+	}
+
+	run := existing.Data
+	if run.Attributes.ExecutionProgress != "COMPLETE" {
+		return mcp.NewErrorResult(fmt.Sprintf("Build run %s is still %s; only completed runs can be retried", run.ID, run.Attributes.ExecutionProgress)), nil
+	}
+	if run.Attributes.CompletionStatus == "SUCCEEDED" {
+		return mcp.NewErrorResult(fmt.Sprintf("Build run %s succeeded; use start_ci_build_run to start a new run", run.ID)), nil
+	}
+
+	rels := run.Relationships
+	if rels == nil || rels.Workflow == nil || rels.Workflow.Data.ID == "" {
+		return mcp.NewErrorResult(fmt.Sprintf("Build run %s has no associated workflow", run.ID)), nil
+	}
+
+	req := &api.CiBuildRunCreateRequest{
+		Data: api.CiBuildRunCreateData{
+			Type: "ciBuildRuns",
+			Relationships: api.CiBuildRunCreateRelationships{
+				Workflow: rels.Workflow,
+				BuildRun: &api.RelationshipData{
+					Data: api.ResourceIdentifier{Type: "ciBuildRuns", ID: run.ID},
+				},
+			},
+		},
+	}
+	if rels.PullRequest != nil && rels.PullRequest.Data.ID != "" {
+		req.Data.Relationships.PullRequest = rels.PullRequest
+	} else if rels.SourceBranchOrTag != nil && rels.SourceBranchOrTag.Data.ID != "" {
+		req.Data.Relationships.SourceBranchOrTag = rels.SourceBranchOrTag
+	}
+	if params.Clean {
+		req.Data.Attributes = &api.CiBuildRunCreateAttributes{Clean: true}
+	}
+
+	resp, err := r.client.CreateCiBuildRun(ctx, req)
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to retry CI build run: %v", err), err), nil
+	}
+
+	return mcp.NewSuccessResult(fmt.Sprintf("Retried build run #%d (%s) as build run: %s (build #%d)",
+		run.Attributes.Number, run.Attributes.CompletionStatus, resp.Data.ID, resp.Data.Attributes.Number)), nil
+}
+
+func (r *Registry) handleGetCiWorkflowDurationStats(ctx context.Context, args json.RawMessage) (*mcp.ToolsCallResult, error) {
+	var params struct {
+		WorkflowID string `json:"workflow_id"`
+		Limit      int    `json:"limit"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if params.WorkflowID == "" {
+		return nil, fmt.Errorf("workflow_id is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	resp, err := r.client.ListCiBuildRuns(ctx, params.WorkflowID, api.ListOptions{Limit: limit})
+	if err != nil {
+		return mcp.NewErrorResultWithError(fmt.Sprintf("Failed to list CI build runs: %v", err), err), nil
+	}
+
+	if len(resp.Data) == 0 {
+		return mcp.NewSuccessResult("No CI build runs found"), nil
+	}
+
+	var queued, run []time.Duration
+	statuses := make(map[string]int)
+	for _, br := range resp.Data {
+		a := br.Attributes
+		if a.CompletionStatus != "" {
+			statuses[a.CompletionStatus]++
+		} else {
+			statuses[a.ExecutionProgress]++
+		}
+		if a.CreatedDate != nil && a.StartedDate != nil {
+			queued = append(queued, a.StartedDate.Sub(*a.CreatedDate))
+		}
+		if a.StartedDate != nil && a.FinishedDate != nil {
+			run = append(run, a.FinishedDate.Sub(*a.StartedDate))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Duration statistics for %d build runs of workflow %s:\n\n", len(resp.Data), params.WorkflowID))
+	sb.WriteString(formatDurationStats("Run Time (started → finished)", run))
+	sb.WriteString(formatDurationStats("Queue Time (created → started)", queued))
+
+	sb.WriteString("Outcomes:\n")
+	keys := make([]string, 0, len(statuses))
+	for k := range statuses {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("  - %s: %d (%.0f%%)\n", k, statuses[k], 100*float64(statuses[k])/float64(len(resp.Data))))
+	}
+
+	return mcp.NewSuccessResult(sb.String()), nil
+}
+
+// formatDurationStats summarizes a set of durations as count, mean, percentiles, and range.
+func formatDurationStats(title string, durations []time.Duration) string {
+	if len(durations) == 0 {
+		return fmt.Sprintf("%s: no data\n\n", title)
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s (%d runs):\n", title, len(sorted)))
+	sb.WriteString(fmt.Sprintf("  - Average: %s\n", (total / time.Duration(len(sorted))).Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("  - p50: %s\n", percentileDuration(sorted, 50).Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("  - p90: %s\n", percentileDuration(sorted, 90).Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("  - p95: %s\n", percentileDuration(sorted, 95).Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("  - Min: %s\n", sorted[0].Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("  - Max: %s\n\n", sorted[len(sorted)-1].Round(time.Second)))
+	return sb.String()
+}
+
+// percentileDuration returns the nearest-rank percentile of an ascending slice.
+func percentileDuration(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
 func formatCiProducts(products []api.CiProduct) string {
 	if len(products) == 0 {
 		return "No CI products found"
@@ -412,6 +622,9 @@ func formatCiBuildRun(run api.CiBuildRun) string {
 		sb.WriteString(fmt.Sprintf("Status: %s\n", run.Attributes.CompletionStatus))
 	}
 	sb.WriteString(fmt.Sprintf("Start Reason: %s\n", run.Attributes.StartReason))
+	if run.Relationships != nil && run.Relationships.Workflow != nil && run.Relationships.Workflow.Data.ID != "" {
+		sb.WriteString(fmt.Sprintf("Workflow: %s\n", run.Relationships.Workflow.Data.ID))
+	}
 	sb.WriteString(fmt.Sprintf("Pull Request Build: %t\n", run.Attributes.IsPullRequestBuild))
 	if run.Attributes.SourceCommit != nil {
 		sb.WriteString(fmt.Sprintf("Commit: %s\n", run.Attributes.SourceCommit.CommitSha))
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// Middleware returns an API client middleware that records a client span for
+// each request attempt, as a child of the span in the request's context. The
+// span names the endpoint with IDs replaced, as in "GET /v1/apps/{id}", and
+// its attributes leave out query values, headers and bodies.
+func (t *Tracer) Middleware() api.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if t == nil {
+			return next
+		}
+		return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := api.EndpointTemplate(req.URL.Path)
+			_, span := t.Start(req.Context(), req.Method+" "+endpoint, SpanKindClient,
+				String("http.request.method", req.Method),
+				String("url.scheme", req.URL.Scheme),
+				String("server.address", req.URL.Hostname()),
+				String("url.template", endpoint),
+			)
+			defer span.End()
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				errorType := "transport"
+				if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+					errorType = "timeout"
+				}
+				span.SetAttributes(String("error.type", errorType))
+				span.SetError("request failed: " + errorType)
+				return resp, err
+			}
+
+			span.SetAttributes(Int("http.response.status_code", resp.StatusCode))
+			if id := api.ResponseRequestID(resp.Header); id != "" {
+				span.SetAttributes(String("asc.request_id", id))
+			}
+			if resp.StatusCode >= 400 {
+				span.SetAttributes(String("error.type", strconv.Itoa(resp.StatusCode)))
+				span.SetError(resp.Status)
+			}
+			return resp, err
+		})
+	}
+}
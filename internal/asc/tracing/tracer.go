@@ -0,0 +1,297 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// exportInterval is how often queued spans are exported.
+	exportInterval = 5 * time.Second
+
+	// exportBatchSize is how many queued spans trigger an export before
+	// the interval has passed.
+	exportBatchSize = 512
+
+	// maxQueuedSpans caps the spans waiting for export. Spans ended while
+	// the queue is full, such as while the collector is down, are dropped.
+	maxQueuedSpans = 4096
+
+	// exportTimeout bounds one export request.
+	exportTimeout = 10 * time.Second
+
+	// scopeName names the instrumentation in exported spans.
+	scopeName = "github.com/antisynthesis/asc-mcp"
+)
+
+// Tracer starts spans and exports the ended ones in batches. A nil *Tracer
+// starts no spans. Its methods are safe for concurrent use.
+type Tracer struct {
+	endpoint string
+	headers  map[string]string
+	resource []Attribute
+	client   *http.Client
+
+	mu      sync.Mutex
+	queue   []*Span
+	dropped int
+
+	flush    chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a tracer that exports spans to an OTLP/HTTP traces endpoint,
+// such as http://localhost:4318/v1/traces, with headers added to each export
+// request. Spans are attributed to the named service and version. Call
+// Shutdown to export the last spans.
+func New(endpoint string, headers map[string]string, serviceName, serviceVersion string) *Tracer {
+	t := &Tracer{
+		endpoint: endpoint,
+		headers:  headers,
+		resource: []Attribute{String("service.name", serviceName), String("service.version", serviceVersion)},
+		client:   &http.Client{Timeout: exportTimeout},
+		flush:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Start starts a span as a child of the context's current span, or of the
+// remote parent set by ContextWithRemoteParent, and returns a context with
+// the new span as its current span.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	span := &Span{tracer: t, name: name, kind: kind, start: time.Now(), attributes: attrs}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.context = newSpanContext(&parent.context)
+		span.parentID = parent.context.spanID
+	} else if remote, ok := ctx.Value(remoteKey{}).(spanContext); ok {
+		span.context = newSpanContext(&remote)
+		span.parentID = remote.spanID
+	} else {
+		span.context = newSpanContext(nil)
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Shutdown stops the background exports and exports the spans still
+// queued, giving up when ctx is done.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.stopOnce.Do(func() { close(t.stop) })
+	select {
+	case <-t.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return t.export(ctx)
+}
+
+// enqueue queues an ended span for export.
+func (t *Tracer) enqueue(span *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.queue) >= maxQueuedSpans {
+		if t.dropped == 0 {
+			log.Printf("trace export queue is full; dropping spans")
+		}
+		t.dropped++
+		return
+	}
+	t.queue = append(t.queue, span)
+	if len(t.queue) >= exportBatchSize {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run exports queued spans every exportInterval, or sooner when a batch is
+// full, until Shutdown.
+func (t *Tracer) run() {
+	defer close(t.stopped)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		case <-t.flush:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		if err := t.export(ctx); err != nil {
+			log.Printf("failed to export traces: %v", err)
+		}
+		cancel()
+	}
+}
+
+// export sends the queued spans to the collector.
+func (t *Tracer) export(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.queue
+	t.queue = nil
+	if t.dropped > 0 {
+		log.Printf("dropped %d spans while the trace export queue was full", t.dropped)
+		t.dropped = 0
+	}
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %s for %d spans", resp.Status, len(spans))
+	}
+	return nil
+}
+
+// OTLP/JSON export request types, as defined by the OpenTelemetry protocol's
+// ExportTraceServiceRequest.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		TraceState        string          `json:"traceState,omitempty"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              SpanKind        `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            otlpStatus      `json:"status"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+	}
+)
+
+// otlpStatusError is the OTLP status code of a failed span.
+const otlpStatusError = 2
+
+// request builds the export request of spans.
+func (t *Tracer) request(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.context.traceID[:]),
+			SpanID:            hex.EncodeToString(s.context.spanID[:]),
+			TraceState:        s.context.traceState,
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.attributes),
+		}
+		if s.parentID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		if s.failed {
+			span.Status = otlpStatus{Code: otlpStatusError, Message: s.message}
+		}
+		s.mu.Unlock()
+		out = append(out, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes(t.resource)},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: out}},
+	}}}
+}
+
+// otlpAttributes converts attributes to OTLP's encoding. Values of other
+// types are formatted as strings.
+func otlpAttributes(attrs []Attribute) []otlpAttribute {
+	out := make([]otlpAttribute, 0, len(attrs))
+	for _, a := range attrs {
+		var v otlpValue
+		switch value := a.Value.(type) {
+		case string:
+			v.StringValue = &value
+		case int:
+			s := strconv.Itoa(value)
+			v.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(value, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &value
+		case bool:
+			v.BoolValue = &value
+		default:
+			s := fmt.Sprint(value)
+			v.StringValue = &s
+		}
+		out = append(out, otlpAttribute{Key: a.Key, Value: v})
+	}
+	return out
+}
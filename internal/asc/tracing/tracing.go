@@ -0,0 +1,199 @@
+// Package tracing records OpenTelemetry spans of tool calls and the API
+// requests they make, and exports them over OTLP/HTTP in the JSON encoding,
+// without an OpenTelemetry SDK dependency.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanKind is the role of a span in a trace, as numbered by OTLP.
+type SpanKind int
+
+// Span kinds.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a span attribute. Value is a string, int, int64, float64 or bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// spanContext identifies a span within a trace.
+type spanContext struct {
+	traceID    [16]byte
+	spanID     [8]byte
+	sampled    bool
+	traceState string
+}
+
+// Span is an operation in a trace. A nil *Span does nothing, so code can
+// trace unconditionally while tracing is off.
+type Span struct {
+	tracer   *Tracer
+	context  spanContext
+	parentID [8]byte
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	failed     bool
+	message    string
+	ended      bool
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attrs...)
+}
+
+// SetError marks the span as failed, with a short description of the
+// failure that must not contain secrets or user data.
+func (s *Span) SetError(description string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	s.message = description
+}
+
+// End ends the span and queues it for export. Calls after the first do nothing.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if s.context.sampled {
+		s.tracer.enqueue(s)
+	}
+}
+
+// TraceParent returns the span's W3C traceparent header value.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	flags := "00"
+	if s.context.sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(s.context.traceID[:]) + "-" + hex.EncodeToString(s.context.spanID[:]) + "-" + flags
+}
+
+// spanKey is the context key of the current span.
+type spanKey struct{}
+
+// remoteKey is the context key of a parent span from another process.
+type remoteKey struct{}
+
+// SpanFromContext returns the context's current span, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteParent returns a context whose next span continues the
+// trace described by W3C traceparent and tracestate values, as sent by a
+// client. Invalid or empty values leave ctx as it is, so a new trace starts.
+func ContextWithRemoteParent(ctx context.Context, traceparent, tracestate string) context.Context {
+	parent, ok := parseTraceParent(traceparent)
+	if !ok {
+		return ctx
+	}
+	parent.traceState = tracestate
+	return context.WithValue(ctx, remoteKey{}, parent)
+}
+
+// parseTraceParent parses a traceparent value such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func parseTraceParent(value string) (spanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return spanContext{}, false
+	}
+	var sc spanContext
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != 16 || isZero(traceID) {
+		return spanContext{}, false
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != 8 || isZero(spanID) {
+		return spanContext{}, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return spanContext{}, false
+	}
+	copy(sc.traceID[:], traceID)
+	copy(sc.spanID[:], spanID)
+	sc.sampled = flags[0]&1 == 1
+	return sc, true
+}
+
+// isZero reports whether b is all zeros, which W3C trace context forbids as an ID.
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// newSpanContext returns the context of a new span: a child of parent if it
+// is set, or the first span of a new, sampled trace.
+func newSpanContext(parent *spanContext) spanContext {
+	var sc spanContext
+	if parent != nil {
+		sc.traceID = parent.traceID
+		sc.sampled = parent.sampled
+		sc.traceState = parent.traceState
+	} else {
+		rand.Read(sc.traceID[:])
+		sc.sampled = true
+	}
+	rand.Read(sc.spanID[:])
+	return sc
+}
@@ -0,0 +1,217 @@
+package tracing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antisynthesis/asc-mcp/internal/asc/api"
+)
+
+// collector is an OTLP/HTTP endpoint that keeps the spans it receives.
+type collector struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	spans []otlpSpan
+}
+
+// newCollector starts a collector. Close it when done.
+func newCollector(t *testing.T) *collector {
+	t.Helper()
+
+	c := &collector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Api-Key") != "secret" {
+			t.Errorf("export request %s %s, Content-Type %q, Api-Key %q", r.Method, r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("Api-Key"))
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid export request: %v", err)
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				c.spans = append(c.spans, ss.Spans...)
+			}
+		}
+	}))
+	return c
+}
+
+// received returns the spans received by name.
+func (c *collector) received() map[string]otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := make(map[string]otlpSpan)
+	for _, span := range c.spans {
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+// attribute returns the value of a span attribute as a string.
+func attribute(span otlpSpan, key string) string {
+	for _, a := range span.Attributes {
+		if a.Key != key {
+			continue
+		}
+		switch {
+		case a.Value.StringValue != nil:
+			return *a.Value.StringValue
+		case a.Value.IntValue != nil:
+			return *a.Value.IntValue
+		}
+	}
+	return ""
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		value   string
+		ok      bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		sc, ok := parseTraceParent(tt.value)
+		if ok != tt.ok || sc.sampled != tt.sampled {
+			t.Errorf("parseTraceParent(%q) = sampled %v, %v; want sampled %v, %v", tt.value, sc.sampled, ok, tt.sampled, tt.ok)
+		}
+	}
+}
+
+func TestTracer_Export(t *testing.T) {
+	c := newCollector(t)
+	defer c.Close()
+	tracer := New(c.URL+"/v1/traces", map[string]string{"Api-Key": "secret"}, "asc-mcp", "1.0.0")
+
+	ctx := ContextWithRemoteParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "vendor=1")
+	ctx, parent := tracer.Start(ctx, "tools/call get_app", SpanKindServer, String("gen_ai.tool.name", "get_app"))
+	_, child := tracer.Start(ctx, "child", SpanKindInternal)
+	child.SetError("failed")
+	child.End()
+	parent.End()
+	parent.End()
+
+	// An unsampled trace isn't exported.
+	unsampled := ContextWithRemoteParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "")
+	_, span := tracer.Start(unsampled, "unsampled", SpanKindServer)
+	span.End()
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	spans := c.received()
+	if len(c.spans) != 2 {
+		t.Fatalf("got %d spans, want 2: %+v", len(c.spans), c.spans)
+	}
+	server, internal := spans["tools/call get_app"], spans["child"]
+	if server.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || server.ParentSpanID != "00f067aa0ba902b7" || server.TraceState != "vendor=1" {
+		t.Errorf("server span = %+v", server)
+	}
+	if server.Kind != SpanKindServer || attribute(server, "gen_ai.tool.name") != "get_app" || server.Status.Code != 0 {
+		t.Errorf("server span = %+v", server)
+	}
+	if internal.TraceID != server.TraceID || internal.ParentSpanID != server.SpanID || internal.Status.Code != otlpStatusError {
+		t.Errorf("child span = %+v", internal)
+	}
+	if child.TraceParent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-"+internal.SpanID+"-01" {
+		t.Errorf("TraceParent() = %s", child.TraceParent())
+	}
+}
+
+func TestTracer_Middleware(t *testing.T) {
+	api404 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-ID", "REQ123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"status": "404", "title": "Not Found"}]}`))
+	}))
+	defer api404.Close()
+	c := newCollector(t)
+	defer c.Close()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	tokens, err := api.NewTokenProviderFromKey("test-issuer", "TESTKEY123", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}))
+	if err != nil {
+		t.Fatalf("failed to create token provider: %v", err)
+	}
+
+	tracer := New(c.URL+"/v1/traces", map[string]string{"Api-Key": "secret"}, "asc-mcp", "1.0.0")
+	client := api.NewClientWithTokenProvider(tokens, api.WithBaseURL(api404.URL), api.WithMiddleware(tracer.Middleware()))
+
+	ctx, parent := tracer.Start(context.Background(), "tools/call get_app", SpanKindServer)
+	if _, err := client.ListBuilds(ctx, "123456789", api.ListOptions{Limit: 5}); err == nil {
+		t.Fatal("expected ListBuilds to fail")
+	}
+	parent.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	span, ok := c.received()["GET /v1/builds"]
+	if !ok {
+		t.Fatalf("no request span in %+v", c.spans)
+	}
+	if span.Kind != SpanKindClient || span.ParentSpanID != c.received()["tools/call get_app"].SpanID {
+		t.Errorf("request span = %+v", span)
+	}
+	for key, want := range map[string]string{
+		"http.request.method":       "GET",
+		"url.template":              "/v1/builds",
+		"http.response.status_code": "404",
+		"asc.request_id":            "REQ123",
+		"error.type":                "404",
+	} {
+		if got := attribute(span, key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	for _, a := range span.Attributes {
+		if a.Value.StringValue != nil && strings.Contains(*a.Value.StringValue, "123456789") {
+			t.Errorf("attribute %s has the query value: %s", a.Key, *a.Value.StringValue)
+		}
+	}
+}
+
+func TestTracer_Nil(t *testing.T) {
+	var tracer *Tracer
+	ctx, span := tracer.Start(context.Background(), "noop", SpanKindInternal)
+	span.SetAttributes(String("k", "v"))
+	span.SetError("failed")
+	span.End()
+	if SpanFromContext(ctx) != nil || span.TraceParent() != "" {
+		t.Error("a nil tracer started a span")
+	}
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}